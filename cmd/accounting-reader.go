@@ -88,7 +88,7 @@ type accountStat struct {
 
 func (c accountStat) JSON() string {
 	c.Status = "success"
-	accountMessageBytes, e := json.MarshalIndent(c, "", " ")
+	accountMessageBytes, e := json.Marshal(c)
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
 	return string(accountMessageBytes)
@@ -0,0 +1,263 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminAccessesFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "audit-log",
+		Usage: auditLogFlagUsage,
+	},
+	cli.StringFlag{
+		Name:  "since",
+		Usage: "only report the accesses since this time, e.g. '2021-06-01T00:00' or '720h' ago",
+	},
+	cli.StringFlag{
+		Name:  "until",
+		Usage: "only report the accesses until this time",
+	},
+}
+
+var adminAccessesCmd = cli.Command{
+	Name:         "accesses",
+	Usage:        "summarize who accessed the buckets",
+	Action:       mainAdminAccesses,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminAccessesFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --audit-log FILE [--audit-log FILE...] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   List, for each bucket, the principals which accessed it and how many of
+   their requests read, wrote, deleted or administered the bucket, from the
+   audit entries sent by the servers to an audit webhook and stored in files.
+   Entries of other deployments are ignored. Requests without credentials
+   are reported for the 'anonymous' principal.
+
+EXAMPLES:
+  1. Summarize the accesses to all the buckets during June for an access review.
+     {{.Prompt}} {{.HelpName}} --audit-log audit-2021-06.ndjson.gz myminio/
+
+  2. Summarize the accesses to bucket 'mybucket' during the last 7 days.
+     {{.Prompt}} {{.HelpName}} --audit-log audit.ndjson --since 168h myminio/mybucket
+`,
+}
+
+// API classes of the accesses.
+const (
+	accessClassRead   = "read"
+	accessClassWrite  = "write"
+	accessClassDelete = "delete"
+	accessClassAdmin  = "admin"
+)
+
+// accessClass returns the class of an S3 API: creating, removing and
+// configuring a bucket is administration, reading the configuration is a
+// read.
+func accessClass(api string) string {
+	switch {
+	case api == "MakeBucket", api == "DeleteBucket", api == "ForceDeleteBucket",
+		strings.HasPrefix(api, "PutBucket"), strings.HasPrefix(api, "DeleteBucket"):
+		return accessClassAdmin
+	case strings.HasPrefix(api, "Delete"), api == "AbortMultipartUpload":
+		return accessClassDelete
+	case strings.HasPrefix(api, "Put"), strings.HasPrefix(api, "Copy"), strings.HasPrefix(api, "Post"),
+		strings.Contains(api, "MultipartUpload"), strings.HasPrefix(api, "UploadPart"):
+		return accessClassWrite
+	default:
+		return accessClassRead
+	}
+}
+
+// bucketAccessMessage is the accesses of a principal to a bucket.
+type bucketAccessMessage struct {
+	Status     string    `json:"status"`
+	Bucket     string    `json:"bucket"`
+	Principal  string    `json:"principal"`
+	Read       int64     `json:"read"`
+	Write      int64     `json:"write"`
+	Delete     int64     `json:"delete"`
+	Admin      int64     `json:"admin"`
+	Denied     int64     `json:"denied"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+func (b bucketAccessMessage) String() string {
+	count := func(n int64, tag string) string {
+		if n == 0 {
+			return fmt.Sprintf("%8s", "-")
+		}
+		return console.Colorize(tag, fmt.Sprintf("%8s", humanize.Comma(n)))
+	}
+	denied := ""
+	if b.Denied > 0 {
+		denied = console.Colorize("AccessDenied", fmt.Sprintf(" (%s denied)", humanize.Comma(b.Denied)))
+	}
+	return fmt.Sprintf("%-24s %-24s%s%s%s%s  %s%s", b.Bucket, b.Principal,
+		count(b.Read, "AccessRead"), count(b.Write, "AccessWrite"),
+		count(b.Delete, "AccessDelete"), count(b.Admin, "AccessAdmin"),
+		b.LastAccess.Format(printDate), denied)
+}
+
+func (b bucketAccessMessage) JSON() string {
+	b.Status = "success"
+	msgBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// accessSummary accumulates the accesses to the buckets of a deployment.
+type accessSummary struct {
+	deploymentID string
+	bucket       string
+	accesses     map[string]map[string]*bucketAccessMessage
+}
+
+func newAccessSummary(deploymentID, bucket string) *accessSummary {
+	return &accessSummary{
+		deploymentID: deploymentID,
+		bucket:       bucket,
+		accesses:     map[string]map[string]*bucketAccessMessage{},
+	}
+}
+
+// add counts a request to a bucket, the requests of other deployments or
+// other buckets are ignored.
+func (s *accessSummary) add(r auditRecord) {
+	if r.Bucket == "" || s.bucket != "" && r.Bucket != s.bucket {
+		return
+	}
+	if r.DeploymentID != "" && s.deploymentID != "" && r.DeploymentID != s.deploymentID {
+		return
+	}
+	principal := r.AccessKey
+	if principal == "" {
+		principal = "anonymous"
+	}
+	principals, ok := s.accesses[r.Bucket]
+	if !ok {
+		principals = map[string]*bucketAccessMessage{}
+		s.accesses[r.Bucket] = principals
+	}
+	access, ok := principals[principal]
+	if !ok {
+		access = &bucketAccessMessage{Bucket: r.Bucket, Principal: principal}
+		principals[principal] = access
+	}
+	if r.StatusCode == 401 || r.StatusCode == 403 {
+		access.Denied++
+		return
+	}
+	switch accessClass(r.API) {
+	case accessClassAdmin:
+		access.Admin++
+	case accessClassDelete:
+		access.Delete++
+	case accessClassWrite:
+		access.Write++
+	default:
+		access.Read++
+	}
+	if r.Time.After(access.LastAccess) {
+		access.LastAccess = r.Time
+	}
+}
+
+// messages returns the accesses sorted by bucket and principal.
+func (s *accessSummary) messages() []bucketAccessMessage {
+	var msgs []bucketAccessMessage
+	for _, principals := range s.accesses {
+		for _, access := range principals {
+			msgs = append(msgs, *access)
+		}
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Bucket != msgs[j].Bucket {
+			return msgs[i].Bucket < msgs[j].Bucket
+		}
+		return msgs[i].Principal < msgs[j].Principal
+	})
+	return msgs
+}
+
+// mainAdminAccesses is the handle for "mc admin accesses" command.
+func mainAdminAccesses(cliCtx *cli.Context) error {
+	ctx, cancelAccesses := context.WithCancel(globalContext)
+	defer cancelAccesses()
+
+	console.SetColor("AccessRead", color.New(color.FgGreen))
+	console.SetColor("AccessWrite", color.New(color.FgYellow))
+	console.SetColor("AccessDelete", color.New(color.FgRed))
+	console.SetColor("AccessAdmin", color.New(color.FgMagenta, color.Bold))
+	console.SetColor("AccessDenied", color.New(color.FgRed, color.Bold))
+	console.SetColor("AccessHeader", color.New(color.Bold))
+
+	logs := cliCtx.StringSlice("audit-log")
+	if len(cliCtx.Args()) != 1 || len(logs) == 0 {
+		cli.ShowCommandHelpAndExit(cliCtx, "accesses", globalInvalidArgsExitStatus)
+	}
+	aliasedURL := cliCtx.Args().Get(0)
+	since := parseAdminLogsTime(cliCtx.String("since"), "since")
+	until := parseAdminLogsTime(cliCtx.String("until"), "until")
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	info, e := client.ServerInfo(ctx)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get the deployment of the server.")
+
+	_, bucket := url2Alias(filepath.Clean(aliasedURL))
+	bucket = strings.SplitN(strings.TrimPrefix(bucket, "/"), "/", 2)[0]
+	summary := newAccessSummary(info.DeploymentID, bucket)
+	skipped, err := readAuditLogs(ctx, logs, since, until, summary.add)
+	fatalIf(err, "Unable to read the audit logs.")
+	if skipped > 0 {
+		errorIf(errDummy().Trace(logs...), "Skipped %d malformed audit entries.", skipped)
+	}
+
+	msgs := summary.messages()
+	if !globalJSON && len(msgs) > 0 {
+		console.Println(console.Colorize("AccessHeader", fmt.Sprintf("%-24s %-24s%8s%8s%8s%8s  %s",
+			"Bucket", "Principal", "Read", "Write", "Delete", "Admin", "Last Access")))
+	}
+	for _, msg := range msgs {
+		printMsg(msg)
+	}
+	return nil
+}
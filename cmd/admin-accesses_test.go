@@ -0,0 +1,92 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccessClass(t *testing.T) {
+	testCases := map[string]string{
+		"GetObject":               accessClassRead,
+		"HeadObject":              accessClassRead,
+		"ListObjectsV2":           accessClassRead,
+		"GetBucketPolicy":         accessClassRead,
+		"SelectObjectContent":     accessClassRead,
+		"PutObject":               accessClassWrite,
+		"CopyObject":              accessClassWrite,
+		"PutObjectTagging":        accessClassWrite,
+		"NewMultipartUpload":      accessClassWrite,
+		"CompleteMultipartUpload": accessClassWrite,
+		"PutObjectPart":           accessClassWrite,
+		"PostPolicyBucket":        accessClassWrite,
+		"DeleteObject":            accessClassDelete,
+		"DeleteMultipleObjects":   accessClassDelete,
+		"AbortMultipartUpload":    accessClassDelete,
+		"MakeBucket":              accessClassAdmin,
+		"DeleteBucket":            accessClassAdmin,
+		"PutBucketPolicy":         accessClassAdmin,
+		"DeleteBucketLifecycle":   accessClassAdmin,
+	}
+	for api, class := range testCases {
+		if got := accessClass(api); got != class {
+			t.Errorf("%s: expected %s, got %s", api, class, got)
+		}
+	}
+}
+
+func TestAccessSummary(t *testing.T) {
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	records := []auditRecord{
+		{Time: day.Add(time.Hour), DeploymentID: "d1", AccessKey: "app", API: "GetObject", Bucket: "b1", StatusCode: 200},
+		{Time: day.Add(3 * time.Hour), DeploymentID: "d1", AccessKey: "app", API: "PutObject", Bucket: "b1", StatusCode: 200},
+		{Time: day.Add(2 * time.Hour), AccessKey: "app", API: "DeleteObject", Bucket: "b1", StatusCode: 204},
+		{Time: day.Add(4 * time.Hour), DeploymentID: "d1", AccessKey: "app", API: "PutBucketPolicy", Bucket: "b1", StatusCode: 403},
+		{Time: day.Add(time.Hour), DeploymentID: "d1", API: "GetObject", Bucket: "b1", StatusCode: 200},
+		{Time: day.Add(time.Hour), DeploymentID: "d1", AccessKey: "admin", API: "MakeBucket", Bucket: "b2", StatusCode: 200},
+		{Time: day.Add(time.Hour), DeploymentID: "d2", AccessKey: "other", API: "GetObject", Bucket: "b1", StatusCode: 200},
+		{Time: day.Add(time.Hour), DeploymentID: "d1", AccessKey: "admin", API: "ListBuckets", StatusCode: 200},
+	}
+
+	summary := newAccessSummary("d1", "")
+	for _, r := range records {
+		summary.add(r)
+	}
+	msgs := summary.messages()
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 accesses, got %v", msgs)
+	}
+	expected := []bucketAccessMessage{
+		{Bucket: "b1", Principal: "anonymous", Read: 1, LastAccess: day.Add(time.Hour)},
+		{Bucket: "b1", Principal: "app", Read: 1, Write: 1, Delete: 1, Denied: 1, LastAccess: day.Add(3 * time.Hour)},
+		{Bucket: "b2", Principal: "admin", Admin: 1, LastAccess: day.Add(time.Hour)},
+	}
+	for i := range expected {
+		if msgs[i] != expected[i] {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, expected[i], msgs[i])
+		}
+	}
+
+	summary = newAccessSummary("d1", "b2")
+	for _, r := range records {
+		summary.add(r)
+	}
+	if msgs = summary.messages(); len(msgs) != 1 || msgs[0].Bucket != "b2" {
+		t.Fatalf("expected the accesses to b2 only, got %v", msgs)
+	}
+}
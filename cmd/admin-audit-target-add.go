@@ -0,0 +1,181 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminAuditTargetAddCmd = cli.Command{
+	Name:         "add",
+	Usage:        "add a new audit log target",
+	Action:       mainAdminAuditTargetAdd,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET TYPE NAME KEY=VALUE [KEY=VALUE...]
+
+TYPE:
+  webhook, kafka
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Send audit logs of 'myminio' to a webhook endpoint.
+     {{.Prompt}} {{.HelpName}} myminio/ webhook logger endpoint=https://audit.example.com/minio auth_token=secret
+
+  2. Send audit logs of 'myminio' to a kafka topic.
+     {{.Prompt}} {{.HelpName}} myminio/ kafka events brokers=kafka1:9092,kafka2:9092 topic=minio-audit
+`,
+}
+
+// auditTargetMessage is container for audit target add/rm/test messages.
+type auditTargetMessage struct {
+	Status      string `json:"status"`
+	Op          string `json:"op"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	TargetState string `json:"targetState,omitempty"`
+	ProbeID     string `json:"probeID,omitempty"`
+	targetAlias string
+	restart     bool
+}
+
+// String colorized audit target message.
+func (u auditTargetMessage) String() (msg string) {
+	switch u.Op {
+	case "add":
+		msg = console.Colorize("AuditTargetSuccess",
+			fmt.Sprintf("Audit %s target `%s` added successfully.", u.Type, u.Name))
+	case "rm":
+		msg = console.Colorize("AuditTargetSuccess",
+			fmt.Sprintf("Audit %s target `%s` removed successfully.", u.Type, u.Name))
+	case "test":
+		if u.TargetState == "online" {
+			msg = console.Colorize("AuditTargetSuccess",
+				fmt.Sprintf("Probe event `%s` sent, audit %s target `%s` is online.", u.ProbeID, u.Type, u.Name))
+		} else {
+			msg = console.Colorize("AuditTargetFailure",
+				fmt.Sprintf("Probe event `%s` sent, audit %s target `%s` is %s.", u.ProbeID, u.Type, u.Name, u.TargetState))
+		}
+	}
+	if u.restart {
+		suggestion := color.RedString("mc admin service restart %s", u.targetAlias)
+		msg += console.Colorize("AuditTargetSuccess",
+			fmt.Sprintf("\nPlease restart your server '%s'.", suggestion))
+	}
+	return msg
+}
+
+// JSON jsonified audit target message.
+func (u auditTargetMessage) JSON() string {
+	u.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminAuditTargetAddSyntax - validate all the passed arguments
+func checkAdminAuditTargetAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) < 4 {
+		cli.ShowCommandHelpAndExit(ctx, "add", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// parseAuditTargetKVS parses 'key=value' arguments and validates
+// the keys against those supported by the server.
+func parseAuditTargetKVS(args []string, help madmin.Help) (madmin.KVS, *probe.Error) {
+	validKeys := set.CreateStringSet(help.Keys()...)
+	var kvs madmin.KVS
+	for _, arg := range args {
+		kv := strings.SplitN(arg, madmin.KvSeparator, 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, probe.NewError(fmt.Errorf("`%s` is not in 'key=value' form", arg))
+		}
+		if kv[0] == madmin.EnableKey {
+			continue
+		}
+		if !validKeys.Contains(kv[0]) {
+			return nil, probe.NewError(fmt.Errorf("unknown key `%s`, valid keys are %s",
+				kv[0], strings.Join(help.Keys(), ", ")))
+		}
+		kvs = append(kvs, madmin.KV{Key: kv[0], Value: kv[1]})
+	}
+	return kvs, nil
+}
+
+// mainAdminAuditTargetAdd is the handle for "mc admin audit target add" command.
+func mainAdminAuditTargetAdd(ctx *cli.Context) error {
+	checkAdminAuditTargetAddSyntax(ctx)
+
+	console.SetColor("AuditTargetSuccess", color.New(color.FgGreen, color.Bold))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	targetType := args.Get(1)
+	targetName := args.Get(2)
+
+	subSys, err := getAuditTargetSubSys(targetType)
+	fatalIf(err, "Unable to add audit target.")
+
+	if strings.ContainsAny(targetName, " "+madmin.SubSystemSeparator+madmin.KvSeparator) {
+		fatalIf(errInvalidArgument().Trace(targetName), "Audit target name must not contain spaces, `:` or `=`.")
+	}
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	help, e := client.HelpConfigKV(globalContext, subSys, "", false)
+	fatalIf(probe.NewError(e), "Unable to get the configuration keys of `%s`.", subSys)
+
+	kvs, err := parseAuditTargetKVS(args[3:], help)
+	fatalIf(err, "Unable to add audit target.")
+
+	fatalIf(probe.NewError(validateAuditTarget(targetType, kvs)), "Unable to add audit target.")
+
+	input := []string{subSys + madmin.SubSystemSeparator + targetName, madmin.EnableKey + madmin.KvSeparator + madmin.EnableOn}
+	for _, kv := range kvs {
+		input = append(input, fmt.Sprintf("%s%s%q", kv.Key, madmin.KvSeparator, kv.Value))
+	}
+
+	restart, e := client.SetConfigKV(globalContext, strings.Join(input, " "))
+	fatalIf(probe.NewError(e), "Unable to add audit %s target `%s`.", targetType, targetName)
+
+	printMsg(auditTargetMessage{
+		Op:          "add",
+		Type:        targetType,
+		Name:        targetName,
+		targetAlias: aliasedURL,
+		restart:     restart,
+	})
+	return nil
+}
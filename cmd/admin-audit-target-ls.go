@@ -0,0 +1,129 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminAuditTargetListCmd = cli.Command{
+	Name:         "ls",
+	Usage:        "list configured audit log targets",
+	Action:       mainAdminAuditTargetList,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List all audit log targets of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio/
+`,
+}
+
+// auditTargetListMessage is container for a listed audit target.
+type auditTargetListMessage struct {
+	Status string `json:"status"`
+	auditTarget
+}
+
+// String colorized audit target list message.
+func (u auditTargetListMessage) String() string {
+	state := console.Colorize("AuditTargetDisabled", "disabled")
+	if u.Enabled {
+		switch u.auditTarget.Status {
+		case "online":
+			state = console.Colorize("AuditTargetOnline", u.auditTarget.Status)
+		default:
+			state = console.Colorize("AuditTargetOffline", u.auditTarget.Status)
+		}
+	}
+
+	keys := make([]string, 0, len(u.Config))
+	for k := range u.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var kvs []string
+	for _, k := range keys {
+		kvs = append(kvs, k+"="+u.Config[k])
+	}
+
+	return fmt.Sprintf("%-8s %-16s %-10s %s", u.Type, console.Colorize("AuditTargetName", u.Name),
+		state, strings.Join(kvs, " "))
+}
+
+// JSON jsonified audit target list message.
+func (u auditTargetListMessage) JSON() string {
+	u.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAdminAuditTargetListSyntax - validate all the passed arguments
+func checkAdminAuditTargetListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "ls", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminAuditTargetList is the handle for "mc admin audit target ls" command.
+func mainAdminAuditTargetList(ctx *cli.Context) error {
+	checkAdminAuditTargetListSyntax(ctx)
+
+	console.SetColor("AuditTargetName", color.New(color.Bold))
+	console.SetColor("AuditTargetOnline", color.New(color.FgGreen, color.Bold))
+	console.SetColor("AuditTargetOffline", color.New(color.FgRed, color.Bold))
+	console.SetColor("AuditTargetDisabled", color.New(color.FgYellow))
+
+	aliasedURL := ctx.Args().Get(0)
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	status, e := getAuditTargetStatus(client)
+	fatalIf(probe.NewError(e), "Unable to get audit target status.")
+
+	for _, targetType := range []string{"webhook", "kafka"} {
+		targets, e := getAuditTargets(client, targetType)
+		fatalIf(probe.NewError(e), "Unable to list audit %s targets.", targetType)
+		for _, target := range targets {
+			if target.Enabled {
+				target.Status = lookupAuditTargetStatus(status, target.Name)
+			}
+			printMsg(auditTargetListMessage{auditTarget: target})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminAuditTargetRemoveCmd = cli.Command{
+	Name:         "rm",
+	Usage:        "remove an audit log target",
+	Action:       mainAdminAuditTargetRemove,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET TYPE NAME
+
+TYPE:
+  webhook, kafka
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the audit kafka target 'events' from 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio/ kafka events
+`,
+}
+
+// checkAdminAuditTargetRemoveSyntax - validate all the passed arguments
+func checkAdminAuditTargetRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 3 {
+		cli.ShowCommandHelpAndExit(ctx, "rm", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminAuditTargetRemove is the handle for "mc admin audit target rm" command.
+func mainAdminAuditTargetRemove(ctx *cli.Context) error {
+	checkAdminAuditTargetRemoveSyntax(ctx)
+
+	console.SetColor("AuditTargetSuccess", color.New(color.FgGreen, color.Bold))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	targetType := args.Get(1)
+	targetName := args.Get(2)
+
+	subSys, err := getAuditTargetSubSys(targetType)
+	fatalIf(err, "Unable to remove audit target.")
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	e := client.DelConfigKV(globalContext, subSys+madmin.SubSystemSeparator+targetName)
+	fatalIf(probe.NewError(e), "Unable to remove audit %s target `%s`.", targetType, targetName)
+
+	printMsg(auditTargetMessage{
+		Op:   "rm",
+		Type: targetType,
+		Name: targetName,
+	})
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// auditProbeQueryKey is the query parameter tagging the probe event.
+const auditProbeQueryKey = "mc-audit-probe"
+
+var adminAuditTargetTestCmd = cli.Command{
+	Name:         "test",
+	Usage:        "send a probe event to an audit log target and report its status",
+	Action:       mainAdminAuditTargetTest,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET TYPE NAME
+
+TYPE:
+  webhook, kafka
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Every request to the server is audited, the probe event is the audit
+  entry of an admin request carrying the query parameter 'mc-audit-probe'
+  with a random ID, search the target's logs for this ID to find it. The
+  status reported after the event is sent is the one of the last delivery
+  attempt of the servers, it is only online when all the servers reach
+  the target.
+
+EXAMPLES:
+  1. Send a probe event to the audit webhook target 'logger' of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio/ webhook logger
+`,
+}
+
+// checkAdminAuditTargetTestSyntax - validate all the passed arguments
+func checkAdminAuditTargetTestSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 3 {
+		cli.ShowCommandHelpAndExit(ctx, "test", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminAuditTargetTest is the handle for "mc admin audit target test" command.
+func mainAdminAuditTargetTest(ctx *cli.Context) error {
+	checkAdminAuditTargetTestSyntax(ctx)
+
+	console.SetColor("AuditTargetSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("AuditTargetFailure", color.New(color.FgRed, color.Bold))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	targetType := args.Get(1)
+	targetName := args.Get(2)
+
+	_, err := getAuditTargetSubSys(targetType)
+	fatalIf(err, "Unable to test audit target.")
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	targets, e := getAuditTargets(client, targetType)
+	fatalIf(probe.NewError(e), "Unable to list audit %s targets.", targetType)
+
+	var found bool
+	for _, target := range targets {
+		if target.Name != targetName {
+			continue
+		}
+		if !target.Enabled {
+			fatalIf(errDummy().Trace(targetName), "Audit %s target `%s` is disabled.", targetType, targetName)
+		}
+		found = true
+	}
+	if !found {
+		fatalIf(errDummy().Trace(targetName), "Audit %s target `%s` does not exist.", targetType, targetName)
+	}
+
+	apiClient, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	// The audit entry of this request is the probe event, the status
+	// is collected once the event had time to be delivered.
+	probeID := newRandomID(16)
+	_, e = apiClient.call(globalContext, http.MethodGet, "/info", url.Values{auditProbeQueryKey: {probeID}}, nil)
+	fatalIf(probe.NewError(e), "Unable to send a probe event.")
+	time.Sleep(2 * time.Second)
+
+	status, e := getAuditTargetStatus(client)
+	fatalIf(probe.NewError(e), "Unable to get audit target status.")
+
+	msg := auditTargetMessage{
+		Op:          "test",
+		ProbeID:     probeID,
+		Type:        targetType,
+		Name:        targetName,
+		TargetState: lookupAuditTargetStatus(status, targetName),
+	}
+	printMsg(msg)
+	if msg.TargetState != "online" {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
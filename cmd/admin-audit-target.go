@@ -0,0 +1,197 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var adminAuditTargetSubcommands = []cli.Command{
+	adminAuditTargetAddCmd,
+	adminAuditTargetListCmd,
+	adminAuditTargetTestCmd,
+	adminAuditTargetRemoveCmd,
+}
+
+var adminAuditTargetCmd = cli.Command{
+	Name:            "target",
+	Usage:           "manage audit log targets",
+	Action:          mainAdminAuditTarget,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands:     adminAuditTargetSubcommands,
+	HideHelpCommand: true,
+}
+
+// mainAdminAuditTarget is the handle for "mc admin audit target" command.
+func mainAdminAuditTarget(ctx *cli.Context) error {
+	commandNotFound(ctx, adminAuditTargetSubcommands)
+	return nil
+	// Sub-commands like "add", "ls" have their own main.
+}
+
+// Audit target types mapped to their config sub-system.
+var auditTargetSubSys = map[string]string{
+	"webhook": "audit_webhook",
+	"kafka":   "audit_kafka",
+}
+
+// auditTarget is a configured audit log target.
+type auditTarget struct {
+	Type    string            `json:"type"`
+	Name    string            `json:"name"`
+	Enabled bool              `json:"enabled"`
+	Status  string            `json:"status,omitempty"`
+	Config  map[string]string `json:"config"`
+}
+
+// getAuditTargetSubSys returns the config sub-system of an audit target type.
+func getAuditTargetSubSys(targetType string) (string, *probe.Error) {
+	subSys, ok := auditTargetSubSys[targetType]
+	if !ok {
+		return "", probe.NewError(fmt.Errorf("unknown audit target type `%s`, possible values are 'webhook' and 'kafka'", targetType))
+	}
+	return subSys, nil
+}
+
+// validateAuditTarget checks the mandatory settings of an audit target.
+func validateAuditTarget(targetType string, kvs madmin.KVS) error {
+	switch targetType {
+	case "webhook":
+		endpoint := kvs.Get("endpoint")
+		if endpoint == "" {
+			return fmt.Errorf("'endpoint' is required for webhook targets")
+		}
+		u, e := url.Parse(endpoint)
+		if e != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("'endpoint' must be an http(s) URL, found `%s`", endpoint)
+		}
+	case "kafka":
+		brokers := kvs.Get("brokers")
+		if brokers == "" {
+			return fmt.Errorf("'brokers' is required for kafka targets")
+		}
+		for _, broker := range strings.Split(brokers, ",") {
+			if _, _, e := net.SplitHostPort(strings.TrimSpace(broker)); e != nil {
+				return fmt.Errorf("broker `%s` must be in 'host:port' form", broker)
+			}
+		}
+		if kvs.Get("topic") == "" {
+			return fmt.Errorf("'topic' is required for kafka targets")
+		}
+	}
+	return nil
+}
+
+// getAuditTargets returns all audit targets of a type as configured on the server.
+func getAuditTargets(client *madmin.AdminClient, targetType string) ([]auditTarget, error) {
+	subSys := auditTargetSubSys[targetType]
+	help, e := client.HelpConfigKV(globalContext, subSys, "", false)
+	if e != nil {
+		return nil, e
+	}
+	buf, e := client.GetConfigKV(globalContext, subSys)
+	if e != nil {
+		return nil, e
+	}
+
+	var targets []auditTarget
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, madmin.KvComment) {
+			continue
+		}
+		target, e := madmin.ParseTarget(line, help)
+		if e != nil {
+			return nil, e
+		}
+		name := ""
+		if parts := strings.SplitN(target.SubSystem, madmin.SubSystemSeparator, 2); len(parts) == 2 {
+			name = parts[1]
+		}
+		t := auditTarget{
+			Type:    targetType,
+			Name:    name,
+			Enabled: target.KVS.Get(madmin.EnableKey) == madmin.EnableOn,
+			Config:  make(map[string]string),
+		}
+		for _, kv := range target.KVS {
+			if kv.Key == madmin.EnableKey || kv.Value == "" {
+				continue
+			}
+			if diagSecretKeyRgx.MatchString(kv.Key) {
+				kv.Value = "REDACTED"
+			}
+			t.Config[kv.Key] = kv.Value
+		}
+		targets = append(targets, t)
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, e
+	}
+	return targets, nil
+}
+
+// getAuditTargetStatus returns the online status of all audit targets
+// as reported by the servers, keyed by target name.
+func getAuditTargetStatus(client *madmin.AdminClient) (map[string]string, error) {
+	info, e := client.ServerInfo(globalContext)
+	if e != nil {
+		return nil, e
+	}
+	status := make(map[string]string)
+	for _, audit := range info.Services.Audit {
+		for id, st := range audit {
+			// A target is only online if every server can reach it.
+			if prev, ok := status[id]; ok && prev != "online" {
+				continue
+			}
+			status[id] = st.Status
+		}
+	}
+	return status, nil
+}
+
+// lookupAuditTargetStatus finds the status of a named target, targets
+// are identified either by their name or by '<name>:<endpoint>'.
+func lookupAuditTargetStatus(status map[string]string, name string) string {
+	if st, ok := status[name]; ok {
+		return st
+	}
+	ids := make([]string, 0, len(status))
+	for id := range status {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if strings.HasPrefix(id, name+madmin.SubSystemSeparator) {
+			return status[id]
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,40 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "github.com/minio/cli"
+
+var adminAuditSubcommands = []cli.Command{
+	adminAuditTargetCmd,
+}
+
+var adminAuditCmd = cli.Command{
+	Name:            "audit",
+	Usage:           "manage audit logging",
+	Action:          mainAdminAudit,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands:     adminAuditSubcommands,
+	HideHelpCommand: true,
+}
+
+// mainAdminAudit is the handle for "mc admin audit" command.
+func mainAdminAudit(ctx *cli.Context) error {
+	commandNotFound(ctx, adminAuditSubcommands)
+	return nil
+	// Sub-commands like "target" have their own main.
+}
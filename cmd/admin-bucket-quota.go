@@ -114,7 +114,7 @@ EXAMPLES:
 // checkAdminBucketQuotaSyntax - validate all the passed arguments
 func checkAdminBucketQuotaSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
-		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, globalInvalidArgsExitStatus) // last argument is exit code
 	}
 
 	if ctx.IsSet("hard") && ctx.IsSet("fifo") {
@@ -17,10 +17,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/fatih/color"
@@ -37,6 +37,10 @@ var adminBandwidthInfoCmdFlags = []cli.Flag{
 		Value: "b",
 		Usage: "[b|bi|B|Bi] Display bandwidth in bits (IEC [bi] or SI [b]) or bytes (IEC [Bi] or SI [B])",
 	},
+	cli.BoolFlag{
+		Name:  "watch, w",
+		Usage: "keep showing the bandwidth every second until interrupted",
+	},
 }
 
 var adminBwInfoCmd = cli.Command{
@@ -52,6 +56,12 @@ var adminBwInfoCmd = cli.Command{
 USAGE:
   {{.HelpName}} FLAGS TARGET
 
+  The current replication bandwidth of the buckets is shown, buckets close
+  to or above their configured limit are highlighted. With --watch, it is
+  shown until interrupted, with --json one report per line is streamed.
+  The servers only report the replication bandwidth, not the bandwidth of
+  the clients.
+
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
@@ -60,77 +70,98 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} play/
   2. Show the bandwidth usage for the bucket 'source-bucket' in a MinIO server setup
      {{.Prompt}} {{.HelpName}} play/source-bucket
+  3. Watch the bandwidth usage of all the buckets until interrupted
+     {{.Prompt}} {{.HelpName}} --watch play/
+  4. Stream the bandwidth reports of all the buckets as JSON lines, in bytes per second
+     {{.Prompt}} {{.HelpName}} --watch --json --unit B play/
 `,
 }
 
-func printTable(report madmin.Report, bits bool, iec bool) {
-	bucketMaxLength := 63
-	bucketColLength := 6
-	var bucketKeys []string
-	for bucket := range report.Report.BucketStats {
-		if len(bucket) <= bucketMaxLength && len(bucket) > bucketColLength {
-			bucketColLength = len(bucket)
-		}
-		bucketKeys = append(bucketKeys, bucket)
-	}
-	sort.Strings(bucketKeys)
-	dspOrder := []col{colGreen} // Header
-	for i := 0; i < len(report.Report.BucketStats); i++ {
-		dspOrder = append(dspOrder, colGrey)
-	}
-	var printColors []*color.Color
-	for _, c := range dspOrder {
-		printColors = append(printColors, getPrintCol(c))
-	}
+// bucketBandwidth is the bandwidth of a single bucket.
+type bucketBandwidth struct {
+	Bucket      string  `json:"bucket"`
+	Limit       int64   `json:"limit"`
+	Current     float64 `json:"current"`
+	Utilization float64 `json:"utilization,omitempty"`
+}
 
-	cellText := make([][]string, len(report.Report.BucketStats)+1) // 1 for the header
-	tbl := console.NewTable(printColors, []bool{false, false, false}, 0)
-	bucketTitle := fmt.Sprintf("%-16v", "Bucket")
-	cellText[0] = []string{
-		bucketTitle,
-		"Configured Max Bandwidth",
-		"Current Bandwidth",
-	}
-	tbl.HeaderRowSeparator = true
-	index := 1
+// bandwidthMessage is container for a single bandwidth report.
+type bandwidthMessage struct {
+	Status  string            `json:"status"`
+	Buckets []bucketBandwidth `json:"buckets"`
 
-	for _, bucket := range bucketKeys {
-		values := report.Report.BucketStats[bucket]
-		if len(bucket) > bucketMaxLength {
-			bucket = bucket[:bucketMaxLength] + ".."
-		}
-		var mul uint64
-		mul = 1
-		if bits {
-			mul = 8
-		}
-		limit := humanize.Bytes(uint64(values.LimitInBytesPerSecond) * mul)
-		current := humanize.Bytes(uint64(values.CurrentBandwidthInBytesPerSecond) * mul)
-		if iec {
-			limit = humanize.IBytes(uint64(values.LimitInBytesPerSecond)*mul) + "/sec"
-			current = humanize.IBytes(uint64(values.CurrentBandwidthInBytesPerSecond)*mul) + "/sec"
-		}
-		if bits {
-			limit = strings.ToLower(limit) + "/sec"
-			current = strings.ToLower(current) + "/sec"
-		}
-		if values.LimitInBytesPerSecond == 0 {
-			limit = "N/A" // N/A means cluster bandwidth is not configured
+	bits, iec bool
+}
+
+func newBandwidthMessage(report madmin.BucketBandwidthReport, bits, iec bool) bandwidthMessage {
+	msg := bandwidthMessage{Status: "success", bits: bits, iec: iec}
+	for bucket, details := range report.BucketStats {
+		bw := bucketBandwidth{
+			Bucket:  bucket,
+			Limit:   details.LimitInBytesPerSecond,
+			Current: details.CurrentBandwidthInBytesPerSecond,
 		}
-		cellText[index] = []string{
-			bucket,
-			limit,
-			current,
+		if bw.Limit > 0 {
+			bw.Utilization = 100 * bw.Current / float64(bw.Limit)
 		}
-		index++
+		msg.Buckets = append(msg.Buckets, bw)
 	}
-	if len(report.Report.BucketStats) > 0 {
-		err := tbl.DisplayTable(cellText)
-		if err != nil {
-			console.Error(err)
+	sort.Slice(msg.Buckets, func(i, j int) bool {
+		return msg.Buckets[i].Bucket < msg.Buckets[j].Bucket
+	})
+	return msg
+}
+
+// String colorized bandwidth report as a table.
+func (b bandwidthMessage) String() string {
+	var s strings.Builder
+	s.WriteString(console.Colorize("BandwidthHeader",
+		fmt.Sprintf("%-32s %16s %16s %8s", "Bucket", "Limit", "Current", "Usage")))
+	for _, bw := range b.Buckets {
+		limit, usage, tag := "N/A", "", "BandwidthOK"
+		if bw.Limit > 0 {
+			limit = formatBandwidth(float64(bw.Limit), b.bits, b.iec)
+			usage = fmt.Sprintf("%.1f%%", bw.Utilization)
+			switch {
+			case bw.Utilization > 100:
+				tag = "BandwidthExceeded"
+			case bw.Utilization > 90:
+				tag = "BandwidthWarning"
+			}
 		}
+		s.WriteString("\n" + console.Colorize(tag, fmt.Sprintf("%-32s %16s %16s %8s",
+			lineTrunc(bw.Bucket, 32), limit, formatBandwidth(bw.Current, b.bits, b.iec), usage)))
+	}
+	return s.String()
+}
+
+// JSON jsonified bandwidth report, on a single line for streaming.
+func (b bandwidthMessage) JSON() string {
+	jsonMessageBytes, e := json.Marshal(b)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// formatBandwidth formats a rate in bits or bytes per second, with IEC or
+// SI units.
+func formatBandwidth(bytesPerSec float64, bits, iec bool) string {
+	var mul uint64 = 1
+	if bits {
+		mul = 8
+	}
+	var s string
+	if iec {
+		s = humanize.IBytes(uint64(bytesPerSec) * mul)
+	} else {
+		s = humanize.Bytes(uint64(bytesPerSec) * mul)
 	}
+	if bits {
+		s = strings.ToLower(s)
+	}
+	return s + "/sec"
 }
+
 func checkAdminBwInfoSyntax(ctx *cli.Context) {
 	u := ctx.String("unit")
 	if u != "bi" &&
@@ -138,57 +169,66 @@ func checkAdminBwInfoSyntax(ctx *cli.Context) {
 		u != "Bi" &&
 		u != "B" &&
 		u != "" {
-		cli.ShowCommandHelpAndExit(ctx, "bandwidth", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "bandwidth", globalInvalidArgsExitStatus)
 	}
 	if len(ctx.Args()) > 1 || len(ctx.Args()) == 0 {
-		cli.ShowCommandHelpAndExit(ctx, "bandwidth", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "bandwidth", globalInvalidArgsExitStatus)
 	}
 }
 
-func mainAdminBwInfo(ctx *cli.Context) {
+func mainAdminBwInfo(ctx *cli.Context) error {
 	checkAdminBwInfoSyntax(ctx)
+
+	console.SetColor("BandwidthHeader", color.New(color.FgGreen, color.Bold))
+	console.SetColor("BandwidthOK", color.New(color.FgWhite))
+	console.SetColor("BandwidthWarning", color.New(color.FgYellow, color.Bold))
+	console.SetColor("BandwidthExceeded", color.New(color.FgRed, color.Bold))
+
 	aliasURL, bucket := getAliasAndBucket(ctx)
 	client := getClient(aliasURL)
-	reportCh := client.GetBucketBandwidth(globalContext, bucket)
-	firstPrint := true
-	bandwidthUnitsString := ctx.String("unit")
-	for {
-		select {
-		case report := <-reportCh:
-			if len(report.Report.BucketStats) == 0 {
-				continue
+
+	var buckets []string
+	if bucket != "" {
+		buckets = append(buckets, bucket)
+	}
+
+	unit := ctx.String("unit")
+	bits := unit == "b" || unit == "bi"
+	iec := unit == "bi" || unit == "Bi"
+	watch := ctx.Bool("watch")
+
+	ctxt, cancel := context.WithCancel(globalContext)
+	defer cancel()
+
+	rewindLines := 0
+	for report := range client.GetBucketBandwidth(ctxt, buckets...) {
+		if report.Err != nil {
+			if globalContext.Err() != nil {
+				break
 			}
-			if report.Err != nil {
-				if strings.Contains(report.Err.Error(), "EOF") {
-					continue
-				}
-				console.Error(report.Err)
+			if strings.Contains(report.Err.Error(), "EOF") {
+				continue
 			}
-			printBandwidth(report, firstPrint, bandwidthUnitsString == "bi" || bandwidthUnitsString == "b",
-				bandwidthUnitsString == "bi" || bandwidthUnitsString == "Bi")
-			firstPrint = false
-		case <-globalContext.Done():
-			return
+			fatalIf(probe.NewError(report.Err).Trace(aliasURL), "Unable to get bandwidth report.")
+		}
+		// Empty reports are sent until replication traffic is seen,
+		// they are only shown when not watching.
+		if watch && len(report.Report.BucketStats) == 0 {
+			continue
+		}
+		msg := newBandwidthMessage(report.Report, bits, iec)
+		if !globalJSON {
+			console.RewindLines(rewindLines)
+			rewindLines = len(msg.Buckets) + 1
+		}
+		printMsg(msg)
+		if !watch {
+			cancel()
+			break
 		}
 	}
-}
-
-func printBandwidth(report madmin.Report, firstPrint bool, bits bool, iec bool) {
-	rewindLines := len(report.Report.BucketStats) + 4
-	if firstPrint {
-		rewindLines = 0
-	}
-	if globalJSON {
-		reportJSON, e := json.MarshalIndent(report, "", "  ")
-		fatalIf(probe.NewError(e), "Unable to marshal to JSON")
-		console.Println(string(reportJSON))
-		time.Sleep(1 * time.Second)
-		return
-	}
-	if len(report.Report.BucketStats) > 0 {
-		console.RewindLines(rewindLines)
-		// For the next iteration, rewind lines
-		printTable(report, bits, iec)
+	if globalContext.Err() != nil {
+		return exitStatus(globalCancelExitStatus)
 	}
-	time.Sleep(1 * time.Second)
+	return nil
 }
@@ -0,0 +1,43 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestNewBandwidthMessage(t *testing.T) {
+	report := madmin.BucketBandwidthReport{
+		BucketStats: map[string]madmin.BandwidthDetails{
+			"videos": {LimitInBytesPerSecond: 1000, CurrentBandwidthInBytesPerSecond: 1500},
+			"logs":   {LimitInBytesPerSecond: 0, CurrentBandwidthInBytesPerSecond: 200},
+			"images": {LimitInBytesPerSecond: 400, CurrentBandwidthInBytesPerSecond: 100},
+		},
+	}
+	expected := []bucketBandwidth{
+		{Bucket: "images", Limit: 400, Current: 100, Utilization: 25},
+		{Bucket: "logs", Limit: 0, Current: 200},
+		{Bucket: "videos", Limit: 1000, Current: 1500, Utilization: 150},
+	}
+	msg := newBandwidthMessage(report, false, false)
+	if !reflect.DeepEqual(msg.Buckets, expected) {
+		t.Errorf("expected %+v, got %+v", expected, msg.Buckets)
+	}
+}
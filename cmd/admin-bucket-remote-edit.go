@@ -79,7 +79,7 @@ EXAMPLES:
 func checkAdminBucketRemoteEditSyntax(ctx *cli.Context) {
 	argsNr := len(ctx.Args())
 	if argsNr != 2 {
-		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -65,7 +65,7 @@ EXAMPLES:
 // checkAdminBucketRemoteListSyntax - validate all the passed arguments
 func checkAdminBucketRemoteListSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
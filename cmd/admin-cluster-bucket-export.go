@@ -0,0 +1,133 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminClusterBucketExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "out",
+		Usage: "write the archive to a file instead of the standard output",
+	},
+}
+
+var adminClusterBucketExportCmd = cli.Command{
+	Name:         "export",
+	Usage:        "export bucket metadata of a cluster as a zip archive",
+	Action:       mainAdminClusterBucketExport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminClusterBucketExportFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET[/BUCKET]
+
+  Policies, lifecycle rules, notifications, tags, quotas, object lock,
+  versioning, encryption and replication configuration of the buckets are
+  exported. When the standard output is a terminal the archive is written
+  to 'ALIAS-bucket-metadata.zip'.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Export the metadata of all buckets of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio > buckets-meta.zip
+
+  2. Export the metadata of bucket 'mybucket' into 'mybucket-meta.zip'.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --out mybucket-meta.zip
+`,
+}
+
+// bucketMetaExportMessage is container for bucket metadata export message.
+type bucketMetaExportMessage struct {
+	Status string `json:"status"`
+	File   string `json:"file"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized bucket metadata export message.
+func (b bucketMetaExportMessage) String() string {
+	return console.Colorize("BucketMetaMessage", "Bucket metadata successfully exported to `"+b.File+"`.")
+}
+
+// JSON jsonified bucket metadata export message.
+func (b bucketMetaExportMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminClusterBucketExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminClusterBucketExport is the handle for "mc admin cluster bucket export" command.
+func mainAdminClusterBucketExport(ctx *cli.Context) error {
+	checkAdminClusterBucketExportSyntax(ctx)
+
+	console.SetColor("BucketMetaMessage", color.New(color.FgGreen))
+
+	aliasedURL, bucket := getAliasAndBucket(ctx)
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	outFile := ctx.String("out")
+	if outFile == "" && isatty.IsTerminal(os.Stdout.Fd()) {
+		outFile = aliasedURL + "-bucket-metadata.zip"
+	}
+
+	r, e := client.exportBucketMetadata(globalContext, bucket)
+	fatalIf(probe.NewError(e).Trace(ctx.Args()...), "Unable to export bucket metadata.")
+	defer r.Close()
+
+	if outFile == "" {
+		_, e = io.Copy(os.Stdout, r)
+		fatalIf(probe.NewError(e), "Unable to export bucket metadata.")
+		return nil
+	}
+
+	f, e := os.OpenFile(outFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	fatalIf(probe.NewError(e), "Unable to create `"+outFile+"`.")
+	n, e := io.Copy(f, r)
+	if e == nil {
+		e = f.Close()
+	}
+	fatalIf(probe.NewError(e), "Unable to export bucket metadata.")
+
+	printMsg(bucketMetaExportMessage{
+		Status: "success",
+		File:   outFile,
+		Size:   n,
+	})
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminClusterBucketImportCmd = cli.Command{
+	Name:         "import",
+	Usage:        "import bucket metadata from a zip archive",
+	Action:       mainAdminClusterBucketImport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET[/BUCKET] FILE
+
+  FILE is an archive created by 'mc admin cluster bucket export', use '-'
+  to read it from the standard input. Missing buckets are created.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Restore the metadata of all buckets exported to 'buckets-meta.zip'.
+     {{.Prompt}} {{.HelpName}} myminio buckets-meta.zip
+
+  2. Restore only the metadata of bucket 'mybucket'.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket buckets-meta.zip
+`,
+}
+
+// bucketMetaImportMessage is container for bucket metadata import message.
+type bucketMetaImportMessage struct {
+	Status  string                   `json:"status"`
+	Buckets []bucketMetaImportStatus `json:"buckets"`
+}
+
+// String colorized bucket metadata import message.
+func (b bucketMetaImportMessage) String() string {
+	var s strings.Builder
+	for i, bucket := range b.Buckets {
+		if i > 0 {
+			s.WriteString("\n")
+		}
+		s.WriteString(console.Colorize("BucketMetaBucket", bucket.Bucket) + ":")
+		var kinds []string
+		for kind := range bucket.Status {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		for _, kind := range kinds {
+			status := bucket.Status[kind]
+			if status == "success" {
+				status = console.Colorize("BucketMetaMessage", status)
+			} else {
+				status = console.Colorize("BucketMetaFailed", status)
+			}
+			s.WriteString(fmt.Sprintf("\n   %-14s %s", kind, status))
+		}
+	}
+	return s.String()
+}
+
+// JSON jsonified bucket metadata import message.
+func (b bucketMetaImportMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminClusterBucketImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "import", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminClusterBucketImport is the handle for "mc admin cluster bucket import" command.
+func mainAdminClusterBucketImport(ctx *cli.Context) error {
+	checkAdminClusterBucketImportSyntax(ctx)
+
+	console.SetColor("BucketMetaBucket", color.New(color.Bold, color.FgHiBlue))
+	console.SetColor("BucketMetaMessage", color.New(color.FgGreen))
+	console.SetColor("BucketMetaFailed", color.New(color.FgRed))
+
+	aliasedURL, bucket := getAliasAndBucket(ctx)
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	var archive []byte
+	var e error
+	if file := ctx.Args().Get(1); file == "-" {
+		archive, e = ioutil.ReadAll(os.Stdin)
+	} else {
+		archive, e = ioutil.ReadFile(file)
+	}
+	fatalIf(probe.NewError(e), "Unable to read bucket metadata archive.")
+
+	status, e := client.importBucketMetadata(globalContext, bucket, archive)
+	fatalIf(probe.NewError(e).Trace(ctx.Args()...), "Unable to import bucket metadata.")
+
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Bucket < status[j].Bucket
+	})
+	printMsg(bucketMetaImportMessage{
+		Status:  "success",
+		Buckets: status,
+	})
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+)
+
+var adminClusterBucketSubcommands = []cli.Command{
+	adminClusterBucketExportCmd,
+	adminClusterBucketImportCmd,
+}
+
+var adminClusterBucketCmd = cli.Command{
+	Name:            "bucket",
+	Usage:           "export and import bucket metadata",
+	Action:          mainAdminClusterBucket,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands:     adminClusterBucketSubcommands,
+	HideHelpCommand: true,
+}
+
+// mainAdminClusterBucket is the handle for "mc admin cluster bucket" command.
+func mainAdminClusterBucket(ctx *cli.Context) error {
+	commandNotFound(ctx, adminClusterBucketSubcommands)
+	return nil
+	// Sub-commands like "export", "import" have their own main.
+}
+
+// bucketMetaImportStatus is the result of importing the metadata of a bucket,
+// keyed by the kind of configuration such as "policy" or "lifecycle".
+type bucketMetaImportStatus struct {
+	Bucket string            `json:"bucket"`
+	Status map[string]string `json:"status"`
+}
+
+// exportBucketMetadata streams a zip archive with the metadata of all
+// buckets, or of a single bucket if one is given.
+func (c *adminAPIClient) exportBucketMetadata(ctx context.Context, bucket string) (io.ReadCloser, error) {
+	query := url.Values{}
+	if bucket != "" {
+		query.Set("bucket", bucket)
+	}
+	resp, e := c.do(ctx, http.MethodGet, "/export-bucket-metadata", query, nil)
+	if e != nil {
+		return nil, e
+	}
+	return resp.Body, nil
+}
+
+// importBucketMetadata restores bucket metadata from a zip archive
+// previously created by exportBucketMetadata.
+func (c *adminAPIClient) importBucketMetadata(ctx context.Context, bucket string, archive []byte) ([]bucketMetaImportStatus, error) {
+	query := url.Values{}
+	if bucket != "" {
+		query.Set("bucket", bucket)
+	}
+	data, e := c.call(ctx, http.MethodPut, "/import-bucket-metadata", query, archive)
+	if e != nil {
+		return nil, e
+	}
+	var status []bucketMetaImportStatus
+	if e = json.Unmarshal(data, &status); e != nil {
+		return nil, e
+	}
+	return status, nil
+}
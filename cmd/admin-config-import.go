@@ -77,7 +77,7 @@ func (u configImportMessage) JSON() string {
 // checkAdminConfigImportSyntax - validate all the passed arguments
 func checkAdminConfigImportSyntax(ctx *cli.Context) {
 	if !ctx.Args().Present() || len(ctx.Args()) > 1 {
-		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "import", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -0,0 +1,204 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminConfigSearchCmd = cli.Command{
+	Name:         "search",
+	Usage:        "search config keys with their current values and help",
+	Before:       setGlobalsFromContext,
+	Action:       mainAdminConfigSearch,
+	OnUsageError: onUsageError,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET PATTERN
+
+  PATTERN is matched, ignoring case, against the sub-system names,
+  the key names and the key descriptions.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Search the settings related to compression on MinIO server.
+     {{.Prompt}} {{.HelpName}} myminio/ compress
+
+  2. Search the settings of all sub-systems with a 'queue_dir' key.
+     {{.Prompt}} {{.HelpName}} myminio/ queue_dir
+`,
+}
+
+// configSearchMessage container for a config key matching a search.
+type configSearchMessage struct {
+	Status      string `json:"status"`
+	SubSys      string `json:"subSys"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type"`
+	Optional    bool   `json:"optional"`
+	Description string `json:"description"`
+	Env         string `json:"env"`
+}
+
+// String colorized config search message.
+func (u configSearchMessage) String() string {
+	var b strings.Builder
+	b.WriteString(console.Colorize("ConfigSearchKey", u.SubSys+" "+u.Key))
+	b.WriteString(madmin.KvSeparator + fmt.Sprintf("%q", u.Value) + "\n")
+	attrs := u.Type
+	if !u.Optional {
+		attrs += ", required"
+	}
+	fmt.Fprintf(&b, "  %s (%s)\n", u.Description, attrs)
+	b.WriteString(console.Colorize("ConfigSearchEnv", "  env: "+u.Env))
+	return b.String()
+}
+
+// JSON jsonified config search message.
+func (u configSearchMessage) JSON() string {
+	u.Status = "success"
+	statusJSONBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statusJSONBytes)
+}
+
+// checkAdminConfigSearchSyntax - validate all the passed arguments
+func checkAdminConfigSearchSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 || ctx.Args().Get(1) == "" {
+		cli.ShowCommandHelpAndExit(ctx, "search", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// matchConfigKeys returns the keys of a sub-system matching the pattern,
+// all keys match when the sub-system name does.
+func matchConfigKeys(pattern string, help madmin.Help) madmin.HelpKVS {
+	pattern = strings.ToLower(pattern)
+	if strings.Contains(strings.ToLower(help.SubSys), pattern) {
+		return help.KeysHelp
+	}
+	var keys madmin.HelpKVS
+	for _, kh := range help.KeysHelp {
+		if strings.Contains(strings.ToLower(kh.Key), pattern) ||
+			strings.Contains(strings.ToLower(kh.Description), pattern) {
+			keys = append(keys, kh)
+		}
+	}
+	return keys
+}
+
+// configKeyEnv returns the environment variable overriding a config key
+// of a 'subsys[:target]', targets are suffixed to the variable name.
+func configKeyEnv(subSys, key string) string {
+	env := "MINIO_"
+	if parts := strings.SplitN(subSys, madmin.SubSystemSeparator, 2); len(parts) == 2 {
+		env += strings.ToUpper(parts[0] + madmin.Default + key + madmin.Default + parts[1])
+	} else {
+		env += strings.ToUpper(subSys + madmin.Default + key)
+	}
+	return env
+}
+
+// configSearchMessages returns the messages of the keys found in the
+// config of a sub-system, in the 'subsys[:target] key=value ...' format,
+// one message per key and target.
+func configSearchMessages(help madmin.Help, keys madmin.HelpKVS, config []byte) ([]configSearchMessage, error) {
+	var msgs []configSearchMessage
+	scanner := bufio.NewScanner(bytes.NewReader(config))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, madmin.KvComment) {
+			continue
+		}
+		target, e := madmin.ParseTarget(line, help)
+		if e != nil {
+			return nil, e
+		}
+		for _, kh := range keys {
+			msgs = append(msgs, configSearchMessage{
+				SubSys:      target.SubSystem,
+				Key:         kh.Key,
+				Value:       target.KVS.Get(kh.Key),
+				Type:        kh.Type,
+				Optional:    kh.Optional,
+				Description: kh.Description,
+				Env:         configKeyEnv(target.SubSystem, kh.Key),
+			})
+		}
+	}
+	return msgs, scanner.Err()
+}
+
+func mainAdminConfigSearch(ctx *cli.Context) error {
+	checkAdminConfigSearchSyntax(ctx)
+
+	console.SetColor("ConfigSearchKey", color.New(color.FgYellow, color.Bold))
+	console.SetColor("ConfigSearchEnv", color.New(color.FgCyan))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	pattern := args.Get(1)
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	// The sub-systems are the keys of the top level help.
+	subSystems, e := client.HelpConfigKV(globalContext, "", "", false)
+	fatalIf(probe.NewError(e), "Unable to list the configuration sub-systems.")
+
+	found := false
+	for _, sub := range subSystems.KeysHelp {
+		help, e := client.HelpConfigKV(globalContext, sub.Key, "", false)
+		fatalIf(probe.NewError(e), "Unable to get help for the sub-system `%s`.", sub.Key)
+
+		keys := matchConfigKeys(pattern, help)
+		if len(keys) == 0 {
+			continue
+		}
+
+		buf, e := client.GetConfigKV(globalContext, sub.Key)
+		fatalIf(probe.NewError(e), "Unable to get server '%s' config", sub.Key)
+
+		msgs, e := configSearchMessages(help, keys, buf)
+		fatalIf(probe.NewError(e), "Unable to parse server '%s' config", sub.Key)
+
+		for _, msg := range msgs {
+			printMsg(msg)
+			found = true
+		}
+	}
+	if !found {
+		fatalIf(errDummy().Trace(pattern), "No configuration keys match `%s`.", pattern)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+var testCompressionHelp = madmin.Help{
+	SubSys: "compression",
+	KeysHelp: madmin.HelpKVS{
+		{Key: "enable", Description: "enable or disable compression", Type: "on|off", Optional: true},
+		{Key: "extensions", Description: "comma separated file extensions", Type: "csv", Optional: true},
+	},
+}
+
+var testWebhookHelp = madmin.Help{
+	SubSys:          "notify_webhook",
+	MultipleTargets: true,
+	KeysHelp: madmin.HelpKVS{
+		{Key: "endpoint", Description: "webhook server endpoint", Type: "url"},
+		{Key: "queue_dir", Description: "staging dir for undelivered messages", Type: "path", Optional: true},
+	},
+}
+
+func TestMatchConfigKeys(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		help    madmin.Help
+		keys    []string
+	}{
+		{"compress", testCompressionHelp, []string{"enable", "extensions"}},
+		{"EXT", testCompressionHelp, []string{"extensions"}},
+		{"disable", testCompressionHelp, []string{"enable"}},
+		{"queue", testCompressionHelp, nil},
+		{"queue_dir", testWebhookHelp, []string{"queue_dir"}},
+		{"undelivered", testWebhookHelp, []string{"queue_dir"}},
+	}
+	for i, tc := range testCases {
+		var keys []string
+		for _, kh := range matchConfigKeys(tc.pattern, tc.help) {
+			keys = append(keys, kh.Key)
+		}
+		if !reflect.DeepEqual(keys, tc.keys) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.keys, keys)
+		}
+	}
+}
+
+func TestConfigSearchMessages(t *testing.T) {
+	config := []byte(`# MINIO_NOTIFY_WEBHOOK_QUEUE_DIR=/tmp/events
+notify_webhook endpoint= queue_dir=
+notify_webhook:1 endpoint="http://localhost:8080" queue_dir="/home/events dir"
+`)
+	msgs, e := configSearchMessages(testWebhookHelp, testWebhookHelp.KeysHelp[1:], config)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := []configSearchMessage{
+		{SubSys: "notify_webhook", Key: "queue_dir", Value: "", Type: "path", Optional: true,
+			Description: "staging dir for undelivered messages", Env: "MINIO_NOTIFY_WEBHOOK_QUEUE_DIR"},
+		{SubSys: "notify_webhook:1", Key: "queue_dir", Value: "/home/events dir", Type: "path", Optional: true,
+			Description: "staging dir for undelivered messages", Env: "MINIO_NOTIFY_WEBHOOK_QUEUE_DIR_1"},
+	}
+	if !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("expected %v, got %v", expected, msgs)
+	}
+
+	if _, e = configSearchMessages(testWebhookHelp, testWebhookHelp.KeysHelp, []byte("region name=us-east-1\n")); e == nil {
+		t.Error("expected an error for the config of another sub-system")
+	}
+}
@@ -87,7 +87,7 @@ func (u configSetMessage) JSON() string {
 // checkAdminConfigSetSyntax - validate all the passed arguments
 func checkAdminConfigSetSyntax(ctx *cli.Context) {
 	if !ctx.Args().Present() && len(ctx.Args()) < 1 {
-		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "set", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -26,6 +26,7 @@ var adminConfigSubcommands = []cli.Command{
 	adminConfigRestoreCmd,
 	adminConfigExportCmd,
 	adminConfigImportCmd,
+	adminConfigSearchCmd,
 }
 
 var adminConfigCmd = cli.Command{
@@ -53,7 +53,7 @@ EXAMPLES:
 // checkAdminGroupAddSyntax - validate all the passed arguments
 func checkAdminGroupAddSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) < 3 {
-		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "add", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -52,7 +52,7 @@ EXAMPLES:
 // checkAdminGroupRemoveSyntax - validate all the passed arguments
 func checkAdminGroupRemoveSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) < 2 {
-		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "remove", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
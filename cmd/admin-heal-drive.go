@@ -0,0 +1,242 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminHealDriveFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between two progress updates",
+		Value: 5 * time.Second,
+	},
+}
+
+var adminHealDriveCmd = cli.Command{
+	Name:         "drive",
+	Usage:        "follow the healing of a replaced drive until it is fully healed",
+	Action:       mainAdminHealDrive,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminHealDriveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET NODE:/DRIVE
+
+  Replaced drives are healed automatically by the server, this command
+  shows the progress of one drive across the cluster and exits once the
+  drive is fully healed.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Follow the healing of the drive '/data3' of the node 'node2' replaced in 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio node2:/data3
+
+  2. Wait for the drive '/data3' of 'node2:9000' to be healed in a script.
+     {{.Prompt}} {{.HelpName}} --json myminio node2:9000:/data3 > /dev/null && echo healed
+`,
+}
+
+// healDriveMessage is container for the healing progress of a drive.
+type healDriveMessage struct {
+	Status        string     `json:"status"`
+	Event         string     `json:"event"`
+	Endpoint      string     `json:"endpoint"`
+	State         string     `json:"state"`
+	Started       *time.Time `json:"started,omitempty"`
+	LastUpdate    *time.Time `json:"lastUpdate,omitempty"`
+	ObjectsHealed uint64     `json:"objectsHealed"`
+	ObjectsFailed uint64     `json:"objectsFailed"`
+	BytesDone     uint64     `json:"bytesDone"`
+	BytesFailed   uint64     `json:"bytesFailed"`
+	Bucket        string     `json:"bucket,omitempty"`
+	Object        string     `json:"object,omitempty"`
+	HealedBuckets int        `json:"healedBuckets"`
+	QueuedBuckets int        `json:"queuedBuckets"`
+}
+
+// String colorized heal drive message.
+func (s healDriveMessage) String() string {
+	switch s.Event {
+	case "healed":
+		msg := fmt.Sprintf("Drive `%s` fully healed.", s.Endpoint)
+		if s.Started != nil {
+			msg += fmt.Sprintf(" %d object(s), %s healed, %d object(s) failed.",
+				s.ObjectsHealed, humanize.IBytes(s.BytesDone), s.ObjectsFailed)
+		}
+		return console.Colorize("HealDriveHealed", msg)
+	case "waiting":
+		return console.Colorize("HealDriveWaiting",
+			fmt.Sprintf("Drive `%s` is %s, waiting for healing to start.", s.Endpoint, s.State))
+	}
+	msg := fmt.Sprintf("%s  %d object(s), %s healed, %d failed, bucket %d/%d",
+		console.Colorize("HealDrive", s.Endpoint),
+		s.ObjectsHealed, humanize.IBytes(s.BytesDone), s.ObjectsFailed,
+		s.HealedBuckets, s.HealedBuckets+s.QueuedBuckets)
+	if s.Bucket != "" {
+		msg += fmt.Sprintf(" (%s)", path.Join(s.Bucket, s.Object))
+	}
+	return msg
+}
+
+// JSON jsonified heal drive message.
+func (s healDriveMessage) JSON() string {
+	s.Status = "success"
+	healJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(healJSONBytes)
+}
+
+// parseHealDrive splits a 'NODE:/DRIVE' argument, NODE may include a port.
+func parseHealDrive(arg string) (node, drive string, err *probe.Error) {
+	i := strings.Index(arg, ":/")
+	if i <= 0 {
+		return "", "", errInvalidArgument().Trace(arg)
+	}
+	return arg[:i], path.Clean(arg[i+1:]), nil
+}
+
+// healDriveMatches returns true if the disk is the drive of the node, the
+// node matches the host of the disk endpoint with or without the port.
+func healDriveMatches(disk madmin.Disk, node, drive string) bool {
+	host, drivePath := "", disk.Endpoint
+	if u, e := url.Parse(disk.Endpoint); e == nil && u.Host != "" {
+		host, drivePath = u.Host, u.Path
+	}
+	if path.Clean(drivePath) != drive {
+		return false
+	}
+	// Single node deployments have no host in their endpoints.
+	if host == "" {
+		return true
+	}
+	return node == host || node == strings.Split(host, ":")[0]
+}
+
+// findHealDrive returns the drive of the node in the background heal status.
+func findHealDrive(state madmin.BgHealState, node, drive string) (madmin.Disk, bool) {
+	for _, set := range state.Sets {
+		for _, disk := range set.Disks {
+			if healDriveMatches(disk, node, drive) {
+				return disk, true
+			}
+		}
+	}
+	return madmin.Disk{}, false
+}
+
+// newHealDriveMessage returns the message for the current state of a drive,
+// last is the healing progress seen before the drive stopped healing.
+func newHealDriveMessage(disk madmin.Disk, last *madmin.HealingDisk) healDriveMessage {
+	msg := healDriveMessage{
+		Endpoint: disk.Endpoint,
+		State:    disk.State,
+	}
+	switch {
+	case disk.Healing:
+		msg.Event = "progress"
+	case disk.State == madmin.DriveStateOk:
+		msg.Event = "healed"
+	default:
+		msg.Event = "waiting"
+	}
+	info := disk.HealInfo
+	if info == nil {
+		info = last
+	}
+	if info != nil {
+		msg.Started = &info.Started
+		msg.LastUpdate = &info.LastUpdate
+		msg.ObjectsHealed = info.ObjectsHealed
+		msg.ObjectsFailed = info.ObjectsFailed
+		msg.BytesDone = info.BytesDone
+		msg.BytesFailed = info.BytesFailed
+		msg.Bucket = info.Bucket
+		msg.Object = info.Object
+		msg.HealedBuckets = len(info.HealedBuckets)
+		msg.QueuedBuckets = len(info.QueuedBuckets)
+	}
+	return msg
+}
+
+// mainAdminHealDrive - the entry function of heal drive command
+func mainAdminHealDrive(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "drive", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	interval := ctx.Duration("interval")
+	if interval <= 0 {
+		fatalIf(errInvalidArgument().Trace(interval.String()), "--interval must be positive.")
+	}
+
+	aliasedURL := ctx.Args().Get(0)
+	node, drive, err := parseHealDrive(ctx.Args().Get(1))
+	fatalIf(err, "Drive must be in 'NODE:/DRIVE' form.")
+
+	console.SetColor("HealDrive", color.New(color.Bold))
+	console.SetColor("HealDriveWaiting", color.New(color.FgYellow))
+	console.SetColor("HealDriveHealed", color.New(color.FgGreen, color.Bold))
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	var last *madmin.HealingDisk
+	var prev healDriveMessage
+	for {
+		state, e := client.BackgroundHealStatus(globalContext)
+		fatalIf(probe.NewError(e), "Failed to get the status of the background heal.")
+
+		disk, ok := findHealDrive(state, node, drive)
+		if !ok {
+			fatalIf(errInvalidArgument().Trace(ctx.Args().Get(1)), "Drive `%s:%s` not found in `%s`.", node, drive, aliasedURL)
+		}
+		if disk.HealInfo != nil {
+			last = disk.HealInfo
+		}
+
+		msg := newHealDriveMessage(disk, last)
+		// Only print the changes, the server updates the progress periodically.
+		if !reflect.DeepEqual(msg, prev) {
+			printMsg(msg)
+			prev = msg
+		}
+		if msg.Event == "healed" {
+			return nil
+		}
+		time.Sleep(interval)
+	}
+}
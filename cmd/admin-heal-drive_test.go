@@ -0,0 +1,88 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestParseHealDrive(t *testing.T) {
+	testCases := []struct {
+		arg   string
+		node  string
+		drive string
+		ok    bool
+	}{
+		{"node2:/data3", "node2", "/data3", true},
+		{"node2:9000:/data3/", "node2:9000", "/data3", true},
+		{"/data3", "", "", false},
+		{"node2", "", "", false},
+		{":/data3", "", "", false},
+	}
+	for i, tc := range testCases {
+		node, drive, err := parseHealDrive(tc.arg)
+		if (err == nil) != tc.ok {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if node != tc.node || drive != tc.drive {
+			t.Errorf("Test %d: expected %s %s, got %s %s", i+1, tc.node, tc.drive, node, drive)
+		}
+	}
+}
+
+func TestHealDriveMatches(t *testing.T) {
+	testCases := []struct {
+		endpoint string
+		node     string
+		drive    string
+		match    bool
+	}{
+		{"http://node2:9000/data3", "node2", "/data3", true},
+		{"http://node2:9000/data3", "node2:9000", "/data3", true},
+		{"http://node2:9000/data3", "node2:9001", "/data3", false},
+		{"http://node2:9000/data3", "node1", "/data3", false},
+		{"http://node2:9000/data3", "node2", "/data1", false},
+		{"/data3", "localhost", "/data3", true},
+	}
+	for i, tc := range testCases {
+		if match := healDriveMatches(madmin.Disk{Endpoint: tc.endpoint}, tc.node, tc.drive); match != tc.match {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.match, match)
+		}
+	}
+}
+
+func TestNewHealDriveMessage(t *testing.T) {
+	info := &madmin.HealingDisk{ObjectsHealed: 10, HealedBuckets: []string{"a"}, QueuedBuckets: []string{"b", "c"}}
+
+	msg := newHealDriveMessage(madmin.Disk{State: madmin.DriveStateOffline}, nil)
+	if msg.Event != "waiting" || msg.Started != nil {
+		t.Errorf("expected a waiting event without progress, got %+v", msg)
+	}
+
+	msg = newHealDriveMessage(madmin.Disk{State: madmin.DriveStateOk, Healing: true, HealInfo: info}, nil)
+	if msg.Event != "progress" || msg.ObjectsHealed != 10 || msg.HealedBuckets != 1 || msg.QueuedBuckets != 2 {
+		t.Errorf("expected a progress event, got %+v", msg)
+	}
+
+	// The last progress is reported once the drive is healed.
+	msg = newHealDriveMessage(madmin.Disk{State: madmin.DriveStateOk}, info)
+	if msg.Event != "healed" || msg.ObjectsHealed != 10 {
+		t.Errorf("expected a healed event, got %+v", msg)
+	}
+}
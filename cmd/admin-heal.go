@@ -68,13 +68,18 @@ var adminHealCmd = cli.Command{
 	Action:          mainAdminHeal,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           append(adminHealFlags, globalFlags...),
+	Flags:           append(append(adminHealFlags, notifyFlags...), globalFlags...),
+	Subcommands:     []cli.Command{adminHealDriveCmd},
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] TARGET
+  {{.HelpName}} drive [FLAGS] TARGET NODE:/DRIVE
+
+COMMANDS:
+  drive  follow the healing of a replaced drive until it is fully healed
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -89,15 +94,16 @@ DEPRECATED:
 }
 
 func checkAdminHealSyntax(ctx *cli.Context) {
+	// heal has sub-commands, its help is the one of the sub-command app.
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "heal", 1) // last argument is exit code
+		cli.ShowAppHelpAndExit(ctx, globalInvalidArgsExitStatus) // last argument is exit code
 	}
 
 	// Check for scan argument
 	scanArg := ctx.String("scan")
 	scanArg = strings.ToLower(scanArg)
 	if scanArg != scanNormalMode && scanArg != scanDeepMode {
-		cli.ShowCommandHelpAndExit(ctx, "heal", 1) // last argument is exit code
+		cli.ShowAppHelpAndExit(ctx, globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -220,7 +226,15 @@ func mainAdminHeal(ctx *cli.Context) error {
 		return nil
 	}
 
+	notifier, err := newJobNotifier(ctx)
+	fatalIf(err, "Unable to parse --notify-url.")
+	summary := jobSummary{Command: "admin heal", Target: aliasedURL}
+
 	healStart, _, herr := client.Heal(globalContext, bucket, prefix, opts, "", forceStart, false)
+	if herr != nil {
+		notifier.addError(probe.NewError(herr), "")
+		notifier.send(summary)
+	}
 	fatalIf(probe.NewError(herr), "Failed to start heal sequence.")
 
 	ui := uiData{
@@ -236,6 +250,12 @@ func mainAdminHeal(ctx *cli.Context) error {
 	}
 
 	res, e := ui.DisplayAndFollowHealStatus(aliasedURL)
+	summary.Objects = ui.ObjectsScanned
+	summary.Bytes = ui.BytesScanned
+	// Objects left without read quorum could not be healed.
+	summary.Failed = ui.HealthCols[colGrey]
+	notifier.addError(probe.NewError(e), "")
+	notifier.send(summary)
 	if e != nil {
 		if res.FailureDetail != "" {
 			data, _ := json.MarshalIndent(res, "", " ")
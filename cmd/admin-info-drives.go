@@ -0,0 +1,160 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// driveInfo wraps the drive information of madmin.
+type driveInfo struct {
+	madmin.Disk
+}
+
+// Storage API calls whose latencies are reported as read and write latency.
+var (
+	driveReadCalls  = []string{"ReadAll", "ReadFile", "ReadFileStream", "ReadVersion"}
+	driveWriteCalls = []string{"AppendFile", "CreateFile", "RenameData", "WriteAll", "WriteMetadata"}
+)
+
+// driveLatency returns the slowest of the moving average latencies of the
+// given storage API calls in milliseconds, zero when none is reported.
+func driveLatency(metrics *madmin.DiskMetrics, calls []string) (latency float64) {
+	if metrics == nil {
+		return 0
+	}
+	for _, call := range calls {
+		v, ok := metrics.APILatencies[call]
+		if !ok {
+			continue
+		}
+		d, e := time.ParseDuration(v)
+		if e != nil {
+			ns, e := strconv.ParseUint(v, 10, 64)
+			if e != nil {
+				continue
+			}
+			d = time.Duration(ns)
+		}
+		if ms := float64(d) / float64(time.Millisecond); ms > latency {
+			latency = ms
+		}
+	}
+	return latency
+}
+
+// serverDrives is the list of drives of a single server.
+type serverDrives struct {
+	Endpoint string      `json:"endpoint"`
+	State    string      `json:"state"`
+	Drives   []driveInfo `json:"drives"`
+}
+
+// serverDrivesInfo fetches the drives of all servers sorted by endpoint.
+func (c *adminAPIClient) serverDrivesInfo(ctx context.Context) ([]serverDrives, error) {
+	data, e := c.call(ctx, http.MethodGet, "/info", nil, nil)
+	if e != nil {
+		return nil, e
+	}
+	var info struct {
+		Servers []serverDrives `json:"servers"`
+	}
+	if e = json.Unmarshal(data, &info); e != nil {
+		return nil, e
+	}
+	sort.Slice(info.Servers, func(i, j int) bool {
+		return info.Servers[i].Endpoint < info.Servers[j].Endpoint
+	})
+	return info.Servers, nil
+}
+
+// driveInfoMessage is container for the drive statistics of all servers.
+type driveInfoMessage struct {
+	Status  string         `json:"status"`
+	Servers []serverDrives `json:"servers"`
+}
+
+// formatLatency formats a latency in milliseconds.
+func formatLatency(latency float64) string {
+	if latency == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1fms", latency)
+}
+
+// String colorized drive statistics, one table per server.
+func (d driveInfoMessage) String() string {
+	var b strings.Builder
+	for i, srv := range d.Servers {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		if srv.State == "offline" {
+			b.WriteString(fmt.Sprintf("%s  %s", console.Colorize("InfoFail", dot), console.Colorize("PrintB", srv.Endpoint)))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s  %s\n", console.Colorize("Info", dot), console.Colorize("PrintB", srv.Endpoint)))
+		b.WriteString(console.Colorize("DriveHeader", fmt.Sprintf("   %-28s %-12s %-22s %-8s %-10s %s",
+			"Drive", "State", "Used/Total", "Healing", "Read", "Write")))
+		for _, drive := range srv.Drives {
+			readLatency := driveLatency(drive.Metrics, driveReadCalls)
+			if readLatency == 0 {
+				readLatency = drive.ReadLatency
+			}
+			writeLatency := driveLatency(drive.Metrics, driveWriteCalls)
+			if writeLatency == 0 {
+				writeLatency = drive.WriteLatency
+			}
+			healing := "-"
+			if drive.Healing {
+				healing = "yes"
+			}
+			line := fmt.Sprintf("   %-28s %-12s %-22s %-8s %-10s %s",
+				lineTrunc(drive.DrivePath, 28), drive.State,
+				humanize.IBytes(drive.UsedSpace)+"/"+humanize.IBytes(drive.TotalSpace), healing,
+				formatLatency(readLatency), formatLatency(writeLatency))
+			switch {
+			case drive.State != madmin.DriveStateOk:
+				line = console.Colorize("InfoFail", line)
+			case drive.Healing:
+				line = console.Colorize("InfoWarning", line)
+			}
+			b.WriteString("\n" + line)
+		}
+	}
+	return b.String()
+}
+
+// JSON jsonified drive statistics.
+func (d driveInfoMessage) JSON() string {
+	statusJSONBytes, e := json.MarshalIndent(d, "", "    ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statusJSONBytes)
+}
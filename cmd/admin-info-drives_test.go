@@ -0,0 +1,48 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestDriveLatency(t *testing.T) {
+	metrics := &madmin.DiskMetrics{APILatencies: map[string]string{
+		"ReadFile":    "1.5ms",
+		"ReadVersion": "2500000",
+		"CreateFile":  "250µs",
+		"WalkDir":     "1s",
+		"WriteAll":    "invalid",
+	}}
+	testCases := []struct {
+		metrics *madmin.DiskMetrics
+		calls   []string
+		latency float64
+	}{
+		{metrics, driveReadCalls, 2.5},
+		{metrics, driveWriteCalls, 0.25},
+		{&madmin.DiskMetrics{}, driveReadCalls, 0},
+		{nil, driveWriteCalls, 0},
+	}
+	for i, testCase := range testCases {
+		if latency := driveLatency(testCase.metrics, testCase.calls); latency != testCase.latency {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.latency, latency)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+
+	"github.com/minio/madmin-go"
+)
+
+// adminInfoSchemaVersion is the version of the fields of 'admin info --json'
+// computed by mc, it is increased whenever one of them is renamed, removed or
+// changes meaning. The 'info' field is passed through from the server as is.
+const adminInfoSchemaVersion = 1
+
+// infoPool is the capacity of a server pool.
+type infoPool struct {
+	PoolIndex      int    `json:"poolIndex"`
+	Servers        int    `json:"servers"`
+	Sets           int    `json:"sets"`
+	Drives         int    `json:"drives"`
+	OnlineDrives   int    `json:"onlineDrives"`
+	OfflineDrives  int    `json:"offlineDrives"`
+	TotalSpace     uint64 `json:"totalSpace"`
+	UsedSpace      uint64 `json:"usedSpace"`
+	AvailableSpace uint64 `json:"availableSpace"`
+}
+
+// infoSet is the health of an erasure set.
+type infoSet struct {
+	PoolIndex     int    `json:"poolIndex"`
+	SetIndex      int    `json:"setIndex"`
+	Drives        int    `json:"drives"`
+	OnlineDrives  int    `json:"onlineDrives"`
+	OfflineDrives int    `json:"offlineDrives"`
+	HealingDrives int    `json:"healingDrives"`
+	Parity        int    `json:"parity"`
+	ReadQuorum    int    `json:"readQuorum"`
+	WriteQuorum   int    `json:"writeQuorum"`
+	Health        string `json:"health"`
+}
+
+// Health of an erasure set.
+const (
+	setHealthy  = "healthy"
+	setDegraded = "degraded"
+	setReadOnly = "read-only"
+	setOffline  = "offline"
+)
+
+// infoServer is the uptime and the interconnect state of a server.
+type infoServer struct {
+	Endpoint     string   `json:"endpoint"`
+	State        string   `json:"state"`
+	Uptime       int64    `json:"uptime"`
+	Version      string   `json:"version,omitempty"`
+	Peers        int      `json:"peers"`
+	OnlinePeers  int      `json:"onlinePeers"`
+	OfflinePeers []string `json:"offlinePeers,omitempty"`
+}
+
+// defaultSetParity returns the default parity of the standard storage class
+// for the drives of an erasure set, for servers which do not report it.
+func defaultSetParity(drives int) int {
+	switch {
+	case drives <= 1:
+		return 0
+	case drives <= 3:
+		return 1
+	case drives <= 5:
+		return 2
+	case drives <= 7:
+		return 3
+	}
+	return 4
+}
+
+// backendParity returns the parity of the standard storage class reported
+// by an erasure backend, 0 when unknown.
+func backendParity(backend interface{}) int {
+	m, ok := backend.(map[string]interface{})
+	if !ok {
+		return 0
+	}
+	parity, _ := m["standardSCParity"].(float64)
+	return int(parity)
+}
+
+// isDriveOnline returns true for the drives serving requests.
+func isDriveOnline(disk madmin.Disk) bool {
+	return disk.State == madmin.DriveStateOk || disk.State == madmin.DriveStateUnformatted
+}
+
+// summarizeSet computes the quorums and the health of an erasure set.
+func summarizeSet(set infoSet, parity int) infoSet {
+	if parity <= 0 || parity > set.Drives/2 {
+		parity = defaultSetParity(set.Drives)
+	}
+	set.Parity = parity
+	set.ReadQuorum = set.Drives - parity
+	set.WriteQuorum = set.ReadQuorum
+	if set.ReadQuorum == parity {
+		set.WriteQuorum++
+	}
+	switch {
+	case set.OnlineDrives == set.Drives && set.HealingDrives == 0:
+		set.Health = setHealthy
+	case set.OnlineDrives >= set.WriteQuorum:
+		set.Health = setDegraded
+	case set.OnlineDrives >= set.ReadQuorum:
+		set.Health = setReadOnly
+	default:
+		set.Health = setOffline
+	}
+	return set
+}
+
+// summarizeClusterInfo computes the pools, erasure sets and servers of the
+// server information, drives not assigned to a set are ignored.
+func summarizeClusterInfo(info madmin.InfoMessage) (pools []infoPool, sets []infoSet, servers []infoServer, uptime int64) {
+	type setKey struct{ pool, set int }
+	poolIdx := map[int]*infoPool{}
+	setIdx := map[setKey]*infoSet{}
+	poolServers := map[int]map[string]bool{}
+
+	for _, srv := range info.Servers {
+		s := infoServer{
+			Endpoint: srv.Endpoint,
+			State:    srv.State,
+			Uptime:   srv.Uptime,
+			Version:  srv.Version,
+			Peers:    len(srv.Network),
+		}
+		for peer, state := range srv.Network {
+			if state == "online" {
+				s.OnlinePeers++
+			} else {
+				s.OfflinePeers = append(s.OfflinePeers, peer)
+			}
+		}
+		sort.Strings(s.OfflinePeers)
+		servers = append(servers, s)
+		if srv.State != "offline" && srv.Uptime > 0 && (uptime == 0 || srv.Uptime < uptime) {
+			uptime = srv.Uptime
+		}
+
+		for _, disk := range srv.Disks {
+			if disk.PoolIndex < 0 || disk.SetIndex < 0 {
+				continue
+			}
+			p, ok := poolIdx[disk.PoolIndex]
+			if !ok {
+				p = &infoPool{PoolIndex: disk.PoolIndex}
+				poolIdx[disk.PoolIndex] = p
+				poolServers[disk.PoolIndex] = map[string]bool{}
+			}
+			k := setKey{disk.PoolIndex, disk.SetIndex}
+			set, ok := setIdx[k]
+			if !ok {
+				set = &infoSet{PoolIndex: disk.PoolIndex, SetIndex: disk.SetIndex}
+				setIdx[k] = set
+				p.Sets++
+			}
+			poolServers[disk.PoolIndex][srv.Endpoint] = true
+
+			p.Drives++
+			set.Drives++
+			if isDriveOnline(disk) {
+				p.OnlineDrives++
+				set.OnlineDrives++
+				p.TotalSpace += disk.TotalSpace
+				p.UsedSpace += disk.UsedSpace
+				p.AvailableSpace += disk.AvailableSpace
+			} else {
+				p.OfflineDrives++
+				set.OfflineDrives++
+			}
+			if disk.Healing {
+				set.HealingDrives++
+			}
+		}
+	}
+
+	parity := backendParity(info.Backend)
+	for i, p := range poolIdx {
+		p.Servers = len(poolServers[i])
+		pools = append(pools, *p)
+	}
+	for _, set := range setIdx {
+		sets = append(sets, summarizeSet(*set, parity))
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].PoolIndex < pools[j].PoolIndex })
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].PoolIndex != sets[j].PoolIndex {
+			return sets[i].PoolIndex < sets[j].PoolIndex
+		}
+		return sets[i].SetIndex < sets[j].SetIndex
+	})
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Endpoint < servers[j].Endpoint })
+	return pools, sets, servers, uptime
+}
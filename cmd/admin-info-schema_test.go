@@ -0,0 +1,101 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestSummarizeSet(t *testing.T) {
+	testCases := []struct {
+		set      infoSet
+		parity   int
+		expected infoSet
+	}{
+		{infoSet{Drives: 4, OnlineDrives: 4}, 0, infoSet{Drives: 4, OnlineDrives: 4, Parity: 2, ReadQuorum: 2, WriteQuorum: 3, Health: setHealthy}},
+		{infoSet{Drives: 4, OnlineDrives: 3, OfflineDrives: 1}, 0, infoSet{Drives: 4, OnlineDrives: 3, OfflineDrives: 1, Parity: 2, ReadQuorum: 2, WriteQuorum: 3, Health: setDegraded}},
+		{infoSet{Drives: 4, OnlineDrives: 2, OfflineDrives: 2}, 0, infoSet{Drives: 4, OnlineDrives: 2, OfflineDrives: 2, Parity: 2, ReadQuorum: 2, WriteQuorum: 3, Health: setReadOnly}},
+		{infoSet{Drives: 4, OnlineDrives: 1, OfflineDrives: 3}, 0, infoSet{Drives: 4, OnlineDrives: 1, OfflineDrives: 3, Parity: 2, ReadQuorum: 2, WriteQuorum: 3, Health: setOffline}},
+		{infoSet{Drives: 16, OnlineDrives: 16, HealingDrives: 1}, 2, infoSet{Drives: 16, OnlineDrives: 16, HealingDrives: 1, Parity: 2, ReadQuorum: 14, WriteQuorum: 14, Health: setDegraded}},
+		{infoSet{Drives: 1, OnlineDrives: 1}, 0, infoSet{Drives: 1, OnlineDrives: 1, Parity: 0, ReadQuorum: 1, WriteQuorum: 1, Health: setHealthy}},
+	}
+	for i, testCase := range testCases {
+		if got := summarizeSet(testCase.set, testCase.parity); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("test %d: expected %+v, got %+v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestSummarizeClusterInfo(t *testing.T) {
+	disk := func(pool, set int, state string, total, used uint64) madmin.Disk {
+		return madmin.Disk{PoolIndex: pool, SetIndex: set, State: state, TotalSpace: total, UsedSpace: used, AvailableSpace: total - used}
+	}
+	info := madmin.InfoMessage{
+		Backend: map[string]interface{}{"backendType": "Erasure", "standardSCParity": float64(1)},
+		Servers: []madmin.ServerProperties{
+			{
+				Endpoint: "node2:9000",
+				State:    "online",
+				Uptime:   100,
+				Network:  map[string]string{"node1:9000": "online", "node2:9000": "online", "node3:9000": "offline"},
+				Disks:    []madmin.Disk{disk(0, 0, madmin.DriveStateOk, 10, 4), disk(0, 0, madmin.DriveStateOffline, 10, 0)},
+			},
+			{
+				Endpoint: "node1:9000",
+				State:    "online",
+				Uptime:   200,
+				Network:  map[string]string{"node1:9000": "online", "node2:9000": "online"},
+				Disks:    []madmin.Disk{disk(0, 0, madmin.DriveStateOk, 10, 4), disk(0, 0, madmin.DriveStateOk, 10, 4)},
+			},
+			{
+				Endpoint: "node3:9000",
+				State:    "offline",
+				Disks:    []madmin.Disk{disk(1, 0, madmin.DriveStateOffline, 0, 0), {PoolIndex: -1, SetIndex: -1}},
+			},
+		},
+	}
+	pools, sets, servers, uptime := summarizeClusterInfo(info)
+
+	expectedPools := []infoPool{
+		{PoolIndex: 0, Servers: 2, Sets: 1, Drives: 4, OnlineDrives: 3, OfflineDrives: 1, TotalSpace: 30, UsedSpace: 12, AvailableSpace: 18},
+		{PoolIndex: 1, Servers: 1, Sets: 1, Drives: 1, OfflineDrives: 1},
+	}
+	if !reflect.DeepEqual(pools, expectedPools) {
+		t.Errorf("expected pools %+v, got %+v", expectedPools, pools)
+	}
+	expectedSets := []infoSet{
+		{PoolIndex: 0, SetIndex: 0, Drives: 4, OnlineDrives: 3, OfflineDrives: 1, Parity: 1, ReadQuorum: 3, WriteQuorum: 3, Health: setDegraded},
+		{PoolIndex: 1, SetIndex: 0, Drives: 1, OfflineDrives: 1, Parity: 0, ReadQuorum: 1, WriteQuorum: 1, Health: setOffline},
+	}
+	if !reflect.DeepEqual(sets, expectedSets) {
+		t.Errorf("expected sets %+v, got %+v", expectedSets, sets)
+	}
+	expectedServers := []infoServer{
+		{Endpoint: "node1:9000", State: "online", Uptime: 200, Peers: 2, OnlinePeers: 2},
+		{Endpoint: "node2:9000", State: "online", Uptime: 100, Peers: 3, OnlinePeers: 2, OfflinePeers: []string{"node3:9000"}},
+		{Endpoint: "node3:9000", State: "offline"},
+	}
+	if !reflect.DeepEqual(servers, expectedServers) {
+		t.Errorf("expected servers %+v, got %+v", expectedServers, servers)
+	}
+	if uptime != 100 {
+		t.Errorf("expected uptime 100, got %d", uptime)
+	}
+}
@@ -34,18 +34,25 @@ import (
 	"github.com/minio/minio/pkg/console"
 )
 
+var adminInfoFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "drives",
+		Usage: "display usage, healing state and latency of each drive",
+	},
+}
+
 var adminInfoCmd = cli.Command{
 	Name:         "info",
 	Usage:        "display MinIO server information",
 	Action:       mainAdminInfo,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(append(adminInfoFlags, multiAliasFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -53,14 +60,27 @@ FLAGS:
 EXAMPLES:
   1. Get server information of the 'play' MinIO server.
      {{.Prompt}} {{.HelpName}} play/
+
+  2. Get drive statistics of all servers of the 'play' MinIO server.
+     {{.Prompt}} {{.HelpName}} --drives play/
+
+  3. Get server information of all configured MinIO servers.
+     {{.Prompt}} {{.HelpName}} --all-aliases
 `,
 }
 
 // Wrap "Info" message together with fields "Status" and "Error"
 type clusterStruct struct {
-	Status string             `json:"status"`
-	Error  string             `json:"error,omitempty"`
-	Info   madmin.InfoMessage `json:"info,omitempty"`
+	SchemaVersion int                `json:"schemaVersion"`
+	Status        string             `json:"status"`
+	Error         string             `json:"error,omitempty"`
+	Info          madmin.InfoMessage `json:"info,omitempty"`
+
+	// Fields computed from "Info", see adminInfoSchemaVersion.
+	Uptime  int64        `json:"uptime,omitempty"`
+	Pools   []infoPool   `json:"pools,omitempty"`
+	Sets    []infoSet    `json:"sets,omitempty"`
+	Servers []infoServer `json:"servers,omitempty"`
 }
 
 // String provides colorized info messages depending on the type of a server
@@ -242,33 +262,59 @@ func (u clusterStruct) JSON() string {
 // checkAdminInfoSyntax - validate arguments passed by a user
 func checkAdminInfoSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
-		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "info", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
 func mainAdminInfo(ctx *cli.Context) error {
+	if isMultiAlias(ctx) {
+		return runOnAliases(ctx)
+	}
+
 	checkAdminInfoSyntax(ctx)
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
 	aliasedURL := args.Get(0)
 
+	if ctx.Bool("drives") {
+		console.SetColor("Info", color.New(color.FgGreen, color.Bold))
+		console.SetColor("InfoFail", color.New(color.FgRed, color.Bold))
+		console.SetColor("InfoWarning", color.New(color.FgYellow, color.Bold))
+		console.SetColor("DriveHeader", color.New(color.FgHiWhite, color.Bold))
+
+		apiClient, err := newAdminAPIClient(aliasedURL)
+		fatalIf(err, "Unable to initialize admin connection.")
+
+		servers, e := apiClient.serverDrivesInfo(globalContext)
+		fatalIf(probe.NewError(e), "Unable to get drive information.")
+
+		printMsg(driveInfoMessage{Status: "success", Servers: servers})
+		return nil
+	}
+
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	var clusterInfo clusterStruct
+	clusterInfo := clusterStruct{SchemaVersion: adminInfoSchemaVersion}
 	// Fetch info of all servers (cluster or single server)
 	admInfo, e := client.ServerInfo(globalContext)
-	if e != nil {
+	infoErr := explainTimeout(probe.NewError(e))
+	if infoErr != nil {
 		clusterInfo.Status = "error"
-		clusterInfo.Error = e.Error()
+		clusterInfo.Error = infoErr.ToGoError().Error()
 	} else {
 		clusterInfo.Status = "success"
 		clusterInfo.Error = ""
 	}
 	clusterInfo.Info = admInfo
-	printMsg(clusterStruct(clusterInfo))
+	clusterInfo.Pools, clusterInfo.Sets, clusterInfo.Servers, clusterInfo.Uptime = summarizeClusterInfo(admInfo)
+
+	printMsg(clusterInfo)
 
+	if infoErr != nil {
+		return exitStatus(errorExitStatus(infoErr))
+	}
 	return nil
 }
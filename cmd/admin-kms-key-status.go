@@ -53,7 +53,7 @@ EXAMPLES:
 // adminKMSKeyCmd is the handle for the "mc admin kms key" command.
 func mainAdminKMSKeyStatus(ctx *cli.Context) error {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
-		cli.ShowCommandHelpAndExit(ctx, "status", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "status", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 
 	console.SetColor("StatusSuccess", color.New(color.FgGreen, color.Bold))
@@ -0,0 +1,334 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminLogsExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "since",
+		Usage: "export the entries logged since this time, e.g. '2021-06-01T00:00' or '24h' ago",
+	},
+	cli.StringFlag{
+		Name:  "until",
+		Usage: "export the entries logged until this time (default: now)",
+	},
+	cli.StringFlag{
+		Name:  "out, o",
+		Usage: "file to write the entries to, one JSON document per line",
+	},
+	cli.StringFlag{
+		Name:  "type, t",
+		Usage: "export error logs by type. Valid options are '[minio, application, all]'",
+		Value: "all",
+	},
+	cli.StringFlag{
+		Name:  "node",
+		Usage: "only export the entries of this node",
+	},
+	cli.IntFlag{
+		Name:  "limit, l",
+		Usage: "number of the most recent entries requested from each server",
+		Value: 10000,
+	},
+}
+
+var adminLogsExportCmd = cli.Command{
+	Name:         "export",
+	Usage:        "save the log entries of a time range to a file",
+	Action:       mainAdminLogsExport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminLogsExportFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --since TIME [--until TIME] --out FILE TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Save the log entries retained in memory by the servers and logged within a
+   time range to a file, sorted by time. The servers only retain their most
+   recent entries, the command reports when the range starts before the oldest
+   one. Audit entries are not retained by the servers, they are only sent to
+   the audit targets, see 'mc admin audit target'.
+
+   When --until is in the future, the entries are collected as they are logged
+   until then.
+
+EXAMPLES:
+  1. Export the logs of a day of the MinIO server with alias 'myminio'.
+     {{.Prompt}} {{.HelpName}} --since 2021-06-01T00:00 --until 2021-06-02T00:00 --out logs.ndjson myminio
+
+  2. Export the application errors of the last 2 hours of node 'node1'.
+     {{.Prompt}} {{.HelpName}} --since 2h --type application --node node1 --out node1.ndjson myminio
+`,
+}
+
+// adminLogsExportIdle is how long the server is waited for when it has
+// sent all the entries it retains.
+const adminLogsExportIdle = 2 * time.Second
+
+// The formats of --since and --until, besides the ones of --rewind.
+var adminLogsTimeFormats = []string{
+	"2006-01-02",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+}
+
+// parseAdminLogsTime parses a time given in the system local time zone,
+// or as a duration ago.
+func parseAdminLogsTime(value, flagName string) time.Time {
+	for _, format := range adminLogsTimeFormats {
+		if t, e := time.ParseInLocation(format, value, time.Local); e == nil {
+			return t
+		}
+	}
+	return parseTimeFlag(value, flagName)
+}
+
+// serverLogEntry is a log entry kept as sent by the server.
+type serverLogEntry struct {
+	time time.Time
+	raw  json.RawMessage
+	err  error
+}
+
+// serverLogs streams the log entries retained by the servers, followed by
+// the entries logged from now on.
+func (c *adminAPIClient) serverLogs(ctx context.Context, node string, limit int, logType string) (<-chan serverLogEntry, error) {
+	query := url.Values{}
+	query.Set("node", node)
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("logType", logType)
+	resp, e := c.do(ctx, http.MethodGet, "/log", query, nil)
+	if e != nil {
+		return nil, e
+	}
+	entryCh := make(chan serverLogEntry)
+	go func() {
+		defer close(entryCh)
+		defer resp.Body.Close()
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var raw json.RawMessage
+			if e := dec.Decode(&raw); e != nil {
+				if ctx.Err() == nil {
+					select {
+					case entryCh <- serverLogEntry{err: e}:
+					case <-ctx.Done():
+					}
+				}
+				return
+			}
+			var entry struct {
+				Time string `json:"time"`
+			}
+			json.Unmarshal(raw, &entry)
+			t, _ := time.Parse(time.RFC3339Nano, entry.Time)
+			select {
+			case entryCh <- serverLogEntry{time: t, raw: raw}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return entryCh, nil
+}
+
+// serverLogsExport is the selection of the entries of a time range.
+type serverLogsExport struct {
+	since, until time.Time
+	entries      []serverLogEntry
+	oldest       time.Time
+	undated      int
+}
+
+// add keeps the entry when it was logged within the time range.
+func (x *serverLogsExport) add(entry serverLogEntry) {
+	if entry.time.IsZero() {
+		x.undated++
+		return
+	}
+	if x.oldest.IsZero() || entry.time.Before(x.oldest) {
+		x.oldest = entry.time
+	}
+	if entry.time.Before(x.since) || entry.time.After(x.until) {
+		return
+	}
+	x.entries = append(x.entries, entry)
+}
+
+// collect reads the entries until the server has sent all the entries it
+// retains and the end of the time range has passed.
+func (x *serverLogsExport) collect(ctx context.Context, entryCh <-chan serverLogEntry, idle time.Duration) error {
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case entry, ok := <-entryCh:
+			if !ok {
+				return nil
+			}
+			if entry.err != nil {
+				return entry.err
+			}
+			x.add(entry)
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(idle)
+		case <-timer.C:
+			if time.Now().After(x.until) {
+				return nil
+			}
+			timer.Reset(idle)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// write saves the entries sorted by time, one per line.
+func (x *serverLogsExport) write(filename string) *probe.Error {
+	sort.SliceStable(x.entries, func(i, j int) bool { return x.entries[i].time.Before(x.entries[j].time) })
+	var buf bytes.Buffer
+	for _, entry := range x.entries {
+		if e := json.Compact(&buf, entry.raw); e != nil {
+			return probe.NewError(e)
+		}
+		buf.WriteByte('\n')
+	}
+	tmpFile := filename + ".tmp"
+	if e := ioutil.WriteFile(tmpFile, buf.Bytes(), 0600); e != nil {
+		return probe.NewError(e)
+	}
+	if e := os.Rename(tmpFile, filename); e != nil {
+		os.Remove(tmpFile)
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// adminLogsExportMessage is the outcome of an export.
+type adminLogsExportMessage struct {
+	Status  string    `json:"status"`
+	Target  string    `json:"target"`
+	File    string    `json:"file"`
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+	Entries int       `json:"entries"`
+	Oldest  time.Time `json:"oldest,omitempty"`
+	Undated int       `json:"undated,omitempty"`
+}
+
+func (m adminLogsExportMessage) String() string {
+	msg := console.Colorize("LogsExport", fmt.Sprintf("Exported %d log entries of `%s` from %s to %s to `%s`.",
+		m.Entries, m.Target, m.Since.Format(printDate), m.Until.Format(printDate), m.File))
+	if m.Oldest.IsZero() || m.Oldest.After(m.Since) {
+		oldest := "no entry"
+		if !m.Oldest.IsZero() {
+			oldest = "entries since " + m.Oldest.Format(printDate) + " only"
+		}
+		msg += "\n" + console.Colorize("LogsExportWarning", "The servers retain "+oldest+", older entries are lost.")
+	}
+	if m.Undated > 0 {
+		msg += "\n" + console.Colorize("LogsExportWarning", fmt.Sprintf("%d entries without a time were skipped.", m.Undated))
+	}
+	return msg
+}
+
+func (m adminLogsExportMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func mainAdminLogsExport(cliCtx *cli.Context) error {
+	ctx, cancelLogsExport := context.WithCancel(globalContext)
+	defer cancelLogsExport()
+
+	console.SetColor("LogsExport", color.New(color.FgGreen))
+	console.SetColor("LogsExportWarning", color.New(color.FgYellow))
+
+	if len(cliCtx.Args()) != 1 || cliCtx.String("since") == "" || cliCtx.String("out") == "" {
+		cli.ShowCommandHelpAndExit(cliCtx, "export", globalInvalidArgsExitStatus)
+	}
+	aliasedURL := cliCtx.Args().Get(0)
+
+	logType := cliCtx.String("type")
+	switch logType {
+	case "minio", "application", "all":
+	default:
+		fatalIf(errInvalidArgument().Trace(logType), "Invalid log type, expected 'minio', 'application' or 'all'.")
+	}
+
+	x := serverLogsExport{
+		since: parseAdminLogsTime(cliCtx.String("since"), "since"),
+		until: time.Now(),
+	}
+	if cliCtx.String("until") != "" {
+		x.until = parseAdminLogsTime(cliCtx.String("until"), "until")
+	}
+	if !x.until.After(x.since) {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("since"), cliCtx.String("until")), "--until must be after --since.")
+	}
+
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	entryCh, e := client.serverLogs(ctx, cliCtx.String("node"), cliCtx.Int("limit"), logType)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get the logs.")
+	e = x.collect(ctx, entryCh, adminLogsExportIdle)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get the logs.")
+	cancelLogsExport()
+
+	filename := cliCtx.String("out")
+	fatalIf(x.write(filename).Trace(filename), "Unable to write the log entries.")
+
+	printMsg(adminLogsExportMessage{
+		Target:  aliasedURL,
+		File:    filename,
+		Since:   x.since,
+		Until:   x.until,
+		Entries: len(x.entries),
+		Oldest:  x.oldest,
+		Undated: x.undated,
+	})
+	return nil
+}
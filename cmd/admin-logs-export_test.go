@@ -0,0 +1,109 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestServerLogsExport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/log" || r.URL.Query().Get("limit") != "100" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"node":"n1","time":"2021-06-01T10:00:00Z","message":"b"}
+{"node":"n2","time":"2021-05-31T23:00:00Z","message":"old"}
+{"node":"n2","time":"2021-06-01T09:00:00Z","message":"a"}
+{"node":"n1","message":"undated"}
+{"node":"n1","time":"2021-06-02T01:00:00Z","message":"late"}
+`))
+		w.(http.Flusher).Flush()
+		// The server keeps streaming the new entries.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	client := &adminAPIClient{
+		endpointURL: u,
+		creds:       credentials.NewStaticV4("minio", "minio123", ""),
+		httpClient:  server.Client(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	entryCh, e := client.serverLogs(ctx, "", 100, "all")
+	if e != nil {
+		t.Fatal(e)
+	}
+	x := serverLogsExport{
+		since: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+		until: time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC),
+	}
+	if e = x.collect(ctx, entryCh, 100*time.Millisecond); e != nil {
+		t.Fatal(e)
+	}
+	cancel()
+
+	if len(x.entries) != 2 || x.undated != 1 {
+		t.Fatalf("expected 2 entries and 1 undated, got %d and %d", len(x.entries), x.undated)
+	}
+	if expected := time.Date(2021, 5, 31, 23, 0, 0, 0, time.UTC); !x.oldest.Equal(expected) {
+		t.Errorf("expected the oldest entry at %s, got %s", expected, x.oldest)
+	}
+
+	filename := filepath.Join(t.TempDir(), "logs.ndjson")
+	if err := x.write(filename); err != nil {
+		t.Fatal(err)
+	}
+	data, e := ioutil.ReadFile(filename)
+	if e != nil {
+		t.Fatal(e)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	expected := []string{
+		`{"node":"n2","time":"2021-06-01T09:00:00Z","message":"a"}`,
+		`{"node":"n1","time":"2021-06-01T10:00:00Z","message":"b"}`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(expected, "\n"), data)
+	}
+}
+
+func TestParseAdminLogsTime(t *testing.T) {
+	expected := time.Date(2021, 6, 1, 12, 30, 0, 0, time.Local)
+	if got := parseAdminLogsTime("2021-06-01T12:30", "since"); !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if got := parseAdminLogsTime("2021.06.01T12:30", "since"); !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if got := parseAdminLogsTime("2h", "since"); time.Since(got) < 2*time.Hour || time.Since(got) > 2*time.Hour+time.Minute {
+		t.Errorf("expected 2 hours ago, got %s", got)
+	}
+}
@@ -42,11 +42,18 @@ var adminCmdSubcommands = []cli.Command{
 	adminTopCmd,
 	adminTraceCmd,
 	adminConsoleCmd,
+	adminLogsCmd,
+	adminAccessesCmd,
 	adminPrometheusCmd,
 	adminKMSCmd,
 	adminHealthCmd,
 	adminSubnetCmd,
 	adminBucketCmd,
+	adminDiagCmd,
+	adminReplicateCmd,
+	adminClusterCmd,
+	adminTopologyCmd,
+	adminAuditCmd,
 }
 
 var adminCmd = cli.Command{
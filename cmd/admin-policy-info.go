@@ -51,7 +51,7 @@ EXAMPLES:
 // checkAdminPolicyInfoSyntax - validate all the passed arguments
 func checkAdminPolicyInfoSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "info", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
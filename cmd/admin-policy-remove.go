@@ -51,7 +51,7 @@ EXAMPLES:
 // checkAdminPolicyRemoveSyntax - validate all the passed arguments
 func checkAdminPolicyRemoveSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "remove", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
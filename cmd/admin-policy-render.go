@@ -0,0 +1,220 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+var adminPolicyRenderFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "var",
+		Usage: "value of a variable of the template, as NAME=VALUE",
+	},
+	cli.StringFlag{
+		Name:  "apply",
+		Usage: "add the rendered policy to this alias",
+	},
+	cli.StringFlag{
+		Name:  "name",
+		Usage: "name of the applied policy, may refer to the variables (default: the template name followed by the values)",
+	},
+}
+
+var adminPolicyRenderCmd = cli.Command{
+	Name:         "render",
+	Usage:        "render a policy template with variables",
+	Action:       mainAdminPolicyRender,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminPolicyRenderFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TEMPLATE [--var NAME=VALUE...] [--apply TARGET [--name POLICYNAME]]
+
+TEMPLATE:
+  Policy file referring to variables as ${NAME}. IAM policy variables with a
+  colon like ${aws:username} are kept as is.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Render the policy of the team 'analytics' in production.
+     {{.Prompt}} {{.HelpName}} team-rw.json --var team=analytics --var env=prod
+
+  2. Add the rendered policy to 'myminio' as 'team-rw-analytics-prod'.
+     {{.Prompt}} {{.HelpName}} team-rw.json --var team=analytics --var env=prod --apply myminio
+
+  3. Add the rendered policy to 'myminio' as 'prod-analytics-rw'.
+     {{.Prompt}} {{.HelpName}} team-rw.json --var team=analytics --var env=prod --apply myminio --name '${env}-${team}-rw'
+`,
+}
+
+// policyTemplateVarRegexp matches the variables of a policy template, the
+// IAM policy variables have a colon and are not matched.
+var policyTemplateVarRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// policyTemplateVar is a variable of a policy template.
+type policyTemplateVar struct {
+	name, value string
+}
+
+// parsePolicyTemplateVars parses the NAME=VALUE variables in the order of
+// the command line.
+func parsePolicyTemplateVars(args []string) ([]policyTemplateVar, *probe.Error) {
+	var vars []policyTemplateVar
+	seen := map[string]bool{}
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || !policyTemplateVarRegexp.MatchString("${"+kv[0]+"}") {
+			return nil, probe.NewError(fmt.Errorf("invalid variable `%s`, expected NAME=VALUE", arg))
+		}
+		if seen[kv[0]] {
+			return nil, probe.NewError(fmt.Errorf("variable `%s` is set more than once", kv[0]))
+		}
+		seen[kv[0]] = true
+		vars = append(vars, policyTemplateVar{name: kv[0], value: kv[1]})
+	}
+	return vars, nil
+}
+
+// renderPolicyTemplate substitutes the variables of a template, the values
+// are escaped with quote when the variables are within JSON strings.
+// Variables without a value are an error.
+func renderPolicyTemplate(template string, vars []policyTemplateVar, quote bool) (string, *probe.Error) {
+	values := map[string]string{}
+	for _, v := range vars {
+		values[v.name] = v.value
+	}
+	missing := map[string]bool{}
+	rendered := policyTemplateVarRegexp.ReplaceAllStringFunc(template, func(ref string) string {
+		name := policyTemplateVarRegexp.FindStringSubmatch(ref)[1]
+		value, ok := values[name]
+		if !ok {
+			missing[name] = true
+			return ref
+		}
+		if quote {
+			buf, _ := json.Marshal(value)
+			value = string(buf[1 : len(buf)-1])
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		var names []string
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", probe.NewError(fmt.Errorf("no value for the variables %s", strings.Join(names, ", ")))
+	}
+	return rendered, nil
+}
+
+// policyTemplateName returns the default name of a rendered policy: the
+// name of the template file followed by the values of the variables.
+func policyTemplateName(templateFile string, vars []policyTemplateVar) string {
+	base := filepath.Base(templateFile)
+	parts := []string{strings.TrimSuffix(base, filepath.Ext(base))}
+	for _, v := range vars {
+		parts = append(parts, v.value)
+	}
+	return strings.Join(parts, "-")
+}
+
+// policyRenderMessage is a rendered policy.
+type policyRenderMessage struct {
+	Status     string          `json:"status"`
+	Policy     string          `json:"policy,omitempty"`
+	PolicyJSON json.RawMessage `json:"policyJSON"`
+}
+
+func (p policyRenderMessage) String() string {
+	if p.Policy != "" {
+		return console.Colorize("PolicyMessage", "Added policy `"+p.Policy+"` successfully.")
+	}
+	var buf bytes.Buffer
+	fatalIf(probe.NewError(json.Indent(&buf, p.PolicyJSON, "", " ")), "Unable to format the policy.")
+	return buf.String()
+}
+
+func (p policyRenderMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// mainAdminPolicyRender is the handle for "mc admin policy render" command.
+func mainAdminPolicyRender(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) != 1 || ctx.IsSet("name") && !ctx.IsSet("apply") {
+		cli.ShowCommandHelpAndExit(ctx, "render", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+
+	console.SetColor("PolicyMessage", color.New(color.FgGreen))
+
+	templateFile := args.Get(0)
+	template, e := ioutil.ReadFile(templateFile)
+	fatalIf(probe.NewError(e).Trace(templateFile), "Unable to read the policy template.")
+
+	vars, err := parsePolicyTemplateVars(ctx.StringSlice("var"))
+	fatalIf(err, "Unable to parse the variables.")
+
+	policy, err := renderPolicyTemplate(string(template), vars, true)
+	fatalIf(err.Trace(templateFile), "Unable to render the policy template.")
+
+	_, e = iampolicy.ParseConfig(strings.NewReader(policy))
+	fatalIf(probe.NewError(e).Trace(templateFile), "Unable to parse the rendered policy")
+
+	var compact bytes.Buffer
+	fatalIf(probe.NewError(json.Compact(&compact, []byte(policy))), "Unable to parse the rendered policy")
+	msg := policyRenderMessage{PolicyJSON: compact.Bytes()}
+
+	if aliasedURL := ctx.String("apply"); aliasedURL != "" {
+		msg.Policy = policyTemplateName(templateFile, vars)
+		if ctx.IsSet("name") {
+			msg.Policy, err = renderPolicyTemplate(ctx.String("name"), vars, false)
+			fatalIf(err.Trace(ctx.String("name")), "Unable to render the policy name.")
+		}
+
+		client, err := newAdminClient(aliasedURL)
+		fatalIf(err, "Unable to initialize admin connection.")
+
+		fatalIf(probe.NewError(client.AddCannedPolicy(globalContext, msg.Policy, compact.Bytes())).Trace(aliasedURL, msg.Policy), "Unable to add new policy")
+	}
+
+	printMsg(msg)
+	return nil
+}
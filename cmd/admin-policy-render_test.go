@@ -0,0 +1,66 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestParsePolicyTemplateVars(t *testing.T) {
+	testCases := []struct {
+		args    []string
+		success bool
+	}{
+		{[]string{"team=analytics", "env=prod"}, true},
+		{[]string{"team="}, true},
+		{[]string{"query=a=b"}, true},
+		{[]string{"team"}, false},
+		{[]string{"=analytics"}, false},
+		{[]string{"aws:username=bob"}, false},
+		{[]string{"team=a", "team=b"}, false},
+	}
+	for i, testCase := range testCases {
+		_, err := parsePolicyTemplateVars(testCase.args)
+		if success := err == nil; success != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestRenderPolicyTemplate(t *testing.T) {
+	vars := []policyTemplateVar{{"team", "analytics"}, {"env", `pr"od`}}
+	template := `{"Resource": ["arn:aws:s3:::${team}-${env}/*", "arn:aws:s3:::${team}/${aws:username}/*"]}`
+
+	rendered, err := renderPolicyTemplate(template, vars, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"Resource": ["arn:aws:s3:::analytics-pr\"od/*", "arn:aws:s3:::analytics/${aws:username}/*"]}`
+	if rendered != expected {
+		t.Errorf("expected %s, got %s", expected, rendered)
+	}
+
+	if rendered, err = renderPolicyTemplate("${env}-${team}", vars, false); err != nil || rendered != `pr"od-analytics` {
+		t.Errorf("unexpected name %s: %v", rendered, err)
+	}
+
+	if _, err = renderPolicyTemplate("${team}-${owner}-${owner}", vars, true); err == nil || err.ToGoError().Error() != "no value for the variables owner" {
+		t.Errorf("expected an error for the missing variable, got %v", err)
+	}
+
+	if name := policyTemplateName("/tmp/team-rw.json", vars); name != `team-rw-analytics-pr"od` {
+		t.Errorf("unexpected default name %s", name)
+	}
+}
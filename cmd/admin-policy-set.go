@@ -60,7 +60,7 @@ var (
 
 func checkAdminPolicySetSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 3 {
-		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "set", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
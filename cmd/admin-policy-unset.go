@@ -57,7 +57,7 @@ EXAMPLES:
 
 func checkAdminPolicyUnsetSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 3 {
-		cli.ShowCommandHelpAndExit(ctx, "unset", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "unset", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
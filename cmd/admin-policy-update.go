@@ -57,7 +57,7 @@ EXAMPLES:
 
 func checkAdminPolicyUpdateSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 3 {
-		cli.ShowCommandHelpAndExit(ctx, "update", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "update", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
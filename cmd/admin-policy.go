@@ -26,6 +26,7 @@ var adminPolicySubcommands = []cli.Command{
 	adminPolicySetCmd,
 	adminPolicyUnsetCmd,
 	adminPolicyUpdateCmd,
+	adminPolicyRenderCmd,
 }
 
 var adminPolicyCmd = cli.Command{
@@ -0,0 +1,193 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminProfileServeFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "address",
+		Usage: "local address to serve profile data on",
+		Value: "localhost:6060",
+	},
+}
+
+var adminProfileServeCmd = cli.Command{
+	Name:            "serve",
+	Usage:           "serve continuously recorded profile data over HTTP for pprof",
+	Action:          mainAdminProfileServe,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           append(adminProfileServeFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+  Profiles recorded by 'mc admin profile start --continuous' are listed on
+  the index page, each node profile is available at a URL which can be
+  passed directly to 'go tool pprof'.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+    1. Serve all profiles recorded for 'myminio' on the default address
+       {{.Prompt}} {{.HelpName}} myminio/
+
+    2. Analyze one of the served CPU profiles
+       {{.Prompt}} go tool pprof -http :8080 http://localhost:6060/profile-2021-05-12T10-00-00+00-00.zip/profile-127.0.0.1:9000-cpu.pprof
+`,
+}
+
+var profileIndexTemplate = template.Must(template.New("index").Parse(`<html>
+<head><title>{{.Alias}} profiles</title></head>
+<body>
+<h1>{{.Alias}} profiles</h1>
+{{range .Profiles}}<h3>{{.Name}}</h3>
+<ul>
+{{$zip := .Name}}{{range .Files}}<li><a href="/{{$zip}}/{{.}}">{{.}}</a></li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
+
+type profileIndexEntry struct {
+	Name  string
+	Files []string
+}
+
+// profileServer serves the content of the recorded profile archives of an alias.
+type profileServer struct {
+	alias string
+	dir   string
+}
+
+func (p profileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if path == "" {
+		p.serveIndex(w)
+		return
+	}
+
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 || strings.Contains(parts[0], "..") {
+		http.NotFound(w, r)
+		return
+	}
+
+	zr, e := zip.OpenReader(filepath.Join(p.dir, parts[0]))
+	if e != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != parts[1] {
+			continue
+		}
+		rc, e := f.Open()
+		if e != nil {
+			http.Error(w, e.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer rc.Close()
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filepath.Base(f.Name)))
+		// The headers are sent already, the error can only be logged.
+		if _, e = io.Copy(w, rc); e != nil {
+			errorIf(probe.NewError(e).Trace(path), "Unable to send the profile `%s`.", path)
+		}
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func (p profileServer) serveIndex(w http.ResponseWriter) {
+	profiles, e := listProfiles(p.dir)
+	if e != nil {
+		http.Error(w, e.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var entries []profileIndexEntry
+	// Most recent profiles first.
+	for i := len(profiles) - 1; i >= 0; i-- {
+		zr, e := zip.OpenReader(filepath.Join(p.dir, profiles[i]))
+		if e != nil {
+			continue
+		}
+		entry := profileIndexEntry{Name: profiles[i]}
+		for _, f := range zr.File {
+			entry.Files = append(entry.Files, f.Name)
+		}
+		zr.Close()
+		entries = append(entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	profileIndexTemplate.Execute(w, struct {
+		Alias    string
+		Profiles []profileIndexEntry
+	}{p.alias, entries})
+}
+
+func checkAdminProfileServeSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "serve", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminProfileServe - the entry function of profile serve command
+func mainAdminProfileServe(ctx *cli.Context) error {
+	checkAdminProfileServeSyntax(ctx)
+
+	alias, _ := url2Alias(ctx.Args().Get(0))
+	dir := getProfileAliasDir(alias)
+	if _, e := listProfiles(dir); e != nil {
+		fatalIf(probe.NewError(e), "Unable to find any recorded profile data for `"+alias+"`.")
+	}
+
+	address := ctx.String("address")
+	console.Infof("Serving profile data of `%s` on http://%s/\n", alias, address)
+
+	server := &http.Server{Addr: address, Handler: profileServer{alias: alias, dir: dir}}
+	go func() {
+		<-globalContext.Done()
+		server.Close()
+	}()
+
+	if e := server.ListenAndServe(); e != nil && e != http.ErrServerClosed {
+		fatalIf(probe.NewError(e), "Unable to serve profile data.")
+	}
+	return nil
+}
@@ -17,7 +17,14 @@
 package cmd
 
 import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/madmin-go"
@@ -32,6 +39,20 @@ var adminProfileStartFlags = []cli.Flag{
 		Usage: "start profiler type, possible values are 'cpu', 'mem', 'block', 'mutex', 'trace', 'threads' and 'goroutines'",
 		Value: "cpu,mem,block,goroutines",
 	},
+	cli.BoolFlag{
+		Name:  "continuous",
+		Usage: "periodically record and download profile data until interrupted",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "duration of each profile in continuous mode",
+		Value: 5 * time.Minute,
+	},
+	cli.IntFlag{
+		Name:  "keep",
+		Usage: "number of most recent profiles to keep in continuous mode",
+		Value: 24,
+	},
 }
 
 var adminProfileStartCmd = cli.Command{
@@ -57,13 +78,16 @@ EXAMPLES:
 
     2. Start CPU, Memory and Block profiling concurrently
        {{.Prompt}} {{.HelpName}} --type cpu,mem,block myminio/
+
+    3. Record CPU and Memory profiles every 5 minutes, keeping the last 24 of them
+       {{.Prompt}} {{.HelpName}} --type cpu,mem --continuous --interval 5m --keep 24 myminio/
 `,
 }
 
 func checkAdminProfileStartSyntax(ctx *cli.Context) {
 	// Check flags combinations
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "start", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "start", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 
 	s := set.NewStringSet()
@@ -94,6 +118,15 @@ func checkAdminProfileStartSyntax(ctx *cli.Context) {
 		fatalIf(errDummy().Trace(ctx.String("type")),
 			"Profiler type unrecognized. Possible values are: %v.", supportedProfilerTypes)
 	}
+
+	if ctx.Bool("continuous") {
+		if ctx.Duration("interval") <= 0 {
+			fatalIf(errInvalidArgument().Trace(ctx.Duration("interval").String()), "Profile interval must be positive.")
+		}
+		if ctx.Int("keep") <= 0 {
+			fatalIf(errInvalidArgument().Trace(strconv.Itoa(ctx.Int("keep"))), "Number of profiles to keep must be positive.")
+		}
+	}
 }
 
 // mainAdminProfileStart - the entry function of profile command
@@ -114,6 +147,12 @@ func mainAdminProfileStart(ctx *cli.Context) error {
 		return nil
 	}
 
+	if ctx.Bool("continuous") {
+		alias, _ := url2Alias(aliasedURL)
+		return continuousProfile(client, madmin.ProfilerType(profilers), getProfileAliasDir(alias),
+			ctx.Duration("interval"), ctx.Int("keep"))
+	}
+
 	// Start profile
 	_, cmdErr := client.StartProfiling(globalContext, madmin.ProfilerType(profilers))
 	fatalIf(probe.NewError(cmdErr), "Unable to start profile.")
@@ -121,3 +160,77 @@ func mainAdminProfileStart(ctx *cli.Context) error {
 	console.Infoln("Profile data successfully started.")
 	return nil
 }
+
+// getProfileAliasDir returns the local directory of the continuous profiles of an alias.
+func getProfileAliasDir(alias string) string {
+	return filepath.Join(mustGetProfileDir(), alias)
+}
+
+// continuousProfile records a profile of the given duration, downloads it into
+// dir and rotates old profiles, over and over until the command is interrupted.
+func continuousProfile(client *madmin.AdminClient, profilers madmin.ProfilerType, dir string, interval time.Duration, keep int) error {
+	fatalIf(probe.NewError(os.MkdirAll(dir, 0700)), "Unable to create profile directory.")
+
+	for {
+		_, e := client.StartProfiling(globalContext, profilers)
+		fatalIf(probe.NewError(e), "Unable to start profile.")
+
+		select {
+		case <-globalContext.Done():
+			return exitStatus(globalCancelExitStatus)
+		case <-time.After(interval):
+		}
+
+		zippedData, e := client.DownloadProfilingData(globalContext)
+		if e != nil {
+			// Keep going, a single failed download must not stop continuous profiling.
+			errorIf(probe.NewError(e), "Unable to download profile data.")
+			continue
+		}
+
+		profilePath := filepath.Join(dir, "profile-"+UTCNow().Format(dateTimeFormatFilename)+".zip")
+		f, e := os.Create(profilePath)
+		fatalIf(probe.NewError(e), "Unable to save profile data.")
+		_, e = io.Copy(f, zippedData)
+		zippedData.Close()
+		f.Close()
+		fatalIf(probe.NewError(e), "Unable to save profile data.")
+
+		console.Infof("Profile data successfully downloaded as %s\n", profilePath)
+
+		fatalIf(probe.NewError(rotateProfiles(dir, keep)), "Unable to remove old profile data.")
+	}
+}
+
+// listProfiles returns the names of all profiles in dir, oldest first.
+func listProfiles(dir string) ([]string, error) {
+	entries, e := ioutil.ReadDir(dir)
+	if e != nil {
+		return nil, e
+	}
+	var profiles []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, "profile-") && strings.HasSuffix(name, ".zip") {
+			profiles = append(profiles, name)
+		}
+	}
+	// Profile names embed their creation time, lexical order is chronological.
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// rotateProfiles removes all but the keep most recent profiles in dir.
+func rotateProfiles(dir string, keep int) error {
+	profiles, e := listProfiles(dir)
+	if e != nil {
+		return e
+	}
+	for len(profiles) > keep {
+		if e = os.Remove(filepath.Join(dir, profiles[0])); e != nil {
+			return e
+		}
+		profiles = profiles[1:]
+	}
+	return nil
+}
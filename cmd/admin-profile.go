@@ -23,6 +23,7 @@ import (
 var adminProfileSubcommands = []cli.Command{
 	adminProfileStartCmd,
 	adminProfileStopCmd,
+	adminProfileServeCmd,
 }
 
 var adminProfileCmd = cli.Command{
@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -37,6 +38,39 @@ const (
 	defaultMetricsPath = "/minio/v2/metrics/cluster"
 )
 
+// Supported categories of metrics, each served on its own metrics path.
+const (
+	metricsCluster = "cluster"
+	metricsNode    = "node"
+	metricsBucket  = "bucket"
+)
+
+var metricsTypes = []string{metricsCluster, metricsNode, metricsBucket}
+
+// getMetricsPath returns the metrics path of a category of metrics.
+func getMetricsPath(metricsType string) string {
+	return "/minio/v2/metrics/" + metricsType
+}
+
+// getMetricsJobName returns the scrape job name of a category of metrics.
+func getMetricsJobName(metricsType string) string {
+	if metricsType == metricsCluster {
+		return defaultJobName
+	}
+	return defaultJobName + "-" + metricsType
+}
+
+// checkMetricsType validates the optional metrics type argument.
+func checkMetricsType(ctx *cli.Context, metricsType string) {
+	for _, t := range metricsTypes {
+		if t == metricsType {
+			return
+		}
+	}
+	fatalIf(errInvalidArgument().Trace(ctx.Args()...),
+		"Unknown metrics type `"+metricsType+"`, possible values are: "+strings.Join(metricsTypes, ", "))
+}
+
 var adminPrometheusGenerateCmd = cli.Command{
 	Name:            "generate",
 	Usage:           "generates prometheus config",
@@ -49,7 +83,12 @@ var adminPrometheusGenerateCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [METRIC-TYPE]
+
+METRIC-TYPE:
+  cluster  cluster wide metrics, scraped from any node (default)
+  node     metrics of a single node, scraped from every node
+  bucket   per bucket metrics, scraped from any node
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -58,6 +97,9 @@ EXAMPLES:
   1. Generate a default prometheus config.
      {{.Prompt}} {{.HelpName}} myminio
 
+  2. Generate a prometheus config scraping node metrics from all nodes.
+     {{.Prompt}} {{.HelpName}} myminio node
+
 `,
 }
 
@@ -145,16 +187,17 @@ var legacyConfig = PrometheusConfig{
 
 // checkAdminPrometheusSyntax - validate all the passed arguments
 func checkAdminPrometheusSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "generate", 1) // last argument is exit code
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+		cli.ShowCommandHelpAndExit(ctx, "generate", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	if len(ctx.Args()) == 2 {
+		checkMetricsType(ctx, ctx.Args().Get(1))
 	}
 }
 
-func generatePrometheusConfig(ctx *cli.Context) error {
-	// Get the alias parameter from cli
-	args := ctx.Args()
-	alias := cleanAlias(args.Get(0))
-
+// getPrometheusToken returns the host config of an alias along with a
+// bearer token which allows prometheus to scrape its metrics.
+func getPrometheusToken(alias string) (*aliasConfigV10, string, error) {
 	if !isValidAlias(alias) {
 		fatalIf(errInvalidAlias(alias), "Invalid alias.")
 	}
@@ -162,12 +205,6 @@ func generatePrometheusConfig(ctx *cli.Context) error {
 	hostConfig := mustGetHostConfig(alias)
 	if hostConfig == nil {
 		fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
-		return nil
-	}
-
-	u, err := url.Parse(hostConfig.URL)
-	if err != nil {
-		return err
 	}
 
 	jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.StandardClaims{
@@ -177,9 +214,31 @@ func generatePrometheusConfig(ctx *cli.Context) error {
 	})
 
 	token, err := jwt.SignedString([]byte(hostConfig.SecretKey))
+	if err != nil {
+		return nil, "", err
+	}
+	return hostConfig, token, nil
+}
+
+func generatePrometheusConfig(ctx *cli.Context) error {
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	alias := cleanAlias(args.Get(0))
+	metricsType := metricsCluster
+	if args.Get(1) != "" {
+		metricsType = args.Get(1)
+	}
+
+	hostConfig, token, err := getPrometheusToken(alias)
 	if err != nil {
 		return err
 	}
+
+	u, err := url.Parse(hostConfig.URL)
+	if err != nil {
+		return err
+	}
+
 	client, cerr := newAdminClient(alias)
 	fatalIf(cerr, "Unable to initialize admin connection.")
 
@@ -188,6 +247,9 @@ func generatePrometheusConfig(ctx *cli.Context) error {
 		fatalIf(probe.NewError(e), "Failed to get server info.")
 	}
 	if info.Servers[0].Version < "2021-01-30T00-20-58Z" {
+		if metricsType != metricsCluster {
+			fatalIf(errDummy().Trace(args...), "Server does not support `"+metricsType+"` metrics.")
+		}
 		legacyConfig.ScrapeConfigs[0].BearerToken = token
 		legacyConfig.ScrapeConfigs[0].Scheme = u.Scheme
 		legacyConfig.ScrapeConfigs[0].StaticConfigs[0].Targets[0] = u.Host
@@ -196,10 +258,21 @@ func generatePrometheusConfig(ctx *cli.Context) error {
 	}
 
 	// Setting the values
+	defaultConfig.ScrapeConfigs[0].JobName = getMetricsJobName(metricsType)
+	defaultConfig.ScrapeConfigs[0].MetricsPath = getMetricsPath(metricsType)
 	defaultConfig.ScrapeConfigs[0].BearerToken = token
 	defaultConfig.ScrapeConfigs[0].Scheme = u.Scheme
 	defaultConfig.ScrapeConfigs[0].StaticConfigs[0].Targets[0] = u.Host
 
+	// Node metrics are local to each node, all of them need to be scraped.
+	if metricsType == metricsNode {
+		var targets []string
+		for _, srv := range info.Servers {
+			targets = append(targets, srv.Endpoint)
+		}
+		defaultConfig.ScrapeConfigs[0].StaticConfigs[0].Targets = targets
+	}
+
 	printMsg(defaultConfig)
 
 	return nil
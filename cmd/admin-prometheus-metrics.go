@@ -0,0 +1,131 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var adminPrometheusMetricsCmd = cli.Command{
+	Name:            "metrics",
+	Usage:           "print prometheus metrics",
+	Action:          mainAdminPrometheusMetrics,
+	OnUsageError:    onUsageError,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [METRIC-TYPE]
+
+METRIC-TYPE:
+  cluster  cluster wide metrics (default)
+  node     metrics of the node serving the request
+  bucket   per bucket metrics
+
+  Metrics are fetched with the same bearer token as generated by
+  'mc admin prometheus generate', which helps debugging scrape failures.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Print the cluster metrics of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Print the node metrics of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio node
+`,
+}
+
+// prometheusMetricsMessage is container for fetched prometheus metrics.
+type prometheusMetricsMessage struct {
+	Status  string `json:"status"`
+	Type    string `json:"type"`
+	Metrics string `json:"metrics"`
+}
+
+// String prometheus metrics in the text exposition format.
+func (p prometheusMetricsMessage) String() string {
+	return strings.TrimSuffix(p.Metrics, "\n")
+}
+
+// JSON jsonified prometheus metrics.
+func (p prometheusMetricsMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func checkAdminPrometheusMetricsSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+		cli.ShowCommandHelpAndExit(ctx, "metrics", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	if len(ctx.Args()) == 2 {
+		checkMetricsType(ctx, ctx.Args().Get(1))
+	}
+}
+
+// mainAdminPrometheusMetrics is the handle for "mc admin prometheus metrics" sub-command.
+func mainAdminPrometheusMetrics(ctx *cli.Context) error {
+	checkAdminPrometheusMetricsSyntax(ctx)
+
+	args := ctx.Args()
+	alias := cleanAlias(args.Get(0))
+	metricsType := metricsCluster
+	if args.Get(1) != "" {
+		metricsType = args.Get(1)
+	}
+
+	hostConfig, token, e := getPrometheusToken(alias)
+	fatalIf(probe.NewError(e), "Unable to generate prometheus bearer token.")
+
+	req, e := http.NewRequestWithContext(globalContext, http.MethodGet,
+		strings.TrimSuffix(hostConfig.URL, "/")+getMetricsPath(metricsType), nil)
+	fatalIf(probe.NewError(e), "Unable to fetch prometheus metrics.")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	transport, err := newAdminTransport(NewS3Config(hostConfig.URL, hostConfig))
+	fatalIf(err.Trace(args...), "Unable to initialize admin connection.")
+
+	client := &http.Client{Transport: transport}
+	resp, e := client.Do(req)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to fetch prometheus metrics.")
+	defer resp.Body.Close()
+
+	body, e := ioutil.ReadAll(resp.Body)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to fetch prometheus metrics.")
+	if resp.StatusCode != http.StatusOK {
+		fatalIf(errDummy().Trace(args...), "Unable to fetch prometheus metrics, server returned `"+
+			resp.Status+"`: "+strings.TrimSpace(string(body)))
+	}
+
+	printMsg(prometheusMetricsMessage{
+		Status:  "success",
+		Type:    metricsType,
+		Metrics: string(body),
+	})
+	return nil
+}
@@ -0,0 +1,131 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminReplicateAddCmd = cli.Command{
+	Name:         "add",
+	Usage:        "add one or more sites for replication",
+	Action:       mainAdminReplicateAdd,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} ALIAS1 ALIAS2 [ALIAS3...]
+
+  Buckets, bucket metadata and IAM entities are replicated between all the
+  given sites. Sites other than the first one must not have any buckets.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Add site replication between the clusters 'minio1', 'minio2' and 'minio3'.
+     {{.Prompt}} {{.HelpName}} minio1 minio2 minio3
+`,
+}
+
+// siteReplicationMessage is container for site replication add and remove messages.
+type siteReplicationMessage struct {
+	op     string
+	Status string   `json:"status"`
+	Sites  []string `json:"sites"`
+}
+
+// String colorized site replication message.
+func (s siteReplicationMessage) String() string {
+	switch s.op {
+	case "add":
+		return console.Colorize("SiteReplicationMessage", "Requested sites were configured for replication successfully: "+strings.Join(s.Sites, ", "))
+	case "rm":
+		if len(s.Sites) == 0 {
+			return console.Colorize("SiteReplicationMessage", "All sites were removed from site replication successfully.")
+		}
+		return console.Colorize("SiteReplicationMessage", "Sites removed from site replication successfully: "+strings.Join(s.Sites, ", "))
+	}
+	return ""
+}
+
+// JSON jsonified site replication message.
+func (s siteReplicationMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminReplicateAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) < 2 {
+		cli.ShowCommandHelpAndExit(ctx, "add", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminReplicateAdd is the handle for "mc admin replicate add" command.
+func mainAdminReplicateAdd(ctx *cli.Context) error {
+	checkAdminReplicateAddSyntax(ctx)
+
+	console.SetColor("SiteReplicationMessage", color.New(color.FgGreen))
+
+	args := ctx.Args()
+
+	var sites []peerSite
+	var names []string
+	seen := make(map[string]bool)
+	for _, aliasedURL := range args {
+		alias, _ := url2Alias(aliasedURL)
+		config, err := newAdminConfig(aliasedURL)
+		fatalIf(err, "Unable to initialize admin connection.")
+		if seen[config.HostURL] {
+			fatalIf(errInvalidArgument().Trace(aliasedURL), fmt.Sprintf("Site `%s` is specified more than once.", config.HostURL))
+		}
+		seen[config.HostURL] = true
+
+		sites = append(sites, peerSite{
+			Name:      alias,
+			Endpoint:  config.HostURL,
+			AccessKey: config.AccessKey,
+			SecretKey: config.SecretKey,
+		})
+		names = append(names, alias)
+	}
+
+	client, err := newAdminAPIClient(args.Get(0))
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	e := client.siteReplicationAdd(globalContext, sites)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to add sites for replication.")
+
+	printMsg(siteReplicationMessage{
+		op:     "add",
+		Status: "success",
+		Sites:  names,
+	})
+	return nil
+}
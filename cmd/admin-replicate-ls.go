@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminReplicateListCmd = cli.Command{
+	Name:         "ls",
+	Usage:        "list sites taking part in site replication",
+	Action:       mainAdminReplicateList,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the sites replicating with 'minio1'.
+     {{.Prompt}} {{.HelpName}} minio1
+`,
+}
+
+// siteReplicationInfoMessage is container for site replication info.
+type siteReplicationInfoMessage struct {
+	Status string `json:"status"`
+	siteReplicationInfo
+}
+
+// String colorized site replication info.
+func (s siteReplicationInfoMessage) String() string {
+	if !s.Enabled {
+		return console.Colorize("SiteReplicationEmpty", "Site replication is not enabled.")
+	}
+	var b strings.Builder
+	b.WriteString(console.Colorize("SiteReplicationHeader", "SiteReplication enabled for:") + "\n\n")
+	b.WriteString(console.Colorize("SiteReplicationHeader", fmt.Sprintf("%-20s %-40s %s", "Name", "Endpoint", "DeploymentID")))
+	for _, site := range s.Sites {
+		b.WriteString(fmt.Sprintf("\n%-20s %-40s %s", site.Name, site.Endpoint, site.DeploymentID))
+	}
+	return b.String()
+}
+
+// JSON jsonified site replication info.
+func (s siteReplicationInfoMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminReplicateListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "ls", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminReplicateList is the handle for "mc admin replicate ls" command.
+func mainAdminReplicateList(ctx *cli.Context) error {
+	checkAdminReplicateListSyntax(ctx)
+
+	console.SetColor("SiteReplicationHeader", color.New(color.Bold, color.FgHiGreen))
+	console.SetColor("SiteReplicationEmpty", color.New(color.FgYellow))
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	info, e := client.siteReplicationInfo(globalContext)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get site replication information.")
+
+	printMsg(siteReplicationInfoMessage{
+		Status:              "success",
+		siteReplicationInfo: info,
+	})
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminReplicateRemoveFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "all",
+		Usage: "remove all sites and disable site replication",
+	},
+	cli.BoolFlag{
+		Name:  "force",
+		Usage: "allow --all to be used",
+	},
+}
+
+var adminReplicateRemoveCmd = cli.Command{
+	Name:         "rm",
+	Usage:        "remove one or more sites from site replication",
+	Action:       mainAdminReplicateRemove,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminReplicateRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [SITE...]
+
+  Buckets and IAM entities are left intact on the removed sites, only the
+  replication between them is stopped.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove site 'minio3' from site replication configured on 'minio1'.
+     {{.Prompt}} {{.HelpName}} minio1 minio3
+
+  2. Remove all sites and disable site replication.
+     {{.Prompt}} {{.HelpName}} --all --force minio1
+`,
+}
+
+func checkAdminReplicateRemoveSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	switch {
+	case len(args) == 0:
+		cli.ShowCommandHelpAndExit(ctx, "rm", globalInvalidArgsExitStatus) // last argument is exit code
+	case ctx.Bool("all") && len(args) > 1:
+		fatalIf(errInvalidArgument().Trace(args...), "Sites cannot be specified together with --all.")
+	case ctx.Bool("all") && !ctx.Bool("force"):
+		fatalIf(errDummy().Trace(args...), "Removing all sites requires --force flag.")
+	case !ctx.Bool("all") && len(args) == 1:
+		cli.ShowCommandHelpAndExit(ctx, "rm", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminReplicateRemove is the handle for "mc admin replicate rm" command.
+func mainAdminReplicateRemove(ctx *cli.Context) error {
+	checkAdminReplicateRemoveSyntax(ctx)
+
+	console.SetColor("SiteReplicationMessage", color.New(color.FgGreen))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	sites := args.Tail()
+	e := client.siteReplicationRemove(globalContext, sites, ctx.Bool("all"))
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to remove sites from site replication.")
+
+	printMsg(siteReplicationMessage{
+		op:     "rm",
+		Status: "success",
+		Sites:  sites,
+	})
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminReplicateStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "buckets",
+		Usage: "show replication lag of each bucket",
+	},
+}
+
+var adminReplicateStatusCmd = cli.Command{
+	Name:         "status",
+	Usage:        "show synchronization status of replicated sites",
+	Action:       mainAdminReplicateStatus,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminReplicateStatusFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the replication lag of every site replicating with 'minio1'.
+     {{.Prompt}} {{.HelpName}} minio1
+
+  2. Show the replication lag of every site and bucket.
+     {{.Prompt}} {{.HelpName}} --buckets minio1
+`,
+}
+
+// siteReplicationStatusMessage is container for site replication status.
+type siteReplicationStatusMessage struct {
+	Status string           `json:"status"`
+	Sites  []siteSyncStatus `json:"sites"`
+}
+
+// String colorized site replication status.
+func (s siteReplicationStatusMessage) String() string {
+	var b strings.Builder
+	for i, site := range s.Sites {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		state := console.Colorize("SiteOnline", "online")
+		if !site.Online {
+			state = console.Colorize("SiteOffline", "offline")
+		}
+		b.WriteString(fmt.Sprintf("%s %s  %s\n", console.Colorize("SiteName", site.Name), site.Endpoint, state))
+		b.WriteString(fmt.Sprintf("   Lag: %s", siteLag(site.Lag)))
+		if len(site.Buckets) > 0 {
+			b.WriteString("\n" + console.Colorize("SiteHeader",
+				fmt.Sprintf("   %-32s %10s %10s %10s %10s", "Bucket", "Pending", "Size", "Failed", "Lag")))
+		}
+		for _, bucket := range site.Buckets {
+			b.WriteString(fmt.Sprintf("\n   %-32s %10d %10s %10d %10s", lineTrunc(bucket.Bucket, 32),
+				bucket.PendingCount, humanize.IBytes(uint64(bucket.PendingSize)), bucket.FailedCount, siteLag(bucket.Lag)))
+		}
+	}
+	return b.String()
+}
+
+// siteLag formats a replication lag for display.
+func siteLag(lag time.Duration) string {
+	if lag <= 0 {
+		return console.Colorize("SiteInSync", "in sync")
+	}
+	return console.Colorize("SiteLagging", timeDurationToHumanizedDuration(lag).StringShort())
+}
+
+// JSON jsonified site replication status.
+func (s siteReplicationStatusMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminReplicateStatusSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "status", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminReplicateStatus is the handle for "mc admin replicate status" command.
+func mainAdminReplicateStatus(ctx *cli.Context) error {
+	checkAdminReplicateStatusSyntax(ctx)
+
+	console.SetColor("SiteName", color.New(color.Bold, color.FgHiBlue))
+	console.SetColor("SiteHeader", color.New(color.Bold, color.FgHiGreen))
+	console.SetColor("SiteOnline", color.New(color.FgGreen))
+	console.SetColor("SiteOffline", color.New(color.FgRed, color.Bold))
+	console.SetColor("SiteInSync", color.New(color.FgGreen))
+	console.SetColor("SiteLagging", color.New(color.FgYellow))
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	status, e := client.siteReplicationStatus(globalContext, ctx.Bool("buckets"))
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get site replication status.")
+
+	printMsg(siteReplicationStatusMessage{
+		Status: "success",
+		Sites:  status,
+	})
+	return nil
+}
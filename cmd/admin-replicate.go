@@ -0,0 +1,147 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+)
+
+var adminReplicateSubcommands = []cli.Command{
+	adminReplicateAddCmd,
+	adminReplicateListCmd,
+	adminReplicateStatusCmd,
+	adminReplicateRemoveCmd,
+}
+
+var adminReplicateCmd = cli.Command{
+	Name:            "replicate",
+	Usage:           "manage multi-site replication of buckets and IAM",
+	Action:          mainAdminReplicate,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	Subcommands:     adminReplicateSubcommands,
+	HideHelpCommand: true,
+}
+
+// mainAdminReplicate is the handle for "mc admin replicate" command.
+func mainAdminReplicate(ctx *cli.Context) error {
+	commandNotFound(ctx, adminReplicateSubcommands)
+	return nil
+	// Sub-commands like "add", "status" have their own main.
+}
+
+// peerSite is a site which takes part in site replication.
+type peerSite struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoints"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+// peerInfo is a site as reported by a site replication member.
+type peerInfo struct {
+	Name         string `json:"name"`
+	Endpoint     string `json:"endpoints"`
+	DeploymentID string `json:"deploymentID"`
+}
+
+// siteReplicationInfo is the site replication configuration of a site.
+type siteReplicationInfo struct {
+	Enabled                 bool       `json:"enabled"`
+	Name                    string     `json:"name,omitempty"`
+	Sites                   []peerInfo `json:"sites,omitempty"`
+	ServiceAccountAccessKey string     `json:"serviceAccountAccessKey,omitempty"`
+}
+
+// bucketSyncStatus is the replication backlog of a bucket towards a site.
+type bucketSyncStatus struct {
+	Bucket       string        `json:"bucket"`
+	PendingCount int64         `json:"pendingCount"`
+	PendingSize  int64         `json:"pendingSize"`
+	FailedCount  int64         `json:"failedCount"`
+	Lag          time.Duration `json:"lag"`
+}
+
+// siteSyncStatus is the replication status of a site.
+type siteSyncStatus struct {
+	Name         string             `json:"name"`
+	Endpoint     string             `json:"endpoint"`
+	DeploymentID string             `json:"deploymentID"`
+	Online       bool               `json:"online"`
+	Lag          time.Duration      `json:"lag"`
+	Buckets      []bucketSyncStatus `json:"buckets,omitempty"`
+}
+
+// siteReplicationAdd sets up site replication between all given sites.
+func (c *adminAPIClient) siteReplicationAdd(ctx context.Context, sites []peerSite) error {
+	sitesBytes, e := json.Marshal(sites)
+	if e != nil {
+		return e
+	}
+	// Site credentials must never be sent in the clear.
+	encBytes, e := madmin.EncryptData(c.secretKey(), sitesBytes)
+	if e != nil {
+		return e
+	}
+	_, e = c.call(ctx, http.MethodPut, "/site-replication/add", nil, encBytes)
+	return e
+}
+
+// siteReplicationInfo returns the site replication configuration.
+func (c *adminAPIClient) siteReplicationInfo(ctx context.Context) (info siteReplicationInfo, e error) {
+	data, e := c.call(ctx, http.MethodGet, "/site-replication/info", nil, nil)
+	if e != nil {
+		return info, e
+	}
+	e = json.Unmarshal(data, &info)
+	return info, e
+}
+
+// siteReplicationStatus returns the replication status of all sites.
+func (c *adminAPIClient) siteReplicationStatus(ctx context.Context, buckets bool) (status []siteSyncStatus, e error) {
+	query := url.Values{}
+	if buckets {
+		query.Set("buckets", "true")
+	}
+	data, e := c.call(ctx, http.MethodGet, "/site-replication/status", query, nil)
+	if e != nil {
+		return nil, e
+	}
+	e = json.Unmarshal(data, &status)
+	return status, e
+}
+
+// siteReplicationRemove removes sites from site replication.
+func (c *adminAPIClient) siteReplicationRemove(ctx context.Context, sites []string, all bool) error {
+	req := struct {
+		Sites []string `json:"sites"`
+		All   bool     `json:"all"`
+	}{sites, all}
+	reqBytes, e := json.Marshal(req)
+	if e != nil {
+		return e
+	}
+	_, e = c.call(ctx, http.MethodPut, "/site-replication/remove", nil, reqBytes)
+	return e
+}
@@ -106,7 +106,7 @@ EXAMPLES:
 // checkAdminHealthSyntax - validate arguments passed by a user
 func checkAdminHealthSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
-		cli.ShowCommandHelpAndExit(ctx, "health", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "health", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -0,0 +1,274 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var topAPIFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval of the statistics, the table is refreshed at every interval",
+		Value: 5 * time.Second,
+	},
+	cli.IntFlag{
+		Name:  "count",
+		Usage: "number of the most called APIs to show, 0 to show all",
+		Value: 10,
+	},
+}
+
+var adminTopAPICmd = cli.Command{
+	Name:         "api",
+	Usage:        "show live call rates, error rates and latencies of S3 APIs",
+	Before:       setGlobalsFromContext,
+	Action:       mainAdminTopAPI,
+	OnUsageError: onUsageError,
+	Flags:        append(topAPIFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET[/BUCKET]
+
+  The statistics are computed from the traces of the S3 calls of the last
+  interval. 4xx and 5xx are the percentages of calls answered with client
+  and server errors. With --json, one report per line is streamed.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the 10 most called S3 APIs of 'myminio', refreshed every 5 seconds.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Show all the S3 APIs called on bucket 'mybucket' during the last minute.
+     {{.Prompt}} {{.HelpName}} --interval 1m --count 0 myminio/mybucket
+`,
+}
+
+// topAPIStat is the statistics of the calls of one API during an interval.
+type topAPIStat struct {
+	API          string        `json:"api"`
+	Calls        int           `json:"calls"`
+	Rate         float64       `json:"rate"`
+	ClientErrors int           `json:"clientErrors"`
+	ServerErrors int           `json:"serverErrors"`
+	P50          time.Duration `json:"p50"`
+	P90          time.Duration `json:"p90"`
+	P99          time.Duration `json:"p99"`
+}
+
+// topAPIMessage is container for the API statistics of an interval.
+type topAPIMessage struct {
+	Status   string        `json:"status"`
+	Time     time.Time     `json:"time"`
+	Interval time.Duration `json:"interval"`
+	APIs     []topAPIStat  `json:"apis"`
+}
+
+// formatTopAPILatency rounds a latency to three significant digits or so.
+func formatTopAPILatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		d = d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		d = d.Round(10 * time.Microsecond)
+	default:
+		d = d.Round(time.Microsecond)
+	}
+	return d.String()
+}
+
+// String colorized API statistics as a table.
+func (t topAPIMessage) String() string {
+	const rowFmt = "%-32s %10s %8s %6s %6s %10s %10s %10s"
+	var s strings.Builder
+	s.WriteString(console.Colorize("TopAPIHeader",
+		fmt.Sprintf(rowFmt, "API", "Rate", "Calls", "4xx", "5xx", "P50", "P90", "P99")))
+	for _, st := range t.APIs {
+		tag := "TopAPI"
+		if st.ServerErrors > 0 {
+			tag = "TopAPIError"
+		}
+		s.WriteString("\n" + console.Colorize(tag, fmt.Sprintf(rowFmt,
+			lineTrunc(st.API, 32),
+			fmt.Sprintf("%.1f/s", st.Rate),
+			fmt.Sprint(st.Calls),
+			fmt.Sprintf("%.0f%%", 100*float64(st.ClientErrors)/float64(st.Calls)),
+			fmt.Sprintf("%.0f%%", 100*float64(st.ServerErrors)/float64(st.Calls)),
+			formatTopAPILatency(st.P50), formatTopAPILatency(st.P90), formatTopAPILatency(st.P99))))
+	}
+	return s.String()
+}
+
+// JSON jsonified API statistics, on a single line for streaming.
+func (t topAPIMessage) JSON() string {
+	t.Status = "success"
+	jsonMessageBytes, e := json.Marshal(t)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// topAPICalls collects the calls of the APIs during an interval.
+type topAPICalls struct {
+	latencies    map[string][]time.Duration
+	clientErrors map[string]int
+	serverErrors map[string]int
+}
+
+func newTopAPICalls() *topAPICalls {
+	return &topAPICalls{
+		latencies:    make(map[string][]time.Duration),
+		clientErrors: make(map[string]int),
+		serverErrors: make(map[string]int),
+	}
+}
+
+// add records a call, the API is the function name without the 's3.' prefix.
+func (c *topAPICalls) add(trace madmin.TraceInfo) {
+	api := strings.TrimPrefix(trace.FuncName, "s3.")
+	c.latencies[api] = append(c.latencies[api], trace.CallStats.Latency)
+	switch code := trace.RespInfo.StatusCode; {
+	case code >= 500:
+		c.serverErrors[api]++
+	case code >= 400:
+		c.clientErrors[api]++
+	}
+}
+
+// latencyPercentile returns the p-th percentile of sorted latencies.
+func latencyPercentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(float64(len(sorted))*p+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+// stats returns the statistics of the most called APIs during the interval,
+// all APIs if count is 0.
+func (c *topAPICalls) stats(interval time.Duration, count int) []topAPIStat {
+	stats := make([]topAPIStat, 0, len(c.latencies))
+	for api, latencies := range c.latencies {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		stats = append(stats, topAPIStat{
+			API:          api,
+			Calls:        len(latencies),
+			Rate:         float64(len(latencies)) / interval.Seconds(),
+			ClientErrors: c.clientErrors[api],
+			ServerErrors: c.serverErrors[api],
+			P50:          latencyPercentile(latencies, 0.50),
+			P90:          latencyPercentile(latencies, 0.90),
+			P99:          latencyPercentile(latencies, 0.99),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Calls != stats[j].Calls {
+			return stats[i].Calls > stats[j].Calls
+		}
+		return stats[i].API < stats[j].API
+	})
+	if count > 0 && len(stats) > count {
+		stats = stats[:count]
+	}
+	return stats
+}
+
+// traceBucket returns the bucket of a path style S3 call.
+func traceBucket(trace madmin.TraceInfo) string {
+	return strings.SplitN(strings.TrimPrefix(trace.ReqInfo.Path, "/"), "/", 2)[0]
+}
+
+// checkAdminTopAPISyntax - validate all the passed arguments
+func checkAdminTopAPISyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "api", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	if ctx.Duration("interval") < time.Second {
+		fatalIf(errInvalidArgument().Trace(ctx.Duration("interval").String()), "--interval must be at least 1s.")
+	}
+	if ctx.Int("count") < 0 {
+		fatalIf(errInvalidArgument().Trace(fmt.Sprint(ctx.Int("count"))), "--count must not be negative.")
+	}
+}
+
+// mainAdminTopAPI is the handle for "mc admin top api" command.
+func mainAdminTopAPI(ctx *cli.Context) error {
+	checkAdminTopAPISyntax(ctx)
+
+	console.SetColor("TopAPIHeader", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TopAPI", color.New(color.FgWhite))
+	console.SetColor("TopAPIError", color.New(color.FgRed, color.Bold))
+
+	aliasedURL, bucket := getAliasAndBucket(ctx)
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	interval := ctx.Duration("interval")
+	count := ctx.Int("count")
+
+	traceCh := client.ServiceTrace(globalContext, madmin.ServiceTraceOpts{S3: true})
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	calls := newTopAPICalls()
+	rewindLines := 0
+	for {
+		select {
+		case traceInfo, ok := <-traceCh:
+			if !ok {
+				if globalContext.Err() != nil {
+					return exitStatus(globalCancelExitStatus)
+				}
+				return nil
+			}
+			fatalIf(probe.NewError(traceInfo.Err), "Unable to listen to http trace")
+			if bucket != "" && traceBucket(traceInfo.Trace) != bucket {
+				continue
+			}
+			calls.add(traceInfo.Trace)
+		case now := <-ticker.C:
+			msg := topAPIMessage{
+				Time:     now.UTC(),
+				Interval: interval,
+				APIs:     calls.stats(interval, count),
+			}
+			if !globalJSON {
+				console.RewindLines(rewindLines)
+				rewindLines = len(msg.APIs) + 1
+			}
+			printMsg(msg)
+			calls = newTopAPICalls()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestTopAPICallsStats(t *testing.T) {
+	calls := newTopAPICalls()
+	add := func(api string, code int, latency time.Duration) {
+		calls.add(madmin.TraceInfo{
+			FuncName:  api,
+			RespInfo:  madmin.TraceResponseInfo{StatusCode: code},
+			CallStats: madmin.TraceCallStats{Latency: latency},
+		})
+	}
+	for i := 1; i <= 100; i++ {
+		code := 200
+		switch {
+		case i <= 5:
+			code = 503
+		case i <= 15:
+			code = 404
+		}
+		add("s3.GetObject", code, time.Duration(101-i)*time.Millisecond)
+	}
+	add("s3.PutObject", 200, time.Second)
+	add("s3.ListObjectsV2", 200, 2*time.Second)
+
+	expected := []topAPIStat{
+		{API: "GetObject", Calls: 100, Rate: 10, ClientErrors: 10, ServerErrors: 5,
+			P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond},
+		{API: "ListObjectsV2", Calls: 1, Rate: 0.1, P50: 2 * time.Second, P90: 2 * time.Second, P99: 2 * time.Second},
+	}
+	if stats := calls.stats(10*time.Second, 2); !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+	if stats := calls.stats(10*time.Second, 0); len(stats) != 3 {
+		t.Errorf("expected the statistics of 3 APIs, got %d", len(stats))
+	}
+}
+
+func TestTraceBucket(t *testing.T) {
+	testCases := map[string]string{
+		"/mybucket/a/b.txt": "mybucket",
+		"/mybucket":         "mybucket",
+		"/":                 "",
+	}
+	for path, bucket := range testCases {
+		if b := traceBucket(madmin.TraceInfo{ReqInfo: madmin.TraceRequestInfo{Path: path}}); b != bucket {
+			t.Errorf("%s: expected %s, got %s", path, bucket, b)
+		}
+	}
+}
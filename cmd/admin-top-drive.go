@@ -0,0 +1,241 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var topDriveFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "watch, w",
+		Usage: "continuously display the drive statistics until interrupted",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between two samples of the drive statistics",
+		Value: 2 * time.Second,
+	},
+}
+
+var adminTopDriveCmd = cli.Command{
+	Name:         "drive",
+	Usage:        "show IOPS, throughput and utilization of the drives",
+	Before:       setGlobalsFromContext,
+	Action:       mainAdminTopDrive,
+	OnUsageError: onUsageError,
+	Flags:        append(topDriveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+  IOPS are the storage calls per second to a drive between two samples,
+  throughput and utilization are reported by the servers. Drives which are
+  not online or are utilized over 90% are highlighted. With --json, one
+  report per line is streamed.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the I/O statistics of all drives of 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Watch the I/O statistics of all drives of 'myminio', refreshed every 5 seconds.
+     {{.Prompt}} {{.HelpName}} --watch --interval 5s myminio
+`,
+}
+
+// topDriveStat is the I/O statistics of a drive.
+type topDriveStat struct {
+	Node            string   `json:"node"`
+	Drive           string   `json:"drive"`
+	State           string   `json:"state"`
+	IOPS            *float64 `json:"iops,omitempty"`
+	ReadThroughput  float64  `json:"readThroughput"`
+	WriteThroughput float64  `json:"writeThroughput"`
+	Utilization     float64  `json:"utilization"`
+}
+
+// topDriveMessage is container for the I/O statistics of all drives.
+type topDriveMessage struct {
+	Status string         `json:"status"`
+	Time   time.Time      `json:"time"`
+	Drives []topDriveStat `json:"drives"`
+}
+
+// String colorized drive statistics as a table.
+func (t topDriveMessage) String() string {
+	const rowFmt = "%-24s %-28s %-10s %10s %12s %12s %6s"
+	var s strings.Builder
+	s.WriteString(console.Colorize("TopDriveHeader",
+		fmt.Sprintf(rowFmt, "Node", "Drive", "State", "IOPS", "Read", "Write", "Util")))
+	for _, st := range t.Drives {
+		iops, read, write, util := "-", "-", "-", "-"
+		if st.IOPS != nil {
+			iops = fmt.Sprintf("%.1f", *st.IOPS)
+		}
+		// Offline drives and servers report no statistics.
+		if st.State != "offline" {
+			read = humanize.IBytes(uint64(st.ReadThroughput)) + "/s"
+			write = humanize.IBytes(uint64(st.WriteThroughput)) + "/s"
+			util = fmt.Sprintf("%.0f%%", st.Utilization)
+		}
+		line := fmt.Sprintf(rowFmt, lineTrunc(st.Node, 24), lineTrunc(st.Drive, 28), st.State, iops, read, write, util)
+		tag := "TopDrive"
+		switch {
+		case st.State != madmin.DriveStateOk:
+			tag = "TopDriveFail"
+		case st.Utilization > 90:
+			tag = "TopDriveBusy"
+		}
+		s.WriteString("\n" + console.Colorize(tag, line))
+	}
+	return s.String()
+}
+
+// JSON jsonified drive statistics, on a single line for streaming.
+func (t topDriveMessage) JSON() string {
+	t.Status = "success"
+	jsonMessageBytes, e := json.Marshal(t)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// topDriveKey identifies a drive of a server across samples.
+func topDriveKey(server string, drive driveInfo) string {
+	return server + "|" + drive.DrivePath
+}
+
+// topDriveCalls returns the number of storage calls served by each drive
+// since its server started.
+func topDriveCalls(servers []serverDrives) map[string]uint64 {
+	calls := make(map[string]uint64)
+	for _, srv := range servers {
+		for _, drive := range srv.Drives {
+			if drive.Metrics == nil {
+				continue
+			}
+			var n uint64
+			for _, c := range drive.Metrics.APICalls {
+				n += c
+			}
+			calls[topDriveKey(srv.Endpoint, drive)] = n
+		}
+	}
+	return calls
+}
+
+// newTopDriveMessage returns the statistics of the drives, IOPS are computed
+// from the calls of the previous sample taken elapsed before.
+func newTopDriveMessage(servers []serverDrives, prevCalls map[string]uint64, elapsed time.Duration) topDriveMessage {
+	msg := topDriveMessage{Time: time.Now().UTC()}
+	calls := topDriveCalls(servers)
+	for _, srv := range servers {
+		if srv.State == "offline" {
+			msg.Drives = append(msg.Drives, topDriveStat{Node: srv.Endpoint, Drive: "-", State: srv.State})
+			continue
+		}
+		for _, drive := range srv.Drives {
+			st := topDriveStat{
+				Node:            srv.Endpoint,
+				Drive:           drive.DrivePath,
+				State:           drive.State,
+				ReadThroughput:  drive.ReadThroughput,
+				WriteThroughput: drive.WriteThroughPut,
+				Utilization:     drive.Utilization,
+			}
+			key := topDriveKey(srv.Endpoint, drive)
+			cur, ok := calls[key]
+			prev, prevOK := prevCalls[key]
+			// The counters are reset when a server restarts.
+			if ok && prevOK && cur >= prev && elapsed > 0 {
+				iops := float64(cur-prev) / elapsed.Seconds()
+				st.IOPS = &iops
+			}
+			msg.Drives = append(msg.Drives, st)
+		}
+	}
+	return msg
+}
+
+// checkAdminTopDriveSyntax - validate all the passed arguments
+func checkAdminTopDriveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "drive", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	if ctx.Duration("interval") < time.Second {
+		fatalIf(errInvalidArgument().Trace(ctx.Duration("interval").String()), "--interval must be at least 1s.")
+	}
+}
+
+// mainAdminTopDrive is the handle for "mc admin top drive" command.
+func mainAdminTopDrive(ctx *cli.Context) error {
+	checkAdminTopDriveSyntax(ctx)
+
+	console.SetColor("TopDriveHeader", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TopDrive", color.New(color.FgWhite))
+	console.SetColor("TopDriveBusy", color.New(color.FgYellow, color.Bold))
+	console.SetColor("TopDriveFail", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	interval := ctx.Duration("interval")
+
+	servers, e := client.serverDrivesInfo(globalContext)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get the drives information.")
+	prevCalls, prevTime := topDriveCalls(servers), time.Now()
+
+	rewindLines := 0
+	for {
+		select {
+		case <-globalContext.Done():
+			return exitStatus(globalCancelExitStatus)
+		case <-time.After(interval):
+		}
+
+		servers, e = client.serverDrivesInfo(globalContext)
+		fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get the drives information.")
+		now := time.Now()
+
+		msg := newTopDriveMessage(servers, prevCalls, now.Sub(prevTime))
+		if !globalJSON && ctx.Bool("watch") {
+			console.RewindLines(rewindLines)
+			rewindLines = len(msg.Drives) + 1
+		}
+		printMsg(msg)
+		if !ctx.Bool("watch") {
+			return nil
+		}
+		prevCalls, prevTime = topDriveCalls(servers), now
+	}
+}
@@ -0,0 +1,60 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func testTopDriveServers(calls uint64) []serverDrives {
+	drive := func(path string, calls uint64) driveInfo {
+		return driveInfo{Disk: madmin.Disk{
+			DrivePath: path,
+			State:     madmin.DriveStateOk,
+			Metrics:   &madmin.DiskMetrics{APICalls: map[string]uint64{"ReadFile": calls, "WalkDir": 10}},
+		}}
+	}
+	return []serverDrives{
+		{Endpoint: "node1:9000", State: "online", Drives: []driveInfo{drive("/data1", calls), drive("/data2", 2*calls)}},
+		{Endpoint: "node2:9000", State: "offline"},
+	}
+}
+
+func TestNewTopDriveMessage(t *testing.T) {
+	prev := topDriveCalls(testTopDriveServers(100))
+	msg := newTopDriveMessage(testTopDriveServers(300), prev, 2*time.Second)
+	if len(msg.Drives) != 3 {
+		t.Fatalf("expected 3 drives, got %d", len(msg.Drives))
+	}
+	for i, iops := range []float64{100, 200} {
+		if msg.Drives[i].IOPS == nil || *msg.Drives[i].IOPS != iops {
+			t.Errorf("drive %s: expected %v IOPS, got %v", msg.Drives[i].Drive, iops, msg.Drives[i].IOPS)
+		}
+	}
+	if st := msg.Drives[2]; st.Node != "node2:9000" || st.State != "offline" || st.IOPS != nil {
+		t.Errorf("expected the offline server without IOPS, got %+v", st)
+	}
+
+	// Counters going backwards after a restart give no IOPS.
+	msg = newTopDriveMessage(testTopDriveServers(50), prev, 2*time.Second)
+	if msg.Drives[0].IOPS != nil {
+		t.Errorf("expected no IOPS after a counter reset, got %v", *msg.Drives[0].IOPS)
+	}
+}
@@ -111,7 +111,7 @@ func (u lockMessage) JSON() string {
 // checkAdminTopLocksSyntax - validate all the passed arguments
 func checkAdminTopLocksSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 1 {
-		cli.ShowCommandHelpAndExit(ctx, "locks", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "locks", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -20,6 +20,8 @@ import "github.com/minio/cli"
 
 var adminTopSubcommands = []cli.Command{
 	adminTopLocksCmd,
+	adminTopAPICmd,
+	adminTopDriveCmd,
 }
 
 var adminTopCmd = cli.Command{
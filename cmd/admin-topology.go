@@ -0,0 +1,238 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminTopologyCmd = cli.Command{
+	Name:         "topology",
+	Usage:        "display pools, erasure sets and drives of a MinIO cluster",
+	Action:       mainAdminTopology,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+  Every erasure set is shown with the number of additional drive failures
+  it can tolerate before losing read quorum.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Display the topology of the 'myminio' cluster.
+     {{.Prompt}} {{.HelpName}} myminio
+`,
+}
+
+// topologyDrive is a drive of an erasure set.
+type topologyDrive struct {
+	Index    int    `json:"index"`
+	Endpoint string `json:"endpoint"`
+	Path     string `json:"path"`
+	State    string `json:"state"`
+	Healing  bool   `json:"healing,omitempty"`
+}
+
+// topologySet is an erasure set of a pool.
+type topologySet struct {
+	Index     int             `json:"index"`
+	Online    int             `json:"online"`
+	Offline   int             `json:"offline"`
+	Parity    int             `json:"parity"`
+	Tolerance int             `json:"tolerance"`
+	Drives    []topologyDrive `json:"drives"`
+}
+
+// topologyPool is a server pool of a cluster.
+type topologyPool struct {
+	Index int           `json:"index"`
+	Sets  []topologySet `json:"sets"`
+}
+
+// topologyMessage is container for the topology of a cluster.
+type topologyMessage struct {
+	Status string         `json:"status"`
+	Parity int            `json:"parity"`
+	Pools  []topologyPool `json:"pools"`
+}
+
+// getStandardSCParity returns the parity of the standard storage class
+// from the untyped backend information.
+func getStandardSCParity(backend interface{}) int {
+	if m, ok := backend.(map[string]interface{}); ok {
+		if parity, ok := m["standardSCParity"].(float64); ok {
+			return int(parity)
+		}
+	}
+	return 0
+}
+
+// newTopologyMessage groups the drives of all servers into pools and erasure sets.
+func newTopologyMessage(info madmin.InfoMessage) topologyMessage {
+	parity := getStandardSCParity(info.Backend)
+	pools := make(map[int]map[int][]topologyDrive)
+	for _, srv := range info.Servers {
+		for _, disk := range srv.Disks {
+			// Drives which are not assigned to a set yet are skipped.
+			if disk.PoolIndex < 0 || disk.SetIndex < 0 {
+				continue
+			}
+			if pools[disk.PoolIndex] == nil {
+				pools[disk.PoolIndex] = make(map[int][]topologyDrive)
+			}
+			pools[disk.PoolIndex][disk.SetIndex] = append(pools[disk.PoolIndex][disk.SetIndex], topologyDrive{
+				Index:    disk.DiskIndex,
+				Endpoint: disk.Endpoint,
+				Path:     disk.DrivePath,
+				State:    disk.State,
+				Healing:  disk.Healing,
+			})
+		}
+	}
+
+	msg := topologyMessage{Status: "success", Parity: parity}
+	for poolIdx, sets := range pools {
+		pool := topologyPool{Index: poolIdx}
+		for setIdx, drives := range sets {
+			sort.Slice(drives, func(i, j int) bool { return drives[i].Index < drives[j].Index })
+			set := topologySet{Index: setIdx, Drives: drives, Parity: parity}
+			if set.Parity <= 0 {
+				// Older backends do not report the parity, assume the default one.
+				set.Parity = defaultSetParity(len(drives))
+			}
+			for _, drive := range drives {
+				if drive.State == madmin.DriveStateOk {
+					set.Online++
+				} else {
+					set.Offline++
+				}
+			}
+			set.Tolerance = set.Parity - set.Offline
+			pool.Sets = append(pool.Sets, set)
+		}
+		sort.Slice(pool.Sets, func(i, j int) bool { return pool.Sets[i].Index < pool.Sets[j].Index })
+		msg.Pools = append(msg.Pools, pool)
+	}
+	sort.Slice(msg.Pools, func(i, j int) bool { return msg.Pools[i].Index < msg.Pools[j].Index })
+	return msg
+}
+
+// String colorized cluster topology as a tree.
+func (t topologyMessage) String() string {
+	var b strings.Builder
+	for i, pool := range t.Pools {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(console.Colorize("TopologyPool", fmt.Sprintf("Pool %d", pool.Index+1)))
+		for j, set := range pool.Sets {
+			setBranch, driveIndent := treeEntry, treeNext+"  "
+			if j == len(pool.Sets)-1 {
+				setBranch, driveIndent = treeLastEntry, "   "
+			}
+			tag := "TopologyOK"
+			switch {
+			case set.Tolerance < 0:
+				tag = "TopologyFail"
+			case set.Offline > 0:
+				tag = "TopologyWarning"
+			}
+			tolerance := fmt.Sprintf("tolerates %d more drive failure(s)", set.Tolerance)
+			if set.Tolerance < 0 {
+				tolerance = "read quorum lost"
+			}
+			b.WriteString(fmt.Sprintf("\n%s%s  %s", setBranch,
+				console.Colorize("TopologySet", fmt.Sprintf("Set %d", set.Index+1)),
+				console.Colorize(tag, fmt.Sprintf("%d/%d drives online, %s", set.Online, set.Online+set.Offline, tolerance))))
+			for k, drive := range set.Drives {
+				driveBranch := treeEntry
+				if k == len(set.Drives)-1 {
+					driveBranch = treeLastEntry
+				}
+				driveTag := "TopologyOK"
+				switch {
+				case drive.State != madmin.DriveStateOk:
+					driveTag = "TopologyFail"
+				case drive.Healing:
+					driveTag = "TopologyWarning"
+				}
+				state := drive.State
+				if drive.Healing {
+					state += ", healing"
+				}
+				b.WriteString(fmt.Sprintf("\n%s%s%s %s %s", driveIndent, driveBranch,
+					console.Colorize(driveTag, dot), drive.Endpoint, console.Colorize(driveTag, state)))
+			}
+		}
+	}
+	return b.String()
+}
+
+// JSON jsonified cluster topology.
+func (t topologyMessage) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+func checkAdminTopologySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "topology", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+}
+
+// mainAdminTopology is the handle for "mc admin topology" command.
+func mainAdminTopology(ctx *cli.Context) error {
+	checkAdminTopologySyntax(ctx)
+
+	console.SetColor("TopologyPool", color.New(color.FgHiBlue, color.Bold))
+	console.SetColor("TopologySet", color.New(color.FgHiWhite, color.Bold))
+	console.SetColor("TopologyOK", color.New(color.FgGreen))
+	console.SetColor("TopologyWarning", color.New(color.FgYellow, color.Bold))
+	console.SetColor("TopologyFail", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	info, e := client.ServerInfo(globalContext)
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get server information.")
+
+	msg := newTopologyMessage(info)
+	if len(msg.Pools) == 0 {
+		fatalIf(errDummy().Trace(aliasedURL), "`"+aliasedURL+"` is not an erasure coded deployment.")
+	}
+	printMsg(msg)
+	return nil
+}
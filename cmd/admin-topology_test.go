@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestNewTopologyMessage(t *testing.T) {
+	disk := func(pool, set, idx int, state string) madmin.Disk {
+		return madmin.Disk{PoolIndex: pool, SetIndex: set, DiskIndex: idx, State: state}
+	}
+	info := madmin.InfoMessage{
+		Backend: map[string]interface{}{"standardSCParity": float64(2)},
+		Servers: []madmin.ServerProperties{
+			{Disks: []madmin.Disk{
+				disk(0, 0, 1, madmin.DriveStateOk),
+				disk(0, 0, 0, madmin.DriveStateOk),
+				disk(0, 1, 0, madmin.DriveStateOffline),
+				disk(-1, -1, -1, madmin.DriveStateUnformatted),
+			}},
+			{Disks: []madmin.Disk{
+				disk(0, 1, 1, madmin.DriveStateOffline),
+				disk(0, 1, 2, madmin.DriveStateOffline),
+				disk(1, 0, 0, madmin.DriveStateOk),
+			}},
+		},
+	}
+
+	msg := newTopologyMessage(info)
+	if msg.Parity != 2 {
+		t.Fatalf("expected parity 2, got %d", msg.Parity)
+	}
+	if len(msg.Pools) != 2 {
+		t.Fatalf("expected 2 pools, got %d", len(msg.Pools))
+	}
+	sets := msg.Pools[0].Sets
+	if len(sets) != 2 {
+		t.Fatalf("expected 2 sets in first pool, got %d", len(sets))
+	}
+	if sets[0].Drives[0].Index != 0 || sets[0].Drives[1].Index != 1 {
+		t.Errorf("expected drives sorted by index, got %v", sets[0].Drives)
+	}
+	if sets[0].Tolerance != 2 {
+		t.Errorf("expected healthy set to tolerate 2 failures, got %d", sets[0].Tolerance)
+	}
+	if sets[1].Offline != 3 || sets[1].Tolerance != -1 {
+		t.Errorf("expected 3 offline drives and lost quorum, got %d offline and tolerance %d",
+			sets[1].Offline, sets[1].Tolerance)
+	}
+
+	// Without the parity reported by the backend, the default parity of
+	// the drives of the set is assumed.
+	var drives []madmin.Disk
+	for i := 0; i < 16; i++ {
+		drives = append(drives, disk(0, 0, i, madmin.DriveStateOk))
+	}
+	drives[3].State = madmin.DriveStateOffline
+	msg = newTopologyMessage(madmin.InfoMessage{Servers: []madmin.ServerProperties{{Disks: drives}}})
+	if set := msg.Pools[0].Sets[0]; set.Parity != 4 || set.Tolerance != 3 {
+		t.Errorf("expected default parity 4 tolerating 3 more failures, got parity %d and tolerance %d",
+			set.Parity, set.Tolerance)
+	}
+}
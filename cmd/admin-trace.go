@@ -118,7 +118,7 @@ var (
 
 func checkAdminTraceSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "trace", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "trace", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -37,11 +37,14 @@ var adminServerUpdateCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [UPDATE-URL]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+All servers download the new release, then restart together with it.
+The server API has no release channels, staged updates or rollbacks.
+
 EXAMPLES:
   1. Update MinIO server represented by its alias 'play'.
      {{.Prompt}} {{.HelpName}} play/
@@ -82,7 +85,7 @@ func (s serverUpdateMessage) JSON() string {
 // checkAdminServerUpdateSyntax - validate all the passed arguments
 func checkAdminServerUpdateSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
-		cli.ShowCommandHelpAndExit(ctx, "update", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "update", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
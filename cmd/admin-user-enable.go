@@ -49,7 +49,7 @@ EXAMPLES:
 // checkAdminUserEnableSyntax - validate all the passed arguments
 func checkAdminUserEnableSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(ctx, "enable", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "enable", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -48,7 +48,7 @@ EXAMPLES:
 // checkAdminUserAddSyntax - validate all the passed arguments
 func checkAdminUserInfoSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "info", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
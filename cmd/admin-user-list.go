@@ -48,7 +48,7 @@ EXAMPLES:
 // checkAdminUserListSyntax - validate all the passed arguments
 func checkAdminUserListSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "list", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
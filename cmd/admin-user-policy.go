@@ -55,7 +55,7 @@ EXAMPLES:
 // checkAdminUserPolicySyntax - validate all the passed arguments
 func checkAdminUserPolicySyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(ctx, "policy", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
@@ -29,7 +31,7 @@ var adminUserRemoveCmd = cli.Command{
 	Action:       mainAdminUserRemove,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(confirmFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -42,13 +44,16 @@ FLAGS:
 EXAMPLES:
   1. Remove a user 'foobar' on MinIO server.
      {{.Prompt}} {{.HelpName}} myminio foobar
+
+  2. Remove a user 'foobar' on MinIO server after typing its name.
+     {{.Prompt}} {{.HelpName}} --confirm myminio foobar
 `,
 }
 
 // checkAdminUserRemoveSyntax - validate all the passed arguments
 func checkAdminUserRemoveSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "remove", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -66,6 +71,15 @@ func mainAdminUserRemove(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
+	if isConfirmRequired(ctx) {
+		description := fmt.Sprintf("Removing user `%s` from `%s`.", args.Get(1), aliasedURL)
+		// The service accounts of the user are removed with it.
+		if svcList, e := client.ListServiceAccounts(globalContext, args.Get(1)); e == nil && len(svcList.Accounts) > 0 {
+			description = fmt.Sprintf("Removing user `%s` and its %d service accounts from `%s`.", args.Get(1), len(svcList.Accounts), aliasedURL)
+		}
+		fatalIf(confirmRemoval(description, args.Get(1)), "Unable to remove %s", args.Get(1))
+	}
+
 	e := client.RemoveUser(globalContext, args.Get(1))
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to remove %s", args.Get(1))
 
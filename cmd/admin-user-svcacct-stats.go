@@ -0,0 +1,234 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var adminUserSvcAcctStatsFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "audit-log",
+		Usage: auditLogFlagUsage,
+	},
+	cli.StringFlag{
+		Name:  "since",
+		Usage: "only count the requests since this time, e.g. '2021-06-01T00:00' or '720h' ago",
+	},
+	cli.StringFlag{
+		Name:  "until",
+		Usage: "only count the requests until this time",
+	},
+}
+
+var adminUserSvcAcctStatsCmd = cli.Command{
+	Name:         "stats",
+	Usage:        "show the usage of a service account",
+	Action:       mainAdminUserSvcAcctStats,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminUserSvcAcctStatsFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --audit-log FILE [--audit-log FILE...] ALIAS SERVICE-ACCOUNT
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Count the requests, the bytes received and sent and the last activity of a
+   service account, from the audit entries sent by the servers to an audit
+   webhook and stored in files. The servers do not retain the audit entries,
+   so a service account without requests is only unused for the period the
+   files cover, which is reported.
+
+EXAMPLES:
+  1. Show the usage of service account 'J123C4ZXEQN8RK6ND35I' from the audit logs of a month.
+     {{.Prompt}} {{.HelpName}} --audit-log audit-2021-06.ndjson.gz myminio/ J123C4ZXEQN8RK6ND35I
+
+  2. Show the usage of a service account during the last 30 days from the audit logs read from STDIN.
+     {{.Prompt}} cat audit/*.ndjson | {{.HelpName}} --audit-log - --since 720h myminio/ J123C4ZXEQN8RK6ND35I
+`,
+}
+
+// svcAcctStatsTopAPIs is the number of APIs listed by the stats.
+const svcAcctStatsTopAPIs = 5
+
+// svcAcctAPICount is the number of requests of an API.
+type svcAcctAPICount struct {
+	API      string `json:"api"`
+	Requests int64  `json:"requests"`
+}
+
+// svcAcctStatsMessage is the usage of a service account.
+type svcAcctStatsMessage struct {
+	Status        string            `json:"status"`
+	AccessKey     string            `json:"accessKey"`
+	ParentUser    string            `json:"parentUser"`
+	AccountStatus string            `json:"accountStatus"`
+	Requests      int64             `json:"requests"`
+	Failed        int64             `json:"failed"`
+	BytesIn       int64             `json:"bytesIn"`
+	BytesOut      int64             `json:"bytesOut"`
+	FirstActivity time.Time         `json:"firstActivity,omitempty"`
+	LastActivity  time.Time         `json:"lastActivity,omitempty"`
+	LastSource    string            `json:"lastSource,omitempty"`
+	APIs          []svcAcctAPICount `json:"apis,omitempty"`
+	LogsFrom      time.Time         `json:"logsFrom,omitempty"`
+	LogsTo        time.Time         `json:"logsTo,omitempty"`
+	Skipped       int               `json:"skipped,omitempty"`
+
+	apis map[string]int64
+}
+
+// add counts a request of the service account, the period covered by the
+// logs is tracked from all the requests.
+func (s *svcAcctStatsMessage) add(r auditRecord) {
+	if s.LogsFrom.IsZero() || r.Time.Before(s.LogsFrom) {
+		s.LogsFrom = r.Time
+	}
+	if r.Time.After(s.LogsTo) {
+		s.LogsTo = r.Time
+	}
+	if r.AccessKey != s.AccessKey {
+		return
+	}
+	s.Requests++
+	if r.StatusCode >= 400 {
+		s.Failed++
+	}
+	s.BytesIn += r.RX
+	s.BytesOut += r.TX
+	if s.FirstActivity.IsZero() || r.Time.Before(s.FirstActivity) {
+		s.FirstActivity = r.Time
+	}
+	if r.Time.After(s.LastActivity) {
+		s.LastActivity = r.Time
+		s.LastSource = r.RemoteHost
+	}
+	if s.apis == nil {
+		s.apis = map[string]int64{}
+	}
+	s.apis[r.API]++
+}
+
+// topAPIs sets the most requested APIs.
+func (s *svcAcctStatsMessage) topAPIs() {
+	s.APIs = nil
+	for api, n := range s.apis {
+		s.APIs = append(s.APIs, svcAcctAPICount{API: api, Requests: n})
+	}
+	sort.Slice(s.APIs, func(i, j int) bool {
+		if s.APIs[i].Requests != s.APIs[j].Requests {
+			return s.APIs[i].Requests > s.APIs[j].Requests
+		}
+		return s.APIs[i].API < s.APIs[j].API
+	})
+	if len(s.APIs) > svcAcctStatsTopAPIs {
+		s.APIs = s.APIs[:svcAcctStatsTopAPIs]
+	}
+}
+
+func (s svcAcctStatsMessage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Access Key: %s\n", s.AccessKey)
+	fmt.Fprintf(&b, "Parent User: %s\n", s.ParentUser)
+	fmt.Fprintf(&b, "Status: %s\n", s.AccountStatus)
+	if s.Skipped > 0 {
+		fmt.Fprintf(&b, "Skipped: %d malformed audit entries\n", s.Skipped)
+	}
+	if s.LogsFrom.IsZero() {
+		b.WriteString(console.Colorize("SvcAcctStatsUnused", "No audit entry found, the usage is unknown."))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Audit Logs: %s to %s\n", s.LogsFrom.Format(printDate), s.LogsTo.Format(printDate))
+	if s.Requests == 0 {
+		b.WriteString(console.Colorize("SvcAcctStatsUnused", "No request during the period of the audit logs."))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Requests: %s (%s failed)\n", humanize.Comma(s.Requests), humanize.Comma(s.Failed))
+	fmt.Fprintf(&b, "Received: %s\n", humanize.IBytes(uint64(s.BytesIn)))
+	fmt.Fprintf(&b, "Sent: %s\n", humanize.IBytes(uint64(s.BytesOut)))
+	fmt.Fprintf(&b, "First Activity: %s\n", s.FirstActivity.Format(printDate))
+	lastActivity := s.LastActivity.Format(printDate)
+	if s.LastSource != "" {
+		lastActivity += " from " + s.LastSource
+	}
+	fmt.Fprintf(&b, "Last Activity: %s\n", console.Colorize("SvcAcctStatsActive", lastActivity))
+	apis := make([]string, 0, len(s.APIs))
+	for _, api := range s.APIs {
+		apis = append(apis, fmt.Sprintf("%s %s", api.API, humanize.Comma(api.Requests)))
+	}
+	fmt.Fprintf(&b, "Top APIs: %s", strings.Join(apis, ", "))
+	return b.String()
+}
+
+func (s svcAcctStatsMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// mainAdminUserSvcAcctStats is the handle for "mc admin user svcacct stats" command.
+func mainAdminUserSvcAcctStats(cliCtx *cli.Context) error {
+	ctx, cancelSvcAcctStats := context.WithCancel(globalContext)
+	defer cancelSvcAcctStats()
+
+	console.SetColor("SvcAcctStatsActive", color.New(color.FgGreen))
+	console.SetColor("SvcAcctStatsUnused", color.New(color.FgYellow))
+
+	logs := cliCtx.StringSlice("audit-log")
+	if len(cliCtx.Args()) != 2 || len(logs) == 0 {
+		cli.ShowCommandHelpAndExit(cliCtx, "stats", globalInvalidArgsExitStatus)
+	}
+	args := cliCtx.Args()
+	aliasedURL, svcAccount := args.Get(0), args.Get(1)
+	since := parseAdminLogsTime(cliCtx.String("since"), "since")
+	until := parseAdminLogsTime(cliCtx.String("until"), "until")
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	svcInfo, e := client.InfoServiceAccount(ctx, svcAccount)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get information of the specified service account")
+
+	stats := svcAcctStatsMessage{
+		AccessKey:     svcAccount,
+		ParentUser:    svcInfo.ParentUser,
+		AccountStatus: svcInfo.AccountStatus,
+	}
+	stats.Skipped, err = readAuditLogs(ctx, logs, since, until, stats.add)
+	fatalIf(err, "Unable to read the audit logs.")
+	stats.topAPIs()
+
+	printMsg(stats)
+	return nil
+}
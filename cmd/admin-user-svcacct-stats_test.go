@@ -0,0 +1,59 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSvcAcctStats(t *testing.T) {
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	stats := svcAcctStatsMessage{AccessKey: "svc1"}
+	records := []auditRecord{
+		{Time: day.Add(time.Hour), AccessKey: "other", API: "GetObject"},
+		{Time: day.Add(2 * time.Hour), AccessKey: "svc1", API: "GetObject", TX: 100, StatusCode: 200, RemoteHost: "10.0.0.1"},
+		{Time: day.Add(4 * time.Hour), AccessKey: "svc1", API: "PutObject", RX: 50, StatusCode: 200, RemoteHost: "10.0.0.2"},
+		{Time: day.Add(3 * time.Hour), AccessKey: "svc1", API: "GetObject", StatusCode: 404},
+		{Time: day.Add(5 * time.Hour), AccessKey: "other", API: "PutObject"},
+	}
+	for _, r := range records {
+		stats.add(r)
+	}
+	stats.topAPIs()
+
+	if stats.Requests != 3 || stats.Failed != 1 || stats.BytesIn != 50 || stats.BytesOut != 100 {
+		t.Fatalf("unexpected counts %+v", stats)
+	}
+	if !stats.FirstActivity.Equal(day.Add(2*time.Hour)) || !stats.LastActivity.Equal(day.Add(4*time.Hour)) || stats.LastSource != "10.0.0.2" {
+		t.Fatalf("unexpected activity %v - %v from %s", stats.FirstActivity, stats.LastActivity, stats.LastSource)
+	}
+	if !stats.LogsFrom.Equal(day.Add(time.Hour)) || !stats.LogsTo.Equal(day.Add(5*time.Hour)) {
+		t.Fatalf("unexpected period of the logs %v - %v", stats.LogsFrom, stats.LogsTo)
+	}
+	if len(stats.APIs) != 2 || stats.APIs[0] != (svcAcctAPICount{API: "GetObject", Requests: 2}) {
+		t.Fatalf("unexpected APIs %v", stats.APIs)
+	}
+
+	unused := svcAcctStatsMessage{AccessKey: "svc2"}
+	for _, r := range records {
+		unused.add(r)
+	}
+	if unused.Requests != 0 || !unused.LastActivity.IsZero() || unused.LogsTo.IsZero() {
+		t.Fatalf("unexpected stats of an unused service account %+v", unused)
+	}
+}
@@ -26,6 +26,7 @@ var adminUserSvcAcctSubcommands = []cli.Command{
 	adminUserSvcAcctSetCmd,
 	adminUserSvcAcctEnableCmd,
 	adminUserSvcAcctDisableCmd,
+	adminUserSvcAcctStatsCmd,
 }
 
 var adminUserSvcAcctCmd = cli.Command{
@@ -0,0 +1,213 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var aliasExportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "format",
+		Value: aliasExportEnv,
+		Usage: "export format, 'env' or 'rclone'",
+	},
+}
+
+var aliasExportCmd = cli.Command{
+	Name:            "export",
+	Usage:           "export aliases for other tools",
+	Action:          mainAliasExport,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasExportFlags, withoutFlag(globalFlags, "format")...),
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [ALIAS...]
+
+FORMATS:
+  env     one MC_HOST_<alias> shell variable per alias, the default
+  rclone  one rclone remote of type 's3' per alias, to be added to 'rclone.conf'
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+Aliases are exported with their secret keys, keep the output safe.
+
+EXAMPLES:
+  1. Add all aliases as rclone remotes.
+     {{.Prompt}} {{.HelpName}} --format rclone >> ~/.config/rclone/rclone.conf
+
+  2. Export the "myminio" alias to the environment of another shell.
+     {{.Prompt}} eval "$({{.HelpName}} myminio)"
+`,
+}
+
+const (
+	aliasExportRclone = "rclone"
+	aliasExportEnv    = "env"
+)
+
+// aliasExportMessage holds one exported alias.
+type aliasExportMessage struct {
+	Status  string `json:"status"`
+	Alias   string `json:"alias"`
+	Format  string `json:"format"`
+	Content string `json:"content"`
+}
+
+func (a aliasExportMessage) String() string {
+	return a.Content
+}
+
+func (a aliasExportMessage) JSON() string {
+	a.Status = "success"
+	jsonMessageBytes, e := json.Marshal(a)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// rcloneProvider returns the rclone S3 provider of an endpoint.
+func rcloneProvider(u *url.URL) string {
+	switch {
+	case strings.HasSuffix(u.Hostname(), ".amazonaws.com"):
+		return "AWS"
+	case u.Hostname() == "storage.googleapis.com":
+		return "GCS"
+	}
+	return "Other"
+}
+
+// exportAliasRclone formats an alias as an rclone remote.
+func exportAliasRclone(alias string, aliasCfg aliasConfigV10) string {
+	u, e := url.Parse(aliasCfg.URL)
+	if e != nil {
+		u = &url.URL{}
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s]\n", alias)
+	fmt.Fprintf(&b, "type = s3\n")
+	fmt.Fprintf(&b, "provider = %s\n", rcloneProvider(u))
+	fmt.Fprintf(&b, "access_key_id = %s\n", aliasCfg.AccessKey)
+	fmt.Fprintf(&b, "secret_access_key = %s\n", aliasCfg.SecretKey)
+	if aliasCfg.SessionToken != "" {
+		fmt.Fprintf(&b, "session_token = %s\n", aliasCfg.SessionToken)
+	}
+	fmt.Fprintf(&b, "endpoint = %s\n", aliasCfg.URL)
+	// rclone defaults to path style, so virtual host style must be explicit.
+	switch aliasCfg.Path {
+	case "on":
+		fmt.Fprintf(&b, "force_path_style = true\n")
+	case "off":
+		fmt.Fprintf(&b, "force_path_style = false\n")
+	}
+	if strings.EqualFold(aliasCfg.API, "S3v2") {
+		fmt.Fprintf(&b, "v2_auth = true\n")
+	}
+	return b.String()
+}
+
+// exportAliasEnv formats an alias as the MC_HOST_<alias> variable.
+func exportAliasEnv(alias string, aliasCfg aliasConfigV10) string {
+	value := aliasEnvValue(aliasCfg)
+	if value == "" {
+		return ""
+	}
+	// Quote for the shell, embedded single quotes are closed and escaped.
+	return "export " + mcEnvHostPrefix + alias + "='" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+// aliasEnvValue returns the value of the MC_HOST_<alias> variable of
+// an alias, with its credentials.
+func aliasEnvValue(aliasCfg aliasConfigV10) string {
+	u, e := url.Parse(aliasCfg.URL)
+	if e != nil {
+		return ""
+	}
+	value := u.Scheme + "://" + u.Host
+	// Anonymous aliases have no credentials.
+	if aliasCfg.AccessKey != "" || aliasCfg.SecretKey != "" {
+		credentials := aliasCfg.AccessKey + ":" + aliasCfg.SecretKey
+		if aliasCfg.SessionToken != "" {
+			credentials += ":" + aliasCfg.SessionToken
+		}
+		value = u.Scheme + "://" + credentials + "@" + u.Host
+	}
+	return value
+}
+
+// checkAliasExportSyntax - verifies input arguments to 'alias export'.
+func checkAliasExportSyntax(ctx *cli.Context) {
+	switch format := ctx.String("format"); format {
+	case aliasExportRclone, aliasExportEnv:
+	default:
+		fatalIf(errInvalidArgument().Trace(format), "Unsupported export format `"+format+"`, valid options are `[env, rclone]`.")
+	}
+}
+
+// mainAliasExport is the handle for "mc alias export" command.
+func mainAliasExport(ctx *cli.Context) error {
+	checkAliasExportSyntax(ctx)
+
+	format := ctx.String("format")
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	aliases := []string(ctx.Args())
+	if len(aliases) == 0 {
+		for alias, aliasCfg := range conf.Aliases {
+			// Skip the default entries which were never configured.
+			if aliasCfg.AccessKey == defaultAccessKey {
+				continue
+			}
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+	}
+
+	for _, alias := range aliases {
+		alias = cleanAlias(alias)
+		aliasCfg, err := getAliasConfig(alias)
+		fatalIf(err, "Unable to export `"+alias+"`.")
+
+		msg := aliasExportMessage{Alias: alias, Format: format}
+		switch format {
+		case aliasExportRclone:
+			msg.Content = exportAliasRclone(alias, *aliasCfg)
+		case aliasExportEnv:
+			// Shell variable names can not have dashes.
+			if strings.Contains(alias, "-") {
+				errorIf(errInvalidAlias(alias).Trace(), "Skipping `"+alias+"`, it is not a valid shell variable name.")
+				continue
+			}
+			msg.Content = exportAliasEnv(alias, *aliasCfg)
+		}
+		printMsg(msg)
+	}
+	return nil
+}
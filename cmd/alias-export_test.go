@@ -0,0 +1,160 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// runAliasExport runs 'alias export' with args and returns the exported
+// content of each alias.
+func runAliasExport(t *testing.T, args ...string) map[string]string {
+	var buf bytes.Buffer
+	color.Output = &buf
+	app := cli.NewApp()
+	app.Commands = []cli.Command{{
+		Name:   "export",
+		Flags:  aliasExportFlags,
+		Action: mainAliasExport,
+	}}
+	if e := app.Run(append([]string{"mc", "export"}, args...)); e != nil {
+		t.Fatal(e)
+	}
+	exported := map[string]string{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var msg aliasExportMessage
+		if e := decoder.Decode(&msg); e != nil {
+			t.Fatal(e)
+		}
+		exported[msg.Alias] = msg.Content
+	}
+	return exported
+}
+
+func TestAliasExportImport(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		t.Skip("the keychain stand-in is a secret-tool shell script")
+	}
+	dir, e := ioutil.TempDir("", "alias-export")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", dir)
+	// A keychain holding the secret key of every alias.
+	script := "#!/bin/sh\n[ \"$1\" = lookup ] && echo 'keychain/secret'\n"
+	if e = ioutil.WriteFile(filepath.Join(dir, "secret-tool"), []byte(script), 0755); e != nil {
+		t.Fatal(e)
+	}
+
+	defer func(load func() (*configV10, *probe.Error), output io.Writer, isJSON bool) {
+		loadMcConfig = load
+		color.Output = output
+		globalJSON = isJSON
+	}(loadMcConfig, color.Output, globalJSON)
+	globalJSON = true
+
+	aliases := map[string]aliasConfigV10{
+		"plain":    {URL: "https://plain.example.com", AccessKey: "access", SecretKey: "plain'secret", API: "S3v4", Path: "auto"},
+		"sts":      {URL: "http://localhost:9000", AccessKey: "access", SecretKey: "secret", SessionToken: "token", API: "S3v4", Path: "on"},
+		"keychain": {URL: "https://keychain.example.com", AccessKey: "access", CredentialStore: credentialStoreKeychain, API: "S3v4", Path: "auto"},
+		"anon":     {URL: "https://anon.example.com", API: "S3v4", Path: "auto"},
+	}
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		conf := newConfigV10()
+		for alias, aliasCfg := range aliases {
+			conf.Aliases[alias] = aliasCfg
+		}
+		return conf, nil
+	}
+
+	exported := runAliasExport(t)
+	if len(exported) != len(aliases) {
+		t.Fatalf("expected %d aliases, got %v", len(aliases), exported)
+	}
+
+	// Import the exported variables in the environment of mc, the
+	// aliases are no longer in the config.
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		return newConfigV10(), nil
+	}
+	for alias, content := range exported {
+		prefix := "export " + mcEnvHostPrefix + alias + "='"
+		if !strings.HasPrefix(content, prefix) || !strings.HasSuffix(content, "'") {
+			t.Fatalf("%s: unexpected export %s", alias, content)
+		}
+		value := strings.Replace(strings.TrimSuffix(strings.TrimPrefix(content, prefix), "'"), `'\''`, "'", -1)
+		defer os.Unsetenv(mcEnvHostPrefix + alias)
+		os.Setenv(mcEnvHostPrefix+alias, value)
+	}
+	for alias, expected := range aliases {
+		if expected.CredentialStore == credentialStoreKeychain {
+			expected.SecretKey = "keychain/secret"
+		}
+		aliasCfg := mustGetHostConfig(alias)
+		if aliasCfg == nil {
+			t.Errorf("%s: alias not imported", alias)
+			continue
+		}
+		if aliasCfg.URL != expected.URL || aliasCfg.AccessKey != expected.AccessKey ||
+			aliasCfg.SecretKey != expected.SecretKey || aliasCfg.SessionToken != expected.SessionToken {
+			t.Errorf("%s: expected %+v, got %+v", alias, expected, *aliasCfg)
+		}
+	}
+}
+
+func TestAliasExportRclone(t *testing.T) {
+	defer func(load func() (*configV10, *probe.Error), output io.Writer, isJSON bool) {
+		loadMcConfig = load
+		color.Output = output
+		globalJSON = isJSON
+	}(loadMcConfig, color.Output, globalJSON)
+	globalJSON = true
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		conf := newConfigV10()
+		conf.Aliases["s3"] = aliasConfigV10{URL: "https://s3.amazonaws.com", AccessKey: "access", SecretKey: "secret", API: "S3v4", Path: "off"}
+		conf.Aliases["my-minio"] = aliasConfigV10{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "minio123", API: "S3v2", Path: "auto"}
+		return conf, nil
+	}
+
+	exported := runAliasExport(t, "--format", "rclone", "s3", "my-minio")
+	expected := map[string]string{
+		"s3": "[s3]\ntype = s3\nprovider = AWS\naccess_key_id = access\nsecret_access_key = secret\n" +
+			"endpoint = https://s3.amazonaws.com\nforce_path_style = false\n",
+		"my-minio": "[my-minio]\ntype = s3\nprovider = Other\naccess_key_id = minio\nsecret_access_key = minio123\n" +
+			"endpoint = http://localhost:9000\nv2_auth = true\n",
+	}
+	for alias, content := range expected {
+		if exported[alias] != content {
+			t.Errorf("%s: expected\n%s\ngot\n%s", alias, content, exported[alias])
+		}
+	}
+}
@@ -0,0 +1,304 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	"github.com/mitchellh/go-homedir"
+)
+
+var aliasImportFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "aws-profile",
+		Usage: "import only the given AWS CLI profile, may be repeated. Defaults to all profiles",
+	},
+	cli.StringFlag{
+		Name:  "prefix",
+		Usage: "prefix the alias names with the given string",
+	},
+}
+
+var aliasImportCmd = cli.Command{
+	Name:            "import",
+	Usage:           "import aliases from other tools",
+	Action:          mainAliasImport,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasImportFlags, globalFlags...),
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} SOURCE [FLAGS]
+
+SOURCE:
+  aws  profiles of the AWS CLI in '~/.aws/credentials' and '~/.aws/config'
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  AWS_SHARED_CREDENTIALS_FILE  location of the AWS CLI credentials file
+  AWS_CONFIG_FILE              location of the AWS CLI config file
+
+Running the import again updates the aliases with the current
+content of the AWS CLI files.
+
+EXAMPLES:
+  1. Import all profiles of the AWS CLI as aliases named after the profiles.
+     {{.Prompt}} {{.HelpName}} aws
+
+  2. Import the "backup" profile of the AWS CLI as "aws-backup" alias.
+     {{.Prompt}} {{.HelpName}} aws --aws-profile backup --prefix aws-
+`,
+}
+
+// awsProfile holds the settings of an AWS CLI profile, from
+// both the credentials and the config file.
+type awsProfile struct {
+	Name         string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Region       string
+	EndpointURL  string
+}
+
+// endpoint returns the S3 endpoint of the profile.
+func (p awsProfile) endpoint() string {
+	if p.EndpointURL != "" {
+		return p.EndpointURL
+	}
+	if p.Region != "" && p.Region != "us-east-1" {
+		return "https://s3." + p.Region + ".amazonaws.com"
+	}
+	return "https://s3.amazonaws.com"
+}
+
+// parseAWSIni parses an AWS CLI credentials or config file into its
+// sections. Indented lines below an empty valued key are nested
+// settings, such as 's3 =' followed by '  endpoint_url = ...', they
+// are stored as 's3.endpoint_url'.
+func parseAWSIni(r io.Reader) (map[string]map[string]string, error) {
+	sections := make(map[string]map[string]string)
+	var section map[string]string
+	var parent string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			name := strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			section, parent = sections[name], ""
+			continue
+		}
+		i := strings.Index(trimmed, "=")
+		if section == nil || i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(trimmed[:i])
+		value := strings.TrimSpace(trimmed[i+1:])
+		nested := line != strings.TrimLeft(line, " \t")
+		switch {
+		case nested && parent != "":
+			section[parent+"."+key] = value
+		case value == "":
+			parent = key
+		default:
+			section[key], parent = value, ""
+		}
+	}
+	return sections, scanner.Err()
+}
+
+// awsCLIFile returns the path of an AWS CLI file, env overrides the default location.
+func awsCLIFile(env, name string) (string, *probe.Error) {
+	if path := os.Getenv(env); path != "" {
+		return path, nil
+	}
+	homeDir, e := homedir.Dir()
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return filepath.Join(homeDir, ".aws", name), nil
+}
+
+// loadAWSIni parses an AWS CLI file, a missing file has no sections.
+func loadAWSIni(path string) (map[string]map[string]string, *probe.Error) {
+	f, e := os.Open(path)
+	if os.IsNotExist(e) {
+		return nil, nil
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+	sections, e := parseAWSIni(f)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	return sections, nil
+}
+
+// mergeAWSProfiles combines the credentials and config files, the
+// credentials file wins when both set the keys of a profile.
+func mergeAWSProfiles(credentials, config map[string]map[string]string) map[string]awsProfile {
+	profiles := make(map[string]awsProfile)
+	apply := func(name string, kv map[string]string) {
+		p := profiles[name]
+		p.Name = name
+		set := func(dst *string, keys ...string) {
+			for _, k := range keys {
+				if v, ok := kv[k]; ok && v != "" {
+					*dst = v
+					return
+				}
+			}
+		}
+		set(&p.AccessKey, "aws_access_key_id")
+		set(&p.SecretKey, "aws_secret_access_key")
+		set(&p.SessionToken, "aws_session_token")
+		set(&p.Region, "region")
+		set(&p.EndpointURL, "s3.endpoint_url", "endpoint_url")
+		profiles[name] = p
+	}
+	for section, kv := range config {
+		// Profiles other than the default one are named 'profile NAME' in the config file.
+		name := strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+		if section != "default" && name == section {
+			continue
+		}
+		apply(name, kv)
+	}
+	for name, kv := range credentials {
+		apply(name, kv)
+	}
+	return profiles
+}
+
+// loadAWSProfiles reads the profiles of the AWS CLI.
+func loadAWSProfiles() (map[string]awsProfile, *probe.Error) {
+	credsPath, err := awsCLIFile("AWS_SHARED_CREDENTIALS_FILE", "credentials")
+	if err != nil {
+		return nil, err.Trace()
+	}
+	configPath, err := awsCLIFile("AWS_CONFIG_FILE", "config")
+	if err != nil {
+		return nil, err.Trace()
+	}
+	credentials, err := loadAWSIni(credsPath)
+	if err != nil {
+		return nil, err.Trace(credsPath)
+	}
+	config, err := loadAWSIni(configPath)
+	if err != nil {
+		return nil, err.Trace(configPath)
+	}
+	return mergeAWSProfiles(credentials, config), nil
+}
+
+// checkAliasImportSyntax - verifies input arguments to 'alias import'.
+func checkAliasImportSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "import", globalInvalidArgsExitStatus)
+	}
+	if args.Get(0) != "aws" {
+		fatalIf(errInvalidArgument().Trace(args.Get(0)), "Unsupported import source `"+args.Get(0)+"`, valid options are `[aws]`.")
+	}
+}
+
+// mainAliasImport is the handle for "mc alias import" command.
+func mainAliasImport(ctx *cli.Context) error {
+	checkAliasImportSyntax(ctx)
+
+	console.SetColor("AliasMessage", color.New(color.FgGreen))
+
+	profiles, err := loadAWSProfiles()
+	fatalIf(err, "Unable to read the AWS CLI profiles.")
+
+	names := ctx.StringSlice("aws-profile")
+	if len(names) == 0 {
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	var msgs []aliasMessage
+	for _, name := range names {
+		p, ok := profiles[name]
+		if !ok {
+			fatalIf(errInvalidArgument().Trace(name), "No such AWS CLI profile `"+name+"` found.")
+		}
+		if p.AccessKey == "" || p.SecretKey == "" {
+			// Profiles using SSO, roles or credential processes have no static keys.
+			errorIf(errInvalidArgument().Trace(name), "Skipping AWS CLI profile `"+name+"` without static credentials.")
+			continue
+		}
+		alias := ctx.String("prefix") + name
+		if !isValidAlias(alias) {
+			errorIf(errInvalidAlias(alias).Trace(name), "Skipping AWS CLI profile `"+name+"`, `"+alias+"` is not a valid alias.")
+			continue
+		}
+		aliasCfg := aliasConfigV10{
+			URL:          p.endpoint(),
+			AccessKey:    p.AccessKey,
+			SecretKey:    p.SecretKey,
+			SessionToken: p.SessionToken,
+			API:          "S3v4",
+			Path:         "auto",
+			// Keep the default flags of an existing alias.
+			Defaults: conf.Aliases[alias].Defaults,
+		}
+		// Keep the secret key of an alias already in the keychain there.
+		if conf.Aliases[alias].CredentialStore == credentialStoreKeychain {
+			fatalIf(moveSecretToKeychain(alias, &aliasCfg), "Unable to store the secret key of `"+alias+"` in the keychain.")
+		}
+		conf.Aliases[alias] = aliasCfg
+		msgs = append(msgs, aliasMessage{op: "import", Alias: alias, URL: aliasCfg.URL})
+	}
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(), "Unable to update hosts in config version `"+mustGetMcConfigPath()+"`.")
+
+	for _, msg := range msgs {
+		printMsg(msg)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadAWSProfiles(t *testing.T) {
+	credentials := `
+[default]
+aws_access_key_id = AKIADEFAULT
+aws_secret_access_key = default/secret
+
+# Keys of the backup account
+[backup]
+aws_access_key_id=AKIABACKUP
+aws_secret_access_key=backup/secret
+aws_session_token=token
+`
+	config := `
+[default]
+region = us-east-1
+
+[profile backup]
+region = eu-west-1
+
+[profile minio]
+aws_access_key_id = minio
+aws_secret_access_key = minio123
+s3 =
+  endpoint_url = http://localhost:9000
+  signature_version = s3v4
+
+[sso-session corp]
+sso_region = us-east-1
+`
+	credsSections, e := parseAWSIni(strings.NewReader(credentials))
+	if e != nil {
+		t.Fatal(e)
+	}
+	configSections, e := parseAWSIni(strings.NewReader(config))
+	if e != nil {
+		t.Fatal(e)
+	}
+	profiles := mergeAWSProfiles(credsSections, configSections)
+
+	testCases := []struct {
+		name      string
+		accessKey string
+		secretKey string
+		token     string
+		endpoint  string
+	}{
+		{"default", "AKIADEFAULT", "default/secret", "", "https://s3.amazonaws.com"},
+		{"backup", "AKIABACKUP", "backup/secret", "token", "https://s3.eu-west-1.amazonaws.com"},
+		{"minio", "minio", "minio123", "", "http://localhost:9000"},
+	}
+	if len(profiles) != len(testCases) {
+		t.Fatalf("expected %d profiles, got %v", len(testCases), profiles)
+	}
+	for i, testCase := range testCases {
+		p, ok := profiles[testCase.name]
+		if !ok {
+			t.Fatalf("Test %d: profile %s not found", i+1, testCase.name)
+		}
+		if p.AccessKey != testCase.accessKey || p.SecretKey != testCase.secretKey || p.SessionToken != testCase.token {
+			t.Errorf("Test %d: unexpected credentials %+v", i+1, p)
+		}
+		if p.endpoint() != testCase.endpoint {
+			t.Errorf("Test %d: expected endpoint %s, got %s", i+1, testCase.endpoint, p.endpoint())
+		}
+	}
+}
@@ -129,6 +129,17 @@ func listAliases(alias string, deprecated bool) (aliases []aliasMessage) {
 				AccessKey:   v.AccessKey,
 				SecretKey:   v.SecretKey,
 				API:         v.API,
+
+				CredentialStore:    v.CredentialStore,
+				Proxy:              v.Proxy,
+				CACert:             v.CACert,
+				ClientCert:         v.ClientCert,
+				ClientKey:          v.ClientKey,
+				RequestPayer:       v.RequestPayer,
+				Tuning:             v.Tuning,
+				PartSize:           v.PartSize,
+				MultipartThreshold: v.MultipartThreshold,
+				Defaults:           v.Defaults,
 			}
 
 			if deprecated {
@@ -150,6 +161,17 @@ func listAliases(alias string, deprecated bool) (aliases []aliasMessage) {
 			AccessKey:   v.AccessKey,
 			SecretKey:   v.SecretKey,
 			API:         v.API,
+
+			CredentialStore:    v.CredentialStore,
+			Proxy:              v.Proxy,
+			CACert:             v.CACert,
+			ClientCert:         v.ClientCert,
+			ClientKey:          v.ClientKey,
+			RequestPayer:       v.RequestPayer,
+			Tuning:             v.Tuning,
+			PartSize:           v.PartSize,
+			MultipartThreshold: v.MultipartThreshold,
+			Defaults:           v.Defaults,
 		}
 
 		if deprecated {
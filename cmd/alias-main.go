@@ -17,6 +17,9 @@
 package cmd
 
 import (
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/cli"
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/probe"
@@ -42,6 +45,10 @@ var aliasSubcommands = []cli.Command{
 	aliasSetCmd,
 	aliasListCmd,
 	aliasRemoveCmd,
+	aliasMigrateCmd,
+	aliasImportCmd,
+	aliasExportCmd,
+	aliasTestCmd,
 }
 
 var aliasCmd = cli.Command{
@@ -72,6 +79,17 @@ type aliasMessage struct {
 	SecretKey   string `json:"secretKey,omitempty"`
 	API         string `json:"api,omitempty"`
 	Path        string `json:"path,omitempty"`
+	// Set when the secret key is kept in the OS keychain
+	CredentialStore    string            `json:"credentialStore,omitempty"`
+	Proxy              string            `json:"proxy,omitempty"`
+	CACert             string            `json:"caCert,omitempty"`
+	ClientCert         string            `json:"clientCert,omitempty"`
+	ClientKey          string            `json:"clientKey,omitempty"`
+	RequestPayer       string            `json:"requestPayer,omitempty"`
+	Tuning             *transportTuning  `json:"tuning,omitempty"`
+	PartSize           uint64            `json:"partSize,omitempty"`
+	MultipartThreshold uint64            `json:"multipartThreshold,omitempty"`
+	Defaults           map[string]string `json:"defaults,omitempty"`
 	// Deprecated field, replaced by Path
 	Lookup string `json:"lookup,omitempty"`
 }
@@ -82,27 +100,68 @@ type aliasMessage struct {
 func (h aliasMessage) String() string {
 	switch h.op {
 	case "list":
-		// Create a new pretty table with cols configuration
-		t := newPrettyRecord(2,
-			Row{"Alias", "Alias"},
-			Row{"URL", "URL"},
-			Row{"AccessKey", "AccessKey"},
-			Row{"SecretKey", "SecretKey"},
-			Row{"API", "API"},
-			Row{"Path", "Path"},
-		)
+		rows := []Row{
+			{"Alias", "Alias"},
+			{"URL", "URL"},
+			{"AccessKey", "AccessKey"},
+			{"SecretKey", "SecretKey"},
+			{"API", "API"},
+			{"Path", "Path"},
+		}
 		// Handle deprecated lookup
 		path := h.Path
 		if path == "" {
 			path = h.Lookup
 		}
-		return t.buildRecord(h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, path)
+		secretKey := h.SecretKey
+		if h.CredentialStore == credentialStoreKeychain {
+			secretKey = "(keychain)"
+		}
+		contents := []string{h.Alias, h.URL, h.AccessKey, secretKey, h.API, path}
+		var tuning, partSize, threshold string
+		if h.Tuning != nil {
+			tuning = h.Tuning.String()
+		}
+		if h.PartSize > 0 {
+			partSize = humanize.IBytes(h.PartSize)
+		}
+		if h.MultipartThreshold > 0 {
+			threshold = humanize.IBytes(h.MultipartThreshold)
+		}
+		var defaults []string
+		for _, name := range sortedDefaults(h.Defaults) {
+			defaults = append(defaults, name+"="+h.Defaults[name])
+		}
+		// Transport settings are only shown when set.
+		for _, opt := range []struct{ name, value string }{
+			{"Proxy", h.Proxy},
+			{"CACert", h.CACert},
+			{"ClientCert", h.ClientCert},
+			{"ClientKey", h.ClientKey},
+			{"RequestPayer", h.RequestPayer},
+			{"Tuning", tuning},
+			{"PartSize", partSize},
+			{"MultipartThreshold", threshold},
+			{"Defaults", strings.Join(defaults, " ")},
+		} {
+			if opt.value != "" {
+				rows = append(rows, Row{opt.name, opt.name})
+				contents = append(contents, opt.value)
+			}
+		}
+		// Create a new pretty table with cols configuration
+		t := newPrettyRecord(2, rows...)
+		return t.buildRecord(contents...)
 	case "remove":
 		return console.Colorize("AliasMessage", "Removed `"+h.Alias+"` successfully.")
 	case "add": // add is deprecated
 		fallthrough
 	case "set":
 		return console.Colorize("AliasMessage", "Added `"+h.Alias+"` successfully.")
+	case "import":
+		return console.Colorize("AliasMessage", "Imported `"+h.Alias+"` ("+h.URL+") successfully.")
+	case "migrate":
+		return console.Colorize("AliasMessage", "Moved the secret key of `"+h.Alias+"` to the keychain successfully.")
 	default:
 		return ""
 	}
@@ -0,0 +1,119 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var aliasMigrateFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "all",
+		Usage: "migrate all aliases which keep their secret key in the configuration file",
+	},
+}
+
+var aliasMigrateCmd = cli.Command{
+	Name:            "migrate",
+	Usage:           "move secret keys from configuration file to the OS keychain",
+	Action:          mainAliasMigrate,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasMigrateFlags, globalFlags...),
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [--all | ALIAS...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Move the secret key of "myminio" alias to the OS keychain.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Move the secret keys of all aliases to the OS keychain.
+     {{.Prompt}} {{.HelpName}} --all
+`,
+}
+
+// checkAliasMigrateSyntax - verifies input arguments to 'alias migrate'.
+func checkAliasMigrateSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if ctx.Bool("all") == (len(args) > 0) {
+		fatalIf(errInvalidArgument().Trace(args...),
+			"Pass either `--all` or one or more aliases to migrate.")
+	}
+	for _, arg := range args {
+		alias := cleanAlias(arg)
+		if !isValidAlias(alias) {
+			fatalIf(errInvalidAlias(alias), "Invalid alias `"+alias+"`.")
+		}
+	}
+}
+
+// mainAliasMigrate is the handle for "mc alias migrate" command.
+func mainAliasMigrate(ctx *cli.Context) error {
+	checkAliasMigrateSyntax(ctx)
+
+	console.SetColor("AliasMessage", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	var aliases []string
+	if ctx.Bool("all") {
+		for alias, aliasCfg := range conf.Aliases {
+			if aliasCfg.CredentialStore != credentialStoreKeychain && aliasCfg.SecretKey != "" {
+				aliases = append(aliases, alias)
+			}
+		}
+		sort.Strings(aliases)
+	} else {
+		for _, arg := range ctx.Args() {
+			alias := cleanAlias(arg)
+			aliasCfg, ok := conf.Aliases[alias]
+			if !ok {
+				fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
+			}
+			if aliasCfg.CredentialStore == credentialStoreKeychain {
+				fatalIf(errInvalidArgument().Trace(alias), "Secret key of `"+alias+"` is already in the keychain.")
+			}
+			aliases = append(aliases, alias)
+		}
+	}
+
+	for _, alias := range aliases {
+		aliasCfg := conf.Aliases[alias]
+		fatalIf(moveSecretToKeychain(alias, &aliasCfg), "Unable to store the secret key of `"+alias+"` in the keychain.")
+		conf.Aliases[alias] = aliasCfg
+
+		// Save after each alias so the config never references
+		// a secret key which is missing from the keychain.
+		err = saveMcConfig(conf)
+		fatalIf(err.Trace(alias), "Unable to update hosts in config version `"+mustGetMcConfigPath()+"`.")
+
+		printMsg(aliasMessage{op: "migrate", Alias: alias})
+	}
+	return nil
+}
@@ -19,6 +19,8 @@ package cmd
 import (
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
 )
 
@@ -84,6 +86,11 @@ func removeAlias(alias string) aliasMessage {
 	conf, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
 
+	// Remove the secret key from the keychain as well.
+	if conf.Aliases[alias].CredentialStore == credentialStoreKeychain {
+		errorIf(probe.NewError(mcconfig.KeychainRemove(keychainAccount(alias))).Trace(alias), "Unable to remove the secret key from the keychain.")
+	}
+
 	// Remove the alias from the config.
 	delete(conf.Aliases, alias)
 
@@ -19,9 +19,12 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -37,13 +40,37 @@ const cred = "YellowItalics"
 
 var aliasSetFlags = []cli.Flag{
 	cli.StringFlag{
-		Name:  "path",
+		Name:  "path-style, path",
 		Value: "auto",
 		Usage: "bucket path lookup supported by the server. Valid options are '[auto, on, off]'",
 	},
+	cli.StringFlag{
+		Name:  "signature",
+		Usage: "request signature version. Valid options are '[v4, v2]'",
+	},
 	cli.StringFlag{
 		Name:  "api",
-		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
+		Usage: "API signature, same as '--signature'. Valid options are '[S3v4, S3v2]'",
+	},
+	cli.BoolFlag{
+		Name:  "keychain",
+		Usage: "store the secret key in the OS keychain instead of the configuration file",
+	},
+	cli.StringFlag{
+		Name:  "proxy",
+		Usage: "HTTP(S) proxy URL for this alias, instead of the proxy environment variables",
+	},
+	cli.StringFlag{
+		Name:  "ca-cert",
+		Usage: "PEM file of the CA certificate(s) trusted for this alias, in addition to the system and '~/.mc/certs/CAs' ones",
+	},
+	cli.StringFlag{
+		Name:  "client-cert",
+		Usage: "PEM file of the TLS client certificate of this alias",
+	},
+	cli.StringFlag{
+		Name:  "client-key",
+		Usage: "PEM file of the private key of the TLS client certificate",
 	},
 }
 
@@ -95,6 +122,33 @@ EXAMPLES:
      {{.Prompt}} echo -e "BKIKJAA5BMMU2RHO6IBB\nV8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12" | \
                  {{.HelpName}} mys3 https://s3.amazonaws.com --api "s3v4" --path "off"
      {{.EnableHistory}}
+
+  6. Add MinIO service under "myminio" alias, keeping the secret key in the OS keychain.
+     {{.Prompt}} {{.HelpName}} --keychain myminio http://localhost:9000
+     Enter Access Key: minio
+     Enter Secret Key: minio123
+
+  7. Add MinIO service reached through a proxy, with a private CA and a TLS client certificate.
+     {{.Prompt}} {{.HelpName}} --proxy http://proxy.example.com:3128 --ca-cert ~/certs/ca.crt \
+                 --client-cert ~/certs/client.crt --client-key ~/certs/client.key \
+                 myminio https://minio.example.com minio minio123
+
+  8. Add an older S3 compatible appliance which only supports path-style requests signed with v2.
+     {{.Prompt}} {{.HelpName}} --path-style on --signature v2 legacy http://192.168.1.60:8080
+     Enter Access Key: legacy
+     Enter Secret Key: legacy123
+
+  9. Add Amazon S3 under "mys3" alias, paying for the requests to requester pays buckets.
+     {{.Prompt}} {{.HelpName}} --request-payer requester mys3 https://s3.amazonaws.com
+     Enter Access Key: BKIKJAA5BMMU2RHO6IBB
+     Enter Secret Key: V8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12
+
+ 10. Add a remote MinIO service reached over a high latency link, with large TCP buffers and more connections.
+     {{.Prompt}} {{.HelpName}} --tcp-buffer 16MiB --max-conns-per-host 64 --max-idle-conns-per-host 64 \
+                 remote https://minio.example.com minio minio123
+
+ 11. Add an object store preferring uploads in 64MiB parts, and single requests up to 256MiB.
+     {{.Prompt}} {{.HelpName}} --part-size 64MiB --multipart-threshold 256MiB archive https://archive.example.com
 `,
 }
 
@@ -136,6 +190,17 @@ func checkAliasSetSyntax(ctx *cli.Context, accessKey string, secretKey string, d
 			"Unrecognized API signature. Valid options are `[S3v4, S3v2]`.")
 	}
 
+	if signature := ctx.String("signature"); signature != "" {
+		if !isValidSignature(signature) {
+			fatalIf(errInvalidArgument().Trace(signature),
+				"Unrecognized signature version. Valid options are `[v4, v2]`.")
+		}
+		if api != "" && !strings.EqualFold(api, signatureAPI(signature)) {
+			fatalIf(errInvalidArgument().Trace(api, signature),
+				"Conflicting `--api` and `--signature` values.")
+		}
+	}
+
 	if deprecated {
 		if !isValidLookup(bucketLookup) {
 			fatalIf(errInvalidArgument().Trace(bucketLookup),
@@ -154,6 +219,11 @@ func setAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 	mcCfgV10, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
 
+	// Keep the default flags when an alias is set again.
+	if aliasCfgV10.Defaults == nil {
+		aliasCfgV10.Defaults = mcCfgV10.Aliases[alias].Defaults
+	}
+
 	// Add new host.
 	mcCfgV10.Aliases[alias] = aliasCfgV10
 
@@ -167,22 +237,27 @@ func setAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 		SecretKey: aliasCfgV10.SecretKey,
 		API:       aliasCfgV10.API,
 		Path:      aliasCfgV10.Path,
+
+		CredentialStore:    aliasCfgV10.CredentialStore,
+		Proxy:              aliasCfgV10.Proxy,
+		CACert:             aliasCfgV10.CACert,
+		ClientCert:         aliasCfgV10.ClientCert,
+		ClientKey:          aliasCfgV10.ClientKey,
+		RequestPayer:       aliasCfgV10.RequestPayer,
+		Tuning:             aliasCfgV10.Tuning,
+		PartSize:           aliasCfgV10.PartSize,
+		MultipartThreshold: aliasCfgV10.MultipartThreshold,
+		Defaults:           aliasCfgV10.Defaults,
 	}
 }
 
 // probeS3Signature - auto probe S3 server signature: issue a Stat call
 // using v4 signature then v2 in case of failure.
-func probeS3Signature(ctx context.Context, accessKey, secretKey, url string) (string, *probe.Error) {
+func probeS3Signature(ctx context.Context, aliasCfg *aliasConfigV10) (string, *probe.Error) {
 	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-bucket-sign-")
 	// Test s3 connection for API auto probe
-	s3Config := &Config{
-		// S3 connection parameters
-		Insecure:  globalInsecure,
-		AccessKey: accessKey,
-		SecretKey: secretKey,
-		HostURL:   urlJoinPath(url, probeBucketName),
-		Debug:     globalDebug,
-	}
+	s3Config := NewS3Config(urlJoinPath(aliasCfg.URL, probeBucketName), aliasCfg)
+	s3Config.Lookup = minio.BucketLookupAuto
 
 	probeSignatureType := func(stype string) (string, *probe.Error) {
 		s3Config.Signature = stype
@@ -222,24 +297,18 @@ func probeS3Signature(ctx context.Context, accessKey, secretKey, url string) (st
 
 // BuildS3Config constructs an S3 Config and does
 // signature auto-probe when needed.
-func BuildS3Config(ctx context.Context, url, accessKey, secretKey, api, path string) (*Config, *probe.Error) {
-	s3Config := NewS3Config(url, &aliasConfigV10{
-		AccessKey: accessKey,
-		SecretKey: secretKey,
-		URL:       url,
-		Path:      path,
-	})
+func BuildS3Config(ctx context.Context, aliasCfg *aliasConfigV10) (*Config, *probe.Error) {
+	s3Config := NewS3Config(aliasCfg.URL, aliasCfg)
 
 	// If api is provided we do not auto probe signature, this is
 	// required in situations when signature type is provided by the user.
-	if api != "" {
-		s3Config.Signature = api
+	if aliasCfg.API != "" {
 		return s3Config, nil
 	}
 	// Probe S3 signature version
-	api, err := probeS3Signature(ctx, accessKey, secretKey, url)
+	api, err := probeS3Signature(ctx, aliasCfg)
 	if err != nil {
-		return nil, err.Trace(url, accessKey, secretKey, api, path)
+		return nil, err.Trace(aliasCfg.URL, aliasCfg.AccessKey, aliasCfg.SecretKey, aliasCfg.Path)
 	}
 
 	s3Config.Signature = api
@@ -247,6 +316,48 @@ func BuildS3Config(ctx context.Context, url, accessKey, secretKey, api, path str
 	return s3Config, nil
 }
 
+// setAliasTransport sets the proxy, CA certificate and client
+// certificate of an alias from the command line, files are stored
+// with their absolute path.
+func setAliasTransport(cli *cli.Context, aliasCfg *aliasConfigV10) *probe.Error {
+	if proxy := cli.String("proxy"); proxy != "" {
+		u, e := url.Parse(proxy)
+		if e != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") {
+			return errInvalidArgument().Trace(proxy)
+		}
+		aliasCfg.Proxy = proxy
+	}
+	if (cli.String("client-cert") == "") != (cli.String("client-key") == "") {
+		return probe.NewError(errors.New("--client-cert and --client-key must be set together"))
+	}
+	for _, f := range []struct {
+		flag string
+		dst  *string
+	}{
+		{"ca-cert", &aliasCfg.CACert},
+		{"client-cert", &aliasCfg.ClientCert},
+		{"client-key", &aliasCfg.ClientKey},
+	} {
+		path := cli.String(f.flag)
+		if path == "" {
+			continue
+		}
+		absPath, e := filepath.Abs(path)
+		if e != nil {
+			return probe.NewError(e).Trace(path)
+		}
+		if _, e = os.Stat(absPath); e != nil {
+			return probe.NewError(e).Trace(path)
+		}
+		*f.dst = absPath
+	}
+	// Fail early on certificates which can not be loaded.
+	if _, err := newTLSConfig(NewS3Config(aliasCfg.URL, aliasCfg)); err != nil {
+		return err.Trace()
+	}
+	return nil
+}
+
 // fetchAliasKeys - returns the user accessKey and secretKey
 func fetchAliasKeys(args cli.Args) (string, string) {
 	accessKey := ""
@@ -311,19 +422,41 @@ func mainAliasSet(cli *cli.Context, deprecated bool) error {
 	accessKey, secretKey := fetchAliasKeys(args)
 	checkAliasSetSyntax(cli, accessKey, secretKey, deprecated)
 
+	if signature := cli.String("signature"); signature != "" {
+		api = signatureAPI(signature)
+	}
+
 	ctx, cancelAliasAdd := context.WithCancel(globalContext)
 	defer cancelAliasAdd()
 
-	s3Config, err := BuildS3Config(ctx, url, accessKey, secretKey, api, path)
+	aliasCfg := aliasConfigV10{
+		URL:       url,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		API:       api,
+		Path:      path,
+
+		// The global --request-payer flag is saved as the default of the alias.
+		RequestPayer: globalRequestPayer,
+	}
+	// And so are the connection settings and the multipart sizes.
+	if globalTransportTuning.isSet() {
+		tuning := globalTransportTuning
+		aliasCfg.Tuning = &tuning
+	}
+	aliasCfg.PartSize = globalPartSize
+	aliasCfg.MultipartThreshold = globalMultipartThreshold
+	fatalIf(setAliasTransport(cli, &aliasCfg), "Invalid TLS or proxy settings.")
+
+	s3Config, err := BuildS3Config(ctx, &aliasCfg)
 	fatalIf(err.Trace(cli.Args()...), "Unable to initialize new alias from the provided credentials.")
+	aliasCfg.API = s3Config.Signature
 
-	msg := setAlias(alias, aliasConfigV10{
-		URL:       s3Config.HostURL,
-		AccessKey: s3Config.AccessKey,
-		SecretKey: s3Config.SecretKey,
-		API:       s3Config.Signature,
-		Path:      path,
-	}) // Add an alias with specified credentials.
+	if cli.Bool("keychain") {
+		fatalIf(moveSecretToKeychain(alias, &aliasCfg), "Unable to store the secret key in the keychain.")
+	}
+
+	msg := setAlias(alias, aliasCfg) // Add an alias with specified credentials.
 
 	msg.op = "set"
 	if deprecated {
@@ -0,0 +1,387 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio/pkg/console"
+)
+
+var aliasTestCmd = cli.Command{
+	Name:            "test",
+	Usage:           "check connectivity and credentials of an alias",
+	Action:          mainAliasTest,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} ALIAS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+The checks run in order and stop at the first failure:
+  dns     resolve the host name of the alias URL
+  tcp     open a connection to the server
+  tls     handshake, verify the certificate chain and its expiry (https only)
+  auth    list buckets with the credentials of the alias
+  s3      get the location of a bucket and list its objects
+  server  detect the server type and version
+
+EXAMPLES:
+  1. Check the "myminio" alias.
+     {{.Prompt}} {{.HelpName}} myminio
+`,
+}
+
+// Certificates expiring sooner than this are reported as a warning.
+const aliasTestCertExpiryWarning = 30 * 24 * time.Hour
+
+// Timeout of each check.
+const aliasTestTimeout = 10 * time.Second
+
+const (
+	aliasTestOK      = "ok"
+	aliasTestWarning = "warning"
+	aliasTestFailed  = "failed"
+	aliasTestSkipped = "skipped"
+)
+
+// aliasTestMessage holds the result of one check of 'mc alias test'.
+type aliasTestMessage struct {
+	Status  string        `json:"status"`
+	Alias   string        `json:"alias"`
+	Stage   string        `json:"stage"`
+	Result  string        `json:"result"`
+	Latency time.Duration `json:"latency"`
+	Detail  string        `json:"detail,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+func (a aliasTestMessage) String() string {
+	var result string
+	switch a.Result {
+	case aliasTestOK:
+		result = console.Colorize("AliasTestOK", "OK  ")
+	case aliasTestWarning:
+		result = console.Colorize("AliasTestWarning", "WARN")
+	case aliasTestFailed:
+		result = console.Colorize("AliasTestFailed", "FAIL")
+	default:
+		result = console.Colorize("AliasTestSkipped", "SKIP")
+	}
+	latency := ""
+	if a.Latency > 0 {
+		latency = a.Latency.Round(time.Millisecond / 10).String()
+	}
+	return fmt.Sprintf("%-7s %s %9s  %s", a.Stage, result, latency, a.Detail)
+}
+
+func (a aliasTestMessage) JSON() string {
+	a.Status = "success"
+	if a.Result == aliasTestFailed {
+		a.Status = "error"
+	}
+	jsonMessageBytes, e := json.Marshal(a)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// aliasTester runs the checks of 'mc alias test', keeping what
+// the later checks need from the earlier ones.
+type aliasTester struct {
+	alias    string
+	aliasCfg *aliasConfigV10
+	u        *url.URL
+	addr     string
+	proxy    *url.URL
+	api      *minio.Client
+	buckets  []minio.BucketInfo
+}
+
+// stage runs one check, its latency is measured around fn.
+func (t *aliasTester) stage(name string, fn func(ctx context.Context) (string, string, error)) *probe.Error {
+	ctx, cancel := context.WithTimeout(globalContext, aliasTestTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result, detail, e := fn(ctx)
+	msg := aliasTestMessage{
+		Alias:   t.alias,
+		Stage:   name,
+		Result:  result,
+		Latency: time.Since(start),
+		Detail:  detail,
+	}
+	if result == aliasTestSkipped {
+		msg.Latency = 0
+	}
+	if e != nil {
+		msg.Result = aliasTestFailed
+		msg.Error = e.Error()
+	}
+	printMsg(msg)
+	return probe.NewError(e)
+}
+
+func (t *aliasTester) checkDNS(ctx context.Context) (string, string, error) {
+	host := t.u.Hostname()
+	if t.proxy != nil {
+		host = t.proxy.Hostname()
+	}
+	if net.ParseIP(host) != nil {
+		return aliasTestSkipped, "host is an IP address", nil
+	}
+	addrs, e := net.DefaultResolver.LookupHost(ctx, host)
+	if e != nil {
+		return "", "", e
+	}
+	return aliasTestOK, host + " resolves to " + strings.Join(addrs, ", "), nil
+}
+
+func (t *aliasTester) checkTCP(ctx context.Context) (string, string, error) {
+	addr := t.addr
+	if t.proxy != nil {
+		addr = hostPort(t.proxy)
+	}
+	var d net.Dialer
+	conn, e := d.DialContext(ctx, "tcp", addr)
+	if e != nil {
+		return "", "", e
+	}
+	defer conn.Close()
+	detail := "connected to " + conn.RemoteAddr().String()
+	if t.proxy != nil {
+		detail += " (proxy)"
+	}
+	return aliasTestOK, detail, nil
+}
+
+func (t *aliasTester) checkTLS(ctx context.Context) (string, string, error) {
+	if t.u.Scheme != "https" {
+		return aliasTestSkipped, "plain http", nil
+	}
+	if t.proxy != nil {
+		return aliasTestSkipped, "connection through proxy " + t.proxy.Host, nil
+	}
+	tlsConfig, err := newTLSConfig(NewS3Config(t.aliasCfg.URL, t.aliasCfg))
+	if err != nil {
+		return "", "", err.ToGoError()
+	}
+	tlsConfig.ServerName = t.u.Hostname()
+	d := &tls.Dialer{Config: tlsConfig}
+	conn, e := d.DialContext(ctx, "tcp", t.addr)
+	if e != nil {
+		return "", "", e
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return "", "", fmt.Errorf("server sent no certificate")
+	}
+	leaf := state.PeerCertificates[0]
+	var chain []string
+	expiry := leaf.NotAfter
+	for _, cert := range state.PeerCertificates {
+		chain = append(chain, cert.Subject.CommonName)
+		if cert.NotAfter.Before(expiry) {
+			expiry = cert.NotAfter
+		}
+	}
+
+	result := aliasTestOK
+	if globalInsecure {
+		result = aliasTestWarning
+	}
+	left := time.Until(expiry)
+	if left < aliasTestCertExpiryWarning {
+		result = aliasTestWarning
+	}
+	detail := fmt.Sprintf("%s, chain %s, expires in %d days (%s)", tlsVersionName(state.Version),
+		strings.Join(chain, " <- "), int(left.Hours()/24), expiry.Format("2006-01-02"))
+	if globalInsecure {
+		detail += ", not verified (--insecure)"
+	}
+	return result, detail, nil
+}
+
+func (t *aliasTester) checkAuth(ctx context.Context) (string, string, error) {
+	if t.aliasCfg.AccessKey == "" && t.aliasCfg.SecretKey == "" {
+		return aliasTestSkipped, "anonymous alias", nil
+	}
+	clnt, err := S3New(NewS3Config(t.aliasCfg.URL, t.aliasCfg))
+	if err != nil {
+		return "", "", err.ToGoError()
+	}
+	t.api = clnt.(*S3Client).api
+	buckets, e := t.api.ListBuckets(ctx)
+	if e != nil {
+		return "", "", e
+	}
+	t.buckets = buckets
+	return aliasTestOK, fmt.Sprintf("signed %s requests accepted, %d buckets", t.aliasCfg.API, len(buckets)), nil
+}
+
+func (t *aliasTester) checkS3(ctx context.Context) (string, string, error) {
+	if t.api == nil || len(t.buckets) == 0 {
+		return aliasTestSkipped, "no bucket to check", nil
+	}
+	bucket := t.buckets[0].Name
+	location, e := t.api.GetBucketLocation(ctx, bucket)
+	if e != nil {
+		return "", "", e
+	}
+	for obj := range t.api.ListObjects(ctx, bucket, minio.ListObjectsOptions{MaxKeys: 1}) {
+		if obj.Err != nil {
+			return "", "", obj.Err
+		}
+		break
+	}
+	if location == "" {
+		location = "us-east-1"
+	}
+	return aliasTestOK, "listed bucket " + bucket + " in region " + location, nil
+}
+
+func (t *aliasTester) checkServer(ctx context.Context) (string, string, error) {
+	req, e := http.NewRequestWithContext(ctx, http.MethodHead, t.aliasCfg.URL, nil)
+	if e != nil {
+		return "", "", e
+	}
+	s3Config := NewS3Config(t.aliasCfg.URL, t.aliasCfg)
+	tlsConfig, err := newTLSConfig(s3Config)
+	if err != nil {
+		return "", "", err.ToGoError()
+	}
+	proxy, err := newProxyFunc(s3Config, http.ProxyFromEnvironment)
+	if err != nil {
+		return "", "", err.ToGoError()
+	}
+	clnt := &http.Client{Transport: &http.Transport{Proxy: proxy, TLSClientConfig: tlsConfig}}
+	resp, e := clnt.Do(req)
+	if e != nil {
+		return "", "", e
+	}
+	resp.Body.Close()
+
+	server := resp.Header.Get("Server")
+	if server == "" {
+		return aliasTestWarning, "server type unknown", nil
+	}
+	detail := server
+	// The version of MinIO is only available with admin credentials.
+	if strings.HasPrefix(server, "MinIO") {
+		if admClnt, err := newAdminClient(t.alias); err == nil {
+			if info, e := admClnt.ServerInfo(ctx); e == nil && len(info.Servers) > 0 {
+				detail += " " + info.Servers[0].Version
+			}
+		}
+	}
+	return aliasTestOK, detail, nil
+}
+
+// hostPort returns the host and port of a URL, with the default port of its scheme.
+func hostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// tlsVersionName returns the name of a TLS version.
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	}
+	return fmt.Sprintf("TLS 0x%04x", version)
+}
+
+// mainAliasTest is the handle for "mc alias test" command.
+func mainAliasTest(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "test", globalInvalidArgsExitStatus)
+	}
+	console.SetColor("AliasTestOK", color.New(color.FgGreen, color.Bold))
+	console.SetColor("AliasTestWarning", color.New(color.FgYellow, color.Bold))
+	console.SetColor("AliasTestFailed", color.New(color.FgRed, color.Bold))
+	console.SetColor("AliasTestSkipped", color.New(color.FgHiBlack))
+
+	alias := cleanAlias(ctx.Args().Get(0))
+	aliasCfg := mustGetHostConfig(alias)
+	if aliasCfg == nil {
+		fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
+	}
+	u, e := url.Parse(aliasCfg.URL)
+	fatalIf(probe.NewError(e), "Invalid URL `"+aliasCfg.URL+"` of alias `"+alias+"`.")
+
+	t := &aliasTester{
+		alias:    alias,
+		aliasCfg: aliasCfg,
+		u:        u,
+		addr:     hostPort(u),
+	}
+	if aliasCfg.Proxy != "" {
+		t.proxy, e = url.Parse(aliasCfg.Proxy)
+		fatalIf(probe.NewError(e), "Invalid proxy `"+aliasCfg.Proxy+"` of alias `"+alias+"`.")
+	}
+
+	stages := []struct {
+		name string
+		fn   func(ctx context.Context) (string, string, error)
+	}{
+		{"dns", t.checkDNS},
+		{"tcp", t.checkTCP},
+		{"tls", t.checkTLS},
+		{"auth", t.checkAuth},
+		{"s3", t.checkS3},
+		{"server", t.checkServer},
+	}
+	for _, s := range stages {
+		err := t.stage(s.name, s.fn)
+		fatalIf(err.Trace(alias), "Alias `"+alias+"` failed the `"+s.name+"` check.")
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// aliasTestHandler serves the requests of 'alias test', only the
+// requests signed with the access key 'good' are accepted.
+func aliasTestHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Server", "TestS3")
+	if !strings.Contains(r.Header.Get("Authorization"), "Credential=good/") {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`))
+		return
+	}
+	switch r.URL.Path {
+	case "/":
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.Write([]byte(`<ListAllMyBucketsResult><Owner><ID></ID></Owner><Buckets>` +
+			`<Bucket><Name>photos</Name><CreationDate>2021-01-01T00:00:00.000Z</CreationDate></Bucket>` +
+			`</Buckets></ListAllMyBucketsResult>`))
+	case "/photos/", "/photos":
+		listHandler{keys: []string{"2021/a.jpg"}}.ServeHTTP(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// runAliasTest runs 'alias test' of an alias of the environment.
+func runAliasTest(alias, url string) error {
+	os.Setenv(mcEnvHostPrefix+alias, url)
+	app := cli.NewApp()
+	app.Commands = []cli.Command{{Name: "test", Action: mainAliasTest}}
+	return app.Run([]string{"mc", "test", alias})
+}
+
+func TestAliasTest(t *testing.T) {
+	// A failed check exits, it is run in a child process.
+	if url := os.Getenv("MC_TEST_ALIAS_TEST_URL"); url != "" {
+		loadMcConfig = func() (*configV10, *probe.Error) {
+			return newConfigV10(), nil
+		}
+		runAliasTest("denied", url)
+		return
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(aliasTestHandler))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	defer func(load func() (*configV10, *probe.Error), output io.Writer, isJSON bool) {
+		loadMcConfig = load
+		color.Output = output
+		globalJSON = isJSON
+	}(loadMcConfig, color.Output, globalJSON)
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		return newConfigV10(), nil
+	}
+	globalJSON = true
+	var buf bytes.Buffer
+	color.Output = &buf
+
+	defer os.Unsetenv(mcEnvHostPrefix + "good")
+	if e := runAliasTest("good", "http://good:secret@"+host); e != nil {
+		t.Fatalf("expected the checks to pass, got %v", e)
+	}
+	results := map[string]string{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var msg aliasTestMessage
+		if e := decoder.Decode(&msg); e != nil {
+			t.Fatal(e)
+		}
+		results[msg.Stage] = msg.Result
+	}
+	expected := map[string]string{
+		"dns":    aliasTestSkipped,
+		"tcp":    aliasTestOK,
+		"tls":    aliasTestSkipped,
+		"auth":   aliasTestOK,
+		"s3":     aliasTestOK,
+		"server": aliasTestOK,
+	}
+	for stage, result := range expected {
+		if results[stage] != result {
+			t.Errorf("%s: expected %s, got %q", stage, result, results[stage])
+		}
+	}
+
+	// Rejected credentials exit with the access denied status.
+	cmd := exec.Command(os.Args[0], "-test.run=^TestAliasTest$")
+	cmd.Env = append(os.Environ(), "MC_TEST_ALIAS_TEST_URL=http://denied:secret@"+host)
+	out, e := cmd.CombinedOutput()
+	if e == nil {
+		t.Fatalf("expected the auth check to fail, got %s", out)
+	}
+	if code := cmd.ProcessState.ExitCode(); code != globalAccessDeniedExitStatus {
+		t.Errorf("expected exit status %d, got %d: %s", globalAccessDeniedExitStatus, code, out)
+	}
+	if !bytes.Contains(out, []byte("failed the `auth` check")) {
+		t.Errorf("expected the auth check to be reported, got %s", out)
+	}
+}
@@ -0,0 +1,231 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var applyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "file, f",
+		Usage: "path to the YAML manifest",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the plan without applying it",
+	},
+}
+
+var applyCmd = cli.Command{
+	Name:         "apply",
+	Usage:        "reconcile buckets and IAM entities with a manifest",
+	Action:       mainApply,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(applyFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} -f FILE [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Compare the buckets, canned policies, users, groups and service accounts
+   declared by a YAML manifest with the cluster, print the plan of the
+   changes and apply it. Resources which are not declared are left
+   unchanged, nothing is removed. Environment variables such as ${SECRET}
+   are expanded in the manifest and files are relative to the manifest.
+
+MANIFEST:
+   policies:
+     - name: logs-read
+       file: policies/logs-read.json
+   buckets:
+     - name: logs
+       lock: false
+       versioning: enabled          # or suspended
+       quota: 10GiB                 # hard quota, 0 removes it
+       lifecycle: logs-ilm.json     # as exported by 'mc ilm export'
+       policy: none                 # none, download, upload, public or a JSON file
+   users:
+     - accessKey: alice
+       secretKey: ${ALICE_SECRET}   # only set when the user is created
+       policies: [logs-read]
+       status: enabled
+   groups:
+     - name: auditors
+       members: [alice]             # other members are removed
+       policies: [readonly]
+   serviceAccounts:
+     - user: alice
+       accessKey: alice-ci
+       secretKey: ${ALICE_CI_SECRET}
+       policy: policies/ci.json
+
+EXAMPLES:
+  1. Print the changes needed for the cluster to match a manifest.
+     {{.Prompt}} {{.HelpName}} -f cluster.yaml --dry-run myminio
+
+  2. Apply a manifest to a cluster.
+     {{.Prompt}} {{.HelpName}} -f cluster.yaml myminio
+`,
+}
+
+// applyChangeMessage is a change of the plan.
+type applyChangeMessage struct {
+	Status string `json:"status"`
+	applyChange
+}
+
+func (m applyChangeMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m applyChangeMessage) String() string {
+	sign, theme := "~", "Update"
+	if m.Action == "create" {
+		sign, theme = "+", "Create"
+	}
+	lines := []string{console.Colorize(theme, fmt.Sprintf("%s %s %s", sign, m.Resource, m.Name))}
+	for _, change := range m.Changes {
+		lines = append(lines, console.Colorize(theme, "    "+change))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// applyPlanMessage summarizes the plan.
+type applyPlanMessage struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+	Create int    `json:"create"`
+	Update int    `json:"update"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+func (m applyPlanMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m applyPlanMessage) String() string {
+	if m.Create+m.Update == 0 {
+		return console.Colorize("Plan", "No changes, `"+m.Target+"` matches the manifest.")
+	}
+	return console.Colorize("Plan", fmt.Sprintf("Plan: %d to create, %d to update.", m.Create, m.Update))
+}
+
+// applyResultMessage is printed once the plan is applied.
+type applyResultMessage struct {
+	Status  string `json:"status"`
+	Target  string `json:"target"`
+	Applied int    `json:"applied"`
+	Failed  int    `json:"failed"`
+}
+
+func (m applyResultMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m applyResultMessage) String() string {
+	msg := fmt.Sprintf("Applied %d change(s) to `%s`.", m.Applied, m.Target)
+	if m.Failed > 0 {
+		msg += fmt.Sprintf(" %d change(s) failed.", m.Failed)
+	}
+	return console.Colorize("Plan", msg)
+}
+
+func checkApplySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 || ctx.String("file") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "apply", globalInvalidArgsExitStatus)
+	}
+}
+
+func mainApply(cliCtx *cli.Context) error {
+	ctx, cancelApply := context.WithCancel(globalContext)
+	defer cancelApply()
+
+	checkApplySyntax(cliCtx)
+
+	console.SetColor("Create", color.New(color.FgGreen))
+	console.SetColor("Update", color.New(color.FgYellow))
+	console.SetColor("Plan", color.New(color.Bold))
+
+	aliasedURL := strings.TrimSuffix(cliCtx.Args().Get(0), "/")
+	manifestFile := cliCtx.String("file")
+	dryRun := cliCtx.Bool("dry-run")
+
+	data, e := ioutil.ReadFile(manifestFile)
+	fatalIf(probe.NewError(e).Trace(manifestFile), "Unable to read the manifest.")
+	m, err := parseApplyManifest(data, filepath.Dir(manifestFile))
+	fatalIf(err.Trace(manifestFile), "Unable to parse the manifest.")
+
+	adm, err := newAdminClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize admin connection.")
+	t := &applyTarget{aliasedURL: aliasedURL, adm: adm}
+
+	st, err := observeApplyState(ctx, t, m)
+	fatalIf(err.Trace(aliasedURL), "Unable to read the current state of `"+aliasedURL+"`.")
+
+	changes := planApply(t, m, st)
+	plan := applyPlanMessage{Status: "success", Target: aliasedURL, DryRun: dryRun}
+	for _, change := range changes {
+		printMsg(applyChangeMessage{Status: "success", applyChange: change})
+		if change.Action == "create" {
+			plan.Create++
+		} else {
+			plan.Update++
+		}
+	}
+	printMsg(plan)
+	if dryRun || len(changes) == 0 {
+		return nil
+	}
+
+	result := applyResultMessage{Status: "success", Target: aliasedURL}
+	for _, change := range changes {
+		if err := change.apply(ctx); err != nil {
+			errorIf(err, "Unable to %s %s `%s`.", change.Action, change.Resource, change.Name)
+			result.Failed++
+			continue
+		}
+		result.Applied++
+	}
+	printMsg(result)
+	if result.Failed > 0 {
+		return exitStatus(globalPartialExitStatus)
+	}
+	return nil
+}
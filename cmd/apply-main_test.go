@@ -0,0 +1,113 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestParseApplyManifest(t *testing.T) {
+	os.Setenv("MC_TEST_APPLY_SECRET", "alicesecret")
+	defer os.Unsetenv("MC_TEST_APPLY_SECRET")
+
+	m, err := parseApplyManifest([]byte(`
+buckets:
+  - name: logs
+    versioning: Enabled
+    quota: 1GiB
+users:
+  - accessKey: alice
+    secretKey: ${MC_TEST_APPLY_SECRET}
+    policies: [readonly, diagnostics]
+`), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Buckets[0].Versioning != "enabled" || m.Buckets[0].quota != 1<<30 {
+		t.Fatalf("unexpected bucket %+v", m.Buckets[0])
+	}
+	if m.Users[0].SecretKey != "alicesecret" {
+		t.Fatalf("expected the secret key to be expanded, got %q", m.Users[0].SecretKey)
+	}
+
+	invalid := []string{
+		"buckets:\n  - name: logs\n    versioning: on\n",
+		"buckets:\n  - name: logs\n  - name: logs\n",
+		"buckets:\n  - name: logs\n    policy: private\n",
+		"buckets:\n  - name: logs\n    quota: lots\n",
+		"users:\n  - accessKey: bob\n",
+		"groups:\n  - name: devs\n",
+		"bukets:\n  - name: logs\n",
+	}
+	for i, manifest := range invalid {
+		if _, err := parseApplyManifest([]byte(manifest), "."); err == nil {
+			t.Errorf("Test %d: expected an error for %q", i+1, manifest)
+		}
+	}
+}
+
+func TestPlanApply(t *testing.T) {
+	m := &applyManifest{
+		Buckets: []applyBucket{
+			{Name: "logs", Versioning: "enabled", Policy: "download"},
+			{Name: "data", Versioning: "enabled", Quota: "1GiB", quota: 1 << 30},
+			{Name: "new", Lock: true, Versioning: "enabled"},
+		},
+		Users: []applyUser{
+			{AccessKey: "alice", SecretKey: "alicesecret", Policies: []string{"readwrite", "diagnostics"}},
+			{AccessKey: "bob", SecretKey: "bobsecret", Policies: []string{"readonly"}, Status: "disabled"},
+		},
+		Groups: []applyGroup{
+			{Name: "devs", Members: []string{"alice", "bob"}},
+		},
+	}
+	st := &applyState{
+		buckets: map[string]*applyBucketState{
+			"logs": {versioning: "enabled", policy: accessDownload},
+			"data": {versioning: "suspended"},
+		},
+		users: map[string]madmin.UserInfo{
+			"alice": {PolicyName: "diagnostics,readwrite", Status: madmin.AccountEnabled},
+		},
+		groups: map[string]*madmin.GroupDesc{
+			"devs": {Name: "devs", Members: []string{"alice", "carol"}},
+		},
+	}
+	changes := planApply(&applyTarget{aliasedURL: "myminio"}, m, st)
+
+	type summary struct {
+		Action, Resource, Name string
+		Changes                []string
+	}
+	var got []summary
+	for _, c := range changes {
+		got = append(got, summary{c.Action, c.Resource, c.Name, c.Changes})
+	}
+	expected := []summary{
+		{"update", "bucket", "data", []string{"versioning: suspended -> enabled", "quota: none -> 1.0 GiB"}},
+		{"create", "bucket", "new", []string{"lock: on"}},
+		{"create", "user", "bob", []string{"policies: readonly", "status: disabled"}},
+		{"update", "group", "devs", []string{"members added: bob", "members removed: carol"}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
@@ -0,0 +1,280 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// applyManifest declares the buckets and the IAM entities of a cluster,
+// the resources which are not declared are left unchanged.
+type applyManifest struct {
+	Policies        []applyPolicy         `yaml:"policies,omitempty"`
+	Buckets         []applyBucket         `yaml:"buckets,omitempty"`
+	Users           []applyUser           `yaml:"users,omitempty"`
+	Groups          []applyGroup          `yaml:"groups,omitempty"`
+	ServiceAccounts []applyServiceAccount `yaml:"serviceAccounts,omitempty"`
+}
+
+// applyPolicy is a canned IAM policy read from a JSON file.
+type applyPolicy struct {
+	Name string `yaml:"name"`
+	File string `yaml:"file"`
+
+	document string
+}
+
+// applyBucket is a bucket with its settings, an empty setting is not
+// managed by the manifest.
+type applyBucket struct {
+	Name string `yaml:"name"`
+	// Lock enables object locking when the bucket is created.
+	Lock bool `yaml:"lock,omitempty"`
+	// Versioning is 'enabled' or 'suspended'.
+	Versioning string `yaml:"versioning,omitempty"`
+	// Quota is a hard quota such as '10GiB', '0' removes the quota.
+	Quota string `yaml:"quota,omitempty"`
+	// Lifecycle is a JSON file as exported by 'mc ilm export'.
+	Lifecycle string `yaml:"lifecycle,omitempty"`
+	// Policy is the anonymous access, one of 'none', 'download',
+	// 'upload', 'public' or a JSON policy file.
+	Policy string `yaml:"policy,omitempty"`
+
+	quota      uint64
+	lifecycle  string
+	policyJSON string
+}
+
+// applyUser is an IAM user, the secret key is only set on creation.
+type applyUser struct {
+	AccessKey string   `yaml:"accessKey"`
+	SecretKey string   `yaml:"secretKey"`
+	Policies  []string `yaml:"policies,omitempty"`
+	// Status is 'enabled' or 'disabled'.
+	Status string `yaml:"status,omitempty"`
+}
+
+// applyGroup is an IAM group, the members which are not listed are
+// removed from the group.
+type applyGroup struct {
+	Name     string   `yaml:"name"`
+	Members  []string `yaml:"members"`
+	Policies []string `yaml:"policies,omitempty"`
+}
+
+// applyServiceAccount is a service account of a user, optionally
+// restricted by a JSON policy file.
+type applyServiceAccount struct {
+	User      string `yaml:"user"`
+	AccessKey string `yaml:"accessKey"`
+	SecretKey string `yaml:"secretKey"`
+	Policy    string `yaml:"policy,omitempty"`
+
+	policyJSON string
+}
+
+// normalizeJSON returns a JSON document with sorted keys and without
+// spaces, to compare documents regardless of their formatting.
+func normalizeJSON(data []byte) (string, error) {
+	var v interface{}
+	if e := json.Unmarshal(data, &v); e != nil {
+		return "", e
+	}
+	out, e := json.Marshal(v)
+	if e != nil {
+		return "", e
+	}
+	return string(out), nil
+}
+
+// joinPolicies returns the sorted comma separated list of policies
+// used by the admin API.
+func joinPolicies(policies []string) string {
+	var names []string
+	for _, p := range policies {
+		for _, name := range strings.Split(p, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// parseApplyManifest parses and validates a manifest, environment
+// variables such as ${SECRET} are expanded and the files are read
+// relative to dir.
+func parseApplyManifest(data []byte, dir string) (*applyManifest, *probe.Error) {
+	var m applyManifest
+	if e := yaml.UnmarshalStrict([]byte(os.ExpandEnv(string(data))), &m); e != nil {
+		return nil, probe.NewError(e)
+	}
+	readFile := func(name string) ([]byte, error) {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		return ioutil.ReadFile(name)
+	}
+	readJSON := func(name string) (string, error) {
+		data, e := readFile(name)
+		if e != nil {
+			return "", e
+		}
+		doc, e := normalizeJSON(data)
+		if e != nil {
+			return "", fmt.Errorf("invalid JSON in `%s`: %v", name, e)
+		}
+		return doc, nil
+	}
+
+	seen := map[string]bool{}
+	unique := func(kind, name string) error {
+		if name == "" {
+			return fmt.Errorf("%s without a name", kind)
+		}
+		if seen[kind+"/"+name] {
+			return fmt.Errorf("%s `%s` is declared more than once", kind, name)
+		}
+		seen[kind+"/"+name] = true
+		return nil
+	}
+
+	for i := range m.Policies {
+		p := &m.Policies[i]
+		if e := unique("policy", p.Name); e != nil {
+			return nil, probe.NewError(e)
+		}
+		if p.File == "" {
+			return nil, probe.NewError(fmt.Errorf("policy `%s` has no file", p.Name))
+		}
+		doc, e := readJSON(p.File)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		p.document = doc
+	}
+
+	for i := range m.Buckets {
+		b := &m.Buckets[i]
+		if e := unique("bucket", b.Name); e != nil {
+			return nil, probe.NewError(e)
+		}
+		b.Versioning = strings.ToLower(b.Versioning)
+		switch b.Versioning {
+		case "", "enabled", "suspended":
+		default:
+			return nil, probe.NewError(fmt.Errorf("bucket `%s` has an invalid versioning `%s`, expected 'enabled' or 'suspended'", b.Name, b.Versioning))
+		}
+		if b.Quota != "" {
+			quota, e := humanize.ParseBytes(b.Quota)
+			if e != nil {
+				return nil, probe.NewError(fmt.Errorf("bucket `%s` has an invalid quota `%s`", b.Name, b.Quota))
+			}
+			b.quota = quota
+		}
+		if b.Lifecycle != "" {
+			data, e := readFile(b.Lifecycle)
+			if e != nil {
+				return nil, probe.NewError(e)
+			}
+			cfg := lifecycle.NewConfiguration()
+			if e = json.Unmarshal(data, cfg); e != nil {
+				return nil, probe.NewError(fmt.Errorf("invalid lifecycle configuration in `%s`: %v", b.Lifecycle, e))
+			}
+			if b.lifecycle, e = lifecycleJSON(cfg); e != nil {
+				return nil, probe.NewError(e)
+			}
+		}
+		if b.Policy != "" {
+			perm := accessPerms(b.Policy)
+			switch {
+			case perm.isValidAccessFile():
+				doc, e := readJSON(b.Policy)
+				if e != nil {
+					return nil, probe.NewError(e)
+				}
+				b.policyJSON = doc
+			case !perm.isValidAccessPERM():
+				return nil, probe.NewError(fmt.Errorf("bucket `%s` has an invalid policy `%s`, expected 'none', 'download', 'upload', 'public' or a JSON file", b.Name, b.Policy))
+			}
+		}
+	}
+
+	for i := range m.Users {
+		u := &m.Users[i]
+		if e := unique("user", u.AccessKey); e != nil {
+			return nil, probe.NewError(e)
+		}
+		if u.SecretKey == "" {
+			return nil, probe.NewError(fmt.Errorf("user `%s` has no secret key", u.AccessKey))
+		}
+		u.Status = strings.ToLower(u.Status)
+		switch u.Status {
+		case "", "enabled", "disabled":
+		default:
+			return nil, probe.NewError(fmt.Errorf("user `%s` has an invalid status `%s`, expected 'enabled' or 'disabled'", u.AccessKey, u.Status))
+		}
+	}
+
+	for _, g := range m.Groups {
+		if e := unique("group", g.Name); e != nil {
+			return nil, probe.NewError(e)
+		}
+		if len(g.Members) == 0 {
+			return nil, probe.NewError(fmt.Errorf("group `%s` has no members", g.Name))
+		}
+	}
+
+	for i := range m.ServiceAccounts {
+		s := &m.ServiceAccounts[i]
+		if e := unique("service account", s.AccessKey); e != nil {
+			return nil, probe.NewError(e)
+		}
+		if s.User == "" || s.SecretKey == "" {
+			return nil, probe.NewError(fmt.Errorf("service account `%s` needs a user and a secret key", s.AccessKey))
+		}
+		if s.Policy != "" {
+			doc, e := readJSON(s.Policy)
+			if e != nil {
+				return nil, probe.NewError(e)
+			}
+			s.policyJSON = doc
+		}
+	}
+	return &m, nil
+}
+
+// lifecycleJSON returns the normalized JSON of a lifecycle configuration.
+func lifecycleJSON(cfg *lifecycle.Configuration) (string, error) {
+	data, e := json.Marshal(cfg)
+	if e != nil {
+		return "", e
+	}
+	return normalizeJSON(data)
+}
@@ -0,0 +1,474 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// applyBucketState is the current state of a declared bucket, only the
+// settings declared by the manifest are read.
+type applyBucketState struct {
+	versioning string
+	quota      uint64
+	lifecycle  string
+	policy     accessPerms
+	policyJSON string
+}
+
+// applyState is the current state of the resources of a manifest, the
+// resources which do not exist are missing from the maps.
+type applyState struct {
+	buckets         map[string]*applyBucketState
+	policies        map[string]string
+	users           map[string]madmin.UserInfo
+	groups          map[string]*madmin.GroupDesc
+	serviceAccounts map[string]madmin.InfoServiceAccountResp
+}
+
+// applyTarget is the cluster a manifest is applied to.
+type applyTarget struct {
+	aliasedURL string
+	adm        *madmin.AdminClient
+}
+
+// applyChange is a change of the plan, it creates or updates a resource.
+type applyChange struct {
+	Action   string   `json:"action"`
+	Resource string   `json:"resource"`
+	Name     string   `json:"name"`
+	Changes  []string `json:"changes,omitempty"`
+
+	steps []func(ctx context.Context) *probe.Error
+}
+
+// apply runs the steps of a change in order.
+func (c applyChange) apply(ctx context.Context) *probe.Error {
+	for _, step := range c.steps {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observeApplyState reads the current state of the resources declared by
+// a manifest from the cluster.
+func observeApplyState(ctx context.Context, t *applyTarget, m *applyManifest) (*applyState, *probe.Error) {
+	st := &applyState{
+		buckets:         map[string]*applyBucketState{},
+		policies:        map[string]string{},
+		users:           map[string]madmin.UserInfo{},
+		groups:          map[string]*madmin.GroupDesc{},
+		serviceAccounts: map[string]madmin.InfoServiceAccountResp{},
+	}
+
+	for _, b := range m.Buckets {
+		bucketURL := urlJoinPath(t.aliasedURL, b.Name)
+		clnt, err := newClient(bucketURL)
+		if err != nil {
+			return nil, err.Trace(bucketURL)
+		}
+		if _, err = clnt.Stat(ctx, StatOptions{}); err != nil {
+			if _, ok := err.ToGoError().(BucketDoesNotExist); ok {
+				continue
+			}
+			return nil, err.Trace(bucketURL)
+		}
+		bst := &applyBucketState{}
+		if b.Versioning != "" {
+			cfg, err := clnt.GetVersion(ctx)
+			if err != nil {
+				return nil, err.Trace(bucketURL)
+			}
+			bst.versioning = strings.ToLower(cfg.Status)
+		}
+		if b.Quota != "" {
+			quota, e := t.adm.GetBucketQuota(ctx, b.Name)
+			if e != nil {
+				return nil, probe.NewError(e).Trace(bucketURL)
+			}
+			bst.quota = quota.Quota
+		}
+		if b.Lifecycle != "" {
+			cfg, err := clnt.GetLifecycle(ctx)
+			if err != nil {
+				if minio.ToErrorResponse(err.ToGoError()).Code != "NoSuchLifecycleConfiguration" {
+					return nil, err.Trace(bucketURL)
+				}
+				cfg = lifecycle.NewConfiguration()
+			}
+			doc, e := lifecycleJSON(cfg)
+			if e != nil {
+				return nil, probe.NewError(e).Trace(bucketURL)
+			}
+			bst.lifecycle = doc
+		}
+		if b.Policy != "" {
+			perm, policyStr, err := doGetAccess(ctx, bucketURL)
+			if err != nil {
+				return nil, err.Trace(bucketURL)
+			}
+			bst.policy = perm
+			if policyStr != "" {
+				if bst.policyJSON, err = probeNormalizeJSON(policyStr); err != nil {
+					return nil, err.Trace(bucketURL)
+				}
+			}
+		}
+		st.buckets[b.Name] = bst
+	}
+
+	if len(m.Policies) > 0 {
+		policies, e := t.adm.ListCannedPolicies(ctx)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		for name, doc := range policies {
+			if st.policies[name], e = normalizeJSON(doc); e != nil {
+				return nil, probe.NewError(e).Trace(name)
+			}
+		}
+	}
+
+	if len(m.Users) > 0 || len(m.ServiceAccounts) > 0 {
+		users, e := t.adm.ListUsers(ctx)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		st.users = users
+	}
+
+	if len(m.Groups) > 0 {
+		groups, e := t.adm.ListGroups(ctx)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		declared := map[string]bool{}
+		for _, g := range m.Groups {
+			declared[g.Name] = true
+		}
+		for _, group := range groups {
+			if !declared[group] {
+				continue
+			}
+			desc, e := t.adm.GetGroupDescription(ctx, group)
+			if e != nil {
+				return nil, probe.NewError(e).Trace(group)
+			}
+			st.groups[group] = desc
+		}
+	}
+
+	listed := map[string]bool{}
+	for _, s := range m.ServiceAccounts {
+		if _, ok := st.users[s.User]; !ok || listed[s.User] {
+			continue
+		}
+		listed[s.User] = true
+		accounts, e := t.adm.ListServiceAccounts(ctx, s.User)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(s.User)
+		}
+		for _, accessKey := range accounts.Accounts {
+			info, e := t.adm.InfoServiceAccount(ctx, accessKey)
+			if e != nil {
+				return nil, probe.NewError(e).Trace(accessKey)
+			}
+			if info.Policy != "" {
+				if info.Policy, e = normalizeJSON([]byte(info.Policy)); e != nil {
+					return nil, probe.NewError(e).Trace(accessKey)
+				}
+			}
+			st.serviceAccounts[accessKey] = info
+		}
+	}
+	return st, nil
+}
+
+// probeNormalizeJSON normalizes a JSON document returned by the server.
+func probeNormalizeJSON(doc string) (string, *probe.Error) {
+	out, e := normalizeJSON([]byte(doc))
+	return out, probe.NewError(e)
+}
+
+// describeVersioning returns the printable versioning status.
+func describeVersioning(status string) string {
+	if status == "" {
+		return "off"
+	}
+	return status
+}
+
+// describeQuota returns the printable hard quota of a bucket.
+func describeQuota(quota uint64) string {
+	if quota == 0 {
+		return "none"
+	}
+	return humanize.IBytes(quota)
+}
+
+// planBucket compares a declared bucket with its current state, cur is
+// nil when the bucket does not exist.
+func planBucket(t *applyTarget, b applyBucket, cur *applyBucketState) (change applyChange) {
+	bucketURL := urlJoinPath(t.aliasedURL, b.Name)
+	change = applyChange{Action: "update", Resource: "bucket", Name: b.Name}
+	if cur == nil {
+		change.Action = "create"
+		cur = &applyBucketState{policy: accessNone}
+		if b.Lock {
+			change.Changes = append(change.Changes, "lock: on")
+			// Object locking enables versioning.
+			cur.versioning = "enabled"
+		}
+		withLock := b.Lock
+		change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+			clnt, err := newClient(bucketURL)
+			if err != nil {
+				return err.Trace(bucketURL)
+			}
+			return clnt.MakeBucket(ctx, "", false, withLock).Trace(bucketURL)
+		})
+	}
+
+	if b.Versioning != "" && b.Versioning != cur.versioning {
+		change.Changes = append(change.Changes, describeChange("versioning", describeVersioning(cur.versioning), b.Versioning, change.Action))
+		status := "enable"
+		if b.Versioning == "suspended" {
+			status = "suspend"
+		}
+		change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+			clnt, err := newClient(bucketURL)
+			if err != nil {
+				return err.Trace(bucketURL)
+			}
+			return clnt.SetVersion(ctx, status).Trace(bucketURL)
+		})
+	}
+
+	if b.Quota != "" && b.quota != cur.quota {
+		change.Changes = append(change.Changes, describeChange("quota", describeQuota(cur.quota), describeQuota(b.quota), change.Action))
+		quota := &madmin.BucketQuota{}
+		if b.quota > 0 {
+			quota = &madmin.BucketQuota{Quota: b.quota, Type: madmin.HardQuota}
+		}
+		change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+			return probe.NewError(t.adm.SetBucketQuota(ctx, b.Name, quota)).Trace(bucketURL)
+		})
+	}
+
+	if b.Lifecycle != "" && b.lifecycle != cur.lifecycle {
+		change.Changes = append(change.Changes, describeChange("lifecycle", "current rules", b.Lifecycle, change.Action))
+		change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+			cfg := lifecycle.NewConfiguration()
+			if e := json.Unmarshal([]byte(b.lifecycle), cfg); e != nil {
+				return probe.NewError(e).Trace(b.Lifecycle)
+			}
+			clnt, err := newClient(bucketURL)
+			if err != nil {
+				return err.Trace(bucketURL)
+			}
+			return clnt.SetLifecycle(ctx, cfg).Trace(bucketURL)
+		})
+	}
+
+	switch {
+	case b.policyJSON != "" && b.policyJSON != cur.policyJSON:
+		change.Changes = append(change.Changes, describeChange("policy", string(cur.policy), b.Policy, change.Action))
+		change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+			clnt, err := newClient(bucketURL)
+			if err != nil {
+				return err.Trace(bucketURL)
+			}
+			return clnt.SetAccess(ctx, b.policyJSON, true).Trace(bucketURL)
+		})
+	case b.Policy != "" && b.policyJSON == "" && accessPerms(b.Policy) != cur.policy:
+		change.Changes = append(change.Changes, describeChange("policy", string(cur.policy), b.Policy, change.Action))
+		change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+			return doSetAccess(ctx, bucketURL, accessPerms(b.Policy))
+		})
+	}
+	return change
+}
+
+// describeChange returns the printable change of a setting, the previous
+// value is omitted for created resources.
+func describeChange(setting, from, to, action string) string {
+	if action == "create" {
+		return fmt.Sprintf("%s: %s", setting, to)
+	}
+	return fmt.Sprintf("%s: %s -> %s", setting, from, to)
+}
+
+// planApply compares a manifest with the current state of the cluster,
+// it returns the changes to apply in order: policies, buckets, users,
+// groups and service accounts.
+func planApply(t *applyTarget, m *applyManifest, st *applyState) []applyChange {
+	var changes []applyChange
+
+	for _, p := range m.Policies {
+		cur, ok := st.policies[p.Name]
+		if ok && cur == p.document {
+			continue
+		}
+		change := applyChange{Action: "update", Resource: "policy", Name: p.Name, Changes: []string{"document: " + p.File}}
+		if !ok {
+			change.Action = "create"
+		}
+		name, doc := p.Name, p.document
+		change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+			return probe.NewError(t.adm.AddCannedPolicy(ctx, name, []byte(doc))).Trace(name)
+		})
+		changes = append(changes, change)
+	}
+
+	for _, b := range m.Buckets {
+		cur := st.buckets[b.Name]
+		change := planBucket(t, b, cur)
+		if cur == nil || len(change.Changes) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, u := range m.Users {
+		u := u
+		policies := joinPolicies(u.Policies)
+		cur, ok := st.users[u.AccessKey]
+		change := applyChange{Action: "update", Resource: "user", Name: u.AccessKey}
+		if !ok {
+			change.Action = "create"
+			cur = madmin.UserInfo{Status: madmin.AccountEnabled}
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				return probe.NewError(t.adm.AddUser(ctx, u.AccessKey, u.SecretKey)).Trace(u.AccessKey)
+			})
+		}
+		if policies != "" && policies != joinPolicies([]string{cur.PolicyName}) {
+			change.Changes = append(change.Changes, describeChange("policies", cur.PolicyName, policies, change.Action))
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				return probe.NewError(t.adm.SetPolicy(ctx, policies, u.AccessKey, false)).Trace(u.AccessKey)
+			})
+		}
+		if u.Status != "" && u.Status != string(cur.Status) {
+			change.Changes = append(change.Changes, describeChange("status", string(cur.Status), u.Status, change.Action))
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				return probe.NewError(t.adm.SetUserStatus(ctx, u.AccessKey, madmin.AccountStatus(u.Status))).Trace(u.AccessKey)
+			})
+		}
+		if !ok || len(change.Changes) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, g := range m.Groups {
+		g := g
+		policies := joinPolicies(g.Policies)
+		change := applyChange{Action: "update", Resource: "group", Name: g.Name}
+		cur, ok := st.groups[g.Name]
+		if !ok {
+			change.Action = "create"
+			cur = &madmin.GroupDesc{}
+		}
+		current := map[string]bool{}
+		for _, member := range cur.Members {
+			current[member] = true
+		}
+		declared := map[string]bool{}
+		var added, removed []string
+		for _, member := range g.Members {
+			declared[member] = true
+			if !current[member] {
+				added = append(added, member)
+			}
+		}
+		for _, member := range cur.Members {
+			if !declared[member] {
+				removed = append(removed, member)
+			}
+		}
+		if len(added) > 0 {
+			change.Changes = append(change.Changes, "members added: "+strings.Join(added, ","))
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				return probe.NewError(t.adm.UpdateGroupMembers(ctx, madmin.GroupAddRemove{Group: g.Name, Members: added})).Trace(g.Name)
+			})
+		}
+		if len(removed) > 0 {
+			change.Changes = append(change.Changes, "members removed: "+strings.Join(removed, ","))
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				return probe.NewError(t.adm.UpdateGroupMembers(ctx, madmin.GroupAddRemove{Group: g.Name, Members: removed, IsRemove: true})).Trace(g.Name)
+			})
+		}
+		if policies != "" && policies != joinPolicies([]string{cur.Policy}) {
+			change.Changes = append(change.Changes, describeChange("policies", cur.Policy, policies, change.Action))
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				return probe.NewError(t.adm.SetPolicy(ctx, policies, g.Name, true)).Trace(g.Name)
+			})
+		}
+		if len(change.Changes) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	for _, s := range m.ServiceAccounts {
+		s := s
+		cur, ok := st.serviceAccounts[s.AccessKey]
+		change := applyChange{Action: "update", Resource: "service account", Name: s.AccessKey}
+		switch {
+		case !ok:
+			change.Action = "create"
+			change.Changes = append(change.Changes, "user: "+s.User)
+			if s.Policy != "" {
+				change.Changes = append(change.Changes, "policy: "+s.Policy)
+			}
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				_, e := t.adm.AddServiceAccount(ctx, madmin.AddServiceAccountReq{
+					Policy:     rawPolicy(s.policyJSON),
+					TargetUser: s.User,
+					AccessKey:  s.AccessKey,
+					SecretKey:  s.SecretKey,
+				})
+				return probe.NewError(e).Trace(s.AccessKey)
+			})
+		case s.policyJSON != "" && s.policyJSON != cur.Policy:
+			change.Changes = append(change.Changes, "policy: "+s.Policy)
+			change.steps = append(change.steps, func(ctx context.Context) *probe.Error {
+				e := t.adm.UpdateServiceAccount(ctx, s.AccessKey, madmin.UpdateServiceAccountReq{NewPolicy: rawPolicy(s.policyJSON)})
+				return probe.NewError(e).Trace(s.AccessKey)
+			})
+		}
+		if len(change.steps) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// rawPolicy returns a policy document for the admin API, nil when empty.
+func rawPolicy(doc string) []byte {
+	if doc == "" {
+		return nil
+	}
+	return []byte(doc)
+}
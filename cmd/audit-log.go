@@ -0,0 +1,179 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// auditLogFlagUsage describes the --audit-log flag of the commands computing
+// access statistics, the servers do not retain the audit entries.
+const auditLogFlagUsage = "file of audit entries, one JSON document per line as sent to an audit webhook, gzip compressed or '-' for STDIN"
+
+// auditEntry is the part of the audit entries of MinIO used for access
+// statistics. Newer servers report the access key and the bytes
+// transferred, they are otherwise deduced from the request and the
+// response.
+type auditEntry struct {
+	DeploymentID string `json:"deploymentid"`
+	Time         string `json:"time"`
+	AccessKey    string `json:"accessKey"`
+	API          struct {
+		Name       string `json:"name"`
+		Bucket     string `json:"bucket"`
+		Object     string `json:"object"`
+		StatusCode int    `json:"statusCode"`
+		RX         int64  `json:"rx"`
+		TX         int64  `json:"tx"`
+	} `json:"api"`
+	RemoteHost string                 `json:"remotehost"`
+	ReqClaims  map[string]interface{} `json:"requestClaims"`
+	ReqQuery   map[string]string      `json:"requestQuery"`
+	ReqHeader  map[string]string      `json:"requestHeader"`
+	RespHeader map[string]string      `json:"responseHeader"`
+}
+
+// auditRecord is an audited request.
+type auditRecord struct {
+	DeploymentID string
+	Time         time.Time
+	AccessKey    string
+	API          string
+	Bucket       string
+	Object       string
+	StatusCode   int
+	RX           int64
+	TX           int64
+	RemoteHost   string
+}
+
+// Access key of the credential of a request signed with v4, in the
+// Authorization header or in the query of a presigned URL.
+var auditCredentialRegexp = regexp.MustCompile(`Credential=([^/,]+)/`)
+
+// parseAuditRecord decodes an audit entry.
+func parseAuditRecord(data []byte) (auditRecord, error) {
+	var entry auditEntry
+	if e := json.Unmarshal(data, &entry); e != nil {
+		return auditRecord{}, e
+	}
+	r := auditRecord{
+		DeploymentID: entry.DeploymentID,
+		AccessKey:    entry.AccessKey,
+		API:          entry.API.Name,
+		Bucket:       entry.API.Bucket,
+		Object:       entry.API.Object,
+		StatusCode:   entry.API.StatusCode,
+		RX:           entry.API.RX,
+		TX:           entry.API.TX,
+		RemoteHost:   entry.RemoteHost,
+	}
+	r.Time, _ = time.Parse(time.RFC3339Nano, entry.Time)
+	if r.AccessKey == "" {
+		if accessKey, ok := entry.ReqClaims["accessKey"].(string); ok {
+			r.AccessKey = accessKey
+		}
+	}
+	if r.AccessKey == "" {
+		if m := auditCredentialRegexp.FindStringSubmatch(entry.ReqHeader["Authorization"]); m != nil {
+			r.AccessKey = m[1]
+		} else if credential := entry.ReqQuery["X-Amz-Credential"]; credential != "" {
+			r.AccessKey = strings.SplitN(credential, "/", 2)[0]
+		} else if credential := entry.ReqQuery["AWSAccessKeyId"]; credential != "" {
+			r.AccessKey = credential
+		}
+	}
+	if r.RX == 0 {
+		r.RX, _ = strconv.ParseInt(entry.ReqHeader["Content-Length"], 10, 64)
+	}
+	if r.TX == 0 {
+		r.TX, _ = strconv.ParseInt(entry.RespHeader["Content-Length"], 10, 64)
+	}
+	return r, nil
+}
+
+// readAuditLogs calls fn with the records logged within since and until,
+// a zero time is not a bound. Lines which are not audit entries are
+// skipped and counted.
+func readAuditLogs(ctx context.Context, files []string, since, until time.Time, fn func(auditRecord)) (skipped int, err *probe.Error) {
+	for _, file := range files {
+		n, err := readAuditLog(ctx, file, since, until, fn)
+		skipped += n
+		if err != nil {
+			return skipped, err.Trace(file)
+		}
+	}
+	return skipped, nil
+}
+
+func readAuditLog(ctx context.Context, file string, since, until time.Time, fn func(auditRecord)) (skipped int, err *probe.Error) {
+	var reader io.Reader = os.Stdin
+	if file != "-" {
+		f, e := os.Open(file)
+		if e != nil {
+			return 0, probe.NewError(e)
+		}
+		defer f.Close()
+		reader = f
+	}
+	br := bufio.NewReader(reader)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, e := gzip.NewReader(br)
+		if e != nil {
+			return 0, probe.NewError(e)
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	scanner := bufio.NewScanner(br)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return skipped, probe.NewError(ctx.Err())
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		r, e := parseAuditRecord(line)
+		if e != nil || r.Time.IsZero() || r.API == "" {
+			skipped++
+			continue
+		}
+		if !since.IsZero() && r.Time.Before(since) || !until.IsZero() && r.Time.After(until) {
+			continue
+		}
+		fn(r)
+	}
+	if e := scanner.Err(); e != nil {
+		return skipped, probe.NewError(e)
+	}
+	return skipped, nil
+}
@@ -0,0 +1,108 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseAuditRecord(t *testing.T) {
+	testCases := []struct {
+		entry     string
+		accessKey string
+		rx, tx    int64
+	}{
+		{`{"time":"2021-06-01T10:00:00Z","accessKey":"svc1","api":{"name":"GetObject","rx":10,"tx":20}}`, "svc1", 10, 20},
+		{`{"time":"2021-06-01T10:00:00Z","api":{"name":"GetObject"},"requestClaims":{"accessKey":"svc2"}}`, "svc2", 0, 0},
+		{`{"time":"2021-06-01T10:00:00Z","api":{"name":"PutObject"},"requestHeader":{"Authorization":"AWS4-HMAC-SHA256 Credential=svc3/20210601/us-east-1/s3/aws4_request, SignedHeaders=host","Content-Length":"42"}}`, "svc3", 42, 0},
+		{`{"time":"2021-06-01T10:00:00Z","api":{"name":"GetObject"},"requestQuery":{"X-Amz-Credential":"svc4/20210601/us-east-1/s3/aws4_request"},"responseHeader":{"Content-Length":"7"}}`, "svc4", 0, 7},
+		{`{"time":"2021-06-01T10:00:00Z","api":{"name":"GetObject"},"requestQuery":{"AWSAccessKeyId":"svc5"}}`, "svc5", 0, 0},
+		{`{"time":"2021-06-01T10:00:00Z","api":{"name":"ListBuckets"}}`, "", 0, 0},
+	}
+	for i, testCase := range testCases {
+		r, e := parseAuditRecord([]byte(testCase.entry))
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if r.AccessKey != testCase.accessKey || r.RX != testCase.rx || r.TX != testCase.tx {
+			t.Errorf("Test %d: expected %s %d/%d, got %s %d/%d", i+1, testCase.accessKey, testCase.rx, testCase.tx, r.AccessKey, r.RX, r.TX)
+		}
+		if !r.Time.Equal(time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)) {
+			t.Errorf("Test %d: unexpected time %v", i+1, r.Time)
+		}
+	}
+}
+
+func TestReadAuditLogs(t *testing.T) {
+	dir, e := ioutil.TempDir("", "audit-log")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	plain := filepath.Join(dir, "audit.ndjson")
+	data := `{"time":"2021-06-01T10:00:00Z","accessKey":"svc1","api":{"name":"GetObject"}}
+not an audit entry
+
+{"time":"2021-06-03T10:00:00Z","accessKey":"svc1","api":{"name":"PutObject"}}
+`
+	if e = ioutil.WriteFile(plain, []byte(data), 0600); e != nil {
+		t.Fatal(e)
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(`{"time":"2021-06-02T10:00:00Z","accessKey":"svc1","api":{"name":"DeleteObject"}}` + "\n"))
+	gz.Close()
+	compressed := filepath.Join(dir, "audit.ndjson.gz")
+	if e = ioutil.WriteFile(compressed, buf.Bytes(), 0600); e != nil {
+		t.Fatal(e)
+	}
+
+	var apis []string
+	skipped, err := readAuditLogs(context.Background(), []string{plain, compressed}, time.Time{}, time.Time{}, func(r auditRecord) {
+		apis = append(apis, r.API)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 1 || len(apis) != 3 || apis[2] != "DeleteObject" {
+		t.Fatalf("unexpected records %v, %d skipped", apis, skipped)
+	}
+
+	apis = nil
+	since := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	until := time.Date(2021, 6, 2, 12, 0, 0, 0, time.UTC)
+	if _, err = readAuditLogs(context.Background(), []string{plain, compressed}, since, until, func(r auditRecord) {
+		apis = append(apis, r.API)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(apis) != 1 || apis[0] != "DeleteObject" {
+		t.Fatalf("unexpected records within the window %v", apis)
+	}
+
+	if _, err = readAuditLogs(context.Background(), []string{filepath.Join(dir, "missing")}, since, until, func(auditRecord) {}); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
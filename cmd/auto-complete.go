@@ -17,12 +17,17 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/mcconfig"
 	"github.com/posener/complete"
 )
 
@@ -79,30 +84,126 @@ func completeAdminConfigKeys(aliasPath string, keyPrefix string) (prediction []s
 	return prediction
 }
 
-// Complete S3 path. If the prediction result is only one directory,
-// then recursively scans it. This is needed to satisfy posener/complete
-// (look at posener/complete.PredictFiles)
-func completeS3Path(s3Path string) (prediction []string) {
+// Remote listings used for completion are cached for a short
+// while, each completion request runs a new mc process.
+const (
+	completionCacheFile = "completion-cache.json"
+	completionCacheTTL  = 30 * time.Second
 
-	// Convert alias/bucket/incompl to alias/bucket/ to list its contents
-	parentDirPath := filepath.Dir(s3Path) + "/"
-	clnt, err := newClient(parentDirPath)
+	// Completion must not hang the shell when a server is unreachable.
+	completionTimeout = 2 * time.Second
+)
+
+// completionCacheEntry holds the listing of one remote directory.
+type completionCacheEntry struct {
+	Time    time.Time `json:"time"`
+	Entries []string  `json:"entries"`
+}
+
+// completionCachePath returns the cache file of the selected profile.
+func completionCachePath() string {
+	if mcProfile == "" {
+		return filepath.Join(mustGetMcConfigDir(), completionCacheFile)
+	}
+	return filepath.Join(mustGetMcConfigDir(), mcProfile+"-"+completionCacheFile)
+}
+
+// completionCacheEnabled returns false when the config file is encrypted,
+// the alias, bucket and prefix names must not be left in plain text next
+// to it. A cache written before the encryption is removed.
+func completionCacheEnabled() bool {
+	data, e := ioutil.ReadFile(mustGetMcConfigPath())
+	if e != nil || !mcconfig.IsEncrypted(data) {
+		return true
+	}
+	os.Remove(completionCachePath())
+	return false
+}
+
+func loadCompletionCache() map[string]completionCacheEntry {
+	cache := make(map[string]completionCacheEntry)
+	buf, e := ioutil.ReadFile(completionCachePath())
+	if e != nil {
+		return cache
+	}
+	if e = json.Unmarshal(buf, &cache); e != nil {
+		return make(map[string]completionCacheEntry)
+	}
+	return cache
+}
+
+func saveCompletionCache(cache map[string]completionCacheEntry) {
+	for dir, entry := range cache {
+		if time.Since(entry.Time) > completionCacheTTL {
+			delete(cache, dir)
+		}
+	}
+	buf, e := json.Marshal(cache)
+	if e != nil {
+		return
+	}
+	ioutil.WriteFile(completionCachePath(), buf, 0600)
+}
+
+// listS3Dir lists the content of a remote directory for completion,
+// directories are returned with a trailing '/'.
+func listS3Dir(dirPath string) []string {
+	useCache := completionCacheEnabled()
+	cache := make(map[string]completionCacheEntry)
+	if useCache {
+		cache = loadCompletionCache()
+	}
+	if entry, ok := cache[dirPath]; ok && time.Since(entry.Time) < completionCacheTTL {
+		return entry.Entries
+	}
+
+	clnt, err := newClient(dirPath)
 	if err != nil {
 		return nil
 	}
 
 	// Calculate alias from the path
-	alias := splitStr(s3Path, "/", 3)[0]
+	alias := splitStr(dirPath, "/", 3)[0]
 
-	// List dirPath content and only pick elements that corresponds
-	// to the path that we want to complete
-	for content := range clnt.List(globalContext, ListOptions{Recursive: false, ShowDir: DirFirst}) {
+	ctx, cancel := context.WithTimeout(globalContext, completionTimeout)
+	defer cancel()
+
+	entries := []string{}
+	for content := range clnt.List(ctx, ListOptions{Recursive: false, ShowDir: DirFirst}) {
+		if content.Err != nil {
+			// Do not cache partial or failed listings.
+			return entries
+		}
 		cmplS3Path := alias + getKey(content)
 		if content.Type.IsDir() {
 			if !strings.HasSuffix(cmplS3Path, "/") {
 				cmplS3Path += "/"
 			}
 		}
+		entries = append(entries, cmplS3Path)
+	}
+	if ctx.Err() != nil {
+		return entries
+	}
+
+	if useCache {
+		cache[dirPath] = completionCacheEntry{Time: time.Now(), Entries: entries}
+		saveCompletionCache(cache)
+	}
+	return entries
+}
+
+// Complete S3 path. If the prediction result is only one directory,
+// then recursively scans it. This is needed to satisfy posener/complete
+// (look at posener/complete.PredictFiles)
+func completeS3Path(s3Path string) (prediction []string) {
+
+	// Convert alias/bucket/incompl to alias/bucket/ to list its contents
+	parentDirPath := filepath.Dir(s3Path) + "/"
+
+	// List dirPath content and only pick elements that corresponds
+	// to the path that we want to complete
+	for _, cmplS3Path := range listS3Dir(parentDirPath) {
 		if strings.HasPrefix(cmplS3Path, s3Path) {
 			prediction = append(prediction, cmplS3Path)
 		}
@@ -219,6 +320,33 @@ func (al aliasComplete) Predict(a complete.Args) (prediction []string) {
 	return
 }
 
+// macroComplete completes the names of the macros defined in the config
+type macroComplete struct{}
+
+func (m macroComplete) Predict(a complete.Args) (prediction []string) {
+	defer func() {
+		sort.Strings(prediction)
+	}()
+
+	// Only the first argument is a macro name.
+	if len(a.Completed) > 0 {
+		return nil
+	}
+
+	loadMcConfig = loadMcConfigFactory()
+	conf, err := loadMcConfig()
+	if err != nil {
+		return nil
+	}
+	for name := range conf.Macros {
+		if strings.HasPrefix(name, a.Last) {
+			prediction = append(prediction, name)
+		}
+	}
+	return
+}
+
+var macroCompleter = macroComplete{}
 var adminConfigCompleter = adminConfigComplete{}
 var s3Completer = s3Complete{}
 var aliasCompleter = aliasComplete{}
@@ -239,26 +367,38 @@ var completeCmds = map[string]complete.Predictor{
 	"/find":   complete.PredictOr(s3Completer, fsCompleter),
 	"/mirror": complete.PredictOr(s3Completer, fsCompleter),
 	"/pipe":   complete.PredictOr(s3Completer, fsCompleter),
+	"/get":    complete.PredictOr(s3Completer, fsCompleter),
+	"/put":    complete.PredictOr(fsCompleter, s3Completer),
 	"/stat":   complete.PredictOr(s3Completer, fsCompleter),
 	"/watch":  complete.PredictOr(s3Completer, fsCompleter),
 	"/policy": complete.PredictOr(s3Completer, fsCompleter),
 	"/tree":   complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 	"/du":     complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 
-	"/retention/set":   s3Completer,
-	"/retention/clear": s3Completer,
-	"/retention/info":  s3Completer,
+	"/checksum": complete.PredictOr(s3Completer, fsCompleter),
+	"/ready":    aliasCompleter,
+	"/ping":     aliasCompleter,
+
+	"/retention/set":    s3Completer,
+	"/retention/clear":  s3Completer,
+	"/retention/info":   s3Completer,
+	"/retention/report": s3Completer,
 
 	"/legalhold/set":   s3Completer,
 	"/legalhold/clear": s3Completer,
 	"/legalhold/info":  s3Completer,
 
-	"/sql": s3Completer,
-	"/mb":  aliasCompleter,
+	"/sql":  s3Completer,
+	"/grep": s3Completer,
+	"/mb":   aliasCompleter,
+
+	"/automate": s3Complete{deepLevel: 2},
 
 	"/event/add":    s3Complete{deepLevel: 2},
 	"/event/list":   s3Complete{deepLevel: 2},
 	"/event/remove": s3Complete{deepLevel: 2},
+	"/event/export": s3Complete{deepLevel: 2},
+	"/event/import": s3Complete{deepLevel: 2},
 
 	"/encrypt/set":   s3Complete{deepLevel: 2},
 	"/encrypt/info":  s3Complete{deepLevel: 2},
@@ -272,10 +412,29 @@ var completeCmds = map[string]complete.Predictor{
 	"/replicate/import": s3Complete{deepLevel: 2},
 	"/replicate/status": s3Complete{deepLevel: 2},
 
+	"/apply": aliasCompleter,
+
+	"/batch/generate": nil,
+	"/batch/start":    aliasCompleter,
+	"/batch/status":   aliasCompleter,
+	"/batch/describe": aliasCompleter,
+	"/batch/cancel":   aliasCompleter,
+
 	"/tag/list":   s3Completer,
 	"/tag/remove": s3Completer,
 	"/tag/set":    s3Completer,
 
+	"/metadata/get":              s3Completer,
+	"/metadata/set":              s3Completer,
+	"/metadata/rm":               s3Completer,
+	"/metadata/fix-content-type": s3Completer,
+
+	"/snapshot/create": s3Completer,
+	"/snapshot/diff":   complete.PredictOr(fsCompleter, s3Completer),
+
+	"/website/deploy": complete.PredictOr(fsCompleter, s3Completer),
+	"/website/config": s3Complete{deepLevel: 2},
+
 	"/version/info":    s3Complete{deepLevel: 2},
 	"/version/enable":  s3Complete{deepLevel: 2},
 	"/version/suspend": s3Complete{deepLevel: 2},
@@ -284,9 +443,11 @@ var completeCmds = map[string]complete.Predictor{
 	"/lock/governance": s3Completer,
 	"/lock/clear":      s3Completer,
 	"/lock/info":       s3Completer,
+	"/lock/edit":       s3Complete{deepLevel: 2},
 
 	"/share/download": s3Completer,
 	"/share/list":     nil,
+	"/share/revoke":   s3Completer,
 	"/share/upload":   s3Completer,
 
 	"/ilm/ls":     s3Complete{deepLevel: 2},
@@ -298,10 +459,32 @@ var completeCmds = map[string]complete.Predictor{
 
 	"/undo": s3Completer,
 
+	"/trash/ls":      s3Completer,
+	"/trash/restore": s3Completer,
+
+	"/shell": s3Complete{deepLevel: 2},
+	"/run":   macroCompleter,
+
 	// Admin API commands MinIO only.
-	"/admin/heal": s3Completer,
+	"/admin/heal":       s3Completer,
+	"/admin/heal/drive": aliasCompleter,
 
-	"/admin/info": aliasCompleter,
+	"/admin/info":     aliasCompleter,
+	"/admin/topology": aliasCompleter,
+	"/admin/diag":     aliasCompleter,
+
+	"/admin/replicate/add":    aliasCompleter,
+	"/admin/replicate/ls":     aliasCompleter,
+	"/admin/replicate/status": aliasCompleter,
+	"/admin/replicate/rm":     aliasCompleter,
+
+	"/admin/cluster/bucket/export": aliasCompleter,
+	"/admin/cluster/bucket/import": aliasCompleter,
+
+	"/admin/audit/target/add":  aliasCompleter,
+	"/admin/audit/target/ls":   aliasCompleter,
+	"/admin/audit/target/test": aliasCompleter,
+	"/admin/audit/target/rm":   aliasCompleter,
 
 	"/admin/config/get":     adminConfigCompleter,
 	"/admin/config/set":     adminConfigCompleter,
@@ -310,19 +493,28 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/config/export":  aliasCompleter,
 	"/admin/config/history": aliasCompleter,
 	"/admin/config/restore": aliasCompleter,
+	"/admin/config/search":  aliasCompleter,
 
 	"/admin/trace":     aliasCompleter,
 	"/admin/console":   aliasCompleter,
 	"/admin/update":    aliasCompleter,
 	"/admin/top/locks": aliasCompleter,
+	"/admin/top/api":   aliasCompleter,
+	"/admin/top/drive": aliasCompleter,
+
+	"/admin/logs/export": aliasCompleter,
+
+	"/admin/accesses": aliasCompleter,
 
 	"/admin/service/stop":    aliasCompleter,
 	"/admin/service/restart": aliasCompleter,
 
 	"/admin/prometheus/generate": aliasCompleter,
+	"/admin/prometheus/metrics":  aliasCompleter,
 
 	"/admin/profile/start": aliasCompleter,
 	"/admin/profile/stop":  aliasCompleter,
+	"/admin/profile/serve": aliasCompleter,
 
 	"/admin/policy/info":   aliasCompleter,
 	"/admin/policy/set":    aliasCompleter,
@@ -331,6 +523,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/policy/add":    aliasCompleter,
 	"/admin/policy/list":   aliasCompleter,
 	"/admin/policy/remove": aliasCompleter,
+	"/admin/policy/render": fsCompleter,
 
 	"/admin/user/add":     aliasCompleter,
 	"/admin/user/disable": aliasCompleter,
@@ -347,6 +540,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/user/svcacct/set":     aliasCompleter,
 	"/admin/user/svcacct/enable":  aliasCompleter,
 	"/admin/user/svcacct/disable": aliasCompleter,
+	"/admin/user/svcacct/stats":   aliasCompleter,
 
 	"/admin/group/add":     aliasCompleter,
 	"/admin/group/disable": aliasCompleter,
@@ -367,9 +561,33 @@ var completeCmds = map[string]complete.Predictor{
 
 	"/admin/subnet/health": aliasCompleter,
 
-	"/alias/set":    nil,
-	"/alias/list":   aliasCompleter,
-	"/alias/remove": aliasCompleter,
+	"/alias/set":     nil,
+	"/alias/list":    aliasCompleter,
+	"/alias/remove":  aliasCompleter,
+	"/alias/migrate": aliasCompleter,
+	"/alias/import":  nil,
+	"/alias/export":  aliasCompleter,
+	"/alias/test":    aliasCompleter,
+
+	"/session/list":   nil,
+	"/session/resume": nil,
+	"/session/prune":  nil,
+
+	"/daemon/start":  nil,
+	"/daemon/submit": nil,
+	"/daemon/list":   nil,
+	"/daemon/cancel": nil,
+	"/daemon/pause":  nil,
+	"/daemon/resume": nil,
+
+	"/config/encrypt":  nil,
+	"/config/decrypt":  nil,
+	"/config/theme":    nil,
+	"/config/defaults": aliasCompleter,
+
+	"/config/profile/add":    nil,
+	"/config/profile/list":   nil,
+	"/config/profile/remove": nil,
 
 	"/update": nil,
 }
@@ -18,7 +18,10 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/minio/cli"
 )
@@ -58,3 +61,31 @@ func TestAutoCompletionCompletness(t *testing.T) {
 
 	}
 }
+
+func TestCompletionCacheEnabled(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-config-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	defer setMcConfigDir(mcCustomConfigDir)
+	setMcConfigDir(dir)
+
+	if !completionCacheEnabled() {
+		t.Fatal("expected the cache to be enabled without a config file")
+	}
+	saveCompletionCache(map[string]completionCacheEntry{"play/": {Time: time.Now(), Entries: []string{"play/mybucket/"}}})
+	if len(loadCompletionCache()) != 1 {
+		t.Fatal("expected the cache to be saved")
+	}
+
+	if err := saveEncryptedConfigV10(mustGetMcConfigPath(), testConfigV10(), "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	if completionCacheEnabled() {
+		t.Fatal("expected the cache to be disabled with an encrypted config")
+	}
+	if _, e = os.Stat(completionCachePath()); !os.IsNotExist(e) {
+		t.Fatalf("expected the cache to be removed, got %v", e)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// Timeout of a webhook call.
+const automateWebhookTimeout = 30 * time.Second
+
+// automateEvent is an object event, it is the payload of webhooks and
+// the record of the dead letter file.
+type automateEvent struct {
+	Time   string `json:"time"`
+	Event  string `json:"event"`
+	Rule   string `json:"rule"`
+	URL    string `json:"url"`
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	Size   int64  `json:"size"`
+}
+
+// vars returns the placeholder values of an event.
+func (e automateEvent) vars() map[string]string {
+	return map[string]string{
+		"{}":       e.URL,
+		"{bucket}": e.Bucket,
+		"{key}":    e.Key,
+		"{size}":   strconv.FormatInt(e.Size, 10),
+		"{event}":  e.Event,
+	}
+}
+
+// expand replaces the placeholders of an action argument.
+func (e automateEvent) expand(arg string) string {
+	var oldnew []string
+	for placeholder, value := range e.vars() {
+		oldnew = append(oldnew, placeholder, value)
+	}
+	return strings.NewReplacer(oldnew...).Replace(arg)
+}
+
+// automateDeadLetter appends the events whose actions failed to a file,
+// one JSON document per line.
+type automateDeadLetter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+type automateDeadLetterRecord struct {
+	automateEvent
+	Action   string `json:"action"`
+	Argument string `json:"argument"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+}
+
+func openAutomateDeadLetter(path string) (*automateDeadLetter, *probe.Error) {
+	file, e := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return &automateDeadLetter{file: file}, nil
+}
+
+func (d *automateDeadLetter) write(record automateDeadLetterRecord) *probe.Error {
+	data, e := json.Marshal(record)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, e = d.file.Write(append(data, '\n'))
+	return probe.NewError(e)
+}
+
+func (d *automateDeadLetter) Close() error {
+	return d.file.Close()
+}
+
+// automateRunner runs the actions of the rules matching the events.
+type automateRunner struct {
+	rules      *automateRules
+	alias      string
+	deadLetter *automateDeadLetter
+}
+
+// handle runs the rules matching an event.
+func (r *automateRunner) handle(ctx context.Context, info EventInfo) {
+	u := newClientURL(info.Path)
+	parts := splitStr(strings.TrimPrefix(u.Path, string(u.Separator)), string(u.Separator), 2)
+	event := automateEvent{
+		Time:   info.Time,
+		Event:  string(info.Type),
+		URL:    r.alias + u.Path,
+		Bucket: parts[0],
+		Key:    parts[1],
+		Size:   info.Size,
+	}
+	for _, rule := range r.rules.Rules {
+		if !rule.matches(event.Event, event.Key) {
+			continue
+		}
+		event.Rule = rule.Name
+		for _, action := range rule.Actions {
+			kind, arg := action.kind()
+			attempts, err := r.retry(ctx, func() *probe.Error {
+				return r.run(ctx, kind, arg, event)
+			})
+			if err != nil {
+				errorIf(err.Trace(event.URL), "Rule `%s`: %s of `%s` failed after %d attempt(s).", rule.Name, kind, event.URL, attempts)
+				if r.deadLetter != nil {
+					werr := r.deadLetter.write(automateDeadLetterRecord{
+						automateEvent: event,
+						Action:        kind,
+						Argument:      arg,
+						Attempts:      attempts,
+						Error:         err.ToGoError().Error(),
+					})
+					errorIf(werr.Trace(), "Unable to write to the dead letter file.")
+				}
+				// The next actions may depend on this one.
+				break
+			}
+			printMsg(automateMessage{
+				Status:   "success",
+				Time:     event.Time,
+				Rule:     rule.Name,
+				Action:   kind,
+				Argument: arg,
+				Event:    event.Event,
+				URL:      event.URL,
+				Attempts: attempts,
+			})
+		}
+	}
+}
+
+// retry calls fn until it succeeds or the attempts are exhausted, the
+// delay doubles after each attempt.
+func (r *automateRunner) retry(ctx context.Context, fn func() *probe.Error) (attempts int, err *probe.Error) {
+	retryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The timer waits twice its unit before the first retry.
+	for range newRetryTimerContinous(retryCtx, r.rules.delay/2, time.Minute, minio.NoJitter) {
+		attempts++
+		if err = fn(); err == nil || attempts >= r.rules.Retry.Attempts {
+			break
+		}
+	}
+	if err == nil && ctx.Err() != nil {
+		err = probe.NewError(ctx.Err())
+	}
+	return attempts, err
+}
+
+// run runs an action once.
+func (r *automateRunner) run(ctx context.Context, kind, arg string, event automateEvent) *probe.Error {
+	switch kind {
+	case "copy":
+		return automateCopy(ctx, r.alias, event, event.expand(arg))
+	case "tag":
+		clnt, err := newClient(event.URL)
+		if err != nil {
+			return err.Trace(event.URL)
+		}
+		return clnt.SetTags(ctx, "", event.expand(arg)).Trace(event.URL)
+	case "webhook":
+		return automateWebhook(ctx, event, event.expand(arg))
+	}
+	return runShellCommand(ctx, expandOnCompleteCommand(arg, event.vars()))
+}
+
+// automateCopy copies the object of an event, the key is appended to a
+// target ending with a separator.
+func automateCopy(ctx context.Context, alias string, event automateEvent, target string) *probe.Error {
+	if strings.HasSuffix(target, "/") {
+		target += event.Key
+	}
+	_, content, err := url2Stat(ctx, event.URL, "", false, nil, time.Time{})
+	if err != nil {
+		return err.Trace(event.URL)
+	}
+	targetAlias, targetURL, _, err := expandAlias(target)
+	if err != nil {
+		return err.Trace(target)
+	}
+	urls := URLs{
+		SourceAlias:   alias,
+		SourceContent: content,
+		TargetAlias:   targetAlias,
+		TargetContent: &ClientContent{URL: *newClientURL(targetURL)},
+	}
+	return uploadSourceToTargetURL(ctx, urls, nil, nil, false).Error
+}
+
+// automateWebhook posts the event as JSON to a URL.
+func automateWebhook(ctx context.Context, event automateEvent, endpoint string) *probe.Error {
+	payload, e := json.Marshal(event)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	ctx, cancel := context.WithTimeout(ctx, automateWebhookTimeout)
+	defer cancel()
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, e := http.DefaultClient.Do(req)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return probe.NewError(fmt.Errorf("webhook `%s` returned %s", endpoint, resp.Status))
+	}
+	return nil
+}
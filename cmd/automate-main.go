@@ -0,0 +1,382 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var automateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "file, f",
+		Usage: "path to the YAML rules",
+	},
+	cli.IntFlag{
+		Name:  "workers",
+		Usage: "number of events processed in parallel",
+		Value: 4,
+	},
+}
+
+var automateCmd = cli.Command{
+	Name:         "automate",
+	Usage:        "run actions on bucket events matching rules",
+	Action:       mainAutomate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(automateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} -f FILE [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Listen to the events of a bucket and run the actions of the rules matching
+   each event, until interrupted. The connection is reopened when it is lost.
+   A failing action is retried, once the attempts are exhausted the event is
+   appended to the dead letter file and the next actions of the rule are
+   skipped.
+
+   Actions:
+     copy: TARGET     copy the object, the key is appended to a TARGET ending with '/'
+     tag: TAGS        set the tags of the object, e.g. 'stage=ingested&owner=etl'
+     webhook: URL     POST the event as JSON to the URL
+     run: COMMAND     run a shell command
+
+   The placeholders {} (ALIAS/BUCKET/KEY), {bucket}, {key}, {size} and
+   {event} are replaced in the actions, they are quoted for the shell in
+   the commands.
+
+RULES:
+   rules:
+     - name: ingest-csv
+       events: [put]                # put, delete or get, put by default
+       prefix: incoming/
+       suffix: .csv
+       actions:
+         - copy: archive/raw/
+         - tag: stage=ingested
+         - webhook: https://etl.example.com/hooks/new-file
+         - run: ./ingest.sh {bucket} {key}
+   retry:
+     attempts: 5                    # 3 by default
+     delay: 2s                      # doubled after each attempt, 1s by default
+   deadLetter: automate-failed.json # failed events as JSON lines
+
+EXAMPLES:
+  1. Run the rules of rules.yaml on the events of a bucket.
+     {{.Prompt}} {{.HelpName}} -f rules.yaml myminio/mybucket
+
+  2. Process up to 16 events in parallel.
+     {{.Prompt}} {{.HelpName}} -f rules.yaml --workers 16 myminio/mybucket
+`,
+}
+
+// automateRules are the rules run by 'mc automate'.
+type automateRules struct {
+	Rules      []automateRule `yaml:"rules"`
+	Retry      automateRetry  `yaml:"retry,omitempty"`
+	DeadLetter string         `yaml:"deadLetter,omitempty"`
+
+	delay time.Duration
+}
+
+// automateRule maps the events of objects matching a prefix and a
+// suffix to actions run in order.
+type automateRule struct {
+	Name    string           `yaml:"name"`
+	Events  []string         `yaml:"events,omitempty"`
+	Prefix  string           `yaml:"prefix,omitempty"`
+	Suffix  string           `yaml:"suffix,omitempty"`
+	Actions []automateAction `yaml:"actions"`
+}
+
+type automateRetry struct {
+	Attempts int    `yaml:"attempts,omitempty"`
+	Delay    string `yaml:"delay,omitempty"`
+}
+
+// automateAction holds exactly one action.
+type automateAction struct {
+	Copy    string `yaml:"copy,omitempty"`
+	Tag     string `yaml:"tag,omitempty"`
+	Webhook string `yaml:"webhook,omitempty"`
+	Run     string `yaml:"run,omitempty"`
+}
+
+// kind returns the type of the action and its argument.
+func (a automateAction) kind() (string, string) {
+	switch {
+	case a.Copy != "":
+		return "copy", a.Copy
+	case a.Tag != "":
+		return "tag", a.Tag
+	case a.Webhook != "":
+		return "webhook", a.Webhook
+	}
+	return "run", a.Run
+}
+
+// automateEventPrefixes maps the event names of the rules to the
+// prefixes of the notification event types.
+var automateEventPrefixes = map[string]string{
+	"put":    "s3:ObjectCreated:",
+	"delete": "s3:ObjectRemoved:",
+	"get":    "s3:ObjectAccessed:",
+}
+
+// parseAutomateRules parses and validates the rules, the dead letter
+// file is relative to dir.
+func parseAutomateRules(data []byte, dir string) (*automateRules, *probe.Error) {
+	var r automateRules
+	if e := yaml.UnmarshalStrict(data, &r); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if len(r.Rules) == 0 {
+		return nil, probe.NewError(fmt.Errorf("no rules are defined"))
+	}
+	names := map[string]bool{}
+	for i := range r.Rules {
+		rule := &r.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if names[rule.Name] {
+			return nil, probe.NewError(fmt.Errorf("rule `%s` is defined more than once", rule.Name))
+		}
+		names[rule.Name] = true
+		if len(rule.Events) == 0 {
+			rule.Events = []string{"put"}
+		}
+		for _, event := range rule.Events {
+			if _, ok := automateEventPrefixes[event]; !ok {
+				return nil, probe.NewError(fmt.Errorf("rule `%s` has an invalid event `%s`, expected 'put', 'delete' or 'get'", rule.Name, event))
+			}
+		}
+		if len(rule.Actions) == 0 {
+			return nil, probe.NewError(fmt.Errorf("rule `%s` has no actions", rule.Name))
+		}
+		for _, action := range rule.Actions {
+			set := 0
+			for _, arg := range []string{action.Copy, action.Tag, action.Webhook, action.Run} {
+				if arg != "" {
+					set++
+				}
+			}
+			if set != 1 {
+				return nil, probe.NewError(fmt.Errorf("rule `%s` has an action which is not exactly one of copy, tag, webhook or run", rule.Name))
+			}
+		}
+	}
+
+	if r.Retry.Attempts <= 0 {
+		r.Retry.Attempts = 3
+	}
+	r.delay = time.Second
+	if r.Retry.Delay != "" {
+		delay, e := time.ParseDuration(r.Retry.Delay)
+		if e != nil || delay <= 0 {
+			return nil, probe.NewError(fmt.Errorf("invalid retry delay `%s`", r.Retry.Delay))
+		}
+		r.delay = delay
+	}
+	if r.DeadLetter != "" && !filepath.IsAbs(r.DeadLetter) {
+		r.DeadLetter = filepath.Join(dir, r.DeadLetter)
+	}
+	return &r, nil
+}
+
+// events returns the events to listen to for all rules.
+func (r *automateRules) events() []string {
+	var events []string
+	seen := map[string]bool{}
+	for _, rule := range r.Rules {
+		for _, event := range rule.Events {
+			if !seen[event] {
+				seen[event] = true
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// matches returns true when the rule applies to an event of a key.
+func (rule automateRule) matches(eventType, key string) bool {
+	if !strings.HasPrefix(key, rule.Prefix) || !strings.HasSuffix(key, rule.Suffix) {
+		return false
+	}
+	for _, event := range rule.Events {
+		if strings.HasPrefix(eventType, automateEventPrefixes[event]) {
+			return true
+		}
+	}
+	return false
+}
+
+// automateMessage is printed for each action run successfully.
+type automateMessage struct {
+	Status   string `json:"status"`
+	Time     string `json:"time"`
+	Rule     string `json:"rule"`
+	Action   string `json:"action"`
+	Argument string `json:"argument"`
+	Event    string `json:"event"`
+	URL      string `json:"url"`
+	Attempts int    `json:"attempts"`
+}
+
+func (m automateMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m automateMessage) String() string {
+	msg := console.Colorize("Time", "["+m.Time+"] ")
+	msg += console.Colorize("Rule", m.Rule+" ")
+	msg += m.Action + " " + console.Colorize("ObjectName", m.URL)
+	if m.Attempts > 1 {
+		msg += fmt.Sprintf(" (%d attempts)", m.Attempts)
+	}
+	return msg
+}
+
+func checkAutomateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 || ctx.String("file") == "" || ctx.Int("workers") <= 0 {
+		cli.ShowCommandHelpAndExit(ctx, "automate", globalInvalidArgsExitStatus)
+	}
+}
+
+func mainAutomate(cliCtx *cli.Context) error {
+	ctx, cancelAutomate := context.WithCancel(globalContext)
+	defer cancelAutomate()
+
+	checkAutomateSyntax(cliCtx)
+
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Rule", color.New(color.FgCyan, color.Bold))
+	console.SetColor("ObjectName", color.New(color.Bold))
+
+	targetURL := strings.TrimSuffix(cliCtx.Args().Get(0), "/")
+	rulesFile := cliCtx.String("file")
+
+	data, e := ioutil.ReadFile(rulesFile)
+	fatalIf(probe.NewError(e).Trace(rulesFile), "Unable to read the rules.")
+	rules, err := parseAutomateRules(data, filepath.Dir(rulesFile))
+	fatalIf(err.Trace(rulesFile), "Unable to parse the rules.")
+
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize `%s`.", targetURL)
+	if _, ok := clnt.(*S3Client); !ok {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Only the events of buckets on object storage can be automated.")
+	}
+
+	var deadLetter *automateDeadLetter
+	if rules.DeadLetter != "" {
+		deadLetter, err = openAutomateDeadLetter(rules.DeadLetter)
+		fatalIf(err.Trace(rules.DeadLetter), "Unable to open the dead letter file.")
+		defer deadLetter.Close()
+	}
+
+	alias, _ := url2Alias(targetURL)
+	runner := &automateRunner{rules: rules, alias: alias, deadLetter: deadLetter}
+
+	eventCh := make(chan EventInfo)
+	var wg sync.WaitGroup
+	for i := 0; i < cliCtx.Int("workers"); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for event := range eventCh {
+				runner.handle(ctx, event)
+			}
+		}()
+	}
+
+	options := WatchOptions{Recursive: true, Events: rules.events()}
+	const maxReconnectDelay = 30 * time.Second
+	delay := time.Second
+	for ctx.Err() == nil {
+		wo, err := clnt.Watch(ctx, options)
+		if err != nil {
+			errorIf(err.Trace(targetURL), "Unable to listen to the events of `%s`, retrying in %s.", targetURL, delay)
+		} else if received := automateWatch(ctx, wo, eventCh); received {
+			delay = time.Second
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+	close(eventCh)
+	wg.Wait()
+	return nil
+}
+
+// automateWatch forwards the events of a watch until the connection is
+// lost or the context is canceled, it returns true when events were
+// received.
+func automateWatch(ctx context.Context, wo *WatchObject, eventCh chan<- EventInfo) (received bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			close(wo.DoneChan)
+			return received
+		case events, ok := <-wo.Events():
+			if !ok {
+				return received
+			}
+			received = true
+			for _, event := range events {
+				select {
+				case eventCh <- event:
+				case <-ctx.Done():
+				}
+			}
+		case err, ok := <-wo.Errors():
+			if !ok {
+				return received
+			}
+			// The watch ends by closing its channels.
+			errorIf(err, "Unable to listen to events.")
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAutomateRules(t *testing.T) {
+	r, err := parseAutomateRules([]byte(`
+rules:
+  - name: ingest-csv
+    prefix: incoming/
+    suffix: .csv
+    actions:
+      - copy: archive/raw/
+      - run: ./ingest.sh {bucket} {key}
+  - events: [delete]
+    actions:
+      - webhook: http://localhost:8080/removed
+deadLetter: failed.json
+`), "/var/lib/mc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Retry.Attempts != 3 || r.delay != time.Second {
+		t.Fatalf("unexpected retry defaults %+v %s", r.Retry, r.delay)
+	}
+	if r.DeadLetter != "/var/lib/mc/failed.json" {
+		t.Fatalf("unexpected dead letter file %s", r.DeadLetter)
+	}
+	if r.Rules[1].Name != "rule-2" {
+		t.Fatalf("unexpected rule name %s", r.Rules[1].Name)
+	}
+	if events := r.events(); len(events) != 2 || events[0] != "put" || events[1] != "delete" {
+		t.Fatalf("unexpected events %v", events)
+	}
+
+	invalid := []string{
+		"rules: []\n",
+		"rules:\n  - name: a\n",
+		"rules:\n  - name: a\n    events: [update]\n    actions:\n      - run: ls\n",
+		"rules:\n  - name: a\n    actions:\n      - run: ls\n        tag: a=b\n",
+		"rules:\n  - name: a\n    actions:\n      - run: ls\nretry:\n  delay: soon\n",
+	}
+	for i, rules := range invalid {
+		if _, err := parseAutomateRules([]byte(rules), "."); err == nil {
+			t.Errorf("Test %d: expected an error for %q", i+1, rules)
+		}
+	}
+}
+
+func TestAutomateRuleMatches(t *testing.T) {
+	rule := automateRule{Events: []string{"put"}, Prefix: "incoming/", Suffix: ".csv"}
+	testCases := []struct {
+		event    string
+		key      string
+		expected bool
+	}{
+		{"s3:ObjectCreated:Put", "incoming/a.csv", true},
+		{"s3:ObjectCreated:CompleteMultipartUpload", "incoming/2021/b.csv", true},
+		{"s3:ObjectRemoved:Delete", "incoming/a.csv", false},
+		{"s3:ObjectCreated:Put", "incoming/a.json", false},
+		{"s3:ObjectCreated:Put", "other/a.csv", false},
+	}
+	for i, testCase := range testCases {
+		if got := rule.matches(testCase.event, testCase.key); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+
+	event := automateEvent{URL: "myminio/data/incoming/a.csv", Bucket: "data", Key: "incoming/a.csv", Size: 10}
+	if got := event.expand("archive/{bucket}/{key}?size={size}"); got != "archive/data/incoming/a.csv?size=10" {
+		t.Fatalf("unexpected expansion %s", got)
+	}
+}
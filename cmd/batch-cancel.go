@@ -0,0 +1,66 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var batchCancelCmd = cli.Command{
+	Name:         "cancel",
+	Usage:        "cancel a running batch job",
+	Action:       mainBatchCancel,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET JOBID
+
+  The objects already processed by the job are left as they are.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Cancel job 'K8dFtHmLs' on 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio K8dFtHmLs
+`,
+}
+
+// mainBatchCancel is the handle for "mc batch cancel" command.
+func mainBatchCancel(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "cancel", globalInvalidArgsExitStatus)
+	}
+	setBatchColors()
+
+	args := ctx.Args()
+	aliasedURL, id := args.Get(0), args.Get(1)
+
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	e := client.cancelBatchJob(globalContext, id)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to cancel the batch job.")
+
+	printMsg(batchJobMessage{op: "cancel", Status: "success", ID: id})
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var batchDescribeCmd = cli.Command{
+	Name:         "describe",
+	Usage:        "print the definition of a batch job",
+	Action:       mainBatchDescribe,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET JOBID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Print the definition job 'K8dFtHmLs' was started with on 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio K8dFtHmLs
+`,
+}
+
+// mainBatchDescribe is the handle for "mc batch describe" command.
+func mainBatchDescribe(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "describe", globalInvalidArgsExitStatus)
+	}
+	setBatchColors()
+
+	args := ctx.Args()
+	aliasedURL, id := args.Get(0), args.Get(1)
+
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	job, e := client.describeBatchJob(globalContext, id)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to describe the batch job.")
+
+	printMsg(batchJobYAMLMessage{Status: "success", ID: id, Job: string(job)})
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var batchGenerateCmd = cli.Command{
+	Name:         "generate",
+	Usage:        "print the template of a batch job definition",
+	Action:       mainBatchGenerate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} JOBTYPE
+
+JOBTYPE:
+  replicate  copy a prefix of the cluster to a remote bucket
+  expire     remove the noncurrent versions of the objects
+  retier     transition the objects to a remote tier
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Write the template of a replicate job to 'replicate.yaml', to edit it before starting the job.
+     {{.Prompt}} {{.HelpName}} replicate > replicate.yaml
+`,
+}
+
+// Templates of the batch job definitions, see batchJobDefinition.
+var batchJobTemplates = map[string]string{
+	batchJobReplicate: `replicate:
+  apiVersion: v1
+  # Objects of the cluster to copy.
+  source:
+    bucket: BUCKET
+    prefix: PREFIX
+  # Remote bucket to copy the objects to.
+  target:
+    endpoint: https://minio.example.com:9000
+    bucket: BUCKET
+    prefix: PREFIX
+    credentials:
+      accessKey: ACCESS-KEY
+      secretKey: SECRET-KEY
+  flags:
+    filter:
+      newerThan: 7d # copy the objects modified in the last 7 days only
+      tags:
+        - key: KEY
+          value: VALUE
+    notify:
+      endpoint: https://notify.example.com # called when the job finishes
+      token: Bearer TOKEN
+    retry:
+      attempts: 10
+      delay: 500ms
+`,
+	batchJobExpire: `expire:
+  apiVersion: v1
+  bucket: BUCKET
+  prefix: PREFIX
+  # Noncurrent versions older than this are removed.
+  olderThan: 30d
+  # Newest noncurrent versions kept regardless of their age.
+  keepVersions: 3
+  # Remove the delete markers left without versions.
+  deleteMarkers: true
+  flags:
+    notify:
+      endpoint: https://notify.example.com # called when the job finishes
+      token: Bearer TOKEN
+    retry:
+      attempts: 10
+      delay: 500ms
+`,
+	batchJobRetier: `retier:
+  apiVersion: v1
+  bucket: BUCKET
+  prefix: PREFIX
+  # Tier added with 'mc admin tier add' to transition the objects to.
+  tier: TIER
+  flags:
+    filter:
+      olderThan: 90d
+      tags:
+        - key: KEY
+          value: VALUE
+    notify:
+      endpoint: https://notify.example.com # called when the job finishes
+      token: Bearer TOKEN
+    retry:
+      attempts: 10
+      delay: 500ms
+`,
+}
+
+// mainBatchGenerate is the handle for "mc batch generate" command.
+func mainBatchGenerate(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "generate", globalInvalidArgsExitStatus)
+	}
+	setBatchColors()
+
+	jobType := strings.ToLower(ctx.Args().Get(0))
+	template, ok := batchJobTemplates[jobType]
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(jobType), "Unknown job type, possible values are 'replicate', 'expire' and 'retier'.")
+	}
+	printMsg(batchJobYAMLMessage{Status: "success", Type: jobType, Job: template})
+	return nil
+}
+
+// readBatchJob reads and validates a job definition, '-' for STDIN.
+func readBatchJob(filename string) ([]byte, string, *probe.Error) {
+	data, err := readFileOrStdin(filename)
+	if err != nil {
+		return nil, "", err
+	}
+	jobType, e := parseBatchJob(data)
+	if e != nil {
+		return nil, "", probe.NewError(e).Trace(filename)
+	}
+	return data, jobType, nil
+}
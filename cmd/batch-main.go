@@ -0,0 +1,382 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var batchSubcommands = []cli.Command{
+	batchGenerateCmd,
+	batchStartCmd,
+	batchStatusCmd,
+	batchDescribeCmd,
+	batchCancelCmd,
+}
+
+var batchCmd = cli.Command{
+	Name:            "batch",
+	Usage:           "manage batch jobs run by the server",
+	Action:          mainBatch,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands:     batchSubcommands,
+}
+
+// mainBatch is the handle for "mc batch" command.
+func mainBatch(ctx *cli.Context) error {
+	commandNotFound(ctx, batchSubcommands)
+	return nil
+	// Sub-commands like "start", "status" have their own main.
+}
+
+// Types of batch jobs.
+const (
+	batchJobReplicate = "replicate"
+	batchJobExpire    = "expire"
+	batchJobRetier    = "retier"
+)
+
+// Version of the batch job definitions.
+const batchJobAPIVersion = "v1"
+
+// States of a batch job.
+const (
+	batchJobRunning  = "running"
+	batchJobComplete = "complete"
+	batchJobFailed   = "failed"
+	batchJobCanceled = "canceled"
+)
+
+// batchJobDefinition is a job definition submitted to the server, a
+// YAML document holding a single job type.
+type batchJobDefinition struct {
+	Replicate *batchJobReplicateDef `yaml:"replicate,omitempty"`
+	Expire    *batchJobExpireDef    `yaml:"expire,omitempty"`
+	Retier    *batchJobRetierDef    `yaml:"retier,omitempty"`
+}
+
+// batchJobFilter selects the objects a job applies to.
+type batchJobFilter struct {
+	NewerThan string           `yaml:"newerThan,omitempty"`
+	OlderThan string           `yaml:"olderThan,omitempty"`
+	Tags      []batchJobKeyVal `yaml:"tags,omitempty"`
+}
+
+type batchJobKeyVal struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+// batchJobNotify is a webhook called when the job finishes.
+type batchJobNotify struct {
+	Endpoint string `yaml:"endpoint,omitempty"`
+	Token    string `yaml:"token,omitempty"`
+}
+
+type batchJobRetry struct {
+	Attempts int    `yaml:"attempts,omitempty"`
+	Delay    string `yaml:"delay,omitempty"`
+}
+
+type batchJobFlags struct {
+	Filter batchJobFilter `yaml:"filter,omitempty"`
+	Notify batchJobNotify `yaml:"notify,omitempty"`
+	Retry  batchJobRetry  `yaml:"retry,omitempty"`
+}
+
+type batchJobCredentials struct {
+	AccessKey    string `yaml:"accessKey"`
+	SecretKey    string `yaml:"secretKey"`
+	SessionToken string `yaml:"sessionToken,omitempty"`
+}
+
+type batchJobReplicateSource struct {
+	Bucket string `yaml:"bucket"`
+	Prefix string `yaml:"prefix,omitempty"`
+}
+
+type batchJobReplicateTarget struct {
+	Endpoint    string              `yaml:"endpoint"`
+	Bucket      string              `yaml:"bucket"`
+	Prefix      string              `yaml:"prefix,omitempty"`
+	Credentials batchJobCredentials `yaml:"credentials"`
+}
+
+// batchJobReplicateDef copies a prefix of the cluster to a remote bucket.
+type batchJobReplicateDef struct {
+	APIVersion string                  `yaml:"apiVersion"`
+	Source     batchJobReplicateSource `yaml:"source"`
+	Target     batchJobReplicateTarget `yaml:"target"`
+	Flags      batchJobFlags           `yaml:"flags,omitempty"`
+}
+
+// batchJobExpireDef removes the noncurrent versions of the objects.
+type batchJobExpireDef struct {
+	APIVersion string `yaml:"apiVersion"`
+	Bucket     string `yaml:"bucket"`
+	Prefix     string `yaml:"prefix,omitempty"`
+	// Noncurrent versions older than this are removed, keeping the
+	// newest KeepVersions of them.
+	OlderThan     string        `yaml:"olderThan"`
+	KeepVersions  int           `yaml:"keepVersions,omitempty"`
+	DeleteMarkers bool          `yaml:"deleteMarkers,omitempty"`
+	Flags         batchJobFlags `yaml:"flags,omitempty"`
+}
+
+// batchJobRetierDef transitions the objects to a tier.
+type batchJobRetierDef struct {
+	APIVersion string        `yaml:"apiVersion"`
+	Bucket     string        `yaml:"bucket"`
+	Prefix     string        `yaml:"prefix,omitempty"`
+	Tier       string        `yaml:"tier"`
+	Flags      batchJobFlags `yaml:"flags,omitempty"`
+}
+
+// parseBatchJob parses and validates a job definition, it returns the
+// type of the job.
+func parseBatchJob(data []byte) (string, error) {
+	var def batchJobDefinition
+	if e := yaml.UnmarshalStrict(data, &def); e != nil {
+		return "", e
+	}
+
+	var jobType, apiVersion string
+	var flags batchJobFlags
+	var n int
+	if r := def.Replicate; r != nil {
+		n++
+		jobType, apiVersion, flags = batchJobReplicate, r.APIVersion, r.Flags
+		if r.Source.Bucket == "" {
+			return "", fmt.Errorf("replicate: source bucket is required")
+		}
+		if r.Target.Bucket == "" {
+			return "", fmt.Errorf("replicate: target bucket is required")
+		}
+		if u, e := url.Parse(r.Target.Endpoint); e != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return "", fmt.Errorf("replicate: invalid target endpoint `%s`", r.Target.Endpoint)
+		}
+		if r.Target.Credentials.AccessKey == "" || r.Target.Credentials.SecretKey == "" {
+			return "", fmt.Errorf("replicate: target credentials are required")
+		}
+	}
+	if x := def.Expire; x != nil {
+		n++
+		jobType, apiVersion, flags = batchJobExpire, x.APIVersion, x.Flags
+		if x.Bucket == "" {
+			return "", fmt.Errorf("expire: bucket is required")
+		}
+		if _, e := ioutils.ParseDurationTime(x.OlderThan); e != nil {
+			return "", fmt.Errorf("expire: invalid olderThan `%s`", x.OlderThan)
+		}
+		if x.KeepVersions < 0 {
+			return "", fmt.Errorf("expire: keepVersions must not be negative")
+		}
+	}
+	if t := def.Retier; t != nil {
+		n++
+		jobType, apiVersion, flags = batchJobRetier, t.APIVersion, t.Flags
+		if t.Bucket == "" {
+			return "", fmt.Errorf("retier: bucket is required")
+		}
+		if t.Tier == "" {
+			return "", fmt.Errorf("retier: tier is required")
+		}
+	}
+	if n != 1 {
+		return "", fmt.Errorf("a job definition must hold exactly one of '%s', '%s' and '%s'",
+			batchJobReplicate, batchJobExpire, batchJobRetier)
+	}
+	if apiVersion != batchJobAPIVersion {
+		return "", fmt.Errorf("%s: unsupported apiVersion `%s`, expected '%s'", jobType, apiVersion, batchJobAPIVersion)
+	}
+
+	filter := flags.Filter
+	if filter.NewerThan != "" {
+		if _, e := ioutils.ParseDurationTime(filter.NewerThan); e != nil {
+			return "", fmt.Errorf("%s: invalid filter newerThan `%s`", jobType, filter.NewerThan)
+		}
+	}
+	if filter.OlderThan != "" {
+		if _, e := ioutils.ParseDurationTime(filter.OlderThan); e != nil {
+			return "", fmt.Errorf("%s: invalid filter olderThan `%s`", jobType, filter.OlderThan)
+		}
+	}
+	if flags.Retry.Delay != "" {
+		if _, e := time.ParseDuration(flags.Retry.Delay); e != nil {
+			return "", fmt.Errorf("%s: invalid retry delay `%s`", jobType, flags.Retry.Delay)
+		}
+	}
+	return jobType, nil
+}
+
+// batchJobInfo describes a job started on the server.
+type batchJobInfo struct {
+	ID      string    `json:"id"`
+	Type    string    `json:"type"`
+	User    string    `json:"user,omitempty"`
+	Started time.Time `json:"started"`
+}
+
+// batchJobStatus is the progress of a job.
+type batchJobStatus struct {
+	batchJobInfo
+	Status        string    `json:"status"`
+	LastUpdate    time.Time `json:"lastUpdate"`
+	Objects       int64     `json:"objects"`
+	ObjectsFailed int64     `json:"objectsFailed"`
+	Bytes         int64     `json:"bytes"`
+	LastObject    string    `json:"lastObject,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// finished returns true when the job no longer runs.
+func (s batchJobStatus) finished() bool {
+	switch s.Status {
+	case batchJobComplete, batchJobFailed, batchJobCanceled:
+		return true
+	}
+	return false
+}
+
+// startBatchJob submits a job definition, the server runs it in the
+// background.
+func (c *adminAPIClient) startBatchJob(ctx context.Context, job []byte) (batchJobInfo, error) {
+	var info batchJobInfo
+	data, e := c.call(ctx, http.MethodPost, "/start-job", nil, job)
+	if e != nil {
+		return info, e
+	}
+	e = json.Unmarshal(data, &info)
+	return info, e
+}
+
+// batchJobStatus returns the progress of a job.
+func (c *adminAPIClient) batchJobStatus(ctx context.Context, id string) (batchJobStatus, error) {
+	var status batchJobStatus
+	data, e := c.call(ctx, http.MethodGet, "/status-job", url.Values{"jobId": []string{id}}, nil)
+	if e != nil {
+		return status, e
+	}
+	e = json.Unmarshal(data, &status)
+	return status, e
+}
+
+// describeBatchJob returns the definition a job was started with.
+func (c *adminAPIClient) describeBatchJob(ctx context.Context, id string) ([]byte, error) {
+	return c.call(ctx, http.MethodGet, "/describe-job", url.Values{"jobId": []string{id}}, nil)
+}
+
+// cancelBatchJob stops a running job.
+func (c *adminAPIClient) cancelBatchJob(ctx context.Context, id string) error {
+	_, e := c.call(ctx, http.MethodDelete, "/cancel-job", url.Values{"id": []string{id}}, nil)
+	return e
+}
+
+// batchJobMessage is printed when a job is started or canceled.
+type batchJobMessage struct {
+	op      string
+	Status  string     `json:"status"`
+	ID      string     `json:"id"`
+	Type    string     `json:"type,omitempty"`
+	User    string     `json:"user,omitempty"`
+	Started *time.Time `json:"started,omitempty"`
+}
+
+func (m batchJobMessage) String() string {
+	if m.op == "cancel" {
+		return console.Colorize("BatchJob", "Canceled job `"+m.ID+"`.")
+	}
+	return console.Colorize("BatchJob", fmt.Sprintf("Started %s job `%s`.", m.Type, m.ID))
+}
+
+func (m batchJobMessage) JSON() string {
+	data, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(data)
+}
+
+// batchJobStatusMessage is the progress of a job.
+type batchJobStatusMessage struct {
+	batchJobStatus
+}
+
+func (m batchJobStatusMessage) String() string {
+	theme := "BatchJob"
+	switch m.Status {
+	case batchJobFailed, batchJobCanceled:
+		theme = "BatchJobFailed"
+	}
+	s := fmt.Sprintf("%-10s %s %-9s %d objects, %s", m.ID, m.Type, m.Status, m.Objects, humanize.IBytes(uint64(m.Bytes)))
+	if m.ObjectsFailed > 0 {
+		s += fmt.Sprintf(", %d failed", m.ObjectsFailed)
+	}
+	if !m.Started.IsZero() && !m.LastUpdate.IsZero() {
+		s += ", " + timeDurationToHumanizedDuration(m.LastUpdate.Sub(m.Started)).StringShort()
+	}
+	if m.Error != "" {
+		s += ": " + m.Error
+	} else if m.LastObject != "" && !m.finished() {
+		s += " (" + m.LastObject + ")"
+	}
+	return console.Colorize(theme, s)
+}
+
+func (m batchJobStatusMessage) JSON() string {
+	data, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(data)
+}
+
+// batchJobYAMLMessage is the definition of a job, or a template of it.
+type batchJobYAMLMessage struct {
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Job    string `json:"job"`
+}
+
+func (m batchJobYAMLMessage) String() string {
+	return console.Colorize("yaml", strings.TrimSuffix(m.Job, "\n"))
+}
+
+func (m batchJobYAMLMessage) JSON() string {
+	data, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(data)
+}
+
+func setBatchColors() {
+	console.SetColor("BatchJob", color.New(color.FgGreen))
+	console.SetColor("BatchJobFailed", color.New(color.FgRed, color.Bold))
+	console.SetColor("yaml", color.New(color.FgGreen))
+}
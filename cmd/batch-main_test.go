@@ -0,0 +1,94 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+func TestParseBatchJob(t *testing.T) {
+	for jobType, template := range batchJobTemplates {
+		parsed, e := parseBatchJob([]byte(template))
+		if e != nil {
+			t.Errorf("%s template: %v", jobType, e)
+		}
+		if parsed != jobType {
+			t.Errorf("%s template: expected type %s, got %s", jobType, jobType, parsed)
+		}
+	}
+
+	testCases := []struct {
+		job string
+		ok  bool
+	}{
+		{"expire:\n  apiVersion: v1\n  bucket: logs\n  olderThan: 30d\n", true},
+		{"expire:\n  apiVersion: v2\n  bucket: logs\n  olderThan: 30d\n", false},
+		{"expire:\n  apiVersion: v1\n  bucket: logs\n  olderThan: a month\n", false},
+		{"expire:\n  apiVersion: v1\n  bucket: logs\n  olderThan: 30d\n  unknown: true\n", false},
+		{"retier:\n  apiVersion: v1\n  bucket: logs\n", false},
+		{"retier:\n  apiVersion: v1\n  bucket: logs\n  tier: WARM\n  flags:\n    retry:\n      delay: soon\n", false},
+		{"replicate:\n  apiVersion: v1\n  source:\n    bucket: a\n  target:\n    endpoint: site2:9000\n    bucket: b\n", false},
+		{"retier:\n  apiVersion: v1\n  bucket: logs\n  tier: WARM\nexpire:\n  apiVersion: v1\n  bucket: logs\n  olderThan: 30d\n", false},
+		{"", false},
+	}
+	for i, testCase := range testCases {
+		if _, e := parseBatchJob([]byte(testCase.job)); (e == nil) != testCase.ok {
+			t.Errorf("Test %d: expected ok %v, got %v", i+1, testCase.ok, e)
+		}
+	}
+}
+
+func TestBatchJobStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio/admin/v3/status-job" || r.URL.Query().Get("jobId") != "K8dFtHmLs" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Code":"XMinioAdminNoSuchJob","Message":"The specified job does not exist"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"K8dFtHmLs","type":"expire","status":"failed","objects":12,"error":"bucket not found"}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	client := &adminAPIClient{
+		endpointURL: u,
+		creds:       credentials.NewStaticV4("minio", "minio123", ""),
+		httpClient:  server.Client(),
+	}
+
+	status, e := client.batchJobStatus(context.Background(), "K8dFtHmLs")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if status.Type != batchJobExpire || status.Objects != 12 || !status.finished() {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if s := (batchJobStatusMessage{status}).String(); !strings.Contains(s, "bucket not found") {
+		t.Errorf("expected the error in %q", s)
+	}
+
+	if _, e = client.batchJobStatus(context.Background(), "unknown"); e == nil || !strings.Contains(e.Error(), "does not exist") {
+		t.Errorf("expected the job not to exist, got %v", e)
+	}
+}
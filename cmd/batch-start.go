@@ -0,0 +1,77 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var batchStartCmd = cli.Command{
+	Name:         "start",
+	Usage:        "start a batch job on the server",
+	Action:       mainBatchStart,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET JOBFILE
+
+  JOBFILE is a job definition created with 'mc batch generate', '-' to read it
+  from STDIN. The job runs on the server, 'mc batch status' follows its progress.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Start the replicate job defined in 'replicate.yaml' on 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio replicate.yaml
+`,
+}
+
+// mainBatchStart is the handle for "mc batch start" command.
+func mainBatchStart(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "start", globalInvalidArgsExitStatus)
+	}
+	setBatchColors()
+
+	args := ctx.Args()
+	aliasedURL, jobFile := args.Get(0), args.Get(1)
+
+	job, _, err := readBatchJob(jobFile)
+	fatalIf(err, "Invalid job definition.")
+
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	info, e := client.startBatchJob(globalContext, job)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to start the batch job.")
+
+	printMsg(batchJobMessage{
+		op:      "start",
+		Status:  "success",
+		ID:      info.ID,
+		Type:    info.Type,
+		User:    info.User,
+		Started: &info.Started,
+	})
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var batchStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "no-wait",
+		Usage: "print the progress once instead of waiting for the job to finish",
+	},
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "interval between the progress updates",
+		Value: 2 * time.Second,
+	},
+}
+
+var batchStatusCmd = cli.Command{
+	Name:         "status",
+	Usage:        "follow the progress of a batch job",
+	Action:       mainBatchStatus,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(batchStatusFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET JOBID
+
+  The progress is printed until the job finishes, the exit status is not zero
+  when the job failed or was canceled.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Follow the progress of job 'K8dFtHmLs' on 'myminio'.
+     {{.Prompt}} {{.HelpName}} myminio K8dFtHmLs
+
+  2. Print the progress of job 'K8dFtHmLs' once, in JSON.
+     {{.Prompt}} {{.HelpName}} --no-wait --json myminio K8dFtHmLs
+`,
+}
+
+// mainBatchStatus is the handle for "mc batch status" command.
+func mainBatchStatus(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "status", globalInvalidArgsExitStatus)
+	}
+	setBatchColors()
+
+	args := ctx.Args()
+	aliasedURL, id := args.Get(0), args.Get(1)
+
+	interval := ctx.Duration("interval")
+	if interval <= 0 {
+		fatalIf(errInvalidArgument().Trace(interval.String()), "Invalid value for --interval, must be positive.")
+	}
+
+	client, err := newAdminAPIClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	for {
+		status, e := client.batchJobStatus(globalContext, id)
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to get the status of the batch job.")
+
+		if status.finished() || ctx.Bool("no-wait") {
+			printMsg(batchJobStatusMessage{status})
+			if status.Status == batchJobFailed || status.Status == batchJobCanceled {
+				return exitStatus(globalErrorExitStatus)
+			}
+			return nil
+		}
+		if !globalQuiet {
+			printMsg(batchJobStatusMessage{status})
+		}
+
+		select {
+		case <-globalContext.Done():
+			return exitStatus(globalCancelExitStatus)
+		case <-time.After(interval):
+		}
+	}
+}
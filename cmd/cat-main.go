@@ -43,6 +43,7 @@ var (
 			Name:  "version-id, vid",
 			Usage: "display a specific version of an object",
 		},
+		identityFlag,
 	}
 )
 
@@ -88,6 +89,9 @@ EXAMPLES:
 
   7. Display the content of a particular object version
      {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
+
+  8. Display the content of an object encrypted client-side with 'mc cp --encrypt-client'.
+     {{.Prompt}} {{.HelpName}} --identity ~/.age/key.txt play/my-bucket/my-object
 `,
 }
 
@@ -166,7 +170,7 @@ func parseCatSyntax(ctx *cli.Context) (args []string, versionID string, timeRef
 }
 
 // catURL displays contents of a URL to stdout.
-func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair, clientEnc *clientEncryption) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
 	switch sourceURL {
@@ -174,6 +178,7 @@ func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.T
 		reader = os.Stdin
 	default:
 		var versionID = sourceVersion
+		var metadata map[string]string
 		var err *probe.Error
 		// Try to stat the object, the purpose is to:
 		// 1. extract the size of S3 object so we can check if the size of the
@@ -187,6 +192,7 @@ func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.T
 			}
 			if client.GetURL().Type == objectStorage {
 				size = content.Size
+				metadata = content.Metadata
 			}
 		} else {
 			return err.Trace(sourceURL)
@@ -195,6 +201,12 @@ func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.T
 			return err.Trace(sourceURL)
 		}
 		defer reader.Close()
+
+		if clientEnc != nil && isClientEncrypted(metadata) {
+			if reader, size, err = clientEnc.transform(reader, size, metadata, nil); err != nil {
+				return err.Trace(sourceURL)
+			}
+		}
 	}
 	return catOut(reader, size).Trace(sourceURL)
 }
@@ -256,6 +268,9 @@ func mainCat(cliCtx *cli.Context) error {
 	// check 'cat' cli arguments.
 	args, versionID, rewind := parseCatSyntax(cliCtx)
 
+	clientEnc, err := getClientEncryption(cliCtx)
+	fatalIf(err, "Unable to parse client-side encryption.")
+
 	// Set command flags from context.
 	stdinMode := false
 	if len(args) == 0 {
@@ -281,7 +296,7 @@ func mainCat(cliCtx *cli.Context) error {
 
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(ctx, url, versionID, rewind, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
+		fatalIf(catURL(ctx, url, versionID, rewind, encKeyDB, clientEnc).Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
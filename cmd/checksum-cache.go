@@ -0,0 +1,146 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Name of the file of the checksum cache in the config directory.
+const checksumCacheFile = "checksum-cache.json"
+
+// checksumCacheEntry holds the checksums of a local file, valid as long
+// as its size and modification time are unchanged.
+type checksumCacheEntry struct {
+	Size    int64             `json:"size"`
+	ModTime time.Time         `json:"mtime"`
+	Sums    map[string]string `json:"sums"`
+}
+
+// checksumCache keeps the checksums of local files by absolute path, so
+// that comparing unchanged files again does not read them.
+type checksumCache struct {
+	sync.Mutex
+	path    string
+	entries map[string]*checksumCacheEntry
+	dirty   bool
+}
+
+// loadChecksumCache reads the checksum cache saved at path, a missing or
+// unreadable cache is started empty.
+func loadChecksumCache(path string) *checksumCache {
+	c := &checksumCache{path: path, entries: make(map[string]*checksumCacheEntry)}
+	// The cache is loaded whole, disable it when it does not fit in
+	// the memory budget.
+	if max := memoryShare(cacheMemoryShare); max > 0 {
+		if fi, e := os.Stat(path); e == nil && uint64(fi.Size()) > max {
+			return nil
+		}
+	}
+	if data, e := ioutil.ReadFile(path); e == nil {
+		if e = json.Unmarshal(data, &c.entries); e != nil {
+			c.entries = make(map[string]*checksumCacheEntry)
+		}
+	}
+	return c
+}
+
+// get returns the checksums named names of a file, if they were all
+// computed since the file was last modified.
+func (c *checksumCache) get(file string, size int64, modTime time.Time, names []string) ([]string, bool) {
+	if c == nil || len(names) == 0 {
+		return nil, false
+	}
+	c.Lock()
+	defer c.Unlock()
+	entry, ok := c.entries[file]
+	if !ok || entry.Size != size || !entry.ModTime.Equal(modTime) {
+		return nil, false
+	}
+	sums := make([]string, 0, len(names))
+	for _, name := range names {
+		sum, ok := entry.Sums[name]
+		if !ok {
+			return nil, false
+		}
+		sums = append(sums, sum)
+	}
+	return sums, true
+}
+
+// put records the checksums named names of a file, the ones recorded
+// before a modification of the file are dropped.
+func (c *checksumCache) put(file string, size int64, modTime time.Time, names, sums []string) {
+	if c == nil || len(names) != len(sums) {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	entry, ok := c.entries[file]
+	if !ok || entry.Size != size || !entry.ModTime.Equal(modTime) {
+		entry = &checksumCacheEntry{Size: size, ModTime: modTime, Sums: make(map[string]string)}
+		c.entries[file] = entry
+	}
+	for i, name := range names {
+		entry.Sums[name] = sums[i]
+	}
+	c.dirty = true
+}
+
+// save writes the cache if checksums were recorded.
+func (c *checksumCache) save() *probe.Error {
+	if c == nil {
+		return nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	data, e := json.Marshal(c.entries)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	// Write to a temporary file first, concurrent runs
+	// must not leave a truncated cache behind.
+	tmp, e := ioutil.TempFile(filepath.Dir(c.path), checksumCacheFile)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if _, e = tmp.Write(data); e == nil {
+		e = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if e == nil {
+		e = os.Rename(tmp.Name(), c.path)
+	}
+	if e != nil {
+		os.Remove(tmp.Name())
+		return probe.NewError(e).Trace(c.path)
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChecksumCache(t *testing.T) {
+	dir, e := ioutil.TempDir("", "checksum-cache")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, checksumCacheFile)
+
+	modTime := time.Date(2021, 5, 1, 10, 0, 0, 123456789, time.UTC)
+	cache := loadChecksumCache(path)
+	if _, ok := cache.get("/data/a", 10, modTime, []string{"md5"}); ok {
+		t.Fatal("unexpected checksum in an empty cache")
+	}
+	cache.put("/data/a", 10, modTime, []string{"md5"}, []string{"sum"})
+	cache.put("/data/a", 10, modTime, []string{"etag-5", "etag-8"}, []string{"sum5-2", "sum8-2"})
+	if err := cache.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache = loadChecksumCache(path)
+	testCases := []struct {
+		size     int64
+		modTime  time.Time
+		names    []string
+		expected []string
+	}{
+		{10, modTime, []string{"md5"}, []string{"sum"}},
+		{10, modTime, []string{"etag-5", "etag-8"}, []string{"sum5-2", "sum8-2"}},
+		{10, modTime, []string{"etag-5", "etag-6"}, nil},
+		{11, modTime, []string{"md5"}, nil},
+		{10, modTime.Add(time.Nanosecond), []string{"md5"}, nil},
+	}
+	for i, testCase := range testCases {
+		sums, ok := cache.get("/data/a", testCase.size, testCase.modTime, testCase.names)
+		if ok != (testCase.expected != nil) || !reflect.DeepEqual(sums, testCase.expected) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, sums)
+		}
+	}
+
+	// A modified file drops the checksums of its previous content.
+	cache.put("/data/a", 11, modTime, []string{"md5"}, []string{"new"})
+	if _, ok := cache.get("/data/a", 11, modTime, []string{"etag-5"}); ok {
+		t.Error("unexpected checksum of a modified file")
+	}
+	if sums, _ := cache.get("/data/a", 11, modTime, []string{"md5"}); !reflect.DeepEqual(sums, []string{"new"}) {
+		t.Errorf("expected the new checksum, got %q", sums)
+	}
+}
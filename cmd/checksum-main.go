@@ -0,0 +1,290 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var checksumFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "version-id, vid",
+		Usage: "show the checksums of a specific object version",
+	},
+}
+
+// Show the checksums of an object.
+var checksumCmd = cli.Command{
+	Name:         "checksum",
+	Usage:        "show the checksums of an object, and verify a local file against them",
+	Action:       mainChecksum,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(checksumFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET [LOCALFILE]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+  1. Show the checksums of an object.
+     {{.Prompt}} {{.HelpName}} s3/backup/2006-Mar-1/backup.tar.gz
+
+  2. Verify that a file was uploaded intact, whatever the part size it was uploaded with.
+     {{.Prompt}} {{.HelpName}} s3/backup/2006-Mar-1/backup.tar.gz /var/backup/backup.tar.gz
+
+  3. Verify a file against an encrypted object, the object is read to compute its MD5.
+     {{.Prompt}} {{.HelpName}} --encrypt-key "s3/backup/=32byteslongsecretkeymustbegiven1" \
+           s3/backup/2006-Mar-1/backup.tar.gz /var/backup/backup.tar.gz
+`,
+}
+
+// checksumMessage container for the checksums of an object, and of
+// the local file verified against them.
+type checksumMessage struct {
+	Status    string `json:"status"`
+	Key       string `json:"name"`
+	VersionID string `json:"versionID,omitempty"`
+	Size      int64  `json:"size"`
+	ETag      string `json:"etag,omitempty"`
+	Parts     int    `json:"parts,omitempty"`
+	MD5       string `json:"md5,omitempty"`
+
+	File         string `json:"file,omitempty"`
+	FileSize     int64  `json:"fileSize,omitempty"`
+	FileChecksum string `json:"fileChecksum,omitempty"`
+	PartSize     int64  `json:"partSize,omitempty"`
+	Result       string `json:"result,omitempty"`
+
+	// True when the MD5 was computed from the content read.
+	md5Read bool
+}
+
+func (c checksumMessage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", console.Colorize("Name", fmt.Sprintf("%-10s: %s", "Name", c.Key)))
+	if c.VersionID != "" {
+		fmt.Fprintf(&b, "%-10s: %s\n", "VersionID", c.VersionID)
+	}
+	fmt.Fprintf(&b, "%-10s: %s\n", "Size", humanize.IBytes(uint64(c.Size)))
+	switch {
+	case c.Parts > 0:
+		fmt.Fprintf(&b, "%-10s: %s (multipart, %d parts)\n", "ETag", c.ETag, c.Parts)
+	case c.MD5 != "" && !c.md5Read:
+		fmt.Fprintf(&b, "%-10s: %s (MD5)\n", "ETag", c.ETag)
+	case c.ETag != "":
+		fmt.Fprintf(&b, "%-10s: %s (not a checksum of the content)\n", "ETag", c.ETag)
+	}
+	if c.md5Read {
+		fmt.Fprintf(&b, "%-10s: %s (of the content read)\n", "MD5", c.MD5)
+	}
+	if c.File == "" {
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	fmt.Fprintf(&b, "%-10s: %s\n", "File", c.File)
+	switch {
+	case c.FileSize != c.Size:
+		fmt.Fprintf(&b, "%-10s: %s\n", "File size", humanize.IBytes(uint64(c.FileSize)))
+	case c.FileChecksum == "":
+		fmt.Fprintf(&b, "%-10s: none, no part size splits the file in %d parts\n", "File ETag", c.Parts)
+	case c.PartSize > 0:
+		fmt.Fprintf(&b, "%-10s: %s (%s parts)\n", "File ETag", c.FileChecksum, humanize.IBytes(uint64(c.PartSize)))
+	default:
+		fmt.Fprintf(&b, "%-10s: %s\n", "File MD5", c.FileChecksum)
+	}
+	result := console.Colorize("Match", c.Result)
+	if c.Result != checksumMatch {
+		result = console.Colorize("Mismatch", c.Result)
+	}
+	fmt.Fprintf(&b, "%-10s: %s", "Result", result)
+	return b.String()
+}
+
+func (c checksumMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// Results of the verification of a local file.
+const (
+	checksumMatch    = "match"
+	checksumMismatch = "mismatch"
+)
+
+// checksumFile computes the ETag of a local file of size bytes, the way
+// it is computed for an object uploaded in parts parts, its MD5 when
+// parts is zero. Unless the part size is known, the ETag is computed for
+// all the part sizes commonly used, the one matching etag is returned
+// with its part size.
+func checksumFile(r io.Reader, size int64, etag string, parts int, partSize int64) (checksum string, matchSize int64, match bool, e error) {
+	if parts == 0 {
+		sums, e := computeETags(r, size, 0)
+		if e != nil {
+			return "", 0, false, e
+		}
+		return sums[0], 0, sums[0] == etag, nil
+	}
+	partSizes := []int64{partSize}
+	if partSize == 0 {
+		partSizes = multipartPartSizes(size, parts)
+	}
+	etags, e := computePartETags(r, partSizes)
+	if e != nil || len(etags) == 0 {
+		return "", 0, false, e
+	}
+	for i := range etags {
+		if etags[i] == etag {
+			return etags[i], partSizes[i], true, nil
+		}
+	}
+	return etags[0], partSizes[0], false, nil
+}
+
+// checksumPartSize returns the part size of an object uploaded in parts
+// from GetObjectAttributes, zero when it is unknown. All parts but the
+// last one are of the same size.
+func checksumPartSize(ctx context.Context, clnt Client, content *ClientContent, url string, encKeyDB map[string][]prefixSSEPair) int64 {
+	if statAttributes(ctx, clnt, content, url, encKeyDB) != nil {
+		return 0
+	}
+	if parts := content.Attributes.Parts; len(parts) > 0 && parts[0].Number == 1 {
+		return parts[0].Size
+	}
+	return 0
+}
+
+// checkChecksumSyntax - validate all the passed arguments
+func checkChecksumSyntax(cliCtx *cli.Context) {
+	args := cliCtx.Args()
+	if !args.Present() || len(args) > 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "checksum", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	for _, arg := range args {
+		if strings.TrimSpace(arg) == "" {
+			fatalIf(errInvalidArgument().Trace(args...), "Unable to validate empty argument.")
+		}
+	}
+}
+
+// mainChecksum - main entry point for checksum command.
+func mainChecksum(cliCtx *cli.Context) error {
+	ctx, cancelChecksum := context.WithCancel(globalContext)
+	defer cancelChecksum()
+
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	checkChecksumSyntax(cliCtx)
+
+	// Additional command specific theme customization.
+	console.SetColor("Name", color.New(color.Bold, color.FgCyan))
+	console.SetColor("Match", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Mismatch", color.New(color.FgRed, color.Bold))
+
+	targetURL := cliCtx.Args().Get(0)
+	targetAlias, _, _ := mustExpandAlias(targetURL)
+	clnt, content, err := url2Stat(ctx, targetURL, cliCtx.String("version-id"), false, encKeyDB, time.Time{})
+	fatalIf(err.Trace(targetURL), "Unable to stat `%s`.", targetURL)
+	if content.Type.IsDir() {
+		fatalIf(errInvalidArgument().Trace(targetURL), "`%s` is a folder, TARGET must be an object.", targetURL)
+	}
+
+	msg := checksumMessage{
+		Key:       targetURL,
+		VersionID: content.VersionID,
+		Size:      content.Size,
+		ETag:      strings.Trim(content.ETag, "\""),
+	}
+	etag, parts, ok := contentETag(content)
+	if ok {
+		msg.Parts = parts
+		if parts == 0 {
+			msg.MD5 = etag
+		}
+	}
+	if cliCtx.NArg() == 1 {
+		printMsg(msg)
+		return nil
+	}
+
+	localFile := cliCtx.Args().Get(1)
+	f, e := os.Open(localFile)
+	fatalIf(probe.NewError(e).Trace(localFile), "Unable to open `%s`.", localFile)
+	defer f.Close()
+	st, e := f.Stat()
+	fatalIf(probe.NewError(e).Trace(localFile), "Unable to stat `%s`.", localFile)
+	if st.IsDir() {
+		fatalIf(errInvalidArgument().Trace(localFile), "`%s` is a folder, LOCALFILE must be a file.", localFile)
+	}
+	msg.File, msg.FileSize = localFile, st.Size()
+
+	var match bool
+	switch {
+	case st.Size() != content.Size:
+		// No need to read a file of another size.
+	case ok:
+		var partSize int64
+		if parts > 0 {
+			partSize = checksumPartSize(ctx, clnt, content, targetURL, encKeyDB)
+		}
+		msg.FileChecksum, msg.PartSize, match, e = checksumFile(f, st.Size(), etag, parts, partSize)
+		fatalIf(probe.NewError(e).Trace(localFile), "Unable to read `%s`.", localFile)
+	default:
+		// The ETag of files and encrypted objects is not a checksum of
+		// their content, the content is read to compute its MD5.
+		reader, err := clnt.Get(ctx, GetOptions{SSE: getSSE(targetURL, encKeyDB[targetAlias]), VersionID: content.VersionID})
+		fatalIf(err.Trace(targetURL), "Unable to read `%s`.", targetURL)
+		defer reader.Close()
+		sums, e := computeETags(reader, content.Size, 0)
+		fatalIf(probe.NewError(e).Trace(targetURL), "Unable to read `%s`.", targetURL)
+		msg.MD5, msg.md5Read = sums[0], true
+		msg.FileChecksum, _, match, e = checksumFile(f, st.Size(), msg.MD5, 0, 0)
+		fatalIf(probe.NewError(e).Trace(localFile), "Unable to read `%s`.", localFile)
+	}
+
+	msg.Result = checksumMismatch
+	if match {
+		msg.Result = checksumMatch
+	}
+	printMsg(msg)
+	if !match {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"testing"
+)
+
+func TestChecksumFile(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 2*1024*1024)
+	size := int64(len(data))
+
+	// The multipart ETag of data uploaded in 10 MiB parts.
+	partSize := int64(10 * 1024 * 1024)
+	var sums []byte
+	for i := int64(0); i < size; i += partSize {
+		end := i + partSize
+		if end > size {
+			end = size
+		}
+		sum := md5.Sum(data[i:end])
+		sums = append(sums, sum[:]...)
+	}
+	sum := md5.Sum(sums)
+	etag := hex.EncodeToString(sum[:]) + "-2"
+
+	checksum, gotPartSize, match, e := checksumFile(bytes.NewReader(data), size, etag, 2, 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if checksum != etag || gotPartSize != partSize || !match {
+		t.Fatalf("expected (%s, %d, true), got (%s, %d, %v)", etag, partSize, checksum, gotPartSize, match)
+	}
+
+	// The part size from GetObjectAttributes.
+	if _, gotPartSize, match, _ = checksumFile(bytes.NewReader(data), size, etag, 2, partSize); gotPartSize != partSize || !match {
+		t.Fatalf("expected (%d, true), got (%d, %v)", partSize, gotPartSize, match)
+	}
+
+	// A single byte changed.
+	data[size-1] = 'x'
+	if _, _, match, _ = checksumFile(bytes.NewReader(data), size, etag, 2, 0); match {
+		t.Fatal("expected a mismatch of the modified data")
+	}
+
+	sum = md5.Sum(data)
+	checksum, gotPartSize, match, e = checksumFile(bytes.NewReader(data), size, hex.EncodeToString(sum[:]), 0, 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if checksum != hex.EncodeToString(sum[:]) || gotPartSize != 0 || !match {
+		t.Fatalf("expected (%s, 0, true), got (%s, %d, %v)", hex.EncodeToString(sum[:]), checksum, gotPartSize, match)
+	}
+}
@@ -17,12 +17,18 @@
 package cmd
 
 import (
-	"crypto/tls"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
+	"runtime"
 	"sync"
 	"time"
 
@@ -31,6 +37,8 @@ import (
 	"github.com/minio/mc/pkg/httptracer"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/signer"
 )
 
 // NewAdminFactory encloses New function with client cache.
@@ -57,6 +65,7 @@ func NewAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
 		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey))
+		confHash.Write([]byte(config.Proxy + config.CACert + config.ClientCert + config.ClientKey))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -78,43 +87,11 @@ func NewAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 				return nil, probe.NewError(e)
 			}
 
-			// Keep TLS config.
-			tlsConfig := &tls.Config{
-				RootCAs: globalRootCAs,
-				// Can't use SSLv3 because of POODLE and BEAST
-				// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
-				// Can't use TLSv1.1 because of RC4 cipher usage
-				MinVersion: tls.VersionTLS12,
-			}
-			if config.Insecure {
-				tlsConfig.InsecureSkipVerify = true
-			}
-
-			var transport http.RoundTripper = &http.Transport{
-				Proxy: ieproxy.GetProxyFunc(),
-				DialContext: (&net.Dialer{
-					Timeout:   10 * time.Second,
-					KeepAlive: 15 * time.Second,
-				}).DialContext,
-				MaxIdleConnsPerHost:   256,
-				IdleConnTimeout:       90 * time.Second,
-				TLSHandshakeTimeout:   10 * time.Second,
-				ExpectContinueTimeout: 10 * time.Second,
-				TLSClientConfig:       tlsConfig,
-				// Set this value so that the underlying transport round-tripper
-				// doesn't try to auto decode the body of objects with
-				// content-encoding set to `gzip`.
-				//
-				// Refer:
-				//    https://golang.org/src/net/http/transport.go?h=roundTrip#L1843
-				DisableCompression: true,
-			}
-
-			if config.Debug {
-				transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
-			}
-
 			// Set custom transport.
+			transport, err := newAdminTransport(config)
+			if err != nil {
+				return nil, err.Trace(config.HostURL)
+			}
 			api.SetCustomTransport(transport)
 
 			// Set app info.
@@ -129,9 +106,50 @@ func NewAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 	}
 }
 
-// newAdminClient gives a new client interface
-func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
-	alias, urlStrFull, aliasCfg, err := expandAlias(aliasedURL)
+// newAdminTransport returns the HTTP transport used for all admin API calls.
+func newAdminTransport(config *Config) (http.RoundTripper, *probe.Error) {
+	// Keep TLS config.
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err.Trace()
+	}
+	proxy, err := newProxyFunc(config, ieproxy.GetProxyFunc())
+	if err != nil {
+		return nil, err.Trace()
+	}
+
+	var transport http.RoundTripper = &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 15 * time.Second,
+		}).DialContext,
+		MaxIdleConnsPerHost:   256,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 10 * time.Second,
+		TLSClientConfig:       tlsConfig,
+		// Set this value so that the underlying transport round-tripper
+		// doesn't try to auto decode the body of objects with
+		// content-encoding set to `gzip`.
+		//
+		// Refer:
+		//    https://golang.org/src/net/http/transport.go?h=roundTrip#L1843
+		DisableCompression: true,
+	}
+
+	if config.Debug {
+		transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
+	}
+	if globalLogger != nil {
+		transport = newLogTransport(transport)
+	}
+	return newTimeoutTransport(transport), nil
+}
+
+// newAdminConfig resolves an aliased URL into the config used by admin clients.
+func newAdminConfig(aliasedURL string) (*Config, *probe.Error) {
+	_, urlStrFull, aliasCfg, err := expandAlias(aliasedURL)
 	if err != nil {
 		return nil, err.Trace(aliasedURL)
 	}
@@ -145,11 +163,19 @@ func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
 		return nil, probe.NewError(fmt.Errorf("No valid configuration found for '%s' host alias", urlStrFull))
 	}
 
-	s3Config := NewS3Config(urlStrFull, aliasCfg)
+	return NewS3Config(urlStrFull, aliasCfg), nil
+}
+
+// newAdminClient gives a new client interface
+func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
+	s3Config, err := newAdminConfig(aliasedURL)
+	if err != nil {
+		return nil, err
+	}
 
 	s3Client, err := s3AdminNew(s3Config)
 	if err != nil {
-		return nil, err.Trace(alias, urlStrFull)
+		return nil, err.Trace(aliasedURL, s3Config.HostURL)
 	}
 	return s3Client, nil
 }
@@ -157,3 +183,98 @@ func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
 // s3AdminNew returns an initialized minioAdmin structure. If debug is enabled,
 // it also enables an internal trace transport.
 var s3AdminNew = NewAdminFactory()
+
+// adminAPIClient is a minimal signed client for MinIO admin APIs
+// which are not wrapped by madmin yet.
+type adminAPIClient struct {
+	endpointURL *url.URL
+	creds       *credentials.Credentials
+	httpClient  *http.Client
+	userAgent   string
+}
+
+// newAdminAPIClient returns an admin API client for the given alias.
+func newAdminAPIClient(aliasedURL string) (*adminAPIClient, *probe.Error) {
+	config, err := newAdminConfig(aliasedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	targetURL, e := url.Parse(config.HostURL)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	transport, err := newAdminTransport(config)
+	if err != nil {
+		return nil, err.Trace(aliasedURL)
+	}
+
+	return &adminAPIClient{
+		endpointURL: &url.URL{Scheme: targetURL.Scheme, Host: targetURL.Host},
+		creds:       credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken),
+		httpClient:  &http.Client{Transport: transport},
+		userAgent:   "MinIO (" + runtime.GOOS + "; " + runtime.GOARCH + ") " + config.AppName + "/" + config.AppVersion,
+	}, nil
+}
+
+// secretKey returns the secret key used to encrypt sensitive payloads.
+func (c *adminAPIClient) secretKey() string {
+	value, e := c.creds.Get()
+	if e != nil {
+		return ""
+	}
+	return value.SecretAccessKey
+}
+
+// do sends a signed request for relPath, relative to the versioned
+// admin API prefix. Non 2xx responses are converted to errors, the
+// caller is responsible for closing the body of a successful response.
+func (c *adminAPIClient) do(ctx context.Context, method, relPath string, query url.Values, content []byte) (*http.Response, error) {
+	targetURL := *c.endpointURL
+	targetURL.Path = "/minio/admin/" + madmin.AdminAPIVersion + relPath
+	targetURL.RawQuery = s3utils.QueryEncode(query)
+
+	req, e := http.NewRequestWithContext(ctx, method, targetURL.String(), bytes.NewReader(content))
+	if e != nil {
+		return nil, e
+	}
+
+	value, e := c.creds.Get()
+	if e != nil {
+		return nil, e
+	}
+
+	req.ContentLength = int64(len(content))
+	req.Header.Set("User-Agent", c.userAgent)
+	sum := sha256.Sum256(content)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	req = signer.SignV4(*req, value.AccessKeyID, value.SecretAccessKey, value.SessionToken, "")
+
+	resp, e := c.httpClient.Do(req)
+	if e != nil {
+		return nil, e
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		var errResp madmin.ErrorResponse
+		if e = json.NewDecoder(resp.Body).Decode(&errResp); e != nil || errResp.Code == "" {
+			return nil, madmin.ErrorResponse{
+				Code:    resp.Status,
+				Message: "Server returned " + resp.Status + " for " + relPath,
+			}
+		}
+		return nil, errResp
+	}
+	return resp, nil
+}
+
+// call is like do, but reads and returns the complete response body.
+func (c *adminAPIClient) call(ctx context.Context, method, relPath string, query url.Values, content []byte) ([]byte, error) {
+	resp, e := c.do(ctx, method, relPath, query, content)
+	if e != nil {
+		return nil, e
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
@@ -387,7 +387,7 @@ func (f *fsClient) ShareDownload(ctx context.Context, versionID string, expires
 }
 
 // ShareUpload - share upload not implemented for filesystem.
-func (f *fsClient) ShareUpload(ctx context.Context, startsWith bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
+func (f *fsClient) ShareUpload(ctx context.Context, startsWith bool, expires time.Duration, opts ShareUploadOptions) (string, map[string]string, *probe.Error) {
 	return "", nil, probe.NewError(APINotImplemented{
 		API:     "ShareUpload",
 		APIType: "filesystem",
@@ -1201,3 +1201,11 @@ func (f *fsClient) GetBucketInfo(ctx context.Context) (BucketInfo, *probe.Error)
 		APIType: "filesystem",
 	})
 }
+
+// GetObjectAttributes - not implemented for filesystem.
+func (f *fsClient) GetObjectAttributes(ctx context.Context, versionID string, sse encrypt.ServerSide) (*ObjectAttributes, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "GetObjectAttributes",
+		APIType: "filesystem",
+	})
+}
@@ -0,0 +1,173 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+const (
+	// The attributes requested by GetObjectAttributes.
+	objectAttributesRequested = "ETag,Checksum,ObjectParts,StorageClass,ObjectSize"
+	// The largest number of parts returned by a request.
+	objectAttributesMaxParts = 1000
+	// SHA256 of an empty payload.
+	emptySHA256Hex = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// getObjectAttributesResponse is the response of GetObjectAttributes.
+type getObjectAttributesResponse struct {
+	XMLName     xml.Name       `xml:"GetObjectAttributesResponse"`
+	ETag        string         `xml:"ETag"`
+	Checksum    ObjectChecksum `xml:"Checksum"`
+	ObjectParts struct {
+		IsTruncated          bool         `xml:"IsTruncated"`
+		NextPartNumberMarker int          `xml:"NextPartNumberMarker"`
+		PartsCount           int          `xml:"PartsCount"`
+		Parts                []ObjectPart `xml:"Part"`
+	} `xml:"ObjectParts"`
+	StorageClass string `xml:"StorageClass"`
+	ObjectSize   int64  `xml:"ObjectSize"`
+}
+
+// GetObjectAttributes - get the size, checksums and parts of an object.
+// The API is not supported by minio-go, the requests are signed here
+// with the credentials of the alias.
+func (c *S3Client) GetObjectAttributes(ctx context.Context, versionID string, sse encrypt.ServerSide) (*ObjectAttributes, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return nil, probe.NewError(ObjectNameEmpty{})
+	}
+	if strings.EqualFold(c.config.Signature, "S3v2") {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "GetObjectAttributes",
+			APIType: "S3v2 signature",
+		})
+	}
+	location, e := c.api.GetBucketLocation(ctx, bucket)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	attrs := &ObjectAttributes{}
+	marker := 0
+	for {
+		resp, err := c.getObjectAttributes(ctx, bucket, object, versionID, sse, location, marker)
+		if err != nil {
+			return nil, err
+		}
+		attrs.ETag = strings.Trim(resp.ETag, "\"")
+		attrs.StorageClass = resp.StorageClass
+		attrs.Size = resp.ObjectSize
+		attrs.Checksum = resp.Checksum
+		attrs.PartsCount = resp.ObjectParts.PartsCount
+		attrs.Parts = append(attrs.Parts, resp.ObjectParts.Parts...)
+		if !resp.ObjectParts.IsTruncated || resp.ObjectParts.NextPartNumberMarker <= marker {
+			return attrs, nil
+		}
+		marker = resp.ObjectParts.NextPartNumberMarker
+	}
+}
+
+// getObjectAttributes sends a GetObjectAttributes request for the parts
+// after the part number marker.
+func (c *S3Client) getObjectAttributes(ctx context.Context, bucket, object, versionID string, sse encrypt.ServerSide, location string, marker int) (*getObjectAttributesResponse, *probe.Error) {
+	u := *c.api.EndpointURL()
+	// Virtual host style is not possible over TLS for bucket names with dots.
+	if c.virtualStyle && !(u.Scheme == "https" && strings.Contains(bucket, ".")) {
+		u.Host = bucket + "." + u.Host
+		u.Path = "/" + object
+	} else {
+		u.Path = "/" + bucket + "/" + object
+	}
+	u.RawPath = s3utils.EncodePath(u.Path)
+	query := url.Values{"attributes": {""}}
+	if versionID != "" {
+		query.Set("versionId", versionID)
+	}
+	u.RawQuery = s3utils.QueryEncode(query)
+
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	req.Header.Set("X-Amz-Object-Attributes", objectAttributesRequested)
+	req.Header.Set("X-Amz-Max-Parts", strconv.Itoa(objectAttributesMaxParts))
+	if marker > 0 {
+		req.Header.Set("X-Amz-Part-Number-Marker", strconv.Itoa(marker))
+	}
+	req.Header.Set("X-Amz-Content-Sha256", emptySHA256Hex)
+	req.Header.Set("User-Agent", c.config.AppName+"/"+c.config.AppVersion)
+	// Only the customer provided keys are needed to read the attributes.
+	if sse != nil && sse.Type() == encrypt.SSEC {
+		sse.Marshal(req.Header)
+	}
+	req = signer.SignV4(*req, c.config.AccessKey, c.config.SecretKey, c.config.SessionToken, location)
+
+	resp, e := (&http.Client{Transport: c.transport}).Do(req)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := minio.ErrorResponse{StatusCode: resp.StatusCode}
+		if e = xml.NewDecoder(resp.Body).Decode(&errResp); e != nil || errResp.Code == "" {
+			errResp.Code = resp.Status
+		}
+		switch errResp.Code {
+		case "NotImplemented", "MethodNotAllowed":
+			return nil, probe.NewError(APINotImplemented{
+				API:     "GetObjectAttributes",
+				APIType: c.GetURL().Host,
+			})
+		case "NoSuchKey":
+			return nil, probe.NewError(ObjectMissing{})
+		case "NoSuchBucket":
+			return nil, probe.NewError(BucketDoesNotExist{Bucket: bucket})
+		}
+		return nil, probe.NewError(errResp)
+	}
+	// Servers not supporting the API may ignore the 'attributes' query
+	// and send the object instead.
+	if !strings.Contains(resp.Header.Get("Content-Type"), "xml") {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "GetObjectAttributes",
+			APIType: c.GetURL().Host,
+		})
+	}
+
+	var attrs getObjectAttributesResponse
+	if e = xml.NewDecoder(resp.Body).Decode(&attrs); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return &attrs, nil
+}
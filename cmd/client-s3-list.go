@@ -0,0 +1,209 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"container/heap"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// Default number of prefixes listed in parallel by a recursive listing.
+const defaultListWorkers = 8
+
+// Maximum number of entries of a listing page, the number of objects
+// buffered for each prefix listed in parallel without memory budget.
+const listPageSize = 1000
+
+// Maximum number of levels descended when a level holds a single
+// prefix, before splitting the keyspace.
+const listShardMaxDepth = 8
+
+// Number of prefixes listed in parallel by a recursive listing, set
+// via command line.
+var globalListWorkers = defaultListWorkers
+
+// listShard is a part of a sharded listing, either an object of the
+// split level, or the recursive listing of one of its prefixes.
+type listShard struct {
+	object  *minio.ObjectInfo
+	objects chan minio.ObjectInfo
+}
+
+// listLevel is a heap of the entries of a listing with a delimiter,
+// sorted by key.
+type listLevel []minio.ObjectInfo
+
+func (l listLevel) Len() int            { return len(l) }
+func (l listLevel) Less(i, j int) bool  { return l[i].Key < l[j].Key }
+func (l listLevel) Swap(i, j int)       { l[i], l[j] = l[j], l[i] }
+func (l *listLevel) Push(x interface{}) { *l = append(*l, x.(minio.ObjectInfo)) }
+func (l *listLevel) Pop() interface{} {
+	old := *l
+	x := old[len(old)-1]
+	*l = old[:len(old)-1]
+	return x
+}
+
+// isListPrefix returns true when key is a common prefix returned by
+// the listing of prefix with a delimiter.
+func isListPrefix(prefix, key string) bool {
+	return strings.HasSuffix(key, "/") && key != prefix
+}
+
+// listSharded lists recursively the objects of bucket starting with
+// prefix, in the order of a single recursive listing. The keyspace is
+// split by the common prefixes of prefix, whose objects are listed by
+// workers in parallel and merged back in order.
+func (c *S3Client) listSharded(ctx context.Context, bucket, prefix string, metadata bool, workers int) <-chan minio.ObjectInfo {
+	if workers <= 1 {
+		return c.listObjectWrapper(ctx, bucket, prefix, true, time.Time{}, false, false, metadata, -1)
+	}
+
+	objectCh := make(chan minio.ObjectInfo)
+	go func() {
+		defer close(objectCh)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		send := func(object minio.ObjectInfo) bool {
+			select {
+			case objectCh <- object:
+				return object.Err == nil
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// The keys of a prefix sort between the objects and prefixes
+		// around it, so the shards are merged in the order of the split.
+		shardCh := make(chan listShard, workers)
+		go c.listShards(ctx, bucket, prefix, metadata, workers, shardCh)
+		for shard := range shardCh {
+			if shard.object != nil {
+				if !send(*shard.object) {
+					return
+				}
+				continue
+			}
+			for object := range shard.objects {
+				if !send(object) {
+					return
+				}
+			}
+		}
+	}()
+	return objectCh
+}
+
+// listShards splits the keyspace of prefix by its common prefixes, and
+// starts the listing of each of them in the order of the split, with
+// at most workers listings running at a time.
+func (c *S3Client) listShards(ctx context.Context, bucket, prefix string, metadata bool, workers int, shardCh chan<- listShard) {
+	defer close(shardCh)
+
+	// The objects buffered by all the workers fit in the memory budget.
+	buffer := memoryBudget(listMemoryShare, listEntryMemory, workers, workers*listPageSize) / workers
+
+	// Descend the levels holding a single prefix, such as the root
+	// folder of a dataset, to split a level with several prefixes.
+	var levelCh <-chan minio.ObjectInfo
+	var peeked []minio.ObjectInfo
+	for depth := 0; ; depth++ {
+		levelCh = c.listObjectWrapper(ctx, bucket, prefix, false, time.Time{}, false, false, metadata, listPageSize)
+		peeked = peeked[:0]
+		for object := range levelCh {
+			peeked = append(peeked, object)
+			if len(peeked) == 2 {
+				break
+			}
+		}
+		if depth == listShardMaxDepth || len(peeked) != 1 || peeked[0].Err != nil || !isListPrefix(prefix, peeked[0].Key) {
+			break
+		}
+		prefix = peeked[0].Key
+	}
+
+	workerCh := make(chan struct{}, workers)
+	split := func(object minio.ObjectInfo) bool {
+		shard := listShard{object: &object}
+		if object.Err == nil && isListPrefix(prefix, object.Key) {
+			select {
+			case workerCh <- struct{}{}:
+			case <-ctx.Done():
+				return false
+			}
+			shard = listShard{objects: make(chan minio.ObjectInfo, buffer)}
+			go func(prefix string, objects chan<- minio.ObjectInfo) {
+				defer func() { <-workerCh }()
+				defer close(objects)
+				for object := range c.listObjectWrapper(ctx, bucket, prefix, true, time.Time{}, false, false, metadata, -1) {
+					select {
+					case objects <- object:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(object.Key, shard.objects)
+		}
+		select {
+		case shardCh <- shard:
+			return object.Err == nil
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	// The objects of a listing page are sent before its prefixes, they
+	// are sorted back within a window of the size of a page: an entry
+	// sorting before the smallest of more than a page of entries would
+	// belong to the same page as all of them.
+	pending := &listLevel{}
+	next := func(object minio.ObjectInfo) bool {
+		if object.Err != nil {
+			for pending.Len() > 0 {
+				if !split(heap.Pop(pending).(minio.ObjectInfo)) {
+					return false
+				}
+			}
+			return split(object)
+		}
+		heap.Push(pending, object)
+		if pending.Len() > listPageSize {
+			return split(heap.Pop(pending).(minio.ObjectInfo))
+		}
+		return true
+	}
+	for _, object := range peeked {
+		if !next(object) {
+			return
+		}
+	}
+	for object := range levelCh {
+		if !next(object) {
+			return
+		}
+	}
+	for pending.Len() > 0 {
+		if !split(heap.Pop(pending).(minio.ObjectInfo)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// listHandler serves the V2 listing of a bucket holding keys, a few
+// entries per page.
+type listHandler struct {
+	keys []string
+}
+
+func (h listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if _, ok := query["location"]; ok {
+		w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`))
+		return
+	}
+	type contents struct {
+		Key  string
+		Size int64
+		ETag string
+	}
+	type commonPrefix struct {
+		Prefix string
+	}
+	result := struct {
+		XMLName               xml.Name `xml:"ListBucketResult"`
+		Name                  string
+		Prefix                string
+		Delimiter             string
+		KeyCount              int
+		MaxKeys               int
+		IsTruncated           bool
+		NextContinuationToken string
+		Contents              []contents
+		CommonPrefixes        []commonPrefix
+	}{Name: "bucket", Prefix: query.Get("prefix"), Delimiter: query.Get("delimiter"), MaxKeys: 2}
+
+	// The entries of the listing, objects or common prefixes.
+	var entries []string
+	for _, key := range h.keys {
+		if !strings.HasPrefix(key, result.Prefix) {
+			continue
+		}
+		if i := strings.Index(key[len(result.Prefix):], result.Delimiter); result.Delimiter != "" && i >= 0 {
+			key = key[:len(result.Prefix)+i+1]
+		}
+		if len(entries) == 0 || entries[len(entries)-1] != key {
+			entries = append(entries, key)
+		}
+	}
+	start, _ := strconv.Atoi(query.Get("continuation-token"))
+	end := start + result.MaxKeys
+	if end < len(entries) {
+		result.IsTruncated = true
+		result.NextContinuationToken = strconv.Itoa(end)
+	} else {
+		end = len(entries)
+	}
+	for _, entry := range entries[start:end] {
+		if result.Delimiter != "" && strings.HasSuffix(entry, result.Delimiter) && entry != result.Prefix {
+			result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{entry})
+		} else {
+			result.Contents = append(result.Contents, contents{Key: entry, Size: 1, ETag: `"etag"`})
+		}
+	}
+	result.KeyCount = end - start
+	xml.NewEncoder(w).Encode(result)
+}
+
+func TestListSharded(t *testing.T) {
+	keys := []string{
+		"a", "a.txt", "a/", "a/1", "a/2/x", "a/2/y", "a0", "b/c", "b0/c/d",
+		"c/d/e/f/1", "c/d/e/f/2", "c/d/e/g", "c/d/e/h/", "c/d/e/h/1", "d",
+	}
+	sort.Strings(keys)
+	server := httptest.NewServer(listHandler{keys: keys})
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	clnt, err := S3New(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, prefix := range []string{"", "a", "a/", "c/", "c/d/e/h/", "e"} {
+		var expected []string
+		for _, key := range keys {
+			if strings.HasPrefix(key, prefix) {
+				expected = append(expected, key)
+			}
+		}
+		for _, workers := range []int{1, 2, 8} {
+			var listed []string
+			for object := range clnt.(*S3Client).listSharded(context.Background(), "bucket", prefix, false, workers) {
+				if object.Err != nil {
+					t.Fatalf("prefix %q, %d workers: %v", prefix, workers, object.Err)
+				}
+				listed = append(listed, object.Key)
+			}
+			if !reflect.DeepEqual(listed, expected) {
+				t.Errorf("prefix %q, %d workers: expected %q, got %q", prefix, workers, expected, listed)
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+// bucketWebsite is the website configuration of a bucket, the index
+// document is served for the requests of a folder and the error
+// document for the missing objects.
+type bucketWebsite struct {
+	XMLName xml.Name `xml:"WebsiteConfiguration" json:"-"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty" json:"-"`
+	Index   string   `xml:"IndexDocument>Suffix,omitempty" json:"index,omitempty"`
+	Error   string   `xml:"ErrorDocument>Key,omitempty" json:"error,omitempty"`
+}
+
+// GetBucketWebsite - get the website configuration of a bucket, nil
+// when there is none. The API is not supported by minio-go, the
+// requests are signed here with the credentials of the alias.
+func (c *S3Client) GetBucketWebsite(ctx context.Context) (*bucketWebsite, *probe.Error) {
+	var website bucketWebsite
+	err := c.bucketWebsiteRequest(ctx, http.MethodGet, nil, &website)
+	if err != nil {
+		if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchWebsiteConfiguration" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &website, nil
+}
+
+// SetBucketWebsite - set the website configuration of a bucket.
+func (c *S3Client) SetBucketWebsite(ctx context.Context, website bucketWebsite) *probe.Error {
+	website.Xmlns = "http://s3.amazonaws.com/doc/2006-03-01/"
+	data, e := xml.Marshal(website)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	return c.bucketWebsiteRequest(ctx, http.MethodPut, data, nil)
+}
+
+// RemoveBucketWebsite - remove the website configuration of a bucket.
+func (c *S3Client) RemoveBucketWebsite(ctx context.Context) *probe.Error {
+	return c.bucketWebsiteRequest(ctx, http.MethodDelete, nil, nil)
+}
+
+// bucketWebsiteRequest sends a request for the website subresource of
+// the bucket, the response is decoded into result when not nil.
+func (c *S3Client) bucketWebsiteRequest(ctx context.Context, method string, body []byte, result interface{}) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if strings.EqualFold(c.config.Signature, "S3v2") {
+		return probe.NewError(APINotImplemented{
+			API:     "bucket website",
+			APIType: "S3v2 signature",
+		})
+	}
+	location, e := c.api.GetBucketLocation(ctx, bucket)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	u := *c.api.EndpointURL()
+	// Virtual host style is not possible over TLS for bucket names with dots.
+	if c.virtualStyle && !(u.Scheme == "https" && strings.Contains(bucket, ".")) {
+		u.Host = bucket + "." + u.Host
+		u.Path = "/"
+	} else {
+		u.Path = "/" + bucket + "/"
+	}
+	u.RawPath = s3utils.EncodePath(u.Path)
+	u.RawQuery = s3utils.QueryEncode(url.Values{"website": {""}})
+
+	req, e := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	sha := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha[:]))
+	if body != nil {
+		sum := md5.Sum(body)
+		req.Header.Set("Content-Md5", base64.StdEncoding.EncodeToString(sum[:]))
+		req.Header.Set("Content-Type", "application/xml")
+		req.ContentLength = int64(len(body))
+	}
+	req.Header.Set("User-Agent", c.config.AppName+"/"+c.config.AppVersion)
+	req = signer.SignV4(*req, c.config.AccessKey, c.config.SecretKey, c.config.SessionToken, location)
+
+	resp, e := (&http.Client{Transport: c.transport}).Do(req)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		errResp := minio.ErrorResponse{StatusCode: resp.StatusCode}
+		if e = xml.NewDecoder(resp.Body).Decode(&errResp); e != nil || errResp.Code == "" {
+			errResp.Code = resp.Status
+		}
+		switch errResp.Code {
+		case "NotImplemented", "MethodNotAllowed":
+			return probe.NewError(APINotImplemented{
+				API:     "bucket website",
+				APIType: c.GetURL().Host,
+			})
+		case "NoSuchBucket":
+			return probe.NewError(BucketDoesNotExist{Bucket: bucket})
+		}
+		return probe.NewError(errResp)
+	}
+	if result == nil {
+		return nil
+	}
+	if e = xml.NewDecoder(resp.Body).Decode(result); e != nil && e != io.EOF {
+		return probe.NewError(e)
+	}
+	return nil
+}
@@ -19,27 +19,26 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"hash/fnv"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/minio/mc/pkg/httptracer"
+	"github.com/minio/mc/pkg/mcconfig"
 	"github.com/minio/mc/pkg/probe"
 	minio "github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
 	"github.com/minio/minio-go/v7/pkg/notification"
@@ -58,6 +57,10 @@ type S3Client struct {
 	targetURL    *ClientURL
 	api          *minio.Client
 	virtualStyle bool
+
+	// Used to sign and send the requests not supported by api.
+	config    *Config
+	transport http.RoundTripper
 }
 
 const (
@@ -91,6 +94,7 @@ var timeSentinel = time.Unix(0, 0).UTC()
 // newFactory encloses New function with client cache.
 func newFactory() func(config *Config) (Client, *probe.Error) {
 	clientCache := make(map[uint32]*minio.Client)
+	transportCache := make(map[uint32]http.RoundTripper)
 	var mutex sync.Mutex
 
 	// Return New function.
@@ -122,6 +126,9 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
 		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken))
+		confHash.Write([]byte(config.Proxy + config.CACert + config.ClientCert + config.ClientKey))
+		confHash.Write([]byte(config.RequestPayer + config.Tuning.String()))
+		confHash.Write([]byte(config.Signature + strconv.Itoa(int(config.Lookup))))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -130,25 +137,26 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		var api *minio.Client
 		var found bool
 		if api, found = clientCache[confSum]; !found {
-			// if Signature version '4' use NewV4 directly.
-			creds := credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken)
-			// if Signature version '2' use NewV2 directly.
-			if strings.ToUpper(config.Signature) == "S3V2" {
-				creds = credentials.NewStaticV2(config.AccessKey, config.SecretKey, "")
-			}
+			creds := mcconfig.StaticCredentials(config.AccessKey, config.SecretKey, config.SessionToken, config.Signature)
 
 			var transport http.RoundTripper
 
 			if config.Transport != nil {
 				transport = config.Transport
 			} else {
+				proxy, err := newProxyFunc(config, http.ProxyFromEnvironment)
+				if err != nil {
+					return nil, err.Trace(config.HostURL)
+				}
+				maxIdleConnsPerHost := defaultMaxIdleConnsPerHost
+				if config.Tuning.MaxIdleConnsPerHost > 0 {
+					maxIdleConnsPerHost = config.Tuning.MaxIdleConnsPerHost
+				}
 				tr := &http.Transport{
-					Proxy: http.ProxyFromEnvironment,
-					DialContext: (&net.Dialer{
-						Timeout:   10 * time.Second,
-						KeepAlive: 15 * time.Second,
-					}).DialContext,
-					MaxIdleConnsPerHost:   256,
+					Proxy:                 proxy,
+					DialContext:           newTuningDialer(config.Tuning),
+					MaxConnsPerHost:       config.Tuning.MaxConnsPerHost,
+					MaxIdleConnsPerHost:   maxIdleConnsPerHost,
 					IdleConnTimeout:       90 * time.Second,
 					TLSHandshakeTimeout:   10 * time.Second,
 					ExpectContinueTimeout: 10 * time.Second,
@@ -162,26 +170,18 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 				}
 				if useTLS {
 					// Keep TLS config.
-					tlsConfig := &tls.Config{
-						RootCAs: globalRootCAs,
-						// Can't use SSLv3 because of POODLE and BEAST
-						// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
-						// Can't use TLSv1.1 because of RC4 cipher usage
-						MinVersion: tls.VersionTLS12,
-					}
-					if config.Insecure {
-						tlsConfig.InsecureSkipVerify = true
+					tlsConfig, err := newTLSConfig(config)
+					if err != nil {
+						return nil, err.Trace(config.HostURL)
 					}
 					tr.TLSClientConfig = tlsConfig
 
 					// Because we create a custom TLSClientConfig, we have to opt-in to HTTP/2.
 					// See https://github.com/golang/go/issues/14275
 					//
-					// TODO: Enable http2.0 when upstream issues related to HTTP/2 are fixed.
-					//
-					// if e = http2.ConfigureTransport(tr); e != nil {
-					// 	return nil, probe.NewError(e)
-					// }
+					// HTTP/2 stays disabled by default until upstream issues related to it
+					// are fixed, it multiplexes the requests on a single connection.
+					tr.ForceAttemptHTTP2 = config.Tuning.HTTP2 == "on"
 				}
 				transport = tr
 			}
@@ -193,6 +193,12 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 					transport = httptracer.GetNewTraceTransport(newTraceV2(), transport)
 				}
 			}
+			if globalLogger != nil {
+				transport = newLogTransport(transport)
+			}
+			transport = newTimeoutTransport(transport)
+			transport = newRateLimitTransport(transport)
+			transport = newRequestPayerTransport(transport, config)
 
 			// Not found. Instantiate a new MinIO
 			var e error
@@ -220,10 +226,13 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 			// Cache the new MinIO Client with hash of config as key.
 			clientCache[confSum] = api
+			transportCache[confSum] = transport
 		}
 
 		// Store the new api object.
 		s3Clnt.api = api
+		s3Clnt.config = config
+		s3Clnt.transport = transportCache[confSum]
 
 		return s3Clnt, nil
 	}
@@ -467,6 +476,58 @@ func (c *S3Client) ListNotificationConfigs(ctx context.Context, arn string) ([]N
 	return configs, nil
 }
 
+// newNotificationConfiguration - Build the bucket notification
+// configuration made of configs, as listed by ListNotificationConfigs.
+func newNotificationConfiguration(configs []NotificationConfig) (notification.Configuration, *probe.Error) {
+	var mb notification.Configuration
+	for _, config := range configs {
+		fields := strings.Split(config.Arn, ":")
+		if len(fields) != 6 {
+			return mb, errInvalidArgument().Trace(config.Arn)
+		}
+		nc := notification.NewConfig(notification.NewArn(fields[1], fields[2], fields[3], fields[4], fields[5]))
+		nc.ID = config.ID
+		for _, event := range config.Events {
+			nc.AddEvents(notification.EventType(event))
+		}
+		if config.Prefix != "" {
+			nc.AddFilterPrefix(config.Prefix)
+		}
+		if config.Suffix != "" {
+			nc.AddFilterSuffix(config.Suffix)
+		}
+
+		var added bool
+		switch fields[2] {
+		case "sns":
+			added = mb.AddTopic(nc)
+		case "sqs":
+			added = mb.AddQueue(nc)
+		case "lambda":
+			added = mb.AddLambda(nc)
+		default:
+			return mb, errInvalidArgument().Trace(fields[2])
+		}
+		if !added {
+			return mb, errInvalidArgument().Trace("Overlapping configs", config.Arn)
+		}
+	}
+	return mb, nil
+}
+
+// SetNotificationConfigs - Replace all notification configs of the bucket
+func (c *S3Client) SetNotificationConfigs(ctx context.Context, configs []NotificationConfig) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	mb, err := newNotificationConfiguration(configs)
+	if err != nil {
+		return err
+	}
+	if e := c.api.SetBucketNotification(ctx, bucket, mb); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
 // Supported content types
 var supportedContentTypes = []string{
 	"csv",
@@ -605,6 +666,9 @@ func selectCompressionType(selOpts SelectObjectOpts, object string) minio.Select
 	if selOpts.CompressionType != "" {
 		return selOpts.CompressionType
 	}
+	if _, ok := selOpts.InputSerOpts["parquet"]; ok {
+		return minio.SelectCompressionNONE
+	}
 	if strings.Contains(ext, "parquet") || strings.Contains(object, ".parquet") {
 		return minio.SelectCompressionNONE
 	}
@@ -882,8 +946,12 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 	destOpts.UserMetadata = metadata
 	destOpts.ReplaceMetadata = len(metadata) > 0
 
+	threshold := int64(defaultCopyMultipartThreshold)
+	if c.config.MultipartThreshold > 0 {
+		threshold = int64(c.config.MultipartThreshold)
+	}
 	var e error
-	if opts.disableMultipart || opts.size < 64*1024*1024 {
+	if opts.disableMultipart || opts.size < threshold {
 		_, e = c.api.CopyObject(ctx, destOpts, srcOpts)
 	} else {
 		_, e = c.api.ComposeObject(ctx, destOpts, srcOpts)
@@ -998,6 +1066,12 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		ServerSideEncryption: putOpts.sse,
 		SendContentMd5:       putOpts.md5,
 		DisableMultipart:     putOpts.disableMultipart,
+		PartSize:             c.config.PartSize,
+	}
+
+	// minio-go uploads objects smaller than a part with a single request.
+	if threshold := c.config.MultipartThreshold; threshold > 0 && size >= 0 && size < int64(threshold) {
+		opts.DisableMultipart = true
 	}
 
 	if !retainUntilDate.IsZero() && !retainUntilDate.Equal(timeSentinel) {
@@ -1781,8 +1855,15 @@ func (c *S3Client) listIncompleteInRoutine(ctx context.Context, contentCh chan *
 					content.Time = time.Now()
 					content.Type = os.ModeDir
 				default:
+					size, e := c.incompleteUploadSize(ctx, bucket.Name, object.Key, object.UploadID)
+					if e != nil {
+						contentCh <- &ClientContent{
+							Err: probe.NewError(e),
+						}
+						return
+					}
 					content.URL = url
-					content.Size = object.Size
+					content.Size = size
 					content.Time = object.Initiated
 					content.Type = os.ModeTemporary
 				}
@@ -1809,8 +1890,15 @@ func (c *S3Client) listIncompleteInRoutine(ctx context.Context, contentCh chan *
 				content.Time = time.Now()
 				content.Type = os.ModeDir
 			default:
+				size, e := c.incompleteUploadSize(ctx, b, object.Key, object.UploadID)
+				if e != nil {
+					contentCh <- &ClientContent{
+						Err: probe.NewError(e),
+					}
+					return
+				}
 				content.URL = url
-				content.Size = object.Size
+				content.Size = size
 				content.Time = object.Initiated
 				content.Type = os.ModeTemporary
 			}
@@ -1819,6 +1907,27 @@ func (c *S3Client) listIncompleteInRoutine(ctx context.Context, contentCh chan *
 	}
 }
 
+// incompleteUploadSize returns the size of the parts uploaded so far,
+// the listing of incomplete uploads does not report it.
+func (c *S3Client) incompleteUploadSize(ctx context.Context, bucket, object, uploadID string) (int64, error) {
+	core := minio.Core{Client: c.api}
+	var size int64
+	partNumberMarker := 0
+	for {
+		result, e := core.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, 0)
+		if e != nil {
+			return 0, e
+		}
+		for _, part := range result.ObjectParts {
+			size += part.Size
+		}
+		if !result.IsTruncated {
+			return size, nil
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
+
 func (c *S3Client) listIncompleteRecursiveInRoutine(ctx context.Context, contentCh chan *ClientContent, opts ListOptions) {
 	// get bucket and object from URL.
 	b, o := c.url2BucketAndObject()
@@ -1844,11 +1953,18 @@ func (c *S3Client) listIncompleteRecursiveInRoutine(ctx context.Context, content
 					}
 					return
 				}
+				size, e := c.incompleteUploadSize(ctx, bucket.Name, object.Key, object.UploadID)
+				if e != nil {
+					contentCh <- &ClientContent{
+						Err: probe.NewError(e),
+					}
+					return
+				}
 				url := c.targetURL.Clone()
 				url.Path = c.joinPath(bucket.Name, object.Key)
 				content := &ClientContent{}
 				content.URL = url
-				content.Size = object.Size
+				content.Size = size
 				content.Time = object.Initiated
 				content.Type = os.ModeTemporary
 				contentCh <- content
@@ -1867,12 +1983,19 @@ func (c *S3Client) listIncompleteRecursiveInRoutine(ctx context.Context, content
 				}
 				return
 			}
+			size, e := c.incompleteUploadSize(ctx, b, object.Key, object.UploadID)
+			if e != nil {
+				contentCh <- &ClientContent{
+					Err: probe.NewError(e),
+				}
+				return
+			}
 			url := c.targetURL.Clone()
 			// Join bucket and incoming object key.
 			url.Path = c.joinPath(b, object.Key)
 			content := &ClientContent{}
 			content.URL = url
-			content.Size = object.Size
+			content.Size = size
 			content.Time = object.Initiated
 			content.Type = os.ModeTemporary
 			contentCh <- content
@@ -2045,6 +2168,10 @@ const (
 )
 
 func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *ClientContent, opts ListOptions) {
+	// Stop the parallel listings when returning on an error.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// get bucket and object from URL.
 	b, o := c.url2BucketAndObject()
 	switch {
@@ -2061,8 +2188,7 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 				contentCh <- c.bucketInfo2ClientContent(bucket)
 			}
 
-			isRecursive := true
-			for object := range c.listObjectWrapper(ctx, bucket.Name, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1) {
+			for object := range c.listSharded(ctx, bucket.Name, o, opts.WithMetadata, globalListWorkers) {
 				if object.Err != nil {
 					contentCh <- &ClientContent{
 						Err: probe.NewError(object.Err),
@@ -2077,8 +2203,7 @@ func (c *S3Client) listRecursiveInRoutine(ctx context.Context, contentCh chan *C
 			}
 		}
 	default:
-		isRecursive := true
-		for object := range c.listObjectWrapper(ctx, b, o, isRecursive, time.Time{}, false, false, opts.WithMetadata, -1) {
+		for object := range c.listSharded(ctx, b, o, opts.WithMetadata, globalListWorkers) {
 			if object.Err != nil {
 				contentCh <- &ClientContent{
 					Err: probe.NewError(object.Err),
@@ -2106,15 +2231,30 @@ func (c *S3Client) ShareDownload(ctx context.Context, versionID string, expires
 }
 
 // ShareUpload - get data for presigned post http form upload.
-func (c *S3Client) ShareUpload(ctx context.Context, isRecursive bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
+func (c *S3Client) ShareUpload(ctx context.Context, isRecursive bool, expires time.Duration, opts ShareUploadOptions) (string, map[string]string, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
 	p := minio.NewPostPolicy()
 	if e := p.SetExpires(UTCNow().Add(expires)); e != nil {
 		return "", nil, probe.NewError(e)
 	}
-	if strings.TrimSpace(contentType) != "" || contentType != "" {
+	if strings.TrimSpace(opts.contentType) != "" || opts.contentType != "" {
 		// No need to verify for error here, since we have stripped out spaces.
-		p.SetContentType(contentType)
+		p.SetContentType(opts.contentType)
+	}
+	if opts.contentTypePrefix != "" {
+		if e := p.SetContentTypeStartsWith(opts.contentTypePrefix); e != nil {
+			return "", nil, probe.NewError(e)
+		}
+	}
+	if opts.maxSize > 0 {
+		if e := p.SetContentLengthRange(opts.minSize, opts.maxSize); e != nil {
+			return "", nil, probe.NewError(e)
+		}
+	}
+	if opts.successRedirect != "" {
+		if e := p.SetSuccessActionRedirect(opts.successRedirect); e != nil {
+			return "", nil, probe.NewError(e)
+		}
 	}
 	if e := p.SetBucket(bucket); e != nil {
 		return "", nil, probe.NewError(e)
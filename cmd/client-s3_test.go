@@ -20,10 +20,13 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
+	"time"
 
 	minio "github.com/minio/minio-go/v7"
 	. "gopkg.in/check.v1"
@@ -239,6 +242,43 @@ func (s *TestSuite) TestObjectOperations(c *C) {
 	}
 }
 
+// Test the conditions of upload POST policies.
+func (s *TestSuite) TestShareUploadPolicy(c *C) {
+	object := objectHandler(objectHandler{
+		resource: "/bucket/avatars/",
+	})
+	server := httptest.NewServer(object)
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + object.resource
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	_, formData, err := s3c.ShareUpload(context.Background(), true, time.Hour, ShareUploadOptions{
+		contentTypePrefix: "image/",
+		minSize:           1,
+		maxSize:           10 * 1024 * 1024,
+		successRedirect:   "https://example.com/uploaded",
+	})
+	c.Assert(err, IsNil)
+	c.Assert(formData["success_action_redirect"], Equals, "https://example.com/uploaded")
+
+	policy, e := base64.StdEncoding.DecodeString(formData["policy"])
+	c.Assert(e, IsNil)
+	for _, condition := range []string{
+		`["starts-with","$key","avatars/"]`,
+		`["starts-with","$Content-Type","image/"]`,
+		`["content-length-range", 1, 10485760]`,
+		`["eq","$success_action_redirect","https://example.com/uploaded"]`,
+	} {
+		c.Assert(strings.Contains(string(policy), condition), Equals, true, Commentf("%s not in %s", condition, policy))
+	}
+}
+
 var testSelectCompressionTypeCases = []struct {
 	opts            SelectObjectOpts
 	object          string
@@ -263,3 +303,68 @@ func (s *TestSuite) TestSelectCompressionType(c *C) {
 		c.Assert(cType, DeepEquals, test.compressionType)
 	}
 }
+
+// Test that aliases differing only by bucket lookup or signature do
+// not share a cached client.
+func (s *TestSuite) TestClientCacheLookupSignature(c *C) {
+	conf := new(Config)
+	conf.HostURL = "http://localhost:9000"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	conf.Lookup = minio.BucketLookupAuto
+	base, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	same, err := S3New(conf)
+	c.Assert(err, IsNil)
+	c.Assert(same.(*S3Client).api == base.(*S3Client).api, Equals, true)
+
+	conf.Lookup = minio.BucketLookupPath
+	pathStyle, err := S3New(conf)
+	c.Assert(err, IsNil)
+	c.Assert(pathStyle.(*S3Client).api == base.(*S3Client).api, Equals, false)
+
+	conf.Lookup = minio.BucketLookupAuto
+	conf.Signature = "S3v2"
+	v2, err := S3New(conf)
+	c.Assert(err, IsNil)
+	c.Assert(v2.(*S3Client).api == base.(*S3Client).api, Equals, false)
+}
+
+// Test the size of an incomplete upload listed over several pages.
+func (s *TestSuite) TestIncompleteUploadSize(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte("<LocationConstraint>us-east-1</LocationConstraint>"))
+			return
+		}
+		if r.URL.Query().Get("uploadId") != "upload1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		response := "<ListPartsResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload1</UploadId>" +
+			"<NextPartNumberMarker>2</NextPartNumberMarker><IsTruncated>true</IsTruncated>" +
+			"<Part><PartNumber>1</PartNumber><Size>5242880</Size></Part>" +
+			"<Part><PartNumber>2</PartNumber><Size>5242880</Size></Part></ListPartsResult>"
+		if r.URL.Query().Get("part-number-marker") == "2" {
+			response = "<ListPartsResult><Bucket>bucket</Bucket><Key>object</Key><UploadId>upload1</UploadId>" +
+				"<IsTruncated>false</IsTruncated><Part><PartNumber>3</PartNumber><Size>100</Size></Part></ListPartsResult>"
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	conf := new(Config)
+	conf.HostURL = server.URL + "/bucket/object"
+	conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+	conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+	conf.Signature = "S3v4"
+	s3c, err := S3New(conf)
+	c.Assert(err, IsNil)
+
+	size, e := s3c.(*S3Client).incompleteUploadSize(context.Background(), "bucket", "object", "upload1")
+	c.Assert(e, IsNil)
+	c.Assert(size, Equals, int64(2*5242880+100))
+}
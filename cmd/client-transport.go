@@ -0,0 +1,47 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// newTLSConfig returns the TLS settings of a client, the CA
+// certificate and client certificate of an alias are added to the
+// global settings.
+func newTLSConfig(config *Config) (*tls.Config, *probe.Error) {
+	tlsConfig, e := mcconfig.TLSConfig(globalRootCAs, mustGetCAsDir(), config.Insecure, config.CACert, config.ClientCert, config.ClientKey)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(config.CACert, config.ClientCert, config.ClientKey)
+	}
+	return tlsConfig, nil
+}
+
+// newProxyFunc returns the proxy of a client, the proxy of an alias
+// takes precedence over the default one.
+func newProxyFunc(config *Config, defaultProxy func(*http.Request) (*url.URL, error)) (func(*http.Request) (*url.URL, error), *probe.Error) {
+	proxy, e := mcconfig.ProxyFunc(config.Proxy, defaultProxy)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(config.Proxy)
+	}
+	return proxy, nil
+}
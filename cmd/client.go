@@ -92,6 +92,15 @@ type CopyOptions struct {
 	storageClass     string
 }
 
+// ShareUploadOptions holds the conditions of the POST policy of an upload
+type ShareUploadOptions struct {
+	contentType       string
+	contentTypePrefix string
+	// Content length range, no range if maxSize is zero.
+	minSize, maxSize int64
+	successRedirect  string
+}
+
 // Client - client interface
 type Client interface {
 	// Common operations
@@ -129,7 +138,7 @@ type Client interface {
 
 	// I/O operations with expiration
 	ShareDownload(ctx context.Context, versionID string, expires time.Duration) (string, *probe.Error)
-	ShareUpload(context.Context, bool, time.Duration, string) (string, map[string]string, *probe.Error)
+	ShareUpload(context.Context, bool, time.Duration, ShareUploadOptions) (string, map[string]string, *probe.Error)
 
 	// Watch events
 	Watch(ctx context.Context, options WatchOptions) (*WatchObject, *probe.Error)
@@ -164,6 +173,38 @@ type Client interface {
 	DeleteEncryption(ctx context.Context) *probe.Error
 	// Bucket info operation
 	GetBucketInfo(ctx context.Context) (BucketInfo, *probe.Error)
+	// Object attributes operation
+	GetObjectAttributes(ctx context.Context, versionID string, sse encrypt.ServerSide) (*ObjectAttributes, *probe.Error)
+}
+
+// ObjectChecksum holds the additional checksums of an object or of
+// a part, base64 encoded.
+type ObjectChecksum struct {
+	CRC32  string `xml:"ChecksumCRC32" json:"crc32,omitempty"`
+	CRC32C string `xml:"ChecksumCRC32C" json:"crc32c,omitempty"`
+	SHA1   string `xml:"ChecksumSHA1" json:"sha1,omitempty"`
+	SHA256 string `xml:"ChecksumSHA256" json:"sha256,omitempty"`
+	// FULL_OBJECT or COMPOSITE, a checksum of the checksums of all parts.
+	Type string `xml:"ChecksumType" json:"type,omitempty"`
+}
+
+// ObjectPart holds the size and checksums of a part of an object
+// uploaded in parts.
+type ObjectPart struct {
+	Number int   `xml:"PartNumber" json:"number"`
+	Size   int64 `xml:"Size" json:"size"`
+	ObjectChecksum
+}
+
+// ObjectAttributes holds the attributes of an object returned by
+// GetObjectAttributes.
+type ObjectAttributes struct {
+	ETag         string         `json:"etag"`
+	StorageClass string         `json:"storageClass,omitempty"`
+	Size         int64          `json:"size"`
+	Checksum     ObjectChecksum `json:"checksum"`
+	PartsCount   int            `json:"partsCount,omitempty"`
+	Parts        []ObjectPart   `json:"parts,omitempty"`
 }
 
 // ClientContent - Content container for content metadata
@@ -191,6 +232,7 @@ type ClientContent struct {
 	IsDeleteMarker    bool
 	IsLatest          bool
 	ReplicationStatus string
+	Attributes        *ObjectAttributes
 	Err               *probe.Error
 }
 
@@ -207,6 +249,16 @@ type Config struct {
 	Insecure     bool
 	Lookup       minio.BucketLookupType
 	Transport    *http.Transport
+	Proxy        string
+	CACert       string
+	ClientCert   string
+	ClientKey    string
+	RequestPayer string
+	Tuning       transportTuning
+	// Part size and threshold of multipart uploads, the defaults of
+	// minio-go when zero.
+	PartSize           uint64
+	MultipartThreshold uint64
 }
 
 // SelectObjectOpts - opts entered for select API
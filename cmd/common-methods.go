@@ -365,6 +365,10 @@ func copySourceToTargetURL(ctx context.Context, alias, urlStr, source, sourceVer
 func filterMetadata(metadata map[string]string) map[string]string {
 	newMetadata := map[string]string{}
 	for k, v := range metadata {
+		// An empty value removes the attribute, see --attr-map.
+		if v == "" {
+			continue
+		}
 		if httpguts.ValidHeaderFieldName(k) && httpguts.ValidHeaderFieldValue(v) {
 			newMetadata[k] = v
 		}
@@ -466,8 +470,9 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
 
-	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias {
+	// Optimize for server side copy if the host is same, unless
+	// the content has to be encrypted or decrypted client-side.
+	if sourceAlias == targetAlias && urls.clientEncryption == nil {
 		// preserve new metadata and save existing ones.
 		if preserve {
 			currentMetadata, err := getAllMetadata(ctx, sourceAlias, sourceURL.String(), srcSSE, urls)
@@ -560,12 +565,61 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			isPreserve:       preserve,
 		}
 
-		if isReadAt(reader) {
+		var putReader io.Reader = reader
+		putProgress := progress
+		if urls.clientEncryption != nil {
+			var encReader io.ReadCloser
+			encReader, length, err = urls.clientEncryption.transform(reader, length, putOpts.metadata, progress)
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			defer encReader.Close()
+			putReader = encReader
+			putProgress = nil
+		}
+
+		// Copy server side from an object with the same content, if any.
+		var contentHash string
+		if urls.Dedupe && urls.clientEncryption == nil && sourceURL.Type == fileSystem && targetURL.Type == objectStorage {
+			contentHash, err = dedupeContentHash(sourceURL.Path)
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			putOpts.metadata[dedupeHashKey] = contentHash
+			if source := dedupeLookup(ctx, targetAlias, targetURL, contentHash, length, tgtSSE); source != "" {
+				copyMetadata := make(map[string]string, len(putOpts.metadata))
+				for k, v := range putOpts.metadata {
+					copyMetadata[k] = v
+				}
+				opts := CopyOptions{
+					srcSSE:           tgtSSE,
+					tgtSSE:           tgtSSE,
+					metadata:         copyMetadata,
+					disableMultipart: urls.DisableMultipart,
+					isPreserve:       preserve,
+					storageClass:     urls.TargetContent.StorageClass,
+				}
+				err = copySourceToTargetURL(ctx, targetAlias, targetURL.String(), source, "", mode, until,
+					legalHold, length, progress, opts)
+				if err == nil {
+					return urls.WithError(nil)
+				}
+				// Fall back to a regular upload if the copy failed.
+			}
+		}
+
+		if isReadAt(putReader) || length < 0 {
 			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
-				legalHold, reader, length, progress, putOpts)
+				legalHold, putReader, length, putProgress, putOpts)
 		} else {
 			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
-				legalHold, io.LimitReader(reader, length), length, progress, putOpts)
+				legalHold, io.LimitReader(putReader, length), length, putProgress, putOpts)
+		}
+
+		// Later uploads of the same content will copy from this object. The
+		// index is only a hint, so failing to update it is not an error.
+		if err == nil && contentHash != "" {
+			dedupeIndexUpdate(ctx, targetAlias, targetURL, contentHash, tgtSSE)
 		}
 	}
 	if err != nil {
@@ -0,0 +1,348 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var configDefaultsCmd = cli.Command{
+	Name:            "defaults",
+	Usage:           "show or set the default flags of aliases and commands",
+	Action:          mainConfigDefaults,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [TARGET [FLAG=VALUE...]]
+
+  TARGET is an alias, or a command such as 'mirror' or 'admin info'. The defaults
+  of an alias apply to the commands whose last argument names the alias, the
+  defaults of a command to all its invocations. Flags given on the command line
+  take precedence over the defaults of the alias, which take precedence over the
+  defaults of the command. Flags not known to a command are ignored, FLAG= with
+  no value removes a default.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show all the defaults.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Upload to the infrequent access storage class of 's3' and print JSON for its commands.
+     {{.Prompt}} {{.HelpName}} s3 storage-class=STANDARD_IA json=true
+
+  3. Always overwrite differing objects when mirroring.
+     {{.Prompt}} {{.HelpName}} mirror overwrite=true
+
+  4. Remove the default storage class of 's3'.
+     {{.Prompt}} {{.HelpName}} s3 storage-class=
+`,
+}
+
+// configDefaultsMessage container for the defaults of an alias or a command
+type configDefaultsMessage struct {
+	op       string
+	Status   string            `json:"status"`
+	Alias    string            `json:"alias,omitempty"`
+	Command  string            `json:"command,omitempty"`
+	Defaults map[string]string `json:"defaults"`
+}
+
+// String colorized defaults message.
+func (m configDefaultsMessage) String() string {
+	target := "command `" + m.Command + "`"
+	if m.Alias != "" {
+		target = "alias `" + m.Alias + "`"
+	}
+	if m.op == "set" {
+		return console.Colorize("Defaults", "Defaults of "+target+" updated successfully.")
+	}
+	if len(m.Defaults) == 0 {
+		return console.Colorize("Defaults", "No defaults for "+target+".")
+	}
+	var b strings.Builder
+	b.WriteString(console.Colorize("Defaults", "Defaults of "+target+":"))
+	for _, name := range sortedDefaults(m.Defaults) {
+		fmt.Fprintf(&b, "\n  %s=%s", name, m.Defaults[name])
+	}
+	return b.String()
+}
+
+// JSON jsonified defaults message.
+func (m configDefaultsMessage) JSON() string {
+	m.Status = "success"
+	jsonBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonBytes)
+}
+
+// sortedDefaults returns the flag names of defaults in order.
+func sortedDefaults(defaults map[string]string) []string {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseDefaults parses FLAG=VALUE arguments, leading dashes of the flag
+// names are ignored.
+func parseDefaults(args []string) (map[string]string, *probe.Error) {
+	defaults := make(map[string]string, len(args))
+	for _, arg := range args {
+		tokens := strings.SplitN(arg, "=", 2)
+		name := strings.TrimLeft(tokens[0], "-")
+		if len(tokens) != 2 || name == "" {
+			return nil, errInvalidArgument().Trace(arg)
+		}
+		defaults[name] = tokens[1]
+	}
+	return defaults, nil
+}
+
+// updateDefaults merges the parsed defaults into the current ones, an
+// empty value removes a default.
+func updateDefaults(current, update map[string]string) map[string]string {
+	merged := make(map[string]string, len(current)+len(update))
+	for name, value := range current {
+		merged[name] = value
+	}
+	for name, value := range update {
+		if value == "" {
+			delete(merged, name)
+		} else {
+			merged[name] = value
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// findCommand returns the command named by space separated names, such
+// as 'admin info', among commands and their subcommands.
+func findCommand(commands []cli.Command, name string) *cli.Command {
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return nil
+	}
+	for i := range commands {
+		if commands[i].Name != fields[0] {
+			continue
+		}
+		if len(fields) == 1 {
+			return &commands[i]
+		}
+		return findCommand(commands[i].Subcommands, strings.Join(fields[1:], " "))
+	}
+	return nil
+}
+
+// rootCommands returns the top-level commands of mc.
+func rootCommands(ctx *cli.Context) []cli.Command {
+	for ctx.Parent() != nil {
+		ctx = ctx.Parent()
+	}
+	return ctx.App.Commands
+}
+
+// commandName returns the name of the running command without the
+// name of the program, such as 'admin info'.
+func commandName(ctx *cli.Context) string {
+	fields := strings.SplitN(ctx.Command.HelpName, " ", 2)
+	if len(fields) != 2 {
+		return ctx.Command.Name
+	}
+	return fields[1]
+}
+
+// lookupFlag returns the flag of a command with the given name, or one
+// of its short names.
+func lookupFlag(flags []cli.Flag, name string) cli.Flag {
+	for _, f := range flags {
+		for _, n := range strings.Split(f.GetName(), ",") {
+			if strings.TrimSpace(n) == name {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// applyConfigDefaults sets the flags of the running command which are not
+// given on the command line to their defaults in the config. It must run
+// before any IsSet() on the context, which caches the flags set.
+func applyConfigDefaults(ctx *cli.Context) {
+	if ctx.Command.Name == "" {
+		return
+	}
+	conf, err := loadMcConfig()
+	if err != nil || conf == nil {
+		return
+	}
+
+	// Alias defaults take precedence over the command defaults. The
+	// alias and config commands name aliases to configure them, not to
+	// use them.
+	name := commandName(ctx)
+	defaults := updateDefaults(nil, conf.Defaults[name])
+	args := ctx.Args()
+	if root := strings.Fields(name)[0]; root == "alias" || root == "config" {
+		args = nil
+	}
+	for i := len(args) - 1; i >= 0; i-- {
+		alias, _ := url2Alias(args[i])
+		if aliasCfg, ok := conf.Aliases[alias]; ok {
+			defaults = updateDefaults(defaults, aliasCfg.Defaults)
+			break
+		}
+	}
+
+	// The flags of the command line are looked up on a copy of the
+	// context, its IsSet() cache is then left to see the defaults too.
+	cmdline := *ctx
+	for _, name := range sortedDefaults(defaults) {
+		f := lookupFlag(ctx.Command.Flags, name)
+		if f == nil || isFlagSet(&cmdline, f) {
+			continue
+		}
+		value := defaults[name]
+		switch f.(type) {
+		case cli.StringSliceFlag, cli.IntSliceFlag, cli.Int64SliceFlag:
+			// Multiple values can not be told apart from the command line ones.
+			continue
+		case cli.BoolFlag:
+			// A bool flag is false unless set, setting it to false would
+			// only make it look given.
+			if v, e := strconv.ParseBool(value); e == nil && !v {
+				continue
+			}
+		}
+		for _, n := range strings.Split(f.GetName(), ",") {
+			if e := ctx.Set(strings.TrimSpace(n), value); e != nil {
+				fatalIf(probe.NewError(e), "Invalid default `%s=%s` in the config.", name, value)
+			}
+		}
+	}
+}
+
+// isFlagSet tells if the flag, by any of its names, is given to the
+// command or to one of its parents.
+func isFlagSet(ctx *cli.Context, f cli.Flag) bool {
+	for _, n := range strings.Split(f.GetName(), ",") {
+		if n = strings.TrimSpace(n); ctx.IsSet(n) || ctx.GlobalIsSet(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkConfigDefaultsSyntax - validate all the passed arguments
+func checkConfigDefaultsSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) > 1 {
+		if _, err := parseDefaults(ctx.Args().Tail()); err != nil {
+			fatalIf(err, "Invalid default, it should be of the form FLAG=VALUE.")
+		}
+	}
+}
+
+// mainConfigDefaults is the handle for "mc config defaults" command.
+func mainConfigDefaults(ctx *cli.Context) error {
+	checkConfigDefaultsSyntax(ctx)
+
+	console.SetColor("Defaults", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	if !ctx.Args().Present() {
+		var msgs []configDefaultsMessage
+		for alias, aliasCfg := range conf.Aliases {
+			if len(aliasCfg.Defaults) > 0 {
+				msgs = append(msgs, configDefaultsMessage{op: "show", Alias: alias, Defaults: aliasCfg.Defaults})
+			}
+		}
+		for command, defaults := range conf.Defaults {
+			msgs = append(msgs, configDefaultsMessage{op: "show", Command: command, Defaults: defaults})
+		}
+		sort.Slice(msgs, func(i, j int) bool {
+			return msgs[i].Alias+"/"+msgs[i].Command < msgs[j].Alias+"/"+msgs[j].Command
+		})
+		for _, msg := range msgs {
+			printMsg(msg)
+		}
+		return nil
+	}
+
+	target := cleanAlias(ctx.Args().First())
+	aliasCfg, isAlias := conf.Aliases[target]
+	if !isAlias && findCommand(rootCommands(ctx), target) == nil {
+		fatalIf(errInvalidArgument().Trace(target), "`%s` is neither an alias nor a command.", target)
+	}
+
+	msg := configDefaultsMessage{op: "show"}
+	if isAlias {
+		msg.Alias = target
+		msg.Defaults = aliasCfg.Defaults
+	} else {
+		msg.Command = strings.Join(strings.Fields(target), " ")
+		msg.Defaults = conf.Defaults[msg.Command]
+	}
+
+	if len(ctx.Args()) > 1 {
+		update, _ := parseDefaults(ctx.Args().Tail())
+		msg.op = "set"
+		msg.Defaults = updateDefaults(msg.Defaults, update)
+		if isAlias {
+			aliasCfg.Defaults = msg.Defaults
+			conf.Aliases[target] = aliasCfg
+		} else {
+			if conf.Defaults == nil {
+				conf.Defaults = make(map[string]map[string]string)
+			}
+			conf.Defaults[msg.Command] = msg.Defaults
+			if msg.Defaults == nil {
+				delete(conf.Defaults, msg.Command)
+			}
+		}
+		fatalIf(saveMcConfig(conf).Trace(target), "Unable to update config `"+mustGetMcConfigPath()+"`.")
+	}
+
+	if msg.Defaults == nil {
+		msg.Defaults = map[string]string{}
+	}
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestParseDefaults(t *testing.T) {
+	testCases := []struct {
+		args      []string
+		expected  map[string]string
+		shouldErr bool
+	}{
+		{[]string{"storage-class=STANDARD_IA"}, map[string]string{"storage-class": "STANDARD_IA"}, false},
+		{[]string{"--json=true", "-q=true"}, map[string]string{"json": "true", "q": "true"}, false},
+		{[]string{"storage-class="}, map[string]string{"storage-class": ""}, false},
+		{[]string{"tags=a=b"}, map[string]string{"tags": "a=b"}, false},
+		{[]string{"storage-class"}, nil, true},
+		{[]string{"=true"}, nil, true},
+		{[]string{"--=true"}, nil, true},
+	}
+	for i, testCase := range testCases {
+		defaults, err := parseDefaults(testCase.args)
+		if testCase.shouldErr {
+			if err == nil {
+				t.Fatalf("Test %d: expected error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(defaults, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, defaults)
+		}
+	}
+}
+
+func TestUpdateDefaults(t *testing.T) {
+	testCases := []struct {
+		current  map[string]string
+		update   map[string]string
+		expected map[string]string
+	}{
+		{nil, map[string]string{"json": "true"}, map[string]string{"json": "true"}},
+		{map[string]string{"json": "true"}, map[string]string{"json": "false"}, map[string]string{"json": "false"}},
+		{map[string]string{"json": "true", "q": "true"}, map[string]string{"q": ""}, map[string]string{"json": "true"}},
+		{map[string]string{"json": "true"}, map[string]string{"json": ""}, nil},
+		{nil, map[string]string{"json": ""}, nil},
+	}
+	for i, testCase := range testCases {
+		merged := updateDefaults(testCase.current, testCase.update)
+		if !reflect.DeepEqual(merged, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, merged)
+		}
+	}
+}
+
+func TestFindCommand(t *testing.T) {
+	commands := []cli.Command{
+		{Name: "cp"},
+		{Name: "admin", Subcommands: []cli.Command{
+			{Name: "info"},
+			{Name: "user", Subcommands: []cli.Command{{Name: "add"}}},
+		}},
+	}
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"cp", "cp"},
+		{"admin", "admin"},
+		{"admin info", "info"},
+		{"admin  user add", "add"},
+		{"admin user del", ""},
+		{"mirror", ""},
+		{"", ""},
+	}
+	for i, testCase := range testCases {
+		cmd := findCommand(commands, testCase.name)
+		name := ""
+		if cmd != nil {
+			name = cmd.Name
+		}
+		if name != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, name)
+		}
+	}
+}
+
+func TestLookupFlag(t *testing.T) {
+	flags := []cli.Flag{
+		cli.StringFlag{Name: "storage-class, sc"},
+		cli.BoolFlag{Name: "quiet, q"},
+	}
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"storage-class", "storage-class, sc"},
+		{"sc", "storage-class, sc"},
+		{"q", "quiet, q"},
+		{"json", ""},
+	}
+	for i, testCase := range testCases {
+		flag := lookupFlag(flags, testCase.name)
+		name := ""
+		if flag != nil {
+			name = flag.GetName()
+		}
+		if name != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, name)
+		}
+	}
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	defer func(load func() (*configV10, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		conf := newConfigV10()
+		conf.Aliases["s3"] = aliasConfigV10{Defaults: map[string]string{"storage-class": "STANDARD_IA"}}
+		conf.Defaults = map[string]map[string]string{
+			"ls": {"json": "false", "quiet": "true", "storage-class": "STANDARD"},
+		}
+		return conf, nil
+	}
+
+	flags := []cli.Flag{
+		cli.BoolFlag{Name: "json"},
+		cli.BoolFlag{Name: "quiet, q"},
+		cli.StringFlag{Name: "storage-class, sc"},
+	}
+	testCases := []struct {
+		args         []string
+		json, quiet  bool
+		jsonSet      bool
+		storageClass string
+	}{
+		{[]string{"play/bucket"}, false, true, false, "STANDARD"},
+		{[]string{"s3/bucket"}, false, true, false, "STANDARD_IA"},
+		{[]string{"--sc", "GLACIER", "s3/bucket"}, false, true, false, "GLACIER"},
+		{[]string{"--json", "s3/bucket"}, true, true, true, "STANDARD_IA"},
+		{[]string{"--q=false", "play/bucket"}, false, false, false, "STANDARD"},
+	}
+	for i, testCase := range testCases {
+		var json, jsonSet, quiet bool
+		var storageClass string
+		app := cli.NewApp()
+		app.Name = "mc"
+		app.Commands = []cli.Command{{
+			Name:  "ls",
+			Flags: flags,
+			Action: func(ctx *cli.Context) error {
+				applyConfigDefaults(ctx)
+				json, jsonSet = ctx.Bool("json"), ctx.IsSet("json")
+				quiet = ctx.Bool("quiet") && ctx.Bool("q")
+				storageClass = ctx.String("storage-class")
+				return nil
+			},
+		}}
+		if e := app.Run(append([]string{"mc", "ls"}, testCase.args...)); e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if json != testCase.json || jsonSet != testCase.jsonSet {
+			t.Fatalf("Test %d: expected json %v (set %v), got %v (set %v)", i+1, testCase.json, testCase.jsonSet, json, jsonSet)
+		}
+		if quiet != testCase.quiet {
+			t.Fatalf("Test %d: expected quiet %v, got %v", i+1, testCase.quiet, quiet)
+		}
+		if storageClass != testCase.storageClass {
+			t.Fatalf("Test %d: expected storage class %q, got %q", i+1, testCase.storageClass, storageClass)
+		}
+	}
+}
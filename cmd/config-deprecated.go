@@ -25,11 +25,15 @@ var configCmd = cli.Command{
 		cli.ShowCommandHelp(ctx, ctx.Args().First())
 		return nil
 	},
-	Hidden:          true,
 	Before:          setGlobalsFromContext,
 	HideHelpCommand: true,
 	Flags:           globalFlags,
 	Subcommands: []cli.Command{
+		configEncryptCmd,
+		configDecryptCmd,
+		configProfileCmd,
+		configThemeCmd,
+		configDefaultsCmd,
 		configHostCmd,
 	},
 }
@@ -41,6 +45,7 @@ var configHostCmd = cli.Command{
 		cli.ShowCommandHelp(ctx, ctx.Args().First())
 		return nil
 	},
+	Hidden: true,
 	Before: setGlobalsFromContext,
 	Flags:  globalFlags,
 	Subcommands: []cli.Command{
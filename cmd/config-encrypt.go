@@ -0,0 +1,270 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// mcEnvConfigPassphrase holds the passphrase of an encrypted config file.
+const mcEnvConfigPassphrase = "MC_CONFIG_PASSPHRASE"
+
+// globalConfigPassphrase is set once the config file is known to be
+// encrypted, config saves are encrypted with it as well.
+var globalConfigPassphrase string
+
+var configEncryptCmd = cli.Command{
+	Name:            "encrypt",
+	Usage:           "encrypt configuration file with a passphrase",
+	Action:          mainConfigEncrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_CONFIG_PASSPHRASE  passphrase of the encrypted configuration file, prompted for when not set
+
+EXAMPLES:
+  1. Encrypt the configuration file, the passphrase is prompted for.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Change the passphrase of an encrypted configuration file.
+     {{.Prompt}} {{.HelpName}}
+     Enter config passphrase:
+     Enter new config passphrase:
+     Confirm new config passphrase:
+`,
+}
+
+var configDecryptCmd = cli.Command{
+	Name:            "decrypt",
+	Usage:           "store configuration file in plain text again",
+	Action:          mainConfigDecrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Decrypt the configuration file with the passphrase from the environment.
+     {{.Prompt}} MC_CONFIG_PASSPHRASE=my-passphrase {{.HelpName}}
+`,
+}
+
+// configEncryptMessage is the message printed by 'mc config encrypt/decrypt'.
+type configEncryptMessage struct {
+	Status    string `json:"status"`
+	Path      string `json:"path"`
+	Encrypted bool   `json:"encrypted"`
+}
+
+func (c configEncryptMessage) String() string {
+	if c.Encrypted {
+		return console.Colorize("ConfigEncrypt", "Encrypted `"+c.Path+"` successfully.")
+	}
+	return console.Colorize("ConfigEncrypt", "Decrypted `"+c.Path+"` successfully.")
+}
+
+func (c configEncryptMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.Marshal(c)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+func mainConfigEncrypt(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "encrypt", globalInvalidArgsExitStatus)
+	}
+	console.SetColor("ConfigEncrypt", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	// The passphrase is only taken from the environment for a
+	// plain text config, a new passphrase is always prompted for.
+	passphrase := os.Getenv(mcEnvConfigPassphrase)
+	if globalConfigPassphrase != "" || passphrase == "" {
+		passphrase, err = readNewConfigPassphrase()
+		fatalIf(err, "Unable to read the new passphrase.")
+	}
+
+	globalConfigPassphrase = passphrase
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(), "Unable to save the encrypted config `"+mustGetMcConfigPath()+"`.")
+
+	printMsg(configEncryptMessage{Path: mustGetMcConfigPath(), Encrypted: true})
+	return nil
+}
+
+func mainConfigDecrypt(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "decrypt", globalInvalidArgsExitStatus)
+	}
+	console.SetColor("ConfigEncrypt", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	if globalConfigPassphrase == "" {
+		fatalIf(errInvalidArgument().Trace(), "Config `"+mustGetMcConfigPath()+"` is not encrypted.")
+	}
+
+	globalConfigPassphrase = ""
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(), "Unable to save the decrypted config `"+mustGetMcConfigPath()+"`.")
+
+	printMsg(configEncryptMessage{Path: mustGetMcConfigPath(), Encrypted: false})
+	return nil
+}
+
+// readPassphrase prompts for a passphrase on the terminal.
+func readPassphrase(prompt string) (string, *probe.Error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return "", probe.NewError(fmt.Errorf("no terminal to prompt for the passphrase, set %s", mcEnvConfigPassphrase))
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, e := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return string(passphrase), nil
+}
+
+// readNewConfigPassphrase prompts twice for a new passphrase.
+func readNewConfigPassphrase() (string, *probe.Error) {
+	passphrase, err := readPassphrase("Enter new config passphrase: ")
+	if err != nil {
+		return "", err.Trace()
+	}
+	if passphrase == "" {
+		return "", errInvalidArgument().Trace()
+	}
+	confirm, err := readPassphrase("Confirm new config passphrase: ")
+	if err != nil {
+		return "", err.Trace()
+	}
+	if passphrase != confirm {
+		return "", probe.NewError(fmt.Errorf("passphrases do not match"))
+	}
+	return passphrase, nil
+}
+
+// configPassphrase returns the passphrase of the encrypted config,
+// from the environment or prompted for.
+func configPassphrase() (string, *probe.Error) {
+	if globalConfigPassphrase != "" {
+		return globalConfigPassphrase, nil
+	}
+	if passphrase := os.Getenv(mcEnvConfigPassphrase); passphrase != "" {
+		return passphrase, nil
+	}
+	return readPassphrase("Enter config passphrase: ")
+}
+
+// childEnv returns the environment of mc child processes with env
+// added, the passphrase of an unlocked config is passed on so that
+// the children neither prompt for it again nor fail without a terminal.
+func childEnv(env ...string) []string {
+	env = append(os.Environ(), env...)
+	if globalConfigPassphrase != "" {
+		env = append(env, mcEnvConfigPassphrase+"="+globalConfigPassphrase)
+	}
+	return env
+}
+
+// loadEncryptedConfigV10 decrypts the config file if it is encrypted,
+// the returned bool is false for a plain text config.
+func loadEncryptedConfigV10(configPath string) (*configV10, bool, *probe.Error) {
+	data, e := ioutil.ReadFile(configPath)
+	if e != nil {
+		return nil, false, probe.NewError(e)
+	}
+	if !mcconfig.IsEncrypted(data) {
+		// Not encrypted, errors are reported by the regular load.
+		return nil, false, nil
+	}
+
+	passphrase, err := configPassphrase()
+	if err != nil {
+		return nil, true, err.Trace(configPath)
+	}
+	plain, e := mcconfig.Decrypt(data, passphrase)
+	if e != nil {
+		return nil, true, probe.NewError(e)
+	}
+
+	cfgV10 := newConfigV10()
+	if e = json.Unmarshal(plain, cfgV10); e != nil {
+		return nil, true, probe.NewError(e)
+	}
+	globalConfigPassphrase = passphrase
+	return cfgV10, true, nil
+}
+
+// saveEncryptedConfigV10 writes the config encrypted with passphrase.
+func saveEncryptedConfigV10(configPath string, cfgV10 *configV10, passphrase string) *probe.Error {
+	plain, e := json.MarshalIndent(cfgV10, "", "\t")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	data, e := mcconfig.Encrypt(cfgV10.Version, plain, passphrase)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	// Write to a temporary file first, a failed write
+	// must not leave a truncated config behind.
+	tmpPath := configPath + ".tmp"
+	if e = ioutil.WriteFile(tmpPath, data, 0600); e != nil {
+		return probe.NewError(e)
+	}
+	if e = os.Rename(tmpPath, configPath); e != nil {
+		os.Remove(tmpPath)
+		return probe.NewError(e)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// testConfigV10 returns a config with a single alias.
+func testConfigV10() *configV10 {
+	conf := newConfigV10()
+	conf.Aliases["play"] = aliasConfigV10{
+		URL:       "https://play.min.io",
+		AccessKey: "Q3AM3UQ867SPQQA43P2F",
+		SecretKey: "zuf+tfteSlswRu7BJ86wekitnifILbZam1KYY3TG",
+		API:       "S3v4",
+		Path:      "auto",
+	}
+	return conf
+}
+
+// withConfigPassphrase runs f with the passphrase of the environment
+// set to passphrase and no unlocked config.
+func withConfigPassphrase(passphrase string, f func()) {
+	defer func(env, global string) {
+		os.Setenv(mcEnvConfigPassphrase, env)
+		globalConfigPassphrase = global
+	}(os.Getenv(mcEnvConfigPassphrase), globalConfigPassphrase)
+
+	os.Setenv(mcEnvConfigPassphrase, passphrase)
+	globalConfigPassphrase = ""
+	f()
+}
+
+func TestEncryptedConfigRoundTrip(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-config-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	configPath := filepath.Join(dir, "config.json")
+
+	conf := testConfigV10()
+	if err := saveEncryptedConfigV10(configPath, conf, "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	data, e := ioutil.ReadFile(configPath)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !mcconfig.IsEncrypted(data) || bytes.Contains(data, []byte(conf.Aliases["play"].SecretKey)) {
+		t.Fatalf("expected an encrypted config, got %s", data)
+	}
+
+	withConfigPassphrase("correct horse", func() {
+		loaded, encrypted, err := loadEncryptedConfigV10(configPath)
+		if err != nil || !encrypted {
+			t.Fatalf("expected an encrypted config, got %v (encrypted %v)", err, encrypted)
+		}
+		if loaded.Version != conf.Version || !reflect.DeepEqual(loaded.Aliases["play"], conf.Aliases["play"]) {
+			t.Errorf("expected %+v, got %+v", conf, loaded)
+		}
+		if globalConfigPassphrase != "correct horse" {
+			t.Errorf("expected the config to be unlocked")
+		}
+	})
+
+	withConfigPassphrase("wrong horse", func() {
+		loaded, encrypted, err := loadEncryptedConfigV10(configPath)
+		if err == nil || !encrypted || loaded != nil {
+			t.Fatalf("expected a decryption error, got %v (encrypted %v)", err, encrypted)
+		}
+		if globalConfigPassphrase != "" {
+			t.Errorf("expected the config to stay locked")
+		}
+	})
+}
+
+func TestEncryptPlainConfig(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-config-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(configDir string, load func() (*configV10, *probe.Error)) {
+		setMcConfigDir(configDir)
+		loadMcConfig = load
+		cacheCfgV10 = nil
+	}(mcCustomConfigDir, loadMcConfig)
+	setMcConfigDir(dir)
+	cacheCfgV10 = nil
+
+	withConfigPassphrase("correct horse", func() {
+		// A plain text config, as saved before 'mc config encrypt'.
+		conf := testConfigV10()
+		if err := saveMcConfig(conf); err != nil {
+			t.Fatal(err)
+		}
+		if _, encrypted, err := loadEncryptedConfigV10(mustGetMcConfigPath()); err != nil || encrypted {
+			t.Fatalf("expected a plain text config, got %v (encrypted %v)", err, encrypted)
+		}
+
+		// 'mc config encrypt' saves it again with the passphrase set.
+		globalConfigPassphrase = "correct horse"
+		if err := saveMcConfig(conf); err != nil {
+			t.Fatal(err)
+		}
+		globalConfigPassphrase = ""
+		cacheCfgV10 = nil
+
+		loaded, err := loadConfigV10()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded.Aliases["play"], conf.Aliases["play"]) {
+			t.Errorf("expected %+v, got %+v", conf.Aliases["play"], loaded.Aliases["play"])
+		}
+		data, e := ioutil.ReadFile(mustGetMcConfigPath())
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !mcconfig.IsEncrypted(data) {
+			t.Errorf("expected an encrypted config, got %s", data)
+		}
+	})
+}
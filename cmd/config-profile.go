@@ -0,0 +1,298 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// mcEnvProfile selects the configuration profile, same as --profile.
+const mcEnvProfile = "MC_PROFILE"
+
+// defaultProfile names the configuration file of the config folder.
+const defaultProfile = mcconfig.DefaultProfile
+
+// mcProfile is the name of the selected configuration profile, empty
+// for the default one. Only access via get/set functions.
+var mcProfile string
+
+// getMcProfile returns the name of the selected configuration profile.
+func getMcProfile() string {
+	if mcProfile == "" {
+		return defaultProfile
+	}
+	return mcProfile
+}
+
+// getMcProfilePath returns the configuration file of a profile.
+func getMcProfilePath(profile string) (string, *probe.Error) {
+	dir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return mcconfig.ProfilePath(dir, profile), nil
+}
+
+// isValidProfile - validates a configuration profile name.
+func isValidProfile(profile string) bool {
+	return isValidAlias(profile)
+}
+
+// setMcProfile selects the configuration profile, all config reads
+// and writes use its file from now on. The profile must exist, a
+// mistyped profile name must not silently start an empty one.
+func setMcProfile(profile string) {
+	if profile == defaultProfile {
+		profile = ""
+	}
+	if profile == mcProfile {
+		return
+	}
+	if profile != "" && !isValidProfile(profile) {
+		fatalIf(errInvalidArgument().Trace(profile), "Invalid profile name `"+profile+"`.")
+	}
+
+	cfgMutex.Lock()
+	mcProfile = profile
+	cacheCfgV10 = nil
+	cfgMutex.Unlock()
+
+	if profile != "" && !isMcConfigExists() {
+		fatalIf(errInvalidArgument().Trace(profile),
+			"Profile `"+profile+"` not found. Use `mc config profile add "+profile+"` to create it.")
+	}
+}
+
+var configProfileCmd = cli.Command{
+	Name:  "profile",
+	Usage: "add, remove and list configuration profiles",
+	Action: func(ctx *cli.Context) error {
+		cli.ShowCommandHelp(ctx, ctx.Args().First())
+		return nil
+	},
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		configProfileAddCmd,
+		configProfileListCmd,
+		configProfileRemoveCmd,
+	},
+}
+
+var configProfileAddCmd = cli.Command{
+	Name:            "add",
+	Usage:           "add a new configuration profile",
+	Action:          mainConfigProfileAdd,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} PROFILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+Each profile has its own set of aliases, kept in '~/.mc/profiles/PROFILE.json'.
+Select a profile with '--profile PROFILE' or the MC_PROFILE environment variable.
+
+EXAMPLES:
+  1. Add a "production" profile and an alias in it.
+     {{.Prompt}} {{.HelpName}} production
+     {{.Prompt}} mc --profile production alias set prod1 https://prod1.example.com
+`,
+}
+
+var configProfileListCmd = cli.Command{
+	Name:            "list",
+	ShortName:       "ls",
+	Usage:           "list configuration profiles",
+	Action:          mainConfigProfileList,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the configuration profiles, the selected one is marked with '*'.
+     {{.Prompt}} {{.HelpName}}
+`,
+}
+
+var configProfileRemoveCmd = cli.Command{
+	Name:            "remove",
+	ShortName:       "rm",
+	Usage:           "remove a configuration profile",
+	Action:          mainConfigProfileRemove,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} PROFILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the "staging" profile and all its aliases.
+     {{.Prompt}} {{.HelpName}} staging
+`,
+}
+
+// configProfileMessage is the message printed by 'mc config profile'.
+type configProfileMessage struct {
+	op       string
+	Status   string `json:"status"`
+	Profile  string `json:"profile"`
+	Path     string `json:"path"`
+	Selected bool   `json:"selected,omitempty"`
+}
+
+func (c configProfileMessage) String() string {
+	switch c.op {
+	case "add":
+		return console.Colorize("ConfigProfile", "Added profile `"+c.Profile+"` successfully.")
+	case "remove":
+		return console.Colorize("ConfigProfile", "Removed profile `"+c.Profile+"` successfully.")
+	}
+	mark := " "
+	if c.Selected {
+		mark = "*"
+	}
+	return fmt.Sprintf("%s %s  %s", mark, console.Colorize("ConfigProfile", fmt.Sprintf("%-16s", c.Profile)), c.Path)
+}
+
+func (c configProfileMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.Marshal(c)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// checkConfigProfileSyntax - verifies the PROFILE argument.
+func checkConfigProfileSyntax(ctx *cli.Context, name string) string {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, name, globalInvalidArgsExitStatus)
+	}
+	profile := ctx.Args().Get(0)
+	if profile == defaultProfile || !isValidProfile(profile) {
+		fatalIf(errInvalidArgument().Trace(profile), "Invalid profile name `"+profile+"`.")
+	}
+	return profile
+}
+
+func mainConfigProfileAdd(ctx *cli.Context) error {
+	profile := checkConfigProfileSyntax(ctx, "add")
+	console.SetColor("ConfigProfile", color.New(color.FgGreen))
+
+	profilePath, err := getMcProfilePath(profile)
+	fatalIf(err.Trace(profile), "Unable to get the path of profile `"+profile+"`.")
+	if _, e := os.Stat(profilePath); e == nil {
+		fatalIf(errInvalidArgument().Trace(profile), "Profile `"+profile+"` already exists.")
+	}
+
+	// Write the new config through the regular path with the profile
+	// selected, the selected profile does not exist yet.
+	previous := mcProfile
+	cfgMutex.Lock()
+	mcProfile, cacheCfgV10 = profile, nil
+	cfgMutex.Unlock()
+	err = saveMcConfig(newMcConfig())
+	fatalIf(err.Trace(profile), "Unable to save profile `"+profile+"`.")
+	setMcProfile(previous)
+
+	printMsg(configProfileMessage{op: "add", Profile: profile, Path: profilePath})
+	return nil
+}
+
+func mainConfigProfileList(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "list", globalInvalidArgsExitStatus)
+	}
+	console.SetColor("ConfigProfile", color.New(color.FgCyan, color.Bold))
+
+	profiles := []string{defaultProfile}
+	entries, e := ioutil.ReadDir(filepath.Join(mustGetMcConfigDir(), globalMCProfilesDir))
+	if e != nil && !os.IsNotExist(e) {
+		fatalIf(probe.NewError(e), "Unable to list the profiles.")
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasSuffix(name, ".json") {
+			names = append(names, strings.TrimSuffix(name, ".json"))
+		}
+	}
+	sort.Strings(names)
+
+	for _, profile := range append(profiles, names...) {
+		profilePath, err := getMcProfilePath(profile)
+		fatalIf(err.Trace(profile), "Unable to get the path of profile `"+profile+"`.")
+		printMsg(configProfileMessage{op: "list", Profile: profile, Path: profilePath, Selected: profile == getMcProfile()})
+	}
+	return nil
+}
+
+func mainConfigProfileRemove(ctx *cli.Context) error {
+	profile := checkConfigProfileSyntax(ctx, "remove")
+	console.SetColor("ConfigProfile", color.New(color.FgGreen))
+
+	if profile == mcProfile {
+		fatalIf(errInvalidArgument().Trace(profile), "Unable to remove the selected profile `"+profile+"`.")
+	}
+	profilePath, err := getMcProfilePath(profile)
+	fatalIf(err.Trace(profile), "Unable to get the path of profile `"+profile+"`.")
+	if e := os.Remove(profilePath); e != nil {
+		if os.IsNotExist(e) {
+			fatalIf(errInvalidArgument().Trace(profile), "Profile `"+profile+"` not found.")
+		}
+		fatalIf(probe.NewError(e), "Unable to remove profile `"+profile+"`.")
+	}
+	// Remove the backup of the previous version of the config too.
+	os.Remove(profilePath + ".old")
+
+	printMsg(configProfileMessage{op: "remove", Profile: profile, Path: profilePath})
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestIsValidProfile(t *testing.T) {
+	testCases := []struct {
+		profile string
+		valid   bool
+	}{
+		{"production", true},
+		{"staging-eu_1", true},
+		{"p", false},
+		{"1prod", false},
+		{"", false},
+		{"../config", false},
+		{"prod/eu", false},
+		{`prod\eu`, false},
+		{"prod.json", false},
+	}
+	for i, testCase := range testCases {
+		if valid := isValidProfile(testCase.profile); valid != testCase.valid {
+			t.Errorf("Test %d: expected %v for `%s`, got %v", i+1, testCase.valid, testCase.profile, valid)
+		}
+	}
+}
+
+func TestGetMcProfilePath(t *testing.T) {
+	defer setMcConfigDir(mcCustomConfigDir)
+	dir := filepath.Join("home", ".mc")
+	setMcConfigDir(dir)
+
+	testCases := []struct {
+		profile string
+		path    string
+	}{
+		{"", filepath.Join(dir, "config.json")},
+		{defaultProfile, filepath.Join(dir, "config.json")},
+		{"production", filepath.Join(dir, "profiles", "production.json")},
+	}
+	for i, testCase := range testCases {
+		path, err := getMcProfilePath(testCase.profile)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if path != testCase.path {
+			t.Errorf("Test %d: expected `%s`, got `%s`", i+1, testCase.path, path)
+		}
+	}
+}
+
+func TestKeychainAccount(t *testing.T) {
+	defer func(profile string) { mcProfile = profile }(mcProfile)
+
+	mcProfile = ""
+	if account := keychainAccount("play"); account != "play" {
+		t.Errorf("expected `play`, got `%s`", account)
+	}
+	mcProfile = "staging"
+	if account := keychainAccount("play"); account != "staging/play" {
+		t.Errorf("expected `staging/play`, got `%s`", account)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var configThemeCmd = cli.Command{
+	Name:            "theme",
+	Usage:           "show or set the color theme",
+	Action:          mainConfigTheme,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	OnUsageError:    onUsageError,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [THEME]
+
+  THEME is one of 'default', 'colorblind' or 'monochrome'. The '--theme' flag
+  and the MC_THEME environment variable take precedence over the configured
+  theme, NO_COLOR or '--no-color' disable colors altogether.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the configured color theme.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Use colors which do not rely on telling apart red and green.
+     {{.Prompt}} {{.HelpName}} colorblind
+`,
+}
+
+// configThemeMessage container for color theme messages
+type configThemeMessage struct {
+	op     string
+	Status string `json:"status"`
+	Theme  string `json:"theme"`
+}
+
+// String colorized color theme message.
+func (c configThemeMessage) String() string {
+	if c.op == "set" {
+		return console.Colorize("Theme", fmt.Sprintf("Color theme set to `%s`.", c.Theme))
+	}
+	return console.Colorize("Theme", fmt.Sprintf("Color theme is `%s`.", c.Theme))
+}
+
+// JSON jsonified color theme message.
+func (c configThemeMessage) JSON() string {
+	c.Status = "success"
+	jsonBytes, e := json.Marshal(c)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonBytes)
+}
+
+// checkConfigThemeSyntax - validate all the passed arguments
+func checkConfigThemeSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "theme", globalInvalidArgsExitStatus)
+	}
+	if theme := ctx.Args().Get(0); theme != "" && !isValidTheme(theme) {
+		fatalIf(errInvalidArgument().Trace(theme), "Invalid color theme `%s`. Valid options are `[default, colorblind, monochrome]`.", theme)
+	}
+}
+
+// mainConfigTheme is the handle for "mc config theme" command.
+func mainConfigTheme(ctx *cli.Context) error {
+	checkConfigThemeSyntax(ctx)
+
+	console.SetColor("Theme", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+
+	if !ctx.Args().Present() {
+		theme := conf.Theme
+		if theme == "" {
+			theme = themeDefault
+		}
+		printMsg(configThemeMessage{op: "show", Theme: theme})
+		return nil
+	}
+
+	conf.Theme = ctx.Args().Get(0)
+	if conf.Theme == themeDefault {
+		conf.Theme = ""
+	}
+	fatalIf(saveMcConfig(conf).Trace(), "Unable to update config `"+mustGetMcConfigPath()+"`.")
+
+	printMsg(configThemeMessage{op: "set", Theme: ctx.Args().Get(0)})
+	return nil
+}
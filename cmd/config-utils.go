@@ -69,6 +69,23 @@ func isValidAPI(api string) (ok bool) {
 	return ok
 }
 
+// isValidSignature - validates if the signature version is supported
+func isValidSignature(signature string) bool {
+	return signatureAPI(signature) != ""
+}
+
+// signatureAPI - returns the API signature of a signature version,
+// 'v4' or 'v2', empty if not supported.
+func signatureAPI(signature string) string {
+	switch strings.ToLower(strings.TrimSpace(signature)) {
+	case "v4":
+		return "S3v4"
+	case "v2":
+		return "S3v2"
+	}
+	return ""
+}
+
 // isValidLookup - validates if bucket lookup is of valid type
 func isValidLookup(lookup string) (ok bool) {
 	l := strings.ToLower(strings.TrimSpace(lookup))
@@ -43,12 +43,34 @@ type aliasConfigV10 struct {
 	SessionToken string `json:"sessionToken,omitempty"`
 	API          string `json:"api"`
 	Path         string `json:"path"`
+	// Set to 'keychain' when SecretKey is kept in the OS keychain.
+	CredentialStore string `json:"credentialStore,omitempty"`
+	// Proxy URL, CA certificate and client certificate files of the alias.
+	Proxy      string `json:"proxy,omitempty"`
+	CACert     string `json:"caCert,omitempty"`
+	ClientCert string `json:"clientCert,omitempty"`
+	ClientKey  string `json:"clientKey,omitempty"`
+	// Set to 'requester' to read requester pays buckets.
+	RequestPayer string `json:"requestPayer,omitempty"`
+	// Connection settings, to tune links with a high latency.
+	Tuning *transportTuning `json:"tuning,omitempty"`
+	// Part size and threshold of multipart uploads, in bytes.
+	PartSize           uint64 `json:"partSize,omitempty"`
+	MultipartThreshold uint64 `json:"multipartThreshold,omitempty"`
+	// Values of the flags not given to the commands using this alias.
+	Defaults map[string]string `json:"defaults,omitempty"`
 }
 
 // configV10 config version.
 type configV10 struct {
 	Version string                    `json:"version"`
 	Aliases map[string]aliasConfigV10 `json:"aliases"`
+	// Named command lines runnable with 'mc run'.
+	Macros map[string]string `json:"macros,omitempty"`
+	// Color theme, one of 'default', 'colorblind' or 'monochrome'.
+	Theme string `json:"theme,omitempty"`
+	// Values of the flags not given to a command, by command name.
+	Defaults map[string]map[string]string `json:"defaults,omitempty"`
 }
 
 // newConfigV10 - new config version.
@@ -119,6 +141,15 @@ func loadConfigV10() (*configV10, *probe.Error) {
 		return nil, errInvalidArgument().Trace()
 	}
 
+	// Encrypted config is decrypted before use.
+	if cfgV10, encrypted, err := loadEncryptedConfigV10(mustGetMcConfigPath()); encrypted {
+		if err != nil {
+			return nil, err.Trace(mustGetMcConfigPath())
+		}
+		cacheCfgV10 = cfgV10
+		return cfgV10, nil
+	}
+
 	// Initialize a new config loader.
 	qc, e := quick.NewConfig(newConfigV10(), nil)
 	if e != nil {
@@ -153,6 +184,10 @@ func saveConfigV10(cfgV10 *configV10) *probe.Error {
 	// update the cache.
 	cacheCfgV10 = cfgV10
 
+	if globalConfigPassphrase != "" {
+		return saveEncryptedConfigV10(mustGetMcConfigPath(), cfgV10, globalConfigPassphrase)
+	}
+
 	e = qs.Save(mustGetMcConfigPath())
 	if e != nil {
 		return probe.NewError(e).Trace(mustGetMcConfigPath())
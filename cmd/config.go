@@ -79,6 +79,9 @@ func createMcConfigDir() *probe.Error {
 	if err != nil {
 		return err.Trace()
 	}
+	if mcProfile != "" {
+		p = filepath.Join(p, globalMCProfilesDir)
+	}
 	if e := os.MkdirAll(p, 0700); e != nil {
 		return probe.NewError(e)
 	}
@@ -87,14 +90,7 @@ func createMcConfigDir() *probe.Error {
 
 // getMcConfigPath - construct MinIO Client configuration path
 func getMcConfigPath() (string, *probe.Error) {
-	if mcCustomConfigDir != "" {
-		return filepath.Join(mcCustomConfigDir, globalMCConfigFile), nil
-	}
-	dir, err := getMcConfigDir()
-	if err != nil {
-		return "", err.Trace()
-	}
-	return filepath.Join(dir, globalMCConfigFile), nil
+	return getMcProfilePath(mcProfile)
 }
 
 // mustGetMcConfigPath - similar to getMcConfigPath, ignores errors
@@ -182,6 +178,9 @@ func getAliasConfig(alias string) (*aliasConfigV10, *probe.Error) {
 	// if host is exact return quickly.
 	if _, ok := mcCfg.Aliases[alias]; ok {
 		hostCfg := mcCfg.Aliases[alias]
+		if err = resolveAliasSecret(alias, &hostCfg); err != nil {
+			return nil, err.Trace(alias)
+		}
 		return &hostCfg, nil
 	}
 
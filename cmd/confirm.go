@@ -0,0 +1,130 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// confirmFlags ask to type the name of what a destructive command
+// removes. Set 'confirm=true' with 'mc config defaults' on the aliases of
+// production clusters to always ask for them.
+var confirmFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "confirm",
+		Usage: "ask to type the name of the bucket or user before removing it",
+	},
+	cli.BoolFlag{
+		Name:  "yes",
+		Usage: "do not ask for a confirmation, even with --confirm",
+	},
+}
+
+// confirmLargeTree is the number of objects from which a recursive
+// removal asks for a confirmation.
+const confirmLargeTree = 1000
+
+// confirmMaxCount is the number of objects after which the count shown
+// before a bucket removal stops, listing a large bucket takes hours.
+const confirmMaxCount = 10000
+
+// isConfirmRequired returns true when the command must ask for a
+// confirmation before removing.
+func isConfirmRequired(ctx *cli.Context) bool {
+	return ctx.Bool("confirm") && !ctx.Bool("yes")
+}
+
+// confirmRemoval describes what is removed and asks to type its name on
+// the terminal, a different name is an error.
+func confirmRemoval(description, name string) *probe.Error {
+	if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return probe.NewError(fmt.Errorf("no terminal to confirm the removal of `%s`, retry with --yes", name))
+	}
+	return readConfirmation(os.Stdin, os.Stderr, description, name)
+}
+
+// readConfirmation prints the description and reads the confirmation.
+func readConfirmation(r io.Reader, w io.Writer, description, name string) *probe.Error {
+	fmt.Fprintf(w, "%s\nType `%s` to confirm: ", description, name)
+	line, e := bufio.NewReader(r).ReadString('\n')
+	if e != nil && e != io.EOF {
+		return probe.NewError(e)
+	}
+	if typed := strings.TrimSpace(line); typed != name {
+		return probe.NewError(fmt.Errorf("typed `%s` instead of `%s`, nothing was removed", typed, name))
+	}
+	return nil
+}
+
+// confirmName returns the name to type to confirm the removal of a URL:
+// the bucket, the alias of a site-wide removal or the local directory.
+func confirmName(url string) string {
+	url = filepath.ToSlash(filepath.Clean(url))
+	if alias, _, _ := mustExpandAlias(url); alias == "" {
+		return filepath.Base(url)
+	}
+	alias, path := url2Alias(url)
+	if path = strings.TrimPrefix(path, "/"); path == "" {
+		return alias
+	}
+	return strings.SplitN(path, "/", 2)[0]
+}
+
+// countObjects returns the number of objects listed with opts, up to max,
+// and whether there are more. Those which can not be listed are not counted.
+func countObjects(ctx context.Context, clnt Client, opts ListOptions, max int64) (count int64, more bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	contentCh := clnt.List(ctx, opts)
+	for content := range contentCh {
+		if content.Err != nil || content.Type.IsDir() {
+			continue
+		}
+		if count == max {
+			// Stop the listing, and drain what the lister has
+			// in flight so that it does not block on sending.
+			cancel()
+			go func() {
+				for range contentCh {
+				}
+			}()
+			return count, true
+		}
+		count++
+	}
+	return count, false
+}
+
+// formatObjectCount formats a count of objects returned by countObjects.
+func formatObjectCount(count int64, more bool) string {
+	if more {
+		return "at least " + humanize.Comma(count)
+	}
+	return humanize.Comma(count)
+}
@@ -0,0 +1,87 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadConfirmation(t *testing.T) {
+	testCases := []struct {
+		input   string
+		success bool
+	}{
+		{"mybucket\n", true},
+		{"  mybucket \r\n", true},
+		{"mybucket", true},
+		{"MyBucket\n", false},
+		{"mybucket2\n", false},
+		{"\n", false},
+		{"", false},
+	}
+	for i, testCase := range testCases {
+		var prompt bytes.Buffer
+		err := readConfirmation(strings.NewReader(testCase.input), &prompt, "Removing `s3/mybucket` removes 3 objects.", "mybucket")
+		if success := err == nil; success != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if expected := "Removing `s3/mybucket` removes 3 objects.\nType `mybucket` to confirm: "; prompt.String() != expected {
+			t.Errorf("Test %d: unexpected prompt %q", i+1, prompt.String())
+		}
+	}
+}
+
+func TestCountObjects(t *testing.T) {
+	root, e := ioutil.TempDir("", "mc-count-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(root)
+	for i := 0; i < 5; i++ {
+		if e = ioutil.WriteFile(filepath.Join(root, strconv.Itoa(i)), []byte("data"), 0o600); e != nil {
+			t.Fatal(e)
+		}
+	}
+	clnt, err := fsNew(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		max   int64
+		count int64
+		more  bool
+	}{
+		{10, 5, false},
+		{5, 5, false},
+		{3, 3, true},
+		{0, 0, true},
+	}
+	for i, testCase := range testCases {
+		count, more := countObjects(context.Background(), clnt, ListOptions{Recursive: true, ShowDir: DirNone}, testCase.max)
+		if count != testCase.count || more != testCase.more {
+			t.Errorf("Test %d: expected %d (more %v), got %d (more %v)", i+1, testCase.count, testCase.more, count, more)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// attrMapRule sets HTTP attributes of the uploaded objects whose key
+// matches a pattern, e.g. "*.js:Cache-Control=max-age=31536000,immutable".
+// Patterns without a slash match the base name of the key, the others
+// the whole key in the bucket. Attributes use the syntax of --attr, an
+// empty value removes the attribute, e.g. one kept from the source.
+type attrMapRule struct {
+	pattern string
+	attrs   map[string]string
+}
+
+// attrMap is a list of rules applied in order, the attributes of the
+// later rules override those of the earlier ones.
+type attrMap []attrMapRule
+
+// parseAttrMapRule parses PATTERN:KEY=VALUE;KEY=VALUE.
+func parseAttrMapRule(rule string) (attrMapRule, *probe.Error) {
+	i := strings.Index(rule, ":")
+	if i <= 0 {
+		return attrMapRule{}, probe.NewError(fmt.Errorf("invalid rule `%s`, expected PATTERN:KEY=VALUE;KEY=VALUE", rule))
+	}
+	pattern := strings.TrimSpace(rule[:i])
+	if _, e := path.Match(pattern, ""); e != nil {
+		return attrMapRule{}, probe.NewError(fmt.Errorf("invalid pattern `%s`: %v", pattern, e))
+	}
+	attrs, err := getMetaDataEntry(strings.TrimSpace(rule[i+1:]))
+	if err != nil {
+		return attrMapRule{}, err.Trace(rule)
+	}
+	return attrMapRule{pattern: pattern, attrs: attrs}, nil
+}
+
+// parseAttrMap parses the --attr-map rules, a rule starting with '@'
+// names a file of rules, one per line, lines starting with '#' are
+// comments.
+func parseAttrMap(rules []string) (attrMap, *probe.Error) {
+	var m attrMap
+	for _, rule := range rules {
+		if !strings.HasPrefix(rule, "@") {
+			r, err := parseAttrMapRule(rule)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, r)
+			continue
+		}
+		fileRules, err := readAttrMapFile(strings.TrimPrefix(rule, "@"))
+		if err != nil {
+			return nil, err
+		}
+		m = append(m, fileRules...)
+	}
+	return m, nil
+}
+
+func readAttrMapFile(filename string) (attrMap, *probe.Error) {
+	f, e := os.Open(filename)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	defer f.Close()
+
+	var m attrMap
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		r, err := parseAttrMapRule(line)
+		if err != nil {
+			return nil, err.Trace(fmt.Sprintf("%s:%d", filename, n))
+		}
+		m = append(m, r)
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	return m, nil
+}
+
+// matches returns true when the rule applies to the key of an object.
+func (r attrMapRule) matches(key string) bool {
+	name := key
+	if !strings.Contains(r.pattern, "/") {
+		name = path.Base(key)
+	}
+	ok, _ := path.Match(r.pattern, name)
+	return ok
+}
+
+// apply sets the attributes of the rules matching key in metadata.
+func (m attrMap) apply(key string, metadata map[string]string) {
+	for _, r := range m {
+		if !r.matches(key) {
+			continue
+		}
+		for k, v := range r.attrs {
+			metadata[k] = v
+		}
+	}
+}
+
+// applyURL sets the attributes of the rules matching an object storage
+// target, other targets have no HTTP attributes.
+func (m attrMap) applyURL(target ClientURL, metadata map[string]string) {
+	if len(m) == 0 || target.Type != objectStorage {
+		return
+	}
+	parts := splitStr(strings.TrimPrefix(target.Path, string(target.Separator)), string(target.Separator), 2)
+	m.apply(parts[1], metadata)
+}
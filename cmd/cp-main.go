@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/cli"
@@ -85,6 +86,12 @@ var (
 			Name:  "md5",
 			Usage: "force all upload(s) to calculate md5sum checksum",
 		},
+		cli.BoolFlag{
+			Name:  "dedupe",
+			Usage: "copy server side from an existing object with identical content instead of uploading",
+		},
+		encryptClientFlag,
+		identityFlag,
 		cli.StringFlag{
 			Name:  "tags",
 			Usage: "apply tags to the uploaded objects",
@@ -101,6 +108,22 @@ var (
 			Name:  lhFlag,
 			Usage: "apply legal hold to the copied object (on, off)",
 		},
+		cli.StringSliceFlag{
+			Name:  "attr-map",
+			Usage: "set attributes of the uploaded objects matching a pattern, e.g. '*.js:Cache-Control=max-age=31536000', '@FILE' for a file of rules",
+		},
+		cli.StringFlag{
+			Name:  "on-complete",
+			Usage: "run a command for each copied object, {} is replaced by the target",
+		},
+		cli.StringFlag{
+			Name:  "files-from",
+			Usage: "copy the keys read from a 'diff --output-manifest' manifest or from a file, one per line, '-' for STDIN",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "list the objects which would be copied and their target, without copying",
+		},
 	}
 )
 
@@ -118,7 +141,7 @@ var cpCmd = cli.Command{
 	Action:       mainCopy,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(cpFlags, ioFlags...), notifyFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -194,6 +217,32 @@ EXAMPLES:
   20. Set tags to the uploaded objects
       {{.Prompt}} {{.HelpName}} -r --tags "category=prod" ./data/ play/another-bucket/
 
+  21. Copy a folder recursively and scan each uploaded object, {key}, {bucket}, {size} and {etag} are available as well.
+      {{.Prompt}} {{.HelpName}} -r --on-complete 'clamscan-object {bucket} {key}' ./data/ play/mybucket/
+
+  22. Copy the objects missing or different in a bucket, as found by 'mc diff', keeping their keys.
+      {{.Prompt}} mc diff --output-manifest diff.json s3/mybucket play/mybucket
+      {{.Prompt}} {{.HelpName}} --files-from diff.json s3/mybucket play/mybucket
+
+  23. Copy a folder recursively, copying server side the files whose content is already in the bucket.
+      {{.Prompt}} {{.HelpName}} -r --dedupe ./photos/ play/mybucket/photos/
+
+  24. Copy a folder encrypted client-side for an age recipient, then restore it with the matching identity.
+      {{.Prompt}} {{.HelpName}} -r --encrypt-client age:age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p ./documents/ s3/mybucket/documents/
+      {{.Prompt}} {{.HelpName}} -r --identity ~/.age/key.txt s3/mybucket/documents/ ./restored/
+
+  25. Preview which objects a recursive copy would transfer and their target keys, without copying.
+      {{.Prompt}} {{.HelpName}} --dry-run --recursive play/mybucket/photos/ s3/archive/
+
+  26. Copy a bucket overnight and post the outcome to a Slack webhook once done.
+      {{.Prompt}} {{.HelpName}} -r --notify-url https://hooks.slack.com/services/T000/B000/XXXX \
+          --notify-template '{"text": {{"{{"}}json (printf "%s %s: %d objects" .Command .Status .Objects){{"}}"}}}' \
+          s3/archive/ myminio/archive/
+
+  27. Deploy a static site with long-lived caching of scripts and styles, and the rules of a file.
+      {{.Prompt}} {{.HelpName}} -r --attr-map '*.js:Cache-Control=max-age=31536000,immutable' \
+          --attr-map '*.css:Cache-Control=max-age=31536000,immutable' --attr-map @headers.rules ./public/ play/website/
+
 `,
 }
 
@@ -221,6 +270,52 @@ func (c copyMessage) JSON() string {
 	return string(copyMessageBytes)
 }
 
+// copyDryRunMessage container for a copy listed by --dry-run
+type copyDryRunMessage struct {
+	Status string `json:"status"`
+	DryRun bool   `json:"dryRun"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized copy dry run message
+func (c copyDryRunMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", c.Source, c.Target)) +
+		console.Colorize("CopySize", fmt.Sprintf(" (%s)", humanize.IBytes(uint64(c.Size))))
+}
+
+// JSON jsonified copy dry run message
+func (c copyDryRunMessage) JSON() string {
+	c.Status = "success"
+	c.DryRun = true
+	msgBytes, e := json.Marshal(c)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// copyDryRunSummary container for the totals of --dry-run
+type copyDryRunSummary struct {
+	Status  string `json:"status"`
+	DryRun  bool   `json:"dryRun"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+}
+
+// String colorized copy dry run summary
+func (c copyDryRunSummary) String() string {
+	return fmt.Sprintf("Would copy %d object(s), %s, nothing was copied.", c.Objects, humanize.IBytes(uint64(c.Size)))
+}
+
+// JSON jsonified copy dry run summary
+func (c copyDryRunSummary) JSON() string {
+	c.Status = "success"
+	c.DryRun = true
+	msgBytes, e := json.Marshal(c)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
 // Progress - an interface which describes current amount
 // of data written.
 type Progress interface {
@@ -279,6 +374,36 @@ func doCopyFake(ctx context.Context, cpURLs URLs, pg Progress) URLs {
 	return cpURLs
 }
 
+// doCopyDryRun lists the source objects with the target they would be
+// copied to, without copying any data.
+func doCopyDryRun(ctx context.Context, cli *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	sourceURLs := cli.Args()[:len(cli.Args())-1]
+	targetURL := cli.Args()[len(cli.Args())-1] // Last one is target
+
+	var summary copyDryRunSummary
+	for cpURLs := range prepareCopyURLs(ctx, sourceURLs, targetURL, cli.Bool("recursive"),
+		encKeyDB, cli.String("older-than"), cli.String("newer-than"), parseRewindFlag(cli.String("rewind")),
+		cli.String("version-id"), cli.String("files-from")) {
+		if cpURLs.Error != nil {
+			if strings.Contains(cpURLs.Error.ToGoError().Error(), " is a folder.") {
+				errorIf(cpURLs.Error.Trace(), "Folder cannot be copied. Please use `...` suffix.")
+			} else {
+				errorIf(cpURLs.Error.Trace(), "Unable to list the objects to copy.")
+			}
+			return exitStatus(globalErrorExitStatus)
+		}
+		printMsg(copyDryRunMessage{
+			Source: filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, cpURLs.SourceContent.URL.Path)),
+			Target: filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path)),
+			Size:   cpURLs.SourceContent.Size,
+		})
+		summary.Objects++
+		summary.Size += cpURLs.SourceContent.Size
+	}
+	printMsg(summary)
+	return nil
+}
+
 // doPrepareCopyURLs scans the source URL and prepares a list of objects for copying.
 func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy context.CancelFunc) (totalBytes, totalObjects int64) {
 	// Separate source and target. 'cp' can take only one target,
@@ -294,6 +419,7 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	newerThan := session.Header.CommandStringFlags["newer-than"]
 	encryptKeys := session.Header.CommandStringFlags["encrypt-key"]
 	encrypt := session.Header.CommandStringFlags["encrypt"]
+	filesFrom := session.Header.CommandStringFlags["files-from"]
 	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
 	fatalIf(err, "Unable to parse encryption keys.")
 
@@ -305,7 +431,7 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 		scanBar = scanBarFactory()
 	}
 
-	URLsCh := prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive, encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID)
+	URLsCh := prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive, encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID, filesFrom)
 	done := false
 	for !done {
 		select {
@@ -360,6 +486,7 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 
 func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, session *sessionV8, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) error {
 	var isCopied func(string) bool
+	var copied map[string]bool
 	var totalObjects, totalBytes int64
 
 	var cpURLsCh = make(chan URLs, 10000)
@@ -377,9 +504,18 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	sourceURLs := cli.Args()[:len(cli.Args())-1]
 	targetURL := cli.Args()[len(cli.Args())-1] // Last one is target
 
+	notifier, err := newJobNotifier(cli)
+	fatalIf(err, "Unable to parse --notify-url.")
+
 	tgtClnt, err := newClient(targetURL)
 	fatalIf(err, "Unable to initialize `"+targetURL+"`.")
 
+	clientEnc, err := getClientEncryption(cli)
+	fatalIf(err, "Unable to parse client-side encryption.")
+
+	attrMap, err := parseAttrMap(cli.StringSlice("attr-map"))
+	fatalIf(err, "Unable to parse --attr-map.")
+
 	// Check if the target bucket has object locking enabled
 	var withLock bool
 	if _, _, _, _, err = tgtClnt.GetObjectLockConfig(ctx); err == nil {
@@ -389,7 +525,11 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	if session != nil {
 		// isCopied returns true if an object has been already copied
 		// or not. This is useful when we resume from a session.
-		isCopied = isLastFactory(session.Header.LastCopied)
+		if copied = session.copiedURLs(); copied != nil {
+			isCopied = func(sourceURL string) bool { return copied[sourceURL] }
+		} else {
+			isCopied = isLastFactory(session.Header.LastCopied)
+		}
 
 		if !session.HasData() {
 			totalBytes, totalObjects = doPrepareCopyURLs(ctx, session, cancelCopy)
@@ -429,7 +569,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		go func() {
 			totalBytes := int64(0)
 			for cpURLs := range prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive,
-				encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID) {
+				encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID, cli.String("files-from")) {
 				if cpURLs.Error != nil {
 					// Print in new line and adjust to top so that we
 					// don't print over the ongoing scan bar
@@ -518,9 +658,12 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 						cpURLs.TargetContent.UserMetadata[metadataKey] = metaDataVal
 					}
 				}
+				attrMap.applyURL(cpURLs.TargetContent.URL, cpURLs.TargetContent.UserMetadata)
 
 				cpURLs.MD5 = cli.Bool("md5") || withLock
 				cpURLs.DisableMultipart = cli.Bool("disable-multipart")
+				cpURLs.Dedupe = cli.Bool("dedupe")
+				cpURLs.clientEncryption = clientEnc
 
 				// Verify if previously copied, notify progress bar.
 				if isCopied != nil && isCopied(cpURLs.SourceContent.URL.String()) {
@@ -536,7 +679,10 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		}
 	}()
 
+	onComplete := newOnCompleteHook(cli.String("on-complete"), encKeyDB)
+
 	var retErr error
+	var copiedObjects, failedObjects int64
 	errSeen := false
 	cpAllFilesErr := true
 
@@ -560,11 +706,22 @@ loop:
 				break loop
 			}
 			if cpURLs.Error == nil {
-				if session != nil {
-					session.Header.LastCopied = cpURLs.SourceContent.URL.String()
-					session.Save()
+				// Objects copied before resuming are already recorded.
+				if session != nil && !copied[cpURLs.SourceContent.URL.String()] {
+					session.markCopied(cpURLs.SourceContent.URL.String(), cpURLs.SourceContent.Size)
+				}
+				// Objects copied before resuming were already handed to the hook.
+				if onComplete != nil && !copied[cpURLs.SourceContent.URL.String()] {
+					if err := onComplete.run(ctx, cpURLs); err != nil {
+						if !globalQuiet && !globalJSON {
+							console.Eraseline()
+						}
+						errorIf(err.Trace(cpURLs.SourceContent.URL.String()), "Unable to run the --on-complete command.")
+						retErr = exitStatus(globalErrorExitStatus)
+					}
 				}
 				cpAllFilesErr = false
+				copiedObjects++
 			} else {
 
 				// Set exit status for any copy error
@@ -581,8 +738,10 @@ loop:
 					cpAllFilesErr = false
 					continue loop
 				}
+				notifier.addError(cpURLs.Error, cpURLs.SourceContent.URL.String())
 
 				errSeen = true
+				failedObjects++
 				if progressReader, pgok := pg.(*progressBar); pgok {
 					if progressReader.ProgressBar.Get() > 0 {
 						writeContSize := (int)(cpURLs.SourceContent.Size)
@@ -595,11 +754,11 @@ loop:
 					}
 				}
 
-				if session != nil {
+				if session != nil && session.Header.CommandBoolFlags["session"] {
 					// For critical errors we should exit. Session
 					// can be resumed after the user figures out
 					// the  problem.
-					session.copyCloseAndDie(session.Header.CommandBoolFlags["session"])
+					session.copyCloseAndDie(true)
 				}
 			}
 		}
@@ -617,6 +776,29 @@ loop:
 		}
 	}
 
+	command := "cp"
+	if isMvCmd {
+		command = "mv"
+	}
+	logSummary(command, map[string]interface{}{
+		"totalObjects":  totalObjects,
+		"copiedObjects": copiedObjects,
+		"failedObjects": failedObjects,
+		"transferred":   pg.Get(),
+	})
+	notifier.send(jobSummary{
+		Command: command,
+		Source:  strings.Join(sourceURLs, " "),
+		Target:  targetURL,
+		Objects: copiedObjects,
+		Failed:  failedObjects,
+		Bytes:   pg.Get(),
+	})
+
+	// Some objects were copied while others failed.
+	if retErr != nil && !cpAllFilesErr {
+		retErr = exitStatus(globalPartialExitStatus)
+	}
 	return retErr
 }
 
@@ -642,6 +824,11 @@ func mainCopy(cliCtx *cli.Context) error {
 	// Additional command specific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
 
+	if cliCtx.Bool("dry-run") {
+		console.SetColor("CopySize", color.New(color.FgYellow))
+		return doCopyDryRun(ctx, cliCtx, encKeyDB)
+	}
+
 	recursive := cliCtx.Bool("recursive")
 	rewind := cliCtx.String("rewind")
 	versionID := cliCtx.String("version-id")
@@ -665,9 +852,11 @@ func mainCopy(cliCtx *cli.Context) error {
 
 	var session *sessionV8
 
-	if cliCtx.Bool("continue") {
-		sessionID := getHash("cp", os.Args[1:])
-		if isSessionExists(sessionID) {
+	// Copies of several objects can always be resumed, with `--continue`
+	// or `mc session resume`. Otherwise the same command starts over.
+	if cliCtx.Bool("continue") || recursive {
+		sessionID := getHash("cp", sessionArgs())
+		if cliCtx.Bool("continue") && isSessionExists(sessionID) {
 			session, err = loadSessionV8(sessionID)
 			fatalIf(err.Trace(sessionID), "Unable to load session.")
 		} else {
@@ -685,6 +874,7 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.CommandStringFlags[lhFlag] = legalHold
 			session.Header.CommandStringFlags["encrypt-key"] = sseKeys
 			session.Header.CommandStringFlags["encrypt"] = sse
+			session.Header.CommandStringFlags["files-from"] = cliCtx.String("files-from")
 			session.Header.CommandBoolFlags["session"] = cliCtx.Bool("continue")
 
 			if cliCtx.Bool("preserve") {
@@ -693,6 +883,7 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.UserMetaData = userMetaMap
 			session.Header.CommandBoolFlags["md5"] = cliCtx.Bool("md5")
 			session.Header.CommandBoolFlags["disable-multipart"] = cliCtx.Bool("disable-multipart")
+			session.Header.CommandBoolFlags["dedupe"] = cliCtx.Bool("dedupe")
 
 			var e error
 			if session.Header.RootPath, e = os.Getwd(); e != nil {
@@ -707,7 +898,15 @@ func mainCopy(cliCtx *cli.Context) error {
 
 	e := doCopySession(ctx, cancelCopy, cliCtx, session, encKeyDB, false)
 	if session != nil {
-		session.Delete()
+		if e == nil {
+			session.Delete()
+		} else {
+			// Keep the session to copy the failed objects again.
+			session.Close()
+			if !globalQuiet && !globalJSON {
+				console.Infoln("Run `mc session resume " + shortSessionID(session.SessionID) + "` to copy the failed objects again.")
+			}
+		}
 	}
 
 	return e
@@ -17,8 +17,20 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"testing"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
 )
 
 func TestParseMetaData(t *testing.T) {
@@ -73,3 +85,126 @@ func TestParseMetaData(t *testing.T) {
 		}
 	}
 }
+
+func TestCopyDryRunMessage(t *testing.T) {
+	msg := copyDryRunMessage{Source: "play/mybucket/a.txt", Target: "s3/archive/a.txt", Size: 2048}
+	expected := `{"status":"success","dryRun":true,"source":"play/mybucket/a.txt","target":"s3/archive/a.txt","size":2048}`
+	if got := msg.JSON(); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+	summary := copyDryRunSummary{Objects: 3, Size: 2048}
+	if got := summary.String(); got != "Would copy 3 object(s), 2.0 KiB, nothing was copied." {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
+
+func TestCopyDryRun(t *testing.T) {
+	dir, e := ioutil.TempDir("", "cp-dry-run")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	source, target := filepath.Join(dir, "src"), filepath.Join(dir, "dst")
+	for _, name := range []string{"a.txt", filepath.Join("sub", "b.txt")} {
+		path := filepath.Join(source, name)
+		if e = os.MkdirAll(filepath.Dir(path), 0700); e != nil {
+			t.Fatal(e)
+		}
+		if e = ioutil.WriteFile(path, []byte("data"), 0600); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	defer func(load func() (*configV10, *probe.Error), output io.Writer, isJSON bool) {
+		loadMcConfig = load
+		color.Output = output
+		globalJSON = isJSON
+	}(loadMcConfig, color.Output, globalJSON)
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		return newConfigV10(), nil
+	}
+	globalJSON = true
+
+	testCases := []struct {
+		source  string
+		targets []string
+	}{
+		// Without a trailing slash the folder itself is copied.
+		{source, []string{"dst/src/a.txt", "dst/src/sub/b.txt"}},
+		// With a trailing slash only its content is.
+		{source + string(filepath.Separator), []string{"dst/a.txt", "dst/sub/b.txt"}},
+	}
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		color.Output = &buf
+		app := cli.NewApp()
+		app.Commands = []cli.Command{{
+			Name:  "cp",
+			Flags: append(append(cpFlags, ioFlags...), notifyFlags...),
+			Action: func(ctx *cli.Context) error {
+				return doCopyDryRun(context.Background(), ctx, nil)
+			},
+		}}
+		if e = app.Run([]string{"mc", "cp", "--recursive", testCase.source, target}); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+
+		var targets []string
+		decoder := json.NewDecoder(&buf)
+		for decoder.More() {
+			var msg copyDryRunMessage
+			if e = decoder.Decode(&msg); e != nil {
+				t.Fatalf("Test %d: %v", i+1, e)
+			}
+			if msg.Target == "" {
+				continue // The summary.
+			}
+			rel, e := filepath.Rel(dir, filepath.FromSlash(msg.Target))
+			if e != nil {
+				t.Fatalf("Test %d: %v", i+1, e)
+			}
+			targets = append(targets, filepath.ToSlash(rel))
+		}
+		sort.Strings(targets)
+		if !reflect.DeepEqual(targets, testCase.targets) {
+			t.Errorf("Test %d: expected targets %v, got %v", i+1, testCase.targets, targets)
+		}
+		if _, e = os.Stat(target); !os.IsNotExist(e) {
+			t.Errorf("Test %d: expected nothing to be copied", i+1)
+		}
+	}
+}
+
+func TestAttrMap(t *testing.T) {
+	m, err := parseAttrMap([]string{
+		"*.js:Cache-Control=max-age=31536000,immutable;Content-Encoding=gzip",
+		"assets/*:Cache-Control=max-age=86400",
+		"*.min.js:Content-Encoding=",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		key      string
+		expected map[string]string
+	}{
+		{"js/app.js", map[string]string{"Cache-Control": "max-age=31536000,immutable", "Content-Encoding": "gzip"}},
+		{"js/app.min.js", map[string]string{"Cache-Control": "max-age=31536000,immutable", "Content-Encoding": ""}},
+		{"assets/logo.svg", map[string]string{"Cache-Control": "max-age=86400"}},
+		{"assets/js/lib.js", map[string]string{"Cache-Control": "max-age=31536000,immutable", "Content-Encoding": "gzip"}},
+		{"index.html", map[string]string{}},
+	}
+	for i, testCase := range testCases {
+		metadata := map[string]string{}
+		m.apply(testCase.key, metadata)
+		if !reflect.DeepEqual(metadata, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, metadata)
+		}
+	}
+
+	for _, rule := range []string{"Cache-Control=no-cache", "[:Cache-Control=no-cache", "*.js:Cache-Control"} {
+		if _, err = parseAttrMap([]string{rule}); err == nil {
+			t.Fatalf("expected rule %q to fail", rule)
+		}
+	}
+}
@@ -30,9 +30,9 @@ import (
 func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) {
 	if len(cliCtx.Args()) < 2 {
 		if isMvCmd {
-			cli.ShowCommandHelpAndExit(cliCtx, "mv", 1) // last argument is exit code.
+			cli.ShowCommandHelpAndExit(cliCtx, "mv", globalInvalidArgsExitStatus) // last argument is exit code.
 		}
-		cli.ShowCommandHelpAndExit(cliCtx, "cp", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(cliCtx, "cp", globalInvalidArgsExitStatus) // last argument is exit code.
 	}
 
 	// extract URLs.
@@ -51,6 +51,17 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		fatalIf(errDummy().Trace(cliCtx.Args()...), "Unable to pass --version flag with multiple copy sources arguments.")
 	}
 
+	// The keys read from --files-from are checked when copied.
+	if cliCtx.String("files-from") != "" {
+		if len(srcURLs) != 1 {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "Unable to pass --files-from with multiple copy sources arguments.")
+		}
+		if isRecursive || versionID != "" {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "Unable to pass --files-from with --recursive or --version-id.")
+		}
+		return
+	}
+
 	// Verify if source(s) exists.
 	for _, srcURL := range srcURLs {
 		var err *probe.Error
@@ -219,11 +219,49 @@ func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL st
 	return copyURLsCh
 }
 
-// prepareCopyURLs - prepares target and source clientURLs for copying.
-func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair, olderThan, newerThan string, timeRef time.Time, versionID string) chan URLs {
+// prepareCopyURLsFromFile - prepares target and source clientURLs for
+// copying the keys read from a file, relative to the source and target.
+func prepareCopyURLsFromFile(ctx context.Context, sourceURL, targetURL, filesFrom string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+	copyURLsCh := make(chan URLs)
+	go func() {
+		defer close(copyURLsCh)
+		keys, err := readCopyKeys(filesFrom)
+		if err != nil {
+			copyURLsCh <- URLs{Error: err.Trace(filesFrom)}
+			return
+		}
+		sourceAlias, _, _ := mustExpandAlias(sourceURL)
+		targetAlias, targetURL, _ := mustExpandAlias(targetURL)
+		for _, key := range keys {
+			keyURL := urlJoinPath(sourceURL, key)
+			// A key which cannot be copied does not stop the others.
+			_, sourceContent, err := url2Stat(ctx, keyURL, "", false, encKeyDB, timeRef)
+			if err != nil {
+				errorIf(err.Trace(keyURL), "Unable to stat `"+keyURL+"`.")
+				continue
+			}
+			if !sourceContent.Type.IsRegular() {
+				errorIf(errInvalidSource(keyURL).Trace(keyURL), "Unable to copy `"+keyURL+"`.")
+				continue
+			}
+			copyURLsCh <- makeCopyContentTypeA(sourceAlias, sourceContent, targetAlias, urlJoinPath(targetURL, key), encKeyDB)
+		}
+	}()
+	return copyURLsCh
+}
+
+// prepareCopyURLs - prepares target and source clientURLs for copying,
+// the keys read from filesFrom only if not empty.
+func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair, olderThan, newerThan string, timeRef time.Time, versionID, filesFrom string) chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs, encKeyDB map[string][]prefixSSEPair, timeRef time.Time) {
 		defer close(copyURLsCh)
+		if filesFrom != "" {
+			for cURLs := range prepareCopyURLsFromFile(ctx, sourceURLs[0], targetURL, filesFrom, timeRef, encKeyDB) {
+				copyURLsCh <- cURLs
+			}
+			return
+		}
 		cpType, cpVersion, err := guessCopyURLType(ctx, sourceURLs, targetURL, isRecursive, encKeyDB, timeRef, versionID)
 		fatalIf(err.Trace(), "Unable to guess the type of copy operation.")
 
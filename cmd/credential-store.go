@@ -0,0 +1,56 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// credentialStoreKeychain is set on aliases whose secret key is kept
+// in the OS keychain instead of the config file.
+const credentialStoreKeychain = mcconfig.CredentialStoreKeychain
+
+// keychainAccount returns the keychain account of an alias of the
+// selected profile.
+func keychainAccount(alias string) string {
+	return mcconfig.KeychainAccount(mcProfile, alias)
+}
+
+// resolveAliasSecret fills the secret key of an alias kept in the keychain.
+func resolveAliasSecret(alias string, aliasCfg *aliasConfigV10) *probe.Error {
+	if aliasCfg.CredentialStore != credentialStoreKeychain || aliasCfg.SecretKey != "" {
+		return nil
+	}
+	secretKey, e := mcconfig.KeychainGet(keychainAccount(alias))
+	if e != nil {
+		return probe.NewError(e).Trace(alias)
+	}
+	aliasCfg.SecretKey = secretKey
+	return nil
+}
+
+// moveSecretToKeychain stores the secret key of an alias in the
+// keychain and removes it from the alias config.
+func moveSecretToKeychain(alias string, aliasCfg *aliasConfigV10) *probe.Error {
+	if e := mcconfig.KeychainSet(keychainAccount(alias), aliasCfg.SecretKey); e != nil {
+		return probe.NewError(e).Trace(alias)
+	}
+	aliasCfg.SecretKey = ""
+	aliasCfg.CredentialStore = credentialStoreKeychain
+	return nil
+}
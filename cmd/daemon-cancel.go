@@ -0,0 +1,73 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/minio/cli"
+)
+
+var daemonCancelCmd = cli.Command{
+	Name:            "cancel",
+	Usage:           "cancel a daemon job",
+	Action:          mainDaemonCancel,
+	Before:          setGlobalsFromContext,
+	Flags:           append([]cli.Flag{daemonAddressFlag}, globalFlags...),
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] JOB-ID [JOB-ID...]
+
+  A running command is stopped, a scheduled job does not run again.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_DAEMON_ADDRESS  address of the daemon API
+
+EXAMPLES:
+  1. Cancel job 3.
+     {{.Prompt}} {{.HelpName}} 3
+`,
+}
+
+// mainDaemonCancel is the handle for "mc daemon cancel" command.
+func mainDaemonCancel(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "cancel", globalInvalidArgsExitStatus)
+	}
+	setDaemonColors()
+
+	var cErr error
+	for _, id := range ctx.Args() {
+		var job daemonJob
+		err := daemonRequest(ctx.String("address"), http.MethodDelete, "/v1/jobs/"+url.PathEscape(id), nil, &job)
+		if err != nil {
+			errorIf(err.Trace(id), "Unable to cancel job `%s`.", id)
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(daemonJobMessage{daemonJob: job, op: "cancel"})
+	}
+	return cErr
+}
@@ -0,0 +1,82 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/minio/cli"
+)
+
+var daemonListCmd = cli.Command{
+	Name:            "list",
+	ShortName:       "ls",
+	Usage:           "list daemon jobs and their progress",
+	Action:          mainDaemonList,
+	Before:          setGlobalsFromContext,
+	Flags:           append([]cli.Flag{daemonAddressFlag}, globalFlags...),
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [JOB-ID]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_DAEMON_ADDRESS  address of the daemon API
+
+EXAMPLES:
+  1. List all jobs of the daemon.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Show the progress of job 3 and its past runs.
+     {{.Prompt}} {{.HelpName}} 3
+
+  3. Show the progress of job 3 in JSON.
+     {{.Prompt}} {{.HelpName}} --json 3
+`,
+}
+
+// mainDaemonList is the handle for "mc daemon list" command.
+func mainDaemonList(ctx *cli.Context) error {
+	if len(ctx.Args()) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", globalInvalidArgsExitStatus)
+	}
+	setDaemonColors()
+	address := ctx.String("address")
+
+	if id := ctx.Args().First(); id != "" {
+		var job daemonJob
+		err := daemonRequest(address, http.MethodGet, "/v1/jobs/"+url.PathEscape(id), nil, &job)
+		fatalIf(err.Trace(id), "Unable to get job `%s`.", id)
+		printMsg(daemonJobMessage{daemonJob: job, history: true})
+		return nil
+	}
+
+	var jobs []daemonJob
+	err := daemonRequest(address, http.MethodGet, "/v1/jobs", nil, &jobs)
+	fatalIf(err.Trace(address), "Unable to list the daemon jobs.")
+	for _, job := range jobs {
+		printMsg(daemonJobMessage{daemonJob: job})
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+const (
+	// mcEnvDaemonAddress overrides the address of the daemon API.
+	mcEnvDaemonAddress = "MC_DAEMON_ADDRESS"
+
+	defaultDaemonAddress = "localhost:9191"
+
+	// daemonTokenFile holds the bearer token of the daemon API, it is
+	// written to the config dir by 'mc daemon start'.
+	daemonTokenFile = "daemon.token"
+)
+
+var daemonAddressFlag = cli.StringFlag{
+	Name:   "address",
+	Usage:  "address of the daemon API",
+	Value:  defaultDaemonAddress,
+	EnvVar: mcEnvDaemonAddress,
+}
+
+var daemonSubcommands = []cli.Command{
+	daemonStartCmd,
+	daemonSubmitCmd,
+	daemonListCmd,
+	daemonCancelCmd,
+	daemonPauseCmd,
+	daemonResumeCmd,
+}
+
+var daemonCmd = cli.Command{
+	Name:            "daemon",
+	Usage:           "run long-lived jobs managed through a local API",
+	Action:          mainDaemon,
+	Before:          setGlobalsFromContext,
+	HideHelpCommand: true,
+	Flags:           globalFlags,
+	Subcommands:     daemonSubcommands,
+}
+
+// mainDaemon is the handle for "mc daemon" command.
+func mainDaemon(ctx *cli.Context) error {
+	commandNotFound(ctx, daemonSubcommands)
+	return nil
+	// Sub-commands like start, submit, list, cancel, pause and resume have their own main.
+}
+
+// daemonJobMessage container for a job of the daemon.
+type daemonJobMessage struct {
+	Status string `json:"status"`
+	daemonJob
+
+	// Operation which returned the job, "submit", "cancel", "pause",
+	// "resume" or empty when listing.
+	op string
+	// Print the past runs of the job as well.
+	history bool
+}
+
+func (d daemonJobMessage) String() string {
+	id := console.Colorize("JobID", "["+d.ID+"]")
+	command := "mc " + strings.Join(d.Args, " ")
+	switch d.op {
+	case "submit":
+		return fmt.Sprintf("Submitted job %s: %s", id, command)
+	case "cancel":
+		return fmt.Sprintf("Cancelled job %s.", id)
+	case "pause":
+		return fmt.Sprintf("Paused job %s.", id)
+	case "resume":
+		return fmt.Sprintf("Resumed job %s.", id)
+	}
+
+	state := d.State
+	if d.State == daemonJobScheduled && d.NextRun != nil {
+		state += " " + humanize.Time(*d.NextRun)
+	}
+	if d.Paused {
+		state += " (paused)"
+	}
+	msg := fmt.Sprintf("%s %s %s", id, console.Colorize("JobState", fmt.Sprintf("%-20s", state)), console.Colorize("Command", command))
+	switch {
+	case d.Every != "":
+		msg += " (every " + d.Every + ")"
+	case d.Schedule != "":
+		msg += " (schedule " + d.Schedule + ")"
+	}
+	if d.ActiveHours != "" {
+		msg += " (active hours " + d.ActiveHours + ")"
+	}
+	msg += fmt.Sprintf("\n     runs: %d, objects: %d, transferred: %s, errors: %d",
+		d.Runs, d.Objects, humanize.IBytes(uint64(d.Bytes)), d.Errors)
+	if d.State == daemonJobFailed {
+		msg += fmt.Sprintf(", exit code: %d", d.ExitCode)
+	}
+	if d.LastError != "" {
+		msg += "\n     " + console.Colorize("JobError", d.LastError)
+	}
+	if d.NotifyError != "" {
+		msg += "\n     " + console.Colorize("JobError", "notification failed: "+d.NotifyError)
+	}
+	if d.history {
+		for i := len(d.History) - 1; i >= 0; i-- {
+			run := d.History[i]
+			msg += fmt.Sprintf("\n     %s  %-9s %s, objects: %d, transferred: %s, errors: %d",
+				run.Started.Local().Format(printDate), run.State,
+				timeDurationToHumanizedDuration(run.Finished.Sub(run.Started)).StringShort(),
+				run.Objects, humanize.IBytes(uint64(run.Bytes)), run.Errors)
+			if run.State == daemonJobFailed {
+				msg += fmt.Sprintf(", exit code: %d", run.ExitCode)
+			}
+		}
+	}
+	return msg
+}
+
+func (d daemonJobMessage) JSON() string {
+	d.Status = "success"
+	jsonMessageBytes, e := json.Marshal(d)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// setDaemonColors sets the colors of the job messages.
+func setDaemonColors() {
+	console.SetColor("JobID", color.New(color.FgYellow, color.Bold))
+	console.SetColor("JobState", color.New(color.FgGreen))
+	console.SetColor("Command", color.New(color.FgCyan))
+	console.SetColor("JobError", color.New(color.FgRed))
+}
+
+// daemonTokenPath returns the path of the daemon API token.
+func daemonTokenPath() string {
+	return filepath.Join(mustGetMcConfigDir(), daemonTokenFile)
+}
+
+// readDaemonToken reads the token written by the running daemon.
+func readDaemonToken() (string, *probe.Error) {
+	data, e := ioutil.ReadFile(daemonTokenPath())
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// daemonRequest calls the daemon API, the response is decoded into out.
+func daemonRequest(address, method, path string, body, out interface{}) *probe.Error {
+	var reqBody io.Reader
+	if body != nil {
+		data, e := json.Marshal(body)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, e := http.NewRequestWithContext(globalContext, method, "http://"+address+path, reqBody)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	token, err := readDaemonToken()
+	if err != nil {
+		return err.Trace(daemonTokenPath())
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, e := http.DefaultClient.Do(req)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		var errResp daemonErrorResponse
+		if e = json.NewDecoder(resp.Body).Decode(&errResp); e != nil || errResp.Error == "" {
+			return probe.NewError(fmt.Errorf("unexpected response %s", resp.Status))
+		}
+		return probe.NewError(errors.New(errResp.Error))
+	}
+	if out != nil {
+		if e = json.NewDecoder(resp.Body).Decode(out); e != nil {
+			return probe.NewError(e)
+		}
+	}
+	return nil
+}
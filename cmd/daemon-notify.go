@@ -0,0 +1,129 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Timeout of a failure notification.
+const daemonNotifyTimeout = 30 * time.Second
+
+// daemonSMTPConfig is the mail server failure notifications are sent
+// through.
+type daemonSMTPConfig struct {
+	Server   string
+	From     string
+	User     string
+	Password string
+}
+
+// daemonJobFailure is the notification of a failed run, posted as JSON
+// to the webhook of the job.
+type daemonJobFailure struct {
+	ID        string    `json:"id"`
+	Args      []string  `json:"args"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+	ExitCode  int       `json:"exitCode"`
+	Errors    int64     `json:"errors"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+func (f daemonJobFailure) subject() string {
+	subject := fmt.Sprintf("mc job %s failed: mc %s", f.ID, strings.Join(f.Args, " "))
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+}
+
+func (f daemonJobFailure) body() string {
+	return fmt.Sprintf("Job %s failed with exit code %d.\r\n\r\nCommand: mc %s\r\nStarted: %s\r\nFinished: %s\r\nErrors: %d\r\nLast error: %s\r\n",
+		f.ID, f.ExitCode, strings.Join(f.Args, " "), f.Started.Format(time.RFC1123Z), f.Finished.Format(time.RFC1123Z), f.Errors, f.LastError)
+}
+
+// checkNotifyWebhook validates the URL failures are posted to.
+func checkNotifyWebhook(webhook string) error {
+	u, e := url.Parse(webhook)
+	if e != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid webhook `%s`", webhook)
+	}
+	return nil
+}
+
+// checkNotifyEmail validates the address failures are mailed to.
+func checkNotifyEmail(smtpConfig *daemonSMTPConfig, address string) error {
+	if _, e := mail.ParseAddress(address); e != nil {
+		return fmt.Errorf("invalid email address `%s`", address)
+	}
+	if smtpConfig == nil {
+		return fmt.Errorf("the daemon was started without --smtp-server, it cannot send emails")
+	}
+	return nil
+}
+
+// notifyWebhook posts a failure to a webhook.
+func notifyWebhook(ctx context.Context, webhook string, failure daemonJobFailure) error {
+	data, e := json.Marshal(failure)
+	if e != nil {
+		return e
+	}
+	ctx, cancel := context.WithTimeout(ctx, daemonNotifyTimeout)
+	defer cancel()
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(data))
+	if e != nil {
+		return e
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, e := http.DefaultClient.Do(req)
+	if e != nil {
+		return e
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// notifyEmail mails a failure through the SMTP server, STARTTLS is used
+// when the server supports it.
+func notifyEmail(smtpConfig *daemonSMTPConfig, to string, failure daemonJobFailure) error {
+	var auth smtp.Auth
+	if smtpConfig.User != "" {
+		host, _, e := net.SplitHostPort(smtpConfig.Server)
+		if e != nil {
+			return e
+		}
+		auth = smtp.PlainAuth("", smtpConfig.User, smtpConfig.Password, host)
+	}
+	msg := "From: " + smtpConfig.From + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + failure.subject() + "\r\n" +
+		"Date: " + failure.Finished.Format(time.RFC1123Z) + "\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n" +
+		failure.body()
+	return smtp.SendMail(smtpConfig.Server, auth, smtpConfig.From, []string{to}, []byte(msg))
+}
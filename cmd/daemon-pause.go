@@ -0,0 +1,110 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/minio/cli"
+)
+
+var daemonPauseCmd = cli.Command{
+	Name:            "pause",
+	Usage:           "pause a running mirror job",
+	Action:          mainDaemonPause,
+	Before:          setGlobalsFromContext,
+	Flags:           append([]cli.Flag{daemonAddressFlag}, globalFlags...),
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] JOB-ID [JOB-ID...]
+
+  The objects being transferred complete, the next ones wait until the job
+  is resumed. The job keeps its progress while paused.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_DAEMON_ADDRESS  address of the daemon API
+
+EXAMPLES:
+  1. Pause job 3.
+     {{.Prompt}} {{.HelpName}} 3
+`,
+}
+
+var daemonResumeCmd = cli.Command{
+	Name:            "resume",
+	Usage:           "resume a paused mirror job",
+	Action:          mainDaemonResume,
+	Before:          setGlobalsFromContext,
+	Flags:           append([]cli.Flag{daemonAddressFlag}, globalFlags...),
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] JOB-ID [JOB-ID...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_DAEMON_ADDRESS  address of the daemon API
+
+EXAMPLES:
+  1. Resume job 3.
+     {{.Prompt}} {{.HelpName}} 3
+`,
+}
+
+// mainDaemonPause is the handle for "mc daemon pause" command.
+func mainDaemonPause(ctx *cli.Context) error {
+	return daemonPauseJobs(ctx, "pause")
+}
+
+// mainDaemonResume is the handle for "mc daemon resume" command.
+func mainDaemonResume(ctx *cli.Context) error {
+	return daemonPauseJobs(ctx, "resume")
+}
+
+// daemonPauseJobs pauses or resumes the jobs given as arguments.
+func daemonPauseJobs(ctx *cli.Context, op string) error {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, op, globalInvalidArgsExitStatus)
+	}
+	setDaemonColors()
+
+	var cErr error
+	for _, id := range ctx.Args() {
+		var job daemonJob
+		err := daemonRequest(ctx.String("address"), http.MethodPost, "/v1/jobs/"+url.PathEscape(id)+"/"+op, nil, &job)
+		if err != nil {
+			errorIf(err.Trace(id), "Unable to %s job `%s`.", op, id)
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(daemonJobMessage{daemonJob: job, op: op})
+	}
+	return cErr
+}
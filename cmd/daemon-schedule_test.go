@@ -0,0 +1,60 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday.
+	from := time.Date(2021, time.May, 12, 10, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"0 2 * * *", time.Date(2021, time.May, 13, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2021, time.May, 12, 11, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2021, time.May, 12, 10, 45, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2021, time.May, 13, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2021, time.May, 13, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2021, time.May, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2021, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week.
+		{"0 0 1 * 5", time.Date(2021, time.May, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for i, testCase := range testCases {
+		s, e := parseCronSchedule(testCase.spec)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if next := s.next(from); !next.Equal(testCase.expected) {
+			t.Errorf("Test %d: %s expected %v, got %v", i+1, testCase.spec, testCase.expected, next)
+		}
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for i, spec := range []string{"", "0 2 * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@often"} {
+		if _, e := parseCronSchedule(spec); e == nil {
+			t.Errorf("Test %d: expected an error for %q", i+1, spec)
+		}
+	}
+}
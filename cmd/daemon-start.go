@@ -0,0 +1,249 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/mail"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var daemonStartFlags = []cli.Flag{
+	daemonAddressFlag,
+	cli.StringFlag{
+		Name:  "smtp-server",
+		Usage: "mail server of the failure notifications of --notify-email, e.g. smtp.example.com:587",
+	},
+	cli.StringFlag{
+		Name:  "smtp-from",
+		Usage: "sender address of the failure notifications",
+	},
+	cli.StringFlag{
+		Name:   "smtp-user",
+		Usage:  "user name to authenticate to the mail server",
+		EnvVar: "MC_DAEMON_SMTP_USER",
+	},
+	cli.StringFlag{
+		Name:   "smtp-password",
+		Usage:  "password to authenticate to the mail server",
+		EnvVar: "MC_DAEMON_SMTP_PASSWORD",
+	},
+}
+
+var daemonStartCmd = cli.Command{
+	Name:            "start",
+	Usage:           "run the daemon in the foreground",
+	Action:          mainDaemonStart,
+	Before:          setGlobalsFromContext,
+	Flags:           append(daemonStartFlags, globalFlags...),
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS]
+
+  The daemon runs mc commands submitted through its REST API and reports
+  their progress, there is no gRPC API. The API only listens on loopback
+  addresses, requests must send the token written to 'daemon.token' in the
+  config dir at start as a bearer token, and a JSON content type unless
+  they are GET requests. The last 100 finished jobs are kept:
+
+    GET    /v1/jobs       list all jobs
+    POST   /v1/jobs       submit a job, e.g. {"args": ["mirror", "--watch", "src", "dst"]}
+                          with an optional interval to run it again, e.g. "every": "1h",
+                          or a crontab schedule, e.g. "schedule": "0 2 * * *", and the
+                          "notifyWebhook" and "notifyEmail" notified of failed runs,
+                          and "activeHours", e.g. "22:00-06:00", to only run at night
+    GET    /v1/jobs/{id}  get a job and its progress
+    DELETE /v1/jobs/{id}  cancel a job
+    POST   /v1/jobs/{id}/pause
+    POST   /v1/jobs/{id}/resume
+                          pause or resume a running mirror job
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_DAEMON_ADDRESS        address of the daemon API
+  MC_DAEMON_SMTP_USER      user name to authenticate to the mail server
+  MC_DAEMON_SMTP_PASSWORD  password to authenticate to the mail server
+
+EXAMPLES:
+  1. Start the daemon on the default address.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Start the daemon on port 7000.
+     {{.Prompt}} {{.HelpName}} --address 127.0.0.1:7000
+
+  3. Submit a job with curl.
+     {{.Prompt}} curl -H "Authorization: Bearer $(cat ~/.mc/daemon.token)" -H "Content-Type: application/json" \
+          -d '{"args": ["mirror", "--watch", "/data", "play/data"]}' http://localhost:9191/v1/jobs
+
+  4. Start the daemon with a mail server to notify job failures by email.
+     {{.Prompt}} export MC_DAEMON_SMTP_USER=alerts MC_DAEMON_SMTP_PASSWORD=secret
+     {{.Prompt}} {{.HelpName}} --smtp-server smtp.example.com:587 --smtp-from mc@example.com
+`,
+}
+
+// daemonStartMessage container for the address the daemon listens on.
+type daemonStartMessage struct {
+	Status  string `json:"status"`
+	Address string `json:"address"`
+}
+
+func (d daemonStartMessage) String() string {
+	return console.Colorize("DaemonStart", "Daemon API listening on `http://"+d.Address+"`.")
+}
+
+func (d daemonStartMessage) JSON() string {
+	d.Status = "success"
+	jsonMessageBytes, e := json.Marshal(d)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// isLoopbackAddress returns true if the host of address can
+// only be reached from the local machine.
+func isLoopbackAddress(address string) bool {
+	host, _, e := net.SplitHostPort(address)
+	if e != nil {
+		return false
+	}
+	return isLoopbackHostname(host)
+}
+
+// isLoopbackHostname returns true for localhost and loopback IPs.
+func isLoopbackHostname(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// writeDaemonToken writes a new random API token, only readable by
+// the current user.
+func writeDaemonToken() (string, *probe.Error) {
+	if err := createMcConfigDir(); err != nil {
+		return "", err.Trace()
+	}
+	buf := make([]byte, 32)
+	if _, e := rand.Read(buf); e != nil {
+		return "", probe.NewError(e)
+	}
+	token := hex.EncodeToString(buf)
+
+	// A previous file is removed so that it cannot keep looser permissions.
+	tokenPath := daemonTokenPath()
+	if e := os.Remove(tokenPath); e != nil && !os.IsNotExist(e) {
+		return "", probe.NewError(e)
+	}
+	f, e := os.OpenFile(tokenPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	if _, e = f.WriteString(token + "\n"); e != nil {
+		f.Close()
+		return "", probe.NewError(e)
+	}
+	if e = f.Close(); e != nil {
+		return "", probe.NewError(e)
+	}
+	return token, nil
+}
+
+// mainDaemonStart is the handle for "mc daemon start" command.
+func mainDaemonStart(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "start", globalInvalidArgsExitStatus)
+	}
+	console.SetColor("DaemonStart", color.New(color.FgGreen))
+
+	address := ctx.String("address")
+	if !isLoopbackAddress(address) {
+		fatalIf(errInvalidArgument().Trace(address),
+			"The daemon API only listens on loopback addresses, `%s` must be an address such as `%s`.", address, defaultDaemonAddress)
+	}
+
+	executable, e := os.Executable()
+	fatalIf(probe.NewError(e), "Unable to find the mc executable.")
+
+	// Jobs always print JSON, their progress is read from it.
+	globalArgs := []string{"--config-dir", mustGetMcConfigDir(), "--profile", getMcProfile(), "--json", "--no-color"}
+	for _, flag := range []string{"debug", "insecure"} {
+		if ctx.IsSet(flag) || ctx.GlobalIsSet(flag) {
+			globalArgs = append(globalArgs, "--"+flag)
+		}
+	}
+
+	listener, e := net.Listen("tcp", address)
+	fatalIf(probe.NewError(e), "Unable to listen on `%s`.", address)
+
+	jobs := newDaemonJobs(globalContext, executable, globalArgs)
+	if server := ctx.String("smtp-server"); server != "" {
+		if _, _, e = net.SplitHostPort(server); e != nil {
+			fatalIf(errInvalidArgument().Trace(server), "Invalid value for --smtp-server, must be HOST:PORT.")
+		}
+		from := ctx.String("smtp-from")
+		if _, e = mail.ParseAddress(from); e != nil {
+			fatalIf(errInvalidArgument().Trace(from), "Invalid or missing --smtp-from address.")
+		}
+		jobs.smtp = &daemonSMTPConfig{
+			Server:   server,
+			From:     from,
+			User:     ctx.String("smtp-user"),
+			Password: ctx.String("smtp-password"),
+		}
+	}
+	token, err := writeDaemonToken()
+	fatalIf(err.Trace(daemonTokenPath()), "Unable to write the daemon API token.")
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/jobs", jobs)
+	mux.Handle("/v1/jobs/", jobs)
+	server := &http.Server{Handler: daemonAuthHandler(token, mux)}
+
+	printMsg(daemonStartMessage{Address: listener.Addr().String()})
+
+	go func() {
+		<-globalContext.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	if e = server.Serve(listener); e != nil && e != http.ErrServerClosed {
+		fatalIf(probe.NewError(e), "Unable to serve the daemon API.")
+	}
+	// Running jobs are killed with the global context.
+	jobs.wait()
+	return nil
+}
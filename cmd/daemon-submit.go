@@ -0,0 +1,117 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/minio/cli"
+)
+
+var daemonSubmitFlags = []cli.Flag{
+	daemonAddressFlag,
+	cli.StringFlag{
+		Name:  "every",
+		Usage: "run the command again after it finished, e.g. 30m, 1h, 1d",
+	},
+	cli.StringFlag{
+		Name:  "schedule",
+		Usage: "run the command at the times of a crontab schedule, e.g. '0 2 * * *' or '@daily'",
+	},
+	cli.StringFlag{
+		Name:  "active-hours",
+		Usage: "only run the command during a daily window, e.g. '22:00-06:00', mirror pauses outside of it",
+	},
+	cli.StringFlag{
+		Name:  "notify-webhook",
+		Usage: "post failed runs as JSON to a webhook URL",
+	},
+	cli.StringFlag{
+		Name:  "notify-email",
+		Usage: "mail failed runs to an address, the daemon must be started with --smtp-server",
+	},
+}
+
+var daemonSubmitCmd = cli.Command{
+	Name:            "submit",
+	Usage:           "submit a command to run as a daemon job",
+	Action:          mainDaemonSubmit,
+	SkipArgReorder:  true,
+	Before:          setGlobalsFromContext,
+	Flags:           append(daemonSubmitFlags, globalFlags...),
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] COMMAND [ARGS...]
+
+  Flags of submit must come before COMMAND, everything after it is passed
+  to the job.
+
+  A --schedule has five fields, minute, hour, day of month, month and day
+  of week, in the time zone of the daemon. Fields accept '*', values, ranges
+  and steps such as '1-5' or '*/15'. The command does not run before the
+  first time of the schedule, a run still in progress delays the next one.
+
+  Runs are delayed until the window of --active-hours opens, a mirror job
+  also pauses when the window closes and resumes when it opens again.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_DAEMON_ADDRESS  address of the daemon API
+
+EXAMPLES:
+  1. Continuously mirror a local folder to the 'play' server.
+     {{.Prompt}} {{.HelpName}} mirror --watch /var/lib/uploads play/uploads
+
+  2. Synchronize a bucket to a backup site every night.
+     {{.Prompt}} {{.HelpName}} --every 1d mirror --overwrite --remove site1/data site2/data
+
+  3. Mirror a bucket to a backup site at 2am every day, and post failed runs to a webhook.
+     {{.Prompt}} {{.HelpName}} --schedule "0 2 * * *" --notify-webhook https://hooks.example.com/mc -- mirror site1/data site2/data
+
+  4. Continuously mirror a bucket to a backup site at night only.
+     {{.Prompt}} {{.HelpName}} --active-hours 22:00-06:00 mirror --watch site1/data site2/data
+`,
+}
+
+// mainDaemonSubmit is the handle for "mc daemon submit" command.
+func mainDaemonSubmit(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, "submit", globalInvalidArgsExitStatus)
+	}
+	setDaemonColors()
+
+	req := daemonJobRequest{
+		Args:          ctx.Args(),
+		Every:         ctx.String("every"),
+		Schedule:      ctx.String("schedule"),
+		ActiveHours:   ctx.String("active-hours"),
+		NotifyWebhook: ctx.String("notify-webhook"),
+		NotifyEmail:   ctx.String("notify-email"),
+	}
+	var job daemonJob
+	err := daemonRequest(ctx.String("address"), http.MethodPost, "/v1/jobs", req, &job)
+	fatalIf(err.Trace(req.Args...), "Unable to submit the job.")
+
+	printMsg(daemonJobMessage{daemonJob: job, op: "submit"})
+	return nil
+}
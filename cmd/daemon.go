@@ -0,0 +1,636 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/ioutils"
+)
+
+// States of a daemon job.
+const (
+	daemonJobRunning   = "running"
+	daemonJobScheduled = "scheduled"
+	daemonJobCompleted = "completed"
+	daemonJobFailed    = "failed"
+	daemonJobCancelled = "cancelled"
+)
+
+// daemonJobRequest is the body of a job submission.
+type daemonJobRequest struct {
+	// Arguments of the mc command, e.g. ["mirror", "--watch", "src", "dst"].
+	Args []string `json:"args"`
+	// Optional interval to run the command again after it finished.
+	Every string `json:"every,omitempty"`
+	// Optional crontab schedule to run the command at, e.g. "0 2 * * *".
+	Schedule string `json:"schedule,omitempty"`
+	// Optional daily window to run the command in, e.g. "22:00-06:00".
+	ActiveHours string `json:"activeHours,omitempty"`
+	// Optional webhook and email address notified of failed runs.
+	NotifyWebhook string `json:"notifyWebhook,omitempty"`
+	NotifyEmail   string `json:"notifyEmail,omitempty"`
+}
+
+// Number of runs kept in the history of a job.
+const daemonJobHistorySize = 20
+
+// Number of finished jobs kept, older ones are forgotten.
+const daemonFinishedJobsMax = 100
+
+// daemonJob is a mc command run by the daemon.
+type daemonJob struct {
+	ID       string   `json:"id"`
+	Args     []string `json:"args"`
+	Every    string   `json:"every,omitempty"`
+	Schedule string   `json:"schedule,omitempty"`
+	State    string   `json:"state"`
+	// True while the running command is paused.
+	Paused    bool       `json:"paused,omitempty"`
+	Submitted time.Time  `json:"submitted"`
+	NextRun   *time.Time `json:"nextRun,omitempty"`
+	Runs      int        `json:"runs"`
+
+	ActiveHours string `json:"activeHours,omitempty"`
+
+	NotifyWebhook string `json:"notifyWebhook,omitempty"`
+	NotifyEmail   string `json:"notifyEmail,omitempty"`
+	// Error of the last failure notification.
+	NotifyError string `json:"notifyError,omitempty"`
+
+	// Progress of the current or last run.
+	Objects   int64  `json:"objects"`
+	Bytes     int64  `json:"bytes"`
+	Errors    int64  `json:"errors"`
+	LastError string `json:"lastError,omitempty"`
+	ExitCode  int    `json:"exitCode"`
+
+	// Last runs, oldest first.
+	History []daemonJobRun `json:"history,omitempty"`
+}
+
+// daemonJobRun is the outcome of a finished run of a job.
+type daemonJobRun struct {
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+	State     string    `json:"state"`
+	Objects   int64     `json:"objects"`
+	Bytes     int64     `json:"bytes"`
+	Errors    int64     `json:"errors"`
+	LastError string    `json:"lastError,omitempty"`
+	ExitCode  int       `json:"exitCode"`
+}
+
+// isDone returns true once the job will not run anymore.
+func (j daemonJob) isDone() bool {
+	switch j.State {
+	case daemonJobCompleted, daemonJobFailed, daemonJobCancelled:
+		return true
+	}
+	return false
+}
+
+// daemonErrorResponse is the body of a failed API request.
+type daemonErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// daemonJobs runs the jobs of the daemon and serves the REST API to
+// manage them, with JSON bodies:
+//
+//	GET    /v1/jobs       list all jobs
+//	POST   /v1/jobs       submit a job
+//	GET    /v1/jobs/{id}         get a job and its progress
+//	DELETE /v1/jobs/{id}         cancel a job
+//	POST   /v1/jobs/{id}/pause   pause a running mirror job
+//	POST   /v1/jobs/{id}/resume  resume a paused mirror job
+type daemonJobs struct {
+	ctx        context.Context
+	executable string
+	globalArgs []string
+	// Mail server of the email notifications, if any.
+	smtp *daemonSMTPConfig
+
+	mu      sync.Mutex
+	lastID  int
+	jobs    []*daemonJob
+	cancels map[string]context.CancelFunc
+	// Processes of the running jobs.
+	procs map[string]*os.Process
+	wg    sync.WaitGroup
+}
+
+func newDaemonJobs(ctx context.Context, executable string, globalArgs []string) *daemonJobs {
+	return &daemonJobs{
+		ctx:        ctx,
+		executable: executable,
+		globalArgs: globalArgs,
+		cancels:    make(map[string]context.CancelFunc),
+		procs:      make(map[string]*os.Process),
+	}
+}
+
+// list returns a copy of all jobs, oldest first.
+func (d *daemonJobs) list() []daemonJob {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	jobs := make([]daemonJob, 0, len(d.jobs))
+	for _, job := range d.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+// get returns a copy of the job with the given ID.
+func (d *daemonJobs) get(id string) (daemonJob, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, job := range d.jobs {
+		if job.ID == id {
+			return *job, true
+		}
+	}
+	return daemonJob{}, false
+}
+
+// update modifies jobs while holding the lock.
+func (d *daemonJobs) update(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fn()
+}
+
+// submit validates and starts a new job.
+func (d *daemonJobs) submit(req daemonJobRequest) (daemonJob, error) {
+	if len(req.Args) == 0 {
+		return daemonJob{}, errors.New("no command given")
+	}
+	if req.Args[0] == "daemon" {
+		return daemonJob{}, errors.New("the daemon cannot run itself")
+	}
+	if req.Every != "" && req.Schedule != "" {
+		return daemonJob{}, errors.New("an interval and a schedule cannot both be given")
+	}
+	var every time.Duration
+	if req.Every != "" {
+		var e error
+		if every, e = ioutils.ParseDurationTime(req.Every); e != nil {
+			return daemonJob{}, fmt.Errorf("invalid interval `%s`: %v", req.Every, e)
+		}
+		if every <= 0 {
+			return daemonJob{}, fmt.Errorf("invalid interval `%s`", req.Every)
+		}
+	}
+	var cron *cronSchedule
+	if req.Schedule != "" {
+		var e error
+		if cron, e = parseCronSchedule(req.Schedule); e != nil {
+			return daemonJob{}, e
+		}
+		if cron.next(time.Now()).IsZero() {
+			return daemonJob{}, fmt.Errorf("schedule `%s` never runs", req.Schedule)
+		}
+	}
+	var hours *activeHours
+	if req.ActiveHours != "" {
+		var e error
+		if hours, e = parseActiveHours(req.ActiveHours); e != nil {
+			return daemonJob{}, e
+		}
+	}
+	if req.NotifyWebhook != "" {
+		if e := checkNotifyWebhook(req.NotifyWebhook); e != nil {
+			return daemonJob{}, e
+		}
+	}
+	if req.NotifyEmail != "" {
+		if e := checkNotifyEmail(d.smtp, req.NotifyEmail); e != nil {
+			return daemonJob{}, e
+		}
+	}
+
+	ctx, cancel := context.WithCancel(d.ctx)
+
+	d.mu.Lock()
+	d.lastID++
+	job := &daemonJob{
+		ID:        strconv.Itoa(d.lastID),
+		Args:      req.Args,
+		Every:     req.Every,
+		Schedule:  req.Schedule,
+		State:     daemonJobScheduled,
+		Submitted: UTCNow(),
+
+		ActiveHours: req.ActiveHours,
+
+		NotifyWebhook: req.NotifyWebhook,
+		NotifyEmail:   req.NotifyEmail,
+	}
+	d.prune(daemonFinishedJobsMax)
+	d.jobs = append(d.jobs, job)
+	d.cancels[job.ID] = cancel
+	snapshot := *job
+	d.mu.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer cancel()
+		d.schedule(ctx, job, every, cron, hours)
+	}()
+	return snapshot, nil
+}
+
+// prune forgets the oldest finished jobs beyond max, the lock must be
+// held.
+func (d *daemonJobs) prune(max int) {
+	finished := 0
+	for _, job := range d.jobs {
+		if job.isDone() {
+			finished++
+		}
+	}
+	jobs := make([]*daemonJob, 0, len(d.jobs))
+	for _, job := range d.jobs {
+		if job.isDone() && finished > max {
+			finished--
+			delete(d.cancels, job.ID)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	d.jobs = jobs
+}
+
+// cancel stops a job, a running command is killed.
+func (d *daemonJobs) cancel(id string) (daemonJob, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, job := range d.jobs {
+		if job.ID != id {
+			continue
+		}
+		if job.isDone() {
+			return *job, fmt.Errorf("job `%s` is already %s", id, job.State)
+		}
+		d.cancels[id]()
+		return *job, nil
+	}
+	return daemonJob{}, fmt.Errorf("job `%s` not found", id)
+}
+
+// pause pauses or resumes a running mirror job.
+func (d *daemonJobs) pause(id string, paused bool) (daemonJob, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, job := range d.jobs {
+		if job.ID != id {
+			continue
+		}
+		proc, ok := d.procs[id]
+		if !ok {
+			return *job, fmt.Errorf("job `%s` is not running", id)
+		}
+		if job.Args[0] != "mirror" {
+			return *job, fmt.Errorf("job `%s` is not a mirror job", id)
+		}
+		if e := signalPause(proc, paused); e != nil {
+			return *job, e
+		}
+		job.Paused = paused
+		return *job, nil
+	}
+	return daemonJob{}, fmt.Errorf("job `%s` not found", id)
+}
+
+// wait waits for all jobs to stop, after the daemon context is done.
+func (d *daemonJobs) wait() {
+	d.wg.Wait()
+}
+
+// schedule runs the job once, every interval or at the times of the
+// cron schedule until cancelled, runs outside of the active hours are
+// delayed until the window opens.
+func (d *daemonJobs) schedule(ctx context.Context, job *daemonJob, every time.Duration, cron *cronSchedule, hours *activeHours) {
+	for runs := 0; ; runs++ {
+		var next time.Time
+		switch {
+		case cron != nil:
+			next = cron.next(time.Now())
+		case runs == 0:
+			next = time.Now()
+		case every > 0:
+			next = time.Now().Add(every)
+		}
+		if next.IsZero() {
+			break
+		}
+		if hours != nil {
+			next = hours.next(next)
+		}
+		if next.After(time.Now()) {
+			d.update(func() {
+				job.State = daemonJobScheduled
+				job.NextRun = &next
+			})
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+			case <-timer.C:
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		d.run(ctx, job)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	d.update(func() {
+		job.NextRun = nil
+		if ctx.Err() != nil {
+			job.State = daemonJobCancelled
+		}
+	})
+}
+
+// run runs the command of a job once, progress is read from
+// its JSON output.
+func (d *daemonJobs) run(ctx context.Context, job *daemonJob) {
+	started := UTCNow()
+	d.update(func() {
+		job.State = daemonJobRunning
+		job.NextRun = nil
+		job.Runs++
+		job.Objects, job.Bytes, job.Errors = 0, 0, 0
+		job.LastError = ""
+		job.ExitCode = 0
+	})
+
+	args := job.Args
+	if job.ActiveHours != "" && args[0] == "mirror" {
+		// Mirror pauses itself outside of the active hours.
+		args = append([]string{args[0], "--active-hours", job.ActiveHours}, args[1:]...)
+	}
+	cmd := exec.CommandContext(ctx, d.executable, append(d.globalArgs, args...)...)
+	cmd.Env = childEnv()
+	stdout, e := cmd.StdoutPipe()
+	if e == nil {
+		var stderr io.ReadCloser
+		if stderr, e = cmd.StderrPipe(); e == nil {
+			if e = cmd.Start(); e == nil {
+				d.update(func() { d.procs[job.ID] = cmd.Process })
+				var wg sync.WaitGroup
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					d.readErrors(job, stderr)
+				}()
+				d.readProgress(job, stdout)
+				wg.Wait()
+				e = cmd.Wait()
+			}
+		}
+	}
+
+	d.update(func() {
+		delete(d.procs, job.ID)
+		job.Paused = false
+		var exitErr *exec.ExitError
+		switch {
+		case ctx.Err() != nil:
+			job.State = daemonJobCancelled
+		case e == nil:
+			job.State = daemonJobCompleted
+		case errors.As(e, &exitErr):
+			job.State = daemonJobFailed
+			job.ExitCode = exitErr.ExitCode()
+		default:
+			job.State = daemonJobFailed
+			job.ExitCode = globalErrorExitStatus
+			job.LastError = e.Error()
+		}
+		job.History = append(job.History, daemonJobRun{
+			Started:   started,
+			Finished:  UTCNow(),
+			State:     job.State,
+			Objects:   job.Objects,
+			Bytes:     job.Bytes,
+			Errors:    job.Errors,
+			LastError: job.LastError,
+			ExitCode:  job.ExitCode,
+		})
+		if len(job.History) > daemonJobHistorySize {
+			job.History = job.History[len(job.History)-daemonJobHistorySize:]
+		}
+	})
+	d.notify(ctx, job)
+}
+
+// notify sends the notifications of a job if its last run failed.
+func (d *daemonJobs) notify(ctx context.Context, job *daemonJob) {
+	var failure daemonJobFailure
+	var webhook, email string
+	failed := false
+	d.update(func() {
+		run := job.History[len(job.History)-1]
+		if run.State != daemonJobFailed {
+			return
+		}
+		failed = true
+		webhook, email = job.NotifyWebhook, job.NotifyEmail
+		failure = daemonJobFailure{
+			ID:        job.ID,
+			Args:      job.Args,
+			Started:   run.Started,
+			Finished:  run.Finished,
+			ExitCode:  run.ExitCode,
+			Errors:    run.Errors,
+			LastError: run.LastError,
+		}
+	})
+	if !failed {
+		return
+	}
+
+	var errs []string
+	if webhook != "" {
+		if e := notifyWebhook(ctx, webhook, failure); e != nil {
+			errs = append(errs, "webhook: "+e.Error())
+		}
+	}
+	if email != "" {
+		if e := notifyEmail(d.smtp, email, failure); e != nil {
+			errs = append(errs, "email: "+e.Error())
+		}
+	}
+	d.update(func() { job.NotifyError = strings.Join(errs, ", ") })
+}
+
+// readProgress accounts the JSON messages printed by a job.
+func (d *daemonJobs) readProgress(job *daemonJob, r io.Reader) {
+	decoder := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Status string `json:"status"`
+			Size   *int64 `json:"size"`
+			Error  *struct {
+				Message string `json:"message"`
+				Cause   struct {
+					Message string `json:"message"`
+				} `json:"cause"`
+			} `json:"error"`
+		}
+		if e := decoder.Decode(&msg); e != nil {
+			// Not JSON, the rest of the output is ignored.
+			io.Copy(ioutil.Discard, r)
+			return
+		}
+		d.update(func() {
+			switch {
+			case msg.Error != nil:
+				job.Errors++
+				job.LastError = strings.TrimSpace(msg.Error.Message + " " + msg.Error.Cause.Message)
+			case msg.Status == "success" && msg.Size != nil:
+				job.Objects++
+				job.Bytes += *msg.Size
+			}
+		})
+	}
+}
+
+// readErrors keeps the last line a job printed on stderr.
+func (d *daemonJobs) readErrors(job *daemonJob, r io.Reader) {
+	data, _ := ioutil.ReadAll(r)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		d.update(func() { job.LastError = last })
+	}
+}
+
+func (d *daemonJobs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/jobs"), "/")
+	var action string
+	if i := strings.Index(id, "/"); i >= 0 {
+		id, action = id[:i], id[i+1:]
+	}
+	switch {
+	case action != "" && r.Method == http.MethodPost && (action == "pause" || action == "resume"):
+		job, e := d.pause(id, action == "pause")
+		switch {
+		case e != nil && job.ID == "":
+			writeDaemonError(w, http.StatusNotFound, e)
+		case e != nil:
+			writeDaemonError(w, http.StatusConflict, e)
+		default:
+			writeDaemonResponse(w, http.StatusOK, job)
+		}
+	case action != "":
+		writeDaemonError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s %s is not supported", r.Method, r.URL.Path))
+	case id == "" && r.Method == http.MethodGet:
+		writeDaemonResponse(w, http.StatusOK, d.list())
+	case id == "" && r.Method == http.MethodPost:
+		var req daemonJobRequest
+		if e := json.NewDecoder(r.Body).Decode(&req); e != nil {
+			writeDaemonError(w, http.StatusBadRequest, e)
+			return
+		}
+		job, e := d.submit(req)
+		if e != nil {
+			writeDaemonError(w, http.StatusBadRequest, e)
+			return
+		}
+		writeDaemonResponse(w, http.StatusCreated, job)
+	case id != "" && r.Method == http.MethodGet:
+		job, ok := d.get(id)
+		if !ok {
+			writeDaemonError(w, http.StatusNotFound, fmt.Errorf("job `%s` not found", id))
+			return
+		}
+		writeDaemonResponse(w, http.StatusOK, job)
+	case id != "" && r.Method == http.MethodDelete:
+		job, e := d.cancel(id)
+		switch {
+		case e != nil && job.ID == "":
+			writeDaemonError(w, http.StatusNotFound, e)
+		case e != nil:
+			writeDaemonError(w, http.StatusConflict, e)
+		default:
+			writeDaemonResponse(w, http.StatusOK, job)
+		}
+	default:
+		writeDaemonError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s %s is not supported", r.Method, r.URL.Path))
+	}
+}
+
+// daemonAuthHandler only lets requests with the bearer token reach
+// the API. Requests with a Host other than a loopback address, e.g. from
+// a rebound DNS name, and requests changing jobs without a JSON content
+// type, which browsers do not send cross origin without a preflight, are
+// rejected as well.
+func daemonAuthHandler(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, e := net.SplitHostPort(host); e == nil {
+			host = h
+		}
+		if !isLoopbackHostname(strings.Trim(host, "[]")) {
+			writeDaemonError(w, http.StatusForbidden, fmt.Errorf("host `%s` is not allowed", r.Host))
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeDaemonError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if mediaType != "application/json" {
+				writeDaemonError(w, http.StatusUnsupportedMediaType, errors.New("content type must be application/json"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeDaemonResponse(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeDaemonError(w http.ResponseWriter, status int, e error) {
+	writeDaemonResponse(w, status, daemonErrorResponse{Error: e.Error()})
+}
@@ -0,0 +1,181 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDaemonJobsAPI(t *testing.T) {
+	jobs := newDaemonJobs(context.Background(), "mc", nil)
+	testCases := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "/v1/jobs", "", http.StatusOK},
+		{http.MethodPost, "/v1/jobs", `{"args": []}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `{"args": ["daemon", "start"]}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `{"args": ["ls"], "every": "often"}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `{"args": ["ls"], "schedule": "0 2 * *"}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `{"args": ["ls"], "schedule": "0 2 * * *", "every": "1d"}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `{"args": ["ls"], "notifyWebhook": "hooks.example.com"}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `{"args": ["ls"], "notifyEmail": "ops@example.com"}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `{"args": ["ls"], "activeHours": "22:00"}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/jobs", `not json`, http.StatusBadRequest},
+		{http.MethodGet, "/v1/jobs/1", "", http.StatusNotFound},
+		{http.MethodDelete, "/v1/jobs/1", "", http.StatusNotFound},
+		{http.MethodPost, "/v1/jobs/1/pause", "", http.StatusNotFound},
+		{http.MethodPost, "/v1/jobs/1/resume", "", http.StatusNotFound},
+		{http.MethodGet, "/v1/jobs/1/pause", "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/v1/jobs/1/stop", "", http.StatusMethodNotAllowed},
+		{http.MethodPut, "/v1/jobs", "", http.StatusMethodNotAllowed},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.body))
+		rec := httptest.NewRecorder()
+		jobs.ServeHTTP(rec, req)
+		if rec.Code != testCase.status {
+			t.Errorf("Test %d: %s %s expected status %d, got %d", i+1, testCase.method, testCase.path, testCase.status, rec.Code)
+		}
+	}
+}
+
+func TestDaemonJobNotify(t *testing.T) {
+	failureCh := make(chan daemonJobFailure, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var failure daemonJobFailure
+		if e := json.NewDecoder(r.Body).Decode(&failure); e != nil {
+			t.Error(e)
+		}
+		failureCh <- failure
+	}))
+	defer webhook.Close()
+
+	// The job always fails.
+	executable, e := exec.LookPath("false")
+	if e != nil {
+		t.Skip("false is not available")
+	}
+	jobs := newDaemonJobs(context.Background(), executable, nil)
+	job, e := jobs.submit(daemonJobRequest{Args: []string{"ls"}, NotifyWebhook: webhook.URL})
+	if e != nil {
+		t.Fatal(e)
+	}
+	jobs.wait()
+
+	select {
+	case failure := <-failureCh:
+		if failure.ID != job.ID || failure.ExitCode != 1 {
+			t.Errorf("unexpected failure %+v", failure)
+		}
+	default:
+		t.Fatal("expected the failure to be notified")
+	}
+	job, _ = jobs.get(job.ID)
+	if len(job.History) != 1 || job.History[0].State != daemonJobFailed || job.NotifyError != "" {
+		t.Errorf("unexpected history %+v, notification error %q", job.History, job.NotifyError)
+	}
+}
+
+func TestDaemonJobsPrune(t *testing.T) {
+	jobs := newDaemonJobs(context.Background(), "mc", nil)
+	states := []string{daemonJobCompleted, daemonJobRunning, daemonJobFailed, daemonJobScheduled, daemonJobCancelled, daemonJobCompleted}
+	for i, state := range states {
+		id := strconv.Itoa(i + 1)
+		jobs.jobs = append(jobs.jobs, &daemonJob{ID: id, State: state})
+		jobs.cancels[id] = func() {}
+	}
+	jobs.prune(2)
+
+	var ids []string
+	for _, job := range jobs.list() {
+		ids = append(ids, job.ID)
+	}
+	// The oldest finished jobs go, running and scheduled jobs stay.
+	if expected := []string{"2", "4", "5", "6"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected jobs %v, got %v", expected, ids)
+	}
+	if _, ok := jobs.cancels["1"]; ok || len(jobs.cancels) != 4 {
+		t.Errorf("expected the cancel functions of the pruned jobs to go, got %d", len(jobs.cancels))
+	}
+}
+
+func TestDaemonAuthHandler(t *testing.T) {
+	handler := daemonAuthHandler("secret", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	testCases := []struct {
+		method        string
+		host          string
+		authorization string
+		contentType   string
+		status        int
+	}{
+		{http.MethodGet, "localhost:9191", "Bearer secret", "", http.StatusOK},
+		{http.MethodGet, "127.0.0.1:9191", "Bearer secret", "", http.StatusOK},
+		{http.MethodGet, "[::1]:9191", "Bearer secret", "", http.StatusOK},
+		{http.MethodPost, "localhost:9191", "Bearer secret", "application/json; charset=utf-8", http.StatusOK},
+		{http.MethodGet, "localhost:9191", "", "", http.StatusUnauthorized},
+		{http.MethodGet, "localhost:9191", "Bearer other", "", http.StatusUnauthorized},
+		{http.MethodGet, "attacker.example.com:9191", "Bearer secret", "", http.StatusForbidden},
+		{http.MethodPost, "localhost:9191", "Bearer secret", "text/plain", http.StatusUnsupportedMediaType},
+		{http.MethodDelete, "localhost:9191", "Bearer secret", "", http.StatusUnsupportedMediaType},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, "/v1/jobs", nil)
+		req.Host = testCase.host
+		if testCase.authorization != "" {
+			req.Header.Set("Authorization", testCase.authorization)
+		}
+		if testCase.contentType != "" {
+			req.Header.Set("Content-Type", testCase.contentType)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != testCase.status {
+			t.Errorf("Test %d: %s on %s expected status %d, got %d", i+1, testCase.method, testCase.host, testCase.status, rec.Code)
+		}
+	}
+}
+
+func TestIsLoopbackAddress(t *testing.T) {
+	testCases := []struct {
+		address  string
+		expected bool
+	}{
+		{"localhost:9191", true},
+		{"127.0.0.1:9191", true},
+		{"[::1]:9191", true},
+		{"0.0.0.0:9191", false},
+		{":9191", false},
+		{"example.com:9191", false},
+		{"localhost", false},
+	}
+	for i, testCase := range testCases {
+		if got := isLoopbackAddress(testCase.address); got != testCase.expected {
+			t.Errorf("Test %d: %s expected %v, got %v", i+1, testCase.address, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+const (
+	// dedupeIndexPrefix is the prefix, at the root of the target bucket,
+	// of the index objects mapping a content hash to an object key.
+	dedupeIndexPrefix = ".mc-dedupe/"
+
+	// dedupeHashKey is the user metadata holding the SHA-256 of the
+	// content of an object uploaded with --dedupe.
+	dedupeHashKey = "X-Amz-Meta-Mc-Content-Sha256"
+
+	// Object keys are at most 1024 bytes long.
+	maxDedupeIndexSize = 1024
+)
+
+// dedupeContentHash returns the hex encoded SHA-256 of a local file.
+func dedupeContentHash(path string) (string, *probe.Error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, e = io.Copy(h, f); e != nil {
+		return "", probe.NewError(e)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// dedupeBucketAndObject splits the path of an object URL into its
+// bucket and object key.
+func dedupeBucketAndObject(u ClientURL) (bucket, object string) {
+	tokens := splitStr(u.Path, string(u.Separator), 3)
+	return tokens[1], tokens[2]
+}
+
+// dedupeIndexURL returns the URL of the index object of a content hash
+// in the bucket of the given object URL.
+func dedupeIndexURL(u ClientURL, hash string) ClientURL {
+	bucket, _ := dedupeBucketAndObject(u)
+	indexURL := u.Clone()
+	sep := string(u.Separator)
+	indexURL.Path = sep + bucket + sep + dedupeIndexPrefix + hash
+	return indexURL
+}
+
+// dedupeHashMatches tells if an object was uploaded with --dedupe with the
+// given content hash and size.
+func dedupeHashMatches(content *ClientContent, hash string, size int64) bool {
+	if content.Size != size {
+		return false
+	}
+	if v, ok := content.Metadata[dedupeHashKey]; ok {
+		return v == hash
+	}
+	for k, v := range content.UserMetadata {
+		if http.CanonicalHeaderKey("X-Amz-Meta-"+k) == dedupeHashKey {
+			return v == hash
+		}
+	}
+	return false
+}
+
+// dedupeLookup returns the path of an object holding the content with the
+// given hash in the bucket of the target URL, or an empty string if none is
+// known. The index is only a hint, the content hash of the object it
+// points to is always verified.
+func dedupeLookup(ctx context.Context, alias string, targetURL ClientURL, hash string, size int64, sse encrypt.ServerSide) string {
+	indexURL := dedupeIndexURL(targetURL, hash)
+	reader, _, err := getSourceStream(ctx, alias, indexURL.String(), "", false, sse, false)
+	if err != nil {
+		return ""
+	}
+	defer reader.Close()
+
+	data, e := ioutil.ReadAll(io.LimitReader(reader, maxDedupeIndexSize))
+	if e != nil {
+		return ""
+	}
+	object := strings.TrimSpace(string(data))
+	if object == "" {
+		return ""
+	}
+
+	bucket, _ := dedupeBucketAndObject(targetURL)
+	sep := string(targetURL.Separator)
+	candidateURL := targetURL.Clone()
+	candidateURL.Path = sep + bucket + sep + object
+
+	clnt, err := newClientFromAlias(alias, candidateURL.String())
+	if err != nil {
+		return ""
+	}
+	content, err := clnt.Stat(ctx, StatOptions{sse: sse})
+	if err != nil || !dedupeHashMatches(content, hash, size) {
+		return ""
+	}
+	return candidateURL.Path
+}
+
+// dedupeIndexUpdate records the target URL as holding the content with
+// the given hash.
+func dedupeIndexUpdate(ctx context.Context, alias string, targetURL ClientURL, hash string, sse encrypt.ServerSide) *probe.Error {
+	_, object := dedupeBucketAndObject(targetURL)
+	indexURL := dedupeIndexURL(targetURL, hash)
+	opts := PutOptions{
+		metadata: map[string]string{"Content-Type": "text/plain"},
+		sse:      sse,
+	}
+	_, err := putTargetStream(ctx, alias, indexURL.String(), "", "", "",
+		strings.NewReader(object), int64(len(object)), nil, opts)
+	return err
+}
@@ -0,0 +1,89 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDedupeContentHash(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-dedupe-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hello.txt")
+	if e = ioutil.WriteFile(path, []byte("hello"), 0644); e != nil {
+		t.Fatal(e)
+	}
+	hash, err := dedupeContentHash(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Fatalf("unexpected hash %s", hash)
+	}
+	if _, err = dedupeContentHash(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
+
+func TestDedupeIndexURL(t *testing.T) {
+	testCases := []struct {
+		url    string
+		bucket string
+		object string
+		index  string
+	}{
+		{"http://localhost:9000/bucket/object", "bucket", "object", "http://localhost:9000/bucket/.mc-dedupe/abc"},
+		{"http://localhost:9000/bucket/dir/sub/object", "bucket", "dir/sub/object", "http://localhost:9000/bucket/.mc-dedupe/abc"},
+	}
+	for i, tc := range testCases {
+		u := newClientURL(tc.url)
+		bucket, object := dedupeBucketAndObject(*u)
+		if bucket != tc.bucket || object != tc.object {
+			t.Fatalf("Test %d: expected %s/%s, got %s/%s", i+1, tc.bucket, tc.object, bucket, object)
+		}
+		indexURL := dedupeIndexURL(*u, "abc")
+		if indexURL.String() != tc.index {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, tc.index, indexURL.String())
+		}
+	}
+}
+
+func TestDedupeHashMatches(t *testing.T) {
+	testCases := []struct {
+		content *ClientContent
+		match   bool
+	}{
+		{&ClientContent{Size: 5, Metadata: map[string]string{dedupeHashKey: "abc"}}, true},
+		{&ClientContent{Size: 5, UserMetadata: map[string]string{"Mc-Content-Sha256": "abc"}}, true},
+		{&ClientContent{Size: 5, UserMetadata: map[string]string{"mc-content-sha256": "abc"}}, true},
+		{&ClientContent{Size: 5, Metadata: map[string]string{dedupeHashKey: "def"}}, false},
+		{&ClientContent{Size: 6, Metadata: map[string]string{dedupeHashKey: "abc"}}, false},
+		{&ClientContent{Size: 5}, false},
+	}
+	for i, tc := range testCases {
+		if match := dedupeHashMatches(tc.content, "abc", 5); match != tc.match {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, tc.match, match)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// Changes of an object since the base snapshot.
+const (
+	diffBaseUnchanged = "unchanged"
+	diffBaseCreated   = "created"
+	diffBaseModified  = "modified"
+	diffBaseDeleted   = "deleted"
+)
+
+// Sides on which an object changed since the base snapshot.
+const (
+	diffBaseOnFirst  = "first"
+	diffBaseOnSecond = "second"
+	diffBaseConflict = "conflict"
+)
+
+// diffBaseObject is an object listed in the base snapshot.
+type diffBaseObject struct {
+	size  int64
+	etag  string
+	mtime time.Time
+}
+
+// diffBase is the common ancestor of a three-way diff, a snapshot of
+// FIRST and SECOND exported by `mc ls --recursive --json`. A nil
+// object is deleted in the snapshot.
+type diffBase map[string]*diffBaseObject
+
+// loadDiffBase reads a listing snapshot, '-' for STDIN.
+func loadDiffBase(filename string) (diffBase, *probe.Error) {
+	if filename == "-" {
+		return parseDiffBase(os.Stdin, filename)
+	}
+	f, e := os.Open(filename)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	defer f.Close()
+	return parseDiffBase(bufio.NewReader(f), filename)
+}
+
+func parseDiffBase(r io.Reader, filename string) (diffBase, *probe.Error) {
+	base := make(diffBase)
+	decoder := json.NewDecoder(r)
+	for {
+		var c contentMessage
+		e := decoder.Decode(&c)
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return nil, probe.NewError(e).Trace(filename)
+		}
+		if c.Status != "success" || c.Filetype != "file" {
+			continue
+		}
+		if max := memoryShare(baseMemoryShare); max > 0 && uint64(len(base)+1)*baseEntryMemory > max {
+			return nil, probe.NewError(fmt.Errorf("snapshot has more than %d objects, too many for --max-memory %s",
+				len(base), humanize.IBytes(globalMaxMemory))).Trace(filename)
+		}
+		key := filepath.ToSlash(c.Key)
+		// Versions of an object are listed latest first.
+		if _, ok := base[key]; ok {
+			continue
+		}
+		if c.IsDeleteMarker {
+			base[key] = nil
+			continue
+		}
+		base[key] = &diffBaseObject{
+			size:  c.Size,
+			etag:  normalizeETag(c.ETag),
+			mtime: c.Time,
+		}
+	}
+	return base, nil
+}
+
+// normalizeETag removes the quotes around an ETag.
+func normalizeETag(etag string) string {
+	return strings.ToLower(strings.Trim(etag, "\""))
+}
+
+// change returns how an object changed since the snapshot, content is
+// nil if the object is not found.
+func (base diffBase) change(key string, content *ClientContent) string {
+	object := base[key]
+	if content == nil || !content.Type.IsRegular() {
+		if object == nil {
+			return diffBaseUnchanged
+		}
+		return diffBaseDeleted
+	}
+	if object == nil {
+		return diffBaseCreated
+	}
+	if content.Size != object.size {
+		return diffBaseModified
+	}
+	// ETags are not available for files, compare their time instead.
+	if etag := normalizeETag(content.ETag); etag != "" && object.etag != "" {
+		if etag != object.etag {
+			return diffBaseModified
+		}
+		return diffBaseUnchanged
+	}
+	if !content.Time.Equal(object.mtime) {
+		return diffBaseModified
+	}
+	return diffBaseUnchanged
+}
+
+// classify returns the side on which an object found different
+// between FIRST and SECOND changed since the snapshot.
+func (base diffBase) classify(d diffMessage, firstURL, secondURL string) diffBaseMessage {
+	key := diffKey(d, firstURL, secondURL)
+	msg := diffBaseMessage{
+		Key:          key,
+		FirstURL:     d.FirstURL,
+		SecondURL:    d.SecondURL,
+		FirstChange:  base.change(key, d.firstContent),
+		SecondChange: base.change(key, d.secondContent),
+	}
+	switch {
+	case msg.SecondChange == diffBaseUnchanged && msg.FirstChange != diffBaseUnchanged:
+		msg.Change = diffBaseOnFirst
+	case msg.FirstChange == diffBaseUnchanged && msg.SecondChange != diffBaseUnchanged:
+		msg.Change = diffBaseOnSecond
+	default:
+		// Changed on both sides, or already different in the snapshot.
+		msg.Change = diffBaseConflict
+	}
+	return msg
+}
+
+// diffBaseMessage container for the differences of a three-way diff.
+type diffBaseMessage struct {
+	Status       string `json:"status"`
+	Key          string `json:"key"`
+	FirstURL     string `json:"first,omitempty"`
+	SecondURL    string `json:"second,omitempty"`
+	Change       string `json:"change"`
+	FirstChange  string `json:"firstChange"`
+	SecondChange string `json:"secondChange"`
+}
+
+// String colorized three-way diff message.
+func (d diffBaseMessage) String() string {
+	switch d.Change {
+	case diffBaseOnFirst:
+		return console.Colorize("DiffBaseFirst", fmt.Sprintf("%-9s %s (%s)", d.Change, d.Key, d.FirstChange))
+	case diffBaseOnSecond:
+		return console.Colorize("DiffBaseSecond", fmt.Sprintf("%-9s %s (%s)", d.Change, d.Key, d.SecondChange))
+	}
+	return console.Colorize("DiffBaseConflict", fmt.Sprintf("%-9s %s (%s on first, %s on second)",
+		d.Change, d.Key, d.FirstChange, d.SecondChange))
+}
+
+// JSON jsonified three-way diff message.
+func (d diffBaseMessage) JSON() string {
+	d.Status = "success"
+	diffJSONBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal diff message `"+d.Key+"`.")
+	return string(diffJSONBytes)
+}
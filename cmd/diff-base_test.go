@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffBaseClassify(t *testing.T) {
+	snapshot := `{"status":"success","type":"file","lastModified":"2021-05-01T10:00:00Z","size":1,"key":"a","etag":"\"0cc175b9c0f1b6a831c399e269772661\""}
+{"status":"success","type":"file","lastModified":"2021-05-01T10:00:00Z","size":2,"key":"b","etag":""}
+{"status":"success","type":"folder","lastModified":"2021-05-01T10:00:00Z","size":0,"key":"dir/","etag":""}
+{"status":"success","type":"file","lastModified":"2021-05-01T11:00:00Z","size":0,"key":"c","etag":"","versionId":"2","isDeleteMarker":true}
+{"status":"success","type":"file","lastModified":"2021-05-01T10:00:00Z","size":3,"key":"c","etag":"","versionId":"1"}
+`
+	base, err := parseDiffBase(strings.NewReader(snapshot), "base.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(base) != 3 || base["c"] != nil {
+		t.Fatalf("unexpected base snapshot %v", base)
+	}
+
+	mtime := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	file := func(size int64, etag string, mtime time.Time) *ClientContent {
+		return &ClientContent{Type: 0644, Size: size, ETag: etag, Time: mtime}
+	}
+	testCases := []struct {
+		key           string
+		first, second *ClientContent
+		change        string
+	}{
+		// Modified on first, compared by ETag.
+		{"a", file(1, "92eb5ffee6ae2fec3ad71c777531578f", mtime), file(1, "0cc175b9c0f1b6a831c399e269772661", mtime.Add(time.Hour)), diffBaseOnFirst},
+		// Modified on second, compared by time without ETag.
+		{"b", file(2, "", mtime), file(2, "", mtime.Add(time.Hour)), diffBaseOnSecond},
+		// Deleted on second.
+		{"b", file(2, "", mtime), nil, diffBaseOnSecond},
+		// Deleted in the snapshot, created on first.
+		{"c", file(3, "", mtime), nil, diffBaseOnFirst},
+		// Created on both sides.
+		{"d", file(1, "", mtime), file(2, "", mtime), diffBaseConflict},
+		// Modified on first, deleted on second.
+		{"a", file(5, "", mtime), nil, diffBaseConflict},
+	}
+	for i, testCase := range testCases {
+		d := diffMessage{firstContent: testCase.first, secondContent: testCase.second}
+		if testCase.first != nil {
+			d.FirstURL = "/first/" + testCase.key
+		} else {
+			d.Diff = differInSecond
+		}
+		if testCase.second != nil {
+			d.SecondURL = "/second/" + testCase.key
+		}
+		msg := base.classify(d, "/first/", "/second/")
+		if msg.Key != testCase.key || msg.Change != testCase.change {
+			t.Errorf("Test %d: expected %s %s, got %s %s (%s, %s)", i+1, testCase.key, testCase.change,
+				msg.Key, msg.Change, msg.FirstChange, msg.SecondChange)
+		}
+	}
+}
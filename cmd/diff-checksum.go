@@ -0,0 +1,295 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"hash"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// Part sizes commonly used by S3 clients for multipart uploads, a
+// multipart ETag is recomputed for each of them matching the number of
+// parts of the object.
+var diffChecksumPartSizes = []int64{
+	5 * humanize.MiByte,
+	8 * humanize.MiByte,
+	10 * humanize.MiByte,
+	15 * humanize.MiByte,
+	16 * humanize.MiByte,
+	32 * humanize.MiByte,
+	64 * humanize.MiByte,
+	100 * humanize.MiByte,
+	128 * humanize.MiByte,
+	256 * humanize.MiByte,
+	512 * humanize.MiByte,
+}
+
+// contentETag returns the ETag of an object if it is the MD5 of its
+// content, or a multipart ETag, which is the MD5 of the MD5 of all
+// parts followed by the number of parts. ETags of encrypted objects
+// and of files are unusable.
+func contentETag(c *ClientContent) (etag string, parts int, ok bool) {
+	for k := range c.Metadata {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-server-side-encryption") {
+			return "", 0, false
+		}
+	}
+	etag = strings.ToLower(strings.Trim(c.ETag, "\""))
+	sum := etag
+	if i := strings.LastIndex(etag, "-"); i >= 0 {
+		n, e := strconv.Atoi(etag[i+1:])
+		if e != nil || n <= 0 {
+			return "", 0, false
+		}
+		sum, parts = etag[:i], n
+	}
+	if len(sum) != 2*md5.Size {
+		return "", 0, false
+	}
+	if _, e := hex.DecodeString(sum); e != nil {
+		return "", 0, false
+	}
+	return etag, parts, true
+}
+
+// multipartPartSizes returns the part sizes splitting size bytes in
+// exactly parts parts.
+func multipartPartSizes(size int64, parts int) (partSizes []int64) {
+	fits := func(partSize int64) bool {
+		return partSize > 0 && (size+partSize-1)/partSize == int64(parts)
+	}
+	seen := make(map[int64]bool)
+	add := func(partSize int64) {
+		if fits(partSize) && !seen[partSize] {
+			seen[partSize] = true
+			partSizes = append(partSizes, partSize)
+		}
+	}
+	if _, partSize, _, e := minio.OptimalPartInfo(size, 0); e == nil {
+		add(partSize)
+	}
+	for _, partSize := range diffChecksumPartSizes {
+		add(partSize)
+	}
+	// The smallest part size in MiB, then the smallest one in bytes.
+	exact := (size + int64(parts) - 1) / int64(parts)
+	add((exact + humanize.MiByte - 1) / humanize.MiByte * humanize.MiByte)
+	add(exact)
+	return partSizes
+}
+
+// multipartHasher computes the multipart ETag of the data written,
+// uploaded in parts of partSize bytes.
+type multipartHasher struct {
+	partSize int64
+	written  int64
+	part     hash.Hash
+	sums     []byte
+	parts    int
+}
+
+func newMultipartHasher(partSize int64) *multipartHasher {
+	return &multipartHasher{partSize: partSize, part: md5.New()}
+}
+
+func (h *multipartHasher) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		chunk := int64(len(p))
+		if left := h.partSize - h.written; chunk > left {
+			chunk = left
+		}
+		h.part.Write(p[:chunk])
+		h.written += chunk
+		p = p[chunk:]
+		if h.written == h.partSize {
+			h.endPart()
+		}
+	}
+	return n, nil
+}
+
+func (h *multipartHasher) endPart() {
+	h.sums = h.part.Sum(h.sums)
+	h.parts++
+	h.part.Reset()
+	h.written = 0
+}
+
+// ETag returns the multipart ETag, once all data was written.
+func (h *multipartHasher) ETag() string {
+	if h.written > 0 {
+		h.endPart()
+	}
+	sum := md5.Sum(h.sums)
+	return hex.EncodeToString(sum[:]) + "-" + strconv.Itoa(h.parts)
+}
+
+// computeETags reads the content of an object and returns its ETag
+// for each possible upload in parts parts, its MD5 when parts is zero.
+func computeETags(r io.Reader, size int64, parts int) ([]string, error) {
+	if parts == 0 {
+		h := md5.New()
+		if _, e := io.Copy(h, r); e != nil {
+			return nil, e
+		}
+		return []string{hex.EncodeToString(h.Sum(nil))}, nil
+	}
+	return computePartETags(r, multipartPartSizes(size, parts))
+}
+
+// etagNames returns the names of the ETags returned by computeETags,
+// in the same order, the keys of the checksum cache.
+func etagNames(size int64, parts int) []string {
+	if parts == 0 {
+		return []string{"md5"}
+	}
+	var names []string
+	for _, partSize := range multipartPartSizes(size, parts) {
+		names = append(names, "etag-"+strconv.FormatInt(partSize, 10))
+	}
+	return names
+}
+
+// computePartETags reads the content of an object and returns its
+// multipart ETag for each part size.
+func computePartETags(r io.Reader, partSizes []int64) ([]string, error) {
+	var (
+		hashers []*multipartHasher
+		writers []io.Writer
+	)
+	for _, partSize := range partSizes {
+		h := newMultipartHasher(partSize)
+		hashers = append(hashers, h)
+		writers = append(writers, h)
+	}
+	if len(hashers) == 0 {
+		return nil, nil
+	}
+	if _, e := io.Copy(io.MultiWriter(writers...), r); e != nil {
+		return nil, e
+	}
+	etags := make([]string, 0, len(hashers))
+	for _, h := range hashers {
+		etags = append(etags, h.ETag())
+	}
+	return etags, nil
+}
+
+// diffChecksums compares the content of objects of the same name and
+// size found by diff, using their ETags whenever possible.
+type diffChecksums struct {
+	firstAlias, secondAlias string
+	firstURL, secondURL     string
+	encKeyDB                map[string][]prefixSSEPair
+	// ETags of the local files, nil when not cached.
+	cache *checksumCache
+}
+
+// etags reads the content of an object of the first or second URL and
+// returns its possible ETags.
+func (d diffChecksums) etags(ctx context.Context, c *ClientContent, isFirst bool, parts int) ([]string, *probe.Error) {
+	alias, urlStr, aliasedURL := d.secondAlias, d.secondURL, d.secondURL
+	if isFirst {
+		alias, urlStr, aliasedURL = d.firstAlias, d.firstURL, d.firstURL
+	}
+	if alias != "" {
+		aliasedURL = alias + "/" + strings.TrimPrefix(c.URL.String(), urlStr)
+	}
+
+	// Local files are not read again while unchanged.
+	var file string
+	names := etagNames(c.Size, parts)
+	if c.URL.Type == fileSystem {
+		file, _ = filepath.Abs(c.URL.Path)
+		if etags, ok := d.cache.get(file, c.Size, c.Time, names); ok {
+			return etags, nil
+		}
+	}
+
+	clnt, err := newClientFromAlias(alias, c.URL.String())
+	if err != nil {
+		return nil, err.Trace(c.URL.String())
+	}
+	reader, err := clnt.Get(ctx, GetOptions{SSE: getSSE(aliasedURL, d.encKeyDB[alias])})
+	if err != nil {
+		return nil, err.Trace(c.URL.String())
+	}
+	defer reader.Close()
+	etags, e := computeETags(reader, c.Size, parts)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(c.URL.String())
+	}
+	if file != "" {
+		d.cache.put(file, c.Size, c.Time, names, etags)
+	}
+	return etags, nil
+}
+
+// equal returns true if both objects have the same content.
+func (d diffChecksums) equal(ctx context.Context, first, second *ClientContent) (bool, *probe.Error) {
+	firstETag, firstParts, firstOK := contentETag(first)
+	secondETag, secondParts, secondOK := contentETag(second)
+	if firstOK && secondOK {
+		if firstETag == secondETag {
+			return true, nil
+		}
+		if firstParts == 0 && secondParts == 0 {
+			return false, nil
+		}
+	}
+
+	// Compute the ETag of one object the way the other one was uploaded.
+	var (
+		etag  string
+		etags []string
+		err   *probe.Error
+	)
+	switch {
+	case firstOK:
+		etag = firstETag
+		etags, err = d.etags(ctx, second, false, firstParts)
+	case secondOK:
+		etag = secondETag
+		etags, err = d.etags(ctx, first, true, secondParts)
+	default:
+		var firstETags []string
+		if firstETags, err = d.etags(ctx, first, true, 0); err == nil {
+			etag = firstETags[0]
+			etags, err = d.etags(ctx, second, false, 0)
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, e := range etags {
+		if e == etag {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"testing"
+)
+
+func TestContentETag(t *testing.T) {
+	testCases := []struct {
+		etag     string
+		metadata map[string]string
+		expected string
+		parts    int
+		ok       bool
+	}{
+		{`"D41D8CD98F00B204E9800998ECF8427E"`, nil, "d41d8cd98f00b204e9800998ecf8427e", 0, true},
+		{"d41d8cd98f00b204e9800998ecf8427e-12", nil, "d41d8cd98f00b204e9800998ecf8427e-12", 12, true},
+		{"d41d8cd98f00b204e9800998ecf8427e-x", nil, "", 0, false},
+		{"not-an-md5", nil, "", 0, false},
+		{"", nil, "", 0, false},
+		{"d41d8cd98f00b204e9800998ecf8427e", map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms"}, "", 0, false},
+	}
+	for i, testCase := range testCases {
+		etag, parts, ok := contentETag(&ClientContent{ETag: testCase.etag, Metadata: testCase.metadata})
+		if etag != testCase.expected || parts != testCase.parts || ok != testCase.ok {
+			t.Errorf("Test %d: expected (%s, %d, %v), got (%s, %d, %v)", i+1,
+				testCase.expected, testCase.parts, testCase.ok, etag, parts, ok)
+		}
+	}
+}
+
+func TestComputeETags(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 2*1024*1024)
+	size := int64(len(data))
+
+	// The multipart ETag of data uploaded in 8 MiB parts.
+	partSize := int64(8 * 1024 * 1024)
+	var sums []byte
+	for i := int64(0); i < size; i += partSize {
+		end := i + partSize
+		if end > size {
+			end = size
+		}
+		sum := md5.Sum(data[i:end])
+		sums = append(sums, sum[:]...)
+	}
+	sum := md5.Sum(sums)
+	expected := hex.EncodeToString(sum[:]) + "-3"
+
+	etags, e := computeETags(bytes.NewReader(data), size, 3)
+	if e != nil {
+		t.Fatal(e)
+	}
+	found := false
+	for _, etag := range etags {
+		found = found || etag == expected
+	}
+	if !found {
+		t.Fatalf("expected %s in %v", expected, etags)
+	}
+
+	sum = md5.Sum(data)
+	etags, e = computeETags(bytes.NewReader(data), size, 0)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(etags) != 1 || etags[0] != hex.EncodeToString(sum[:]) {
+		t.Fatalf("expected %s, got %v", hex.EncodeToString(sum[:]), etags)
+	}
+}
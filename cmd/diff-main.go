@@ -19,7 +19,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -31,7 +33,26 @@ import (
 
 // diff specific flags.
 var (
-	diffFlags = []cli.Flag{}
+	diffFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "compare",
+			Usage: "compare objects by 'size' and time, or also by 'checksum' of their content",
+			Value: "size",
+		},
+		cli.StringFlag{
+			Name:  "output-manifest",
+			Usage: "write the differing objects to a JSON manifest, read by 'cp --files-from' and 'rm --manifest'",
+		},
+		cli.BoolFlag{
+			Name:  "no-checksum-cache",
+			Usage: "read all local files with --compare checksum, instead of using the checksums cached since their last modification",
+		},
+		cli.StringFlag{
+			Name:  "base",
+			Usage: "classify the differences by the side changed since a snapshot exported by 'ls --recursive --json'",
+		},
+		maxMemoryFlag,
+	}
 )
 
 // Compute differences in object name, size, and date between two buckets.
@@ -52,12 +73,22 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 DESCRIPTION:
-  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents.
+  Diff only calculates differences in object name, size and time. It *DOES NOT* compare objects' contents,
+  unless --compare checksum is given. Objects of the same name and size are then compared by ETag, the
+  content of files, of encrypted objects and of objects uploaded in parts of another size is read to
+  compute the ETag to compare. The ETags of local files are cached in the config directory by path, size
+  and modification time, so that unchanged files are not read again by the next runs.
+
+  With --base, the differences are compared to a listing snapshot of the objects taken before FIRST and
+  SECOND diverged, and are classified as changed on first, changed on second or conflict.
 
 LEGEND:
   < - object is only in source.
   > - object is only in destination.
-  ! - newer object is in source.
+  ! - newer object is in source, or differs in size or checksum.
+  first    - object created, modified or deleted on first since the base snapshot.
+  second   - object created, modified or deleted on second since the base snapshot.
+  conflict - object changed on both sides, or already different in the base snapshot.
 
 EXAMPLES:
   1. Compare a local folder with a folder on Amazon S3 cloud storage.
@@ -65,6 +96,18 @@ EXAMPLES:
 
   2. Compare two folders on a local filesystem.
      {{.Prompt}} {{.HelpName}} ~/Photos /Media/Backup/Photos
+
+  3. Verify the content of a bucket migrated from Amazon S3 cloud storage to a MinIO server.
+     {{.Prompt}} {{.HelpName}} --compare checksum s3/mybucket myminio/mybucket
+
+  4. Reconcile two buckets, copy the missing or different objects and remove the extra ones.
+     {{.Prompt}} {{.HelpName}} --output-manifest diff.json s3/mybucket myminio/mybucket
+     {{.Prompt}} mc cp --files-from diff.json s3/mybucket myminio/mybucket
+     {{.Prompt}} mc rm --force --manifest diff.json myminio/mybucket
+
+  5. Find the objects written on each site during a replication outage, from a listing taken before.
+     {{.Prompt}} mc ls --recursive --json site1/mybucket > base.json
+     {{.Prompt}} {{.HelpName}} --base base.json site1/mybucket site2/mybucket
 `,
 }
 
@@ -95,6 +138,8 @@ func (d diffMessage) String() string {
 		msg = console.Colorize("DiffMetadata", "! "+d.SecondURL)
 	case differInAASourceMTime:
 		msg = console.Colorize("DiffMMSourceMTime", "! "+d.SecondURL)
+	case differInChecksum:
+		msg = console.Colorize("DiffChecksum", "! "+d.SecondURL)
 	case differInNone:
 		msg = console.Colorize("DiffInNone", "= "+d.FirstURL)
 	default:
@@ -116,7 +161,15 @@ func (d diffMessage) JSON() string {
 
 func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	if len(cliCtx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(cliCtx, "diff", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(cliCtx, "diff", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	switch compare := cliCtx.String("compare"); compare {
+	case "size", "checksum":
+	default:
+		fatalIf(errInvalidArgument().Trace(compare), "Unable to compare by `%s`, expected size or checksum.", compare)
+	}
+	if cliCtx.String("base") != "" && (cliCtx.String("compare") != "size" || cliCtx.String("output-manifest") != "") {
+		fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "You cannot specify --base with --compare checksum or --output-manifest.")
 	}
 	for _, arg := range cliCtx.Args() {
 		if strings.TrimSpace(arg) == "" {
@@ -155,8 +208,29 @@ func checkDiffSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 	}
 }
 
+// Number of objects compared by checksum concurrently.
+const diffChecksumWorkers = 8
+
+// diffOptions holds the options of a diff.
+type diffOptions struct {
+	// Compare objects of the same name and size by checksum.
+	isChecksum bool
+	// Cache the checksums of local files.
+	checksumCache bool
+	// Write the differences to this manifest file.
+	manifest string
+	// Classify the differences by the side changed since this snapshot.
+	base     diffBase
+	encKeyDB map[string][]prefixSSEPair
+}
+
 // doDiffMain runs the diff.
-func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
+func doDiffMain(ctx context.Context, firstURL, secondURL string, opts diffOptions) error {
+	var manifest *diffManifest
+	if opts.manifest != "" {
+		manifest = newDiffManifest(firstURL, secondURL)
+	}
+
 	// Source and targets are always directories
 	sourceSeparator := string(newClientURL(firstURL).Separator)
 	if !strings.HasSuffix(firstURL, sourceSeparator) {
@@ -183,16 +257,94 @@ func doDiffMain(ctx context.Context, firstURL, secondURL string) error {
 			fmt.Sprintf("Failed to diff '%s' and '%s'", firstURL, secondURL))
 	}
 
+	printDiff := func(diffMsg diffMessage) {
+		printMsg(diffMsg)
+		if manifest != nil {
+			manifest.add(diffMsg, firstURL, secondURL)
+		}
+	}
+
 	// Diff first and second urls.
-	for diffMsg := range objectDifference(ctx, firstClient, secondClient, firstURL, secondURL, true) {
+	if opts.base != nil {
+		for diffMsg := range objectDifference(ctx, firstClient, secondClient, firstURL, secondURL, true) {
+			if diffMsg.Error != nil {
+				errorIf(diffMsg.Error, "Unable to calculate objects difference.")
+				// Ignore error and proceed to next object.
+				continue
+			}
+			printMsg(opts.base.classify(diffMsg, firstURL, secondURL))
+		}
+		return nil
+	}
+	if !opts.isChecksum {
+		for diffMsg := range objectDifference(ctx, firstClient, secondClient, firstURL, secondURL, true) {
+			if diffMsg.Error != nil {
+				errorIf(diffMsg.Error, "Unable to calculate objects difference.")
+				// Ignore error and proceed to next object.
+				continue
+			}
+			printDiff(diffMsg)
+		}
+		return saveDiffManifest(manifest, opts.manifest)
+	}
+
+	checksums := diffChecksums{
+		firstAlias:  firstAlias,
+		secondAlias: secondAlias,
+		firstURL:    firstURL,
+		secondURL:   secondURL,
+		encKeyDB:    opts.encKeyDB,
+	}
+	if opts.checksumCache {
+		checksums.cache = loadChecksumCache(filepath.Join(mustGetMcConfigDir(), checksumCacheFile))
+	}
+	similarCh := make(chan diffMessage)
+	var wg sync.WaitGroup
+	for i := 0; i < diffChecksumWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for diffMsg := range similarCh {
+				equal, err := checksums.equal(ctx, diffMsg.firstContent, diffMsg.secondContent)
+				if err != nil {
+					errorIf(err, "Unable to compare the checksums of `%s` and `%s`.", diffMsg.FirstURL, diffMsg.SecondURL)
+					continue
+				}
+				if !equal {
+					diffMsg.Diff = differInChecksum
+					printDiff(diffMsg)
+				}
+			}
+		}()
+	}
+	for diffMsg := range difference(ctx, firstClient, secondClient, firstURL, secondURL, true, true, true, DirNone) {
 		if diffMsg.Error != nil {
 			errorIf(diffMsg.Error, "Unable to calculate objects difference.")
 			// Ignore error and proceed to next object.
 			continue
 		}
-		printMsg(diffMsg)
+		if diffMsg.Diff == differInNone {
+			similarCh <- diffMsg
+			continue
+		}
+		printDiff(diffMsg)
 	}
+	close(similarCh)
+	wg.Wait()
+	errorIf(checksums.cache.save(), "Unable to save the checksum cache.")
+
+	return saveDiffManifest(manifest, opts.manifest)
+}
 
+// saveDiffManifest writes the manifest if --output-manifest is given.
+func saveDiffManifest(manifest *diffManifest, filename string) error {
+	if manifest == nil {
+		return nil
+	}
+	if err := manifest.save(filename); err != nil {
+		errorIf(err, "Unable to write the manifest `%s`.", filename)
+		return exitStatus(globalErrorExitStatus)
+	}
 	return nil
 }
 
@@ -207,6 +359,7 @@ func mainDiff(cliCtx *cli.Context) error {
 
 	// check 'diff' cli arguments.
 	checkDiffSyntax(ctx, cliCtx, encKeyDB)
+	setMaxMemoryFromContext(cliCtx)
 
 	// Additional command specific theme customization.
 	console.SetColor("DiffMessage", color.New(color.FgGreen, color.Bold))
@@ -216,10 +369,26 @@ func mainDiff(cliCtx *cli.Context) error {
 	console.SetColor("DiffSize", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMetadata", color.New(color.FgYellow, color.Bold))
 	console.SetColor("DiffMMSourceMTime", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffChecksum", color.New(color.FgYellow, color.Bold))
+	console.SetColor("DiffBaseFirst", color.New(color.FgRed))
+	console.SetColor("DiffBaseSecond", color.New(color.FgGreen))
+	console.SetColor("DiffBaseConflict", color.New(color.FgYellow, color.Bold))
 
 	URLs := cliCtx.Args()
 	firstURL := URLs.Get(0)
 	secondURL := URLs.Get(1)
 
-	return doDiffMain(ctx, firstURL, secondURL)
+	var base diffBase
+	if baseFile := cliCtx.String("base"); baseFile != "" {
+		base, err = loadDiffBase(baseFile)
+		fatalIf(err, "Unable to read the base snapshot `%s`.", baseFile)
+	}
+
+	return doDiffMain(ctx, firstURL, secondURL, diffOptions{
+		isChecksum:    cliCtx.String("compare") == "checksum",
+		checksumCache: !cliCtx.Bool("no-checksum-cache"),
+		manifest:      cliCtx.String("output-manifest"),
+		base:          base,
+		encKeyDB:      encKeyDB,
+	})
 }
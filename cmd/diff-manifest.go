@@ -0,0 +1,269 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Version of the manifest written by diff --output-manifest.
+const diffManifestVersion = 1
+
+// diffManifest lists the objects found different by diff, so that
+// `cp --files-from` copies those missing or different in SECOND and
+// `rm --manifest` removes those only in SECOND.
+type diffManifest struct {
+	Version int                 `json:"version"`
+	First   string              `json:"first"`
+	Second  string              `json:"second"`
+	Entries []diffManifestEntry `json:"entries"`
+
+	mu sync.Mutex
+	// Entries beyond the memory budget, spilled to a temporary file
+	// one per line.
+	spill    *os.File
+	spillErr error
+}
+
+// diffManifestEntry is an object found different, its key is relative
+// to FIRST and SECOND.
+type diffManifestEntry struct {
+	Key  string `json:"key"`
+	Diff string `json:"diff"`
+}
+
+func newDiffManifest(first, second string) *diffManifest {
+	return &diffManifest{
+		Version: diffManifestVersion,
+		First:   first,
+		Second:  second,
+		Entries: []diffManifestEntry{},
+	}
+}
+
+// diffKey returns the key of a difference found between the expanded
+// first and second URLs, relative to them.
+func diffKey(d diffMessage, firstURL, secondURL string) string {
+	key := strings.TrimPrefix(d.FirstURL, firstURL)
+	if d.Diff == differInSecond {
+		key = strings.TrimPrefix(d.SecondURL, secondURL)
+	}
+	return strings.TrimPrefix(filepath.ToSlash(key), "/")
+}
+
+// add adds a difference found between the expanded first and second URLs.
+func (m *diffManifest) add(d diffMessage, firstURL, secondURL string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := diffManifestEntry{
+		Key:  diffKey(d, firstURL, secondURL),
+		Diff: d.Diff.String(),
+	}
+	if m.spill == nil && m.spillErr == nil {
+		max := memoryBudget(manifestMemoryShare, manifestEntryMemory, 1, len(m.Entries)+1)
+		if len(m.Entries) < max {
+			m.Entries = append(m.Entries, entry)
+			return
+		}
+		if m.spill, m.spillErr = ioutil.TempFile("", "mc-diff-manifest-"); m.spillErr != nil {
+			return
+		}
+	}
+	if m.spillErr == nil {
+		m.spillErr = json.NewEncoder(m.spill).Encode(entry)
+	}
+}
+
+// save writes the manifest to a file, along with the entries spilled
+// to disk if any.
+func (m *diffManifest) save(filename string) *probe.Error {
+	if m.spill != nil {
+		defer func() {
+			m.spill.Close()
+			os.Remove(m.spill.Name())
+		}()
+	}
+	if m.spillErr != nil {
+		return probe.NewError(m.spillErr)
+	}
+	if m.spill == nil {
+		data, e := json.MarshalIndent(m, "", " ")
+		if e != nil {
+			return probe.NewError(e)
+		}
+		if e = ioutil.WriteFile(filename, append(data, '\n'), 0644); e != nil {
+			return probe.NewError(e).Trace(filename)
+		}
+		return nil
+	}
+
+	f, e := os.Create(filename)
+	if e != nil {
+		return probe.NewError(e).Trace(filename)
+	}
+	defer f.Close()
+	if e = m.writeSpilled(f); e != nil {
+		return probe.NewError(e).Trace(filename)
+	}
+	if e = f.Close(); e != nil {
+		return probe.NewError(e).Trace(filename)
+	}
+	return nil
+}
+
+// writeSpilled writes the manifest with the entries kept in memory
+// followed by those spilled to disk, without loading them.
+func (m *diffManifest) writeSpilled(w io.Writer) error {
+	if _, e := m.spill.Seek(0, io.SeekStart); e != nil {
+		return e
+	}
+	header, e := json.MarshalIndent(struct {
+		Version int    `json:"version"`
+		First   string `json:"first"`
+		Second  string `json:"second"`
+	}{m.Version, m.First, m.Second}, "", " ")
+	if e != nil {
+		return e
+	}
+	bw := bufio.NewWriter(w)
+	bw.Write(bytes.TrimSuffix(header, []byte("\n}")))
+	bw.WriteString(",\n \"entries\": [")
+	n := 0
+	writeEntry := func(entry diffManifestEntry) error {
+		data, e := json.MarshalIndent(entry, "  ", " ")
+		if e != nil {
+			return e
+		}
+		if n > 0 {
+			bw.WriteByte(',')
+		}
+		n++
+		bw.WriteString("\n  ")
+		_, e = bw.Write(data)
+		return e
+	}
+	for _, entry := range m.Entries {
+		if e = writeEntry(entry); e != nil {
+			return e
+		}
+	}
+	decoder := json.NewDecoder(bufio.NewReader(m.spill))
+	for {
+		var entry diffManifestEntry
+		if e = decoder.Decode(&entry); e == io.EOF {
+			break
+		} else if e != nil {
+			return e
+		}
+		if e = writeEntry(entry); e != nil {
+			return e
+		}
+	}
+	bw.WriteString("\n ]\n}\n")
+	return bw.Flush()
+}
+
+// copyKeys returns the keys of the objects missing or different in SECOND.
+func (m *diffManifest) copyKeys() (keys []string) {
+	for _, entry := range m.Entries {
+		switch entry.Diff {
+		case differInFirst.String(), differInSize.String(), differInMetadata.String(),
+			differInAASourceMTime.String(), differInChecksum.String():
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+// removeKeys returns the keys of the objects only in SECOND.
+func (m *diffManifest) removeKeys() (keys []string) {
+	for _, entry := range m.Entries {
+		if entry.Diff == differInSecond.String() {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+// readFileOrStdin reads a file, or the standard input when filename is "-".
+func readFileOrStdin(filename string) ([]byte, *probe.Error) {
+	var data []byte
+	var e error
+	if filename == "-" {
+		data, e = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, e = ioutil.ReadFile(filename)
+	}
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	return data, nil
+}
+
+// loadDiffManifest reads a manifest written by diff --output-manifest.
+func loadDiffManifest(filename string) (*diffManifest, *probe.Error) {
+	data, err := readFileOrStdin(filename)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiffManifest(data, filename)
+}
+
+func parseDiffManifest(data []byte, filename string) (*diffManifest, *probe.Error) {
+	m := &diffManifest{}
+	if e := json.Unmarshal(data, m); e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	if m.Version != diffManifestVersion {
+		return nil, errInvalidArgument().Trace(filename)
+	}
+	return m, nil
+}
+
+// readCopyKeys reads the keys to copy from a manifest written by diff
+// --output-manifest, or from a list of keys, one per line.
+func readCopyKeys(filename string) ([]string, *probe.Error) {
+	data, err := readFileOrStdin(filename)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		m, err := parseDiffManifest(data, filename)
+		if err != nil {
+			return nil, err
+		}
+		return m.copyKeys(), nil
+	}
+	var keys []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if key := strings.TrimSuffix(scanner.Text(), "\r"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
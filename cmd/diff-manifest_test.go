@@ -0,0 +1,77 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffManifestKeys(t *testing.T) {
+	m := newDiffManifest("s3/first", "play/second")
+	m.add(diffMessage{FirstURL: "/first/a", SecondURL: "/second/a", Diff: differInSize}, "/first", "/second")
+	m.add(diffMessage{FirstURL: "/first/dir/b", Diff: differInFirst}, "/first", "/second")
+	m.add(diffMessage{SecondURL: "/second/c", Diff: differInSecond}, "/first", "/second")
+	m.add(diffMessage{FirstURL: "/first/d", SecondURL: "/second/d", Diff: differInType}, "/first", "/second")
+
+	if keys := m.copyKeys(); !reflect.DeepEqual(keys, []string{"a", "dir/b"}) {
+		t.Errorf("expected copy keys [a dir/b], got %v", keys)
+	}
+	if keys := m.removeKeys(); !reflect.DeepEqual(keys, []string{"c"}) {
+		t.Errorf("expected remove keys [c], got %v", keys)
+	}
+
+	dir, e := ioutil.TempDir("", "diff-manifest")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	manifestFile := filepath.Join(dir, "diff.json")
+	if err := m.save(manifestFile); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := readCopyKeys(manifestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, []string{"a", "dir/b"}) {
+		t.Errorf("expected manifest copy keys [a dir/b], got %v", keys)
+	}
+
+	keysFile := filepath.Join(dir, "keys.txt")
+	if e = ioutil.WriteFile(keysFile, []byte("a\r\n\ndir/b\n"), 0644); e != nil {
+		t.Fatal(e)
+	}
+	keys, err = readCopyKeys(keysFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(keys, []string{"a", "dir/b"}) {
+		t.Errorf("expected listed copy keys [a dir/b], got %v", keys)
+	}
+
+	if e = ioutil.WriteFile(manifestFile, []byte(`{"version": 2}`), 0644); e != nil {
+		t.Fatal(e)
+	}
+	if _, err = loadDiffManifest(manifestFile); err == nil {
+		t.Error("expected an error for an unknown manifest version")
+	}
+}
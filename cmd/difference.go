@@ -41,6 +41,7 @@ const (
 	differInFirst                    // only in source (FIRST)
 	differInSecond                   // only in target (SECOND)
 	differInAASourceMTime            // differs in active-active source modtime
+	differInChecksum                 // differs in content, found by diff --compare checksum
 )
 
 func (d differType) String() string {
@@ -53,6 +54,8 @@ func (d differType) String() string {
 		return "metadata"
 	case differInAASourceMTime:
 		return "mm-source-mtime"
+	case differInChecksum:
+		return "checksum"
 	case differInType:
 		return "type"
 	case differInFirst:
@@ -297,10 +300,8 @@ func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, sour
 					firstContent:  srcCtnt,
 					secondContent: tgtCtnt,
 				}
-			}
-
-			// No differ
-			if returnSimilar {
+			} else if returnSimilar {
+				// No differ
 				diffCh <- diffMessage{
 					FirstURL:      srcCtnt.URL.String(),
 					SecondURL:     tgtCtnt.URL.String(),
@@ -329,7 +330,7 @@ func differenceInternal(ctx context.Context, sourceClnt, targetClnt Client, sour
 // objectDifference function finds the difference between all objects
 // recursively in sorted order from source and target.
 func difference(ctx context.Context, sourceClnt, targetClnt Client, sourceURL, targetURL string, isMetadata bool, isRecursive, returnSimilar bool, dirOpt DirOpt) (diffCh chan diffMessage) {
-	diffCh = make(chan diffMessage, 10000)
+	diffCh = make(chan diffMessage, memoryBudget(diffMemoryShare, diffMessageMemory, 16, 10000))
 
 	go func() {
 		defer close(diffCh)
@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 
@@ -49,6 +50,14 @@ var (
 			Name:  "versions",
 			Usage: "include all object versions",
 		},
+		cli.BoolFlag{
+			Name:  "by-storage-class",
+			Usage: "break down the total of each folder prefix by storage class",
+		},
+		cli.IntFlag{
+			Name:  "by-depth",
+			Usage: "print the total only for folder prefixes exactly N levels below the command line argument, and for the argument",
+		},
 	}
 )
 
@@ -59,7 +68,7 @@ var duCmd = cli.Command{
 	Action:       mainDu,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(duFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(duFlags, ioFlags...), multiAliasFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -84,6 +93,12 @@ EXAMPLES:
 
   4. Summarize disk usage of 'jazz-songs' bucket with all objects versions
      {{.Prompt}} {{.HelpName}} --versions s3/jazz-songs/
+
+  5. Summarize disk usage of the 'backups' bucket on all configured aliases.
+     {{.Prompt}} {{.HelpName}} --all-aliases --depth=1 backups
+
+  6. Show how many bytes of each project of the 'archive' bucket were transitioned by lifecycle rules.
+     {{.Prompt}} {{.HelpName}} --by-storage-class --by-depth=1 s3/archive
 `,
 }
 
@@ -92,24 +107,97 @@ type duMessage struct {
 	Prefix string `json:"prefix"`
 	Size   int64  `json:"size"`
 	Status string `json:"status"`
+
+	// Size per storage class, only with --by-storage-class.
+	StorageClasses map[string]int64 `json:"storageClasses,omitempty"`
 }
 
 // Colorized message for console printing.
 func (r duMessage) String() string {
 	humanSize := strings.Join(strings.Fields(humanize.IBytes(uint64(r.Size))), "")
 
-	return fmt.Sprintf("%s\t%s", console.Colorize("Size", humanSize),
+	msg := fmt.Sprintf("%s\t%s", console.Colorize("Size", humanSize),
 		console.Colorize("Prefix", r.Prefix))
+	if len(r.StorageClasses) > 0 {
+		classes := make([]string, 0, len(r.StorageClasses))
+		for class := range r.StorageClasses {
+			classes = append(classes, class)
+		}
+		sort.Strings(classes)
+		for i, class := range classes {
+			classSize := strings.Join(strings.Fields(humanize.IBytes(uint64(r.StorageClasses[class]))), "")
+			classes[i] = class + "=" + console.Colorize("Size", classSize)
+		}
+		msg += "\t" + strings.Join(classes, " ")
+	}
+	return msg
 }
 
-// JSON'ified message for scripting.
+// JSON'ified message for scripting, one line per prefix.
 func (r duMessage) JSON() string {
-	msgBytes, e := json.MarshalIndent(r, "", " ")
+	msgBytes, e := json.Marshal(r)
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 	return string(msgBytes)
 }
 
-func du(urlStr string, timeRef time.Time, withVersions bool, depth int, encKeyDB map[string][]prefixSSEPair) (int64, error) {
+// duOptions holds the options of a disk usage summary.
+type duOptions struct {
+	timeRef        time.Time
+	withVersions   bool
+	byStorageClass bool
+	encKeyDB       map[string][]prefixSSEPair
+
+	// Print the folder prefixes up to depth levels below the argument,
+	// or exactly byDepth levels below when not zero.
+	depth   int
+	byDepth int
+}
+
+// summarized returns true when no folder below level is printed, the
+// usage of the folder at level is then computed with a single recursive
+// listing instead of a listing per folder.
+func (opts duOptions) summarized(level int) bool {
+	if opts.byDepth > 0 {
+		return level >= opts.byDepth
+	}
+	return opts.depth == 0 || opts.depth == 1
+}
+
+// duUsage is the disk usage of a folder prefix.
+type duUsage struct {
+	size           int64
+	storageClasses map[string]int64
+}
+
+func (u *duUsage) add(size int64, storageClass string, byStorageClass bool) {
+	u.size += size
+	if !byStorageClass {
+		return
+	}
+	if u.storageClasses == nil {
+		u.storageClasses = make(map[string]int64)
+	}
+	if storageClass == "" {
+		storageClass = "STANDARD"
+	}
+	u.storageClasses[storageClass] += size
+}
+
+func (u *duUsage) merge(v duUsage) {
+	u.size += v.size
+	for class, size := range v.storageClasses {
+		if u.storageClasses == nil {
+			u.storageClasses = make(map[string]int64)
+		}
+		u.storageClasses[class] += size
+	}
+}
+
+// du summarizes the disk usage of urlStr, level levels below the
+// command line argument.
+func du(urlStr string, opts duOptions, level int) (duUsage, error) {
+	var usage duUsage
+
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 	if !strings.HasSuffix(targetURL, "/") {
 		targetURL += "/"
@@ -118,16 +206,20 @@ func du(urlStr string, timeRef time.Time, withVersions bool, depth int, encKeyDB
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
 		errorIf(pErr.Trace(urlStr), "Failed to summarize disk usage `"+urlStr+"`.")
-		return 0, exitStatus(globalErrorExitStatus) // End of journey.
+		return usage, exitStatus(globalErrorExitStatus) // End of journey.
 	}
 
+	summarized := opts.summarized(level)
+	showDir := DirFirst
+	if summarized {
+		showDir = DirNone
+	}
 	contentCh := clnt.List(globalContext, ListOptions{
-		TimeRef:           timeRef,
-		WithOlderVersions: withVersions,
-		Recursive:         false,
-		ShowDir:           DirFirst,
+		TimeRef:           opts.timeRef,
+		WithOlderVersions: opts.withVersions,
+		Recursive:         summarized,
+		ShowDir:           showDir,
 	})
-	size := int64(0)
 	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
@@ -139,52 +231,65 @@ func du(urlStr string, timeRef time.Time, withVersions bool, depth int, encKeyDB
 				continue
 			}
 			errorIf(content.Err.Trace(urlStr), "Failed to find disk usage of `"+urlStr+"` recursively.")
-			return 0, exitStatus(globalErrorExitStatus)
+			return usage, exitStatus(globalErrorExitStatus)
 		}
 		if content.URL.String() == targetURL {
 			continue
 		}
 
 		if content.Type.IsDir() {
-			depth := depth
-			if depth > 0 {
-				depth--
+			if summarized {
+				// Folder markers of a recursive listing.
+				continue
+			}
+			opts := opts
+			if opts.depth > 0 {
+				opts.depth--
 			}
 
 			subDirAlias := content.URL.Path
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, err := du(subDirAlias, timeRef, withVersions, depth, encKeyDB)
+			used, err := du(subDirAlias, opts, level+1)
 			if err != nil {
-				return 0, err
+				return usage, err
 			}
-			size += used
+			usage.merge(used)
 		} else {
-			size += content.Size
+			usage.add(content.Size, content.StorageClass, opts.byStorageClass)
 		}
 	}
 
-	if depth != 0 {
+	printUsage := opts.depth != 0
+	if opts.byDepth > 0 {
+		printUsage = level == 0 || level == opts.byDepth
+	}
+	if printUsage {
 		u, err := url.Parse(targetURL)
 		if err != nil {
 			panic(err)
 		}
 
 		printMsg(duMessage{
-			Prefix: strings.Trim(u.Path, "/"),
-			Size:   size,
-			Status: "success",
+			Prefix:         strings.Trim(u.Path, "/"),
+			Size:           usage.size,
+			Status:         "success",
+			StorageClasses: usage.storageClasses,
 		})
 	}
 
-	return size, nil
+	return usage, nil
 }
 
 // main for du command.
 func mainDu(ctx *cli.Context) error {
+	if isMultiAlias(ctx) {
+		return runOnAliases(ctx)
+	}
+
 	if !ctx.Args().Present() {
-		cli.ShowCommandHelpAndExit(ctx, "du", 1)
+		cli.ShowCommandHelpAndExit(ctx, "du", globalInvalidArgsExitStatus)
 	}
 
 	// Set colors.
@@ -208,12 +313,24 @@ func mainDu(ctx *cli.Context) error {
 		}
 	}
 
-	withVersions := ctx.Bool("versions")
-	timeRef := parseRewindFlag(ctx.String("rewind"))
+	opts := duOptions{
+		timeRef:        parseRewindFlag(ctx.String("rewind")),
+		withVersions:   ctx.Bool("versions"),
+		byStorageClass: ctx.Bool("by-storage-class"),
+		encKeyDB:       encKeyDB,
+		depth:          depth,
+		byDepth:        ctx.Int("by-depth"),
+	}
+	if opts.byDepth < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("by-depth")), "--by-depth cannot be negative.")
+	}
+	if opts.byDepth > 0 && (ctx.IsSet("depth") || ctx.Bool("recursive")) {
+		fatalIf(errInvalidArgument().Trace(), "--by-depth cannot be used with --depth or --recursive.")
+	}
 
 	var duErr error
 	for _, urlStr := range ctx.Args() {
-		if _, err := du(urlStr, timeRef, withVersions, depth, encKeyDB); duErr == nil {
+		if _, err := du(urlStr, opts, 0); duErr == nil {
 			duErr = err
 		}
 	}
@@ -52,7 +52,7 @@ EXAMPLES:
 // checkEncryptClearSyntax - validate all the passed arguments
 func checkEncryptClearSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "clear", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -0,0 +1,241 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// encryptClientKey is the metadata holding the scheme of objects
+	// encrypted client-side with --encrypt-client.
+	encryptClientKey = "X-Amz-Meta-Mc-Encrypt-Client"
+
+	// encryptClientSizeKey is the metadata holding the size of the
+	// plaintext of objects encrypted client-side.
+	encryptClientSizeKey = "X-Amz-Meta-Mc-Encrypt-Client-Size"
+
+	encryptClientAge = "age"
+
+	// Each chunk of an age payload is authenticated by a 16 bytes tag.
+	ageChunkSize = 64 * 1024
+	ageTagSize   = 16
+)
+
+var encryptClientFlag = cli.StringFlag{
+	Name:  "encrypt-client",
+	Usage: "encrypt objects client-side for the age recipients, e.g. 'age:age1...' or 'age:RECIPIENTS_FILE'",
+}
+
+var identityFlag = cli.StringFlag{
+	Name:  "identity",
+	Usage: "age identity file to decrypt client-side encrypted objects",
+}
+
+// clientEncryption holds the recipients to encrypt objects for and the
+// identities to decrypt objects with, client-side.
+type clientEncryption struct {
+	recipients []age.Recipient
+	identities []age.Identity
+}
+
+// parseEncryptClient parses the value of --encrypt-client, the scheme
+// followed by comma separated recipients or recipients files.
+func parseEncryptClient(value string) ([]age.Recipient, *probe.Error) {
+	tokens := strings.SplitN(value, ":", 2)
+	if len(tokens) != 2 || tokens[1] == "" {
+		return nil, probe.NewError(fmt.Errorf("client-side encryption `%s` should be of the form age:RECIPIENT", value))
+	}
+	if tokens[0] != encryptClientAge {
+		return nil, probe.NewError(fmt.Errorf("unsupported client-side encryption `%s`, only `age` is supported", tokens[0]))
+	}
+
+	var recipients []age.Recipient
+	for _, arg := range strings.Split(tokens[1], ",") {
+		arg = strings.TrimSpace(arg)
+		if strings.HasPrefix(arg, "age1") {
+			r, e := age.ParseX25519Recipient(arg)
+			if e != nil {
+				return nil, probe.NewError(e).Trace(arg)
+			}
+			recipients = append(recipients, r)
+			continue
+		}
+		f, e := os.Open(arg)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(arg)
+		}
+		rs, e := age.ParseRecipients(f)
+		f.Close()
+		if e != nil {
+			return nil, probe.NewError(e).Trace(arg)
+		}
+		recipients = append(recipients, rs...)
+	}
+	return recipients, nil
+}
+
+// parseIdentityFile parses an age identity file.
+func parseIdentityFile(path string) ([]age.Identity, *probe.Error) {
+	f, e := os.Open(path)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+
+	identities, e := age.ParseIdentities(f)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	return identities, nil
+}
+
+// getClientEncryption returns the client-side encryption requested by
+// --encrypt-client and --identity, nil if none.
+func getClientEncryption(ctx *cli.Context) (*clientEncryption, *probe.Error) {
+	var c clientEncryption
+	var err *probe.Error
+	if value := ctx.String("encrypt-client"); value != "" {
+		if c.recipients, err = parseEncryptClient(value); err != nil {
+			return nil, err
+		}
+	}
+	if path := ctx.String("identity"); path != "" {
+		if c.identities, err = parseIdentityFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if c.recipients == nil && c.identities == nil {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+// isClientEncrypted tells if the metadata of an object marks it as
+// encrypted client-side.
+func isClientEncrypted(metadata map[string]string) bool {
+	return metadata[encryptClientKey] == encryptClientAge
+}
+
+// encryptClientSize returns the size of the age encryption of size bytes
+// with a header of headerSize bytes.
+func encryptClientSize(headerSize, size int64) int64 {
+	chunks := (size + ageChunkSize - 1) / ageChunkSize
+	if chunks == 0 {
+		chunks = 1
+	}
+	return headerSize + size + chunks*ageTagSize
+}
+
+// switchWriter lets the header of the encryption be buffered before the
+// payload is streamed.
+type switchWriter struct {
+	io.Writer
+}
+
+// encryptClientReader encrypts reader for the recipients, the size of the
+// result is returned if size is known, -1 otherwise. Closing the result
+// stops the encryption.
+func encryptClientReader(reader io.Reader, size int64, recipients []age.Recipient) (io.ReadCloser, int64, *probe.Error) {
+	var header bytes.Buffer
+	dst := &switchWriter{Writer: &header}
+	w, e := age.Encrypt(dst, recipients...)
+	if e != nil {
+		return nil, 0, probe.NewError(e)
+	}
+
+	encSize := int64(-1)
+	if size >= 0 {
+		encSize = encryptClientSize(int64(header.Len()), size)
+	}
+
+	pr, pw := io.Pipe()
+	dst.Writer = pw
+	go func() {
+		_, e := io.Copy(w, reader)
+		if e == nil {
+			e = w.Close()
+		}
+		pw.CloseWithError(e)
+	}()
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(&header, pr), pr}, encSize, nil
+}
+
+// decryptClientReader decrypts a client-side encrypted reader.
+func decryptClientReader(reader io.Reader, identities []age.Identity) (io.Reader, *probe.Error) {
+	r, e := age.Decrypt(reader, identities...)
+	if e != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(e, &noMatch) {
+			return nil, probe.NewError(errors.New("no identity matches the recipients of the object"))
+		}
+		return nil, probe.NewError(e)
+	}
+	return r, nil
+}
+
+// transform decrypts a client-side encrypted source stream if identities
+// are known, then encrypts it if recipients are known. A stream already
+// encrypted is never encrypted twice. The envelope metadata is updated
+// and the progress is accounted on the source stream, it must not be
+// accounted again by the upload.
+func (c *clientEncryption) transform(reader io.Reader, size int64, metadata map[string]string, progress io.Reader) (io.ReadCloser, int64, *probe.Error) {
+	if progress != nil {
+		reader = hookreader.NewHook(reader, progress)
+	}
+
+	if isClientEncrypted(metadata) {
+		if c.identities == nil {
+			return ioutil.NopCloser(reader), size, nil
+		}
+		r, err := decryptClientReader(reader, c.identities)
+		if err != nil {
+			return nil, 0, err
+		}
+		reader = r
+		size = -1
+		if s, e := strconv.ParseInt(metadata[encryptClientSizeKey], 10, 64); e == nil {
+			size = s
+		}
+		delete(metadata, encryptClientKey)
+		delete(metadata, encryptClientSizeKey)
+	}
+
+	if c.recipients == nil {
+		return ioutil.NopCloser(reader), size, nil
+	}
+	metadata[encryptClientKey] = encryptClientAge
+	if size >= 0 {
+		metadata[encryptClientSizeKey] = strconv.FormatInt(size, 10)
+	}
+	return encryptClientReader(reader, size, c.recipients)
+}
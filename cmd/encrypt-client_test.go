@@ -0,0 +1,118 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestParseEncryptClient(t *testing.T) {
+	identity, e := age.GenerateX25519Identity()
+	if e != nil {
+		t.Fatal(e)
+	}
+	recipient := identity.Recipient().String()
+
+	testCases := []struct {
+		value      string
+		recipients int
+		success    bool
+	}{
+		{"age:" + recipient, 1, true},
+		{"age:" + recipient + "," + recipient, 2, true},
+		{"age:", 0, false},
+		{recipient, 0, false},
+		{"pgp:" + recipient, 0, false},
+		{"age:age1invalid", 0, false},
+		{"age:/nonexistent/recipients.txt", 0, false},
+	}
+	for i, tc := range testCases {
+		recipients, err := parseEncryptClient(tc.value)
+		if tc.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, tc.success, err)
+		}
+		if len(recipients) != tc.recipients {
+			t.Fatalf("Test %d: expected %d recipients, got %d", i+1, tc.recipients, len(recipients))
+		}
+	}
+}
+
+func TestClientEncryptionTransform(t *testing.T) {
+	identity, e := age.GenerateX25519Identity()
+	if e != nil {
+		t.Fatal(e)
+	}
+	other, e := age.GenerateX25519Identity()
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	for _, size := range []int{0, 1, ageChunkSize - 1, ageChunkSize, ageChunkSize + 1, 3*ageChunkSize + 5} {
+		plaintext := bytes.Repeat([]byte{'x'}, size)
+
+		enc := &clientEncryption{recipients: []age.Recipient{identity.Recipient()}}
+		metadata := map[string]string{}
+		r, encSize, err := enc.transform(bytes.NewReader(plaintext), int64(size), metadata, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ciphertext, e := ioutil.ReadAll(r)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if int64(len(ciphertext)) != encSize {
+			t.Fatalf("size %d: expected %d bytes of ciphertext, got %d", size, encSize, len(ciphertext))
+		}
+		if !isClientEncrypted(metadata) {
+			t.Fatalf("size %d: expected the metadata to mark the object as encrypted", size)
+		}
+
+		// Without identity, the ciphertext is kept as is.
+		r, n, err := enc.transform(bytes.NewReader(ciphertext), encSize, metadata, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data, _ := ioutil.ReadAll(r); !bytes.Equal(data, ciphertext) || n != encSize {
+			t.Fatalf("size %d: expected the ciphertext not to be encrypted twice", size)
+		}
+
+		dec := &clientEncryption{identities: []age.Identity{other}}
+		if _, _, err = dec.transform(bytes.NewReader(ciphertext), encSize, metadata, nil); err == nil {
+			t.Fatalf("size %d: expected an error for a wrong identity", size)
+		}
+
+		dec = &clientEncryption{identities: []age.Identity{identity}}
+		r, n, err = dec.transform(bytes.NewReader(ciphertext), encSize, metadata, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, e := ioutil.ReadAll(r)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if !bytes.Equal(data, plaintext) || n != int64(size) {
+			t.Fatalf("size %d: decrypted content or size mismatch", size)
+		}
+		if isClientEncrypted(metadata) || metadata[encryptClientSizeKey] != "" {
+			t.Fatalf("size %d: expected the envelope metadata to be removed", size)
+		}
+	}
+}
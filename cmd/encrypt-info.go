@@ -52,7 +52,7 @@ EXAMPLES:
 // checkversionInfoSyntax - validate all the passed arguments
 func checkEncryptInfoSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
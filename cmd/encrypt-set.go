@@ -56,7 +56,7 @@ EXAMPLES:
 // checkEncryptSetSyntax - validate all the passed arguments
 func checkEncryptSetSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) < 2 || len(ctx.Args()) > 3 {
-		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "set", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
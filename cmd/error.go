@@ -20,12 +20,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"os"
 	"strings"
 	"unicode"
 
 	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio/pkg/console"
 )
 
@@ -53,6 +57,8 @@ func fatalIf(err *probe.Error, msg string, data ...interface{}) {
 }
 
 func fatal(err *probe.Error, msg string, data ...interface{}) {
+	err = explainTimeout(err)
+	logError(err, true, fmt.Sprintf(msg, data...))
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: msg,
@@ -77,7 +83,7 @@ func fatal(err *probe.Error, msg string, data ...interface{}) {
 			console.Fatalln(probe.NewError(e))
 		}
 		console.Println(string(json))
-		console.Fatalln()
+		os.Exit(errorExitStatus(err))
 	}
 
 	msg = fmt.Sprintf(msg, data...)
@@ -113,7 +119,54 @@ func fatal(err *probe.Error, msg string, data ...interface{}) {
 		}
 	}
 
-	console.Fatalln(fmt.Sprintf("%s %s", msg, errmsg))
+	console.Errorln(fmt.Sprintf("%s %s", msg, errmsg))
+	os.Exit(errorExitStatus(err))
+}
+
+// errorExitStatus maps an error to the exit status of its class of
+// failure, so that scripts can tell apart why a command failed.
+func errorExitStatus(err *probe.Error) int {
+	if err == nil {
+		return globalErrorExitStatus
+	}
+	e := err.ToGoError()
+	if e == errInvalidArgumentCause {
+		return globalInvalidArgsExitStatus
+	}
+
+	switch e.(type) {
+	case BucketDoesNotExist, ObjectMissing, PathNotFound, ObjectIsDeleteMarker:
+		return globalNotFoundExitStatus
+	case PathInsufficientPermission:
+		return globalAccessDeniedExitStatus
+	}
+	if errors.Is(e, os.ErrNotExist) {
+		return globalNotFoundExitStatus
+	}
+	if errors.Is(e, os.ErrPermission) {
+		return globalAccessDeniedExitStatus
+	}
+
+	code := minio.ToErrorResponse(e).Code
+	if code == "" {
+		code = madmin.ToErrorResponse(e).Code
+	}
+	switch code {
+	case "AccessDenied", "InvalidAccessKeyId", "SignatureDoesNotMatch", "ExpiredToken",
+		"InvalidToken", "XMinioAdminNoSuchAccessKey":
+		return globalAccessDeniedExitStatus
+	case "NoSuchBucket", "NoSuchKey", "NoSuchVersion", "NoSuchUpload", "XMinioAdminNoSuchUser",
+		"XMinioAdminNoSuchGroup", "XMinioAdminNoSuchPolicy", "XMinioAdminNoSuchQuotaConfiguration":
+		return globalNotFoundExitStatus
+	case "XMinioAdminBucketQuotaExceeded", "XMinioStorageFull", "QuotaExceeded":
+		return globalQuotaExceededExitStatus
+	}
+
+	var netErr net.Error
+	if errors.As(e, &netErr) {
+		return globalConnectionExitStatus
+	}
+	return globalErrorExitStatus
 }
 
 // Exit coder wraps cli new exit error with a
@@ -130,6 +183,8 @@ func errorIf(err *probe.Error, msg string, data ...interface{}) {
 	if err == nil {
 		return
 	}
+	err = explainTimeout(err)
+	logError(err, false, fmt.Sprintf(msg, data...))
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: fmt.Sprintf(msg, data...),
@@ -0,0 +1,49 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestErrorExitStatus(t *testing.T) {
+	testCases := []struct {
+		err      *probe.Error
+		expected int
+	}{
+		{errInvalidArgument(), globalInvalidArgsExitStatus},
+		{probe.NewError(BucketDoesNotExist{Bucket: "test"}), globalNotFoundExitStatus},
+		{probe.NewError(os.ErrNotExist), globalNotFoundExitStatus},
+		{probe.NewError(minio.ErrorResponse{Code: "NoSuchKey"}), globalNotFoundExitStatus},
+		{probe.NewError(minio.ErrorResponse{Code: "AccessDenied"}), globalAccessDeniedExitStatus},
+		{probe.NewError(PathInsufficientPermission{Path: "/tmp"}), globalAccessDeniedExitStatus},
+		{probe.NewError(minio.ErrorResponse{Code: "XMinioAdminBucketQuotaExceeded"}), globalQuotaExceededExitStatus},
+		{probe.NewError(&net.OpError{Op: "dial", Err: errors.New("connection refused")}), globalConnectionExitStatus},
+		{probe.NewError(errors.New("unknown")), globalErrorExitStatus},
+	}
+	for i, testCase := range testCases {
+		if got := errorExitStatus(testCase.err); got != testCase.expected {
+			t.Errorf("Test %d: expected exit status %d, got %d", i+1, testCase.expected, got)
+		}
+	}
+}
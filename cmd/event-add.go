@@ -83,7 +83,7 @@ EXAMPLES:
 // checkEventAddSyntax - validate all the passed arguments
 func checkEventAddSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "add", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
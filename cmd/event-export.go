@@ -0,0 +1,110 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var eventExportCmd = cli.Command{
+	Name:         "export",
+	Usage:        "export bucket notifications in JSON format",
+	Action:       mainEventExport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+DESCRIPTION:
+  Exports the notification configurations of a bucket, with their ARN, events,
+  prefix and suffix, in JSON format to STDOUT.
+
+EXAMPLES:
+  1. Export the notifications of 'mybucket' to 'events.json' file.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket > events.json
+
+  2. Print the notifications of 'mybucket' to STDOUT.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket
+`,
+}
+
+type eventExportMessage struct {
+	Status string               `json:"status"`
+	Target string               `json:"target"`
+	Config []NotificationConfig `json:"config"`
+}
+
+func (e eventExportMessage) String() string {
+	msgBytes, err := json.MarshalIndent(e.Config, "", " ")
+	fatalIf(probe.NewError(err), "Unable to export notification configuration.")
+
+	return string(msgBytes)
+}
+
+func (e eventExportMessage) JSON() string {
+	msgBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+
+	return string(msgBytes)
+}
+
+// checkEventExportSyntax - validate arguments passed by user
+func checkEventExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", globalInvalidArgsExitStatus)
+	}
+}
+
+func mainEventExport(cliCtx *cli.Context) error {
+	ctx, cancelEventExport := context.WithCancel(globalContext)
+	defer cancelEventExport()
+
+	checkEventExportSyntax(cliCtx)
+
+	urlStr := cliCtx.Args().Get(0)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr+".")
+
+	s3Client, ok := client.(*S3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
+	}
+
+	configs, err := s3Client.ListNotificationConfigs(ctx, "")
+	fatalIf(err.Trace(urlStr), "Unable to list notifications on the specified bucket.")
+	if len(configs) == 0 {
+		fatalIf(probe.NewError(errors.New("notification configuration not set")).Trace(urlStr),
+			"Unable to export notification configuration.")
+	}
+
+	printMsg(eventExportMessage{
+		Status: "success",
+		Target: urlStr,
+		Config: configs,
+	})
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var eventImportCmd = cli.Command{
+	Name:         "import",
+	Usage:        "import bucket notifications in JSON format",
+	Action:       mainEventImport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+DESCRIPTION:
+  Import the notification configurations of a bucket from STDIN, in the JSON
+  format of 'mc event export'. They replace all the notifications of the bucket.
+
+EXAMPLES:
+  1. Restore the notifications of 'mybucket' exported to 'events.json' file.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket < events.json
+
+  2. Copy the notifications of a bucket to another bucket.
+     {{.Prompt}} mc event export myminio/mybucket | {{.HelpName}} myminio/otherbucket
+`,
+}
+
+type eventImportMessage struct {
+	Status string `json:"status"`
+	Target string `json:"target"`
+}
+
+func (e eventImportMessage) String() string {
+	return console.Colorize("Event", "Notification configuration imported successfully to `"+e.Target+"`.")
+}
+
+func (e eventImportMessage) JSON() string {
+	msgBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// readEventConfigs reads the notification configurations exported by
+// 'mc event export'.
+func readEventConfigs(r io.Reader) ([]NotificationConfig, *probe.Error) {
+	var configs []NotificationConfig
+	if e := json.NewDecoder(r).Decode(&configs); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if _, err := newNotificationConfiguration(configs); err != nil {
+		return nil, err.Trace()
+	}
+	return configs, nil
+}
+
+// checkEventImportSyntax - validate arguments passed by user
+func checkEventImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "import", globalInvalidArgsExitStatus)
+	}
+}
+
+func mainEventImport(cliCtx *cli.Context) error {
+	ctx, cancelEventImport := context.WithCancel(globalContext)
+	defer cancelEventImport()
+
+	checkEventImportSyntax(cliCtx)
+	console.SetColor("Event", color.New(color.FgGreen, color.Bold))
+
+	urlStr := cliCtx.Args().Get(0)
+
+	client, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize client for "+urlStr+".")
+
+	s3Client, ok := client.(*S3Client)
+	if !ok {
+		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
+	}
+
+	configs, err := readEventConfigs(os.Stdin)
+	fatalIf(err.Trace(urlStr), "Unable to read notification configuration.")
+	if len(configs) == 0 {
+		// Abort here, importing no configuration would remove all the notifications.
+		fatalIf(errDummy().Trace(urlStr), "The provided notification configuration is empty, aborting.")
+	}
+
+	fatalIf(s3Client.SetNotificationConfigs(ctx, configs).Trace(urlStr), "Unable to set the notifications of `"+urlStr+"`.")
+
+	printMsg(eventImportMessage{
+		Status: "success",
+		Target: urlStr,
+	})
+	return nil
+}
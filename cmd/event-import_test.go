@@ -0,0 +1,64 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadEventConfigs(t *testing.T) {
+	testCases := []struct {
+		input   string
+		configs int
+		ok      bool
+	}{
+		{`[{"id":"1","arn":"arn:minio:sqs::1:webhook","events":["s3:ObjectCreated:*","s3:ObjectRemoved:*"],"prefix":"photos/","suffix":".jpg"},
+		   {"id":"2","arn":"arn:minio:sqs::1:webhook","events":["s3:ObjectCreated:*"],"prefix":"videos/","suffix":""},
+		   {"id":"3","arn":"arn:aws:sns:us-east-1:444455556666:topic","events":["s3:ObjectAccessed:*"],"prefix":"","suffix":""}]`, 3, true},
+		{`[]`, 0, true},
+		// Malformed ARN.
+		{`[{"arn":"arn:minio:sqs:webhook","events":["s3:ObjectCreated:*"]}]`, 0, false},
+		// Unknown service.
+		{`[{"arn":"arn:minio:s3::1:webhook","events":["s3:ObjectCreated:*"]}]`, 0, false},
+		{`{"arn":"arn:minio:sqs::1:webhook"}`, 0, false},
+	}
+	for i, testCase := range testCases {
+		configs, err := readEventConfigs(strings.NewReader(testCase.input))
+		if (err == nil) != testCase.ok {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.ok, err)
+		}
+		if len(configs) != testCase.configs {
+			t.Errorf("Test %d: expected %d configurations, got %d", i+1, testCase.configs, len(configs))
+		}
+	}
+
+	configs, err := readEventConfigs(strings.NewReader(testCases[0].input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mb, err := newNotificationConfiguration(configs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mb.QueueConfigs) != 2 || len(mb.TopicConfigs) != 1 || len(mb.QueueConfigs[0].Events) != 2 {
+		t.Fatalf("unexpected notification configuration %+v", mb)
+	}
+	if rules := mb.QueueConfigs[0].Filter.S3Key.FilterRules; len(rules) != 2 || rules[0].Value != "photos/" || rules[1].Value != ".jpg" {
+		t.Errorf("unexpected filter rules %+v", rules)
+	}
+}
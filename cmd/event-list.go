@@ -59,7 +59,7 @@ EXAMPLES:
 // checkEventListSyntax - validate all the passed arguments
 func checkEventListSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 && len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "list", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -26,6 +26,8 @@ var eventSubcommands = []cli.Command{
 	eventAddCmd,
 	eventRemoveCmd,
 	eventListCmd,
+	eventExportCmd,
+	eventImportCmd,
 }
 
 var eventCmd = cli.Command{
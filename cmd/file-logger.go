@@ -0,0 +1,238 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Log levels supported by --log-level, in increasing order of severity.
+const (
+	logLevelDebug = iota
+	logLevelInfo
+	logLevelWarn
+	logLevelError
+)
+
+var logLevels = map[string]int{
+	"debug": logLevelDebug,
+	"info":  logLevelInfo,
+	"warn":  logLevelWarn,
+	"error": logLevelError,
+}
+
+var logLevelNames = []string{"debug", "info", "warn", "error"}
+
+const (
+	// Log files are rotated once they grow beyond this size.
+	logFileMaxSize = 100 * humanize.MiByte
+
+	// Number of rotated log files kept next to the current one.
+	logFileMaxBackups = 5
+)
+
+// fileLogger writes JSON lines to a file, independent of the
+// console output, rotating it when it grows too large.
+type fileLogger struct {
+	mu    sync.Mutex
+	path  string
+	level int
+	file  *os.File
+	size  int64
+}
+
+// globalLogger is set when --log-file is used.
+var globalLogger *fileLogger
+
+// newFileLogger opens, or creates, the log file at path.
+func newFileLogger(path, level string) (*fileLogger, *probe.Error) {
+	if level == "" {
+		level = "info"
+	}
+	lvl, ok := logLevels[strings.ToLower(level)]
+	if !ok {
+		return nil, probe.NewError(fmt.Errorf("unknown log level `%s`, possible values are %s",
+			level, strings.Join(logLevelNames, ", ")))
+	}
+	l := &fileLogger{path: path, level: lvl}
+	if e := l.open(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return l, nil
+}
+
+func (l *fileLogger) open() error {
+	f, e := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if e != nil {
+		return e
+	}
+	st, e := f.Stat()
+	if e != nil {
+		f.Close()
+		return e
+	}
+	l.file = f
+	l.size = st.Size()
+	return nil
+}
+
+// rotate renames path to path.1, path.1 to path.2 and so
+// on, dropping the oldest file, then reopens path.
+func (l *fileLogger) rotate() error {
+	l.file.Close()
+	os.Remove(fmt.Sprintf("%s.%d", l.path, logFileMaxBackups))
+	for i := logFileMaxBackups - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+	}
+	if e := os.Rename(l.path, l.path+".1"); e != nil {
+		return e
+	}
+	return l.open()
+}
+
+// log writes one entry of the given type with the extra fields.
+func (l *fileLogger) log(level int, entryType, msg string, fields map[string]interface{}) {
+	if l == nil || level < l.level {
+		return
+	}
+	entry := map[string]interface{}{
+		"time":    time.Now().UTC().Format(time.RFC3339Nano),
+		"level":   logLevelNames[level],
+		"type":    entryType,
+		"message": msg,
+		"pid":     os.Getpid(),
+	}
+	for k, v := range fields {
+		entry[k] = v
+	}
+	buf, e := json.Marshal(entry)
+	if e != nil {
+		return
+	}
+	buf = append(buf, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.size+int64(len(buf)) > logFileMaxSize {
+		if e := l.rotate(); e != nil {
+			return
+		}
+	}
+	n, _ := l.file.Write(buf)
+	l.size += int64(n)
+}
+
+// logError records an error reported to the user.
+func logError(err *probe.Error, fatal bool, msg string) {
+	if globalLogger == nil || err == nil {
+		return
+	}
+	entryType := "error"
+	if fatal {
+		entryType = "fatal"
+	}
+	fields := map[string]interface{}{"error": err.ToGoError().Error()}
+	if fatal {
+		fields["exitCode"] = errorExitStatus(err)
+	}
+	globalLogger.log(logLevelError, entryType, msg, fields)
+}
+
+// logSummary records the outcome of a transfer command.
+func logSummary(command string, fields map[string]interface{}) {
+	if globalLogger == nil {
+		return
+	}
+	fields["command"] = command
+	globalLogger.log(logLevelInfo, "summary", command+" finished", fields)
+}
+
+// logTransport logs every HTTP request, requests sent again after
+// a failure of the same method and URL are logged as retries.
+type logTransport struct {
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+func newLogTransport(transport http.RoundTripper) http.RoundTripper {
+	return &logTransport{transport: transport, failures: make(map[string]int)}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.String()
+
+	t.mu.Lock()
+	attempt := t.failures[key] + 1
+	t.mu.Unlock()
+
+	if attempt > 1 {
+		globalLogger.log(logLevelWarn, "retry", "retrying request", map[string]interface{}{
+			"method":  req.Method,
+			"host":    req.URL.Host,
+			"path":    req.URL.Path,
+			"attempt": attempt,
+		})
+	}
+
+	start := time.Now()
+	resp, e := t.transport.RoundTrip(req)
+
+	fields := map[string]interface{}{
+		"method":   req.Method,
+		"host":     req.URL.Host,
+		"path":     req.URL.Path,
+		"duration": time.Since(start).String(),
+		"attempt":  attempt,
+	}
+	failed := e != nil
+	if e != nil {
+		fields["error"] = e.Error()
+	} else {
+		fields["statusCode"] = resp.StatusCode
+		if resp.ContentLength >= 0 {
+			fields["size"] = resp.ContentLength
+		}
+		failed = resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+	}
+	globalLogger.log(logLevelDebug, "request", "", fields)
+
+	t.mu.Lock()
+	if failed {
+		// Avoid growing without bounds on requests never retried.
+		if len(t.failures) > 1000 {
+			t.failures = make(map[string]int)
+		}
+		t.failures[key] = attempt
+	} else {
+		delete(t.failures, key)
+	}
+	t.mu.Unlock()
+
+	return resp, e
+}
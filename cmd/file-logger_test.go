@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileLogger(t *testing.T) {
+	dir, e := ioutil.TempDir(os.TempDir(), "mc-log-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "mc.log")
+	l, err := newFileLogger(path, "warn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.log(logLevelInfo, "summary", "skipped", nil)
+	l.log(logLevelError, "error", "kept", map[string]interface{}{"error": "failure"})
+
+	// Force a rotation on the next entry.
+	l.size = logFileMaxSize
+	l.log(logLevelWarn, "retry", "rotated", nil)
+	l.file.Close()
+
+	readEntries := func(path string) []map[string]interface{} {
+		f, e := os.Open(path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		defer f.Close()
+		var entries []map[string]interface{}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var entry map[string]interface{}
+			if e := json.Unmarshal(scanner.Bytes(), &entry); e != nil {
+				t.Fatal(e)
+			}
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	rotated := readEntries(path + ".1")
+	if len(rotated) != 1 || rotated[0]["message"] != "kept" || rotated[0]["error"] != "failure" {
+		t.Errorf("unexpected rotated log entries %v", rotated)
+	}
+	current := readEntries(path)
+	if len(current) != 1 || current[0]["type"] != "retry" || current[0]["level"] != "warn" {
+		t.Errorf("unexpected log entries %v", current)
+	}
+
+	if _, err = newFileLogger(path, "verbose"); err == nil {
+		t.Error("expected an error for an unknown log level")
+	}
+}
@@ -0,0 +1,279 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/pkg/console"
+)
+
+// Number of matches whose tags or storage class are changed concurrently.
+const findActionWorkers = 16
+
+// findActionMessage container for an action applied to a match of find.
+type findActionMessage struct {
+	Status string `json:"status"`
+	Action string `json:"action"`
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+func (f findActionMessage) String() string {
+	var msg string
+	switch f.Action {
+	case "delete":
+		msg = fmt.Sprintf("Removed `%s`.", f.Key)
+		if f.DryRun {
+			msg = fmt.Sprintf("Would remove `%s`.", f.Key)
+		}
+	case "set-tags":
+		msg = fmt.Sprintf("Set tags `%s` on `%s`.", f.Value, f.Key)
+		if f.DryRun {
+			msg = fmt.Sprintf("Would set tags `%s` on `%s`.", f.Value, f.Key)
+		}
+	case "set-storage-class":
+		msg = fmt.Sprintf("Set storage class `%s` of `%s`.", f.Value, f.Key)
+		if f.DryRun {
+			msg = fmt.Sprintf("Would set storage class `%s` of `%s`.", f.Value, f.Key)
+		}
+	}
+	return console.Colorize("FindAction", msg)
+}
+
+func (f findActionMessage) JSON() string {
+	f.Status = "success"
+	jsonMessageBytes, e := json.Marshal(f)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// findActions applies --delete, --set-tags and --set-storage-class to
+// the matches of find. The matches are handled by a pool of workers,
+// one request per match, so that each action is printed once done.
+//
+// The storage class is set by copying an object onto itself, which
+// would add a version in versioned buckets, those are skipped.
+type findActions struct {
+	delete       bool
+	tags         string
+	storageClass string
+	dryRun       bool
+
+	targetAlias string
+
+	workCh  chan findActionWork
+	workWg  sync.WaitGroup
+	errMu   sync.Mutex
+	errSeen bool
+
+	// Versioning of the buckets, by host and bucket name.
+	versionedMu sync.Mutex
+	versioned   map[string]bool
+}
+
+// findActionWork is a match acted on by a worker.
+type findActionWork struct {
+	content *ClientContent
+	key     string
+}
+
+// newFindActions returns nil when no action is requested.
+func newFindActions(isDelete bool, tagSet, storageClass string, dryRun bool) (*findActions, *probe.Error) {
+	if !isDelete && tagSet == "" && storageClass == "" {
+		return nil, nil
+	}
+	if isDelete && (tagSet != "" || storageClass != "") {
+		return nil, probe.NewError(fmt.Errorf("--delete cannot be combined with --set-tags or --set-storage-class"))
+	}
+	if tagSet != "" {
+		if _, e := tags.Parse(tagSet, true); e != nil {
+			return nil, probe.NewError(e).Trace(tagSet)
+		}
+	}
+	return &findActions{
+		delete:       isDelete,
+		tags:         tagSet,
+		storageClass: strings.ToUpper(storageClass),
+		dryRun:       dryRun,
+		versioned:    make(map[string]bool),
+	}, nil
+}
+
+// start prepares the workers.
+func (a *findActions) start(ctx context.Context, targetAlias string) {
+	a.targetAlias = targetAlias
+	if a.dryRun {
+		return
+	}
+	a.workCh = make(chan findActionWork)
+	for i := 0; i < findActionWorkers; i++ {
+		a.workWg.Add(1)
+		go func() {
+			defer a.workWg.Done()
+			for work := range a.workCh {
+				if a.delete {
+					a.remove(ctx, work.content, work.key)
+				} else {
+					a.update(ctx, work.content, work.key)
+				}
+			}
+		}()
+	}
+}
+
+func (a *findActions) failed() {
+	a.errMu.Lock()
+	a.errSeen = true
+	a.errMu.Unlock()
+}
+
+// apply applies the actions to a match, key is the path printed.
+func (a *findActions) apply(ctx context.Context, content *ClientContent, key string) {
+	if a.dryRun {
+		switch {
+		case a.delete:
+			printMsg(findActionMessage{Action: "delete", Key: key, DryRun: true})
+		default:
+			if a.storageClass != "" && !a.isVersioned(ctx, content, key) {
+				printMsg(findActionMessage{Action: "set-storage-class", Key: key, Value: a.storageClass, DryRun: true})
+			}
+			if a.tags != "" {
+				printMsg(findActionMessage{Action: "set-tags", Key: key, Value: a.tags, DryRun: true})
+			}
+		}
+		return
+	}
+	select {
+	case a.workCh <- findActionWork{content: content, key: key}:
+	case <-ctx.Done():
+	}
+}
+
+// isVersioned tells if the bucket of a match has versioning enabled or
+// suspended, once per bucket with a warning. Copying an object onto
+// itself there would keep the former data as a version.
+func (a *findActions) isVersioned(ctx context.Context, content *ClientContent, key string) bool {
+	bucket := content.URL.Host + "/" + splitStr(content.URL.Path, string(content.URL.Separator), 3)[1]
+	a.versionedMu.Lock()
+	defer a.versionedMu.Unlock()
+	versioned, ok := a.versioned[bucket]
+	if ok {
+		return versioned
+	}
+	urlStr := content.URL.String()
+	clnt, err := newClientFromAlias(a.targetAlias, urlStr)
+	if err == nil {
+		var config minio.BucketVersioningConfiguration
+		config, err = clnt.GetVersion(ctx)
+		versioned = err == nil && config.Status != ""
+	}
+	if err != nil {
+		errorIf(err.Trace(urlStr), "Unable to get the versioning of the bucket of `%s`, its storage class is not changed.", key)
+		versioned = true
+	} else if versioned {
+		errorIf(errDummy().Trace(urlStr), "Skipping --set-storage-class in the versioned bucket of `%s`, it would add a version of each object.", key)
+	}
+	a.versioned[bucket] = versioned
+	return versioned
+}
+
+// remove removes a match.
+func (a *findActions) remove(ctx context.Context, content *ClientContent, key string) {
+	urlStr := content.URL.String()
+	clnt, err := newClientFromAlias(a.targetAlias, urlStr)
+	if err != nil {
+		errorIf(err.Trace(urlStr), "Unable to initialize `%s`.", key)
+		a.failed()
+		return
+	}
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- content
+	close(contentCh)
+	removed := true
+	for err = range clnt.Remove(ctx, false, false, false, contentCh) {
+		errorIf(err.Trace(urlStr), "Unable to remove `%s`.", key)
+		a.failed()
+		removed = false
+	}
+	if removed {
+		printMsg(findActionMessage{Action: "delete", Key: key})
+	}
+}
+
+// update changes the storage class, then the tags of an object.
+func (a *findActions) update(ctx context.Context, content *ClientContent, key string) {
+	urlStr := content.URL.String()
+	clnt, err := newClientFromAlias(a.targetAlias, urlStr)
+	if err != nil {
+		errorIf(err.Trace(urlStr), "Unable to initialize `%s`.", key)
+		a.failed()
+		return
+	}
+
+	if a.storageClass != "" && !a.isVersioned(ctx, content, key) {
+		// The object is copied onto itself, its metadata is kept.
+		st, err := clnt.Stat(ctx, StatOptions{})
+		if err == nil {
+			metadata := make(map[string]string, len(st.Metadata))
+			for k, v := range st.Metadata {
+				metadata[http.CanonicalHeaderKey(k)] = v
+			}
+			err = clnt.Copy(ctx, content.URL.Path, CopyOptions{
+				size:         st.Size,
+				metadata:     filterMetadata(metadata),
+				storageClass: a.storageClass,
+			}, nil)
+		}
+		if err != nil {
+			errorIf(err.Trace(urlStr), "Unable to set the storage class of `%s`.", key)
+			a.failed()
+			return
+		}
+		printMsg(findActionMessage{Action: "set-storage-class", Key: key, Value: a.storageClass})
+	}
+
+	if a.tags != "" {
+		if err = clnt.SetTags(ctx, "", a.tags); err != nil {
+			errorIf(err.Trace(urlStr), "Unable to set the tags of `%s`.", key)
+			a.failed()
+			return
+		}
+		printMsg(findActionMessage{Action: "set-tags", Key: key, Value: a.tags})
+	}
+}
+
+// finish waits for all actions to complete.
+func (a *findActions) finish() error {
+	if a.workCh != nil {
+		close(a.workCh)
+		a.workWg.Wait()
+	}
+	if a.errSeen {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"regexp"
 	"strings"
 	"time"
 
@@ -61,16 +62,28 @@ var (
 		},
 		cli.StringFlag{
 			Name:  "regex",
-			Usage: "match directory and object name with PCRE regex pattern",
+			Usage: "match the full object key with a regular expression (RE2 syntax)",
 		},
 		cli.StringFlag{
-			Name:  "larger",
+			Name:  "larger, larger-than",
 			Usage: "match all objects larger than specified size in units (see UNITS)",
 		},
 		cli.StringFlag{
-			Name:  "smaller",
+			Name:  "smaller, smaller-than",
 			Usage: "match all objects smaller than specified size in units (see UNITS)",
 		},
+		cli.StringSliceFlag{
+			Name:  "metadata",
+			Usage: "match objects with the metadata KEY=VALUE, VALUE may be a wildcard pattern",
+		},
+		cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "match objects with the tag KEY=VALUE, VALUE may be a wildcard pattern",
+		},
+		cli.StringFlag{
+			Name:  "storage-class, sc",
+			Usage: "match objects of the storage class, e.g. STANDARD, GLACIER",
+		},
 		cli.UintFlag{
 			Name:  "maxdepth",
 			Usage: "limit directory navigation to specified depth",
@@ -79,6 +92,26 @@ var (
 			Name:  "watch",
 			Usage: "monitor a specified path for newly created object(s)",
 		},
+		cli.BoolFlag{
+			Name:  "null, 0",
+			Usage: "print each match followed by a NUL character instead of a newline, like -print0",
+		},
+		cli.BoolFlag{
+			Name:  "delete",
+			Usage: "remove all matching objects",
+		},
+		cli.StringFlag{
+			Name:  "set-tags",
+			Usage: "replace the tags of all matching objects, e.g. \"key1=value1&key2=value2\"",
+		},
+		cli.StringFlag{
+			Name:  "set-storage-class",
+			Usage: "move all matching objects to the storage class, e.g. STANDARD_IA",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "show what --delete, --set-tags and --set-storage-class would do",
+		},
 	}
 )
 
@@ -88,7 +121,7 @@ var findCmd = cli.Command{
 	Action:       mainFind,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(findFlags, globalFlags...),
+	Flags:        append(append(findFlags, maxRPSFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -103,7 +136,23 @@ UNITS
   suffixes such as "k", "m", "g" and "t" referring to the metric units KB,
   MB, GB and TB respectively. Adding an "i" to these prefixes, uses the IEC
   units, so that "gi" refers to "gibibyte" or "GiB". A "b" at the end is
-  also accepted. Without suffixes the unit is bytes.
+  also accepted. Without suffixes the unit is bytes. Both flags can be
+  given together to match a size range.
+
+  --regex matches the full object key within its bucket, or the full path
+  of a file, whatever the search path. All given flags must match.
+
+  --metadata, --tag and --storage-class only apply to object storage, they
+  need one more request per matching object for metadata and for tags.
+  Metadata keys are case-insensitive, the "X-Amz-Meta-" prefix of user
+  metadata is optional. --metadata and --tag may be repeated.
+
+  --delete, --set-tags and --set-storage-class act on the matches instead of
+  printing them, they cannot be used with --exec, --print, --watch or --null.
+  --set-tags and --set-storage-class only apply to object storage, when both
+  are given the storage class is changed first. Use --dry-run to preview.
+  --set-storage-class copies each object onto itself, which would add a
+  version of it in versioned buckets, those are skipped with a warning.
 
   --older-than, --newer-than flags accept the string for days, hours and minutes 
   i.e. 1d2h30m states 1 day, 2 hours and 30 minutes.
@@ -153,6 +202,28 @@ EXAMPLES:
 
   10. List all objects up to 3 levels sub-directory deep under "s3/bucket".
       {{.Prompt}} {{.HelpName}} s3/bucket --maxdepth 3
+
+  11. Find all parquet files in dated folders, e.g. "logs/2021/05/12/part-0.parquet", larger than 1MB under "s3/lake".
+      {{.Prompt}} {{.HelpName}} s3/lake --regex '/\d{4}/\d{2}/\d{2}/.*\.parquet$' --larger 1MB
+
+  12. Find objects between 1GB and 10GB of the infra team tagged as gold under "s3/data".
+      {{.Prompt}} {{.HelpName}} s3/data --larger-than 1GB --smaller-than 10GB --metadata "X-Amz-Meta-Team=infra" --tag "class=gold"
+
+  13. Find all objects archived to GLACIER under "s3/backups".
+      {{.Prompt}} {{.HelpName}} s3/backups --storage-class GLACIER
+
+  14. Remove all objects older than 30 days under "s3/logs", whatever characters their names contain.
+      {{.Prompt}} {{.HelpName}} s3/logs --older-than 30d --null | mc rm --force --files-from - --null
+
+  15. Preview, then remove all ".tmp" objects older than 7 days under "s3/scratch".
+      {{.Prompt}} {{.HelpName}} s3/scratch --name "*.tmp" --older-than 7d --delete --dry-run
+      {{.Prompt}} {{.HelpName}} s3/scratch --name "*.tmp" --older-than 7d --delete
+
+  16. Tag and move all objects larger than 1GB under "s3/media" to the STANDARD_IA storage class.
+      {{.Prompt}} {{.HelpName}} s3/media --larger 1GB --set-tags "tier=cold" --set-storage-class STANDARD_IA
+
+  17. Find all objects larger than 1GB under "s3/data", sending at most 20 requests per second.
+      {{.Prompt}} {{.HelpName}} s3/data --larger 1GB --max-rps 20
 `,
 }
 
@@ -194,13 +265,19 @@ type findContext struct {
 	namePattern   string
 	pathPattern   string
 	regexPattern  string
+	regex         *regexp.Regexp
 	maxDepth      uint
 	printFmt      string
 	olderThan     string
 	newerThan     string
 	largerSize    uint64
 	smallerSize   uint64
+	metadata      map[string]string
+	tags          map[string]string
+	storageClass  string
 	watch         bool
+	null          bool
+	actions       *findActions
 
 	// Internal values
 	targetAlias   string
@@ -214,9 +291,12 @@ func mainFind(cliCtx *cli.Context) error {
 	ctx, cancelFind := context.WithCancel(globalContext)
 	defer cancelFind()
 
+	setGlobalRateLimiter(cliCtx)
+
 	// Additional command specific theme customization.
 	console.SetColor("Find", color.New(color.FgGreen, color.Bold))
 	console.SetColor("FindExecErr", color.New(color.FgRed, color.Italic, color.Bold))
+	console.SetColor("FindAction", color.New(color.FgGreen))
 
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(cliCtx)
@@ -258,6 +338,44 @@ func mainFind(cliCtx *cli.Context) error {
 		fatalIf(probe.NewError(e).Trace(cliCtx.String("smaller")), "Unable to parse input bytes.")
 	}
 
+	if largerSize > 0 && smallerSize > 0 && smallerSize <= largerSize+1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("larger"), cliCtx.String("smaller")),
+			"No size can be larger than `%s` and smaller than `%s`.", cliCtx.String("larger"), cliCtx.String("smaller"))
+	}
+
+	metadata, err := parseFindKeyValues(cliCtx.StringSlice("metadata"))
+	fatalIf(err, "Unable to parse --metadata, expected KEY=VALUE.")
+	tags, err := parseFindKeyValues(cliCtx.StringSlice("tag"))
+	fatalIf(err, "Unable to parse --tag, expected KEY=VALUE.")
+	storageClass := strings.ToUpper(cliCtx.String("storage-class"))
+	if (len(metadata) > 0 || len(tags) > 0 || storageClass != "") && clnt.GetURL().Type != objectStorage {
+		fatalIf(errInvalidArgument().Trace(args[0]), "--metadata, --tag and --storage-class are only supported on object storage.")
+	}
+
+	if cliCtx.Bool("null") && globalJSON {
+		fatalIf(errInvalidArgument().Trace(), "--null cannot be used with --json.")
+	}
+
+	actions, err := newFindActions(cliCtx.Bool("delete"), cliCtx.String("set-tags"),
+		cliCtx.String("set-storage-class"), cliCtx.Bool("dry-run"))
+	fatalIf(err, "Unable to parse the find actions.")
+	if actions != nil {
+		if cliCtx.String("exec") != "" || cliCtx.String("print") != "" || cliCtx.Bool("watch") || cliCtx.Bool("null") {
+			fatalIf(errInvalidArgument().Trace(), "--delete, --set-tags and --set-storage-class cannot be used with --exec, --print, --watch or --null.")
+		}
+		if !actions.delete && clnt.GetURL().Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(args[0]), "--set-tags and --set-storage-class are only supported on object storage.")
+		}
+	} else if cliCtx.Bool("dry-run") {
+		fatalIf(errInvalidArgument().Trace(), "--dry-run needs --delete, --set-tags or --set-storage-class.")
+	}
+
+	var regex *regexp.Regexp
+	if cliCtx.String("regex") != "" {
+		regex, e = regexp.Compile(cliCtx.String("regex"))
+		fatalIf(probe.NewError(e).Trace(cliCtx.String("regex")), "Unable to parse the regular expression.")
+	}
+
 	targetAlias, _, hostCfg, err := expandAlias(args[0])
 	fatalIf(err.Trace(args[0]), "Unable to expand alias.")
 
@@ -274,12 +392,18 @@ func mainFind(cliCtx *cli.Context) error {
 		namePattern:   cliCtx.String("name"),
 		pathPattern:   cliCtx.String("path"),
 		regexPattern:  cliCtx.String("regex"),
+		regex:         regex,
 		ignorePattern: cliCtx.String("ignore"),
 		olderThan:     olderThan,
 		newerThan:     newerThan,
 		largerSize:    largerSize,
 		smallerSize:   smallerSize,
+		metadata:      metadata,
+		tags:          tags,
+		storageClass:  storageClass,
 		watch:         cliCtx.Bool("watch"),
+		null:          cliCtx.Bool("null"),
+		actions:       actions,
 		targetAlias:   targetAlias,
 		targetURL:     args[0],
 		targetFullURL: targetFullURL,
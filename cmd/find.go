@@ -99,6 +99,17 @@ func regexMatch(pattern, path string) bool {
 	return matched
 }
 
+// findObjectKey returns the key of an object within its bucket, or
+// the path of a file, which is matched by --regex.
+func findObjectKey(ctx *findContext, path string) string {
+	if ctx.targetAlias == "" {
+		return path
+	}
+	separator := string(ctx.clnt.GetURL().Separator)
+	path = strings.TrimPrefix(strings.TrimPrefix(path, ctx.targetAlias), separator)
+	return splitStr(path, separator, 2)[1]
+}
+
 func getExitStatus(err error) int {
 	if err == nil {
 		return 0
@@ -163,7 +174,7 @@ func watchFind(ctxCtx context.Context, ctx *findContext) {
 					continue
 				}
 
-				find(ctxCtx, ctx, contentMessage{
+				find(ctxCtx, ctx, event.Path, contentMessage{
 					Key:  getAliasedPath(ctx, event.Path),
 					Time: time,
 					Size: event.Size,
@@ -221,9 +232,9 @@ func getAliasedPath(ctx *findContext, path string) string {
 	return trimSuffixAtMaxDepth(ctx.targetURL, aliasedPath, separator, ctx.maxDepth)
 }
 
-func find(ctxCtx context.Context, ctx *findContext, fileContent contentMessage) {
+func find(ctxCtx context.Context, ctx *findContext, urlStr string, fileContent contentMessage) {
 	// Match the incoming content, didn't match return.
-	if !matchFind(ctx, fileContent) {
+	if !matchFind(ctx, fileContent) || !matchFindObject(ctxCtx, ctx, urlStr, nil) {
 		return
 	} // For all matching content
 
@@ -232,10 +243,7 @@ func find(ctxCtx context.Context, ctx *findContext, fileContent contentMessage)
 		execFind(stringsReplace(ctxCtx, ctx.execCmd, fileContent))
 		return
 	}
-	if ctx.printFmt != "" {
-		fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
-	}
-	printMsg(findMessage{fileContent})
+	printFind(ctxCtx, ctx, fileContent)
 }
 
 // doFind - find is main function body which interprets and executes
@@ -248,6 +256,10 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 	var prevKeyName string
 
+	if ctx.actions != nil {
+		ctx.actions.start(ctxCtx, ctx.targetAlias)
+	}
+
 	// iterate over all content which is within the given directory
 	for content := range ctx.clnt.List(globalContext, ListOptions{Recursive: true, ShowDir: DirFirst}) {
 		if content.Err != nil {
@@ -269,7 +281,8 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 			fatalIf(content.Err.Trace(ctx.clnt.GetURL().String()), "Unable to list folder.")
 			continue
 		}
-		if content.StorageClass == s3StorageClassGlacier {
+		// Archived objects cannot be read, they are only found on request.
+		if content.StorageClass == s3StorageClassGlacier && ctx.storageClass != s3StorageClassGlacier {
 			continue
 		}
 
@@ -285,18 +298,30 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 			continue
 		} // For all matching content
 
+		if !matchFindObject(ctxCtx, ctx, content.URL.String(), content) {
+			continue
+		}
+
 		prevKeyName = fileKeyName
 
+		// act on the match, folders are left untouched.
+		if ctx.actions != nil {
+			if !content.Type.IsDir() {
+				ctx.actions.apply(ctxCtx, content, fileKeyName)
+			}
+			continue
+		}
+
 		// proceed to either exec, format the output string.
 		if ctx.execCmd != "" {
 			execFind(stringsReplace(ctxCtx, ctx.execCmd, fileContent))
 			continue
 		}
-		if ctx.printFmt != "" {
-			fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
-		}
+		printFind(ctxCtx, ctx, fileContent)
+	}
 
-		printMsg(findMessage{fileContent})
+	if ctx.actions != nil {
+		return ctx.actions.finish()
 	}
 
 	// Success, notice watch will execute in defer only if enabled and this call
@@ -304,6 +329,18 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 	return nil
 }
 
+// printFind prints a match, formatted by --print if given.
+func printFind(ctxCtx context.Context, ctx *findContext, fileContent contentMessage) {
+	if ctx.printFmt != "" {
+		fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
+	}
+	if ctx.null {
+		printNullTerminated(fileContent.Key)
+		return
+	}
+	printMsg(findMessage{fileContent})
+}
+
 // stringsReplace - formats the string to remove {} and replace each
 // with the appropriate argument
 func stringsReplace(ctx context.Context, args string, fileContent contentMessage) string {
@@ -392,8 +429,8 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	if match && ctx.pathPattern != "" {
 		match = pathMatch(ctx.pathPattern, path)
 	}
-	if match && ctx.regexPattern != "" {
-		match = regexMatch(ctx.regexPattern, path)
+	if match && ctx.regex != nil {
+		match = ctx.regex.MatchString(findObjectKey(ctx, fileContent.Key))
 	}
 	if match && ctx.olderThan != "" {
 		match = !isOlder(fileContent.Time, ctx.olderThan)
@@ -410,6 +447,93 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	return match
 }
 
+// parseFindKeyValues parses the KEY=VALUE arguments of --metadata and --tag.
+func parseFindKeyValues(args []string) (map[string]string, *probe.Error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	kvs := make(map[string]string, len(args))
+	for _, arg := range args {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errInvalidArgument().Trace(arg)
+		}
+		kvs[kv[0]] = kv[1]
+	}
+	return kvs, nil
+}
+
+// findMetadataValue returns the value of a metadata key, user metadata
+// keys match with or without their "X-Amz-Meta-" prefix.
+func findMetadataValue(content *ClientContent, key string) (string, bool) {
+	for k, v := range content.Metadata {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	for k, v := range content.UserMetadata {
+		if strings.EqualFold(k, key) || strings.EqualFold("X-Amz-Meta-"+k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// matchFindObject matches the storage class, metadata and tags of an
+// object. They are not part of the listing, or of events, so they are
+// fetched only when requested and after all the other flags matched.
+func matchFindObject(ctxCtx context.Context, ctx *findContext, urlStr string, content *ClientContent) bool {
+	if ctx.storageClass == "" && len(ctx.metadata) == 0 && len(ctx.tags) == 0 {
+		return true
+	}
+	if content != nil && content.Type.IsDir() {
+		return false
+	}
+
+	clnt, err := newClientFromAlias(ctx.targetAlias, urlStr)
+	if err != nil {
+		errorIf(err.Trace(urlStr), "Unable to initialize `%s`.", urlStr)
+		return false
+	}
+	if content == nil || len(ctx.metadata) > 0 {
+		if content, err = clnt.Stat(ctxCtx, StatOptions{}); err != nil {
+			errorIf(err.Trace(urlStr), "Unable to stat `%s`.", urlStr)
+			return false
+		}
+	}
+
+	if ctx.storageClass != "" {
+		// Objects of the default storage class may not report it.
+		storageClass := content.StorageClass
+		if storageClass == "" {
+			storageClass = "STANDARD"
+		}
+		if !strings.EqualFold(storageClass, ctx.storageClass) {
+			return false
+		}
+	}
+	for key, pattern := range ctx.metadata {
+		value, ok := findMetadataValue(content, key)
+		if !ok || !wildcard.Match(pattern, value) {
+			return false
+		}
+	}
+	if len(ctx.tags) > 0 {
+		tags, err := clnt.GetTags(ctxCtx, content.VersionID)
+		if err != nil {
+			errorIf(err.Trace(urlStr), "Unable to get the tags of `%s`.", urlStr)
+			return false
+		}
+		for key, pattern := range ctx.tags {
+			value, ok := tags[key]
+			if !ok || !wildcard.Match(pattern, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // 7 days in seconds.
 var defaultSevenDays = time.Duration(604800) * time.Second
 
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"os/exec"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -53,6 +54,7 @@ func TestMatchFind(t *testing.T) {
 				targetURL: &ClientURL{},
 			},
 			regexPattern: `^(\d+\.){3}\d+$`,
+			regex:        regexp.MustCompile(`^(\d+\.){3}\d+$`),
 		},
 		{
 			clnt: &S3Client{
@@ -174,6 +176,63 @@ func TestMatchFind(t *testing.T) {
 	}
 }
 
+// Tests the object key matched by --regex.
+func TestFindObjectKey(t *testing.T) {
+	testCases := []struct {
+		targetAlias string
+		path        string
+		expectedKey string
+	}{
+		{"", "/var/lib/data/2021/05/12/a.parquet", "/var/lib/data/2021/05/12/a.parquet"},
+		{"s3", "s3/lake/logs/2021/05/12/a.parquet", "logs/2021/05/12/a.parquet"},
+		{"s3", "s3/lake/a.parquet", "a.parquet"},
+		{"s3", "s3/lake", ""},
+	}
+	for i, testCase := range testCases {
+		ctx := &findContext{
+			clnt:        &S3Client{targetURL: &ClientURL{Separator: '/'}},
+			targetAlias: testCase.targetAlias,
+		}
+		if key := findObjectKey(ctx, testCase.path); key != testCase.expectedKey {
+			t.Errorf("Test %d: expected key %s, got %s", i+1, testCase.expectedKey, key)
+		}
+	}
+}
+
+// Tests metadata lookup of --metadata.
+func TestFindMetadataValue(t *testing.T) {
+	content := &ClientContent{
+		Metadata:     map[string]string{"Content-Type": "image/png", "X-Amz-Meta-Team": "infra"},
+		UserMetadata: map[string]string{"Team": "infra"},
+	}
+	testCases := []struct {
+		key           string
+		expectedValue string
+		expectedOk    bool
+	}{
+		{"Content-Type", "image/png", true},
+		{"content-type", "image/png", true},
+		{"X-Amz-Meta-Team", "infra", true},
+		{"x-amz-meta-team", "infra", true},
+		{"Team", "infra", true},
+		{"Owner", "", false},
+	}
+	for i, testCase := range testCases {
+		value, ok := findMetadataValue(content, testCase.key)
+		if value != testCase.expectedValue || ok != testCase.expectedOk {
+			t.Errorf("Test %d: expected %s %v, got %s %v", i+1, testCase.expectedValue, testCase.expectedOk, value, ok)
+		}
+	}
+
+	if _, err := parseFindKeyValues([]string{"class=gold", "novalue"}); err == nil {
+		t.Errorf("Expected an error for an argument without value")
+	}
+	kvs, err := parseFindKeyValues([]string{"class=gold", "owner="})
+	if err != nil || kvs["class"] != "gold" || kvs["owner"] != "" || len(kvs) != 2 {
+		t.Errorf("Unexpected key values %v, %v", kvs, err)
+	}
+}
+
 // Tests suffix strings trimmed off correctly at maxdepth.
 func TestSuffixTrimmingAtMaxDepth(t *testing.T) {
 	var testCases = []struct {
@@ -453,3 +512,37 @@ func TestGetExitStatus(t *testing.T) {
 		}
 	}
 }
+
+func TestNewFindActions(t *testing.T) {
+	testCases := []struct {
+		isDelete     bool
+		tags         string
+		storageClass string
+		expectNil    bool
+		expectErr    bool
+	}{
+		{expectNil: true},
+		{isDelete: true},
+		{tags: "a=b&c=d"},
+		{storageClass: "standard_ia"},
+		{tags: "a=b", storageClass: "GLACIER"},
+		{isDelete: true, tags: "a=b", expectErr: true},
+		{isDelete: true, storageClass: "GLACIER", expectErr: true},
+		{tags: "=b", expectErr: true},
+	}
+	for i, testCase := range testCases {
+		actions, err := newFindActions(testCase.isDelete, testCase.tags, testCase.storageClass, false)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if testCase.expectNil != (actions == nil) {
+			t.Fatalf("Test %d: expected nil actions %v, got %v", i+1, testCase.expectNil, actions)
+		}
+		if actions != nil && actions.storageClass != strings.ToUpper(testCase.storageClass) {
+			t.Fatalf("Test %d: expected storage class %s, got %s", i+1, strings.ToUpper(testCase.storageClass), actions.storageClass)
+		}
+	}
+}
@@ -20,6 +20,24 @@ import (
 	"github.com/minio/cli"
 )
 
+// globalFormatFlag formats the output of all commands with a template,
+// commands may define a --format flag of their own instead.
+var globalFormatFlag = cli.StringFlag{
+	Name:  "format",
+	Usage: "format output using a Go template, e.g. '{{.AccessKey}}\\t{{.Status}}'",
+}
+
+// withoutFlag returns the flags but the one of the given name.
+func withoutFlag(flags []cli.Flag, name string) []cli.Flag {
+	var filtered []cli.Flag
+	for _, f := range flags {
+		if f.GetName() != name {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
 // Collection of mc flags currently supported
 var globalFlags = []cli.Flag{
 	cli.StringFlag{
@@ -27,6 +45,11 @@ var globalFlags = []cli.Flag{
 		Value: mustGetMcConfigDir(),
 		Usage: "path to configuration folder",
 	},
+	cli.StringFlag{
+		Name:   "profile",
+		Usage:  "configuration profile to use, from the 'profiles' folder of the configuration folder",
+		EnvVar: mcEnvProfile,
+	},
 	cli.BoolFlag{
 		Name:  "quiet, q",
 		Usage: "disable progress bar display",
@@ -35,10 +58,29 @@ var globalFlags = []cli.Flag{
 		Name:  "no-color",
 		Usage: "disable color theme",
 	},
+	cli.StringFlag{
+		Name:   "theme",
+		Usage:  "color theme, one of 'default', 'colorblind' or 'monochrome'",
+		EnvVar: mcEnvTheme,
+	},
 	cli.BoolFlag{
 		Name:  "json",
 		Usage: "enable JSON lines formatted output",
 	},
+	cli.StringFlag{
+		Name:  "output",
+		Usage: "output format, one of 'json', 'yaml', 'csv' or 'table'",
+	},
+	globalFormatFlag,
+	cli.StringFlag{
+		Name:  "log-file",
+		Usage: "write JSON logs of requests, retries, errors and summaries to a rotating file",
+	},
+	cli.StringFlag{
+		Name:  "log-level",
+		Usage: "minimum level of the file logs, one of 'debug', 'info', 'warn' or 'error'",
+		Value: "info",
+	},
 	cli.BoolFlag{
 		Name:  "debug",
 		Usage: "enable debug output",
@@ -47,6 +89,46 @@ var globalFlags = []cli.Flag{
 		Name:  "insecure",
 		Usage: "disable SSL certificate verification",
 	},
+	cli.StringFlag{
+		Name:  "request-payer",
+		Usage: "set to 'requester' to read requester pays buckets, at the expense of the account of the alias",
+	},
+	cli.DurationFlag{
+		Name:  "request-timeout",
+		Usage: "fail a request sending or receiving no data for this duration, e.g. '30s'",
+	},
+	cli.DurationFlag{
+		Name:  "operation-deadline",
+		Usage: "fail the command when not complete within this duration, e.g. '1h'",
+	},
+	cli.IntFlag{
+		Name:  "max-conns-per-host",
+		Usage: "maximum number of connections per host, no limit by default",
+	},
+	cli.IntFlag{
+		Name:  "max-idle-conns-per-host",
+		Usage: "maximum number of idle connections kept per host (default: 256)",
+	},
+	cli.StringFlag{
+		Name:  "tcp-buffer",
+		Usage: "size of the TCP send and receive buffers of the connections, e.g. '16MiB'",
+	},
+	cli.StringFlag{
+		Name:  "http2",
+		Usage: "set to 'on' to use HTTP/2 with TLS servers. Valid options are '[on, off]'",
+	},
+	cli.StringFlag{
+		Name:  "part-size",
+		Usage: "size of the parts of multipart uploads, e.g. '64MiB', between 5MiB and 5GiB (default: 16MiB)",
+	},
+	cli.StringFlag{
+		Name:  "multipart-threshold",
+		Usage: "upload objects smaller than this size with a single request, e.g. '128MiB', at least the part size (default: the part size)",
+	},
+	cli.IntFlag{
+		Name:  "list-workers",
+		Usage: "number of prefixes listed in parallel by recursive listings, 1 to list sequentially (default: 8)",
+	},
 }
 
 // Flags common across all I/O commands such as cp, mirror, stat, pipe etc.
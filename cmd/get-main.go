@@ -0,0 +1,228 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var getFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "version-id, vid",
+		Usage: "download a specific version of the object",
+	},
+}
+
+var getCmd = cli.Command{
+	Name:         "get",
+	Usage:        "download an object to a file",
+	Action:       mainGet,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(getFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS/BUCKET/KEY [FILE|-]
+
+  Download one object. FILE defaults to the last element of KEY in the
+  current folder, '-' writes the object to the standard output. An existing
+  FILE is replaced, use 'mc cp' to copy folders or many objects.
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+  1. Download an object to the file 'report.pdf' in the current folder.
+     {{.Prompt}} {{.HelpName}} play/mybucket/2021/report.pdf
+
+  2. Download an object to a file with another name.
+     {{.Prompt}} {{.HelpName}} play/mybucket/2021/report.pdf /tmp/report-2021.pdf
+
+  3. Write an object to the standard output.
+     {{.Prompt}} {{.HelpName}} play/mybucket/data.csv.gz - | gunzip
+
+  4. Download a previous version of an object.
+     {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/mybucket/config.json
+`,
+}
+
+// getMessage container for object download messages.
+type getMessage struct {
+	Status    string `json:"status"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Size      int64  `json:"size"`
+	VersionID string `json:"versionId,omitempty"`
+}
+
+// String colorized get message.
+func (g getMessage) String() string {
+	return console.Colorize("Get", fmt.Sprintf("`%s` -> `%s`", g.Source, g.Target))
+}
+
+// JSON jsonified get message.
+func (g getMessage) JSON() string {
+	g.Status = "success"
+	getMessageBytes, e := json.MarshalIndent(g, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(getMessageBytes)
+}
+
+// checkObjectURL exits unless aliasedURL names an object as
+// ALIAS/BUCKET/KEY, get and put never guess what a path means.
+func checkObjectURL(aliasedURL string) {
+	_, _, aliasCfg, err := expandAlias(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to parse `"+aliasedURL+"`.")
+	alias, bucketKey := url2Alias(aliasedURL)
+	if aliasCfg == nil {
+		fatalIf(errInvalidArgument().Trace(aliasedURL),
+			"`"+alias+"` is not an alias, expected ALIAS/BUCKET/KEY. Add the alias with `mc alias set`.")
+	}
+	parts := strings.SplitN(filepath.ToSlash(bucketKey), "/", 2)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" || strings.HasSuffix(parts[1], "/") {
+		fatalIf(errInvalidArgument().Trace(aliasedURL),
+			"`"+aliasedURL+"` is not an object, expected ALIAS/BUCKET/KEY. Use `mc cp --recursive` for buckets and folders.")
+	}
+}
+
+// checkGetSyntax - validate arguments passed by a user.
+func checkGetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) < 1 || len(ctx.Args()) > 2 {
+		cli.ShowCommandHelpAndExit(ctx, "get", globalInvalidArgsExitStatus)
+	}
+	checkObjectURL(ctx.Args().Get(0))
+
+	if len(ctx.Args()) == 2 {
+		target := ctx.Args().Get(1)
+		if target == "" || strings.HasSuffix(target, "/") || strings.HasSuffix(target, string(filepath.Separator)) {
+			fatalIf(errInvalidArgument().Trace(target), "`"+target+"` is not a file name.")
+		}
+	}
+}
+
+// getTargetFile returns the file an object is downloaded to, the
+// last element of its key unless a target is given.
+func getTargetFile(sourceURL, target string) string {
+	if target != "" {
+		return target
+	}
+	return path.Base(filepath.ToSlash(sourceURL))
+}
+
+// writeObjectFile writes reader to a temporary file next to target and
+// renames it to target once complete, a failed download leaves no
+// partial file behind.
+func writeObjectFile(reader io.Reader, target string) (int64, *probe.Error) {
+	if st, e := os.Stat(target); e == nil && st.IsDir() {
+		return 0, probe.NewError(fmt.Errorf("`%s` is a folder, give the name of the file to write", target))
+	}
+	tmp, e := ioutil.TempFile(filepath.Dir(target), "."+filepath.Base(target)+".mc-get-")
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	n, e := io.Copy(tmp, reader)
+	if e == nil {
+		e = tmp.Chmod(0644)
+	}
+	if ce := tmp.Close(); e == nil {
+		e = ce
+	}
+	if e == nil {
+		e = os.Rename(tmp.Name(), target)
+	}
+	if e != nil {
+		os.Remove(tmp.Name())
+		return n, probe.NewError(e)
+	}
+	return n, nil
+}
+
+// mainGet is the entry point for get command.
+func mainGet(cliCtx *cli.Context) error {
+	ctx, cancelGet := context.WithCancel(globalContext)
+	defer cancelGet()
+
+	checkGetSyntax(cliCtx)
+	console.SetColor("Get", color.New(color.FgGreen, color.Bold))
+
+	encKeyDB, err := getEncKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	sourceURL := cliCtx.Args().Get(0)
+	target := getTargetFile(sourceURL, cliCtx.Args().Get(1))
+	versionID := cliCtx.String("version-id")
+
+	_, content, err := url2Stat(ctx, sourceURL, versionID, false, encKeyDB, time.Time{})
+	fatalIf(err.Trace(sourceURL), "Unable to get `"+sourceURL+"`.")
+	if content.Type.IsDir() {
+		fatalIf(errInvalidArgument().Trace(sourceURL),
+			"`"+sourceURL+"` is a folder, not an object. Use `mc cp --recursive` to download folders.")
+	}
+
+	reader, err := getSourceStreamFromURL(ctx, sourceURL, content.VersionID, encKeyDB)
+	fatalIf(err.Trace(sourceURL), "Unable to get `"+sourceURL+"`.")
+	defer reader.Close()
+
+	if target == "-" {
+		_, e := io.Copy(os.Stdout, reader)
+		fatalIf(probe.NewError(e).Trace(sourceURL), "Unable to write `"+sourceURL+"` to the standard output.")
+		return nil
+	}
+
+	var pg *progressBar
+	var source io.Reader = reader
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(content.Size)
+		pg.SetCaption(sourceURL + ": ")
+		source = io.TeeReader(reader, pg)
+	}
+	n, err := writeObjectFile(source, target)
+	if pg != nil {
+		pg.Finish()
+	}
+	fatalIf(err.Trace(sourceURL, target), "Unable to download `"+sourceURL+"` to `"+target+"`.")
+
+	if pg == nil {
+		printMsg(getMessage{
+			Source:    sourceURL,
+			Target:    target,
+			Size:      n,
+			VersionID: content.VersionID,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetTargetFile(t *testing.T) {
+	testCases := []struct {
+		sourceURL, target, expected string
+	}{
+		{"play/mybucket/report.pdf", "", "report.pdf"},
+		{"play/mybucket/2021/q1/report.pdf", "", "report.pdf"},
+		{"play/mybucket/report.pdf", "/tmp/r.pdf", "/tmp/r.pdf"},
+		{"play/mybucket/report.pdf", "-", "-"},
+	}
+	for i, testCase := range testCases {
+		if target := getTargetFile(testCase.sourceURL, testCase.target); target != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, target)
+		}
+	}
+}
+
+func TestWriteObjectFile(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-get-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, "object")
+	for _, content := range []string{"first", "second"} {
+		n, err := writeObjectFile(strings.NewReader(content), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, e := ioutil.ReadFile(target)
+		if e != nil || string(data) != content || n != int64(len(content)) {
+			t.Fatalf("expected %q, got %q, %d (%v)", content, data, n, e)
+		}
+	}
+	if _, err := writeObjectFile(strings.NewReader("x"), dir); err == nil {
+		t.Fatal("expected an error writing to a folder")
+	}
+	if _, err := writeObjectFile(strings.NewReader("x"), filepath.Join(dir, "missing", "object")); err == nil {
+		t.Fatal("expected an error writing to a missing folder")
+	}
+	if entries, _ := ioutil.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("expected only the object in %s, got %d files", dir, len(entries))
+	}
+}
+
+func TestOpenPutSource(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-put-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+	if e = ioutil.WriteFile(file, []byte("hello"), 0644); e != nil {
+		t.Fatal(e)
+	}
+	f, size, err := openPutSource(file)
+	if err != nil || size != 5 {
+		t.Fatalf("expected size 5, got %d (%v)", size, err)
+	}
+	f.Close()
+	if f, size, err = openPutSource("-"); err != nil || f != os.Stdin || size != -1 {
+		t.Fatalf("expected stdin of unknown size, got %d (%v)", size, err)
+	}
+	if _, _, err = openPutSource(dir); err == nil {
+		t.Fatal("expected an error opening a folder")
+	}
+}
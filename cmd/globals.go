@@ -20,17 +20,21 @@ package cmd
 import (
 	"context"
 	"crypto/x509"
+	"text/template"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
 )
 
 const (
 	globalMCConfigVersion = "10"
 
-	globalMCConfigFile = "config.json"
-	globalMCCertsDir   = "certs"
-	globalMCCAsDir     = "CAs"
+	globalMCConfigFile  = mcconfig.ConfigFile
+	globalMCProfilesDir = mcconfig.ProfilesDir
+	globalMCCertsDir    = "certs"
+	globalMCCAsDir      = "CAs"
 
 	// session config and shared urls related constants
 	globalSessionDir           = "session"
@@ -40,9 +44,32 @@ const (
 	// Profile directory for dumping profiler outputs.
 	globalProfileDir = "profile"
 
-	// Global error exit status.
+	// Global error exit status, used for failures which
+	// do not fall in any of the classes below.
 	globalErrorExitStatus = 1
 
+	// Invalid arguments or command line usage exit status.
+	globalInvalidArgsExitStatus = 2
+
+	// Unable to connect to the server exit status.
+	globalConnectionExitStatus = 3
+
+	// Access denied or invalid credentials exit status.
+	globalAccessDeniedExitStatus = 4
+
+	// Bucket, object, path or admin resource not found exit status.
+	globalNotFoundExitStatus = 5
+
+	// Some, but not all, of the objects were transferred exit status.
+	globalPartialExitStatus = 6
+
+	// Bucket quota exceeded or storage full exit status.
+	globalQuotaExceededExitStatus = 7
+
+	// Cluster read-only, or a node cannot be taken down without losing
+	// quorum, exit status.
+	globalDegradedExitStatus = 8
+
 	// Global CTRL-C (SIGINT, #2) exit status.
 	globalCancelExitStatus = 130
 
@@ -59,6 +86,10 @@ var (
 	globalDebug    = false // Debug flag set via command line
 	globalNoColor  = false // No Color flag set via command line
 	globalInsecure = false // Insecure flag set via command line
+	globalOutput   = ""    // Output format set via command line
+
+	// Go template set via --format, applied to every printed message.
+	globalFormat *template.Template
 
 	globalContext, globalCancel = context.WithCancel(context.Background())
 )
@@ -87,11 +118,118 @@ func setGlobals(quiet, debug, json, noColor, insecure bool) {
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
 func setGlobalsFromContext(ctx *cli.Context) error {
-	quiet := ctx.IsSet("quiet") || ctx.GlobalIsSet("quiet")
-	debug := ctx.IsSet("debug") || ctx.GlobalIsSet("debug")
-	json := ctx.IsSet("json") || ctx.GlobalIsSet("json")
-	noColor := ctx.IsSet("no-color") || ctx.GlobalIsSet("no-color")
-	insecure := ctx.IsSet("insecure") || ctx.GlobalIsSet("insecure")
+	// The profile may also be given after the command name.
+	if profile := ctx.String("profile"); profile != "" && profile != getMcProfile() {
+		setMcProfile(profile)
+		checkConfig()
+	}
+
+	// Flags not given on the command line take their configured defaults.
+	applyConfigDefaults(ctx)
+
+	quiet := ctx.Bool("quiet") || ctx.GlobalBool("quiet")
+	debug := ctx.Bool("debug") || ctx.GlobalBool("debug")
+	json := ctx.Bool("json") || ctx.GlobalBool("json")
+	noColor := ctx.Bool("no-color") || ctx.GlobalBool("no-color") || isNoColorEnv()
+	insecure := ctx.Bool("insecure") || ctx.GlobalBool("insecure")
+
+	theme := ctx.String("theme")
+	if theme == "" {
+		theme = ctx.GlobalString("theme")
+	}
+	setTheme(theme)
+
+	output := ctx.String("output")
+	if output == "" {
+		output = ctx.GlobalString("output")
+	}
+	if e := checkOutputFormat(output); e != nil {
+		fatalIf(probe.NewError(e), "Invalid value for --output.")
+	}
+	if output != "" {
+		globalOutput = output
+	}
+	// Only the global --format flag is a template, 'alias export' has
+	// a --format flag of its own.
+	var format string
+	if f := lookupFlag(ctx.Command.Flags, "format"); f == nil || f == cli.Flag(globalFormatFlag) {
+		format = ctx.String("format")
+	}
+	if format == "" {
+		format = ctx.GlobalString("format")
+	}
+	if format != "" {
+		tmpl, e := parseOutputTemplate(format)
+		fatalIf(probe.NewError(e), "Invalid value for --format.")
+		globalFormat = tmpl
+		// Templates are meant for scripts, take the machine readable code paths.
+		json = true
+	}
+
+	logFile := ctx.String("log-file")
+	if logFile == "" {
+		logFile = ctx.GlobalString("log-file")
+	}
+	if logFile != "" && globalLogger == nil {
+		logLevel := ctx.String("log-level")
+		if !ctx.IsSet("log-level") && ctx.GlobalIsSet("log-level") {
+			logLevel = ctx.GlobalString("log-level")
+		}
+		logger, err := newFileLogger(logFile, logLevel)
+		fatalIf(err, "Unable to open log file `%s`.", logFile)
+		globalLogger = logger
+	}
+
+	requestPayer := ctx.String("request-payer")
+	if requestPayer == "" {
+		requestPayer = ctx.GlobalString("request-payer")
+	}
+	if !isValidRequestPayer(requestPayer) {
+		fatalIf(errInvalidArgument().Trace(requestPayer), "Invalid value for --request-payer, only 'requester' is supported.")
+	}
+	if requestPayer != "" {
+		globalRequestPayer = requestPayer
+	}
+
+	requestTimeout := ctx.Duration("request-timeout")
+	if !ctx.IsSet("request-timeout") && ctx.GlobalIsSet("request-timeout") {
+		requestTimeout = ctx.GlobalDuration("request-timeout")
+	}
+	if requestTimeout < 0 {
+		fatalIf(errInvalidArgument(), "Invalid value for --request-timeout, must be positive.")
+	}
+	if requestTimeout > 0 {
+		globalRequestTimeout = requestTimeout
+	}
+	deadline := ctx.Duration("operation-deadline")
+	if !ctx.IsSet("operation-deadline") && ctx.GlobalIsSet("operation-deadline") {
+		deadline = ctx.GlobalDuration("operation-deadline")
+	}
+	if deadline < 0 {
+		fatalIf(errInvalidArgument(), "Invalid value for --operation-deadline, must be positive.")
+	}
+	setOperationDeadline(deadline)
+	listWorkers := ctx.Int("list-workers")
+	if !ctx.IsSet("list-workers") && ctx.GlobalIsSet("list-workers") {
+		listWorkers = ctx.GlobalInt("list-workers")
+	}
+	if listWorkers < 0 {
+		fatalIf(errInvalidArgument(), "Invalid value for --list-workers, must be positive.")
+	}
+	if listWorkers > 0 {
+		globalListWorkers = listWorkers
+	}
+	setTransportTuningFromContext(ctx)
+	setMultipartSizesFromContext(ctx)
+
+	// Structured formats are rendered from the JSON form of the messages.
+	switch globalOutput {
+	case outputJSON, outputYAML, outputCSV:
+		json = true
+	case outputTable:
+		json = false
+		globalJSON = false
+	}
 	setGlobals(quiet, debug, json, noColor, insecure)
 	return nil
 }
@@ -0,0 +1,312 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var grepFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "name",
+		Usage: "only search the objects whose name matches the wildcard pattern",
+	},
+	cli.BoolFlag{
+		Name:  "ignore-case, i",
+		Usage: "match the pattern ignoring case",
+	},
+	cli.BoolFlag{
+		Name:  "regexp, E",
+		Usage: "the pattern is a regular expression, matched locally on the lines selected by its literal prefix",
+	},
+	cli.BoolFlag{
+		Name:  "files-with-matches, l",
+		Usage: "only print the names of the objects with matching lines",
+	},
+	cli.IntFlag{
+		Name:  "parallel",
+		Usage: "number of objects searched concurrently",
+		Value: 4,
+	},
+}
+
+// Search object contents on the server.
+var grepCmd = cli.Command{
+	Name:         "grep",
+	Usage:        "search the lines of objects matching a pattern using S3 Select",
+	Action:       mainGrep,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(grepFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET PATTERN
+
+  The lines of all the objects under TARGET are filtered by the server with
+  S3 Select, only the matching lines are downloaded. Objects compressed with
+  gzip or bzip2, named '*.gz' or '*.bz2', are searched uncompressed. The exit
+  status is 1 when no line matches.
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+  1. Search the lines containing 'connection refused' in the logs of a day.
+     {{.Prompt}} {{.HelpName}} --name '*.log' myminio/logs/2021-03-01/ 'connection refused'
+
+  2. Search the requests answered with a 5xx status code, ignoring case.
+     {{.Prompt}} {{.HelpName}} -i -E myminio/logs/nginx/ 'status=5[0-9][0-9]'
+
+  3. List the objects mentioning an order number.
+     {{.Prompt}} {{.HelpName}} -l --parallel 16 myminio/archive/ 'ORD-4711'
+`,
+}
+
+// grepMessage container for a matching line of an object.
+type grepMessage struct {
+	Status string `json:"status"`
+	Key    string `json:"key"`
+	Line   string `json:"line,omitempty"`
+
+	matches [][]int
+}
+
+// String colorized grep message, the matches of the line are highlighted.
+func (g grepMessage) String() string {
+	if g.Line == "" {
+		return console.Colorize("GrepKey", g.Key)
+	}
+	var b strings.Builder
+	b.WriteString(console.Colorize("GrepKey", g.Key) + ":")
+	prev := 0
+	for _, m := range g.matches {
+		b.WriteString(g.Line[prev:m[0]])
+		b.WriteString(console.Colorize("GrepMatch", g.Line[m[0]:m[1]]))
+		prev = m[1]
+	}
+	b.WriteString(g.Line[prev:])
+	return b.String()
+}
+
+// JSON jsonified grep message.
+func (g grepMessage) JSON() string {
+	g.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(g, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// grepLinesOpts reads objects as CSV of a single column, one record per
+// line: the delimiter, quote and comment characters are control characters
+// which are not expected in text.
+var grepLinesOpts = SelectObjectOpts{
+	InputSerOpts: map[string]map[string]string{
+		"csv": {
+			recordDelimiterType: "\n",
+			fieldDelimiterType:  "\x1f",
+			quoteCharacterType:  "\x1e",
+			commentCharType:     "\x1d",
+			fileHeaderType:      "NONE",
+		},
+	},
+	OutputSerOpts: map[string]map[string]string{
+		"json": {recordDelimiterType: "\n"},
+	},
+}
+
+// grepPattern compiles the pattern matched locally and returns the
+// literal the lines are selected by, empty if all lines must be.
+func grepPattern(pattern string, isRegexp, ignoreCase bool) (*regexp.Regexp, string, error) {
+	literal := pattern
+	if isRegexp {
+		re, e := regexp.Compile(pattern)
+		if e != nil {
+			return nil, "", e
+		}
+		literal, _ = re.LiteralPrefix()
+	} else {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+		literal = strings.ToLower(literal)
+	}
+	re, e := regexp.Compile(pattern)
+	return re, literal, e
+}
+
+// grepExpression returns the S3 Select expression of the lines containing
+// the literal, lowered when ignoring case.
+func grepExpression(literal string, ignoreCase bool) string {
+	if literal == "" {
+		return "SELECT s._1 FROM S3Object s"
+	}
+	column := "s._1"
+	if ignoreCase {
+		column = "LOWER(s._1)"
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `'`, `''`).Replace(literal)
+	return fmt.Sprintf(`SELECT s._1 FROM S3Object s WHERE %s LIKE '%%%s%%' ESCAPE '\'`, column, escaped)
+}
+
+// grepLines prints the lines of the selected records matching the pattern,
+// only the key of the object is printed if keyOnly is set. It returns the
+// number of matching lines.
+func grepLines(r io.Reader, key string, re *regexp.Regexp, keyOnly bool) (int, error) {
+	dec := json.NewDecoder(r)
+	found := 0
+	for {
+		var record struct {
+			Line string `json:"_1"`
+		}
+		if e := dec.Decode(&record); e != nil {
+			if e == io.EOF {
+				return found, nil
+			}
+			return found, e
+		}
+		line := strings.TrimSuffix(record.Line, "\r")
+		matches := re.FindAllStringIndex(line, -1)
+		if matches == nil {
+			continue
+		}
+		found++
+		if keyOnly {
+			printMsg(grepMessage{Key: key})
+			return found, nil
+		}
+		printMsg(grepMessage{Key: key, Line: line, matches: matches})
+	}
+}
+
+// grepObject searches the lines of an object.
+func grepObject(ctx context.Context, url, expression string, re *regexp.Regexp, keyOnly bool, encKeyDB map[string][]prefixSSEPair) (int, *probe.Error) {
+	ctx, cancelSelect := context.WithCancel(ctx)
+	defer cancelSelect()
+
+	alias, _, _, err := expandAlias(url)
+	if err != nil {
+		return 0, err.Trace(url)
+	}
+	clnt, err := newClient(url)
+	if err != nil {
+		return 0, err.Trace(url)
+	}
+	reader, err := clnt.Select(ctx, expression, getSSE(url, encKeyDB[alias]), grepLinesOpts)
+	if err != nil {
+		return 0, err.Trace(url, expression)
+	}
+	defer reader.Close()
+
+	found, e := grepLines(reader, url, re, keyOnly)
+	return found, probe.NewError(e)
+}
+
+// checkGrepSyntax - validate all the passed arguments
+func checkGrepSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 || ctx.Args().Get(1) == "" {
+		cli.ShowCommandHelpAndExit(ctx, "grep", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	if ctx.Int("parallel") < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("parallel")), "--parallel must be at least 1.")
+	}
+}
+
+// mainGrep is the main entry point for grep command.
+func mainGrep(cliCtx *cli.Context) error {
+	ctx, cancelGrep := context.WithCancel(globalContext)
+	defer cancelGrep()
+
+	checkGrepSyntax(cliCtx)
+
+	console.SetColor("GrepKey", color.New(color.FgMagenta))
+	console.SetColor("GrepMatch", color.New(color.FgRed, color.Bold))
+
+	encKeyDB, err := getEncKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	targetURL := cliCtx.Args().Get(0)
+	re, literal, e := grepPattern(cliCtx.Args().Get(1), cliCtx.Bool("regexp"), cliCtx.Bool("ignore-case"))
+	fatalIf(probe.NewError(e).Trace(cliCtx.Args().Get(1)), "Unable to parse the pattern.")
+	expression := grepExpression(literal, cliCtx.Bool("ignore-case"))
+	keyOnly := cliCtx.Bool("files-with-matches")
+	namePattern := cliCtx.String("name")
+
+	var found, failed int64
+	objectsCh := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < cliCtx.Int("parallel"); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range objectsCh {
+				n, err := grepObject(ctx, url, expression, re, keyOnly, encKeyDB)
+				atomic.AddInt64(&found, int64(n))
+				if err != nil {
+					atomic.AddInt64(&failed, 1)
+					errorIf(err.Trace(url), "Unable to search `"+url+"`.")
+				}
+			}
+		}()
+	}
+
+	if _, content, err := url2Stat(ctx, targetURL, "", false, encKeyDB, time.Time{}); err == nil && !content.Type.IsDir() {
+		objectsCh <- targetURL
+	} else {
+		targetAlias, url, _ := mustExpandAlias(targetURL)
+		clnt, err := newClientFromAlias(targetAlias, url)
+		fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+
+		for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				atomic.AddInt64(&failed, 1)
+				errorIf(content.Err.Trace(targetURL), "Unable to list on target `"+targetURL+"`.")
+				continue
+			}
+			if content.Size == 0 || (namePattern != "" && !nameMatch(namePattern, content.URL.Path)) {
+				continue
+			}
+			objectsCh <- targetAlias + content.URL.Path
+		}
+	}
+	close(objectsCh)
+	wg.Wait()
+
+	if failed > 0 || found == 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestGrepPattern(t *testing.T) {
+	testCases := []struct {
+		pattern    string
+		isRegexp   bool
+		ignoreCase bool
+		literal    string
+		line       string
+		match      bool
+	}{
+		{"100%", false, false, "100%", "ok 100%", true},
+		{"a.c", false, false, "a.c", "abc", false},
+		{"Refused", false, true, "refused", "connection REFUSED", true},
+		{"status=5[0-9]+", true, false, "status=5", "status=503", true},
+		{"status=5[0-9]+", true, false, "status=5", "status=5xx", false},
+		{"(GET|PUT) /", true, true, "", "get /bucket", true},
+	}
+	for i, tc := range testCases {
+		re, literal, e := grepPattern(tc.pattern, tc.isRegexp, tc.ignoreCase)
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, e)
+		}
+		if literal != tc.literal {
+			t.Errorf("Test %d: expected literal %q, got %q", i+1, tc.literal, literal)
+		}
+		if match := re.MatchString(tc.line); match != tc.match {
+			t.Errorf("Test %d: expected match %v, got %v", i+1, tc.match, match)
+		}
+	}
+	if _, _, e := grepPattern("(", true, false); e == nil {
+		t.Error("expected an error for an invalid regular expression")
+	}
+}
+
+func TestGrepExpression(t *testing.T) {
+	testCases := []struct {
+		literal    string
+		ignoreCase bool
+		expression string
+	}{
+		{"", false, `SELECT s._1 FROM S3Object s`},
+		{"refused", false, `SELECT s._1 FROM S3Object s WHERE s._1 LIKE '%refused%' ESCAPE '\'`},
+		{"refused", true, `SELECT s._1 FROM S3Object s WHERE LOWER(s._1) LIKE '%refused%' ESCAPE '\'`},
+		{`it's 100%_\`, false, `SELECT s._1 FROM S3Object s WHERE s._1 LIKE '%it''s 100\%\_\\%' ESCAPE '\'`},
+	}
+	for i, tc := range testCases {
+		if expression := grepExpression(tc.literal, tc.ignoreCase); expression != tc.expression {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expression, expression)
+		}
+	}
+}
@@ -127,7 +127,7 @@ func (i ilmAddMessage) JSON() string {
 // Validate user given arguments
 func checkILMAddSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "add", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "add", globalInvalidArgsExitStatus)
 	}
 }
 
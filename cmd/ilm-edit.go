@@ -87,7 +87,7 @@ func (i ilmEditMessage) JSON() string {
 // Validate user given arguments
 func checkILMEditSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "edit", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "edit", globalInvalidArgsExitStatus)
 	}
 	id := ctx.String("id")
 	if id == "" {
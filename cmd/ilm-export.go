@@ -74,7 +74,7 @@ func (i ilmExportMessage) JSON() string {
 // checkILMExportSyntax - validate arguments passed by user
 func checkILMExportSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "export", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "export", globalInvalidArgsExitStatus)
 	}
 }
 
@@ -84,7 +84,7 @@ func readILMConfig() (*lifecycle.Configuration, *probe.Error) {
 // checkILMImportSyntax - validate arguments passed by user
 func checkILMImportSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "import", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "import", globalInvalidArgsExitStatus)
 	}
 }
 
@@ -160,7 +160,7 @@ func validateILMListFlagSet(ctx *cli.Context) bool {
 // checkILMListSyntax - validate arguments passed by a user
 func checkILMListSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "ls", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "ls", globalInvalidArgsExitStatus)
 	}
 
 	if !validateILMListFlagSet(ctx) {
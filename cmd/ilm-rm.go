@@ -94,7 +94,7 @@ func (i ilmRmMessage) JSON() string {
 
 func checkILMRemoveSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "rm", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "rm", globalInvalidArgsExitStatus)
 	}
 
 	ilmAll := ctx.Bool("all")
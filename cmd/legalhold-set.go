@@ -174,7 +174,7 @@ func setLegalHold(ctx context.Context, urlStr, versionID string, timeRef time.Ti
 func parseLegalHoldArgs(cliCtx *cli.Context) (targetURL, versionID string, timeRef time.Time, recursive, withVersions bool) {
 	args := cliCtx.Args()
 	if len(args) != 1 {
-		cli.ShowCommandHelpAndExit(cliCtx, cliCtx.Command.Name, 1)
+		cli.ShowCommandHelpAndExit(cliCtx, cliCtx.Command.Name, globalInvalidArgsExitStatus)
 	}
 
 	targetURL = args[0]
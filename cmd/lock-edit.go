@@ -0,0 +1,295 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/minio/minio/pkg/console"
+)
+
+var lockEditFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "mode",
+		Usage: "default retention mode, 'governance' or 'compliance'",
+	},
+	cli.StringFlag{
+		Name:  "validity",
+		Usage: "default retention validity, Nd or Ny, e.g. '30d', '1y'",
+	},
+	cli.BoolFlag{
+		Name:  "clear",
+		Usage: "remove the default retention",
+	},
+	cli.BoolFlag{
+		Name:  "force",
+		Usage: "apply the change despite the warnings",
+	},
+}
+
+var lockEditCmd = cli.Command{
+	Name:         "edit",
+	Usage:        "change the default retention of a bucket",
+	Action:       mainLockEdit,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(lockEditFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS/BUCKET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Change the mode and/or the validity of the default retention of a bucket
+   with object locking enabled, the flag which is not given is kept. The new
+   default applies to the objects written from now on.
+
+   The change is checked against the current default and the lifecycle rules
+   of the bucket. It is not applied without --force when it makes objects
+   undeletable by anyone, deletable before they used to be, or when a rule
+   expires noncurrent versions before their retention ends.
+
+EXAMPLES:
+  1. Retain the new objects of a bucket for 90 days in governance mode.
+     {{.Prompt}} {{.HelpName}} --mode governance --validity 90d myminio/mybucket
+
+  2. Extend the default retention of a bucket to one year, keeping its mode.
+     {{.Prompt}} {{.HelpName}} --validity 1y myminio/mybucket
+
+  3. Switch a bucket to compliance mode, knowing that its objects cannot be deleted before they expire.
+     {{.Prompt}} {{.HelpName}} --mode compliance --force myminio/mybucket
+
+  4. Remove the default retention of a bucket.
+     {{.Prompt}} {{.HelpName}} --clear myminio/mybucket
+`,
+}
+
+// lockDefault is the default retention of a bucket, a zero value when
+// there is none.
+type lockDefault struct {
+	Mode     minio.RetentionMode `json:"mode,omitempty"`
+	Validity uint64              `json:"validity,omitempty"`
+	Unit     minio.ValidityUnit  `json:"unit,omitempty"`
+}
+
+// days returns the validity in days, a year counts as 365 days.
+func (l lockDefault) days() uint64 {
+	if l.Unit == minio.Years {
+		return l.Validity * 365
+	}
+	return l.Validity
+}
+
+func (l lockDefault) String() string {
+	if l.Mode == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%s for %d%s", l.Mode, l.Validity, l.Unit)
+}
+
+// lockEditMessage is the outcome of a change of the default retention.
+type lockEditMessage struct {
+	Status   string      `json:"status"`
+	URL      string      `json:"url"`
+	Previous lockDefault `json:"previous"`
+	Current  lockDefault `json:"current"`
+	Warnings []string    `json:"warnings,omitempty"`
+	Applied  bool        `json:"applied"`
+}
+
+func (l lockEditMessage) String() string {
+	var msg string
+	if l.Applied {
+		msg = console.Colorize("LockEdit", fmt.Sprintf("Default retention of `%s` changed from %s to %s.", l.URL, l.Previous, l.Current))
+	} else {
+		msg = console.Colorize("LockEdit", fmt.Sprintf("Default retention of `%s` not changed from %s to %s, use --force to apply.", l.URL, l.Previous, l.Current))
+	}
+	for _, w := range l.Warnings {
+		msg += "\n" + console.Colorize("LockWarning", "  Warning: "+w)
+	}
+	return msg
+}
+
+func (l lockEditMessage) JSON() string {
+	l.Status = "success"
+	if !l.Applied {
+		l.Status = "failure"
+	}
+	msgBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// parseLockEditFlags returns the default retention after applying the
+// flags to the current one.
+func parseLockEditFlags(cliCtx *cli.Context, current lockDefault) (lockDefault, *probe.Error) {
+	modeStr, validityStr := cliCtx.String("mode"), cliCtx.String("validity")
+	if cliCtx.Bool("clear") {
+		if modeStr != "" || validityStr != "" {
+			return lockDefault{}, probe.NewError(fmt.Errorf("--clear cannot be used with --mode or --validity"))
+		}
+		return lockDefault{}, nil
+	}
+	if modeStr == "" && validityStr == "" {
+		return lockDefault{}, probe.NewError(fmt.Errorf("one of --mode, --validity or --clear is required"))
+	}
+	edited := current
+	if modeStr != "" {
+		edited.Mode = minio.RetentionMode(strings.ToUpper(modeStr))
+		if !edited.Mode.IsValid() {
+			return lockDefault{}, probe.NewError(fmt.Errorf("invalid retention mode `%s`", modeStr))
+		}
+	}
+	if validityStr != "" {
+		validity, unit, err := parseRetentionValidity(validityStr)
+		if err != nil {
+			return lockDefault{}, probe.NewError(fmt.Errorf("invalid validity `%s`, expected Nd or Ny", validityStr))
+		}
+		edited.Validity, edited.Unit = validity, unit
+	}
+	switch {
+	case edited.Mode == "":
+		return lockDefault{}, probe.NewError(fmt.Errorf("--mode is required, the bucket has no default retention"))
+	case edited.Validity == 0:
+		return lockDefault{}, probe.NewError(fmt.Errorf("--validity is required, the bucket has no default retention"))
+	}
+	return edited, nil
+}
+
+// noncurrentExpiryRules returns the enabled lifecycle rules which delete
+// noncurrent versions, by their days.
+func noncurrentExpiryRules(cfg *lifecycle.Configuration) map[string]uint64 {
+	rules := map[string]uint64{}
+	if cfg == nil {
+		return rules
+	}
+	for _, rule := range cfg.Rules {
+		if rule.Status != "Enabled" || rule.NoncurrentVersionExpiration.IsDaysNull() {
+			continue
+		}
+		rules[rule.ID] = uint64(rule.NoncurrentVersionExpiration.NoncurrentDays)
+	}
+	return rules
+}
+
+// lockEditWarnings returns the consequences of changing the default
+// retention from previous to current worth a confirmation.
+func lockEditWarnings(previous, current lockDefault, rules map[string]uint64) []string {
+	var warnings []string
+	switch {
+	case current.Mode == minio.Compliance && previous.Mode != minio.Compliance:
+		warnings = append(warnings, fmt.Sprintf("objects written from now on cannot be deleted by anyone, root included, for %d%s", current.Validity, current.Unit))
+	case current.Mode == minio.Compliance && current.days() > previous.days():
+		warnings = append(warnings, fmt.Sprintf("objects written from now on cannot be deleted by anyone, root included, for %d%s instead of %d%s",
+			current.Validity, current.Unit, previous.Validity, previous.Unit))
+	}
+	if previous.Mode == minio.Compliance && current.Mode == minio.Governance {
+		warnings = append(warnings, "objects written from now on can be deleted before their retention ends by users allowed to bypass governance")
+	}
+	switch {
+	case previous.Mode != "" && current.Mode == "":
+		warnings = append(warnings, fmt.Sprintf("objects written from now on are not retained, they were for %d%s", previous.Validity, previous.Unit))
+	case previous.Mode != "" && current.days() < previous.days():
+		warnings = append(warnings, fmt.Sprintf("objects written from now on are retained for %d%s instead of %d%s",
+			current.Validity, current.Unit, previous.Validity, previous.Unit))
+	}
+
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		days := rules[id]
+		switch {
+		case current.Mode != "" && days < current.days():
+			warnings = append(warnings, fmt.Sprintf("lifecycle rule `%s` expires noncurrent versions after %d days, they are kept until their retention of %d%s ends",
+				id, days, current.Validity, current.Unit))
+		case previous.Mode != "" && days < previous.days():
+			warnings = append(warnings, fmt.Sprintf("lifecycle rule `%s` will delete the noncurrent versions of new objects after %d days, before the %d%s they were retained for",
+				id, days, previous.Validity, previous.Unit))
+		}
+	}
+	return warnings
+}
+
+func mainLockEdit(cliCtx *cli.Context) error {
+	ctx, cancelLockEdit := context.WithCancel(globalContext)
+	defer cancelLockEdit()
+
+	console.SetColor("LockEdit", color.New(color.FgGreen))
+	console.SetColor("LockWarning", color.New(color.FgYellow))
+
+	if len(cliCtx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "edit", globalInvalidArgsExitStatus)
+	}
+	urlStr := cliCtx.Args().Get(0)
+
+	clnt, err := newClient(urlStr)
+	fatalIf(err.Trace(urlStr), "Unable to initialize target `"+urlStr+"`.")
+	if _, ok := clnt.(*S3Client); !ok {
+		fatalIf(errInvalidArgument().Trace(urlStr), "Object locking is supported only for S3 servers.")
+	}
+
+	status, mode, validity, unit, err := clnt.GetObjectLockConfig(ctx)
+	fatalIf(err.Trace(urlStr), "Unable to get the object lock configuration of `%s`.", urlStr)
+	if status != "Enabled" {
+		fatalIf(errInvalidArgument().Trace(urlStr), "Object locking is not enabled on `%s`.", urlStr)
+	}
+	previous := lockDefault{}
+	if mode != "" {
+		previous = lockDefault{Mode: mode, Validity: validity, Unit: unit}
+	}
+
+	current, err := parseLockEditFlags(cliCtx, previous)
+	fatalIf(err.Trace(urlStr), "Invalid arguments.")
+
+	cfg, err := clnt.GetLifecycle(ctx)
+	if err != nil && minio.ToErrorResponse(err.ToGoError()).Code != "NoSuchLifecycleConfiguration" {
+		fatalIf(err.Trace(urlStr), "Unable to get the lifecycle rules of `%s`.", urlStr)
+	}
+
+	msg := lockEditMessage{
+		URL:      urlStr,
+		Previous: previous,
+		Current:  current,
+		Warnings: lockEditWarnings(previous, current, noncurrentExpiryRules(cfg)),
+	}
+	if len(msg.Warnings) > 0 && !cliCtx.Bool("force") {
+		printMsg(msg)
+		return exitStatus(globalErrorExitStatus)
+	}
+
+	err = clnt.SetObjectLockConfig(ctx, current.Mode, current.Validity, current.Unit)
+	fatalIf(err.Trace(urlStr), "Unable to set the default retention of `%s`.", urlStr)
+	msg.Applied = true
+	printMsg(msg)
+	return nil
+}
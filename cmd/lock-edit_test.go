@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+func TestNoncurrentExpiryRules(t *testing.T) {
+	cfg := lifecycle.NewConfiguration()
+	cfg.Rules = []lifecycle.Rule{
+		{ID: "old", Status: "Enabled", NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{NoncurrentDays: 10}},
+		{ID: "disabled", Status: "Disabled", NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{NoncurrentDays: 5}},
+		{ID: "current", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 3}},
+	}
+	expected := map[string]uint64{"old": 10}
+	if got := noncurrentExpiryRules(cfg); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := noncurrentExpiryRules(nil); len(got) != 0 {
+		t.Errorf("expected no rules, got %v", got)
+	}
+}
+
+func TestLockEditWarnings(t *testing.T) {
+	none := lockDefault{}
+	gov30 := lockDefault{Mode: minio.Governance, Validity: 30, Unit: minio.Days}
+	gov60 := lockDefault{Mode: minio.Governance, Validity: 60, Unit: minio.Days}
+	gov5 := lockDefault{Mode: minio.Governance, Validity: 5, Unit: minio.Days}
+	comp30 := lockDefault{Mode: minio.Compliance, Validity: 30, Unit: minio.Days}
+	comp1y := lockDefault{Mode: minio.Compliance, Validity: 1, Unit: minio.Years}
+
+	testCases := []struct {
+		previous, current lockDefault
+		rules             map[string]uint64
+		expected          []string
+	}{
+		{none, gov30, nil, nil},
+		{gov30, gov60, map[string]uint64{"r": 90}, nil},
+		{gov30, gov60, map[string]uint64{"r": 45}, []string{
+			"lifecycle rule `r` expires noncurrent versions after 45 days, they are kept until their retention of 60DAYS ends",
+		}},
+		{gov30, comp30, nil, []string{
+			"objects written from now on cannot be deleted by anyone, root included, for 30DAYS",
+		}},
+		{comp30, comp1y, nil, []string{
+			"objects written from now on cannot be deleted by anyone, root included, for 1YEARS instead of 30DAYS",
+		}},
+		{comp1y, comp30, nil, []string{
+			"objects written from now on are retained for 30DAYS instead of 1YEARS",
+		}},
+		{comp30, gov5, map[string]uint64{"b": 10, "a": 20}, []string{
+			"objects written from now on can be deleted before their retention ends by users allowed to bypass governance",
+			"objects written from now on are retained for 5DAYS instead of 30DAYS",
+			"lifecycle rule `a` will delete the noncurrent versions of new objects after 20 days, before the 30DAYS they were retained for",
+			"lifecycle rule `b` will delete the noncurrent versions of new objects after 10 days, before the 30DAYS they were retained for",
+		}},
+		{gov30, none, map[string]uint64{"r": 40}, []string{
+			"objects written from now on are not retained, they were for 30DAYS",
+		}},
+	}
+	for i, testCase := range testCases {
+		got := lockEditWarnings(testCase.previous, testCase.current, testCase.rules)
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -50,7 +50,43 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "summarize",
-			Usage: "display summary information (number of objects, total size)",
+			Usage: "display summary information (number of objects, total size, prefixes, newest and oldest)",
+		},
+		cli.BoolFlag{
+			Name:  "null, 0",
+			Usage: "print only the names, each followed by a NUL character instead of a newline",
+		},
+		cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort by 'name', 'size' largest first or 'mtime' newest first, keeps the whole listing in memory unless --limit is given",
+		},
+		cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "reverse the sort order",
+		},
+		cli.IntFlag{
+			Name:  "limit",
+			Usage: "list at most N objects",
+		},
+		cli.StringFlag{
+			Name:  "created-before",
+			Usage: "list only the versions created before a date or a duration ago, with --versions",
+		},
+		cli.StringFlag{
+			Name:  "created-after",
+			Usage: "list only the versions created after a date or a duration ago, with --versions",
+		},
+		cli.BoolFlag{
+			Name:  "only-delete-markers",
+			Usage: "list only the delete markers, with --versions",
+		},
+		cli.IntFlag{
+			Name:  "min-versions",
+			Usage: "list only the objects with at least N versions, with --versions",
+		},
+		cli.StringFlag{
+			Name:  "older-than",
+			Usage: "list only the uploads older than L days, M hours and N minutes, with --incomplete",
 		},
 	}
 )
@@ -62,7 +98,7 @@ var lsCmd = cli.Command{
 	Action:       mainList,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(lsFlags, globalFlags...),
+	Flags:        append(append(lsFlags, multiAliasFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -101,6 +137,27 @@ EXAMPLES:
 
   9. List all objects on mybucket, summarize the number of objects and total size.
      {{.Prompt}} {{.HelpName}} --summarize s3/mybucket/
+
+  10. List the buckets of the 'site1' and 'site2' aliases.
+     {{.Prompt}} {{.HelpName}} --aliases site1,site2
+
+  11. Count the objects of mybucket, names with newlines included.
+     {{.Prompt}} {{.HelpName}} --recursive --null s3/mybucket/ | tr -cd '\0' | wc -c
+
+  12. List the 10 largest objects of mybucket.
+     {{.Prompt}} {{.HelpName}} --recursive --sort size --limit 10 s3/mybucket/
+
+  13. List the 5 least recently modified objects of mybucket.
+     {{.Prompt}} {{.HelpName}} --recursive --sort mtime --reverse --limit 5 s3/mybucket/
+
+  14. List the objects of mybucket with 100 versions or more.
+     {{.Prompt}} {{.HelpName}} --recursive --versions --min-versions 100 s3/mybucket/
+
+  15. List the objects of mybucket deleted during the last 7 days.
+     {{.Prompt}} {{.HelpName}} --recursive --versions --only-delete-markers --created-after 7d s3/mybucket/
+
+  16. List the incomplete uploads of mybucket started more than 7 days ago.
+     {{.Prompt}} {{.HelpName}} --recursive --incomplete --older-than 7d s3/mybucket/
 `,
 }
 
@@ -113,40 +170,46 @@ var rewindSupportedFormat = []string{
 
 // Parse rewind flag while considering the system local time zone
 func parseRewindFlag(rewind string) (timeRef time.Time) {
-	if rewind != "" {
+	return parseTimeFlag(rewind, "rewind")
+}
+
+// Parse a flag given as a date in the system local time zone, or as
+// a duration ago.
+func parseTimeFlag(value, flagName string) (timeRef time.Time) {
+	if value != "" {
 		location, e := time.LoadLocation("Local")
 		if e != nil {
 			return
 		}
 
 		for _, format := range rewindSupportedFormat {
-			if t, e := time.ParseInLocation(format, rewind, location); e == nil {
+			if t, e := time.ParseInLocation(format, value, location); e == nil {
 				timeRef = t
 				break
 			}
 		}
 
 		if timeRef.IsZero() {
-			// rewind is not parsed, check if it is a duration instead
-			if duration, e := ioutils.ParseDurationTime(rewind); e == nil {
+			// value is not parsed, check if it is a duration instead
+			if duration, e := ioutils.ParseDurationTime(value); e == nil {
 				if duration < 0 {
 					fatalIf(probe.NewError(errors.New("negative duration is not supported")),
-						"Unable to parse --rewind argument")
+						"Unable to parse --%s argument", flagName)
 				}
 				timeRef = time.Now().Add(-duration)
 			}
 		}
 
 		if timeRef.IsZero() {
-			// rewind argument still not parsed, error out
-			fatalIf(probe.NewError(errors.New("unknown format")), "Unable to parse --rewind argument")
+			// argument still not parsed, error out
+			fatalIf(probe.NewError(errors.New("unknown format")), "Unable to parse --%s argument", flagName)
 		}
 	}
 	return
 }
 
 // checkListSyntax - validate all the passed arguments
-func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, bool, bool, bool, time.Time, bool) {
+func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doListOptions) {
 	args := cliCtx.Args()
 	if !cliCtx.Args().Present() {
 		args = []string{"."}
@@ -157,21 +220,62 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, bool,
 		}
 	}
 
-	isRecursive := cliCtx.Bool("recursive")
-	isIncomplete := cliCtx.Bool("incomplete")
-	withOlderVersions := cliCtx.Bool("versions")
-	isSummary := cliCtx.Bool("summarize")
+	opts := doListOptions{
+		isRecursive:       cliCtx.Bool("recursive"),
+		isIncomplete:      cliCtx.Bool("incomplete"),
+		withOlderVersions: cliCtx.Bool("versions"),
+		isSummary:         cliCtx.Bool("summarize"),
+		isNull:            cliCtx.Bool("null"),
+		sortBy:            cliCtx.String("sort"),
+		isReverse:         cliCtx.Bool("reverse"),
+		limit:             cliCtx.Int("limit"),
+		createdBefore:     parseTimeFlag(cliCtx.String("created-before"), "created-before"),
+		createdAfter:      parseTimeFlag(cliCtx.String("created-after"), "created-after"),
+		onlyDeleteMarkers: cliCtx.Bool("only-delete-markers"),
+		minVersions:       cliCtx.Int("min-versions"),
+		olderThan:         cliCtx.String("older-than"),
+	}
+	switch opts.sortBy {
+	case "", "name", "size", "mtime":
+	default:
+		fatalIf(errInvalidArgument().Trace(opts.sortBy), "Unable to sort by `%s`, expected name, size or mtime.", opts.sortBy)
+	}
+	if opts.limit < 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("limit")), "--limit cannot be negative.")
+	}
+	if opts.minVersions < 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("min-versions")), "--min-versions cannot be negative.")
+	}
+	if opts.hasVersionFilters() && !opts.withOlderVersions {
+		fatalIf(errInvalidArgument().Trace(),
+			"--created-before, --created-after, --only-delete-markers and --min-versions require --versions.")
+	}
+	if opts.olderThan != "" {
+		if !opts.isIncomplete {
+			fatalIf(errInvalidArgument().Trace(), "--older-than requires --incomplete.")
+		}
+		if _, e := ioutils.ParseDurationTime(opts.olderThan); e != nil {
+			fatalIf(probe.NewError(e).Trace(opts.olderThan), "Unable to parse --older-than argument.")
+		}
+	}
+	if opts.isNull && (globalJSON || opts.isSummary) {
+		fatalIf(errInvalidArgument().Trace(), "--null cannot be used with --json or --summarize.")
+	}
 
-	timeRef := parseRewindFlag(cliCtx.String("rewind"))
-	if timeRef.IsZero() && withOlderVersions {
-		timeRef = time.Now().UTC()
+	opts.timeRef = parseRewindFlag(cliCtx.String("rewind"))
+	if opts.timeRef.IsZero() && opts.withOlderVersions {
+		opts.timeRef = time.Now().UTC()
 	}
 
-	return args, isRecursive, isIncomplete, isSummary, timeRef, withOlderVersions
+	return args, opts
 }
 
 // mainList - is a handler for mc ls command
 func mainList(cliCtx *cli.Context) error {
+	if isMultiAlias(cliCtx) {
+		return runOnAliases(cliCtx)
+	}
+
 	ctx, cancelList := context.WithCancel(globalContext)
 	defer cancelList()
 
@@ -187,7 +291,7 @@ func mainList(cliCtx *cli.Context) error {
 	console.SetColor("Summarize", color.New(color.Bold))
 
 	// check 'ls' cliCtx arguments.
-	args, isRecursive, isIncomplete, isSummary, timeRef, withOlderVersions := checkListSyntax(ctx, cliCtx)
+	args, opts := checkListSyntax(ctx, cliCtx)
 
 	var cErr error
 	for _, targetURL := range args {
@@ -195,14 +299,14 @@ func mainList(cliCtx *cli.Context) error {
 		fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 		if !strings.HasSuffix(targetURL, string(clnt.GetURL().Separator)) {
 			var st *ClientContent
-			st, err = clnt.Stat(ctx, StatOptions{incomplete: isIncomplete})
+			st, err = clnt.Stat(ctx, StatOptions{incomplete: opts.isIncomplete})
 			if st != nil && err == nil && st.Type.IsDir() {
 				targetURL = targetURL + string(clnt.GetURL().Separator)
 				clnt, err = newClient(targetURL)
 				fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 			}
 		}
-		if e := doList(ctx, clnt, isRecursive, isIncomplete, isSummary, timeRef, withOlderVersions); e != nil {
+		if e := doList(ctx, clnt, opts); e != nil {
 			cErr = e
 		}
 	}
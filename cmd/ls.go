@@ -77,10 +77,11 @@ func (c contentMessage) String() string {
 	return message
 }
 
-// JSON jsonified content message.
+// JSON jsonified content message, always a single line so that
+// listings are strictly newline delimited.
 func (c contentMessage) JSON() string {
 	c.Status = "success"
-	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	jsonMessageBytes, e := json.Marshal(c)
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
 	return string(jsonMessageBytes)
@@ -173,52 +174,236 @@ func sortObjectVersions(ctntVersions []*ClientContent) {
 
 // summaryMessage container for summary message structure
 type summaryMessage struct {
-	TotalObjects int64 `json:"totalObjects"`
-	TotalSize    int64 `json:"totalSize"`
+	TotalObjects  int64      `json:"totalObjects"`
+	TotalSize     int64      `json:"totalSize"`
+	TotalPrefixes int64      `json:"totalPrefixes"`
+	Newest        *time.Time `json:"newest,omitempty"`
+	Oldest        *time.Time `json:"oldest,omitempty"`
+
+	// Prefixes already counted, relative to the listed URL.
+	prefixes map[string]struct{}
 }
 
 // String colorized string message
 func (s summaryMessage) String() string {
 	msg := console.Colorize("Summarize", fmt.Sprintf("\nTotal Size: %s", humanize.IBytes(uint64(s.TotalSize))))
 	msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Total Objects: %d", s.TotalObjects))
+	msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Total Prefixes: %d", s.TotalPrefixes))
+	if s.Newest != nil {
+		msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Newest: %s", s.Newest.Format(printDate)))
+		msg += "\n" + console.Colorize("Summarize", fmt.Sprintf("Oldest: %s", s.Oldest.Format(printDate)))
+	}
 	return msg
 }
 
+// add counts a listed entry, folders are counted as prefixes, the
+// folders above an object found by a recursive listing as well.
+func (s *summaryMessage) add(clntURL ClientURL, content *ClientContent) {
+	if s.prefixes == nil {
+		s.prefixes = make(map[string]struct{})
+	}
+	separator := string(content.URL.Separator)
+	key := strings.TrimPrefix(strings.TrimPrefix(content.URL.Path, clntURL.Path), separator)
+	if content.Type.IsDir() {
+		s.addPrefix(strings.TrimSuffix(key, separator), separator)
+		return
+	}
+	if i := strings.LastIndex(key, separator); i > 0 {
+		s.addPrefix(key[:i], separator)
+	}
+
+	s.TotalObjects++
+	s.TotalSize += content.Size
+	mtime := content.Time.Local()
+	if s.Newest == nil || mtime.After(*s.Newest) {
+		s.Newest = &mtime
+	}
+	if s.Oldest == nil || mtime.Before(*s.Oldest) {
+		s.Oldest = &mtime
+	}
+}
+
+// addPrefix counts a prefix and all its parents not yet counted.
+func (s *summaryMessage) addPrefix(prefix, separator string) {
+	for prefix != "" {
+		if _, ok := s.prefixes[prefix]; ok {
+			return
+		}
+		s.prefixes[prefix] = struct{}{}
+		s.TotalPrefixes++
+		i := strings.LastIndex(prefix, separator)
+		if i < 0 {
+			return
+		}
+		prefix = prefix[:i]
+	}
+}
+
 // JSON jsonified summary message
 func (s summaryMessage) JSON() string {
-	jsonMessageBytes, e := json.MarshalIndent(s, "", "")
+	jsonMessageBytes, e := json.Marshal(s)
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON")
 	return string(jsonMessageBytes)
 }
 
+// doListOptions holds the options of a listing.
+type doListOptions struct {
+	timeRef           time.Time
+	isRecursive       bool
+	isIncomplete      bool
+	isSummary         bool
+	withOlderVersions bool
+
+	// Print only the keys, each terminated by a NUL character.
+	isNull bool
+
+	// Sort by name, size or mtime instead of printing as listed,
+	// then print at most limit objects when limit is not zero.
+	sortBy    string
+	isReverse bool
+	limit     int
+
+	// Print only the versions created in this time window, only the
+	// delete markers, of the objects with at least minVersions versions.
+	createdBefore     time.Time
+	createdAfter      time.Time
+	onlyDeleteMarkers bool
+	minVersions       int
+
+	// Print only the incomplete uploads older than this duration.
+	olderThan string
+}
+
+// hasVersionFilters returns true if only some versions are listed.
+func (opts doListOptions) hasVersionFilters() bool {
+	return !opts.createdBefore.IsZero() || !opts.createdAfter.IsZero() ||
+		opts.onlyDeleteMarkers || opts.minVersions > 0
+}
+
+// matchVersion returns true if a version of an object is listed.
+func (opts doListOptions) matchVersion(c *ClientContent) bool {
+	if !opts.createdBefore.IsZero() && !c.Time.Before(opts.createdBefore) {
+		return false
+	}
+	if !opts.createdAfter.IsZero() && !c.Time.After(opts.createdAfter) {
+		return false
+	}
+	if opts.onlyDeleteMarkers && !c.IsDeleteMarker {
+		return false
+	}
+	return true
+}
+
+// matchObjectVersions returns true if an object, given with all its
+// versions, has any version listed.
+func (opts doListOptions) matchObjectVersions(ctntVersions []*ClientContent) bool {
+	if !opts.hasVersionFilters() {
+		return true
+	}
+	if len(ctntVersions) < opts.minVersions || ctntVersions[0].Type.IsDir() {
+		return false
+	}
+	for _, c := range ctntVersions {
+		if opts.matchVersion(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortListedObjects sorts objects, each given with all its versions, by
+// name, by size largest first or by modification time newest first.
+func sortListedObjects(objects [][]*ClientContent, sortBy string, isReverse bool) {
+	less := func(i, j int) bool {
+		a, b := objects[i][0], objects[j][0]
+		switch sortBy {
+		case "size":
+			if a.Size != b.Size {
+				return a.Size > b.Size
+			}
+		case "mtime":
+			if !a.Time.Equal(b.Time) {
+				return a.Time.After(b.Time)
+			}
+		}
+		return a.URL.Path < b.URL.Path
+	}
+	if isReverse {
+		sort.SliceStable(objects, func(i, j int) bool { return less(j, i) })
+		return
+	}
+	sort.SliceStable(objects, less)
+}
+
 // Pretty print the list of versions belonging to one object
-func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions, isSummary bool) {
+func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, opts doListOptions) {
 	sortObjectVersions(ctntVersions)
-	msgs := generateContentMessages(clntURL, ctntVersions, printAllVersions)
-	for _, msg := range msgs {
+	msgs := generateContentMessages(clntURL, ctntVersions, opts.withOlderVersions)
+	for i, msg := range msgs {
+		if !opts.matchVersion(ctntVersions[i]) {
+			continue
+		}
+		if opts.isNull {
+			printNullTerminated(msg.Key)
+			continue
+		}
 		printMsg(msg)
 	}
 }
 
 // doList - list all entities inside a folder.
-func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete, isSummary bool, timeRef time.Time, withOlderVersions bool) error {
+func doList(ctx context.Context, clnt Client, opts doListOptions) error {
 
 	var (
 		lastPath          string
 		perObjectVersions []*ClientContent
 		cErr              error
-		totalSize         int64
-		totalObjects      int64
+		summary           summaryMessage
+		sorted            [][]*ClientContent
+		printed           int
 	)
 
-	for content := range clnt.List(ctx, ListOptions{
-		Recursive:         isRecursive,
-		Incomplete:        isIncomplete,
-		TimeRef:           timeRef,
-		WithOlderVersions: withOlderVersions || !timeRef.IsZero(),
+	// printObject prints an object or keeps it to sort it later.
+	printObject := func(versions []*ClientContent) {
+		if len(versions) == 0 || !opts.matchObjectVersions(versions) {
+			return
+		}
+		if opts.isSummary {
+			for _, content := range versions {
+				if opts.matchVersion(content) {
+					summary.add(clnt.GetURL(), content)
+				}
+			}
+		}
+		if opts.sortBy != "" || opts.isReverse {
+			sortObjectVersions(versions)
+			sorted = append(sorted, versions)
+			// Only the first objects in order are printed with --limit,
+			// keep no more than twice as many in memory.
+			if opts.limit > 0 && len(sorted) >= 2*opts.limit {
+				sortListedObjects(sorted, opts.sortBy, opts.isReverse)
+				sorted = sorted[:opts.limit]
+			}
+			return
+		}
+		if opts.limit == 0 || printed < opts.limit {
+			printObjectVersions(clnt.GetURL(), versions, opts)
+			printed++
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	contentCh := clnt.List(ctx, ListOptions{
+		Recursive:         opts.isRecursive,
+		Incomplete:        opts.isIncomplete,
+		TimeRef:           opts.timeRef,
+		WithOlderVersions: opts.withOlderVersions || !opts.timeRef.IsZero(),
 		WithDeleteMarkers: true,
 		ShowDir:           DirNone,
-	}) {
+	})
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -244,25 +429,46 @@ func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete, isSumma
 			continue
 		}
 
+		// Skip uploads not older than --older-than, if specified.
+		if opts.olderThan != "" && isOlder(content.Time, opts.olderThan) {
+			continue
+		}
+
 		if lastPath != content.URL.Path {
 			// Print any object in the current list before reinitializing it
-			printObjectVersions(clnt.GetURL(), perObjectVersions, withOlderVersions, isSummary)
+			printObject(perObjectVersions)
+			if opts.limit > 0 && printed >= opts.limit && !opts.isSummary {
+				// Stop the listing, and drain what the lister has
+				// in flight so that it does not block on sending.
+				cancel()
+				go func() {
+					for range contentCh {
+					}
+				}()
+				perObjectVersions = nil
+				break
+			}
 			lastPath = content.URL.Path
 			perObjectVersions = []*ClientContent{}
 		}
 
 		perObjectVersions = append(perObjectVersions, content)
-		totalSize += content.Size
-		totalObjects++
 	}
 
-	printObjectVersions(clnt.GetURL(), perObjectVersions, withOlderVersions, isSummary)
+	printObject(perObjectVersions)
+
+	if sorted != nil {
+		sortListedObjects(sorted, opts.sortBy, opts.isReverse)
+		if opts.limit > 0 && len(sorted) > opts.limit {
+			sorted = sorted[:opts.limit]
+		}
+		for _, versions := range sorted {
+			printObjectVersions(clnt.GetURL(), versions, opts)
+		}
+	}
 
-	if isSummary {
-		printMsg(summaryMessage{
-			TotalObjects: totalObjects,
-			TotalSize:    totalSize,
-		})
+	if opts.isSummary {
+		printMsg(summary)
 	}
 
 	return cErr
@@ -15,3 +15,107 @@
  */
 
 package cmd
+
+import (
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestSummaryMessageAdd(t *testing.T) {
+	clntURL := *newClientURL("s3/bucket/logs/")
+	content := func(path string, size int64, day int, typ os.FileMode) *ClientContent {
+		return &ClientContent{
+			URL:  *newClientURL("s3/bucket/logs/" + path),
+			Size: size,
+			Time: time.Date(2021, 5, day, 0, 0, 0, 0, time.UTC),
+			Type: typ,
+		}
+	}
+
+	var summary summaryMessage
+	summary.add(clntURL, content("2021/05/10/app.log", 10, 10, 0))
+	summary.add(clntURL, content("2021/05/11/app.log", 20, 11, 0))
+	summary.add(clntURL, content("archive/", 0, 12, os.ModeDir))
+	summary.add(clntURL, content("index.html", 5, 1, 0))
+
+	if summary.TotalObjects != 3 {
+		t.Errorf("expected 3 objects, got %d", summary.TotalObjects)
+	}
+	if summary.TotalSize != 35 {
+		t.Errorf("expected a total size of 35, got %d", summary.TotalSize)
+	}
+	// 2021, 2021/05, 2021/05/10, 2021/05/11 and archive
+	if summary.TotalPrefixes != 5 {
+		t.Errorf("expected 5 prefixes, got %d", summary.TotalPrefixes)
+	}
+	if summary.Newest.Day() != 11 || summary.Oldest.Day() != 1 {
+		t.Errorf("expected newest on day 11 and oldest on day 1, got %s and %s", summary.Newest, summary.Oldest)
+	}
+}
+
+func TestSortListedObjects(t *testing.T) {
+	object := func(name string, size int64, day int) []*ClientContent {
+		return []*ClientContent{{
+			URL:  *newClientURL("s3/bucket/" + name),
+			Size: size,
+			Time: time.Date(2021, 5, day, 0, 0, 0, 0, time.UTC),
+		}}
+	}
+	testCases := []struct {
+		sortBy    string
+		isReverse bool
+		expected  []string
+	}{
+		{"name", false, []string{"a", "b", "c"}},
+		{"name", true, []string{"c", "b", "a"}},
+		{"size", false, []string{"b", "c", "a"}},
+		{"size", true, []string{"a", "c", "b"}},
+		{"mtime", false, []string{"c", "a", "b"}},
+		{"mtime", true, []string{"b", "a", "c"}},
+		{"", true, []string{"c", "b", "a"}},
+	}
+	for i, testCase := range testCases {
+		objects := [][]*ClientContent{object("a", 1, 2), object("b", 3, 1), object("c", 2, 3)}
+		sortListedObjects(objects, testCase.sortBy, testCase.isReverse)
+		for j, name := range testCase.expected {
+			if got := path.Base(objects[j][0].URL.Path); got != name {
+				t.Errorf("Test %d: expected %s at %d, got %s", i+1, name, j, got)
+			}
+		}
+	}
+}
+
+func TestMatchObjectVersions(t *testing.T) {
+	version := func(day int, isDeleteMarker bool) *ClientContent {
+		return &ClientContent{
+			Time:           time.Date(2021, 5, day, 0, 0, 0, 0, time.UTC),
+			IsDeleteMarker: isDeleteMarker,
+		}
+	}
+	versions := []*ClientContent{version(12, true), version(11, false), version(10, false)}
+
+	testCases := []struct {
+		opts     doListOptions
+		expected bool
+	}{
+		{doListOptions{}, true},
+		{doListOptions{minVersions: 3}, true},
+		{doListOptions{minVersions: 4}, false},
+		{doListOptions{onlyDeleteMarkers: true}, true},
+		{doListOptions{onlyDeleteMarkers: true, createdBefore: time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)}, false},
+		{doListOptions{createdAfter: time.Date(2021, 5, 12, 0, 0, 0, 0, time.UTC)}, false},
+		{doListOptions{createdAfter: time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)}, true},
+	}
+	for i, testCase := range testCases {
+		if match := testCase.opts.matchObjectVersions(versions); match != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, match)
+		}
+	}
+
+	dir := []*ClientContent{{Type: os.ModeDir}}
+	if (doListOptions{minVersions: 1}).matchObjectVersions(dir) {
+		t.Error("expected folders not to be listed with version filters")
+	}
+}
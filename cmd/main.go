@@ -81,6 +81,7 @@ func Main(args []string) {
 	if len(args) > 1 {
 		switch args[1] {
 		case "mc", filepath.Base(args[0]):
+			mcProfile = os.Getenv(mcEnvProfile)
 			mainComplete()
 			return
 		}
@@ -97,6 +98,11 @@ func Main(args []string) {
 		defer profile.Start(profile.BlockProfile, profile.ProfilePath(mustGetProfileDir())).Stop()
 	}
 
+	// Disable colors before any message is printed.
+	if isNoColorEnv() {
+		console.SetColorOff()
+	}
+
 	probe.Init() // Set project's root source path.
 	probe.SetAppInfo("Release-Tag", ReleaseTag)
 	probe.SetAppInfo("Commit", ShortCommitID)
@@ -118,6 +124,8 @@ func Main(args []string) {
 
 	// Monitor OS exit signals and cancel the global context in such case
 	go trapSignals(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	// Pause and resume transfers on user signals.
+	go trapPauseSignals()
 
 	// Run the app - exit on error.
 	if err := registerApp(appName).Run(args); err != nil {
@@ -156,7 +164,8 @@ func onUsageError(ctx *cli.Context, err error, subcommand bool) error {
 		spaces := string(bytes.Repeat([]byte{' '}, maxWidth-len(h.flagName)))
 		fmt.Fprintf(&errMsg, "   %s%s%s\n", h.flagName, spaces, h.usage)
 	}
-	console.Fatal(errMsg.String())
+	console.Error(errMsg.String())
+	os.Exit(globalInvalidArgsExitStatus)
 	return err
 }
 
@@ -350,6 +359,9 @@ func registerBefore(ctx *cli.Context) error {
 		setMcConfigDir(ctx.GlobalString("config-dir"))
 	}
 
+	// Select the configuration profile.
+	setMcProfile(ctx.String("profile"))
+
 	// Set global flags.
 	setGlobalsFromContext(ctx)
 
@@ -362,6 +374,9 @@ func registerBefore(ctx *cli.Context) error {
 	// Check if config can be read.
 	checkConfig()
 
+	// Select the color theme of the config file, if any.
+	setThemeFromConfig()
+
 	return nil
 }
 
@@ -411,28 +426,46 @@ var appCmds = []cli.Command{
 	catCmd,
 	headCmd,
 	pipeCmd,
+	getCmd,
+	putCmd,
 	shareCmd,
 	findCmd,
 	sqlCmd,
+	grepCmd,
 	statCmd,
 	mvCmd,
 	treeCmd,
 	duCmd,
 	retentionCmd,
+	lockCmd,
 	legalHoldCmd,
 	diffCmd,
+	snapshotCmd,
+	websiteCmd,
+	checksumCmd,
+	readyCmd,
+	pingCmd,
 	rmCmd,
 	versionCmd,
 	ilmCmd,
 	encryptCmd,
 	eventCmd,
 	watchCmd,
+	automateCmd,
 	undoCmd,
+	trashCmd,
 	policyCmd,
 	tagCmd,
+	metadataCmd,
 	replicateCmd,
+	batchCmd,
+	applyCmd,
 	adminCmd,
 	configCmd,
+	sessionCmd,
+	daemonCmd,
+	runCmd,
+	shellCmd,
 	updateCmd,
 }
 
@@ -456,13 +489,18 @@ func registerApp(name string) *cli.App {
 			return nil
 		}
 
-		if ctx.Args().First() != "" {
+		if command := ctx.Args().First(); command != "" {
+			plugin, err := findPlugin(command)
+			fatalIf(err, "Unable to run plugin `%s`.", pluginPrefix+command)
+			if plugin != "" {
+				return runPlugin(ctx, plugin)
+			}
 			commandNotFound(ctx, app.Commands)
 		} else {
 			cli.ShowAppHelp(ctx)
 		}
 
-		return exitStatus(globalErrorExitStatus)
+		return exitStatus(globalInvalidArgsExitStatus)
 	}
 
 	app.Before = registerBefore
@@ -108,7 +108,7 @@ func (s makeBucketMessage) JSON() string {
 // Validate command line arguments.
 func checkMakeBucketSyntax(cliCtx *cli.Context) {
 	if !cliCtx.Args().Present() {
-		cli.ShowCommandHelpAndExit(cliCtx, "mb", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(cliCtx, "mb", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+)
+
+// Memory budget of an operation set via --max-memory, the buffers,
+// queues and comparison state are sized from it. No limit when zero.
+var globalMaxMemory uint64
+
+// Smallest memory budget accepted.
+const minMaxMemory = 32 * humanize.MiByte
+
+// Estimated memory used by an item of the buffers and queues.
+const (
+	listEntryMemory     = humanize.KiByte     // an object listed
+	diffMessageMemory   = 2 * humanize.KiByte // two objects compared
+	manifestEntryMemory = 128                 // a key of a manifest
+	baseEntryMemory     = 256                 // an object of a snapshot
+)
+
+// Shares of the memory budget, the budget divided by the share is
+// available to each part. They add up to less than half of the budget,
+// the garbage collector lets the heap grow up to twice the live memory.
+const (
+	transferMemoryShare = 4  // buffers of the transfers running in parallel
+	listMemoryShare     = 16 // buffers of a listing, two run at a time
+	diffMemoryShare     = 16 // differences queued for the transfers
+	manifestMemoryShare = 16 // entries of a manifest, spilled to disk beyond
+	baseMemoryShare     = 8  // snapshot of a three-way diff
+	cacheMemoryShare    = 16 // checksum cache
+)
+
+var maxMemoryFlag = cli.StringFlag{
+	Name:  "max-memory",
+	Usage: "bound the memory used by buffers, queues and comparison state, e.g. '256MiB'",
+}
+
+// setMaxMemoryFromContext sets the memory budget given via command line.
+func setMaxMemoryFromContext(ctx *cli.Context) {
+	size := parseSizeFlag(ctx, "max-memory")
+	if size > 0 && size < minMaxMemory {
+		fatalIf(errInvalidArgument().Trace(ctx.String("max-memory")), "Invalid value for --max-memory, must be 32MiB at least.")
+	}
+	globalMaxMemory = size
+}
+
+// memoryBudget returns the number of items of itemSize bytes fitting in
+// the share of the memory budget, between min and def, def when there
+// is no budget.
+func memoryBudget(share, itemSize uint64, min, def int) int {
+	if globalMaxMemory == 0 {
+		return def
+	}
+	n := globalMaxMemory / share / itemSize
+	if n < uint64(min) {
+		return min
+	}
+	if n > uint64(def) {
+		return def
+	}
+	return int(n)
+}
+
+// memoryShare returns the number of bytes of the share of the memory
+// budget, zero when there is no budget.
+func memoryShare(share uint64) uint64 {
+	return globalMaxMemory / share
+}
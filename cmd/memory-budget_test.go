@@ -0,0 +1,90 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	defer func(max uint64) { globalMaxMemory = max }(globalMaxMemory)
+
+	testCases := []struct {
+		maxMemory uint64
+		expected  int
+	}{
+		{0, 10000},
+		{32 * humanize.MiByte, 1024},
+		{1 * humanize.GiByte, 10000},
+		{256 * humanize.KiByte, 16},
+	}
+	for i, testCase := range testCases {
+		globalMaxMemory = testCase.maxMemory
+		if n := memoryBudget(diffMemoryShare, diffMessageMemory, 16, 10000); n != testCase.expected {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.expected, n)
+		}
+	}
+}
+
+func TestDiffManifestSpill(t *testing.T) {
+	defer func(max uint64) { globalMaxMemory = max }(globalMaxMemory)
+	// Room for two entries in memory.
+	globalMaxMemory = 2 * manifestMemoryShare * manifestEntryMemory
+
+	m := newDiffManifest("s3/first", "play/second")
+	for _, key := range []string{"a", "b", "c", "d"} {
+		m.add(diffMessage{FirstURL: "/first/" + key, Diff: differInFirst}, "/first", "/second")
+	}
+	m.add(diffMessage{SecondURL: "/second/e", Diff: differInSecond}, "/first", "/second")
+	if len(m.Entries) != 2 || m.spill == nil {
+		t.Fatalf("expected 2 entries in memory and the others spilled, got %d", len(m.Entries))
+	}
+	spill := m.spill.Name()
+
+	dir, e := ioutil.TempDir("", "diff-manifest")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	manifestFile := filepath.Join(dir, "diff.json")
+	if err := m.save(manifestFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, e = os.Stat(spill); !os.IsNotExist(e) {
+		t.Errorf("expected the spilled entries to be removed, got %v", e)
+	}
+	loaded, err := loadDiffManifest(manifestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.First != "s3/first" || loaded.Second != "play/second" {
+		t.Errorf("expected s3/first and play/second, got %s and %s", loaded.First, loaded.Second)
+	}
+	if keys := loaded.copyKeys(); !reflect.DeepEqual(keys, []string{"a", "b", "c", "d"}) {
+		t.Errorf("expected copy keys [a b c d], got %v", keys)
+	}
+	if keys := loaded.removeKeys(); !reflect.DeepEqual(keys, []string{"e"}) {
+		t.Errorf("expected remove keys [e], got %v", keys)
+	}
+}
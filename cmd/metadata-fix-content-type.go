@@ -0,0 +1,310 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/cheggaaa/pb"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	"github.com/minio/minio/pkg/mimedb"
+)
+
+var metadataFixContentTypeFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "fix all objects under the prefix",
+	},
+	cli.BoolFlag{
+		Name:  "sniff",
+		Usage: "detect the content type from the first bytes of objects without a known extension",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show the objects with a wrong content type without fixing them",
+	},
+}
+
+var metadataFixContentTypeCmd = cli.Command{
+	Name:         "fix-content-type",
+	Usage:        "fix the content type of object(s)",
+	Action:       mainMetadataFixContentType,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(metadataFixContentTypeFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Detect the content type of objects from the extension of their names and
+   replace the stored Content-Type when it is different, for example
+   'application/octet-stream' for an '.html' object. With --sniff objects
+   without a known extension are detected from their first 512 bytes.
+   Objects of unknown type are left unchanged.
+
+EXAMPLES:
+  1. Show the objects of a website with a wrong content type.
+     {{.Prompt}} {{.HelpName}} --recursive --dry-run myminio/testbucket/site/
+
+  2. Fix the content type of all objects of a bucket.
+     {{.Prompt}} {{.HelpName}} --recursive myminio/testbucket
+
+  3. Fix the content type of all objects under a prefix, detecting objects without extension.
+     {{.Prompt}} {{.HelpName}} --recursive --sniff myminio/testbucket/uploads/
+`,
+}
+
+// metadataFixMessage is printed for each object with a wrong content type.
+type metadataFixMessage struct {
+	Status  string `json:"status"`
+	DryRun  bool   `json:"dryRun,omitempty"`
+	URL     string `json:"url"`
+	OldType string `json:"oldContentType"`
+	NewType string `json:"newContentType"`
+}
+
+func (m metadataFixMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m metadataFixMessage) String() string {
+	oldType := m.OldType
+	if oldType == "" {
+		oldType = "none"
+	}
+	return fmt.Sprintf("`%s`: %s -> %s", m.URL, console.Colorize("OldType", oldType), console.Colorize("NewType", m.NewType))
+}
+
+// metadataFixSummary is printed once all objects are checked.
+type metadataFixSummary struct {
+	Status  string `json:"status"`
+	DryRun  bool   `json:"dryRun,omitempty"`
+	Checked int64  `json:"checked"`
+	Fixed   int64  `json:"fixed"`
+	Failed  int64  `json:"failed"`
+}
+
+func (m metadataFixSummary) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m metadataFixSummary) String() string {
+	msg := fmt.Sprintf("Checked %d object(s), fixed %d.", m.Checked, m.Fixed)
+	if m.DryRun {
+		msg = fmt.Sprintf("Checked %d object(s), %d would be fixed, nothing was changed.", m.Checked, m.Fixed)
+	}
+	if m.Failed > 0 {
+		msg += fmt.Sprintf(" %d object(s) failed.", m.Failed)
+	}
+	return console.Colorize("Summary", msg)
+}
+
+const unknownContentType = "application/octet-stream"
+
+// sameContentType compares the media types of two content types, the
+// parameters such as the charset are ignored.
+func sameContentType(a, b string) bool {
+	mediaA, _, e := mime.ParseMediaType(a)
+	if e != nil {
+		return strings.EqualFold(a, b)
+	}
+	mediaB, _, e := mime.ParseMediaType(b)
+	if e != nil {
+		return strings.EqualFold(a, b)
+	}
+	return mediaA == mediaB
+}
+
+// detectContentType returns the content type of an object from its
+// extension, or from its first bytes when sniff is set and the
+// extension is not known.
+func detectContentType(ctx context.Context, clnt Client, st *ClientContent, sniff bool) (string, *probe.Error) {
+	contentType := mimedb.TypeByExtension(path.Ext(st.URL.Path))
+	if contentType != unknownContentType || !sniff || st.Size == 0 {
+		return contentType, nil
+	}
+	reader, err := clnt.Get(ctx, GetOptions{VersionID: st.VersionID})
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	buf := make([]byte, 512)
+	n, e := io.ReadFull(reader, buf)
+	if e != nil && e != io.ErrUnexpectedEOF {
+		return "", probe.NewError(e)
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// checkContentType checks the content type of an object, the returned
+// target holds the content type to set when it is wrong and is nil
+// otherwise. The object is fixed unless dryRun is set.
+func checkContentType(ctx context.Context, objectURL string, sniff, dryRun bool) URLs {
+	clnt, err := newClient(objectURL)
+	if err != nil {
+		return URLs{Error: err.Trace(objectURL)}
+	}
+	st, err := clnt.Stat(ctx, StatOptions{})
+	if err != nil {
+		return URLs{Error: err.Trace(objectURL)}
+	}
+	sURLs := URLs{SourceContent: st}
+	if st.Type.IsDir() {
+		return sURLs
+	}
+	contentType, err := detectContentType(ctx, clnt, st, sniff)
+	if err != nil {
+		return sURLs.WithError(err.Trace(objectURL))
+	}
+	metadata := editableMetadata(st.Metadata)
+	if contentType == unknownContentType || sameContentType(metadata["Content-Type"], contentType) {
+		return sURLs
+	}
+	sURLs.TargetContent = &ClientContent{
+		URL:      st.URL,
+		Metadata: map[string]string{"Content-Type": contentType},
+	}
+	if !dryRun {
+		metadata["Content-Type"] = contentType
+		if err = replaceMetadata(ctx, clnt, st, metadata); err != nil {
+			return sURLs.WithError(err.Trace(objectURL))
+		}
+	}
+	return sURLs
+}
+
+func mainMetadataFixContentType(cliCtx *cli.Context) error {
+	ctx, cancelMetadataFix := context.WithCancel(globalContext)
+	defer cancelMetadataFix()
+
+	console.SetColor("OldType", color.New(color.FgRed))
+	console.SetColor("NewType", color.New(color.FgGreen))
+	console.SetColor("Summary", color.New(color.FgGreen, color.Bold))
+
+	if len(cliCtx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "fix-content-type", globalInvalidArgsExitStatus)
+	}
+	targetURL := cliCtx.Args().Get(0)
+	sniff := cliCtx.Bool("sniff")
+	dryRun := cliCtx.Bool("dry-run")
+
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize `%s`.", targetURL)
+	if _, ok := clnt.(*S3Client); !ok {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Content types can only be fixed for objects on object storage.")
+	}
+	alias, _ := url2Alias(targetURL)
+
+	var pg *progressBar
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(0)
+		pg.SetUnits(pb.U_NO)
+		pg.ShowSpeed = false
+		pg.SetCaption("Checking: ")
+	}
+
+	statusCh := make(chan URLs)
+	parallel := newParallelManager(statusCh)
+	go func() {
+		defer close(statusCh)
+		defer parallel.stopAndWait()
+		if !cliCtx.Bool("recursive") {
+			if pg != nil {
+				pg.SetTotal(1)
+			}
+			parallel.queueTask(func() URLs {
+				return checkContentType(ctx, targetURL, sniff, dryRun)
+			})
+			return
+		}
+		var total int64
+		for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				statusCh <- URLs{Error: content.Err.Trace(targetURL)}
+				continue
+			}
+			total++
+			if pg != nil {
+				pg.SetTotal(total)
+			}
+			objectURL := alias + content.URL.Path
+			parallel.queueTask(func() URLs {
+				return checkContentType(ctx, objectURL, sniff, dryRun)
+			})
+		}
+	}()
+
+	summary := metadataFixSummary{Status: "success", DryRun: dryRun}
+	for sURLs := range statusCh {
+		if sURLs.SourceContent != nil {
+			summary.Checked++
+			if pg != nil {
+				pg.Increment()
+			}
+		}
+		if sURLs.Error != nil {
+			if pg != nil {
+				console.Eraseline()
+			}
+			errorIf(sURLs.Error, "Unable to fix the content type.")
+			summary.Failed++
+			continue
+		}
+		if sURLs.TargetContent == nil {
+			continue
+		}
+		summary.Fixed++
+		if pg != nil {
+			console.Eraseline()
+		}
+		printMsg(metadataFixMessage{
+			Status:  "success",
+			DryRun:  dryRun,
+			URL:     alias + sURLs.SourceContent.URL.Path,
+			OldType: sURLs.SourceContent.Metadata["Content-Type"],
+			NewType: sURLs.TargetContent.Metadata["Content-Type"],
+		})
+	}
+	if pg != nil {
+		pg.Finish()
+	}
+	printMsg(summary)
+	if summary.Failed > 0 {
+		return exitStatus(globalPartialExitStatus)
+	}
+	return nil
+}
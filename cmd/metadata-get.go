@@ -0,0 +1,136 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var metadataGetFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "version-id, vid",
+		Usage: "get metadata of a specific object version",
+	},
+}
+
+var metadataGetCmd = cli.Command{
+	Name:         "get",
+	Usage:        "get metadata of an object",
+	Action:       mainMetadataGet,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(metadataGetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Show the standard headers and the user defined metadata of an object.
+
+EXAMPLES:
+  1. Show the metadata of an object.
+     {{.Prompt}} {{.HelpName}} myminio/testbucket/testobject
+
+  2. Show the metadata of a particular version of an object in JSON format.
+     {{.Prompt}} {{.HelpName}} --json --version-id "ieQq7aXsyhlhDt47YURGlrucYY3GxWHa" myminio/testbucket/testobject
+`,
+}
+
+// metadataGetMessage structure for displaying object metadata
+type metadataGetMessage struct {
+	Status    string            `json:"status"`
+	URL       string            `json:"url"`
+	VersionID string            `json:"versionID,omitempty"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+func (m metadataGetMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", "  ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON for "+m.URL)
+	return string(msgBytes)
+}
+
+func (m metadataGetMessage) String() string {
+	keys := metadataKeys(m.Metadata)
+	maxKeyLen := 4 // len("Name")
+	for _, key := range keys {
+		if len(key) > maxKeyLen {
+			maxKeyLen = len(key)
+		}
+	}
+
+	name := m.URL
+	if m.VersionID != "" {
+		name += " (" + m.VersionID + ")"
+	}
+	maxKeyLen += 2 // add len(" :")
+	strs := []string{
+		fmt.Sprintf("%v%*v %v", console.Colorize("Name", "Name"), maxKeyLen-4, ":", console.Colorize("Name", name)),
+	}
+	for _, key := range keys {
+		strs = append(
+			strs,
+			fmt.Sprintf("%v%*v %v", console.Colorize("Key", key), maxKeyLen-len(key), ":", console.Colorize("Value", m.Metadata[key])),
+		)
+	}
+	return strings.Join(strs, "\n")
+}
+
+func mainMetadataGet(cliCtx *cli.Context) error {
+	ctx, cancelMetadataGet := context.WithCancel(globalContext)
+	defer cancelMetadataGet()
+
+	console.SetColor("Name", color.New(color.Bold, color.FgCyan))
+	console.SetColor("Key", color.New(color.FgGreen))
+	console.SetColor("Value", color.New(color.FgYellow))
+
+	if len(cliCtx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "get", globalInvalidArgsExitStatus)
+	}
+	targetURL := cliCtx.Args().Get(0)
+	versionID := cliCtx.String("version-id")
+
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target "+targetURL)
+
+	st, err := clnt.Stat(ctx, StatOptions{versionID: versionID})
+	fatalIf(err.Trace(targetURL), "Unable to get metadata of "+targetURL)
+	if st.Type.IsDir() {
+		fatalIf(errInvalidArgument().Trace(targetURL), "`"+targetURL+"` is not an object.")
+	}
+
+	printMsg(metadataGetMessage{
+		Status:    "success",
+		URL:       targetURL,
+		VersionID: st.VersionID,
+		Metadata:  editableMetadata(st.Metadata),
+	})
+	return nil
+}
@@ -0,0 +1,208 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var metadataSubcommands = []cli.Command{
+	metadataGetCmd,
+	metadataSetCmd,
+	metadataRemoveCmd,
+	metadataFixContentTypeCmd,
+}
+
+var metadataCmd = cli.Command{
+	Name:            "metadata",
+	Usage:           "manage metadata of object(s)",
+	Action:          mainMetadata,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands:     metadataSubcommands,
+}
+
+func mainMetadata(ctx *cli.Context) error {
+	commandNotFound(ctx, metadataSubcommands)
+	return nil
+}
+
+// Headers stored with an object which can be changed by a copy,
+// user defined metadata is stored under the X-Amz-Meta- prefix.
+var metadataStandardHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+	"X-Amz-Website-Redirect-Location",
+}
+
+const metadataUserPrefix = "X-Amz-Meta-"
+
+// normalizeMetadataKey returns the canonical header of a metadata key, keys
+// which are not standard headers are user defined metadata.
+func normalizeMetadataKey(key string) string {
+	key = http.CanonicalHeaderKey(strings.TrimSpace(key))
+	for _, header := range metadataStandardHeaders {
+		if key == header {
+			return key
+		}
+	}
+	if strings.HasPrefix(key, metadataUserPrefix) {
+		return key
+	}
+	return metadataUserPrefix + key
+}
+
+// editableMetadata extracts the metadata of an object which is preserved
+// or changed by a metadata update from the headers returned by stat.
+func editableMetadata(headers map[string]string) map[string]string {
+	metadata := map[string]string{}
+	for k, v := range headers {
+		k = http.CanonicalHeaderKey(k)
+		if strings.HasPrefix(k, metadataUserPrefix) {
+			metadata[k] = v
+			continue
+		}
+		for _, header := range metadataStandardHeaders {
+			if k == header {
+				metadata[k] = v
+			}
+		}
+	}
+	return metadata
+}
+
+// parseMetadataAttrs parses the --attr values, each holds one or more
+// KEY=VALUE pairs separated by ';'.
+func parseMetadataAttrs(attrs []string) (map[string]string, *probe.Error) {
+	metadata := map[string]string{}
+	for _, attr := range attrs {
+		entries, err := getMetaDataEntry(attr)
+		if err != nil {
+			return nil, err.Trace(attr)
+		}
+		for k, v := range entries {
+			metadata[normalizeMetadataKey(k)] = v
+		}
+	}
+	return metadata, nil
+}
+
+// mergeMetadata applies the given changes to the current metadata of an
+// object, the keys to remove are dropped after the new values are set.
+func mergeMetadata(current, set map[string]string, remove []string) map[string]string {
+	metadata := make(map[string]string, len(current)+len(set))
+	for k, v := range current {
+		metadata[k] = v
+	}
+	for k, v := range set {
+		metadata[k] = v
+	}
+	for _, k := range remove {
+		delete(metadata, normalizeMetadataKey(k))
+	}
+	return metadata
+}
+
+// metadataKeys returns the sorted keys of a metadata map.
+func metadataKeys(metadata map[string]string) []string {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// updateMetadata replaces the metadata of an object by copying it onto
+// itself on the server with the REPLACE metadata directive. The data and
+// the storage class of the object are left unchanged.
+func updateMetadata(ctx context.Context, objectURL string, set map[string]string, remove []string) (map[string]string, *probe.Error) {
+	clnt, err := newClient(objectURL)
+	if err != nil {
+		return nil, err.Trace(objectURL)
+	}
+	if _, ok := clnt.(*S3Client); !ok {
+		return nil, errInvalidArgument().Trace(objectURL)
+	}
+	st, err := clnt.Stat(ctx, StatOptions{})
+	if err != nil {
+		return nil, err.Trace(objectURL)
+	}
+	if st.Type.IsDir() {
+		return nil, probe.NewError(ObjectMissing{}).Trace(objectURL)
+	}
+	metadata := mergeMetadata(editableMetadata(st.Metadata), set, remove)
+	if err = replaceMetadata(ctx, clnt, st, metadata); err != nil {
+		return nil, err.Trace(objectURL)
+	}
+	return metadata, nil
+}
+
+// replaceMetadata copies an object onto itself with the given metadata.
+func replaceMetadata(ctx context.Context, clnt Client, st *ClientContent, metadata map[string]string) *probe.Error {
+	if _, ok := metadata["Content-Type"]; !ok {
+		// An empty metadata map would keep the current metadata.
+		metadata["Content-Type"] = "application/octet-stream"
+	}
+	opts := CopyOptions{
+		size:         st.Size,
+		metadata:     metadata,
+		storageClass: st.StorageClass,
+	}
+	return clnt.Copy(ctx, clnt.GetURL().Path, opts, nil)
+}
+
+// updateMetadataRecursive updates the metadata of all objects under a
+// prefix, a failure is reported and the remaining objects are updated.
+func updateMetadataRecursive(ctx context.Context, targetURL string, set map[string]string, remove []string) bool {
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize `%s`.", targetURL)
+	alias, _ := url2Alias(targetURL)
+
+	failed := false
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(targetURL), "Unable to list `%s`.", targetURL)
+			failed = true
+			continue
+		}
+		objectURL := alias + content.URL.Path
+		metadata, err := updateMetadata(ctx, objectURL, set, remove)
+		if err != nil {
+			errorIf(err.Trace(objectURL), "Unable to update metadata of `%s`.", objectURL)
+			failed = true
+			continue
+		}
+		printMsg(metadataUpdateMessage{
+			Status:   "success",
+			URL:      objectURL,
+			Metadata: metadata,
+		})
+	}
+	return !failed
+}
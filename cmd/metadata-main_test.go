@@ -0,0 +1,102 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeMetadataKey(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"cache-control", "Cache-Control"},
+		{"Content-Type", "Content-Type"},
+		{"x-amz-meta-team", "X-Amz-Meta-Team"},
+		{"team", "X-Amz-Meta-Team"},
+		{" owner ", "X-Amz-Meta-Owner"},
+	}
+	for i, testCase := range testCases {
+		if got := normalizeMetadataKey(testCase.key); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestMergeMetadata(t *testing.T) {
+	current := editableMetadata(map[string]string{
+		"Content-Type":    "text/plain",
+		"Content-Length":  "12",
+		"Etag":            "\"abc\"",
+		"X-Amz-Meta-Team": "storage",
+		"x-amz-meta-env":  "prod",
+	})
+	expected := map[string]string{
+		"Content-Type":    "text/plain",
+		"X-Amz-Meta-Team": "storage",
+		"X-Amz-Meta-Env":  "prod",
+	}
+	if !reflect.DeepEqual(current, expected) {
+		t.Fatalf("expected %v, got %v", expected, current)
+	}
+
+	set, err := parseMetadataAttrs([]string{"Cache-Control=max-age=3600", "team=infra;Owner=ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := mergeMetadata(current, set, []string{"x-amz-meta-env", "owner"})
+	expected = map[string]string{
+		"Cache-Control":   "max-age=3600",
+		"Content-Type":    "text/plain",
+		"X-Amz-Meta-Team": "infra",
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("expected %v, got %v", expected, merged)
+	}
+	if current["X-Amz-Meta-Env"] != "prod" {
+		t.Fatal("current metadata should not be modified")
+	}
+
+	if _, err = parseMetadataAttrs([]string{"team:infra"}); err == nil {
+		t.Fatal("expected an error for an invalid attribute")
+	}
+}
+
+func TestSameContentType(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"text/html", "text/html", true},
+		{"text/html; charset=utf-8", "text/html", true},
+		{"Text/HTML", "text/html", true},
+		{"application/octet-stream", "text/html", false},
+		{"", "text/html", false},
+	}
+	for i, testCase := range testCases {
+		if got := sameContentType(testCase.a, testCase.b); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+
+	summary := metadataFixSummary{DryRun: true, Checked: 10, Fixed: 2}
+	if got := summary.String(); got != "Checked 10 object(s), 2 would be fixed, nothing was changed." {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var metadataRemoveFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "attr",
+		Usage: "metadata key to remove, may be repeated",
+	},
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "remove metadata from all objects under the prefix",
+	},
+}
+
+var metadataRemoveCmd = cli.Command{
+	Name:         "rm",
+	Usage:        "remove metadata from object(s)",
+	Action:       mainMetadataRemove,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(metadataRemoveFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Remove standard headers and user defined metadata from objects without
+   uploading them again. Each object is copied onto itself on the server
+   without the removed keys.
+
+EXAMPLES:
+  1. Remove a user defined metadata from an object.
+     {{.Prompt}} {{.HelpName}} --attr X-Amz-Meta-Team myminio/testbucket/testobject
+
+  2. Remove the cache control header from all objects under a prefix.
+     {{.Prompt}} {{.HelpName}} --recursive --attr Cache-Control myminio/testbucket/site/
+`,
+}
+
+func mainMetadataRemove(cliCtx *cli.Context) error {
+	ctx, cancelMetadataRemove := context.WithCancel(globalContext)
+	defer cancelMetadataRemove()
+
+	console.SetColor("Metadata", color.New(color.FgGreen))
+
+	remove := cliCtx.StringSlice("attr")
+	if len(cliCtx.Args()) != 1 || len(remove) == 0 {
+		cli.ShowCommandHelpAndExit(cliCtx, "rm", globalInvalidArgsExitStatus)
+	}
+	targetURL := cliCtx.Args().Get(0)
+
+	if cliCtx.Bool("recursive") {
+		if !updateMetadataRecursive(ctx, targetURL, nil, remove) {
+			return exitStatus(globalPartialExitStatus)
+		}
+		return nil
+	}
+
+	metadata, err := updateMetadata(ctx, targetURL, nil, remove)
+	fatalIf(err, "Unable to remove metadata of `"+targetURL+"`.")
+	printMsg(metadataUpdateMessage{
+		Status:   "success",
+		URL:      targetURL,
+		Metadata: metadata,
+	})
+	return nil
+}
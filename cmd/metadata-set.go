@@ -0,0 +1,116 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var metadataSetFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "attr",
+		Usage: "metadata to set as KEY=VALUE, may be repeated",
+	},
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "set metadata on all objects under the prefix",
+	},
+}
+
+var metadataSetCmd = cli.Command{
+	Name:         "set",
+	Usage:        "set metadata of object(s)",
+	Action:       mainMetadataSet,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(metadataSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Set standard headers and user defined metadata of objects without
+   uploading them again. Each object is copied onto itself on the server
+   with its metadata replaced, the other metadata of the object is kept.
+   Keys which are not standard headers are stored as X-Amz-Meta- metadata.
+
+EXAMPLES:
+  1. Set the cache control header and a user defined metadata of an object.
+     {{.Prompt}} {{.HelpName}} --attr "Cache-Control=max-age=3600" --attr "X-Amz-Meta-Team=infra" myminio/testbucket/testobject
+
+  2. Set the content type of all objects under a prefix.
+     {{.Prompt}} {{.HelpName}} --recursive --attr "Content-Type=text/html" myminio/testbucket/site/
+`,
+}
+
+// metadataUpdateMessage structure for displaying updated object metadata
+type metadataUpdateMessage struct {
+	Status   string            `json:"status"`
+	URL      string            `json:"url"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+func (m metadataUpdateMessage) JSON() string {
+	msgBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+func (m metadataUpdateMessage) String() string {
+	return console.Colorize("Metadata", "Metadata updated for `"+m.URL+"`.")
+}
+
+func mainMetadataSet(cliCtx *cli.Context) error {
+	ctx, cancelMetadataSet := context.WithCancel(globalContext)
+	defer cancelMetadataSet()
+
+	console.SetColor("Metadata", color.New(color.FgGreen))
+
+	if len(cliCtx.Args()) != 1 || len(cliCtx.StringSlice("attr")) == 0 {
+		cli.ShowCommandHelpAndExit(cliCtx, "set", globalInvalidArgsExitStatus)
+	}
+	targetURL := cliCtx.Args().Get(0)
+	set, err := parseMetadataAttrs(cliCtx.StringSlice("attr"))
+	fatalIf(err, "Unable to parse --attr values.")
+
+	if cliCtx.Bool("recursive") {
+		if !updateMetadataRecursive(ctx, targetURL, set, nil) {
+			return exitStatus(globalPartialExitStatus)
+		}
+		return nil
+	}
+
+	metadata, err := updateMetadata(ctx, targetURL, set, nil)
+	fatalIf(err, "Unable to set metadata of `"+targetURL+"`.")
+	printMsg(metadataUpdateMessage{
+		Status:   "success",
+		URL:      targetURL,
+		Metadata: metadata,
+	})
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"os"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -91,6 +92,10 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		cli.BoolFlag{
+			Name:  "dedupe",
+			Usage: "copy server side from an existing object with identical content instead of uploading",
+		},
 		cli.StringSliceFlag{
 			Name:  "exclude",
 			Usage: "exclude object(s) that match specified object name pattern",
@@ -119,6 +124,15 @@ var (
 			Name:  "monitoring-address",
 			Usage: "if specified, a new prometheus endpoint will be created to report mirroring activity. (eg: localhost:8081)",
 		},
+		cli.StringFlag{
+			Name:  "on-complete",
+			Usage: "run a command for each mirrored object, {} is replaced by the target",
+		},
+		cli.StringFlag{
+			Name:  "active-hours",
+			Usage: "only transfer during a daily window of the local time, e.g. '22:00-06:00'",
+		},
+		maxMemoryFlag,
 	}
 )
 
@@ -129,7 +143,7 @@ var mirrorCmd = cli.Command{
 	Action:       mainMirror,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(append(mirrorFlags, ioFlags...), notifyFlags...), maxRPSFlag), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -196,6 +210,23 @@ EXAMPLES:
   16. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --active-active siteB siteA
+
+  17. Continuously mirror a local folder and notify an indexer of each uploaded object.
+      {{.Prompt}} {{.HelpName}} --watch --on-complete 'curl -s -d {key} http://indexer:8080/add' /var/lib/uploads play/uploads
+
+  18. Mirror a local folder with many duplicate files, uploading each distinct content only once.
+      {{.Prompt}} {{.HelpName}} --dedupe backup/ play/archive
+
+  19. Continuously mirror a bucket to a backup site outside of business hours only. Send SIGUSR1 to
+      pause mirroring at any time and SIGUSR2 to resume it.
+      {{.Prompt}} {{.HelpName}} --watch --active-hours 22:00-06:00 site1/data site2/data
+      {{.Prompt}} pkill -USR1 -f 'mc mirror'
+
+  20. Mirror a bucket and post a JSON summary of the outcome to a webhook once done.
+      {{.Prompt}} {{.HelpName}} --notify-url http://monitor:8080/jobs s3/archive myminio/archive
+
+  21. Mirror a bucket of a shared production cluster, sending at most 100 requests per second.
+      {{.Prompt}} {{.HelpName}} --max-rps 100 prod/data backup/data
 `,
 }
 
@@ -246,6 +277,19 @@ type mirrorJob struct {
 	targetURL string
 
 	opts mirrorOptions
+
+	// Records the progress of the mirror, nil in watch mode.
+	session *sessionV8
+
+	// Command run for each mirrored object, nil when not set.
+	onComplete *onCompleteHook
+
+	// Reports the outcome of the mirror, nil when not set.
+	notifier *jobNotifier
+
+	// Why mirroring is paused, empty when running.
+	pauseMu     sync.Mutex
+	pauseReason string
 }
 
 // mirrorMessage container for file mirror messages
@@ -263,10 +307,10 @@ func (m mirrorMessage) String() string {
 	return console.Colorize("Mirror", fmt.Sprintf("`%s` -> `%s`", m.Source, m.Target))
 }
 
-// JSON jsonified mirror message
+// JSON jsonified mirror message, one line per object
 func (m mirrorMessage) JSON() string {
 	m.Status = "success"
-	mirrorMessageBytes, e := json.MarshalIndent(m, "", " ")
+	mirrorMessageBytes, e := json.Marshal(m)
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
 	return string(mirrorMessageBytes)
@@ -321,6 +365,8 @@ func (mj *mirrorJob) doRemove(ctx context.Context, sURLs URLs) URLs {
 		return sURLs.WithError(nil)
 	}
 
+	mj.waitActive(ctx)
+
 	// Construct proper path with alias.
 	targetWithAlias := filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)
 	clnt, pErr := newClient(targetWithAlias)
@@ -394,6 +440,8 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 		return sURLs.WithError(nil)
 	}
 
+	mj.waitActive(ctx)
+
 	sourceAlias := sURLs.SourceAlias
 	sourceURL := sURLs.SourceContent.URL
 	targetAlias := sURLs.TargetAlias
@@ -434,6 +482,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 	})
 	sURLs.MD5 = mj.opts.md5
 	sURLs.DisableMultipart = mj.opts.disableMultipart
+	sURLs.Dedupe = mj.opts.dedupe
 	return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.opts.encKeyDB, mj.opts.isMetadata)
 }
 
@@ -443,23 +492,39 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 	mj.status.Start()
 	defer mj.status.Finish()
 
+	var copiedObjects, removedObjects, failedObjects int64
+	defer func() {
+		logSummary("mirror", map[string]interface{}{
+			"source":         mj.sourceURL,
+			"target":         mj.targetURL,
+			"copiedObjects":  copiedObjects,
+			"removedObjects": removedObjects,
+			"failedObjects":  failedObjects,
+			"transferred":    mj.status.Get(),
+		})
+		mj.notifier.count(copiedObjects, failedObjects, mj.status.Get())
+	}()
+
 	for sURLs := range mj.statusCh {
 		// Update prometheus fields
 		s3mirrorTotalOps.Inc()
 
 		if sURLs.Error != nil {
 			s3mirrorFailedOps.Inc()
+			failedObjects++
 			switch {
 			case sURLs.SourceContent != nil:
 				if !isErrIgnored(sURLs.Error) {
 					errorIf(sURLs.Error.Trace(sURLs.SourceContent.URL.String()),
 						fmt.Sprintf("Failed to copy `%s`.", sURLs.SourceContent.URL.String()))
+					mj.notifier.addError(sURLs.Error, sURLs.SourceContent.URL.String())
 					errDuringMirror = true
 				}
 			case sURLs.TargetContent != nil:
 				// When sURLs.SourceContent is nil, we know that we have an error related to removing
 				errorIf(sURLs.Error.Trace(sURLs.TargetContent.URL.String()),
 					fmt.Sprintf("Failed to remove `%s`.", sURLs.TargetContent.URL.String()))
+				mj.notifier.addError(sURLs.Error, sURLs.TargetContent.URL.String())
 				errDuringMirror = true
 			default:
 				if sURLs.ErrorCond == differInUnknown {
@@ -468,6 +533,7 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 					errorIf(sURLs.Error.Trace(),
 						"Failed to perform mirroring, with error condition (%s)", sURLs.ErrorCond)
 				}
+				mj.notifier.addError(sURLs.Error, "")
 				errDuringMirror = true
 			}
 			if mj.opts.activeActive {
@@ -478,7 +544,22 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 
 		if sURLs.SourceContent != nil {
 			s3mirrorTotalUploadedBytes.Add(float64(sURLs.SourceContent.Size))
+			if sURLs.Error == nil {
+				copiedObjects++
+				if mj.session != nil {
+					mj.session.addCopied(sURLs.SourceContent.Size)
+				}
+				if mj.onComplete != nil && !mj.opts.isFake {
+					if err := mj.onComplete.run(globalContext, sURLs); err != nil {
+						errorIf(err.Trace(sURLs.SourceContent.URL.String()), "Unable to run the --on-complete command.")
+						errDuringMirror = true
+					}
+				}
+			}
 		} else if sURLs.TargetContent != nil {
+			if sURLs.Error == nil {
+				removedObjects++
+			}
 			// Construct user facing message and path.
 			targetPath := filepath.ToSlash(filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path))
 			size := sURLs.TargetContent.Size
@@ -818,7 +899,7 @@ func getEventPathURLWin(srcURL, eventPath string) string {
 }
 
 // runMirror - mirrors all buckets to another S3 server
-func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dstURL string, cli *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
+func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dstURL string, cli *cli.Context, encKeyDB map[string][]prefixSSEPair, session *sessionV8, notifier *jobNotifier) bool {
 	// Parse metadata.
 	userMetadata := make(map[string]string)
 	if cli.String("attr") != "" {
@@ -854,6 +935,7 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		isMetadata:       isMetadata,
 		md5:              cli.Bool("md5"),
 		disableMultipart: cli.Bool("disable-multipart"),
+		dedupe:           cli.Bool("dedupe"),
 		excludeOptions:   cli.StringSlice("exclude"),
 		olderThan:        cli.String("older-than"),
 		newerThan:        cli.String("newer-than"),
@@ -863,8 +945,22 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		activeActive:     isWatch,
 	}
 
+	if hours := cli.String("active-hours"); hours != "" {
+		var e error
+		mopts.activeHours, e = parseActiveHours(hours)
+		fatalIf(probe.NewError(e), "Unable to parse active hours.")
+	}
+
+	// Never mirror nor remove the index objects of --dedupe.
+	if mopts.dedupe {
+		mopts.excludeOptions = append(mopts.excludeOptions, dedupeIndexPrefix+"*")
+	}
+
 	// Create a new mirror job and execute it
 	mj := newMirrorJob(srcURL, dstURL, mopts)
+	mj.session = session
+	mj.onComplete = newOnCompleteHook(cli.String("on-complete"), encKeyDB)
+	mj.notifier = notifier
 
 	preserve := cli.Bool("preserve")
 
@@ -962,12 +1058,15 @@ func mainMirror(cliCtx *cli.Context) error {
 	ctx, cancelMirror := context.WithCancel(globalContext)
 	defer cancelMirror()
 
+	setGlobalRateLimiter(cliCtx)
+
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
 	// check 'mirror' cli arguments.
 	srcURL, tgtURL := checkMirrorSyntax(ctx, cliCtx, encKeyDB)
+	setMaxMemoryFromContext(cliCtx)
 
 	if prometheusAddress := cliCtx.String("monitoring-address"); prometheusAddress != "" {
 		http.Handle("/metrics", promhttp.Handler())
@@ -979,22 +1078,73 @@ func mainMirror(cliCtx *cli.Context) error {
 		}()
 	}
 
+	isWatch := cliCtx.Bool("watch") || cliCtx.Bool("multi-master") || cliCtx.Bool("active-active")
+
+	// An interrupted mirror can be resumed with `mc session resume`,
+	// the objects already mirrored are then skipped.
+	var session *sessionV8
+	if !isWatch && !cliCtx.Bool("fake") {
+		session = newMirrorSession(cliCtx)
+	}
+
+	// The summary covers all the runs of a watching mirror.
+	notifier, err := newJobNotifier(cliCtx)
+	fatalIf(err, "Unable to parse --notify-url.")
+	defer notifier.send(jobSummary{Command: "mirror", Source: srcURL, Target: tgtURL})
+
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	for {
 		select {
 		case <-ctx.Done():
 			return exitStatus(globalErrorExitStatus)
 		default:
-			errorDetected := runMirror(ctx, cancelMirror, srcURL, tgtURL, cliCtx, encKeyDB)
-			if cliCtx.Bool("watch") || cliCtx.Bool("multi-master") || cliCtx.Bool("active-active") {
+			errorDetected := runMirror(ctx, cancelMirror, srcURL, tgtURL, cliCtx, encKeyDB, session, notifier)
+			// Mirroring stops quietly when the operation deadline is exceeded.
+			checkOperationDeadline()
+			if isWatch {
 				s3mirrorRestarts.Inc()
 				time.Sleep(time.Duration(r.Float64() * float64(2*time.Second)))
 				continue
 			}
 			if errorDetected {
-				return exitStatus(globalErrorExitStatus)
+				if session != nil {
+					// Keep the session to mirror the failed objects again.
+					session.Close()
+					if !globalQuiet && !globalJSON {
+						console.Infoln("Run `mc session resume " + shortSessionID(session.SessionID) + "` to mirror the failed objects again.")
+					}
+				}
+				// Objects failing to mirror do not stop mirroring the others.
+				return exitStatus(globalPartialExitStatus)
+			}
+			if session != nil {
+				session.Delete()
 			}
 			return nil
 		}
 	}
 }
+
+// newMirrorSession - get the session of the current mirror command,
+// progress is accumulated across the runs of the same command.
+func newMirrorSession(cliCtx *cli.Context) *sessionV8 {
+	sessionID := getHash("mirror", sessionArgs())
+	if isSessionExists(sessionID) {
+		if session, err := loadSessionV8(sessionID); err == nil {
+			session.Header.CommandLine = os.Args[1:]
+			return session
+		}
+	}
+
+	session := newSessionV8(sessionID)
+	session.Header.CommandType = "mirror"
+	session.Header.CommandArgs = cliCtx.Args()
+
+	var e error
+	if session.Header.RootPath, e = os.Getwd(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to get current working folder.")
+	}
+	fatalIf(session.Save().Trace(sessionID), "Unable to save session.")
+	return session
+}
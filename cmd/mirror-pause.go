@@ -0,0 +1,187 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// activeHours is a daily window, in the local time zone, during which
+// transfers run, e.g. 22:00-06:00. The window wraps around midnight
+// when it ends before it starts.
+type activeHours struct {
+	// Minutes since midnight.
+	start, end int
+	spec       string
+}
+
+// parseActiveHours parses a window such as "22:00-06:00".
+func parseActiveHours(spec string) (*activeHours, error) {
+	bounds := strings.Split(spec, "-")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid active hours `%s`, expected HH:MM-HH:MM", spec)
+	}
+	var minutes [2]int
+	for i, bound := range bounds {
+		tokens := strings.Split(strings.TrimSpace(bound), ":")
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("invalid active hours `%s`, expected HH:MM-HH:MM", spec)
+		}
+		hour, e1 := strconv.Atoi(tokens[0])
+		minute, e2 := strconv.Atoi(tokens[1])
+		if e1 != nil || e2 != nil || hour < 0 || hour > 24 || minute < 0 || minute > 59 || hour == 24 && minute != 0 {
+			return nil, fmt.Errorf("invalid time `%s` in active hours `%s`", bound, spec)
+		}
+		minutes[i] = hour*60 + minute
+	}
+	if minutes[0] == minutes[1] {
+		return nil, fmt.Errorf("active hours `%s` start and end at the same time", spec)
+	}
+	return &activeHours{start: minutes[0], end: minutes[1], spec: spec}, nil
+}
+
+// contains returns true if t is within the window.
+func (a *activeHours) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if a.start < a.end {
+		return minute >= a.start && minute < a.end
+	}
+	return minute >= a.start || minute < a.end
+}
+
+// next returns the time the window opens next, or t if it is open.
+func (a *activeHours) next(t time.Time) time.Time {
+	if a.contains(t) {
+		return t
+	}
+	next := time.Date(t.Year(), t.Month(), t.Day(), 0, a.start, 0, 0, t.Location())
+	if !next.After(t) {
+		next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, a.start, 0, 0, t.Location())
+	}
+	return next
+}
+
+func (a *activeHours) String() string {
+	return a.spec
+}
+
+// transferPause pauses transfers until resumed, by SIGUSR1 and SIGUSR2
+// or the daemon API. Transfers in progress complete, the next ones wait.
+type transferPause struct {
+	mu     sync.Mutex
+	paused bool
+	// Closed when resumed.
+	resumeCh chan struct{}
+}
+
+// globalTransferPause is paused and resumed by the signals.
+var globalTransferPause = &transferPause{}
+
+// set pauses or resumes the transfers.
+func (p *transferPause) set(paused bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if paused == p.paused {
+		return
+	}
+	p.paused = paused
+	if paused {
+		p.resumeCh = make(chan struct{})
+	} else {
+		close(p.resumeCh)
+	}
+}
+
+// isPaused returns true when paused, with a channel closed on resume.
+func (p *transferPause) isPaused() (bool, <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.paused, p.resumeCh
+}
+
+// mirrorPauseMessage is printed when mirroring pauses and resumes.
+type mirrorPauseMessage struct {
+	Status string     `json:"status"`
+	Reason string     `json:"reason,omitempty"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+func (m mirrorPauseMessage) String() string {
+	switch {
+	case m.Status == "resumed":
+		return console.Colorize("Mirror", "Resumed mirroring.")
+	case m.Until != nil:
+		return console.Colorize("Mirror", fmt.Sprintf("Paused mirroring until %s, outside of the active hours.", m.Until.Format("Jan 02 15:04")))
+	}
+	return console.Colorize("Mirror", "Paused mirroring, send SIGUSR2 to resume.")
+}
+
+func (m mirrorPauseMessage) JSON() string {
+	msgBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// waitActive blocks while mirroring is paused or outside of the active
+// hours, the pause and the resume are printed once.
+func (mj *mirrorJob) waitActive(ctx context.Context) {
+	for ctx.Err() == nil {
+		if paused, resumeCh := globalTransferPause.isPaused(); paused {
+			mj.reportPause(mirrorPauseMessage{Status: "paused", Reason: "signal"})
+			select {
+			case <-ctx.Done():
+			case <-resumeCh:
+			}
+			continue
+		}
+		if hours := mj.opts.activeHours; hours != nil {
+			now := time.Now()
+			if next := hours.next(now); next.After(now) {
+				mj.reportPause(mirrorPauseMessage{Status: "paused", Reason: "active hours", Until: &next})
+				timer := time.NewTimer(next.Sub(now))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+				case <-timer.C:
+				}
+				continue
+			}
+		}
+		mj.reportPause(mirrorPauseMessage{Status: "resumed"})
+		return
+	}
+}
+
+// reportPause prints the message if the pause state changed.
+func (mj *mirrorJob) reportPause(msg mirrorPauseMessage) {
+	mj.pauseMu.Lock()
+	defer mj.pauseMu.Unlock()
+	if msg.Reason == mj.pauseReason {
+		return
+	}
+	mj.pauseReason = msg.Reason
+	mj.status.PrintMsg(msg)
+}
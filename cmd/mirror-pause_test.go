@@ -0,0 +1,112 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseActiveHours(t *testing.T) {
+	testCases := []struct {
+		spec       string
+		start, end int
+		shouldErr  bool
+	}{
+		{"22:00-06:00", 22 * 60, 6 * 60, false},
+		{"09:30-17:45", 9*60 + 30, 17*60 + 45, false},
+		{"0:00-24:00", 0, 24 * 60, false},
+		{"22:00", 0, 0, true},
+		{"22-06", 0, 0, true},
+		{"25:00-06:00", 0, 0, true},
+		{"22:60-06:00", 0, 0, true},
+		{"24:30-06:00", 0, 0, true},
+		{"08:00-08:00", 0, 0, true},
+		{"aa:00-06:00", 0, 0, true},
+	}
+	for i, testCase := range testCases {
+		hours, e := parseActiveHours(testCase.spec)
+		if testCase.shouldErr {
+			if e == nil {
+				t.Fatalf("Test %d: expected error for %q", i+1, testCase.spec)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if hours.start != testCase.start || hours.end != testCase.end {
+			t.Fatalf("Test %d: expected %d-%d, got %d-%d", i+1, testCase.start, testCase.end, hours.start, hours.end)
+		}
+	}
+}
+
+func TestActiveHoursNext(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2021, time.March, day, hour, minute, 0, 0, time.UTC)
+	}
+	night, _ := parseActiveHours("22:00-06:00")
+	day, _ := parseActiveHours("09:00-17:00")
+	testCases := []struct {
+		hours    *activeHours
+		now      time.Time
+		expected time.Time
+	}{
+		{night, at(1, 23, 0), at(1, 23, 0)},
+		{night, at(1, 5, 59), at(1, 5, 59)},
+		{night, at(1, 6, 0), at(1, 22, 0)},
+		{night, at(1, 12, 0), at(1, 22, 0)},
+		{night, at(1, 22, 0), at(1, 22, 0)},
+		{day, at(1, 8, 0), at(1, 9, 0)},
+		{day, at(1, 10, 0), at(1, 10, 0)},
+		{day, at(1, 17, 0), at(2, 9, 0)},
+		{day, at(31, 18, 0), time.Date(2021, time.April, 1, 9, 0, 0, 0, time.UTC)},
+	}
+	for i, testCase := range testCases {
+		if next := testCase.hours.next(testCase.now); !next.Equal(testCase.expected) {
+			t.Errorf("Test %d: %s at %s expected %s, got %s", i+1, testCase.hours, testCase.now, testCase.expected, next)
+		}
+	}
+}
+
+func TestTransferPause(t *testing.T) {
+	p := &transferPause{}
+	if paused, _ := p.isPaused(); paused {
+		t.Fatal("expected not to be paused")
+	}
+	p.set(true)
+	p.set(true)
+	paused, resumeCh := p.isPaused()
+	if !paused {
+		t.Fatal("expected to be paused")
+	}
+	select {
+	case <-resumeCh:
+		t.Fatal("expected the resume channel to be open")
+	default:
+	}
+	p.set(false)
+	p.set(false)
+	select {
+	case <-resumeCh:
+	default:
+		t.Fatal("expected the resume channel to be closed")
+	}
+	if paused, _ := p.isPaused(); paused {
+		t.Fatal("expected not to be paused")
+	}
+}
@@ -36,7 +36,7 @@ import (
 // checkMirrorSyntax(URLs []string)
 func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) (srcURL, tgtURL string) {
 	if len(cliCtx.Args()) != 2 {
-		cli.ShowCommandHelpAndExit(cliCtx, "mirror", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(cliCtx, "mirror", globalInvalidArgsExitStatus) // last argument is exit code.
 	}
 
 	// extract URLs.
@@ -206,10 +206,11 @@ type mirrorOptions struct {
 	isWatch, isRemove, isMetadata     bool
 	excludeOptions                    []string
 	encKeyDB                          map[string][]prefixSSEPair
-	md5, disableMultipart             bool
+	md5, disableMultipart, dedupe     bool
 	olderThan, newerThan              string
 	storageClass                      string
 	userMetadata                      map[string]string
+	activeHours                       *activeHours
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
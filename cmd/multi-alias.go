@@ -0,0 +1,245 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// multiAliasFlags run read-only commands against several aliases.
+var multiAliasFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "aliases",
+		Usage: "run against each of the comma separated aliases, TARGET is relative to each alias",
+	},
+	cli.BoolFlag{
+		Name:  "all-aliases",
+		Usage: "run against all configured aliases, TARGET is relative to each alias",
+	},
+}
+
+// Number of aliases a command runs against at a time.
+const multiAliasConcurrency = 8
+
+// multiAliasMessage holds the output of a command run against one alias.
+type multiAliasMessage struct {
+	Status  string            `json:"status"`
+	Alias   string            `json:"alias"`
+	Results []json.RawMessage `json:"results,omitempty"`
+	Error   string            `json:"error,omitempty"`
+
+	output string
+}
+
+func (m multiAliasMessage) String() string {
+	header := console.Colorize("MultiAlias", "==> "+m.Alias+" <==")
+	if m.Status != "success" {
+		header += " " + console.Colorize("MultiAliasFailed", m.Error)
+	}
+	return header + "\n" + strings.TrimSuffix(m.output, "\n")
+}
+
+func (m multiAliasMessage) JSON() string {
+	jsonMessageBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// isMultiAlias returns true when a command is asked to run against several aliases.
+func isMultiAlias(ctx *cli.Context) bool {
+	return ctx.String("aliases") != "" || ctx.Bool("all-aliases")
+}
+
+// multiAliasList returns the aliases selected by --aliases or --all-aliases.
+func multiAliasList(ctx *cli.Context) []string {
+	if ctx.String("aliases") != "" && ctx.Bool("all-aliases") {
+		fatalIf(errInvalidArgument().Trace(), "`--aliases` and `--all-aliases` are mutually exclusive.")
+	}
+	var aliases []string
+	if ctx.Bool("all-aliases") {
+		conf, err := loadMcConfig()
+		fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
+		for alias, aliasCfg := range conf.Aliases {
+			// Skip the default entries which were never configured.
+			if aliasCfg.AccessKey == defaultAccessKey {
+				continue
+			}
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		return aliases
+	}
+	for _, alias := range strings.Split(ctx.String("aliases"), ",") {
+		alias = cleanAlias(strings.TrimSpace(alias))
+		if alias == "" {
+			continue
+		}
+		if mustGetHostConfig(alias) == nil {
+			fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
+
+// commandFlagArgs rebuilds the command line flags set on a command,
+// except the ones in skip.
+func commandFlagArgs(ctx *cli.Context, skip ...string) []string {
+	skipped := make(map[string]bool)
+	for _, name := range skip {
+		skipped[name] = true
+	}
+	var args []string
+	for _, flag := range ctx.Command.Flags {
+		name := strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+		if skipped[name] || !(ctx.IsSet(name) || ctx.GlobalIsSet(name)) {
+			continue
+		}
+		switch flag.(type) {
+		case cli.BoolFlag:
+			args = append(args, "--"+name)
+		case cli.BoolTFlag:
+			args = append(args, "--"+name+"="+strconv.FormatBool(ctx.BoolT(name)))
+		case cli.StringSliceFlag:
+			for _, value := range ctx.StringSlice(name) {
+				args = append(args, "--"+name+"="+value)
+			}
+		case cli.IntFlag:
+			args = append(args, "--"+name+"="+strconv.Itoa(ctx.Int(name)))
+		case cli.DurationFlag:
+			args = append(args, "--"+name+"="+ctx.Duration(name).String())
+		default:
+			args = append(args, "--"+name+"="+ctx.String(name))
+		}
+	}
+	return args
+}
+
+// runOnAliases runs the command of ctx once per selected alias, with
+// each argument made relative to the alias, and prints the output of
+// each alias in turn. Failing aliases do not stop the others.
+func runOnAliases(ctx *cli.Context) error {
+	console.SetColor("MultiAlias", color.New(color.FgCyan, color.Bold))
+	console.SetColor("MultiAliasFailed", color.New(color.FgRed))
+
+	aliases := multiAliasList(ctx)
+	if len(aliases) == 0 {
+		fatalIf(errInvalidArgument().Trace(), "No alias to run against.")
+	}
+
+	executable, e := os.Executable()
+	fatalIf(probe.NewError(e), "Unable to find the mc executable.")
+
+	cmdArgs := append([]string{"--config-dir", mustGetMcConfigDir(), "--profile", getMcProfile()}, strings.Fields(ctx.Command.FullName())...)
+	cmdArgs = append(cmdArgs, commandFlagArgs(ctx, "aliases", "all-aliases", "config-dir", "profile", "json")...)
+	if globalJSON {
+		cmdArgs = append(cmdArgs, "--json")
+	}
+
+	msgs := make([]multiAliasMessage, len(aliases))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, multiAliasConcurrency)
+	for i, alias := range aliases {
+		wg.Add(1)
+		go func(i int, alias string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			args := append([]string{}, cmdArgs...)
+			for _, arg := range ctx.Args() {
+				args = append(args, alias+"/"+strings.TrimPrefix(arg, "/"))
+			}
+			if len(ctx.Args()) == 0 {
+				args = append(args, alias)
+			}
+			msgs[i] = runOnAlias(executable, alias, args)
+		}(i, alias)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, msg := range msgs {
+		if msg.Status != "success" {
+			failed++
+		}
+		printMsg(msg)
+	}
+	switch {
+	case failed == len(msgs):
+		return exitStatus(globalErrorExitStatus)
+	case failed > 0:
+		return exitStatus(globalPartialExitStatus)
+	}
+	return nil
+}
+
+// runOnAlias runs mc with args and collects its output.
+func runOnAlias(executable, alias string, args []string) multiAliasMessage {
+	msg := multiAliasMessage{Status: "success", Alias: alias}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(executable, args...)
+	cmd.Env = childEnv()
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if e := cmd.Run(); e != nil {
+		msg.Status = "error"
+		var exitErr *exec.ExitError
+		if errors.As(e, &exitErr) {
+			msg.Error = fmt.Sprintf("exit status %d", exitErr.ExitCode())
+		} else {
+			msg.Error = e.Error()
+		}
+	}
+
+	if !globalJSON {
+		msg.output = stdout.String() + stderr.String()
+		return msg
+	}
+	// The JSON output is a stream of messages, some of them indented.
+	dec := json.NewDecoder(&stdout)
+	for {
+		var result json.RawMessage
+		if e := dec.Decode(&result); e != nil {
+			if e != io.EOF && msg.Error == "" {
+				msg.Status = "error"
+				msg.Error = "unable to parse the output: " + e.Error()
+			}
+			break
+		}
+		msg.Results = append(msg.Results, result)
+	}
+	if msg.Error != "" && len(msg.Results) == 0 {
+		msg.Error = strings.TrimSpace(stderr.String())
+	}
+	return msg
+}
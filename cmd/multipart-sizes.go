@@ -0,0 +1,84 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+)
+
+// Part size and threshold of the multipart uploads set via command
+// line, override the ones of the alias.
+var (
+	globalPartSize           uint64
+	globalMultipartThreshold uint64
+)
+
+const (
+	// Sizes of a part accepted by S3, and the default of minio-go.
+	minPartSize     = 5 * humanize.MiByte
+	defaultPartSize = 16 * humanize.MiByte
+	maxPartSize     = 5 * humanize.GiByte
+	// Maximum size of an object uploaded with a single PUT.
+	maxSinglePutSize = 5 * humanize.GiByte
+	// Objects of this size or larger are copied server side in parts,
+	// unless --multipart-threshold is set.
+	defaultCopyMultipartThreshold = 64 * humanize.MiByte
+)
+
+// parseSizeFlag parses the size given to a flag, zero when not given.
+func parseSizeFlag(ctx *cli.Context, name string) uint64 {
+	value := ctx.String(name)
+	if value == "" {
+		value = ctx.GlobalString(name)
+	}
+	if value == "" {
+		return 0
+	}
+	size, e := humanize.ParseBytes(value)
+	if e != nil || size == 0 {
+		fatalIf(errInvalidArgument().Trace(value), "Invalid value for --%s, must be a size, e.g. '64MiB'.", name)
+	}
+	return size
+}
+
+// setMultipartSizesFromContext sets the part size and the threshold of
+// the multipart uploads given via command line.
+func setMultipartSizesFromContext(ctx *cli.Context) {
+	if size := parseSizeFlag(ctx, "part-size"); size > 0 {
+		if size < minPartSize || size > maxPartSize {
+			fatalIf(errInvalidArgument().Trace(ctx.String("part-size")), "Invalid value for --part-size, must be between 5MiB and 5GiB.")
+		}
+		globalPartSize = size
+	}
+	if size := parseSizeFlag(ctx, "multipart-threshold"); size > 0 {
+		if size > maxSinglePutSize {
+			fatalIf(errInvalidArgument().Trace(ctx.String("multipart-threshold")), "Invalid value for --multipart-threshold, must be 5GiB at most.")
+		}
+		// Objects smaller than a part are always uploaded with a single
+		// request, a lower threshold would have no effect.
+		partSize := uint64(defaultPartSize)
+		if globalPartSize > 0 {
+			partSize = globalPartSize
+		}
+		if size < partSize {
+			fatalIf(errInvalidArgument().Trace(ctx.String("multipart-threshold")),
+				"Invalid value for --multipart-threshold, must be at least the part size of %s.", humanize.IBytes(partSize))
+		}
+		globalMultipartThreshold = size
+	}
+}
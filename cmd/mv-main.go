@@ -67,6 +67,10 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		cli.StringFlag{
+			Name:  "rewrite",
+			Usage: "rename the objects of a bucket on the server, replacing a key prefix, e.g. 'old-prefix/=new-prefix/'",
+		},
 	}
 )
 
@@ -83,6 +87,11 @@ var mvCmd = cli.Command{
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE [SOURCE...] TARGET
+  {{.HelpName}} [FLAGS] --rewrite OLD-PREFIX=NEW-PREFIX ALIAS/BUCKET
+
+  With --rewrite, the objects of the bucket whose key starts with OLD-PREFIX
+  are copied on the server to the key with NEW-PREFIX instead, then removed
+  in batches. Run the same command again to resume an interrupted rename.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -140,6 +149,9 @@ EXAMPLES:
 
   16. Move a text file to an object storage and disable multipart upload feature.
       {{.Prompt}} {{.HelpName}} --disable-multipart myobject.txt play/mybucket
+
+  17. Rename the folder 'photos/2020/' of a bucket to 'archive/photos/2020/' on the server.
+      {{.Prompt}} {{.HelpName}} --rewrite 'photos/2020/=archive/photos/2020/' play/mybucket
 `,
 }
 
@@ -216,6 +228,11 @@ func mainMove(cliCtx *cli.Context) error {
 	encKeyDB, err := getEncKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
+	if cliCtx.IsSet("rewrite") {
+		console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+		return mainMoveRewrite(ctx, cliCtx, encKeyDB)
+	}
+
 	// Parse metadata.
 	userMetaMap := make(map[string]string)
 	if cliCtx.String("attr") != "" {
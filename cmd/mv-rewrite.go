@@ -0,0 +1,232 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// mvRewriteMessage container for an object renamed by `mv --rewrite`.
+type mvRewriteMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+}
+
+func (m mvRewriteMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", m.Source, m.Target))
+}
+
+func (m mvRewriteMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// parseRewriteRule parses a rule such as "old-prefix/=new-prefix/".
+func parseRewriteRule(rule string) (oldPrefix, newPrefix string, e error) {
+	i := strings.Index(rule, "=")
+	if i < 0 {
+		return "", "", fmt.Errorf("invalid rewrite rule `%s`, expected OLD-PREFIX=NEW-PREFIX", rule)
+	}
+	oldPrefix = strings.TrimPrefix(rule[:i], "/")
+	newPrefix = strings.TrimPrefix(rule[i+1:], "/")
+	switch {
+	case oldPrefix == "":
+		return "", "", fmt.Errorf("invalid rewrite rule `%s`, the old prefix cannot be empty", rule)
+	case strings.HasPrefix(newPrefix, oldPrefix):
+		// The renamed objects would be listed and renamed again.
+		return "", "", fmt.Errorf("invalid rewrite rule `%s`, the new prefix cannot start with the old prefix", rule)
+	}
+	return oldPrefix, newPrefix, nil
+}
+
+// rewriteKey replaces the old prefix of a key by the new prefix.
+func rewriteKey(key, oldPrefix, newPrefix string) string {
+	return newPrefix + strings.TrimPrefix(key, oldPrefix)
+}
+
+// mvRewriteObject copies an object to its rewritten key on the server.
+func mvRewriteObject(ctx context.Context, sURLs URLs, pg ProgressReader, encKeyDB map[string][]prefixSSEPair) URLs {
+	sourcePath := sURLs.SourceContent.URL.Path
+	targetURL := sURLs.TargetContent.URL.String()
+	if progressReader, ok := pg.(*progressBar); ok {
+		progressReader.SetCaption(sURLs.SourceAlias + sourcePath + ": ")
+	} else {
+		printMsg(mvRewriteMessage{
+			Source: sURLs.SourceAlias + sourcePath,
+			Target: targetURL,
+			Size:   sURLs.SourceContent.Size,
+		})
+	}
+	targetClnt, err := newClient(targetURL)
+	if err != nil {
+		return sURLs.WithError(err.Trace(targetURL))
+	}
+	opts := CopyOptions{
+		size:   sURLs.SourceContent.Size,
+		srcSSE: getSSE(sURLs.SourceAlias+sourcePath, encKeyDB[sURLs.SourceAlias]),
+		tgtSSE: getSSE(targetURL, encKeyDB[sURLs.TargetAlias]),
+	}
+	if err = targetClnt.Copy(ctx, sourcePath, opts, pg); err != nil {
+		return sURLs.WithError(err.Trace(sourcePath, targetURL))
+	}
+	return sURLs.WithError(nil)
+}
+
+// mainMoveRewrite renames the objects of a bucket under a prefix by server
+// side copies to the rewritten keys, the originals are removed in batches
+// once copied. Running it again moves the objects left under the prefix.
+func mainMoveRewrite(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	if cliCtx.NArg() != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "mv", globalInvalidArgsExitStatus)
+	}
+	oldPrefix, newPrefix, e := parseRewriteRule(cliCtx.String("rewrite"))
+	fatalIf(probe.NewError(e), "Unable to parse the rewrite rule.")
+
+	bucketURL := strings.TrimSuffix(cliCtx.Args().Get(0), "/")
+	clnt, err := newClient(bucketURL)
+	fatalIf(err.Trace(bucketURL), "Unable to initialize `%s`.", bucketURL)
+	bucketClnt, ok := clnt.(*S3Client)
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(bucketURL), "--rewrite only renames objects of buckets on object storage.")
+	}
+	bucket, object := bucketClnt.url2BucketAndObject()
+	if bucket == "" || object != "" {
+		fatalIf(errInvalidArgument().Trace(bucketURL), "--rewrite expects ALIAS/BUCKET, the prefixes are given by the rule.")
+	}
+	enabled, err := isBucketLockEnabled(ctx, bucketURL)
+	fatalIf(err.Trace(bucketURL), "Unable to get bucket lock configuration of `%s`", bucketURL)
+	if enabled {
+		fatalIf(errDummy().Trace(), fmt.Sprintf("Object lock configuration is enabled on the specified bucket in alias %v.", bucketURL))
+	}
+
+	alias, _ := url2Alias(bucketURL)
+	listURL := urlJoinPath(bucketURL, oldPrefix)
+	listClnt, err := newClient(listURL)
+	fatalIf(err.Trace(listURL), "Unable to initialize `%s`.", listURL)
+	olderThan := cliCtx.String("older-than")
+	newerThan := cliCtx.String("newer-than")
+
+	var pg ProgressReader
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(0)
+	} else {
+		pg = newAccounter(0)
+	}
+
+	statusCh := make(chan URLs)
+	parallel := newParallelManager(statusCh)
+
+	// List the objects under the old prefix and queue their copies.
+	go func() {
+		defer close(statusCh)
+		defer parallel.stopAndWait()
+		var totalBytes int64
+		for content := range listClnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err != nil {
+				statusCh <- URLs{Error: content.Err.Trace(listURL)}
+				continue
+			}
+			key := strings.TrimPrefix(content.URL.Path, "/"+bucket+"/")
+			if !strings.HasPrefix(key, oldPrefix) {
+				continue
+			}
+			if isOlder(content.Time, olderThan) || isNewer(content.Time, newerThan) {
+				continue
+			}
+			targetURL := newClientURL(urlJoinPath(bucketURL, rewriteKey(key, oldPrefix, newPrefix)))
+			sURLs := URLs{
+				SourceAlias:   alias,
+				SourceContent: content,
+				TargetAlias:   alias,
+				TargetContent: &ClientContent{URL: *targetURL},
+			}
+			totalBytes += content.Size
+			pg.SetTotal(totalBytes)
+			parallel.queueTask(func() URLs {
+				return mvRewriteObject(ctx, sURLs, pg, encKeyDB)
+			})
+		}
+	}()
+
+	// The originals are removed in batches once copied.
+	removeCh := make(chan *ClientContent, 1000)
+	removeErrCh := clnt.Remove(ctx, false, false, false, removeCh)
+	var failed int32
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for err := range removeErrCh {
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			errorIf(err.Trace(bucketURL), "Unable to remove the original object.")
+			atomic.StoreInt32(&failed, 1)
+		}
+	}()
+
+	var movedObjects, failedObjects int64
+	for sURLs := range statusCh {
+		if sURLs.Error != nil {
+			if !globalQuiet && !globalJSON {
+				console.Eraseline()
+			}
+			errorIf(sURLs.Error, "Unable to move object.")
+			atomic.StoreInt32(&failed, 1)
+			failedObjects++
+			continue
+		}
+		movedObjects++
+		removeCh <- &ClientContent{URL: sURLs.SourceContent.URL}
+	}
+	close(removeCh)
+	wg.Wait()
+
+	if progressReader, ok := pg.(*progressBar); ok {
+		if progressReader.ProgressBar.Get() > 0 {
+			progressReader.ProgressBar.Finish()
+		}
+	} else if accntReader, ok := pg.(*accounter); ok {
+		printMsg(accntReader.Stat())
+	}
+	logSummary("mv", map[string]interface{}{
+		"movedObjects":  movedObjects,
+		"failedObjects": failedObjects,
+		"transferred":   pg.Get(),
+	})
+
+	if atomic.LoadInt32(&failed) != 0 {
+		if !globalQuiet && !globalJSON {
+			console.Infoln("Run the same command again to move the objects left under `" + oldPrefix + "`.")
+		}
+		return exitStatus(globalPartialExitStatus)
+	}
+	return nil
+}
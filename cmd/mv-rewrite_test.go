@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestParseRewriteRule(t *testing.T) {
+	testCases := []struct {
+		rule                 string
+		oldPrefix, newPrefix string
+		shouldErr            bool
+	}{
+		{"photos/2020/=archive/2020/", "photos/2020/", "archive/2020/", false},
+		{"/photos/=/archive/", "photos/", "archive/", false},
+		{"img-=image-", "img-", "image-", false},
+		{"tmp/=", "tmp/", "", false},
+		{"a/b/=a/", "a/b/", "a/", false},
+		{"a=b=c", "a", "b=c", false},
+		{"photos/", "", "", true},
+		{"=archive/", "", "", true},
+		{"photos/=photos/", "", "", true},
+		{"photos/=photos/old/", "", "", true},
+	}
+	for i, testCase := range testCases {
+		oldPrefix, newPrefix, e := parseRewriteRule(testCase.rule)
+		if testCase.shouldErr {
+			if e == nil {
+				t.Fatalf("Test %d: expected error for %q", i+1, testCase.rule)
+			}
+			continue
+		}
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if oldPrefix != testCase.oldPrefix || newPrefix != testCase.newPrefix {
+			t.Fatalf("Test %d: expected %q=%q, got %q=%q", i+1, testCase.oldPrefix, testCase.newPrefix, oldPrefix, newPrefix)
+		}
+	}
+}
+
+func TestRewriteKey(t *testing.T) {
+	testCases := []struct {
+		key, oldPrefix, newPrefix string
+		expected                  string
+	}{
+		{"photos/2020/a.jpg", "photos/2020/", "archive/2020/", "archive/2020/a.jpg"},
+		{"img-1.png", "img-", "image-", "image-1.png"},
+		{"tmp/x/y", "tmp/", "", "x/y"},
+		{"a/b/c", "a/b/", "a/", "a/c"},
+	}
+	for i, testCase := range testCases {
+		if got := rewriteKey(testCase.key, testCase.oldPrefix, testCase.newPrefix); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Flags of the commands reporting their outcome to a URL.
+var notifyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "notify-url",
+		Usage: "post a summary of the operation to this URL once it completes",
+	},
+	cli.StringFlag{
+		Name:  "notify-template",
+		Usage: "Go template of the payload posted to --notify-url (default: the summary as JSON)",
+	},
+}
+
+const (
+	// Errors beyond this number are only counted in the summary.
+	notifyMaxErrors = 100
+	notifyTimeout   = 30 * time.Second
+)
+
+// jobSummary is the outcome of a cp, mirror or admin heal run posted
+// to --notify-url.
+type jobSummary struct {
+	Command   string    `json:"command"`
+	Status    string    `json:"status"`
+	Source    string    `json:"source,omitempty"`
+	Target    string    `json:"target"`
+	Objects   int64     `json:"objects"`
+	Failed    int64     `json:"failed"`
+	Bytes     int64     `json:"bytes"`
+	StartTime time.Time `json:"startTime"`
+	Duration  string    `json:"duration"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// jobNotifier collects the errors of an operation and posts its
+// summary once it completes. The payload is the summary as JSON, or
+// the result of the user template which sees the fields of jobSummary
+// along with the functions json, bytes and join, e.g. for Slack:
+//
+//	{"text": {{json (printf "%s %s: %d objects, %s in %s" .Command .Status .Objects (bytes .Bytes) .Duration)}}}
+type jobNotifier struct {
+	url      string
+	template *template.Template
+	start    time.Time
+
+	mu      sync.Mutex
+	errors  []string
+	dropped int
+
+	// Counters of operations made of several runs.
+	objects, failed, bytes int64
+}
+
+// newJobNotifier returns nil when --notify-url is not set.
+func newJobNotifier(ctx *cli.Context) (*jobNotifier, *probe.Error) {
+	endpoint := ctx.String("notify-url")
+	if endpoint == "" {
+		if ctx.String("notify-template") != "" {
+			return nil, probe.NewError(fmt.Errorf("--notify-template requires --notify-url"))
+		}
+		return nil, nil
+	}
+	u, e := url.Parse(endpoint)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, probe.NewError(fmt.Errorf("unsupported --notify-url `%s`, expected an http or https URL", endpoint))
+	}
+	n := &jobNotifier{url: endpoint, start: time.Now().UTC()}
+	if text := ctx.String("notify-template"); text != "" {
+		n.template, e = parseNotifyTemplate(text)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+	}
+	return n, nil
+}
+
+// parseNotifyTemplate parses a --notify-template.
+func parseNotifyTemplate(text string) (*template.Template, error) {
+	return template.New("notify").Option("missingkey=error").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, e := json.Marshal(v)
+			return string(data), e
+		},
+		"bytes": func(n int64) string {
+			return humanize.IBytes(uint64(n))
+		},
+		"join": strings.Join,
+	}).Parse(text)
+}
+
+// addError records an error of the operation, safe to call on a nil notifier.
+func (n *jobNotifier) addError(err *probe.Error, object string) {
+	if n == nil || err == nil {
+		return
+	}
+	msg := err.ToGoError().Error()
+	if object != "" {
+		msg = object + ": " + msg
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.errors) < notifyMaxErrors {
+		n.errors = append(n.errors, msg)
+	} else {
+		n.dropped++
+	}
+}
+
+// count accumulates the counters of a run, safe to call on a nil notifier.
+func (n *jobNotifier) count(objects, failed, bytes int64) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.objects += objects
+	n.failed += failed
+	n.bytes += bytes
+}
+
+// summary completes s with the duration and the recorded errors.
+func (n *jobNotifier) summary(s jobSummary) jobSummary {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s.Objects += n.objects
+	s.Failed += n.failed
+	s.Bytes += n.bytes
+	s.StartTime = n.start
+	s.Duration = time.Since(n.start).Round(time.Second).String()
+	s.Errors = append([]string{}, n.errors...)
+	if n.dropped > 0 {
+		s.Errors = append(s.Errors, fmt.Sprintf("... and %d more errors", n.dropped))
+	}
+	if globalContext.Err() != nil {
+		s.Errors = append(s.Errors, "interrupted before completion")
+	}
+	if s.Status == "" {
+		s.Status = "success"
+		if s.Failed > 0 || len(s.Errors) > 0 {
+			s.Status = "failure"
+		}
+	}
+	return s
+}
+
+// payload renders the summary to post.
+func (n *jobNotifier) payload(s jobSummary) ([]byte, error) {
+	if n.template == nil {
+		return json.Marshal(s)
+	}
+	var buf bytes.Buffer
+	if e := n.template.Execute(&buf, s); e != nil {
+		return nil, e
+	}
+	return buf.Bytes(), nil
+}
+
+// send posts the summary of the completed operation, safe to call on
+// a nil notifier. It does not use the global context so that the
+// outcome of an interrupted operation is reported as well.
+func (n *jobNotifier) send(s jobSummary) {
+	if n == nil {
+		return
+	}
+	errorIf(n.post(n.summary(s)).Trace(n.url), "Unable to post the summary to --notify-url.")
+}
+
+func (n *jobNotifier) post(s jobSummary) *probe.Error {
+	payload, e := n.payload(s)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, e := http.DefaultClient.Do(req)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return probe.NewError(fmt.Errorf("`%s` returned %s", n.url, resp.Status))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestJobNotifierSummary(t *testing.T) {
+	n := &jobNotifier{start: time.Now().UTC()}
+	s := n.summary(jobSummary{Command: "cp", Target: "play/bucket", Objects: 3, Bytes: 2048})
+	if s.Status != "success" || len(s.Errors) != 0 {
+		t.Fatalf("expected a successful summary, got %+v", s)
+	}
+
+	for i := 0; i < notifyMaxErrors+2; i++ {
+		n.addError(probe.NewError(errors.New("access denied")), fmt.Sprintf("play/bucket/%d", i))
+	}
+	n.count(1, 1, 10)
+	s = n.summary(jobSummary{Command: "mirror", Objects: 3})
+	if s.Status != "failure" || s.Objects != 4 || s.Failed != 1 || s.Bytes != 10 {
+		t.Fatalf("unexpected summary %+v", s)
+	}
+	if len(s.Errors) != notifyMaxErrors+1 {
+		t.Fatalf("expected %d errors, got %d", notifyMaxErrors+1, len(s.Errors))
+	}
+	if s.Errors[0] != "play/bucket/0: access denied" || s.Errors[notifyMaxErrors] != "... and 2 more errors" {
+		t.Fatalf("unexpected errors %q, %q", s.Errors[0], s.Errors[notifyMaxErrors])
+	}
+
+	// Calls on a nil notifier are no-ops.
+	var none *jobNotifier
+	none.addError(probe.NewError(errors.New("ignored")), "")
+	none.count(1, 0, 0)
+	none.send(jobSummary{})
+}
+
+func TestJobNotifierTemplate(t *testing.T) {
+	tmpl, e := parseNotifyTemplate(`{"text": {{json (printf "%s %s: %d objects, %s" .Command .Status .Objects (bytes .Bytes))}}}`)
+	if e != nil {
+		t.Fatal(e)
+	}
+	n := &jobNotifier{template: tmpl}
+	payload, e := n.payload(jobSummary{Command: "cp", Status: "success", Objects: 2, Bytes: 2048})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if expected := `{"text": "cp success: 2 objects, 2.0 KiB"}`; string(payload) != expected {
+		t.Fatalf("expected %s, got %s", expected, payload)
+	}
+	if _, e = parseNotifyTemplate("{{.Command"); e == nil {
+		t.Fatal("expected an invalid template to fail")
+	}
+}
+
+func TestJobNotifierPost(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		if strings.Contains(body, "reject") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	n := &jobNotifier{url: server.URL}
+	if err := n.post(jobSummary{Command: "cp", Status: "success", Target: "play/bucket"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, `"target":"play/bucket"`) {
+		t.Fatalf("unexpected payload %s", body)
+	}
+	if err := n.post(jobSummary{Command: "cp", Target: "play/reject"}); err == nil {
+		t.Fatal("expected an error status to fail")
+	}
+}
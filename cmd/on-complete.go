@@ -0,0 +1,252 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// onCompleteHook runs a user command for every object transferred
+// successfully by cp or mirror. Placeholders in the command are
+// replaced by the properties of the transferred object:
+//
+//	{}, {target}  target of the transfer, e.g. myminio/bucket/dir/object
+//	{source}      source of the transfer
+//	{bucket}      bucket of the target, empty on a filesystem target
+//	{key}         key of the object in the target bucket
+//	{size}        size in bytes
+//	{etag}        ETag of the target object, empty on a filesystem target
+//
+// Substituted values are quoted for the shell running the command.
+type onCompleteHook struct {
+	command  string
+	encKeyDB map[string][]prefixSSEPair
+}
+
+// newOnCompleteHook returns nil when no command is configured.
+func newOnCompleteHook(command string, encKeyDB map[string][]prefixSSEPair) *onCompleteHook {
+	if strings.TrimSpace(command) == "" {
+		return nil
+	}
+	return &onCompleteHook{command: command, encKeyDB: encKeyDB}
+}
+
+// vars returns the placeholder values of a transfer.
+func (h *onCompleteHook) vars(ctx context.Context, urls URLs) map[string]string {
+	targetURL := urls.TargetContent.URL
+	target := filepath.ToSlash(filepath.Join(urls.TargetAlias, targetURL.Path))
+
+	bucket, key := "", targetURL.Path
+	if targetURL.Type == objectStorage {
+		parts := splitStr(strings.TrimPrefix(targetURL.Path, string(targetURL.Separator)), string(targetURL.Separator), 2)
+		bucket, key = parts[0], parts[1]
+	}
+
+	var size int64
+	if urls.SourceContent != nil {
+		size = urls.SourceContent.Size
+	}
+
+	// The ETag is only known to the server, avoid
+	// the extra request when it is not needed.
+	var etag string
+	if targetURL.Type == objectStorage && strings.Contains(h.command, "{etag}") {
+		_, content, err := url2Stat(ctx, target, "", false, h.encKeyDB, time.Time{})
+		if err == nil {
+			etag = strings.Trim(content.ETag, "\"")
+		}
+	}
+
+	var source string
+	if urls.SourceContent != nil {
+		source = filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path))
+	}
+
+	return map[string]string{
+		"{}":       target,
+		"{target}": target,
+		"{source}": source,
+		"{bucket}": bucket,
+		"{key}":    key,
+		"{size}":   strconv.FormatInt(size, 10),
+		"{etag}":   etag,
+	}
+}
+
+// expandOnCompleteCommand replaces the placeholders of command by
+// their values, quoted for the shell running the command.
+func expandOnCompleteCommand(command string, vars map[string]string) string {
+	return expandShellCommand(command, vars, runtime.GOOS == "windows")
+}
+
+// expandShellCommand replaces the placeholders of command by their
+// values quoted for sh, or for cmd.exe on windows. A placeholder within
+// quotes of the command, e.g. "{}", is escaped for the quotes around it.
+func expandShellCommand(command string, vars map[string]string, windows bool) string {
+	if windows {
+		return expandCmdCommand(command, vars)
+	}
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(command); i++ {
+		if placeholder, value, ok := matchPlaceholder(command[i:], vars); ok {
+			b.WriteString(shQuote(value, quote))
+			i += len(placeholder) - 1
+			continue
+		}
+		c := command[i]
+		switch {
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote != 0 && c == quote:
+			quote = 0
+		case c == '\\' && quote != '\'' && i+1 < len(command):
+			// The escaped character is copied as is.
+			b.WriteByte(c)
+			i++
+			c = command[i]
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// matchPlaceholder returns the placeholder s starts with, if any.
+func matchPlaceholder(s string, vars map[string]string) (string, string, bool) {
+	if !strings.HasPrefix(s, "{") {
+		return "", "", false
+	}
+	for placeholder, value := range vars {
+		if strings.HasPrefix(s, placeholder) {
+			return placeholder, value, true
+		}
+	}
+	return "", "", false
+}
+
+// shQuote quotes s for sh so that it is passed as a single argument,
+// within the quotes of the command given by quote if any.
+func shQuote(s string, quote byte) string {
+	switch quote {
+	case '\'':
+		return strings.Replace(s, "'", `'\''`, -1)
+	case '"':
+		return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(s)
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// expandCmdCommand replaces the placeholders of command by their values
+// quoted for cmd.exe. Values are quoted for the command line parsing of
+// the programs, then the metacharacters of cmd.exe are escaped with ^.
+// The quotes of the command are escaped as well, with the metacharacters
+// within them, so that cmd.exe passes the quoted parts as they are
+// without tracking quotes which the values may open or close.
+func expandCmdCommand(command string, vars map[string]string) string {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(command); i++ {
+		if placeholder, value, ok := matchPlaceholder(command[i:], vars); ok {
+			i += len(placeholder) - 1
+			if quoted {
+				beforeQuote := i+1 < len(command) && command[i+1] == '"'
+				b.WriteString(cmdEscape(argvEscape(value, beforeQuote)))
+			} else {
+				b.WriteString(cmdEscape(`"` + argvEscape(value, true) + `"`))
+			}
+			continue
+		}
+		c := command[i]
+		if c == '"' {
+			quoted = !quoted
+		}
+		if quoted || c == '"' {
+			if strings.IndexByte(`"&|<>^()`, c) >= 0 {
+				b.WriteByte('^')
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// argvEscape escapes s within quotes of a windows command line,
+// beforeQuote tells if the closing quote follows s.
+func argvEscape(s string, beforeQuote bool) string {
+	var b strings.Builder
+	backslashes := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			// Backslashes before a quote are escaped, and the quote too.
+			b.WriteString(strings.Repeat(`\`, 2*backslashes+1))
+		default:
+			b.WriteString(strings.Repeat(`\`, backslashes))
+		}
+		backslashes = 0
+		b.WriteByte(s[i])
+	}
+	if beforeQuote {
+		backslashes *= 2
+	}
+	b.WriteString(strings.Repeat(`\`, backslashes))
+	return b.String()
+}
+
+// cmdEscape escapes the metacharacters of cmd.exe in s with ^.
+func cmdEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(`()%!^"<>&|`, s[i]) >= 0 {
+			b.WriteByte('^')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// runShellCommand runs command with sh, or cmd.exe on windows, its
+// output goes to the output of mc, or to stderr in JSON mode.
+func runShellCommand(ctx context.Context, command string) *probe.Error {
+	cmd := shellCommand(ctx, command)
+	cmd.Stdout = os.Stdout
+	if globalJSON {
+		cmd.Stdout = os.Stderr
+	}
+	cmd.Stderr = os.Stderr
+	if e := cmd.Run(); e != nil {
+		return probe.NewError(fmt.Errorf("`%s` failed: %v", command, e))
+	}
+	return nil
+}
+
+// run executes the command for a completed transfer.
+func (h *onCompleteHook) run(ctx context.Context, urls URLs) *probe.Error {
+	return runShellCommand(ctx, expandOnCompleteCommand(h.command, h.vars(ctx, urls)))
+}
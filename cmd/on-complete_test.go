@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestExpandOnCompleteCommand(t *testing.T) {
+	vars := map[string]string{
+		"{}":     "play/bucket/it's here",
+		"{key}":  "it's here",
+		"{size}": "42",
+		"{etag}": `$HOME "x"`,
+	}
+	testCases := []struct {
+		command  string
+		expected string
+	}{
+		{"echo {}", `echo 'play/bucket/it'\''s here'`},
+		{"scan {key} {size}", `scan 'it'\''s here' '42'`},
+		{"echo {unknown}", "echo {unknown}"},
+		{"echo $(rm {key})", `echo $(rm 'it'\''s here')`},
+		{`echo "{}"`, `echo "play/bucket/it's here"`},
+		{`echo '{key}.txt'`, `echo 'it'\''s here.txt'`},
+		{`echo "tag: {etag}"`, `echo "tag: \$HOME \"x\""`},
+		{`echo \"{size}`, `echo \"'42'`},
+	}
+	for i, testCase := range testCases {
+		if got := expandShellCommand(testCase.command, vars, false); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestExpandOnCompleteCommandWindows(t *testing.T) {
+	vars := map[string]string{
+		"{}":    `play/bucket/a&b "c" 100%`,
+		"{key}": `dir\`,
+	}
+	testCases := []struct {
+		command  string
+		expected string
+	}{
+		{"echo {}", `echo ^"play/bucket/a^&b \^"c\^" 100^%^"`},
+		{`echo "{}"`, `echo ^"play/bucket/a^&b \^"c\^" 100^%^"`},
+		{`echo "{key} & more" & dir`, `echo ^"dir\ ^& more^" & dir`},
+		{`echo "{key}x"`, `echo ^"dir\x^"`},
+		{"echo {key}", `echo ^"dir\\^"`},
+	}
+	for i, testCase := range testCases {
+		if got := expandShellCommand(testCase.command, vars, true); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
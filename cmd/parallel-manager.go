@@ -57,6 +57,8 @@ type ParallelManager struct {
 
 	// Current threads number
 	workersNum uint32
+	// Maximum threads number
+	maxWorkers uint32
 
 	// Channel to receive tasks to run
 	queueCh chan task
@@ -69,7 +71,7 @@ type ParallelManager struct {
 
 // addWorker creates a new worker to process tasks
 func (p *ParallelManager) addWorker() {
-	if atomic.LoadUint32(&p.workersNum) >= maxParallelWorkers {
+	if atomic.LoadUint32(&p.workersNum) >= p.maxWorkers {
 		// Number of maximum workers is reached, no need to
 		// to create a new one.
 		return
@@ -181,9 +183,16 @@ func (p *ParallelManager) stopAndWait() {
 
 // newParallelManager starts new workers waiting for executing tasks
 func newParallelManager(resultCh chan URLs) *ParallelManager {
+	// The part buffers of the uploads streamed in parallel fit in the
+	// memory budget.
+	partSize := uint64(defaultPartSize)
+	if globalPartSize > 0 {
+		partSize = globalPartSize
+	}
 	p := &ParallelManager{
 		wg:            &sync.WaitGroup{},
 		workersNum:    0,
+		maxWorkers:    uint32(memoryBudget(transferMemoryShare, partSize, 1, maxParallelWorkers)),
 		stopMonitorCh: make(chan struct{}),
 		queueCh:       make(chan task),
 		resultCh:      resultCh,
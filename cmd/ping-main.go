@@ -0,0 +1,283 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var pingFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "count, c",
+		Value: 4,
+		Usage: "number of requests sent to each node",
+	},
+	cli.DurationFlag{
+		Name:  "interval, i",
+		Value: time.Second,
+		Usage: "wait time between the requests to a node",
+	},
+}
+
+// Measure the latency of the nodes of an alias.
+var pingCmd = cli.Command{
+	Name:         "ping",
+	Usage:        "measure the request latency of each node of an alias",
+	Action:       mainPing,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(pingFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+The nodes of ALIAS are listed with the admin API when its credentials allow
+it, otherwise the endpoint of ALIAS is measured alone. The requests are sent
+to the liveness endpoint of each node, which is not authenticated and does no
+I/O, so the latency is the one of the network and of the server handling.
+
+EXAMPLES:
+  1. Measure the latency of the nodes of "myminio" with 10 requests, one per second.
+     {{.Prompt}} {{.HelpName}} --count 10 --interval 1s myminio
+
+  2. Measure the latency of the nodes of "myminio", and report it as JSON.
+     {{.Prompt}} {{.HelpName}} --json myminio
+`,
+}
+
+// Timeout of a ping request.
+const pingTimeout = 10 * time.Second
+
+// pingMessage holds the result of one request to a node.
+type pingMessage struct {
+	Status   string        `json:"status"`
+	Endpoint string        `json:"endpoint"`
+	Seq      int           `json:"seq"`
+	Latency  time.Duration `json:"latency"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func (p pingMessage) String() string {
+	if p.Error != "" {
+		return fmt.Sprintf("%s  seq=%d  %s", console.Colorize("PingEndpoint", p.Endpoint), p.Seq, console.Colorize("PingError", p.Error))
+	}
+	return fmt.Sprintf("%s  seq=%d  time=%s", console.Colorize("PingEndpoint", p.Endpoint), p.Seq, p.Latency.Round(time.Microsecond))
+}
+
+func (p pingMessage) JSON() string {
+	p.Status = "success"
+	if p.Error != "" {
+		p.Status = "error"
+	}
+	jsonMessageBytes, e := json.Marshal(p)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// pingSummaryMessage holds the latency statistics of a node.
+type pingSummaryMessage struct {
+	Status   string        `json:"status"`
+	Endpoint string        `json:"endpoint"`
+	Sent     int           `json:"sent"`
+	Failed   int           `json:"failed"`
+	Min      time.Duration `json:"min"`
+	Avg      time.Duration `json:"avg"`
+	Max      time.Duration `json:"max"`
+	P99      time.Duration `json:"p99"`
+}
+
+func (p pingSummaryMessage) String() string {
+	summary := fmt.Sprintf("%s  sent %d, failed %d", console.Colorize("PingEndpoint", p.Endpoint), p.Sent, p.Failed)
+	if p.Failed == p.Sent {
+		return summary
+	}
+	return summary + fmt.Sprintf(", min/avg/max/p99 %s/%s/%s/%s", p.Min.Round(time.Microsecond),
+		p.Avg.Round(time.Microsecond), p.Max.Round(time.Microsecond), p.P99.Round(time.Microsecond))
+}
+
+func (p pingSummaryMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.Marshal(p)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// newPingSummary computes the latency statistics of the successful
+// requests to a node, the p99 is the nearest rank.
+func newPingSummary(endpoint string, sent int, latencies []time.Duration) pingSummaryMessage {
+	summary := pingSummaryMessage{Endpoint: endpoint, Sent: sent, Failed: sent - len(latencies)}
+	if len(latencies) == 0 {
+		return summary
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+	summary.Min = sorted[0]
+	summary.Max = sorted[len(sorted)-1]
+	summary.Avg = total / time.Duration(len(sorted))
+	summary.P99 = sorted[(len(sorted)*99+99)/100-1]
+	return summary
+}
+
+// pingEndpoints returns the URLs of the nodes of an alias, from the
+// admin API, or the URL of the alias when they cannot be listed.
+func pingEndpoints(ctx context.Context, aliasedURL string, aliasCfg *aliasConfigV10) []string {
+	u, e := url.Parse(aliasCfg.URL)
+	if e != nil {
+		return []string{aliasCfg.URL}
+	}
+	client, err := newAdminClient(aliasedURL)
+	if err != nil {
+		return []string{aliasCfg.URL}
+	}
+	info, e := client.ServerInfo(ctx)
+	if e != nil || len(info.Servers) == 0 {
+		return []string{aliasCfg.URL}
+	}
+	var endpoints []string
+	for _, server := range info.Servers {
+		endpoints = append(endpoints, u.Scheme+"://"+server.Endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// pingNode sends a request to the liveness endpoint of a node and
+// returns its round trip time.
+func pingNode(ctx context.Context, client *http.Client, endpoint string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/minio/health/live", nil)
+	if e != nil {
+		return 0, e
+	}
+	start := time.Now()
+	resp, e := client.Do(req)
+	if e != nil {
+		return 0, e
+	}
+	latency := time.Since(start)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected response `%s`", resp.Status)
+	}
+	return latency, nil
+}
+
+// mainPing is the handle for "mc ping" command.
+func mainPing(cliCtx *cli.Context) error {
+	ctx, cancelPing := context.WithCancel(globalContext)
+	defer cancelPing()
+
+	if cliCtx.NArg() != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "ping", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	count, interval := cliCtx.Int("count"), cliCtx.Duration("interval")
+	if count < 1 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("count")), "--count must be at least 1.")
+	}
+	if interval < 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("interval")), "--interval must be positive.")
+	}
+
+	console.SetColor("PingEndpoint", color.New(color.FgCyan))
+	console.SetColor("PingError", color.New(color.FgRed))
+
+	aliasedURL := cliCtx.Args().Get(0)
+	alias, _, aliasCfg, err := expandAlias(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to get the configuration of `%s`.", aliasedURL)
+	if aliasCfg == nil {
+		fatalIf(errInvalidAliasedURL(aliasedURL), "No such alias `%s` found.", aliasedURL)
+	}
+
+	// Use the transport of the alias, for its proxy and certificates.
+	clnt, err := S3New(NewS3Config(aliasCfg.URL, aliasCfg))
+	fatalIf(err.Trace(alias), "Unable to initialize `%s`.", alias)
+	client := &http.Client{Transport: clnt.(*S3Client).transport}
+
+	endpoints := pingEndpoints(ctx, alias, aliasCfg)
+	for i := range endpoints {
+		endpoints[i] = strings.TrimSuffix(endpoints[i], "/")
+	}
+	latencies := make([][]time.Duration, len(endpoints))
+	results := make([]pingMessage, len(endpoints))
+	sent := 0
+	for seq := 1; seq <= count; seq++ {
+		if seq > 1 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(interval):
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		// All the nodes are measured at the same time.
+		var wg sync.WaitGroup
+		for i, endpoint := range endpoints {
+			wg.Add(1)
+			go func(i int, endpoint string) {
+				defer wg.Done()
+				results[i] = pingMessage{Endpoint: endpoint, Seq: seq}
+				latency, e := pingNode(ctx, client, endpoint)
+				if e != nil {
+					results[i].Error = e.Error()
+					return
+				}
+				results[i].Latency = latency
+				latencies[i] = append(latencies[i], latency)
+			}(i, endpoint)
+		}
+		wg.Wait()
+		sent++
+		for _, result := range results {
+			printMsg(result)
+		}
+	}
+
+	var failed bool
+	for i, endpoint := range endpoints {
+		summary := newPingSummary(endpoint, sent, latencies[i])
+		failed = failed || summary.Failed > 0
+		printMsg(summary)
+	}
+	if failed {
+		return exitStatus(globalConnectionExitStatus)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPingSummary(t *testing.T) {
+	ms := func(n int) time.Duration { return time.Duration(n) * time.Millisecond }
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, ms(i))
+	}
+	testCases := []struct {
+		sent      int
+		latencies []time.Duration
+		expected  pingSummaryMessage
+	}{
+		{3, nil, pingSummaryMessage{Sent: 3, Failed: 3}},
+		{1, []time.Duration{ms(5)}, pingSummaryMessage{Sent: 1, Min: ms(5), Avg: ms(5), Max: ms(5), P99: ms(5)}},
+		{4, []time.Duration{ms(3), ms(1), ms(2)}, pingSummaryMessage{Sent: 4, Failed: 1, Min: ms(1), Avg: ms(2), Max: ms(3), P99: ms(3)}},
+		{100, latencies, pingSummaryMessage{Sent: 100, Min: ms(1), Avg: 50500 * time.Microsecond, Max: ms(100), P99: ms(99)}},
+	}
+	for i, testCase := range testCases {
+		summary := newPingSummary("", testCase.sent, testCase.latencies)
+		if summary != testCase.expected {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, summary)
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"io"
 	"os"
 	"syscall"
 
@@ -34,6 +35,7 @@ var (
 			Name:  "storage-class, sc",
 			Usage: "set storage class for new object(s) on target",
 		},
+		encryptClientFlag,
 	}
 )
 
@@ -73,10 +75,13 @@ EXAMPLES:
 
   5. Write contents of stdin to an object on Amazon S3 cloud storage and assign REDUCED_REDUNDANCY storage-class to the uploaded object.
      {{.Prompt}} {{.HelpName}} --storage-class REDUCED_REDUNDANCY s3/personalbuck/meeting-notes.txt
+
+  6. Stream a database dump encrypted client-side for an age recipient, to be read with 'mc cat --identity'.
+     {{.Prompt}} pg_dump accountsdb | {{.HelpName}} --encrypt-client age:age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p s3/sql-backups/accountsdb.sql
 `,
 }
 
-func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass string) *probe.Error {
+func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass string, clientEnc *clientEncryption) *probe.Error {
 	if targetURL == "" {
 		// When no target is specified, pipe cat's stdin to stdout.
 		return catOut(os.Stdin, -1).Trace()
@@ -88,10 +93,20 @@ func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass st
 	// Ignore size, since os.Stat() would not return proper size all the time
 	// for local filesystem for example /proc files.
 	opts := PutOptions{
+		metadata:     map[string]string{},
 		sse:          sseKey,
 		storageClass: storageClass,
 	}
-	_, err := putTargetStreamWithURL(targetURL, os.Stdin, -1, opts)
+	var reader io.Reader = os.Stdin
+	if clientEnc != nil {
+		encReader, _, err := clientEnc.transform(os.Stdin, -1, opts.metadata, nil)
+		if err != nil {
+			return err.Trace(targetURL)
+		}
+		defer encReader.Close()
+		reader = encReader
+	}
+	_, err := putTargetStreamWithURL(targetURL, reader, -1, opts)
 	// TODO: See if this check is necessary.
 	switch e := err.ToGoError().(type) {
 	case *os.PathError:
@@ -106,7 +121,7 @@ func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass st
 // check pipe input arguments.
 func checkPipeSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) > 1 {
-		cli.ShowCommandHelpAndExit(ctx, "pipe", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(ctx, "pipe", globalInvalidArgsExitStatus) // last argument is exit code.
 	}
 }
 
@@ -119,13 +134,16 @@ func mainPipe(ctx *cli.Context) error {
 	// validate pipe input arguments.
 	checkPipeSyntax(ctx)
 
+	clientEnc, err := getClientEncryption(ctx)
+	fatalIf(err, "Unable to parse client-side encryption.")
+
 	if len(ctx.Args()) == 0 {
-		err = pipe("", nil, ctx.String("storage-class"))
+		err = pipe("", nil, ctx.String("storage-class"), nil)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
 	} else {
 		// extract URLs.
 		URLs := ctx.Args()
-		err = pipe(URLs[0], encKeyDB, ctx.String("storage-class"))
+		err = pipe(URLs[0], encKeyDB, ctx.String("storage-class"), clientEnc)
 		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
 	}
 
@@ -0,0 +1,134 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Plugins are executables named mc-<command> found on PATH, run for
+// the commands mc does not know.
+const pluginPrefix = "mc-"
+
+// Environment of a plugin, besides MC_PROFILE and the MC_HOST_<alias>
+// variables of the aliases named in its arguments.
+const (
+	mcEnvPluginName     = "MC_PLUGIN_NAME"
+	mcEnvPluginMC       = "MC_PLUGIN_MC"
+	mcEnvPluginJSON     = "MC_PLUGIN_JSON"
+	mcEnvPluginQuiet    = "MC_PLUGIN_QUIET"
+	mcEnvPluginNoColor  = "MC_PLUGIN_NO_COLOR"
+	mcEnvPluginDebug    = "MC_PLUGIN_DEBUG"
+	mcEnvPluginInsecure = "MC_PLUGIN_INSECURE"
+)
+
+var pluginNameRegexp = regexp.MustCompile("^[a-z0-9][a-z0-9_-]*$")
+
+// findPlugin returns the path of the plugin of a command, or an empty
+// path if there is none.
+func findPlugin(command string) (string, *probe.Error) {
+	if !pluginNameRegexp.MatchString(command) {
+		return "", nil
+	}
+	path, e := exec.LookPath(pluginPrefix + command)
+	if e != nil {
+		return "", nil
+	}
+	if e = checkPluginPermissions(path); e != nil {
+		return "", probe.NewError(e).Trace(path)
+	}
+	return path, nil
+}
+
+// pluginEnv returns the environment of a plugin. Only the credentials
+// of the aliases named in its arguments are passed, as MC_HOST_<alias>.
+// This keeps other credentials out of its environment, it is no
+// sandbox: plugins run as the user and are fully trusted.
+func pluginEnv(command string, args []string) []string {
+	// The credentials mc inherited are not passed on.
+	var env []string
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, mcEnvHostPrefix) && !strings.HasPrefix(kv, mcEnvHostsDeprecatedPrefix) {
+			env = append(env, kv)
+		}
+	}
+	set := func(key, value string) {
+		env = append(env, key+"="+value)
+	}
+	setBool := func(key string, value bool) {
+		if value {
+			set(key, "true")
+		}
+	}
+
+	set(mcEnvPluginName, command)
+	if executable, e := os.Executable(); e == nil {
+		set(mcEnvPluginMC, executable)
+	}
+	set(mcEnvProfile, getMcProfile())
+	setBool(mcEnvPluginJSON, globalJSON)
+	setBool(mcEnvPluginQuiet, globalQuiet)
+	setBool(mcEnvPluginNoColor, globalNoColor)
+	setBool(mcEnvPluginDebug, globalDebug)
+	setBool(mcEnvPluginInsecure, globalInsecure)
+
+	passed := make(map[string]bool)
+	for _, arg := range args {
+		alias := strings.SplitN(arg, "/", 2)[0]
+		if passed[alias] || !isValidAlias(alias) {
+			continue
+		}
+		// Aliases of the environment of mc are passed as well.
+		aliasCfg := mustGetHostConfig(alias)
+		if aliasCfg == nil {
+			continue
+		}
+		if value := aliasEnvValue(*aliasCfg); value != "" {
+			set(mcEnvHostPrefix+alias, value)
+			passed[alias] = true
+		}
+	}
+	return env
+}
+
+// runPlugin runs a plugin with the arguments following the command,
+// its exit status is that of mc. A plugin killed by a signal exits
+// with 128 plus the signal, as shells do.
+func runPlugin(ctx *cli.Context, path string) error {
+	command, args := ctx.Args().First(), ctx.Args().Tail()
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = pluginEnv(command, args)
+	e := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(e, &exitErr) {
+		code := exitErr.ExitCode()
+		if code < 0 {
+			code = signalExitStatus(exitErr.ProcessState)
+		}
+		return exitStatus(code)
+	}
+	fatalIf(probe.NewError(e).Trace(path), "Unable to run plugin `%s`.", pluginPrefix+command)
+	return nil
+}
@@ -0,0 +1,51 @@
+// +build !windows
+
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// checkPluginPermissions refuses plugins which other users could
+// modify or replace, writable by all or in a folder writable by all.
+func checkPluginPermissions(path string) error {
+	for _, name := range []string{path, filepath.Dir(path)} {
+		fi, e := os.Stat(name)
+		if e != nil {
+			return e
+		}
+		// Sticky folders such as /tmp only let owners replace files.
+		if fi.Mode().Perm()&0002 != 0 && (name == path || fi.Mode()&os.ModeSticky == 0) {
+			return fmt.Errorf("%s is writable by all users", name)
+		}
+	}
+	return nil
+}
+
+// signalExitStatus returns the exit status of a process killed by a
+// signal, 128 plus the signal.
+func signalExitStatus(state *os.ProcessState) int {
+	if status, ok := state.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return globalErrorExitStatus
+}
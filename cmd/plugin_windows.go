@@ -0,0 +1,33 @@
+// +build windows
+
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "os"
+
+// checkPluginPermissions accepts all plugins, the permissions of files
+// are ACLs not reflected in their mode on Windows.
+func checkPluginPermissions(path string) error {
+	return nil
+}
+
+// signalExitStatus returns the exit status of a process which did not
+// exit normally, there are no signals to report on Windows.
+func signalExitStatus(state *os.ProcessState) int {
+	return globalErrorExitStatus
+}
@@ -0,0 +1,235 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7/pkg/policy"
+	"github.com/minio/minio/pkg/console"
+)
+
+// Actions granting anonymous users to read, list or write, matched
+// against the actions of the policy statements, which may be patterns.
+var (
+	policyAuditReadActions  = []string{"s3:GetObject", "s3:GetObjectVersion"}
+	policyAuditListActions  = []string{"s3:ListBucket", "s3:ListBucketVersions", "s3:ListBucketMultipartUploads"}
+	policyAuditWriteActions = []string{
+		"s3:PutObject", "s3:DeleteObject", "s3:DeleteObjectVersion", "s3:AbortMultipartUpload",
+		"s3:PutBucketPolicy", "s3:DeleteBucketPolicy", "s3:DeleteBucket", "s3:PutObjectTagging",
+		"s3:PutObjectRetention", "s3:PutObjectLegalHold", "s3:PutBucketVersioning", "s3:PutLifecycleConfiguration",
+	}
+)
+
+// policyAuditMessage container for the anonymous access granted to a
+// resource of a bucket.
+type policyAuditMessage struct {
+	Status      string `json:"status"`
+	Bucket      string `json:"bucket"`
+	Resource    string `json:"resource"`
+	Read        bool   `json:"read"`
+	List        bool   `json:"list"`
+	Write       bool   `json:"write"`
+	Conditional bool   `json:"conditional"`
+}
+
+// String colorized anonymous access, in red when writable.
+func (m policyAuditMessage) String() string {
+	var access []string
+	if m.Read {
+		access = append(access, "read")
+	}
+	if m.List {
+		access = append(access, "list")
+	}
+	if m.Write {
+		access = append(access, "write")
+	}
+	msg := fmt.Sprintf("%-16s %s", strings.Join(access, ","), m.Resource)
+	if m.Conditional {
+		msg += " (with conditions)"
+	}
+	if m.Write {
+		return console.Colorize("PolicyWrite", msg)
+	}
+	return console.Colorize("PolicyRead", msg)
+}
+
+// JSON jsonified anonymous access.
+func (m policyAuditMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// policyAuditSummaryMessage container for the summary of an audit.
+type policyAuditSummaryMessage struct {
+	Status          string `json:"status"`
+	Buckets         int    `json:"buckets"`
+	PublicBuckets   int    `json:"publicBuckets"`
+	WritableBuckets int    `json:"writableBuckets"`
+}
+
+// String colorized audit summary.
+func (m policyAuditSummaryMessage) String() string {
+	msg := fmt.Sprintf("%d of %d buckets allow anonymous access, %d allow anonymous writes.",
+		m.PublicBuckets, m.Buckets, m.WritableBuckets)
+	if m.WritableBuckets > 0 {
+		return console.Colorize("PolicyWrite", msg)
+	}
+	return console.Colorize("Policy", msg)
+}
+
+// JSON jsonified audit summary.
+func (m policyAuditSummaryMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// policyActionsMatch returns true if one of the patterns of a
+// statement matches one of actions.
+func policyActionsMatch(patterns []string, actions []string) bool {
+	for _, pattern := range patterns {
+		for _, action := range actions {
+			if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(action)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// auditBucketPolicy returns the anonymous access granted by the
+// statements of a bucket policy, one message per resource. Deny
+// statements are not subtracted, the access they restrict is still
+// reported.
+func auditBucketPolicy(alias, bucket, policyJSON string) ([]policyAuditMessage, *probe.Error) {
+	if policyJSON == "" {
+		return nil, nil
+	}
+	var p policy.BucketAccessPolicy
+	if e := json.Unmarshal([]byte(policyJSON), &p); e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	access := map[string]*policyAuditMessage{}
+	for _, statement := range p.Statements {
+		if statement.Effect != "Allow" || !statement.Principal.AWS.Contains("*") {
+			continue
+		}
+		actions := statement.Actions.ToSlice()
+		read := policyActionsMatch(actions, policyAuditReadActions)
+		list := policyActionsMatch(actions, policyAuditListActions)
+		write := policyActionsMatch(actions, policyAuditWriteActions)
+		if !read && !list && !write {
+			continue
+		}
+		for _, resource := range statement.Resources.ToSlice() {
+			resource = strings.TrimPrefix(resource, "arn:aws:s3:::")
+			msg, ok := access[resource]
+			if !ok {
+				msg = &policyAuditMessage{Bucket: alias + "/" + bucket, Resource: alias + "/" + resource}
+				access[resource] = msg
+			}
+			msg.Read = msg.Read || read
+			msg.List = msg.List || list
+			msg.Write = msg.Write || write
+			msg.Conditional = msg.Conditional || len(statement.Conditions) > 0
+		}
+	}
+
+	msgs := make([]policyAuditMessage, 0, len(access))
+	for _, msg := range access {
+		msgs = append(msgs, *msg)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Resource < msgs[j].Resource })
+	return msgs, nil
+}
+
+// policyAuditBuckets returns the buckets of targetURL, the bucket
+// itself when targetURL names one.
+func policyAuditBuckets(ctx context.Context, targetURL string) ([]string, *probe.Error) {
+	alias, bucketPath := url2Alias(targetURL)
+	if bucket := strings.Trim(filepath.ToSlash(bucketPath), "/"); bucket != "" {
+		return []string{strings.SplitN(bucket, "/", 2)[0]}, nil
+	}
+	clnt, err := newClient(alias)
+	if err != nil {
+		return nil, err.Trace(targetURL)
+	}
+	var buckets []string
+	for content := range clnt.List(ctx, ListOptions{ShowDir: DirNone}) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(targetURL)
+		}
+		buckets = append(buckets, strings.Trim(filepath.ToSlash(content.URL.Path), "/"))
+	}
+	return buckets, nil
+}
+
+// Run policy audit command
+func runPolicyAuditCmd(args cli.Args) error {
+	ctx, cancelPolicyAudit := context.WithCancel(globalContext)
+	defer cancelPolicyAudit()
+
+	targetURL := args.First()
+	alias, _ := url2Alias(targetURL)
+	buckets, err := policyAuditBuckets(ctx, targetURL)
+	fatalIf(err, "Unable to list the buckets of `"+targetURL+"`.")
+
+	var cErr error
+	summary := policyAuditSummaryMessage{Buckets: len(buckets)}
+	for _, bucket := range buckets {
+		bucketURL := alias + "/" + bucket
+		_, policyJSON, err := doGetAccess(ctx, bucketURL)
+		if err != nil {
+			errorIf(err.Trace(bucketURL), "Unable to get the policy of `"+bucketURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		msgs, err := auditBucketPolicy(alias, bucket, policyJSON)
+		if err != nil {
+			errorIf(err.Trace(bucketURL), "Unable to parse the policy of `"+bucketURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		if len(msgs) > 0 {
+			summary.PublicBuckets++
+		}
+		writable := false
+		for _, msg := range msgs {
+			writable = writable || msg.Write
+			printMsg(msg)
+		}
+		if writable {
+			summary.WritableBuckets++
+		}
+	}
+	printMsg(summary)
+	return cErr
+}
@@ -0,0 +1,65 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuditBucketPolicy(t *testing.T) {
+	testCases := []struct {
+		policy   string
+		expected []policyAuditMessage
+	}{
+		{"", nil},
+		// Canned download policy, as set by 'mc policy set download'.
+		{`{"Version":"2012-10-17","Statement":[
+			{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetBucketLocation","s3:ListBucket"],"Resource":["arn:aws:s3:::shared"]},
+			{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::shared/*"]}]}`,
+			[]policyAuditMessage{
+				{Bucket: "s3/shared", Resource: "s3/shared", List: true},
+				{Bucket: "s3/shared", Resource: "s3/shared/*", Read: true},
+			}},
+		// Wildcard actions on a prefix, with a principal given as a string.
+		{`{"Version":"2012-10-17","Statement":[
+			{"Effect":"Allow","Principal":"*","Action":"s3:Put*","Resource":"arn:aws:s3:::shared/incoming/*"},
+			{"Effect":"Allow","Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::shared/open/*",
+			 "Condition":{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}]}`,
+			[]policyAuditMessage{
+				{Bucket: "s3/shared", Resource: "s3/shared/incoming/*", Write: true},
+				{Bucket: "s3/shared", Resource: "s3/shared/open/*", Read: true, List: true, Write: true, Conditional: true},
+			}},
+		// Statements for named users or denying access are not anonymous access.
+		{`{"Version":"2012-10-17","Statement":[
+			{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::shared/*"]},
+			{"Effect":"Deny","Principal":"*","Action":["s3:PutObject"],"Resource":["arn:aws:s3:::shared/*"]}]}`,
+			[]policyAuditMessage{}},
+	}
+	for i, testCase := range testCases {
+		msgs, err := auditBucketPolicy("s3", "shared", testCase.policy)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(msgs, testCase.expected) {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, msgs)
+		}
+	}
+	if _, err := auditBucketPolicy("s3", "shared", "{"); err == nil {
+		t.Error("expected an error parsing an invalid policy")
+	}
+}
@@ -57,6 +57,7 @@ USAGE:
   {{.HelpName}} [FLAGS] get TARGET
   {{.HelpName}} [FLAGS] get-json TARGET
   {{.HelpName}} [FLAGS] list TARGET
+  {{.HelpName}} [FLAGS] audit TARGET
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -94,6 +95,9 @@ EXAMPLES:
 
   9. List public object URLs recursively.
      {{.Prompt}} {{.HelpName}} --recursive links s3/shared/
+
+  10. Audit the anonymous access granted by the policies of all buckets, writable resources are shown in red.
+      {{.Prompt}} {{.HelpName}} audit s3
 `,
 }
 
@@ -179,11 +183,11 @@ func checkPolicySyntax(ctx *cli.Context) {
 	argsLength := len(ctx.Args())
 	// Always print a help message when we have extra arguments
 	if argsLength > 3 {
-		cli.ShowCommandHelpAndExit(ctx, "policy", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus) // last argument is exit code.
 	}
 	// Always print a help message when no arguments specified
 	if argsLength < 1 {
-		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+		cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 	}
 
 	firstArg := ctx.Args().Get(0)
@@ -194,7 +198,7 @@ func checkPolicySyntax(ctx *cli.Context) {
 	case "set":
 		// Always expect three arguments when setting a policy permission.
 		if argsLength != 3 {
-			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+			cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 		}
 		if accessPerms(secondArg) != accessNone &&
 			accessPerms(secondArg) != accessDownload &&
@@ -207,25 +211,25 @@ func checkPolicySyntax(ctx *cli.Context) {
 	case "set-json":
 		// Always expect three arguments when setting a policy permission.
 		if argsLength != 3 {
-			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+			cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 		}
 	case "get", "get-json":
 		// get or get-json always expects two arguments
 		if argsLength != 2 {
-			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+			cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 		}
 	case "list":
 		// Always expect an argument after list cmd
 		if argsLength != 2 {
-			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+			cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 		}
-	case "links":
-		// Always expect an argument after links cmd
+	case "links", "audit":
+		// Always expect an argument after links and audit cmds
 		if argsLength != 2 {
-			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+			cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 		}
 	default:
-		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+		cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 	}
 }
 
@@ -475,6 +479,8 @@ func mainPolicy(ctx *cli.Context) error {
 
 	// Additional command speific theme customization.
 	console.SetColor("Policy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyRead", color.New(color.FgYellow))
+	console.SetColor("PolicyWrite", color.New(color.FgRed, color.Bold))
 
 	switch ctx.Args().First() {
 	case "set", "set-json", "get", "get-json":
@@ -489,9 +495,12 @@ func mainPolicy(ctx *cli.Context) error {
 	case "links":
 		// policy links alias/bucket/prefix
 		runPolicyLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"))
+	case "audit":
+		// policy audit alias[/bucket]
+		return runPolicyAuditCmd(ctx.Args().Tail())
 	default:
 		// Shows command example and exit
-		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+		cli.ShowCommandHelpAndExit(ctx, "policy", globalInvalidArgsExitStatus)
 	}
 	return nil
 }
@@ -17,7 +17,25 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+
 	"github.com/minio/minio/pkg/console"
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats supported by the global --output flag.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputCSV   = "csv"
 )
 
 // message interface for all structured messages implementing JSON(), String() methods.
@@ -26,13 +44,207 @@ type message interface {
 	String() string
 }
 
+// checkOutputFormat validates the value of the --output flag.
+func checkOutputFormat(format string) error {
+	switch format {
+	case "", outputTable, outputJSON, outputYAML, outputCSV:
+		return nil
+	}
+	return fmt.Errorf("unknown output format `%s`, possible values are 'json', 'yaml', 'csv' and 'table'", format)
+}
+
+// formatEscapes expands the escape sequences commonly used in --format
+// templates, which shells pass through literally inside single quotes.
+var formatEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// parseOutputTemplate parses the Go template of the --format flag.
+func parseOutputTemplate(format string) (*template.Template, error) {
+	return template.New("format").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			buf, e := json.Marshal(v)
+			return string(buf), e
+		},
+	}).Option("missingkey=error").Parse(formatEscapes.Replace(format))
+}
+
+// msgToFormat renders a message with the --format template. Templates
+// may refer to the fields of the message struct, e.g. {{.AccessKey}},
+// or to the keys of its JSON form, e.g. {{.accessKey}}.
+func msgToFormat(msg message) string {
+	var buf bytes.Buffer
+	if e := globalFormat.Execute(&buf, msg); e == nil {
+		return buf.String()
+	}
+
+	var value interface{}
+	dec := json.NewDecoder(strings.NewReader(msg.JSON()))
+	dec.UseNumber()
+	if e := dec.Decode(&value); e != nil {
+		fatalIf(errDummy().Trace(e.Error()), "Unable to decode the JSON message.")
+	}
+	buf.Reset()
+	if e := globalFormat.Execute(&buf, value); e != nil {
+		fatalIf(errDummy().Trace(e.Error()), "Unable to apply --format template.")
+	}
+	return buf.String()
+}
+
 // printMsg prints message string or JSON structure depending on the type of output console.
 func printMsg(msg message) {
 	var msgStr string
-	if !globalJSON {
+	switch {
+	case globalFormat != nil:
+		msgStr = msgToFormat(msg)
+	case globalOutput == outputYAML:
+		msgStr = msgToYAML(msg)
+	case globalOutput == outputCSV:
+		msgStr = msgToCSV(msg)
+	case !globalJSON:
 		msgStr = msg.String()
-	} else {
+	default:
 		msgStr = msg.JSON()
 	}
 	console.Println(msgStr)
 }
+
+// printNullTerminated prints s followed by a NUL character, for
+// output safely split by `xargs -0` whatever the characters of s.
+func printNullTerminated(s string) {
+	console.Print(s + "\x00")
+}
+
+// decodeOrderedJSON decodes the next JSON value from the decoder,
+// objects are returned as yaml.MapSlice to preserve the field order
+// of the message structs.
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	token, e := dec.Token()
+	if e != nil {
+		return nil, e
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+	switch delim {
+	case '{':
+		var obj yaml.MapSlice
+		for dec.More() {
+			key, e := dec.Token()
+			if e != nil {
+				return nil, e
+			}
+			value, e := decodeOrderedJSON(dec)
+			if e != nil {
+				return nil, e
+			}
+			obj = append(obj, yaml.MapItem{Key: key, Value: value})
+		}
+		// Consume the closing delimiter.
+		_, e = dec.Token()
+		return obj, e
+	case '[':
+		arr := []interface{}{}
+		for dec.More() {
+			value, e := decodeOrderedJSON(dec)
+			if e != nil {
+				return nil, e
+			}
+			arr = append(arr, value)
+		}
+		_, e = dec.Token()
+		return arr, e
+	}
+	return nil, fmt.Errorf("unexpected JSON delimiter `%s`", delim)
+}
+
+// msgToOrdered converts the JSON form of a message to an ordered value.
+func msgToOrdered(msg message) interface{} {
+	dec := json.NewDecoder(strings.NewReader(msg.JSON()))
+	dec.UseNumber()
+	value, e := decodeOrderedJSON(dec)
+	if e != nil && e != io.EOF {
+		fatalIf(errDummy().Trace(e.Error()), "Unable to decode the JSON message.")
+	}
+	return value
+}
+
+// msgToYAML renders a message as a YAML document.
+func msgToYAML(msg message) string {
+	buf, e := yaml.Marshal(msgToOrdered(msg))
+	if e != nil {
+		fatalIf(errDummy().Trace(e.Error()), "Unable to marshal into YAML.")
+	}
+	return "---\n" + strings.TrimSuffix(string(buf), "\n")
+}
+
+// flattenOrdered flattens nested objects into dotted column names,
+// arrays are kept as compact JSON so that each message is one row.
+func flattenOrdered(prefix string, value interface{}, columns, values *[]string) {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range v {
+			key := fmt.Sprint(item.Key)
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flattenOrdered(key, item.Value, columns, values)
+		}
+		return
+	case []interface{}:
+		buf, _ := json.Marshal(toPlainJSON(v))
+		*columns = append(*columns, prefix)
+		*values = append(*values, string(buf))
+		return
+	case nil:
+		*columns = append(*columns, prefix)
+		*values = append(*values, "")
+		return
+	}
+	*columns = append(*columns, prefix)
+	*values = append(*values, fmt.Sprint(value))
+}
+
+// toPlainJSON converts ordered values back to values encoding/json understands.
+func toPlainJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		m := make(map[string]interface{}, len(v))
+		for _, item := range v {
+			m[fmt.Sprint(item.Key)] = toPlainJSON(item.Value)
+		}
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = toPlainJSON(v[i])
+		}
+	}
+	return value
+}
+
+// Columns of the last printed CSV header, a new header is
+// printed whenever the shape of the messages changes.
+var (
+	csvHeaderMu sync.Mutex
+	csvHeader   []string
+)
+
+// msgToCSV renders a message as a CSV row, preceded by a header row
+// for the first message and whenever the columns change.
+func msgToCSV(msg message) string {
+	var columns, values []string
+	flattenOrdered("", msgToOrdered(msg), &columns, &values)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	csvHeaderMu.Lock()
+	if strings.Join(columns, ",") != strings.Join(csvHeader, ",") {
+		csvHeader = columns
+		w.Write(columns)
+	}
+	csvHeaderMu.Unlock()
+
+	w.Write(values)
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+type testOutputMessage struct {
+	json string
+}
+
+func (m testOutputMessage) String() string { return "" }
+func (m testOutputMessage) JSON() string   { return m.json }
+
+func TestMsgToYAML(t *testing.T) {
+	msg := testOutputMessage{`{"status":"success","size":1024,"user":{"name":"foo","groups":["a","b"]}}`}
+	expected := "---\nstatus: success\nsize: 1024\nuser:\n  name: foo\n  groups:\n  - a\n  - b"
+	if got := msgToYAML(msg); got != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, got)
+	}
+}
+
+func TestMsgToCSV(t *testing.T) {
+	csvHeader = nil
+	testCases := []struct {
+		json     string
+		expected string
+	}{
+		{`{"status":"success","key":"a,b","user":{"name":"foo"}}`, "status,key,user.name\nsuccess,\"a,b\",foo"},
+		// Same columns, no header.
+		{`{"status":"success","key":"c","user":{"name":"bar"}}`, "success,c,bar"},
+		// Different columns, new header.
+		{`{"status":"success","list":[1,2],"empty":null}`, "status,list,empty\nsuccess,\"[1,2]\","},
+	}
+	for i, testCase := range testCases {
+		if got := msgToCSV(testOutputMessage{testCase.json}); got != testCase.expected {
+			t.Errorf("Test %d: expected\n%s\ngot\n%s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+type testFormatMessage struct {
+	Status    string `json:"status"`
+	AccessKey string `json:"accessKey"`
+}
+
+func (m testFormatMessage) String() string { return "" }
+func (m testFormatMessage) JSON() string {
+	return `{"status":"` + m.Status + `","accessKey":"` + m.AccessKey + `"}`
+}
+
+func TestMsgToFormat(t *testing.T) {
+	defer func() { globalFormat = nil }()
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{`{{.AccessKey}}\t{{.Status}}`, "foo\tsuccess"},
+		{`{{.accessKey}}`, "foo"},
+		{`{{json .}}`, `{"status":"success","accessKey":"foo"}`},
+	}
+	for i, testCase := range testCases {
+		tmpl, e := parseOutputTemplate(testCase.format)
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, e)
+		}
+		globalFormat = tmpl
+		if got := msgToFormat(testFormatMessage{Status: "success", AccessKey: "foo"}); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var putFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "encrypt",
+		Usage: "encrypt the object (using server-side encryption with server managed keys)",
+	},
+	cli.StringFlag{
+		Name:  "storage-class, sc",
+		Usage: "set storage class of the object",
+	},
+}
+
+var putCmd = cli.Command{
+	Name:         "put",
+	Usage:        "upload a file to an object",
+	Action:       mainPut,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(append(putFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FILE|- ALIAS/BUCKET/KEY
+
+  Upload one file, or the standard input with '-', to the object KEY. An
+  existing object is replaced, use 'mc cp' to copy folders or many files.
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT:      list of comma delimited prefixes
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+  1. Upload a file to an object.
+     {{.Prompt}} {{.HelpName}} report.pdf play/mybucket/2021/report.pdf
+
+  2. Upload the standard input to an object.
+     {{.Prompt}} tar czf - /etc | {{.HelpName}} - play/backups/etc.tar.gz
+
+  3. Upload a file to an object with the REDUCED_REDUNDANCY storage class.
+     {{.Prompt}} {{.HelpName}} --storage-class REDUCED_REDUNDANCY notes.txt play/mybucket/notes.txt
+`,
+}
+
+// putMessage container for object upload messages.
+type putMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized put message.
+func (p putMessage) String() string {
+	return console.Colorize("Put", fmt.Sprintf("`%s` -> `%s`", p.Source, p.Target))
+}
+
+// JSON jsonified put message.
+func (p putMessage) JSON() string {
+	p.Status = "success"
+	putMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(putMessageBytes)
+}
+
+// checkPutSyntax - validate arguments passed by a user.
+func checkPutSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "put", globalInvalidArgsExitStatus)
+	}
+	checkObjectURL(ctx.Args().Get(1))
+}
+
+// openPutSource opens the file to upload, or the standard input for
+// '-', with its size or -1 when unknown.
+func openPutSource(source string) (*os.File, int64, *probe.Error) {
+	if source == "-" {
+		return os.Stdin, -1, nil
+	}
+	f, e := os.Open(source)
+	if e != nil {
+		return nil, 0, probe.NewError(e)
+	}
+	st, e := f.Stat()
+	if e != nil {
+		f.Close()
+		return nil, 0, probe.NewError(e)
+	}
+	if st.IsDir() {
+		f.Close()
+		return nil, 0, probe.NewError(fmt.Errorf("`%s` is a folder, use `mc cp --recursive` to upload folders", source))
+	}
+	if !st.Mode().IsRegular() {
+		return f, -1, nil
+	}
+	return f, st.Size(), nil
+}
+
+// mainPut is the entry point for put command.
+func mainPut(cliCtx *cli.Context) error {
+	ctx, cancelPut := context.WithCancel(globalContext)
+	defer cancelPut()
+
+	checkPutSyntax(cliCtx)
+	console.SetColor("Put", color.New(color.FgGreen, color.Bold))
+
+	encKeyDB, err := getEncKeys(cliCtx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	source := cliCtx.Args().Get(0)
+	targetURL := cliCtx.Args().Get(1)
+
+	reader, size, err := openPutSource(source)
+	fatalIf(err.Trace(source), "Unable to read `"+source+"`.")
+	defer reader.Close()
+
+	alias, urlStr, _ := mustExpandAlias(targetURL)
+	opts := PutOptions{
+		metadata:     map[string]string{"Content-Type": guessURLContentType(targetURL)},
+		sse:          getSSE(targetURL, encKeyDB[alias]),
+		storageClass: cliCtx.String("storage-class"),
+	}
+
+	var pg *progressBar
+	var progress io.Reader
+	if !globalQuiet && !globalJSON && size >= 0 {
+		pg = newProgressBar(size)
+		pg.SetCaption(source + ": ")
+		progress = pg
+	}
+	n, err := putTargetStream(ctx, alias, urlStr, "", "", "", reader, size, progress, opts)
+	if pg != nil {
+		pg.Finish()
+	}
+	fatalIf(err.Trace(source, targetURL), "Unable to upload `"+source+"` to `"+targetURL+"`.")
+
+	if pg == nil {
+		printMsg(putMessage{
+			Source: source,
+			Target: targetURL,
+			Size:   n,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+// maxRPSFlag caps the rate of the requests of the commands listing or
+// removing many objects.
+var maxRPSFlag = cli.IntFlag{
+	Name:  "max-rps",
+	Usage: "limit the S3 requests to N per second, to spare a shared cluster",
+}
+
+// The limit of the requests of the command, set via --max-rps.
+var globalRateLimiter *rateLimiter
+
+// rateLimiter spaces the requests evenly to stay below a rate.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(rps int) *rateLimiter {
+	return &rateLimiter{interval: time.Second / time.Duration(rps)}
+}
+
+// wait blocks until the next request may be sent.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	at := l.next
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// setGlobalRateLimiter applies --max-rps, it must be called before the
+// first client is created.
+func setGlobalRateLimiter(cliCtx *cli.Context) {
+	rps := cliCtx.Int("max-rps")
+	if rps < 0 {
+		fatalIf(errInvalidArgument().Trace(cliCtx.String("max-rps")), "--max-rps must not be negative.")
+	}
+	if rps > 0 {
+		globalRateLimiter = newRateLimiter(rps)
+	}
+}
+
+// rateLimitTransport delays the requests over the limit.
+type rateLimitTransport struct {
+	transport http.RoundTripper
+	limiter   *rateLimiter
+}
+
+// newRateLimitTransport applies --max-rps to the requests of the given
+// transport, if set.
+func newRateLimitTransport(transport http.RoundTripper) http.RoundTripper {
+	if globalRateLimiter == nil {
+		return transport
+	}
+	return &rateLimitTransport{transport: transport, limiter: globalRateLimiter}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if e := t.limiter.wait(req.Context()); e != nil {
+		return nil, e
+	}
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(100)
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		if e := l.wait(context.Background()); e != nil {
+			t.Fatal(e)
+		}
+	}
+	// The first request is not delayed, the next ones are 10ms apart.
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("11 requests at 100 per second took %s", elapsed)
+	}
+
+	l = newRateLimiter(1)
+	if e := l.wait(context.Background()); e != nil {
+		t.Fatal(e)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if e := l.wait(ctx); e != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, e)
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	defer func(l *rateLimiter) { globalRateLimiter = l }(globalRateLimiter)
+	globalRateLimiter = nil
+	if transport := newRateLimitTransport(http.DefaultTransport); transport != http.DefaultTransport {
+		t.Fatal("expected the transport to be unchanged without --max-rps")
+	}
+
+	globalRateLimiter = newRateLimiter(50)
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport)}
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		resp, e := client.Get(server.URL)
+		if e != nil {
+			t.Fatal(e)
+		}
+		resp.Body.Close()
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("6 requests at 50 per second took %s", elapsed)
+	}
+	if requests != 6 {
+		t.Errorf("expected 6 requests, got %d", requests)
+	}
+}
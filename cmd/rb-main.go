@@ -50,7 +50,7 @@ var rbCmd = cli.Command{
 	Action:       mainRemoveBucket,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(rbFlags, globalFlags...),
+	Flags:        append(append(rbFlags, confirmFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -72,6 +72,9 @@ EXAMPLES:
 
   4. Remove all buckets and objects recursively from S3 host
      {{.Prompt}} {{.HelpName}} --force --dangerous s3
+
+  5. Remove bucket 'jazz-songs' and all its contents after typing its name.
+     {{.Prompt}} {{.HelpName}} --force --confirm s3/jazz-songs
 `,
 }
 
@@ -97,7 +100,7 @@ func (s removeBucketMessage) JSON() string {
 // Validate command line arguments.
 func checkRbSyntax(ctx context.Context, cliCtx *cli.Context) {
 	if !cliCtx.Args().Present() {
-		exitCode := 1
+		exitCode := globalInvalidArgsExitStatus
 		cli.ShowCommandHelpAndExit(cliCtx, "rb", exitCode)
 	}
 	// Set command flags from context.
@@ -245,6 +248,12 @@ func mainRemoveBucket(cliCtx *cli.Context) error {
 			fatalIf(errDummy().Trace(), "`"+targetURL+"` is not empty. Retry this command with ‘--force’ flag if you want to remove `"+targetURL+"` and all its contents")
 		}
 
+		if !isEmpty && isConfirmRequired(cliCtx) {
+			count, more := countObjects(ctx, clnt, opts, confirmMaxCount)
+			description := fmt.Sprintf("Removing `%s` removes %s objects, versions and delete markers.", targetURL, formatObjectCount(count, more))
+			fatalIf(confirmRemoval(description, confirmName(targetURL)), "Unable to remove `"+targetURL+"`.")
+		}
+
 		e := deleteBucket(ctx, targetURL)
 		fatalIf(e.Trace(targetURL), "Failed to remove `"+targetURL+"`.")
 
@@ -0,0 +1,229 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var readyFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "cluster-read",
+		Usage: "check if the cluster has read quorum only",
+	},
+	cli.BoolFlag{
+		Name:  "maintenance",
+		Usage: "check if the node of ALIAS can be taken down for maintenance without losing quorum",
+	},
+}
+
+// Check if the cluster of an alias is ready.
+var readyCmd = cli.Command{
+	Name:         "ready",
+	Usage:        "check if the cluster of an alias is ready to serve requests",
+	Action:       mainReady,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(readyFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXIT STATUS:
+  0  the cluster is healthy, or the node can be taken down with --maintenance
+  3  the cluster is down, or unreachable
+  8  the cluster is read-only, or the node cannot be taken down with --maintenance
+
+EXAMPLES:
+  1. Check if the cluster of "myminio" has write quorum.
+     {{.Prompt}} {{.HelpName}} myminio
+
+  2. Wait for the cluster of "myminio" to serve reads, e.g. in an init container.
+     {{.Prompt}} until {{.HelpName}} --cluster-read myminio; do sleep 5; done
+
+  3. Check if the node behind "node1" can be restarted without losing quorum.
+     {{.Prompt}} {{.HelpName}} --maintenance node1
+`,
+}
+
+// Timeout of a health check request.
+const readyTimeout = 10 * time.Second
+
+// States reported by 'mc ready'.
+const (
+	readyHealthy     = "healthy"
+	readyReadOnly    = "read-only"
+	readyMaintenance = "maintenance-unsafe"
+	readyDown        = "down"
+)
+
+// readyMessage holds the health of the cluster of an alias.
+type readyMessage struct {
+	Status      string `json:"status"`
+	Alias       string `json:"alias"`
+	State       string `json:"state"`
+	Maintenance bool   `json:"maintenance,omitempty"`
+	WriteQuorum int    `json:"writeQuorum,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func (r readyMessage) String() string {
+	switch r.State {
+	case readyHealthy:
+		if r.Maintenance {
+			return console.Colorize("ReadyHealthy", fmt.Sprintf("The node of `%s` can be taken down for maintenance.", r.Alias))
+		}
+		msg := fmt.Sprintf("The cluster of `%s` is ready.", r.Alias)
+		if r.WriteQuorum > 0 {
+			msg = fmt.Sprintf("The cluster of `%s` is ready, write quorum %d.", r.Alias, r.WriteQuorum)
+		}
+		return console.Colorize("ReadyHealthy", msg)
+	case readyReadOnly:
+		return console.Colorize("ReadyDegraded", fmt.Sprintf("The cluster of `%s` is read-only, it lost write quorum.", r.Alias))
+	case readyMaintenance:
+		return console.Colorize("ReadyDegraded", fmt.Sprintf("Taking the node of `%s` down would lose quorum.", r.Alias))
+	}
+	return console.Colorize("ReadyDown", fmt.Sprintf("The cluster of `%s` is down: %s.", r.Alias, r.Error))
+}
+
+func (r readyMessage) JSON() string {
+	r.Status = "success"
+	jsonMessageBytes, e := json.Marshal(r)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// readyGet sends a health check request, health checks are not
+// authenticated.
+func readyGet(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if e != nil {
+		return nil, e
+	}
+	resp, e := client.Do(req)
+	if e != nil {
+		return nil, e
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// checkReady checks the health endpoints of the server at baseURL: the
+// cluster has write quorum, only read quorum, or none. With maintenance,
+// the server tells if the cluster keeps quorum without the node.
+func checkReady(ctx context.Context, client *http.Client, baseURL string, clusterRead, maintenance bool) readyMessage {
+	msg := readyMessage{State: readyDown, Maintenance: maintenance}
+	path := "/minio/health/cluster"
+	switch {
+	case clusterRead:
+		path = "/minio/health/cluster/read"
+	case maintenance:
+		path = "/minio/health/cluster?maintenance=true"
+	}
+	resp, e := readyGet(ctx, client, baseURL+path)
+	if e != nil {
+		msg.Error = e.Error()
+		return msg
+	}
+	msg.WriteQuorum, _ = strconv.Atoi(resp.Header.Get("X-Minio-Write-Quorum"))
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		msg.State = readyHealthy
+		return msg
+	case resp.StatusCode == http.StatusPreconditionFailed && maintenance:
+		msg.State = readyMaintenance
+		return msg
+	case resp.StatusCode != http.StatusServiceUnavailable:
+		msg.Error = "unexpected response `" + resp.Status + "`, not a MinIO server"
+		return msg
+	case clusterRead || maintenance:
+		msg.Error = "no quorum"
+		return msg
+	}
+
+	// Without write quorum, the cluster may still serve reads.
+	resp, e = readyGet(ctx, client, baseURL+"/minio/health/cluster/read")
+	if e != nil {
+		msg.Error = e.Error()
+		return msg
+	}
+	if resp.StatusCode == http.StatusOK {
+		msg.State = readyReadOnly
+		return msg
+	}
+	msg.Error = "no read quorum"
+	return msg
+}
+
+// mainReady is the handle for "mc ready" command.
+func mainReady(cliCtx *cli.Context) error {
+	ctx, cancelReady := context.WithCancel(globalContext)
+	defer cancelReady()
+
+	if cliCtx.NArg() != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "ready", globalInvalidArgsExitStatus) // last argument is exit code
+	}
+	clusterRead, maintenance := cliCtx.Bool("cluster-read"), cliCtx.Bool("maintenance")
+	if clusterRead && maintenance {
+		fatalIf(errInvalidArgument(), "--cluster-read cannot be specified with --maintenance.")
+	}
+
+	console.SetColor("ReadyHealthy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ReadyDegraded", color.New(color.FgYellow, color.Bold))
+	console.SetColor("ReadyDown", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := cliCtx.Args().Get(0)
+	alias, _, aliasCfg, err := expandAlias(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to get the configuration of `%s`.", aliasedURL)
+	if aliasCfg == nil {
+		fatalIf(errInvalidAliasedURL(aliasedURL), "No such alias `%s` found.", aliasedURL)
+	}
+
+	// Use the transport of the alias, for its proxy and certificates.
+	clnt, err := S3New(NewS3Config(aliasCfg.URL, aliasCfg))
+	fatalIf(err.Trace(alias), "Unable to initialize `%s`.", alias)
+	client := &http.Client{Transport: clnt.(*S3Client).transport}
+
+	msg := checkReady(ctx, client, strings.TrimSuffix(aliasCfg.URL, "/"), clusterRead, maintenance)
+	msg.Alias = alias
+	printMsg(msg)
+	switch msg.State {
+	case readyHealthy:
+		return nil
+	case readyReadOnly, readyMaintenance:
+		return exitStatus(globalDegradedExitStatus)
+	}
+	return exitStatus(globalConnectionExitStatus)
+}
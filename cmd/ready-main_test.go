@@ -0,0 +1,58 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckReady(t *testing.T) {
+	testCases := []struct {
+		cluster, clusterRead, maintenance int
+		withClusterRead, withMaintenance  bool
+		expected                          string
+	}{
+		{http.StatusOK, http.StatusOK, http.StatusOK, false, false, readyHealthy},
+		{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK, false, false, readyReadOnly},
+		{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK, false, false, readyDown},
+		{http.StatusServiceUnavailable, http.StatusOK, http.StatusOK, true, false, readyHealthy},
+		{http.StatusOK, http.StatusOK, http.StatusPreconditionFailed, false, true, readyMaintenance},
+		{http.StatusOK, http.StatusOK, http.StatusOK, false, true, readyHealthy},
+		{http.StatusForbidden, http.StatusForbidden, http.StatusForbidden, false, false, readyDown},
+	}
+	for i, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/minio/health/cluster/read":
+				w.WriteHeader(testCase.clusterRead)
+			case r.URL.Query().Get("maintenance") == "true":
+				w.WriteHeader(testCase.maintenance)
+			default:
+				w.Header().Set("X-Minio-Write-Quorum", "3")
+				w.WriteHeader(testCase.cluster)
+			}
+		}))
+		msg := checkReady(context.Background(), server.Client(), server.URL, testCase.withClusterRead, testCase.withMaintenance)
+		server.Close()
+		if msg.State != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s (%s)", i+1, testCase.expected, msg.State, msg.Error)
+		}
+	}
+}
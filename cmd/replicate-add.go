@@ -103,7 +103,7 @@ EXAMPLES:
 // checkReplicateAddSyntax - validate all the passed arguments
 func checkReplicateAddSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "add", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 	if ctx.String("arn") == "" {
 		fatal(errDummy().Trace(), "--arn flag needs to be specified.")
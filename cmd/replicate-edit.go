@@ -102,7 +102,7 @@ EXAMPLES:
 // checkReplicateEditSyntax - validate all the passed arguments
 func checkReplicateEditSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "edit", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "edit", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -55,7 +55,7 @@ EXAMPLES:
 // checkReplicateExportSyntax - validate all the passed arguments
 func checkReplicateExportSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "export", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
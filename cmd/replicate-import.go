@@ -56,7 +56,7 @@ EXAMPLES:
 // checkReplicateImportSyntax - validate all the passed arguments
 func checkReplicateImportSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "import", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
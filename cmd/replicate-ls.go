@@ -62,7 +62,7 @@ EXAMPLES:
 // checkReplicateListSyntax - validate all the passed arguments
 func checkReplicateListSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "ls", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "ls", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 func printReplicateListHeader() {
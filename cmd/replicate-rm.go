@@ -71,7 +71,7 @@ EXAMPLES:
 // checkReplicateRemoveSyntax - validate all the passed arguments
 func checkReplicateRemoveSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "rm", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "rm", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 	rmAll := ctx.Bool("all")
 	rmForce := ctx.Bool("force")
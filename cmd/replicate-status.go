@@ -53,7 +53,7 @@ EXAMPLES:
 // checkReplicateStatusSyntax - validate all the passed arguments
 func checkReplicateStatusSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "status", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "status", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -0,0 +1,42 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/minio/mc/pkg/mcconfig"
+)
+
+// The party paying for the requests to a requester pays bucket, set
+// via --request-payer, overrides the one of the alias.
+var globalRequestPayer string
+
+// requestPayerRequester is the only value accepted by S3, the owner
+// of a bucket pays unless the requester does.
+const requestPayerRequester = "requester"
+
+// isValidRequestPayer - validates a --request-payer value.
+func isValidRequestPayer(payer string) bool {
+	return payer == "" || payer == requestPayerRequester
+}
+
+// newRequestPayerTransport sets the request payer of the alias, if any,
+// on the requests of the given transport.
+func newRequestPayerTransport(transport http.RoundTripper, config *Config) http.RoundTripper {
+	return mcconfig.RequestPayerTransport(transport, config.RequestPayer, config.AccessKey, config.SecretKey, config.SessionToken)
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+type recordTransport struct {
+	req *http.Request
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.req = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestRequestPayerTransport(t *testing.T) {
+	config := &Config{AccessKey: "minio", SecretKey: "minio123", RequestPayer: requestPayerRequester}
+	testCases := []struct {
+		method string
+		signed bool
+	}{
+		{http.MethodGet, true},
+		{http.MethodHead, true},
+		{http.MethodPut, false},
+	}
+	for i, testCase := range testCases {
+		req, e := http.NewRequest(testCase.method, "https://s3.amazonaws.com/bucket/object", nil)
+		if e != nil {
+			t.Fatal(e)
+		}
+		req.Header.Set("X-Amz-Content-Sha256", emptySHA256Hex)
+		req = signer.SignV4(*req, config.AccessKey, config.SecretKey, "", "eu-west-1")
+		auth := req.Header.Get("Authorization")
+
+		recorder := &recordTransport{}
+		if _, e = newRequestPayerTransport(recorder, config).RoundTrip(req); e != nil {
+			t.Fatal(e)
+		}
+		sent := recorder.req.Header
+		if testCase.signed != (sent.Get("X-Amz-Request-Payer") == requestPayerRequester) {
+			t.Errorf("Test %d: unexpected request payer %q", i+1, sent.Get("X-Amz-Request-Payer"))
+		}
+		signedHeaders := strings.Contains(sent.Get("Authorization"), "x-amz-request-payer")
+		if testCase.signed != signedHeaders || testCase.signed == (sent.Get("Authorization") == auth) {
+			t.Errorf("Test %d: unexpected signature %q", i+1, sent.Get("Authorization"))
+		}
+		if !strings.Contains(sent.Get("Authorization"), "/eu-west-1/s3/") {
+			t.Errorf("Test %d: expected the region of the original signature, got %q", i+1, sent.Get("Authorization"))
+		}
+	}
+}
@@ -24,6 +24,7 @@ var retentionSubcommands = []cli.Command{
 	retentionSetCmd,
 	retentionClearCmd,
 	retentionInfoCmd,
+	retentionReportCmd,
 }
 
 var retentionCmd = cli.Command{
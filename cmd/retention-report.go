@@ -0,0 +1,277 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio/pkg/console"
+)
+
+var retentionReportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "expiring-within",
+		Usage: "list the objects whose retention expires within L days, M hours and N minutes",
+		Value: "30d",
+	},
+	cli.BoolFlag{
+		Name:  "versions",
+		Usage: "include the non current versions of the objects",
+	},
+}
+
+var retentionReportCmd = cli.Command{
+	Name:         "report",
+	Usage:        "summarize the retention of the objects of a bucket",
+	Action:       mainRetentionReport,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(retentionReportFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+  List the objects under TARGET whose retention expires soon, then count the
+  objects and their size per retention mode, with the earliest and latest
+  retain until dates. Use the global '--output csv' flag to get the report as
+  CSV.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Report the retention of the objects of a bucket.
+     $ {{.HelpName}} myminio/mybucket
+
+  2. Report the retention of all the versions under a prefix, listing the ones expiring within 7 days.
+     $ {{.HelpName}} --versions --expiring-within 7d myminio/mybucket/invoices/
+
+  3. Save the report of a bucket as CSV for an audit.
+     $ {{.HelpName}} --output csv myminio/mybucket > retention.csv
+`,
+}
+
+// retentionExpiringMessage container for an object whose retention
+// expires soon.
+type retentionExpiringMessage struct {
+	Status      string              `json:"status"`
+	Key         string              `json:"key"`
+	VersionID   string              `json:"versionId,omitempty"`
+	Mode        minio.RetentionMode `json:"mode"`
+	RetainUntil time.Time           `json:"retainUntil"`
+}
+
+// String colorized expiring object.
+func (m retentionExpiringMessage) String() string {
+	msg := console.Colorize("RetentionExpiring", fmt.Sprintf("[%s]", m.RetainUntil.Local().Format(printDate)))
+	msg += fmt.Sprintf(" %-10s %s", m.Mode, m.Key)
+	if m.VersionID != "" {
+		msg += console.Colorize("RetentionVersionID", " (versionId="+m.VersionID+")")
+	}
+	return msg
+}
+
+// JSON jsonified expiring object.
+func (m retentionExpiringMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// retentionReportMessage container for the objects of a retention
+// mode, the mode is empty for the objects without retention.
+type retentionReportMessage struct {
+	Status              string              `json:"status"`
+	Mode                minio.RetentionMode `json:"mode"`
+	Objects             int64               `json:"objects"`
+	Size                int64               `json:"size"`
+	EarliestRetainUntil *time.Time          `json:"earliestRetainUntil"`
+	LatestRetainUntil   *time.Time          `json:"latestRetainUntil"`
+	Expired             int64               `json:"expired"`
+	Expiring            int64               `json:"expiring"`
+	ExpiringWithin      string              `json:"expiringWithin"`
+}
+
+// String colorized retention mode summary.
+func (m retentionReportMessage) String() string {
+	mode := "NONE"
+	if m.Mode != "" {
+		mode = m.Mode.String()
+	}
+	msg := console.Colorize("RetentionMode", fmt.Sprintf("%-10s", mode)) + " : "
+	msg += fmt.Sprintf("%d objects, %s", m.Objects, strings.Join(strings.Fields(humanize.IBytes(uint64(m.Size))), ""))
+	if m.EarliestRetainUntil != nil {
+		msg += fmt.Sprintf(", retained until %s to %s, %d expired, %d expiring within %s",
+			m.EarliestRetainUntil.Local().Format(printDate), m.LatestRetainUntil.Local().Format(printDate),
+			m.Expired, m.Expiring, m.ExpiringWithin)
+	}
+	return msg
+}
+
+// JSON jsonified retention mode summary.
+func (m retentionReportMessage) JSON() string {
+	m.Status = "success"
+	msgBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// retentionReport counts the objects per retention mode.
+type retentionReport struct {
+	now            time.Time
+	expiringWithin time.Duration
+	modes          map[minio.RetentionMode]*retentionReportMessage
+}
+
+func newRetentionReport(now time.Time, expiringWithin string) (*retentionReport, *probe.Error) {
+	within, e := ioutils.ParseDurationTime(expiringWithin)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	report := &retentionReport{
+		now:            now,
+		expiringWithin: within,
+		modes:          map[minio.RetentionMode]*retentionReportMessage{},
+	}
+	for _, mode := range []minio.RetentionMode{minio.Governance, minio.Compliance, ""} {
+		report.modes[mode] = &retentionReportMessage{Mode: mode, ExpiringWithin: expiringWithin}
+	}
+	return report, nil
+}
+
+// add counts an object, it returns true when its retention expires
+// within the duration of the report.
+func (r *retentionReport) add(mode minio.RetentionMode, retainUntil time.Time, size int64) bool {
+	if !mode.IsValid() || retainUntil.IsZero() {
+		mode = ""
+	}
+	m := r.modes[mode]
+	m.Objects++
+	m.Size += size
+	if mode == "" {
+		return false
+	}
+	if m.EarliestRetainUntil == nil || retainUntil.Before(*m.EarliestRetainUntil) {
+		earliest := retainUntil
+		m.EarliestRetainUntil = &earliest
+	}
+	if m.LatestRetainUntil == nil || retainUntil.After(*m.LatestRetainUntil) {
+		latest := retainUntil
+		m.LatestRetainUntil = &latest
+	}
+	switch {
+	case !retainUntil.After(r.now):
+		m.Expired++
+	case retainUntil.Sub(r.now) <= r.expiringWithin:
+		m.Expiring++
+		return true
+	}
+	return false
+}
+
+// messages returns the summary of each retention mode.
+func (r *retentionReport) messages() []retentionReportMessage {
+	return []retentionReportMessage{*r.modes[minio.Governance], *r.modes[minio.Compliance], *r.modes[""]}
+}
+
+// reportRetention lists the objects under target, printing the ones
+// whose retention expires soon, and adds them to the report.
+func reportRetention(ctx context.Context, target string, withVersions bool, report *retentionReport) error {
+	clnt, err := newClient(target)
+	fatalIf(err.Trace(target), "Unable to parse the provided url.")
+	alias, _, _ := mustExpandAlias(target)
+
+	lstOptions := ListOptions{Recursive: true, ShowDir: DirNone}
+	if withVersions {
+		lstOptions.WithOlderVersions = true
+		lstOptions.TimeRef = time.Now().UTC()
+	}
+
+	var cErr error
+	for content := range clnt.List(ctx, lstOptions) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		if content.IsDeleteMarker {
+			continue
+		}
+
+		objClnt, err := newClientFromAlias(alias, content.URL.String())
+		if err != nil {
+			errorIf(err.Trace(content.URL.String()), "Invalid URL")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		mode, until, err := objClnt.GetObjectRetention(ctx, content.VersionID)
+		if err != nil && minio.ToErrorResponse(err.ToGoError()).Code != "NoSuchObjectLockConfiguration" {
+			errorIf(err.Trace(content.URL.String()), "Unable to get the retention of `%s`.", alias+getKey(content))
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+
+		if report.add(mode, until, content.Size) {
+			printMsg(retentionExpiringMessage{
+				Key:         alias + getKey(content),
+				VersionID:   content.VersionID,
+				Mode:        mode,
+				RetainUntil: until,
+			})
+		}
+	}
+	return cErr
+}
+
+// main for retention report command.
+func mainRetentionReport(cliCtx *cli.Context) error {
+	ctx, cancelRetentionReport := context.WithCancel(globalContext)
+	defer cancelRetentionReport()
+
+	console.SetColor("RetentionMode", color.New(color.FgGreen, color.Bold))
+	console.SetColor("RetentionExpiring", color.New(color.FgYellow))
+	console.SetColor("RetentionVersionID", color.New(color.FgGreen))
+
+	if len(cliCtx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "report", globalInvalidArgsExitStatus)
+	}
+	target := cliCtx.Args().Get(0)
+
+	report, err := newRetentionReport(time.Now(), cliCtx.String("expiring-within"))
+	fatalIf(err.Trace(cliCtx.String("expiring-within")), "Invalid value for --expiring-within.")
+
+	checkObjectLockSupport(ctx, target)
+
+	cErr := reportRetention(ctx, target, cliCtx.Bool("versions"), report)
+	for _, msg := range report.messages() {
+		printMsg(msg)
+	}
+	return cErr
+}
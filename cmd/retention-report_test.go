@@ -0,0 +1,68 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestRetentionReport(t *testing.T) {
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	report, err := newRetentionReport(now, "30d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		mode     minio.RetentionMode
+		until    time.Time
+		expiring bool
+	}{
+		{minio.Governance, now.Add(24 * time.Hour), true},
+		{minio.Governance, now.Add(90 * 24 * time.Hour), false},
+		{minio.Governance, now.Add(-time.Hour), false},
+		{minio.Compliance, now.Add(30 * 24 * time.Hour), true},
+		{minio.Compliance, now.Add(365 * 24 * time.Hour), false},
+		{"", time.Time{}, false},
+	}
+	for i, testCase := range testCases {
+		if expiring := report.add(testCase.mode, testCase.until, 10); expiring != testCase.expiring {
+			t.Errorf("Test %d: expected expiring %v, got %v", i+1, testCase.expiring, expiring)
+		}
+	}
+
+	msgs := report.messages()
+	governance, compliance, none := msgs[0], msgs[1], msgs[2]
+	if governance.Objects != 3 || governance.Size != 30 || governance.Expired != 1 || governance.Expiring != 1 {
+		t.Errorf("unexpected governance summary %+v", governance)
+	}
+	if !governance.EarliestRetainUntil.Equal(now.Add(-time.Hour)) || !governance.LatestRetainUntil.Equal(now.Add(90*24*time.Hour)) {
+		t.Errorf("unexpected governance retain until dates %v, %v", governance.EarliestRetainUntil, governance.LatestRetainUntil)
+	}
+	if compliance.Objects != 2 || compliance.Expired != 0 || compliance.Expiring != 1 {
+		t.Errorf("unexpected compliance summary %+v", compliance)
+	}
+	if none.Objects != 1 || none.EarliestRetainUntil != nil {
+		t.Errorf("unexpected summary of objects without retention %+v", none)
+	}
+
+	if _, err = newRetentionReport(now, "soon"); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+}
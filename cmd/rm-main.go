@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
@@ -26,6 +27,8 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/mc/pkg/colorjson"
@@ -53,6 +56,10 @@ var (
 			Name:  "recursive, r",
 			Usage: "remove recursively",
 		},
+		cli.BoolFlag{
+			Name:  "soft",
+			Usage: "only add delete markers in versioned buckets, restore with 'mc trash restore'",
+		},
 		cli.BoolFlag{
 			Name:  "force",
 			Usage: "allow a recursive remove operation",
@@ -71,7 +78,15 @@ var (
 		},
 		cli.BoolFlag{
 			Name:  "stdin",
-			Usage: "read object names from STDIN",
+			Usage: "read object names from STDIN, same as '--files-from -'",
+		},
+		cli.StringFlag{
+			Name:  "files-from",
+			Usage: "read object names from FILE, or from STDIN for '-'",
+		},
+		cli.BoolFlag{
+			Name:  "null, 0",
+			Usage: "object names read from FILE or STDIN are terminated by a NUL character instead of a newline",
 		},
 		cli.StringFlag{
 			Name:  "older-than",
@@ -85,6 +100,11 @@ var (
 			Name:  "bypass",
 			Usage: "bypass governance",
 		},
+		cli.StringFlag{
+			Name:  "manifest",
+			Usage: "remove the objects only in SECOND of a 'diff --output-manifest' manifest, relative to TARGET",
+		},
+		maxMemoryFlag,
 	}
 )
 
@@ -95,7 +115,7 @@ var rmCmd = cli.Command{
 	Action:       mainRm,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(rmFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(append(rmFlags, ioFlags...), maxRPSFlag), confirmFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -148,6 +168,25 @@ EXAMPLES:
   13. Remove all object versions older than one year.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --versions --rewind 365d
 
+  14. Remove all objects found with NUL terminated names, which may contain newlines.
+      {{.Prompt}} mc find s3/logs --older-than 30d --null | {{.HelpName}} --force --files-from - --null
+
+  15. Remove the objects of a bucket which are not in its source, as found by 'mc diff'.
+      {{.Prompt}} mc diff --output-manifest diff.json s3/mybucket play/mybucket
+      {{.Prompt}} {{.HelpName}} --force --manifest diff.json play/mybucket
+
+  16. Remove the incomplete uploads abandoned for more than 7 days, and print the bytes reclaimed.
+      {{.Prompt}} {{.HelpName}} --incomplete --recursive --force --older-than 7d s3/mybucket/
+
+  17. Remove the objects under a prefix of a versioned bucket, keeping their versions to restore them later.
+      {{.Prompt}} {{.HelpName}} --soft --recursive s3/docs/drafts/
+
+  18. Remove the objects older than 90 days of a bucket of a shared cluster, sending at most 50 requests per second.
+      {{.Prompt}} {{.HelpName}} --recursive --force --older-than 90d --max-rps 50 s3/logs/
+
+  19. Remove a prefix holding many objects after typing the name of the bucket.
+      {{.Prompt}} {{.HelpName}} --recursive --force --confirm s3/jazz-songs/louis/
+
 `,
 }
 
@@ -177,23 +216,89 @@ func (r rmMessage) String() string {
 // JSON'ified message for scripting.
 func (r rmMessage) JSON() string {
 	r.Status = "success"
-	msgBytes, e := json.MarshalIndent(r, "", " ")
+	msgBytes, e := json.Marshal(r)
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 	return string(msgBytes)
 }
 
+// rmSummaryMessage container for the summary of the incomplete uploads
+// removed recursively.
+type rmSummaryMessage struct {
+	Status       string `json:"status"`
+	TotalUploads int64  `json:"totalUploads"`
+	TotalSize    int64  `json:"totalSize"`
+	IsFake       bool   `json:"fake,omitempty"`
+}
+
+// add counts a removed incomplete upload.
+func (s *rmSummaryMessage) add(content *ClientContent) {
+	s.TotalUploads++
+	s.TotalSize += content.Size
+}
+
+// Colorized message for console printing.
+func (s rmSummaryMessage) String() string {
+	uploads := english.Plural(int(s.TotalUploads), "incomplete upload", "")
+	if s.IsFake {
+		return console.Colorize("RemoveSummary", fmt.Sprintf("Would remove %s, reclaiming %s.",
+			uploads, humanize.IBytes(uint64(s.TotalSize))))
+	}
+	return console.Colorize("RemoveSummary", fmt.Sprintf("Removed %s, reclaimed %s.",
+		uploads, humanize.IBytes(uint64(s.TotalSize))))
+}
+
+// JSON'ified message for scripting.
+func (s rmSummaryMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.Marshal(s)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// rmFilesFrom returns the file object names are read from, '-' for STDIN,
+// empty when the names are only passed as arguments.
+func rmFilesFrom(cliCtx *cli.Context) string {
+	if cliCtx.Bool("stdin") {
+		return "-"
+	}
+	return cliCtx.String("files-from")
+}
+
 // Validate command line arguments.
 func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	// Set command flags from context.
 	isForce := cliCtx.Bool("force")
 	isRecursive := cliCtx.Bool("recursive")
-	isStdin := cliCtx.Bool("stdin")
+	isFilesFrom := rmFilesFrom(cliCtx) != ""
 	isDangerous := cliCtx.Bool("dangerous")
 	isVersions := cliCtx.Bool("versions")
 	versionID := cliCtx.String("version-id")
 	rewind := cliCtx.String("rewind")
+	isSoft := cliCtx.Bool("soft")
 	isNamespaceRemoval := false
 
+	// A soft removal only adds delete markers, it never removes versions.
+	if isSoft && (isVersions || versionID != "" || rewind != "" || cliCtx.Bool("incomplete")) {
+		fatalIf(errInvalidArgument().Trace(),
+			"You cannot specify --soft with any of --versions, --version-id, --rewind and --incomplete flags.")
+	}
+
+	// The keys of --manifest are removed one by one, relative to TARGET.
+	if cliCtx.String("manifest") != "" {
+		if len(cliCtx.Args()) != 1 {
+			fatalIf(errInvalidArgument().Trace(cliCtx.Args()...), "You need to specify exactly one TARGET with --manifest.")
+		}
+		if isRecursive || isFilesFrom || isVersions || versionID != "" || rewind != "" {
+			fatalIf(errInvalidArgument().Trace(),
+				"You cannot specify --manifest with any of --recursive, --stdin, --files-from, --versions, --version-id and --rewind flags.")
+		}
+		if !isForce {
+			fatalIf(errDummy().Trace(),
+				"Removal requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
+		}
+		return
+	}
+
 	if versionID != "" && (isRecursive || isVersions || rewind != "") {
 		fatalIf(errDummy().Trace(),
 			"You cannot specify --version-id with any of --versions, --rewind and --recursive flags.")
@@ -210,7 +315,7 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 			isNamespaceRemoval = (path == "")
 			break
 		}
-		if dir && isRecursive && !isForce {
+		if dir && isRecursive && !isForce && !isSoft {
 			fatalIf(errDummy().Trace(),
 				"Removal requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
 		}
@@ -219,13 +324,20 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 				"Removal requires --recursive flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
 		}
 	}
-	if !cliCtx.Args().Present() && !isStdin {
-		exitCode := 1
+	if cliCtx.Bool("stdin") && cliCtx.String("files-from") != "" {
+		fatalIf(errInvalidArgument().Trace(), "You cannot specify --stdin with --files-from.")
+	}
+	if cliCtx.Bool("null") && !isFilesFrom {
+		fatalIf(errInvalidArgument().Trace(), "--null requires --stdin or --files-from.")
+	}
+	if !cliCtx.Args().Present() && !isFilesFrom {
+		exitCode := globalInvalidArgsExitStatus
 		cli.ShowCommandHelpAndExit(cliCtx, "rm", exitCode)
 	}
 
-	// For all recursive or versions bulk deletion operations make sure to check for 'force' flag.
-	if (isVersions || isRecursive || isStdin) && !isForce {
+	// For all recursive or versions bulk deletion operations make sure to check for 'force' flag,
+	// unless the objects removed can be restored.
+	if (isVersions || isRecursive || isFilesFrom) && !isForce && !isSoft {
 		if isNamespaceRemoval {
 			fatalIf(errDummy().Trace(),
 				"This operation results in site-wide removal of objects. If you are really sure, retry this command with ‘--dangerous’ and ‘--force’ flags.")
@@ -233,12 +345,28 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 		fatalIf(errDummy().Trace(),
 			"Removal requires --force flag. This operation is *IRREVERSIBLE*. Please review carefully before performing this *DANGEROUS* operation.")
 	}
-	if (isRecursive || isStdin) && isNamespaceRemoval && !isDangerous {
+	if (isRecursive || isFilesFrom) && isNamespaceRemoval && !isDangerous {
 		fatalIf(errDummy().Trace(),
 			"This operation results in site-wide removal of objects. If you are really sure, retry this command with ‘--dangerous’ and ‘--force’ flags.")
 	}
 }
 
+// checkSoftRm exits unless url is in a bucket with versioning enabled,
+// where removing an object only adds a delete marker. The buckets
+// checked are remembered in versioned.
+func checkSoftRm(ctx context.Context, url string, versioned map[string]bool) {
+	alias, path := url2Alias(url)
+	bucketURL := alias + "/" + strings.SplitN(filepath.ToSlash(path), "/", 2)[0]
+	if versioned[bucketURL] {
+		return
+	}
+	if !checkIfBucketIsVersioned(ctx, bucketURL) {
+		fatalIf(errInvalidArgument().Trace(url),
+			"`"+url+"` is not in a bucket with versioning enabled, --soft would remove it permanently.")
+	}
+	versioned[bucketURL] = true
+}
+
 // Remove a single object or a single version in a versioned bucket
 func removeSingle(url, versionID string, isIncomplete, isFake, isForce, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
 	ctx, cancel := context.WithCancel(globalContext)
@@ -330,7 +458,8 @@ func removeSingle(url, versionID string, isIncomplete, isFake, isForce, isBypass
 //   Use cases:
 //      * Remove objects recursively
 //      * Remove all versions of a single object
-func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isIncomplete, isFake, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+// The removed incomplete uploads are counted in summary if not nil.
+func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isIncomplete, isFake, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair, summary *rmSummaryMessage) error {
 	ctx, cancelRemove := context.WithCancel(globalContext)
 	defer cancelRemove()
 
@@ -407,6 +536,24 @@ func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isI
 			VersionID: content.VersionID,
 			ModTime:   content.Time,
 		})
+		if summary != nil {
+			// Removed one by one so that only the successful removals
+			// are counted.
+			if !isFake {
+				if pErr := removeContent(ctx, clnt, isIncomplete, isBypass, content); pErr != nil {
+					errorIf(pErr.Trace(urlString), "Failed to remove `"+urlString+"`.")
+					switch pErr.ToGoError().(type) {
+					case PathInsufficientPermission:
+						// Ignore Permission error.
+						continue
+					}
+					close(contentCh)
+					return exitStatus(globalErrorExitStatus)
+				}
+			}
+			summary.add(content)
+			continue
+		}
 
 		if !isFake {
 			sent := false
@@ -447,23 +594,56 @@ func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isI
 	return nil
 }
 
+// removeContent removes a single listed entry, the first error is returned.
+func removeContent(ctx context.Context, clnt Client, isIncomplete, isBypass bool, content *ClientContent) *probe.Error {
+	contentCh := make(chan *ClientContent, 1)
+	contentCh <- content
+	close(contentCh)
+
+	var err *probe.Error
+	for pErr := range clnt.Remove(ctx, isIncomplete, false, isBypass, contentCh) {
+		if err == nil {
+			err = pErr
+		}
+	}
+	return err
+}
+
+// confirmRemoveTree asks to type the name of the bucket before a recursive
+// removal of at least confirmLargeTree objects.
+func confirmRemoveTree(ctx context.Context, url string, opts ListOptions) *probe.Error {
+	clnt, err := newClient(url)
+	if err != nil {
+		return err.Trace(url)
+	}
+	count, more := countObjects(ctx, clnt, opts, confirmLargeTree)
+	if !more {
+		return nil
+	}
+	description := fmt.Sprintf("Removing `%s` recursively removes %s objects.", url, formatObjectCount(count, more))
+	return confirmRemoval(description, confirmName(url))
+}
+
 // main for rm command.
 func mainRm(cliCtx *cli.Context) error {
 	ctx, cancelRm := context.WithCancel(globalContext)
 	defer cancelRm()
 
+	setGlobalRateLimiter(cliCtx)
+
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(cliCtx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
 	// check 'rm' cli arguments.
 	checkRmSyntax(ctx, cliCtx, encKeyDB)
+	setMaxMemoryFromContext(cliCtx)
 
 	// rm specific flags.
 	isIncomplete := cliCtx.Bool("incomplete")
 	isRecursive := cliCtx.Bool("recursive")
 	isFake := cliCtx.Bool("fake")
-	isStdin := cliCtx.Bool("stdin")
+	filesFrom := rmFilesFrom(cliCtx)
 	isBypass := cliCtx.Bool("bypass")
 	olderThan := cliCtx.String("older-than")
 	newerThan := cliCtx.String("newer-than")
@@ -471,6 +651,15 @@ func mainRm(cliCtx *cli.Context) error {
 	withVersions := cliCtx.Bool("versions")
 	versionID := cliCtx.String("version-id")
 	rewind := parseRewindFlag(cliCtx.String("rewind"))
+	isSoft := cliCtx.Bool("soft")
+
+	// Buckets checked to be versioned by a soft removal.
+	versioned := map[string]bool{}
+	if isSoft {
+		for _, url := range cliCtx.Args() {
+			checkSoftRm(ctx, url, versioned)
+		}
+	}
 
 	if withVersions && rewind.IsZero() {
 		rewind = time.Now().UTC()
@@ -478,13 +667,53 @@ func mainRm(cliCtx *cli.Context) error {
 
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
+	console.SetColor("RemoveSummary", color.New(color.Bold))
+
+	// Summarize the incomplete uploads removed recursively.
+	var summary *rmSummaryMessage
+	if isIncomplete && isRecursive {
+		summary = &rmSummaryMessage{IsFake: isFake}
+		defer func() { printMsg(*summary) }()
+	}
+
+	// Large trees are only removed once their bucket name is typed.
+	isConfirm := isRecursive && !isFake && !isSoft && isConfirmRequired(cliCtx)
+	if isConfirm && filesFrom != "" {
+		fatalIf(errInvalidArgument().Trace(), "Unable to confirm the removal of the names read from FILE or STDIN, retry with --yes.")
+	}
 
 	var rerr error
 	var e error
+
+	if manifestFile := cliCtx.String("manifest"); manifestFile != "" {
+		manifest, err := loadDiffManifest(manifestFile)
+		fatalIf(err, "Unable to read the manifest `%s`.", manifestFile)
+		for _, key := range manifest.removeKeys() {
+			if isSoft {
+				checkSoftRm(ctx, urlJoinPath(cliCtx.Args().Get(0), key), versioned)
+			}
+			e = removeSingle(urlJoinPath(cliCtx.Args().Get(0), key), "", isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB)
+			if rerr == nil {
+				rerr = e
+			}
+		}
+		return rerr
+	}
+
 	// Support multiple targets.
 	for _, url := range cliCtx.Args() {
+		if isConfirm {
+			opts := ListOptions{
+				Recursive:         true,
+				Incomplete:        isIncomplete,
+				WithOlderVersions: withVersions,
+				WithDeleteMarkers: withVersions,
+				ShowDir:           DirNone,
+			}
+			fatalIf(confirmRemoveTree(ctx, url, opts), "Unable to remove `"+url+"`.")
+		}
 		if isRecursive || withVersions {
-			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB)
+			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB, summary)
 		} else {
 			e = removeSingle(url, versionID, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB)
 		}
@@ -493,15 +722,27 @@ func mainRm(cliCtx *cli.Context) error {
 		}
 	}
 
-	if !isStdin {
+	if filesFrom == "" {
 		return rerr
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
+	input := os.Stdin
+	if filesFrom != "-" {
+		input, e = os.Open(filesFrom)
+		fatalIf(probe.NewError(e).Trace(filesFrom), "Unable to open the object names file.")
+		defer input.Close()
+	}
+	scanner := bufio.NewScanner(input)
+	if cliCtx.Bool("null") {
+		scanner.Split(scanNullTerminated)
+	}
 	for scanner.Scan() {
 		url := scanner.Text()
+		if isSoft {
+			checkSoftRm(ctx, url, versioned)
+		}
 		if isRecursive || withVersions {
-			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB)
+			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB, summary)
 		} else {
 			e = removeSingle(url, versionID, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB)
 		}
@@ -512,3 +753,17 @@ func mainRm(cliCtx *cli.Context) error {
 
 	return rerr
 }
+
+// scanNullTerminated is a bufio.SplitFunc splitting NUL terminated names.
+func scanNullTerminated(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,230 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var runCmd = cli.Command{
+	Name:         "run",
+	Usage:        "run a command macro defined in the config",
+	Action:       mainRun,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [MACRO [ARGS...]]
+
+  Macros are defined in the "macros" section of the config file, e.g.
+    "macros": {
+      "backup-logs": "mirror --older-than 1d local/logs s3/backup/logs",
+      "restore": "cp --recursive s3/backup/$1/ ${2}"
+    }
+
+  $1 to $9, or ${N}, are replaced by the arguments given to the macro and
+  $@ by all of them. Arguments are appended to macros without parameters.
+  Without a macro name all macros are listed.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List all macros.
+     {{.Prompt}} {{.HelpName}}
+
+  2. Run the 'backup-logs' macro.
+     {{.Prompt}} {{.HelpName}} backup-logs
+
+  3. Run the 'restore' macro with its two parameters.
+     {{.Prompt}} {{.HelpName}} restore 2021-05-01 /tmp/restore/
+`,
+}
+
+// macroMessage container for a macro listed by 'mc run'.
+type macroMessage struct {
+	Status  string `json:"status"`
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+// String colorized macro message.
+func (m macroMessage) String() string {
+	return fmt.Sprintf("%s = %s", console.Colorize("MacroName", m.Name), m.Command)
+}
+
+// JSON jsonified macro message.
+func (m macroMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.Marshal(m)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// splitMacro splits a macro into arguments, honoring single and double
+// quotes as well as backslash escapes like a POSIX shell does.
+func splitMacro(macro string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var inArg bool
+	var quote rune
+	escaped := false
+	for _, r := range macro {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if escaped || quote != 0 {
+		return nil, errors.New("unterminated quote or escape")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+var macroParamRgx = regexp.MustCompile(`\$(\d|\{\d+\})`)
+
+// expandMacro substitutes the macro parameters with the given arguments.
+func expandMacro(macro string, params []string) ([]string, error) {
+	args, e := splitMacro(macro)
+	if e != nil {
+		return nil, e
+	}
+
+	var expanded []string
+	var substituted bool
+	for _, arg := range args {
+		if arg == "$@" {
+			expanded = append(expanded, params...)
+			substituted = true
+			continue
+		}
+		var missing string
+		arg = macroParamRgx.ReplaceAllStringFunc(arg, func(p string) string {
+			substituted = true
+			n, _ := strconv.Atoi(strings.Trim(p, "${}"))
+			if n < 1 || n > len(params) {
+				missing = p
+				return ""
+			}
+			return params[n-1]
+		})
+		if missing != "" {
+			return nil, fmt.Errorf("missing argument for parameter `%s`", missing)
+		}
+		expanded = append(expanded, arg)
+	}
+	if !substituted {
+		expanded = append(expanded, params...)
+	}
+	return expanded, nil
+}
+
+// mainRun is the main entry point for run command.
+func mainRun(ctx *cli.Context) error {
+	console.SetColor("MacroName", color.New(color.FgCyan, color.Bold))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(), "Unable to load config.")
+
+	if !ctx.Args().Present() {
+		names := make([]string, 0, len(conf.Macros))
+		for name := range conf.Macros {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			printMsg(macroMessage{Name: name, Command: conf.Macros[name]})
+		}
+		return nil
+	}
+
+	name := ctx.Args().First()
+	macro, ok := conf.Macros[name]
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(name), "Macro `%s` is not defined.", name)
+	}
+
+	args, e := expandMacro(macro, ctx.Args().Tail())
+	fatalIf(probe.NewError(e), "Unable to expand macro `%s`.", name)
+	if len(args) == 0 {
+		fatalIf(errInvalidArgument().Trace(name), "Macro `%s` is empty.", name)
+	}
+
+	executable, e := os.Executable()
+	fatalIf(probe.NewError(e), "Unable to find the mc executable.")
+
+	globalArgs := []string{"--config-dir", mustGetMcConfigDir(), "--profile", getMcProfile()}
+	for _, flag := range []string{"quiet", "json", "debug", "insecure", "no-color"} {
+		if ctx.IsSet(flag) || ctx.GlobalIsSet(flag) {
+			globalArgs = append(globalArgs, "--"+flag)
+		}
+	}
+
+	cmd := exec.Command(executable, append(globalArgs, args...)...)
+	cmd.Env = childEnv()
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if e = cmd.Run(); e != nil {
+		var exitErr *exec.ExitError
+		if errors.As(e, &exitErr) {
+			return exitStatus(exitErr.ExitCode())
+		}
+		fatalIf(probe.NewError(e), "Unable to run macro `%s`.", name)
+	}
+	return nil
+}
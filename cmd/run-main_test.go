@@ -0,0 +1,53 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandMacro(t *testing.T) {
+	testCases := []struct {
+		macro    string
+		params   []string
+		expected []string
+		success  bool
+	}{
+		{"mirror --older-than 1d local/logs s3/backup/logs", nil,
+			[]string{"mirror", "--older-than", "1d", "local/logs", "s3/backup/logs"}, true},
+		{"ls s3/backup", []string{"--recursive"},
+			[]string{"ls", "s3/backup", "--recursive"}, true},
+		{"cp --recursive s3/backup/$1/ ${2}", []string{"2021-05-01", "/tmp/restore/"},
+			[]string{"cp", "--recursive", "s3/backup/2021-05-01/", "/tmp/restore/"}, true},
+		{"rm $@ s3/tmp", []string{"--recursive", "--force"},
+			[]string{"rm", "--recursive", "--force", "s3/tmp"}, true},
+		{`find s3/logs --name "*.log" --exec 'echo {}'`, nil,
+			[]string{"find", "s3/logs", "--name", "*.log", "--exec", "echo {}"}, true},
+		{"cp $1 $2", []string{"a"}, nil, false},
+		{`ls "s3/unterminated`, nil, nil, false},
+	}
+	for i, testCase := range testCases {
+		got, e := expandMacro(testCase.macro, testCase.params)
+		if testCase.success != (e == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, e)
+		}
+		if testCase.success && !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
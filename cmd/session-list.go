@@ -0,0 +1,87 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/minio/pkg/console"
+)
+
+var sessionListCmd = cli.Command{
+	Name:            "list",
+	ShortName:       "ls",
+	Usage:           "list interrupted copy and mirror sessions",
+	Action:          mainSessionList,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List the interrupted sessions with their progress.
+     {{.Prompt}} {{.HelpName}}
+`,
+}
+
+// checkSessionListSyntax - validate all the passed arguments
+func checkSessionListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "list", globalInvalidArgsExitStatus)
+	}
+}
+
+// listSessions - get all the sessions, oldest first.
+func listSessions() (sessions []sessionV8) {
+	for _, sid := range getSessionIDs() {
+		header, err := loadSessionV8Header(sid)
+		if err != nil {
+			errorIf(err.Trace(sid), "Unable to load session `%s`.", shortSessionID(sid))
+			continue
+		}
+		sessions = append(sessions, sessionV8{Header: header, SessionID: sid})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Header.When.Before(sessions[j].Header.When)
+	})
+	return sessions
+}
+
+// mainSessionList is the handle for "mc session list" command.
+func mainSessionList(ctx *cli.Context) error {
+	checkSessionListSyntax(ctx)
+
+	console.SetColor("SessionID", color.New(color.FgYellow, color.Bold))
+	console.SetColor("SessionTime", color.New(color.FgGreen))
+	console.SetColor("Command", color.New(color.FgWhite, color.Bold))
+	console.SetColor("SessionProgress", color.New(color.FgCyan))
+
+	for _, session := range listSessions() {
+		printMsg(session)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var sessionPruneFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "remove the sessions without progress for longer than this duration, e.g. '7d10h31s'",
+	},
+	cli.BoolFlag{
+		Name:  "all",
+		Usage: "remove all the sessions",
+	},
+}
+
+var sessionPruneCmd = cli.Command{
+	Name:            "prune",
+	Usage:           "remove interrupted copy and mirror sessions",
+	Action:          mainSessionPrune,
+	Before:          setGlobalsFromContext,
+	Flags:           append(sessionPruneFlags, globalFlags...),
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --older-than DURATION | --all
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the sessions which made no progress for a week.
+     {{.Prompt}} {{.HelpName}} --older-than 7d
+
+  2. Remove all the sessions.
+     {{.Prompt}} {{.HelpName}} --all
+`,
+}
+
+// sessionPruneMessage container for removed session messages
+type sessionPruneMessage struct {
+	Status      string   `json:"status"`
+	SessionID   string   `json:"sessionId"`
+	CommandType string   `json:"commandType"`
+	CommandArgs []string `json:"commandArgs"`
+}
+
+// String colorized removed session message.
+func (s sessionPruneMessage) String() string {
+	return console.Colorize("SessionPrune", fmt.Sprintf("Removed session `%s` (%s %s).",
+		shortSessionID(s.SessionID), s.CommandType, strings.Join(s.CommandArgs, " ")))
+}
+
+// JSON jsonified removed session message.
+func (s sessionPruneMessage) JSON() string {
+	s.Status = "success"
+	sessionBytes, e := json.Marshal(s)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(sessionBytes)
+}
+
+// checkSessionPruneSyntax - validate all the passed arguments
+func checkSessionPruneSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 || ctx.IsSet("older-than") == ctx.Bool("all") {
+		cli.ShowCommandHelpAndExit(ctx, "prune", globalInvalidArgsExitStatus)
+	}
+}
+
+// removeSession - remove all the files of a session.
+func removeSession(sid string) *probe.Error {
+	sessionFile, err := getSessionFile(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	dataFile, err := getSessionDataFile(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	doneFile, err := getSessionDoneFile(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	for _, file := range []string{dataFile, doneFile, sessionFile + ".old", sessionFile} {
+		if e := os.Remove(file); e != nil && !os.IsNotExist(e) {
+			return probe.NewError(e)
+		}
+	}
+	return nil
+}
+
+// mainSessionPrune is the handle for "mc session prune" command.
+func mainSessionPrune(ctx *cli.Context) error {
+	checkSessionPruneSyntax(ctx)
+
+	console.SetColor("SessionPrune", color.New(color.FgGreen, color.Bold))
+
+	var olderThan time.Duration
+	if ctx.IsSet("older-than") {
+		var e error
+		olderThan, e = ioutils.ParseDurationTime(ctx.String("older-than"))
+		fatalIf(probe.NewError(e).Trace(ctx.String("older-than")), "Invalid value for --older-than.")
+	}
+
+	for _, sid := range getSessionIDs() {
+		sessionFile, err := getSessionFile(sid)
+		fatalIf(err.Trace(sid), "Unable to determine session filename for `%s`.", sid)
+
+		// The session file is saved on every progress.
+		st, e := os.Stat(sessionFile)
+		if e != nil || time.Since(st.ModTime()) < olderThan {
+			continue
+		}
+
+		msg := sessionPruneMessage{SessionID: sid}
+		if header, err := loadSessionV8Header(sid); err == nil {
+			msg.CommandType = header.CommandType
+			msg.CommandArgs = header.CommandArgs
+		}
+		if err := removeSession(sid); err != nil {
+			errorIf(err.Trace(sid), "Unable to remove session `%s`.", shortSessionID(sid))
+			continue
+		}
+		printMsg(msg)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var sessionResumeCmd = cli.Command{
+	Name:            "resume",
+	Usage:           "resume an interrupted copy or mirror session",
+	Action:          mainSessionResume,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	OnUsageError:    onUsageError,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} SESSION-ID
+
+  SESSION-ID is shown by 'mc session list', any unique prefix of it is accepted.
+  Copies skip the objects already copied, mirrors skip the objects already
+  present on the target.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Resume an interrupted copy.
+     {{.Prompt}} {{.HelpName}} cp-4a1b9f0c
+`,
+}
+
+// checkSessionResumeSyntax - validate all the passed arguments
+func checkSessionResumeSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "resume", globalInvalidArgsExitStatus)
+	}
+}
+
+// resumeArgs - get the command line resuming a session, copies and
+// moves resume their session with --continue.
+func resumeArgs(header *sessionV8Header) []string {
+	args := append([]string{}, header.CommandLine...)
+	if header.CommandType != "cp" && header.CommandType != "mv" {
+		return args
+	}
+	for i, arg := range args {
+		if arg == header.CommandType {
+			resumed := append(append([]string{}, args[:i+1]...), "--continue")
+			return append(resumed, args[i+1:]...)
+		}
+	}
+	return args
+}
+
+// mainSessionResume is the handle for "mc session resume" command.
+func mainSessionResume(ctx *cli.Context) error {
+	checkSessionResumeSyntax(ctx)
+
+	id := ctx.Args().Get(0)
+	sid, err := findSessionID(id)
+	fatalIf(err.Trace(id), "Unable to find session.")
+
+	header, err := loadSessionV8Header(sid)
+	fatalIf(err.Trace(sid), "Unable to load session `%s`.", id)
+	if len(header.CommandLine) == 0 {
+		fatalIf(errDummy().Trace(sid), "Session `%s` was created by an older version of mc, run the same `%s` command with `--continue` to resume it.",
+			shortSessionID(sid), header.CommandType)
+	}
+
+	executable, e := os.Executable()
+	fatalIf(probe.NewError(e), "Unable to find the mc executable.")
+
+	// The original command runs again from its working folder, it maps
+	// to the same session which skips what was already done.
+	cmd := exec.Command(executable, resumeArgs(header)...)
+	cmd.Dir = header.RootPath
+	cmd.Env = childEnv(mcEnvProfile + "=" + getMcProfile())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if e = cmd.Run(); e != nil {
+		var exitErr *exec.ExitError
+		if errors.As(e, &exitErr) {
+			return exitStatus(exitErr.ExitCode())
+		}
+		fatalIf(probe.NewError(e), "Unable to resume session `%s`.", shortSessionID(sid))
+	}
+	return nil
+}
@@ -19,6 +19,7 @@
 package cmd
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
@@ -27,6 +28,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
@@ -51,15 +53,23 @@ type sessionV8Header struct {
 	TotalBytes         int64             `json:"totalBytes"`
 	TotalObjects       int64             `json:"totalObjects"`
 	UserMetaData       map[string]string `json:"metaData"`
+	// Full command line, used to resume the session.
+	CommandLine   []string `json:"commandLine,omitempty"`
+	CopiedBytes   int64    `json:"copiedBytes,omitempty"`
+	CopiedObjects int64    `json:"copiedObjects,omitempty"`
 }
 
 // sessionMessage container for session messages
 type sessionMessage struct {
-	Status      string    `json:"status"`
-	SessionID   string    `json:"sessionId"`
-	Time        time.Time `json:"time"`
-	CommandType string    `json:"commandType"`
-	CommandArgs []string  `json:"commandArgs"`
+	Status        string    `json:"status"`
+	SessionID     string    `json:"sessionId"`
+	Time          time.Time `json:"time"`
+	CommandType   string    `json:"commandType"`
+	CommandArgs   []string  `json:"commandArgs"`
+	CopiedObjects int64     `json:"copiedObjects"`
+	CopiedBytes   int64     `json:"copiedBytes"`
+	TotalObjects  int64     `json:"totalObjects,omitempty"`
+	TotalBytes    int64     `json:"totalBytes,omitempty"`
 }
 
 // sessionV8 resumable session container.
@@ -68,6 +78,8 @@ type sessionV8 struct {
 	SessionID string
 	mutex     *sync.Mutex
 	DataFP    *sessionDataFP
+	// Objects copied so far, one source URL per line.
+	doneFP *os.File
 }
 
 // sessionDataFP data file pointer.
@@ -81,31 +93,52 @@ func (file *sessionDataFP) Write(p []byte) (int, error) {
 	return file.File.Write(p)
 }
 
+// progress returns the copied objects and bytes of the session,
+// against the totals when known.
+func (s sessionV8) progress() string {
+	h := s.Header
+	if h.TotalObjects == 0 {
+		return fmt.Sprintf("%d objects, %s", h.CopiedObjects, humanize.IBytes(uint64(h.CopiedBytes)))
+	}
+	var percent int64 = 100
+	if h.TotalBytes > 0 {
+		percent = h.CopiedBytes * 100 / h.TotalBytes
+	}
+	return fmt.Sprintf("%d/%d objects, %s/%s (%d%%)", h.CopiedObjects, h.TotalObjects,
+		humanize.IBytes(uint64(h.CopiedBytes)), humanize.IBytes(uint64(h.TotalBytes)), percent)
+}
+
 // String colorized session message.
 func (s sessionV8) String() string {
-	message := console.Colorize("SessionID", fmt.Sprintf("%s -> ", s.SessionID))
+	message := console.Colorize("SessionID", fmt.Sprintf("%s -> ", shortSessionID(s.SessionID)))
 	message = message + console.Colorize("SessionTime", fmt.Sprintf("[%s]", s.Header.When.Local().Format(printDate)))
 	message = message + console.Colorize("Command", fmt.Sprintf(" %s %s", s.Header.CommandType, strings.Join(s.Header.CommandArgs, " ")))
+	message = message + console.Colorize("SessionProgress", fmt.Sprintf(" (%s)", s.progress()))
 	return message
 }
 
 // JSON jsonified session message.
 func (s sessionV8) JSON() string {
 	sessionMsg := sessionMessage{
-		SessionID:   s.SessionID,
-		Time:        s.Header.When.Local(),
-		CommandType: s.Header.CommandType,
-		CommandArgs: s.Header.CommandArgs,
+		SessionID:     s.SessionID,
+		Time:          s.Header.When.Local(),
+		CommandType:   s.Header.CommandType,
+		CommandArgs:   s.Header.CommandArgs,
+		CopiedObjects: s.Header.CopiedObjects,
+		CopiedBytes:   s.Header.CopiedBytes,
+		TotalObjects:  s.Header.TotalObjects,
+		TotalBytes:    s.Header.TotalBytes,
 	}
 	sessionMsg.Status = "success"
-	sessionBytes, e := json.MarshalIndent(sessionMsg, "", " ")
+	sessionBytes, e := json.Marshal(sessionMsg)
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
 	return string(sessionBytes)
 }
 
-// loadSessionV8 - reads session file if exists and re-initiates internal variables
-func loadSessionV8(sid string) (*sessionV8, *probe.Error) {
+// loadSessionV8Header - reads the header of a session, without
+// opening its data file.
+func loadSessionV8Header(sid string) (*sessionV8Header, *probe.Error) {
 	if !isSessionDirExists() {
 		return nil, errInvalidArgument().Trace()
 	}
@@ -118,22 +151,18 @@ func loadSessionV8(sid string) (*sessionV8, *probe.Error) {
 		return nil, probe.NewError(e)
 	}
 
-	// Initialize new session.
-	s := &sessionV8{
-		Header: &sessionV8Header{
-			Version: globalSessionConfigVersion,
-		},
-		SessionID: sid,
+	header := &sessionV8Header{
+		Version: globalSessionConfigVersion,
 	}
 
 	// Initialize session config loader.
-	qs, e := quick.NewConfig(s.Header, nil)
+	qs, e := quick.NewConfig(header, nil)
 	if e != nil {
-		return nil, probe.NewError(e).Trace(sid, s.Header.Version)
+		return nil, probe.NewError(e).Trace(sid, header.Version)
 	}
 
 	if e = qs.Load(sessionFile); e != nil {
-		return nil, probe.NewError(e).Trace(sid, s.Header.Version)
+		return nil, probe.NewError(e).Trace(sid, header.Version)
 	}
 
 	// Validate if the version matches with expected current version.
@@ -143,16 +172,31 @@ func loadSessionV8(sid string) (*sessionV8, *probe.Error) {
 			sV8Header.Version, globalSessionConfigVersion)
 		return nil, probe.NewError(errors.New(msg)).Trace(sid, sV8Header.Version)
 	}
+	return sV8Header, nil
+}
 
-	s.mutex = new(sync.Mutex)
-	s.Header = sV8Header
+// loadSessionV8 - reads session file if exists and re-initiates internal variables
+func loadSessionV8(sid string) (*sessionV8, *probe.Error) {
+	sV8Header, err := loadSessionV8Header(sid)
+	if err != nil {
+		return nil, err.Trace(sid)
+	}
+
+	// Initialize new session.
+	s := &sessionV8{
+		Header:    sV8Header,
+		SessionID: sid,
+		mutex:     new(sync.Mutex),
+	}
 
 	sessionDataFile, err := getSessionDataFile(s.SessionID)
 	if err != nil {
 		return nil, err.Trace(sid, s.Header.Version)
 	}
 
-	dataFile, e := os.Open(sessionDataFile)
+	// Opened for writing as well, URLs are prepared again when the
+	// session was interrupted before copying anything.
+	dataFile, e := os.OpenFile(sessionDataFile, os.O_RDWR, 0600)
 	if e != nil {
 		return nil, probe.NewError(e)
 	}
@@ -175,6 +219,7 @@ func newSessionV8(sessionID string) *sessionV8 {
 	s.Header.CommandIntFlags = make(map[string]int)
 	s.Header.CommandStringFlags = make(map[string]string)
 	s.Header.UserMetaData = make(map[string]string)
+	s.Header.CommandLine = os.Args[1:]
 	s.Header.When = UTCNow()
 	s.mutex = new(sync.Mutex)
 	s.SessionID = sessionID
@@ -187,6 +232,12 @@ func newSessionV8(sessionID string) *sessionV8 {
 
 	s.DataFP = &sessionDataFP{false, dataFile}
 
+	// Objects copied by an earlier session of the same command must
+	// not be skipped.
+	if doneFile, err := getSessionDoneFile(s.SessionID); err == nil {
+		os.Remove(doneFile)
+	}
+
 	// Capture state of global flags.
 	s.setGlobals()
 
@@ -198,6 +249,63 @@ func (s sessionV8) HasData() bool {
 	return s.Header.LastCopied != "" || s.Header.LastRemoved != ""
 }
 
+// markCopied records an object copied by this session, recorded
+// objects are skipped when the session is resumed.
+func (s *sessionV8) markCopied(sourceURL string, size int64) *probe.Error {
+	s.mutex.Lock()
+	if s.doneFP == nil {
+		doneFile, err := getSessionDoneFile(s.SessionID)
+		if err != nil {
+			s.mutex.Unlock()
+			return err.Trace(s.SessionID)
+		}
+		f, e := os.OpenFile(doneFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if e != nil {
+			s.mutex.Unlock()
+			return probe.NewError(e)
+		}
+		s.doneFP = f
+	}
+	_, e := s.doneFP.WriteString(sourceURL + "\n")
+	s.Header.LastCopied = sourceURL
+	s.mutex.Unlock()
+	if e != nil {
+		return probe.NewError(e)
+	}
+	return s.addCopied(size)
+}
+
+// addCopied adds an object of the given size to the progress of
+// this session.
+func (s *sessionV8) addCopied(size int64) *probe.Error {
+	s.mutex.Lock()
+	s.Header.CopiedObjects++
+	s.Header.CopiedBytes += size
+	s.mutex.Unlock()
+	return s.Save()
+}
+
+// copiedURLs returns the source URLs of the objects already copied
+// by this session, nil for sessions which did not record them.
+func (s *sessionV8) copiedURLs() map[string]bool {
+	doneFile, err := getSessionDoneFile(s.SessionID)
+	if err != nil {
+		return nil
+	}
+	f, e := os.Open(doneFile)
+	if e != nil {
+		return nil
+	}
+	defer f.Close()
+
+	copied := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		copied[scanner.Text()] = true
+	}
+	return copied
+}
+
 // NewDataReader provides reader interface to session data file.
 func (s *sessionV8) NewDataReader() io.Reader {
 	// DataFP is always intitialized, either via new or load functions.
@@ -318,6 +426,9 @@ func (s *sessionV8) Close() *probe.Error {
 	if err := s.DataFP.Close(); err != nil {
 		return probe.NewError(err)
 	}
+	if s.doneFP != nil {
+		s.doneFP.Close()
+	}
 
 	// Attempt to save the header if modified.
 	return s.save()
@@ -341,6 +452,14 @@ func (s *sessionV8) Delete() *probe.Error {
 		}
 	}
 
+	if s.doneFP != nil {
+		s.doneFP.Close()
+	}
+	// Remove the list of copied objects if any, ignore any error.
+	if doneFile, err := getSessionDoneFile(s.SessionID); err == nil {
+		os.Remove(doneFile)
+	}
+
 	// Fetch the session file.
 	sessionFile, err := getSessionFile(s.SessionID)
 	if err != nil {
@@ -361,13 +480,13 @@ func (s *sessionV8) Delete() *probe.Error {
 // Close a session and exit.
 func (s sessionV8) CloseAndDie() {
 	s.Close()
-	console.Fatalln("Session safely terminated. Run the same command to resume copy again.")
+	console.Fatalln("Session safely terminated. Run `mc session resume " + shortSessionID(s.SessionID) + "` to resume copy again.")
 }
 
 func (s sessionV8) copyCloseAndDie(sessionFlag bool) {
 	if sessionFlag {
 		s.Close()
-		console.Fatalln("Command terminated safely. Run this command to resume copy again.")
+		console.Fatalln("Command terminated safely. Run `mc session resume " + shortSessionID(s.SessionID) + "` to resume copy again.")
 	} else {
 		s.mutex.Lock()
 		defer s.mutex.Unlock()
@@ -19,6 +19,7 @@ package cmd
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -107,6 +108,17 @@ func getSessionDataFile(sid string) (string, *probe.Error) {
 	return sessionDataFile, nil
 }
 
+// getSessionDoneFile - get the list of copied objects of a given session.
+func getSessionDoneFile(sid string) (string, *probe.Error) {
+	sessionDir, err := getSessionDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+
+	sessionDoneFile := filepath.Join(sessionDir, sid+".done")
+	return sessionDoneFile, nil
+}
+
 // getSessionIDs - get all active sessions.
 func getSessionIDs() (sids []string) {
 	sessionDir, err := getSessionDir()
@@ -121,6 +133,51 @@ func getSessionIDs() (sids []string) {
 	return sids
 }
 
+// findSessionID - get the session matching an ID as displayed to
+// the user, or any unique prefix of it.
+func findSessionID(id string) (string, *probe.Error) {
+	var matches []string
+	for _, sid := range getSessionIDs() {
+		if sid == id {
+			return sid, nil
+		}
+		if strings.HasPrefix(sid, id) {
+			matches = append(matches, sid)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", probe.NewError(fmt.Errorf("session `%s` not found", id))
+	case 1:
+		return matches[0], nil
+	}
+	return "", probe.NewError(fmt.Errorf("session ID `%s` is ambiguous, %d sessions match", id, len(matches)))
+}
+
+// shortSessionID - get the session ID as displayed to the user, the
+// command followed by the first 8 characters of the hash.
+func shortSessionID(sid string) string {
+	if i := strings.LastIndex(sid, "-"); i >= 0 && len(sid) > i+9 {
+		return sid[:i+9]
+	}
+	return sid
+}
+
+// sessionArgs - get the arguments identifying the session of the
+// current command, the flags resuming a session are left out so that
+// a command maps to the same session with and without them.
+func sessionArgs() []string {
+	var args []string
+	for _, arg := range os.Args[1:] {
+		switch arg {
+		case "--continue", "-continue", "--c", "-c":
+			continue
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
 func getHash(prefix string, args []string) string {
 	hasher := sha256.New()
 	for _, arg := range args {
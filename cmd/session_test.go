@@ -58,3 +58,42 @@ func (s *TestSuite) TestSession(c *C) {
 	_, e = os.Stat(session.DataFP.Name())
 	c.Assert(e, NotNil)
 }
+
+func (s *TestSuite) TestSessionCopied(c *C) {
+	err := createSessionDir()
+	c.Assert(err, IsNil)
+
+	session := newSessionV8(getHash("cp", []string{"-r", "mybucket/", "myminio/mybucket"}))
+	c.Assert(session.copiedURLs(), IsNil)
+
+	c.Assert(session.markCopied("mybucket/a", 10), IsNil)
+	c.Assert(session.markCopied("mybucket/b", 20), IsNil)
+	c.Assert(session.Close(), IsNil)
+
+	savedSession, err := loadSessionV8(session.SessionID)
+	c.Assert(err, IsNil)
+	c.Assert(savedSession.Header.CopiedObjects, Equals, int64(2))
+	c.Assert(savedSession.Header.CopiedBytes, Equals, int64(30))
+	c.Assert(savedSession.copiedURLs(), DeepEquals, map[string]bool{"mybucket/a": true, "mybucket/b": true})
+
+	c.Assert(savedSession.Delete(), IsNil)
+	c.Assert(savedSession.copiedURLs(), IsNil)
+}
+
+func (s *TestSuite) TestSessionResumeArgs(c *C) {
+	testCases := []struct {
+		header   sessionV8Header
+		expected []string
+	}{
+		{sessionV8Header{CommandType: "cp", CommandLine: []string{"--json", "cp", "-r", "a/", "b/"}},
+			[]string{"--json", "cp", "--continue", "-r", "a/", "b/"}},
+		{sessionV8Header{CommandType: "mv", CommandLine: []string{"mv", "a/", "b/"}},
+			[]string{"mv", "--continue", "a/", "b/"}},
+		{sessionV8Header{CommandType: "mirror", CommandLine: []string{"mirror", "a/", "b/"}},
+			[]string{"mirror", "a/", "b/"}},
+	}
+	for _, testCase := range testCases {
+		c.Assert(resumeArgs(&testCase.header), DeepEquals, testCase.expected)
+	}
+	c.Assert(shortSessionID("cp-98c0603fd19e71b2e902e7ffe60baff8"), Equals, "cp-98c0603f")
+}
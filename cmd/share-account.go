@@ -0,0 +1,132 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/policy/condition"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// shareAccount is a service account which signs the URLs of revocable
+// download shares. It is only allowed to download the objects shared,
+// deleting it revokes all the URLs it signed.
+type shareAccount struct {
+	accessKey string
+	secretKey string
+}
+
+// newShareAccount creates a service account of the user of the alias,
+// only allowed to download the objects of the bucket whose names match
+// the pattern, which may end with '*' to match a prefix.
+func newShareAccount(ctx context.Context, targetAlias, bucket, pattern string) (*shareAccount, *probe.Error) {
+	client, err := newAdminClient(targetAlias)
+	if err != nil {
+		return nil, err.Trace(targetAlias)
+	}
+
+	sharePolicy := iampolicy.Policy{
+		Version: iampolicy.DefaultVersion,
+		Statements: []iampolicy.Statement{
+			iampolicy.NewStatement(
+				policy.Allow,
+				iampolicy.NewActionSet(iampolicy.GetObjectAction, iampolicy.GetObjectVersionAction),
+				iampolicy.NewResourceSet(iampolicy.NewResource(bucket, pattern)),
+				condition.NewFunctions(),
+			),
+			// Needed to sign for the region of the bucket.
+			iampolicy.NewStatement(
+				policy.Allow,
+				iampolicy.NewActionSet(iampolicy.GetBucketLocationAction),
+				iampolicy.NewResourceSet(iampolicy.NewResource(bucket, "")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+	if e := sharePolicy.Validate(); e != nil {
+		return nil, probe.NewError(e).Trace(bucket, pattern)
+	}
+	buf, e := json.Marshal(sharePolicy)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	creds, e := client.AddServiceAccount(ctx, madmin.AddServiceAccountReq{Policy: buf})
+	if e != nil {
+		return nil, probe.NewError(e).Trace(targetAlias, bucket, pattern)
+	}
+	return &shareAccount{accessKey: creds.AccessKey, secretKey: creds.SecretKey}, nil
+}
+
+// newShareTargetAccount creates the service account of a revocable
+// share of TARGET, restricted to the object shared, or to all the
+// objects under the folder shared.
+func newShareTargetAccount(ctx context.Context, targetAlias string, clnt Client, isDir bool) (*shareAccount, *probe.Error) {
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return nil, probe.NewError(APINotImplemented{
+			API:     "ShareDownload --revocable",
+			APIType: "filesystem",
+		})
+	}
+	bucket, object := s3Clnt.url2BucketAndObject()
+	if isDir {
+		if object != "" && !strings.HasSuffix(object, "/") {
+			object += "/"
+		}
+		object += "*"
+	}
+	return newShareAccount(ctx, targetAlias, bucket, object)
+}
+
+// newShareClient returns a client of the URL signing with the service
+// account, or with the credentials of the alias if account is nil.
+func newShareClient(targetAlias, urlStr string, account *shareAccount) (Client, *probe.Error) {
+	if account == nil {
+		return newClientFromAlias(targetAlias, urlStr)
+	}
+	_, _, hostCfg, err := expandAlias(targetAlias)
+	if err != nil {
+		return nil, err.Trace(targetAlias, urlStr)
+	}
+	if hostCfg == nil {
+		return nil, errInvalidAliasedURL(targetAlias).Trace(targetAlias, urlStr)
+	}
+	s3Config := NewS3Config(urlStr, hostCfg)
+	s3Config.AccessKey = account.accessKey
+	s3Config.SecretKey = account.secretKey
+	s3Config.SessionToken = ""
+	return S3New(s3Config)
+}
+
+// deleteShareAccount deletes the service account of revocable shares.
+func deleteShareAccount(ctx context.Context, targetAlias, accessKey string) *probe.Error {
+	client, err := newAdminClient(targetAlias)
+	if err != nil {
+		return err.Trace(targetAlias)
+	}
+	if e := client.DeleteServiceAccount(ctx, accessKey); e != nil {
+		return probe.NewError(e).Trace(targetAlias, accessKey)
+	}
+	return nil
+}
@@ -32,6 +32,15 @@ type shareEntryV1 struct {
 	Date        time.Time     `json:"date"`
 	Expiry      time.Duration `json:"expiry"`
 	ContentType string        `json:"contentType,omitempty"` // Only used by upload cmd.
+
+	// Alias and service account of revocable downloads.
+	Alias     string `json:"alias,omitempty"`
+	AccessKey string `json:"accessKey,omitempty"`
+}
+
+// isExpired returns true if the shared URL is no longer valid.
+func (e shareEntryV1) isExpired() bool {
+	return e.Expiry-time.Since(e.Date) <= 0
 }
 
 // JSON file to persist previously shared uploads.
@@ -66,6 +75,21 @@ func (s *shareDBV1) Set(objectURL string, shareURL string, expiry time.Duration,
 	}
 }
 
+// SetRevocable sets download info for each share signed by the service
+// account of a revocable share.
+func (s *shareDBV1) SetRevocable(objectURL string, shareURL string, expiry time.Duration, alias, accessKey string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.Shares[shareURL] = shareEntryV1{
+		URL:       objectURL,
+		Date:      UTCNow(),
+		Expiry:    expiry,
+		Alias:     alias,
+		AccessKey: accessKey,
+	}
+}
+
 // Delete upload info if it exists.
 func (s *shareDBV1) Delete(objectURL string) {
 	s.mutex.Lock()
@@ -74,10 +98,11 @@ func (s *shareDBV1) Delete(objectURL string) {
 	delete(s.Shares, objectURL)
 }
 
-// Delete all expired uploads.
+// Delete all expired uploads. Revocable downloads are kept until
+// revoked, their service accounts outlive the URLs.
 func (s *shareDBV1) deleteAllExpired() {
 	for shareURL, share := range s.Shares {
-		if (share.Expiry - time.Since(share.Date)) <= 0 {
+		if share.isExpired() && share.AccessKey == "" {
 			// Expired entry. Safe to drop.
 			delete(s.Shares, shareURL)
 		}
@@ -0,0 +1,67 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestShareDBKeepsExpiredRevocable(t *testing.T) {
+	dir, e := ioutil.TempDir("", "share-db-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "downloads.json")
+
+	shareDB := newShareDBV1()
+	shareDB.Set("http://localhost:9000/bucket/a", "share-a", time.Hour, "")
+	shareDB.Set("http://localhost:9000/bucket/b", "share-b", time.Second, "")
+	shareDB.SetRevocable("http://localhost:9000/bucket/c", "share-c", time.Second, "myminio", "AK")
+
+	// Expire b and c.
+	for _, shareURL := range []string{"share-b", "share-c"} {
+		share := shareDB.Shares[shareURL]
+		share.Date = share.Date.Add(-time.Minute)
+		shareDB.Shares[shareURL] = share
+	}
+	if err := shareDB.Save(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newShareDBV1()
+	if err := loaded.Load(filename); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.Shares["share-a"]; !ok {
+		t.Error("share-a: unexpired share dropped")
+	}
+	if _, ok := loaded.Shares["share-b"]; ok {
+		t.Error("share-b: expired share kept")
+	}
+	share, ok := loaded.Shares["share-c"]
+	if !ok {
+		t.Fatal("share-c: expired revocable share dropped before being revoked")
+	}
+	if !share.isExpired() || share.Alias != "myminio" || share.AccessKey != "AK" {
+		t.Errorf("share-c: unexpected entry %+v", share)
+	}
+}
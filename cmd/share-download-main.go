@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"context"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -36,6 +37,24 @@ var (
 			Usage: "share a particular object version",
 		},
 		shareFlagExpire,
+		cli.StringFlag{
+			Name:  "manifest",
+			Usage: "write all the share URLs to a single file instead of printing them",
+		},
+		cli.StringFlag{
+			Name:  "manifest-format",
+			Usage: "format of the --manifest file, 'csv', 'json' or an 'html' index page (default: from its extension)",
+		},
+		cli.StringFlag{
+			Name:  "files-from",
+			Usage: "share the objects whose keys, relative to TARGET, are read one per line from a file, '-' for STDIN",
+		},
+		shareFlagQR,
+		shareFlagQRPNG,
+		cli.BoolFlag{
+			Name:  "revocable",
+			Usage: "sign with a new service account only allowed to download TARGET, revoked by 'mc share revoke', still expiring within 7 days",
+		},
 	}
 )
 
@@ -56,6 +75,9 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+Share URLs are presigned, they expire after --expire and 7 days at most,
+also with --revocable which only allows to revoke them before.
+
 EXAMPLES:
   1. Share this object with 7 days default expiry.
      {{.Prompt}} {{.HelpName}} s3/backup/2006-Mar-1/backup.tar.gz
@@ -68,6 +90,21 @@ EXAMPLES:
 
   4. Share all objects under this bucket and all its folders and sub-folders with 5 days expiry.
      {{.Prompt}} {{.HelpName}} --recursive --expire=120h s3/backup/
+
+  5. Share a dataset as an HTML index page linking to all its objects.
+     {{.Prompt}} {{.HelpName}} --recursive --manifest index.html s3/datasets/imagenet/
+
+  6. Share the objects listed in a file, and write their keys and URLs to a CSV file.
+     {{.Prompt}} {{.HelpName}} --files-from keys.txt --manifest urls.csv s3/datasets/imagenet/
+
+  7. Share this object with 1 hour expiry, and show its URL as a QR code to scan with a phone.
+     {{.Prompt}} {{.HelpName}} --expire=1h --qr s3/backup/2006-Mar-1/report.pdf
+
+  8. Share this object, and save the QR code of its URL as a PNG image.
+     {{.Prompt}} {{.HelpName}} --qr-png report.png s3/backup/2006-Mar-1/report.pdf
+
+  9. Share all objects under this folder with URLs which can be revoked before they expire.
+     {{.Prompt}} {{.HelpName}} --recursive --revocable s3/backup/2006-Mar-1/
 `,
 }
 
@@ -75,7 +112,7 @@ EXAMPLES:
 func checkShareDownloadSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	args := cliCtx.Args()
 	if !args.Present() {
-		cli.ShowCommandHelpAndExit(cliCtx, "download", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(cliCtx, "download", globalInvalidArgsExitStatus) // last argument is exit code.
 	}
 
 	// Parse expiry.
@@ -92,7 +129,9 @@ func checkShareDownloadSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 		fatalIf(errDummy().Trace(expiry.String()), "Expiry cannot be lesser than 1 second.")
 	}
 	if expiry.Seconds() > 604800 {
-		fatalIf(errDummy().Trace(expiry.String()), "Expiry cannot be larger than 7 days.")
+		// The servers reject presigned URLs valid longer than 7 days,
+		// whatever the credentials which sign them.
+		fatalIf(errDummy().Trace(expiry.String()), "Expiry cannot be larger than 7 days, also with --revocable.")
 	}
 
 	isRecursive := cliCtx.Bool("recursive")
@@ -102,19 +141,105 @@ func checkShareDownloadSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 		fatalIf(errDummy().Trace(), "--version-id cannot be specified with --recursive flag.")
 	}
 
+	if manifest := cliCtx.String("manifest"); manifest != "" {
+		if _, err := shareDownloadOutputFormat(manifest, cliCtx.String("manifest-format")); err != nil {
+			fatalIf(err, "Unable to write `%s`, expected a --manifest-format of csv, json or html.", manifest)
+		}
+	} else if cliCtx.String("manifest-format") != "" {
+		fatalIf(errInvalidArgument().Trace(), "--manifest-format requires --manifest.")
+	}
+
+	qr := parseShareQROptions(cliCtx)
+	if qr.terminal && cliCtx.String("manifest") != "" {
+		fatalIf(errInvalidArgument().Trace(), "--qr cannot be specified with --manifest.")
+	}
+	if qr.pngFile != "" && (isRecursive || cliCtx.String("files-from") != "" || cliCtx.String("manifest") != "") {
+		fatalIf(errInvalidArgument().Trace(args...), "--qr-png cannot be specified with --recursive, --files-from or --manifest.")
+	}
+
+	// The keys read from --files-from are checked when shared.
+	if cliCtx.String("files-from") != "" {
+		if len(args) != 1 {
+			fatalIf(errInvalidArgument().Trace(args...), "You need to specify exactly one TARGET with --files-from.")
+		}
+		if isRecursive || versionID != "" || cliCtx.Bool("revocable") {
+			fatalIf(errInvalidArgument().Trace(args...), "--files-from cannot be specified with --recursive, --version-id or --revocable.")
+		}
+		return
+	}
+
 	// Validate if object exists only if the `--recursive` flag was NOT specified
 	if !isRecursive {
 		for _, url := range cliCtx.Args() {
-			_, _, err := url2Stat(ctx, url, "", false, encKeyDB, time.Time{})
+			_, content, err := url2Stat(ctx, url, "", false, encKeyDB, time.Time{})
 			if err != nil {
 				fatalIf(err.Trace(url), "Unable to stat `"+url+"`.")
 			}
+			if qr.pngFile != "" && content.Type.IsDir() {
+				fatalIf(errInvalidArgument().Trace(url), "--qr-png requires TARGET to be an object, not a folder.")
+			}
 		}
 	}
 }
 
+// shareDownloadOptions holds the options of share download common to
+// all the objects shared.
+type shareDownloadOptions struct {
+	expiry  time.Duration
+	shareDB *shareDBV1
+	output  *shareDownloadOutput
+	qr      shareQROptions
+
+	// Service account signing the URLs of --revocable shares.
+	revocable bool
+	account   *shareAccount
+}
+
+// shareDownloadObject generates the share URL of an object, the key
+// of the object, relative to the TARGET shared, is used by --manifest.
+func shareDownloadObject(ctx context.Context, targetAlias, objectURL, versionID, key string, opts shareDownloadOptions) *probe.Error {
+	clnt, err := newShareClient(targetAlias, objectURL, opts.account)
+	if err != nil {
+		return err.Trace(objectURL)
+	}
+
+	// Generate share URL.
+	shareURL, err := clnt.ShareDownload(ctx, versionID, opts.expiry)
+	if err != nil {
+		// add objectURL and expiry as part of the trace arguments.
+		return err.Trace(objectURL, "expiry="+opts.expiry.String())
+	}
+
+	// Make new entries to shareDB.
+	contentType := "" // Not useful for download shares.
+	var accessKey string
+	if opts.account != nil {
+		accessKey = opts.account.accessKey
+		opts.shareDB.SetRevocable(objectURL, shareURL, opts.expiry, targetAlias, accessKey)
+	} else {
+		opts.shareDB.Set(objectURL, shareURL, opts.expiry, contentType)
+	}
+	if opts.output != nil {
+		opts.output.add(key, shareURL)
+		return nil
+	}
+	qrCode, err := renderShareQR(shareURL, opts.qr)
+	if err != nil {
+		return err.Trace(objectURL)
+	}
+	printMsg(shareMesssage{
+		ObjectURL:   objectURL,
+		ShareURL:    shareURL,
+		TimeLeft:    opts.expiry,
+		ContentType: contentType,
+		AccessKey:   accessKey,
+		qrCode:      qrCode,
+	})
+	return nil
+}
+
 // doShareURL share files from target.
-func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecursive bool, expiry time.Duration) *probe.Error {
+func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecursive bool, opts shareDownloadOptions) *probe.Error {
 	targetAlias, targetURLFull, _, err := expandAlias(targetURL)
 	if err != nil {
 		return err.Trace(targetURL)
@@ -124,14 +249,6 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 		return err.Trace(targetURL)
 	}
 
-	// Load previously saved upload-shares. Add new entries and write it back.
-	shareDB := newShareDBV1()
-	shareDownloadsFile := getShareDownloadsFile()
-	err = shareDB.Load(shareDownloadsFile)
-	if err != nil {
-		return err.Trace(shareDownloadsFile)
-	}
-
 	// Channel which will receive objects whose URLs need to be shared
 	objectsCh := make(chan *ClientContent)
 
@@ -140,7 +257,20 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 		return err.Trace(clnt.GetURL().String())
 	}
 
+	// All the URLs shared from TARGET are signed by the same service
+	// account, restricted to TARGET.
+	if opts.revocable {
+		opts.account, err = newShareTargetAccount(ctx, targetAlias, clnt, content.Type.IsDir())
+		if err != nil {
+			return err.Trace(targetURL)
+		}
+	}
+
+	// Object keys are relative to the folder shared, or to the folder
+	// of the object shared.
+	prefix := targetURLFull
 	if !content.Type.IsDir() {
+		prefix = prefix[:strings.LastIndex(prefix, string(clnt.GetURL().Separator))+1]
 		go func() {
 			defer close(objectsCh)
 			objectsCh <- content
@@ -149,6 +279,7 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 		if !strings.HasSuffix(targetURLFull, string(clnt.GetURL().Separator)) {
 			targetURLFull = targetURLFull + string(clnt.GetURL().Separator)
 		}
+		prefix = targetURLFull
 		clnt, err = newClientFromAlias(targetAlias, targetURLFull)
 		if err != nil {
 			return err.Trace(targetURLFull)
@@ -172,32 +303,40 @@ func doShareDownloadURL(ctx context.Context, targetURL, versionID string, isRecu
 			continue
 		}
 		objectURL := content.URL.String()
-		objectVersionID := content.VersionID
-		newClnt, err := newClientFromAlias(targetAlias, objectURL)
+		key := strings.TrimPrefix(content.URL.Path, newClientURL(prefix).Path)
+		err = shareDownloadObject(ctx, targetAlias, objectURL, content.VersionID, filepath.ToSlash(key), opts)
 		if err != nil {
-			return err.Trace(objectURL)
+			return err
 		}
+	}
+	return nil
+}
 
-		// Generate share URL.
-		shareURL, err := newClnt.ShareDownload(ctx, objectVersionID, expiry)
+// doShareDownloadFilesFrom shares the objects whose keys, relative to
+// targetURL, are read one per line from filesFrom, or from the standard
+// input when filesFrom is "-". Keys which cannot be shared are reported
+// and skipped, false is then returned.
+func doShareDownloadFilesFrom(ctx context.Context, targetURL, filesFrom string, opts shareDownloadOptions, encKeyDB map[string][]prefixSSEPair) bool {
+	keys, err := readCopyKeys(filesFrom)
+	fatalIf(err, "Unable to read the list of keys `%s`.", filesFrom)
+
+	targetAlias, _, _ := mustExpandAlias(targetURL)
+	sharedAll := true
+	for _, key := range keys {
+		url := urlJoinPath(targetURL, key)
+		_, content, err := url2Stat(ctx, url, "", false, encKeyDB, time.Time{})
+		if err == nil && content.Type.IsDir() {
+			err = errInvalidArgument().Trace(url)
+		}
+		if err == nil {
+			err = shareDownloadObject(ctx, targetAlias, content.URL.String(), "", key, opts)
+		}
 		if err != nil {
-			// add objectURL and expiry as part of the trace arguments.
-			return err.Trace(objectURL, "expiry="+expiry.String())
+			errorIf(err.Trace(url), "Unable to share `%s`.", key)
+			sharedAll = false
 		}
-
-		// Make new entries to shareDB.
-		contentType := "" // Not useful for download shares.
-		shareDB.Set(objectURL, shareURL, expiry, contentType)
-		printMsg(shareMesssage{
-			ObjectURL:   objectURL,
-			ShareURL:    shareURL,
-			TimeLeft:    expiry,
-			ContentType: contentType,
-		})
 	}
-
-	// Save downloads and return.
-	return shareDB.Save(shareDownloadsFile)
+	return sharedAll
 }
 
 // main for share download.
@@ -221,6 +360,7 @@ func mainShareDownload(cliCtx *cli.Context) error {
 	// Set command flags from context.
 	isRecursive := cliCtx.Bool("recursive")
 	versionID := cliCtx.String("version-id")
+	qr := parseShareQROptions(cliCtx)
 	expiry := shareDefaultExpiry
 	if cliCtx.String("expire") != "" {
 		var e error
@@ -228,16 +368,53 @@ func mainShareDownload(cliCtx *cli.Context) error {
 		fatalIf(probe.NewError(e), "Unable to parse expire=`"+cliCtx.String("expire")+"`.")
 	}
 
-	for _, targetURL := range cliCtx.Args() {
-		err := doShareDownloadURL(ctx, targetURL, versionID, isRecursive, expiry)
-		if err != nil {
-			switch err.ToGoError().(type) {
-			case APINotImplemented:
-				fatalIf(err.Trace(), "Unable to share a non S3 url `"+targetURL+"`.")
-			default:
-				fatalIf(err.Trace(targetURL), "Unable to share target `"+targetURL+"`.")
+	// Load previously saved download-shares. Add new entries and write it back.
+	shareDB := newShareDBV1()
+	shareDownloadsFile := getShareDownloadsFile()
+	err = shareDB.Load(shareDownloadsFile)
+	fatalIf(err.Trace(shareDownloadsFile), "Unable to load the shared URLs.")
+
+	opts := shareDownloadOptions{
+		expiry:    expiry,
+		shareDB:   shareDB,
+		qr:        qr,
+		revocable: cliCtx.Bool("revocable"),
+	}
+	outputFile := cliCtx.String("manifest")
+	if outputFile != "" {
+		format, _ := shareDownloadOutputFormat(outputFile, cliCtx.String("manifest-format"))
+		opts.output = newShareDownloadOutput(format, strings.Join(cliCtx.Args(), ", "), expiry)
+	}
+
+	var cErr error
+	if filesFrom := cliCtx.String("files-from"); filesFrom != "" {
+		if !doShareDownloadFilesFrom(ctx, cliCtx.Args().Get(0), filesFrom, opts, encKeyDB) {
+			cErr = exitStatus(globalErrorExitStatus)
+		}
+	} else {
+		for _, targetURL := range cliCtx.Args() {
+			err := doShareDownloadURL(ctx, targetURL, versionID, isRecursive, opts)
+			if err != nil {
+				switch err.ToGoError().(type) {
+				case APINotImplemented:
+					fatalIf(err.Trace(), "Unable to share a non S3 url `"+targetURL+"`.")
+				default:
+					fatalIf(err.Trace(targetURL), "Unable to share target `"+targetURL+"`.")
+				}
 			}
 		}
 	}
-	return nil
+
+	// Save downloads.
+	fatalIf(shareDB.Save(shareDownloadsFile).Trace(shareDownloadsFile), "Unable to save the shared URLs.")
+
+	if opts.output != nil {
+		fatalIf(opts.output.save(outputFile), "Unable to write the share URLs to `%s`.", outputFile)
+		printMsg(shareOutputMessage{
+			File:     outputFile,
+			Objects:  len(opts.output.urls),
+			TimeLeft: expiry,
+		})
+	}
+	return cErr
 }
@@ -0,0 +1,180 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+// Formats of the file written by share download --manifest.
+var shareDownloadOutputFormats = []string{"csv", "json", "html"}
+
+// shareDownloadOutputFormat returns the format given, or else guessed
+// from the extension of the file.
+func shareDownloadOutputFormat(filename, format string) (string, *probe.Error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+		if format == "htm" {
+			format = "html"
+		}
+	}
+	for _, f := range shareDownloadOutputFormats {
+		if format == f {
+			return format, nil
+		}
+	}
+	return "", errInvalidArgument().Trace(filename, format)
+}
+
+// shareDownloadOutput collects the URLs shared by share download
+// --manifest, to write them to a single file.
+type shareDownloadOutput struct {
+	format string
+	title  string
+	expiry time.Duration
+	// Share URLs by object key, relative to the TARGET shared.
+	urls map[string]string
+}
+
+func newShareDownloadOutput(format, title string, expiry time.Duration) *shareDownloadOutput {
+	return &shareDownloadOutput{
+		format: format,
+		title:  title,
+		expiry: expiry,
+		urls:   make(map[string]string),
+	}
+}
+
+// add adds the share URL of an object.
+func (o *shareDownloadOutput) add(key, shareURL string) {
+	o.urls[key] = shareURL
+}
+
+// keys returns the object keys sorted.
+func (o *shareDownloadOutput) keys() []string {
+	keys := make([]string, 0, len(o.urls))
+	for key := range o.urls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var shareDownloadIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>These links expire on {{.Expires}}.</p>
+<ul>
+{{range .Objects}}<li><a href="{{.URL}}">{{.Key}}</a></li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+// write writes the share URLs as a CSV file of keys and URLs, a JSON
+// object mapping keys to URLs, or an HTML index page.
+func (o *shareDownloadOutput) write(w io.Writer) error {
+	switch o.format {
+	case "csv":
+		csvWriter := csv.NewWriter(w)
+		if e := csvWriter.Write([]string{"key", "url"}); e != nil {
+			return e
+		}
+		for _, key := range o.keys() {
+			if e := csvWriter.Write([]string{key, o.urls[key]}); e != nil {
+				return e
+			}
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	case "json":
+		encoder := json.NewEncoder(w)
+		// Keep the ampersands of the URLs usable.
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", " ")
+		return encoder.Encode(o.urls)
+	}
+
+	type object struct {
+		Key string
+		URL template.URL
+	}
+	index := struct {
+		Title   string
+		Expires string
+		Objects []object
+	}{
+		Title:   o.title,
+		Expires: time.Now().Add(o.expiry).UTC().Format(printDate),
+	}
+	for _, key := range o.keys() {
+		index.Objects = append(index.Objects, object{Key: key, URL: template.URL(o.urls[key])})
+	}
+	return shareDownloadIndexTemplate.Execute(w, index)
+}
+
+// save writes the share URLs to a file.
+func (o *shareDownloadOutput) save(filename string) *probe.Error {
+	var buf bytes.Buffer
+	if e := o.write(&buf); e != nil {
+		return probe.NewError(e)
+	}
+	if e := ioutil.WriteFile(filename, buf.Bytes(), 0600); e != nil {
+		return probe.NewError(e).Trace(filename)
+	}
+	return nil
+}
+
+// shareOutputMessage is printed once the share URLs are written to a file.
+type shareOutputMessage struct {
+	Status   string        `json:"status"`
+	File     string        `json:"file"`
+	Objects  int           `json:"objects"`
+	TimeLeft time.Duration `json:"timeLeft"`
+}
+
+// String - Themefied string message for console printing.
+func (s shareOutputMessage) String() string {
+	return console.Colorize("Share", fmt.Sprintf("Wrote %d share URLs expiring in %s to `%s`.",
+		s.Objects, timeDurationToHumanizedDuration(s.TimeLeft).StringShort(), s.File))
+}
+
+// JSON - JSONified message for scripting.
+func (s shareOutputMessage) JSON() string {
+	s.Status = "success"
+	shareMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(shareMessageBytes)
+}
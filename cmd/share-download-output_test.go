@@ -0,0 +1,75 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShareDownloadOutputFormat(t *testing.T) {
+	testCases := []struct {
+		filename, format, expected string
+		ok                         bool
+	}{
+		{"urls.csv", "", "csv", true},
+		{"urls.JSON", "", "json", true},
+		{"index.htm", "", "html", true},
+		{"urls.txt", "csv", "csv", true},
+		{"urls.txt", "", "", false},
+		{"urls.csv", "xml", "", false},
+	}
+	for i, testCase := range testCases {
+		format, err := shareDownloadOutputFormat(testCase.filename, testCase.format)
+		if format != testCase.expected || (err == nil) != testCase.ok {
+			t.Errorf("Test %d: expected %q, got %q (%v)", i+1, testCase.expected, format, err)
+		}
+	}
+}
+
+func TestShareDownloadOutputWrite(t *testing.T) {
+	newOutput := func(format string) *shareDownloadOutput {
+		output := newShareDownloadOutput(format, "play/datasets/", time.Hour)
+		output.add("b/2.jpg", "https://play.min.io/datasets/b/2.jpg?X-Amz-Expires=3600&X-Amz-Signature=2")
+		output.add("a <1>.jpg", "https://play.min.io/datasets/a%20%3C1%3E.jpg?X-Amz-Expires=3600&X-Amz-Signature=1")
+		return output
+	}
+	testCases := []struct {
+		format   string
+		contains []string
+	}{
+		{"csv", []string{"key,url\na <1>.jpg,https://play.min.io/datasets/a%20%3C1%3E.jpg?X-Amz-Expires=3600&X-Amz-Signature=1\nb/2.jpg,"}},
+		{"json", []string{`"b/2.jpg": "https://play.min.io/datasets/b/2.jpg?X-Amz-Expires=3600&X-Amz-Signature=2"`}},
+		{"html", []string{
+			`<title>play/datasets/</title>`,
+			`<li><a href="https://play.min.io/datasets/a%20%3C1%3E.jpg?X-Amz-Expires=3600&amp;X-Amz-Signature=1">a &lt;1&gt;.jpg</a></li>`,
+		}},
+	}
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		if e := newOutput(testCase.format).write(&buf); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		for _, s := range testCase.contains {
+			if !strings.Contains(buf.String(), s) {
+				t.Errorf("Test %d: expected %q in\n%s", i+1, s, buf.String())
+			}
+		}
+	}
+}
@@ -31,6 +31,7 @@ var (
 // Share documents via URL.
 var shareList = cli.Command{
 	Name:         "list",
+	Aliases:      []string{"ls"},
 	Usage:        "list previously shared objects",
 	Action:       mainShareList,
 	OnUsageError: onUsageError,
@@ -46,8 +47,11 @@ COMMAND:
   upload:   list previously shared access to uploads.
   download: list previously shared access to downloads.
 
+Revocable downloads are listed with the service account which signed
+them, until revoked by 'mc share revoke', even once expired.
+
 EXAMPLES:
-  1. List previously shared downloads, that haven't expired yet.
+  1. List previously shared downloads, that haven't expired yet or, if revocable, haven't been revoked yet.
       {{.Prompt}} {{.HelpName}} download
 
   2. List previously shared uploads, that haven't expired yet.
@@ -59,7 +63,7 @@ EXAMPLES:
 func checkShareListSyntax(ctx *cli.Context) {
 	args := ctx.Args()
 	if !args.Present() || (args.First() != "upload" && args.First() != "download") {
-		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(ctx, "list", globalInvalidArgsExitStatus) // last argument is exit code.
 	}
 }
 
@@ -97,6 +101,7 @@ func doShareList(cmd string) *probe.Error {
 			ShareURL:    shareURL,
 			TimeLeft:    share.Expiry - time.Since(share.Date),
 			ContentType: share.ContentType,
+			AccessKey:   share.AccessKey,
 		})
 	}
 	return nil
@@ -33,6 +33,7 @@ var shareSubcommands = []cli.Command{
 	shareDownload,
 	shareUpload,
 	shareList,
+	shareRevoke,
 }
 
 // Share documents via URL.
@@ -0,0 +1,209 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var (
+	shareRevokeFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "revoke the shares of all objects under TARGET",
+		},
+		cli.BoolFlag{
+			Name:  "expired",
+			Usage: "delete the service accounts of all the expired revocable shares",
+		},
+	}
+)
+
+// Revoke shared documents.
+var shareRevoke = cli.Command{
+	Name:         "revoke",
+	Usage:        "revoke URLs shared with 'share download --revocable'",
+	Action:       mainShareRevoke,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(shareRevokeFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [TARGET...]
+
+  All the URLs shared for one TARGET of 'share download --revocable' are signed by the
+  same service account, revoking any of them revokes them all.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Revoke the URL shared for this object.
+     {{.Prompt}} {{.HelpName}} s3/backup/2006-Mar-1/backup.tar.gz
+
+  2. Revoke the URLs shared for all objects under this folder.
+     {{.Prompt}} {{.HelpName}} --recursive s3/backup/2006-Mar-1/
+
+  3. Delete the service accounts left by revocable shares which have expired.
+     {{.Prompt}} {{.HelpName}} --expired
+`,
+}
+
+// shareRevokeMessage is printed for each service account deleted.
+type shareRevokeMessage struct {
+	Status    string `json:"status"`
+	AccessKey string `json:"accessKey"`
+	Shares    int    `json:"shares"`
+}
+
+// String - Themefied string message for console printing.
+func (s shareRevokeMessage) String() string {
+	return console.Colorize("Revoke", fmt.Sprintf("Revoked %d share URL(s) signed by service account `%s`.", s.Shares, s.AccessKey))
+}
+
+// JSON - JSONified message for scripting.
+func (s shareRevokeMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkShareRevokeSyntax - validate command-line args.
+func checkShareRevokeSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() && !ctx.Bool("expired") {
+		cli.ShowCommandHelpAndExit(ctx, "revoke", globalInvalidArgsExitStatus) // last argument is exit code.
+	}
+	if ctx.Bool("recursive") && !ctx.Args().Present() {
+		fatalIf(errInvalidArgument().Trace(), "--recursive requires a TARGET.")
+	}
+}
+
+// shareRevokeAccount identifies the service account of revocable shares.
+type shareRevokeAccount struct {
+	alias     string
+	accessKey string
+}
+
+// matchShareRevokeTarget returns the service accounts of the revocable
+// shares of TARGET, or of the objects under TARGET if recursive.
+func matchShareRevokeTarget(shareDB *shareDBV1, targetURL string, isRecursive bool) []shareRevokeAccount {
+	targetAlias, targetURLFull, _ := mustExpandAlias(targetURL)
+	var accounts []shareRevokeAccount
+	for _, share := range shareDB.Shares {
+		if share.AccessKey == "" || share.Alias != targetAlias {
+			continue
+		}
+		if share.URL == targetURLFull || isRecursive && strings.HasPrefix(share.URL, targetURLFull) {
+			accounts = append(accounts, shareRevokeAccount{share.Alias, share.AccessKey})
+		}
+	}
+	return accounts
+}
+
+// doShareRevoke deletes the service account and forgets all the URLs it
+// signed.
+func doShareRevoke(ctx context.Context, shareDB *shareDBV1, account shareRevokeAccount) *probe.Error {
+	if err := deleteShareAccount(ctx, account.alias, account.accessKey); err != nil {
+		return err
+	}
+	revoked := 0
+	for shareURL, share := range shareDB.Shares {
+		if share.Alias == account.alias && share.AccessKey == account.accessKey {
+			shareDB.Delete(shareURL)
+			revoked++
+		}
+	}
+	printMsg(shareRevokeMessage{
+		AccessKey: account.accessKey,
+		Shares:    revoked,
+	})
+	return nil
+}
+
+// main for share revoke.
+func mainShareRevoke(cliCtx *cli.Context) error {
+	ctx, cancelShareRevoke := context.WithCancel(globalContext)
+	defer cancelShareRevoke()
+
+	// check input arguments.
+	checkShareRevokeSyntax(cliCtx)
+
+	// Initialize share config folder.
+	initShareConfig()
+
+	// Additional command speific theme customization.
+	shareSetColor()
+
+	shareDB := newShareDBV1()
+	shareDownloadsFile := getShareDownloadsFile()
+	err := shareDB.Load(shareDownloadsFile)
+	fatalIf(err.Trace(shareDownloadsFile), "Unable to load the shared URLs.")
+
+	accounts := make(map[shareRevokeAccount]bool)
+	isRecursive := cliCtx.Bool("recursive")
+	for _, targetURL := range cliCtx.Args() {
+		matched := matchShareRevokeTarget(shareDB, targetURL, isRecursive)
+		if len(matched) == 0 {
+			fatalIf(errInvalidArgument().Trace(targetURL), "No revocable share found for `"+targetURL+"`.")
+		}
+		for _, account := range matched {
+			accounts[account] = true
+		}
+	}
+	if cliCtx.Bool("expired") {
+		for _, share := range shareDB.Shares {
+			if share.AccessKey != "" && share.isExpired() {
+				accounts[shareRevokeAccount{share.Alias, share.AccessKey}] = true
+			}
+		}
+	}
+
+	// Revoke in a stable order.
+	sorted := make([]shareRevokeAccount, 0, len(accounts))
+	for account := range accounts {
+		sorted = append(sorted, account)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].alias != sorted[j].alias {
+			return sorted[i].alias < sorted[j].alias
+		}
+		return sorted[i].accessKey < sorted[j].accessKey
+	})
+
+	var cErr error
+	for _, account := range sorted {
+		if err := doShareRevoke(ctx, shareDB, account); err != nil {
+			errorIf(err.Trace(account.alias, account.accessKey), "Unable to revoke the shares signed by service account `"+account.accessKey+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+		}
+	}
+
+	// Save downloads.
+	fatalIf(shareDB.Save(shareDownloadsFile).Trace(shareDownloadsFile), "Unable to save the shared URLs.")
+	return cErr
+}
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
 )
@@ -34,9 +35,34 @@ var (
 		},
 		shareFlagExpire,
 		shareFlagContentType,
+		cli.StringFlag{
+			Name:  "content-type-prefix",
+			Usage: "specify a content-type prefix to allow, e.g. 'image/'",
+		},
+		cli.StringFlag{
+			Name:  "min-size",
+			Usage: "allow only uploads of at least this size, e.g. '1KiB'",
+		},
+		cli.StringFlag{
+			Name:  "max-size",
+			Usage: "allow only uploads of at most this size, e.g. '10MiB'",
+		},
+		cli.StringFlag{
+			Name:  "key-prefix",
+			Usage: "allow any object name starting with this prefix, relative to TARGET",
+		},
+		cli.StringFlag{
+			Name:  "redirect",
+			Usage: "redirect the browser to this URL after a successful upload",
+		},
+		shareFlagQR,
+		shareFlagQRPNG,
 	}
 )
 
+// Largest size allowed with --min-size only, the maximum size of an object.
+const shareUploadMaxSize = 5 * humanize.TiByte
+
 // Share documents via URL.
 var shareUpload = cli.Command{
 	Name:         "upload",
@@ -66,6 +92,14 @@ EXAMPLES:
 
   4. Generate a curl command to allow upload access to any objects matching the key prefix 'backup/'. Command expires in 2 hours.
      {{.Prompt}} {{.HelpName}} --recursive --expire=2h s3/backup/2007-Mar-2/backup/
+
+  5. Generate a curl command to allow browsers to upload images of 10MiB at most under 'avatars/', and
+     to redirect them to a page of the website once uploaded.
+     {{.Prompt}} {{.HelpName}} --key-prefix avatars/ --content-type-prefix image/ --max-size 10MiB \
+           --redirect https://example.com/uploaded s3/website/
+
+  6. Generate a curl command to allow upload access for a single object, and show it as a QR code.
+     {{.Prompt}} {{.HelpName}} --expire=1h --qr s3/backup/2006-Mar-1/backup.tar.gz
 `,
 }
 
@@ -73,7 +107,7 @@ EXAMPLES:
 func checkShareUploadSyntax(ctx *cli.Context) {
 	args := ctx.Args()
 	if !args.Present() {
-		cli.ShowCommandHelpAndExit(ctx, "upload", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(ctx, "upload", globalInvalidArgsExitStatus) // last argument is exit code.
 	}
 
 	// Set command flags from context.
@@ -98,6 +132,17 @@ func checkShareUploadSyntax(ctx *cli.Context) {
 			"Expiry cannot be larger than 7 days.")
 	}
 
+	if ctx.String("content-type") != "" && ctx.String("content-type-prefix") != "" {
+		fatalIf(errInvalidArgument().Trace(), "--content-type cannot be specified with --content-type-prefix.")
+	}
+
+	// Any object name starting with the key prefix is allowed.
+	if ctx.String("key-prefix") != "" {
+		isRecursive = true
+	}
+
+	parseShareQROptions(ctx)
+
 	for _, targetURL := range ctx.Args() {
 		url := newClientURL(targetURL)
 		if strings.HasSuffix(targetURL, string(url.Separator)) && !isRecursive {
@@ -107,6 +152,34 @@ func checkShareUploadSyntax(ctx *cli.Context) {
 	}
 }
 
+// parseShareUploadOptions parses the conditions of the POST policy.
+func parseShareUploadOptions(ctx *cli.Context) ShareUploadOptions {
+	opts := ShareUploadOptions{
+		contentType:       ctx.String("content-type"),
+		contentTypePrefix: ctx.String("content-type-prefix"),
+		successRedirect:   ctx.String("redirect"),
+	}
+	if minSize := ctx.String("min-size"); minSize != "" {
+		size, e := humanize.ParseBytes(minSize)
+		fatalIf(probe.NewError(e).Trace(minSize), "Unable to parse --min-size argument.")
+		opts.minSize = int64(size)
+		opts.maxSize = shareUploadMaxSize
+	}
+	if maxSize := ctx.String("max-size"); maxSize != "" {
+		size, e := humanize.ParseBytes(maxSize)
+		fatalIf(probe.NewError(e).Trace(maxSize), "Unable to parse --max-size argument.")
+		if size == 0 {
+			fatalIf(errInvalidArgument().Trace(maxSize), "--max-size cannot be zero.")
+		}
+		opts.maxSize = int64(size)
+	}
+	if opts.minSize > opts.maxSize {
+		fatalIf(errInvalidArgument().Trace(ctx.String("min-size"), ctx.String("max-size")),
+			"--min-size cannot be larger than --max-size.")
+	}
+	return opts
+}
+
 // makeCurlCmd constructs curl command-line.
 func makeCurlCmd(key, postURL string, isRecursive bool, uploadInfo map[string]string) (string, *probe.Error) {
 	postURL += " "
@@ -144,16 +217,22 @@ func saveSharedURL(objectURL string, shareURL string, expiry time.Duration, cont
 }
 
 // doShareUploadURL uploads files to the target.
-func doShareUploadURL(ctx context.Context, objectURL string, isRecursive bool, expiry time.Duration, contentType string) *probe.Error {
+func doShareUploadURL(ctx context.Context, objectURL string, isRecursive bool, expiry time.Duration, opts ShareUploadOptions, qr shareQROptions) *probe.Error {
 	clnt, err := newClient(objectURL)
 	if err != nil {
 		return err.Trace(objectURL)
 	}
 
 	// Generate pre-signed access info.
-	shareURL, uploadInfo, err := clnt.ShareUpload(context.Background(), isRecursive, expiry, contentType)
+	shareURL, uploadInfo, err := clnt.ShareUpload(context.Background(), isRecursive, expiry, opts)
 	if err != nil {
-		return err.Trace(objectURL, "expiry="+expiry.String(), "contentType="+contentType)
+		return err.Trace(objectURL, "expiry="+expiry.String(), "contentType="+opts.contentType)
+	}
+
+	// Any content-type starting with the prefix is allowed.
+	contentType := opts.contentType
+	if opts.contentTypePrefix != "" {
+		contentType = opts.contentTypePrefix + "*"
 	}
 
 	// Get the new expanded url.
@@ -165,11 +244,17 @@ func doShareUploadURL(ctx context.Context, objectURL string, isRecursive bool, e
 		return err.Trace(objectURL)
 	}
 
+	qrCode, err := renderShareQR(curlCmd, qr)
+	if err != nil {
+		return err.Trace(objectURL)
+	}
+
 	printMsg(shareMesssage{
 		ObjectURL:   objectURL,
 		ShareURL:    curlCmd,
 		TimeLeft:    expiry,
 		ContentType: contentType,
+		qrCode:      qrCode,
 	})
 
 	// save shared URL to disk.
@@ -194,15 +279,24 @@ func mainShareUpload(cliCtx *cli.Context) error {
 	isRecursive := cliCtx.Bool("recursive")
 	expireArg := cliCtx.String("expire")
 	expiry := shareDefaultExpiry
-	contentType := cliCtx.String("content-type")
+	opts := parseShareUploadOptions(cliCtx)
+	qr := parseShareQROptions(cliCtx)
 	if expireArg != "" {
 		var e error
 		expiry, e = time.ParseDuration(expireArg)
 		fatalIf(probe.NewError(e), "Unable to parse expire=`"+expireArg+"`.")
 	}
 
+	keyPrefix := cliCtx.String("key-prefix")
+	if keyPrefix != "" {
+		isRecursive = true
+	}
+
 	for _, targetURL := range cliCtx.Args() {
-		err := doShareUploadURL(ctx, targetURL, isRecursive, expiry, contentType)
+		if keyPrefix != "" {
+			targetURL = urlJoinPath(targetURL, keyPrefix)
+		}
+		err := doShareUploadURL(ctx, targetURL, isRecursive, expiry, opts, qr)
 		if err != nil {
 			switch err.ToGoError().(type) {
 			case APINotImplemented:
@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
+	"rsc.io/qr"
 )
 
 const (
@@ -45,10 +47,21 @@ var (
 	shareFlagExpire = cli.StringFlag{
 		Name:  "expire, E",
 		Value: "168h",
-		Usage: "set expiry in NN[h|m|s]",
+		Usage: "set expiry in NN[h|m|s], 7 days at most",
+	}
+	shareFlagQR = cli.BoolFlag{
+		Name:  "qr",
+		Usage: "render the share URL as a QR code in the terminal",
+	}
+	shareFlagQRPNG = cli.StringFlag{
+		Name:  "qr-png",
+		Usage: "save the QR code of the share URL as a PNG image",
 	}
 )
 
+// Pixels per module of the PNG images written by --qr-png.
+const shareQRPNGScale = 8
+
 // Structured share command message.
 type shareMesssage struct {
 	Status      string        `json:"status"`
@@ -56,15 +69,26 @@ type shareMesssage struct {
 	ShareURL    string        `json:"share"`
 	TimeLeft    time.Duration `json:"timeLeft"`
 	ContentType string        `json:"contentType,omitempty"` // Only used by upload cmd.
+	AccessKey   string        `json:"accessKey,omitempty"`   // Only used by revocable downloads.
+	Revocable   bool          `json:"revocable"`
+
+	qrCode string // Rendered QR code of the share URL, printed by --qr.
 }
 
 // String - Themefied string message for console printing.
 func (s shareMesssage) String() string {
 	msg := console.Colorize("URL", fmt.Sprintf("URL: %s\n", s.ObjectURL))
-	msg += console.Colorize("Expire", fmt.Sprintf("Expire: %s\n", timeDurationToHumanizedDuration(s.TimeLeft)))
+	if s.TimeLeft > 0 {
+		msg += console.Colorize("Expire", fmt.Sprintf("Expire: %s\n", timeDurationToHumanizedDuration(s.TimeLeft)))
+	} else {
+		msg += console.Colorize("Expire", "Expire: expired\n")
+	}
 	if s.ContentType != "" {
 		msg += console.Colorize("Content-type", fmt.Sprintf("Content-Type: %s\n", s.ContentType))
 	}
+	if s.AccessKey != "" {
+		msg += console.Colorize("Revocable", fmt.Sprintf("Revocable: yes, signed by service account %s\n", s.AccessKey))
+	}
 
 	// Highlight <FILE> specifically. "share upload" sub-commands use this identifier.
 	shareURL := strings.Replace(s.ShareURL, "<FILE>", console.Colorize("File", "<FILE>"), 1)
@@ -72,6 +96,7 @@ func (s shareMesssage) String() string {
 	shareURL = strings.Replace(shareURL, "<NAME>", console.Colorize("File", "<NAME>"), 1)
 
 	msg += console.Colorize("Share", fmt.Sprintf("Share: %s\n", shareURL))
+	msg += s.qrCode
 
 	return msg
 }
@@ -79,6 +104,7 @@ func (s shareMesssage) String() string {
 // JSON - JSONified message for scripting.
 func (s shareMesssage) JSON() string {
 	s.Status = "success"
+	s.Revocable = s.AccessKey != ""
 	shareMessageBytes, e := json.MarshalIndent(s, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
@@ -92,6 +118,82 @@ func (s shareMesssage) JSON() string {
 	return string(shareMessageBytes)
 }
 
+// shareQROptions holds the --qr and --qr-png flags.
+type shareQROptions struct {
+	terminal bool
+	pngFile  string
+}
+
+// parseShareQROptions parses and validates the QR code flags.
+func parseShareQROptions(ctx *cli.Context) shareQROptions {
+	opts := shareQROptions{
+		terminal: ctx.Bool("qr"),
+		pngFile:  ctx.String("qr-png"),
+	}
+	if opts.terminal && globalJSON {
+		fatalIf(errInvalidArgument().Trace(), "--qr cannot be specified with --json.")
+	}
+	if opts.pngFile != "" && len(ctx.Args()) != 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "You need to specify exactly one TARGET with --qr-png.")
+	}
+	return opts
+}
+
+// renderShareQR encodes the share URL as a QR code. The PNG image is
+// saved if requested, and the code rendered for the terminal returned
+// if requested.
+func renderShareQR(shareURL string, opts shareQROptions) (string, *probe.Error) {
+	if !opts.terminal && opts.pngFile == "" {
+		return "", nil
+	}
+	code, e := qr.Encode(shareURL, qr.L)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	if opts.pngFile != "" {
+		code.Scale = shareQRPNGScale
+		if e = ioutil.WriteFile(opts.pngFile, code.PNG(), 0600); e != nil {
+			return "", probe.NewError(e).Trace(opts.pngFile)
+		}
+	}
+	if !opts.terminal {
+		return "", nil
+	}
+	return renderQRTerminal(code), nil
+}
+
+// renderQRTerminal renders a QR code with half block characters, two
+// rows of modules per line of text, and a quiet zone of two modules
+// around it. Colors are forced with ANSI escape sequences, dark on
+// white, so that the code scans on both dark and light terminals.
+func renderQRTerminal(code *qr.Code) string {
+	const (
+		quiet = 2
+		start = "\x1b[30;107m"
+		reset = "\x1b[0m"
+	)
+	var s strings.Builder
+	for y := -quiet; y < code.Size+quiet; y += 2 {
+		s.WriteString(start)
+		for x := -quiet; x < code.Size+quiet; x++ {
+			top, bottom := code.Black(x, y), code.Black(x, y+1)
+			switch {
+			case top && bottom:
+				s.WriteString("█")
+			case top:
+				s.WriteString("▀")
+			case bottom:
+				s.WriteString("▄")
+			default:
+				s.WriteString(" ")
+			}
+		}
+		s.WriteString(reset)
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
 // shareSetColor sets colors share sub-commands.
 func shareSetColor() {
 	// Additional command speific theme customization.
@@ -100,6 +202,8 @@ func shareSetColor() {
 	console.SetColor("Content-type", color.New(color.FgBlue))
 	console.SetColor("Share", color.New(color.FgGreen))
 	console.SetColor("File", color.New(color.FgRed, color.Bold))
+	console.SetColor("Revocable", color.New(color.FgYellow))
+	console.SetColor("Revoke", color.New(color.FgGreen, color.Bold))
 }
 
 // Get share dir name.
@@ -0,0 +1,103 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"rsc.io/qr"
+)
+
+func TestRenderShareQR(t *testing.T) {
+	dir, e := ioutil.TempDir("", "share-qr")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	pngFile := filepath.Join(dir, "share.png")
+
+	const shareURL = "https://play.min.io/bucket/object?X-Amz-Signature=abcdef"
+	terminal, err := renderShareQR(shareURL, shareQROptions{terminal: true, pngFile: pngFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, e := qr.Encode(shareURL, qr.L)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	// Two rows of modules per line, with a quiet zone of two modules.
+	lines := strings.Split(strings.TrimSuffix(terminal, "\n"), "\n")
+	if len(lines) != (code.Size+4+1)/2 {
+		t.Errorf("expected %d lines, got %d", (code.Size+4+1)/2, len(lines))
+	}
+
+	f, e := os.Open(pngFile)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer f.Close()
+	img, e := png.Decode(f)
+	if e != nil {
+		t.Fatal(e)
+	}
+	// A quiet zone of four modules on each side.
+	if width := (code.Size + 8) * shareQRPNGScale; img.Bounds().Dx() != width {
+		t.Errorf("expected a width of %d pixels, got %d", width, img.Bounds().Dx())
+	}
+	// Top left corner of the finder pattern, after the quiet zone.
+	if r, _, _, _ := img.At(4*shareQRPNGScale, 4*shareQRPNGScale).RGBA(); r != 0 {
+		t.Error("expected the finder pattern to be dark")
+	}
+	if r, _, _, _ := img.At(4*shareQRPNGScale-1, 4*shareQRPNGScale-1).RGBA(); r != 0xffff {
+		t.Error("expected the quiet zone to be light")
+	}
+}
+
+func TestShareMessageRevocable(t *testing.T) {
+	testCases := []struct {
+		accessKey string
+		revocable bool
+	}{
+		{"", false},
+		{"J123C4ZXEQN8RK6ND35I", true},
+	}
+	for i, testCase := range testCases {
+		msg := shareMesssage{
+			ObjectURL: "https://play.min.io/mybucket/myobject.txt",
+			ShareURL:  "https://play.min.io/mybucket/myobject.txt?X-Amz-Expires=3600",
+			TimeLeft:  time.Hour,
+			AccessKey: testCase.accessKey,
+		}
+		if revocable := strings.Contains(msg.String(), "Revocable: yes"); revocable != testCase.revocable {
+			t.Errorf("Test %d: expected revocable %v in %q", i+1, testCase.revocable, msg.String())
+		}
+		expected := `"revocable":false`
+		if testCase.revocable {
+			expected = `"revocable":true`
+		}
+		if !strings.Contains(msg.JSON(), expected) {
+			t.Errorf("Test %d: expected %s in %s", i+1, expected, msg.JSON())
+		}
+	}
+}
@@ -0,0 +1,548 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var shellCmd = cli.Command{
+	Name:         "shell",
+	Usage:        "start an interactive shell to explore buckets and objects",
+	Action:       mainShell,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [TARGET]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+SHELL COMMANDS:
+  cd [PATH]          change the current alias, bucket or prefix, '..' goes one level up
+  pwd                print the current path
+  ls [PATH]          list buckets and objects
+  get PATH [LOCAL]   download an object to a local file or directory
+  put LOCAL [PATH]   upload a local file to the current, or given, path
+  history            print the commands run in this session
+  help               print this help
+  exit               leave the shell, same as Ctrl-D
+
+  Any other mc command can be run with its arguments relative to the current path,
+  e.g. 'stat object' or 'rm --recursive --force prefix/'. Local files are named
+  './file', '../file', '~/file' or with an absolute path, e.g. 'cp ./notes.txt .'.
+  Arguments are quoted like in a shell. Press Tab to complete aliases, buckets
+  and prefixes.
+
+EXAMPLES:
+  1. Start a shell in the 'mybucket' bucket of 'play'.
+     {{.Prompt}} {{.HelpName}} play/mybucket
+`,
+}
+
+// Commands handled by the shell itself.
+var shellBuiltins = []string{"cd", "exit", "get", "help", "history", "ls", "put", "pwd", "quit"}
+
+// Commands whose arguments do not refer to remote paths.
+var shellLocalArgCmds = map[string]bool{
+	"alias":  true,
+	"admin":  true,
+	"config": true,
+	"update": true,
+}
+
+// mcShell holds the state of an interactive shell session.
+type mcShell struct {
+	term    *terminal.Terminal
+	fd      int
+	state   *terminal.State
+	cwd     string // current path, empty for the list of aliases
+	history []string
+	cmds    []cli.Command // top level commands, for completion
+	status  int           // exit status of the last command
+}
+
+// resolve returns the remote path of an argument relative to the
+// current path, arguments starting with '/' are relative to the root.
+func (sh *mcShell) resolve(arg string) string {
+	p := arg
+	if !strings.HasPrefix(arg, "/") {
+		p = sh.cwd + "/" + arg
+	}
+	isDir := strings.HasSuffix(arg, "/") || arg == "." || arg == ".." || strings.HasSuffix(arg, "/..") || arg == ""
+	p = strings.TrimPrefix(path.Clean("/"+p), "/")
+	if isDir && p != "" {
+		p += "/"
+	}
+	return p
+}
+
+// prompt returns the shell prompt for the current path.
+func (sh *mcShell) prompt() string {
+	return console.Colorize("ShellPath", "/"+sh.cwd) + "> "
+}
+
+// shellAliases returns the sorted names of all configured aliases.
+func shellAliases() []string {
+	conf, err := loadMcConfig()
+	if err != nil || conf == nil {
+		return nil
+	}
+	var aliases []string
+	for alias := range conf.Aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// complete returns the candidates completing a remote path.
+func (sh *mcShell) complete(arg string) []string {
+	full := sh.resolve(arg)
+	if strings.HasSuffix(arg, "/") || arg == "" {
+		if !strings.HasSuffix(full, "/") && full != "" {
+			full += "/"
+		}
+	} else {
+		full = strings.TrimSuffix(full, "/")
+	}
+	// Remote paths are completed against the full path, candidates
+	// are then rewritten to be relative to what the user typed.
+	var candidates []string
+	if !strings.Contains(full, "/") {
+		for _, alias := range shellAliases() {
+			if strings.HasPrefix(alias, full) {
+				candidates = append(candidates, alias+"/")
+			}
+		}
+	} else {
+		candidates = completeS3Path(full)
+	}
+
+	base := full[:strings.LastIndex(full, "/")+1]
+	typedBase := arg[:strings.LastIndex(arg, "/")+1]
+	var results []string
+	for _, c := range candidates {
+		if !strings.HasPrefix(c, base) {
+			continue
+		}
+		rest := strings.TrimPrefix(c, base)
+		// Only complete one level at a time.
+		if i := strings.Index(rest, "/"); i >= 0 && i < len(rest)-1 {
+			continue
+		}
+		results = append(results, typedBase+rest)
+	}
+	return results
+}
+
+// autoComplete implements the tab completion of the terminal.
+func (sh *mcShell) autoComplete(line string, pos int, key rune) (string, int, bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+	head := line[:pos]
+	fields := strings.Fields(head)
+	typed := ""
+	if len(fields) > 0 && !strings.HasSuffix(head, " ") {
+		typed = fields[len(fields)-1]
+		fields = fields[:len(fields)-1]
+	}
+
+	var candidates []string
+	if len(fields) == 0 {
+		// Complete the command names.
+		names := append([]string{}, shellBuiltins...)
+		for _, cmd := range sh.cmds {
+			if !cmd.Hidden {
+				names = append(names, cmd.Name)
+			}
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, typed) {
+				candidates = append(candidates, name+" ")
+			}
+		}
+	} else if !shellLocalArgCmds[fields[0]] && !strings.HasPrefix(typed, "-") {
+		candidates = sh.complete(typed)
+	}
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+
+	completion := longestCommonPrefix(candidates)
+	if len(candidates) > 1 {
+		sort.Strings(candidates)
+		fmt.Fprintln(sh.term, strings.Join(candidates, "  "))
+	}
+	if len(completion) <= len(typed) {
+		return line, pos, true
+	}
+	newHead := head[:len(head)-len(typed)] + completion
+	return newHead + line[pos:], len(newHead), true
+}
+
+// longestCommonPrefix returns the longest prefix shared by all strings.
+func longestCommonPrefix(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	prefix := strs[0]
+	for _, s := range strs[1:] {
+		for !strings.HasPrefix(s, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// run executes mc with the given arguments in cooked terminal mode
+// and records its exit status.
+func (sh *mcShell) run(args ...string) {
+	executable, e := os.Executable()
+	if e != nil {
+		errorIf(probe.NewError(e), "Unable to find the mc executable.")
+		sh.status = globalErrorExitStatus
+		return
+	}
+	globalArgs := []string{"--config-dir", mustGetMcConfigDir(), "--profile", getMcProfile()}
+	if globalInsecure {
+		globalArgs = append(globalArgs, "--insecure")
+	}
+	if globalNoColor {
+		globalArgs = append(globalArgs, "--no-color")
+	}
+
+	terminal.Restore(sh.fd, sh.state)
+	defer func() {
+		if state, e := terminal.MakeRaw(sh.fd); e == nil {
+			sh.state = state
+		}
+	}()
+
+	cmd := exec.Command(executable, append(globalArgs, args...)...)
+	cmd.Env = childEnv()
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	e = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case e == nil:
+		sh.status = 0
+	case errors.As(e, &exitErr):
+		// The command reported its own errors.
+		sh.status = exitErr.ExitCode()
+		if sh.status < 0 {
+			sh.status = signalExitStatus(exitErr.ProcessState)
+		}
+	default:
+		errorIf(probe.NewError(e).Trace(executable), "Unable to run `%s`.", args[0])
+		sh.status = globalErrorExitStatus
+	}
+}
+
+// splitShellLine splits a command line into arguments like a POSIX
+// shell: single quotes keep their content as is, double quotes and
+// backslashes escape spaces.
+func splitShellLine(line string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inArg = true, true
+		case quote == '"':
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape")
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// isShellLocalPath returns true for the arguments naming local files:
+// those starting with './', '../' or '~/', and the absolute paths which
+// do not start with an alias.
+func isShellLocalPath(arg string, aliases map[string]bool) bool {
+	switch {
+	case arg == "." || arg == "..":
+		return false
+	case arg == "~", strings.HasPrefix(arg, "./"), strings.HasPrefix(arg, "../"), strings.HasPrefix(arg, "~/"):
+		return true
+	case strings.HasPrefix(arg, "/"):
+		return !aliases[strings.SplitN(strings.TrimPrefix(arg, "/"), "/", 2)[0]]
+	}
+	return false
+}
+
+// expandShellHome replaces the '~' of a local path by the home directory
+// of the user, as the commands run from the shell do not go through a
+// system shell.
+func expandShellHome(arg string) string {
+	if arg != "~" && !strings.HasPrefix(arg, "~/") {
+		return arg
+	}
+	home, e := os.UserHomeDir()
+	if e != nil {
+		return arg
+	}
+	return home + strings.TrimPrefix(arg, "~")
+}
+
+// resolveArgs resolves the positional arguments of a command which name
+// remote paths. Subcommand names, flags with their values and local paths
+// are kept as is.
+func (sh *mcShell) resolveArgs(name string, args []string) []string {
+	var flags []cli.Flag
+	var subcommands []cli.Command
+	if cmd := findCommand(sh.cmds, name); cmd != nil {
+		flags, subcommands = cmd.Flags, cmd.Subcommands
+	}
+	aliases := map[string]bool{}
+	for _, alias := range shellAliases() {
+		aliases[alias] = true
+	}
+
+	resolved := make([]string, 0, len(args))
+	positional, flagValue, sawPath := false, false, false
+	for _, arg := range args {
+		switch {
+		case flagValue:
+			flagValue = false
+		case !positional && arg == "--":
+			positional = true
+		case !positional && strings.HasPrefix(arg, "-") && arg != "-":
+			if f := lookupFlag(flags, strings.TrimLeft(arg, "-")); f != nil && !strings.Contains(arg, "=") {
+				switch f.(type) {
+				case cli.BoolFlag, cli.BoolTFlag:
+				default:
+					flagValue = true
+				}
+			}
+		case !sawPath && findCommand(subcommands, arg) != nil:
+			// Subcommands come before the paths.
+			cmd := findCommand(subcommands, arg)
+			flags, subcommands = cmd.Flags, cmd.Subcommands
+		case isShellLocalPath(arg, aliases):
+			arg = expandShellHome(arg)
+			sawPath = true
+		default:
+			arg = sh.resolve(arg)
+			sawPath = true
+		}
+		resolved = append(resolved, arg)
+	}
+	return resolved
+}
+
+// execute runs one command line, it returns false when the shell should exit.
+func (sh *mcShell) execute(line string) bool {
+	fields, e := splitShellLine(line)
+	if e != nil {
+		fmt.Fprintln(sh.term, e)
+		return true
+	}
+	if len(fields) == 0 {
+		return true
+	}
+	cmd, args := fields[0], fields[1:]
+	switch cmd {
+	case "exit", "quit":
+		return false
+	case "help":
+		fmt.Fprint(sh.term, shellHelp)
+	case "history":
+		for i, h := range sh.history {
+			fmt.Fprintf(sh.term, "%4d  %s\n", i+1, h)
+		}
+	case "pwd":
+		fmt.Fprintln(sh.term, "/"+sh.cwd)
+	case "cd":
+		target := ""
+		if len(args) > 0 {
+			target = sh.resolve(args[0])
+		}
+		alias := strings.SplitN(target, "/", 2)[0]
+		if alias != "" {
+			if mustGetHostConfig(alias) == nil {
+				fmt.Fprintf(sh.term, "cd: no such alias `%s`\n", alias)
+				return true
+			}
+		}
+		sh.cwd = strings.TrimSuffix(target, "/")
+		sh.term.SetPrompt(sh.prompt())
+	case "ls":
+		if len(args) == 0 && sh.cwd == "" {
+			for _, alias := range shellAliases() {
+				fmt.Fprintln(sh.term, alias+"/")
+			}
+			return true
+		}
+		if len(args) == 0 {
+			args = []string{"."}
+		}
+		sh.run(append([]string{"ls"}, sh.resolveArgs("ls", args)...)...)
+	case "get":
+		if len(args) == 0 || len(args) > 2 {
+			fmt.Fprintln(sh.term, "usage: get PATH [LOCAL]")
+			return true
+		}
+		local := "."
+		if len(args) == 2 {
+			local = expandShellHome(args[1])
+		}
+		sh.run("cp", sh.resolve(args[0]), local)
+	case "put":
+		if len(args) == 0 || len(args) > 2 {
+			fmt.Fprintln(sh.term, "usage: put LOCAL [PATH]")
+			return true
+		}
+		remote := sh.resolve("./")
+		if len(args) == 2 {
+			remote = sh.resolve(args[1])
+		}
+		sh.run("cp", expandShellHome(args[0]), remote)
+	case "shell":
+		fmt.Fprintln(sh.term, "already in a shell")
+	default:
+		if shellLocalArgCmds[cmd] {
+			for i := range args {
+				args[i] = expandShellHome(args[i])
+			}
+			sh.run(append([]string{cmd}, args...)...)
+			return true
+		}
+		sh.run(append([]string{cmd}, sh.resolveArgs(cmd, args)...)...)
+	}
+	return true
+}
+
+// shellHelp is printed by the 'help' shell command.
+const shellHelp = `cd [PATH]          change the current alias, bucket or prefix
+pwd                print the current path
+ls [PATH]          list buckets and objects
+get PATH [LOCAL]   download an object
+put LOCAL [PATH]   upload a local file
+history            print the commands run in this session
+exit               leave the shell
+Any other mc command runs with its arguments relative to the current path,
+local files are named './file', '../file', '~/file' or with an absolute path.
+`
+
+// mainShell is the main entry point for shell command.
+func mainShell(ctx *cli.Context) error {
+	if len(ctx.Args()) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "shell", globalInvalidArgsExitStatus)
+	}
+
+	console.SetColor("ShellPath", color.New(color.FgCyan, color.Bold))
+
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		fatalIf(errInvalidArgument().Trace(), "mc shell requires an interactive terminal.")
+	}
+
+	// Interrupts are meant for the commands run from the shell,
+	// leave the shell itself running.
+	signal.Ignore(os.Interrupt)
+
+	state, e := terminal.MakeRaw(fd)
+	fatalIf(probe.NewError(e), "Unable to initialize the terminal.")
+
+	sh := &mcShell{fd: fd, state: state, cmds: ctx.App.Commands}
+	defer func() { terminal.Restore(sh.fd, sh.state) }()
+
+	sh.term = terminal.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "")
+	sh.term.AutoCompleteCallback = sh.autoComplete
+
+	if ctx.Args().Present() {
+		sh.execute("cd " + ctx.Args().First())
+	}
+	sh.term.SetPrompt(sh.prompt())
+
+	for {
+		line, e := sh.term.ReadLine()
+		if e != nil {
+			if e != io.EOF {
+				errorIf(probe.NewError(e), "Unable to read the command line.")
+			}
+			fmt.Fprintln(sh.term)
+			break
+		}
+		if strings.TrimSpace(line) != "" {
+			sh.history = append(sh.history, line)
+		}
+		if !sh.execute(line) {
+			break
+		}
+	}
+	// Like a system shell, exit with the status of the last command.
+	if sh.status != 0 {
+		return exitStatus(sh.status)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestSplitShellLine(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected []string
+		success  bool
+	}{
+		{"ls  -r  photos/", []string{"ls", "-r", "photos/"}, true},
+		{`cp "my file.txt" 'other file'`, []string{"cp", "my file.txt", "other file"}, true},
+		{`stat a\ b "x\"y" 'c\d'`, []string{"stat", "a b", `x"y`, `c\d`}, true},
+		{`find . --name ""`, []string{"find", ".", "--name", ""}, true},
+		{`cat "unterminated`, nil, false},
+		{`cat trailing\`, nil, false},
+		{"", nil, true},
+	}
+	for i, testCase := range testCases {
+		args, e := splitShellLine(testCase.line)
+		if success := e == nil; success != testCase.success {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, e)
+		}
+		if !reflect.DeepEqual(args, testCase.expected) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, args)
+		}
+	}
+}
+
+func TestShellResolveArgs(t *testing.T) {
+	defer func(load func() (*configV10, *probe.Error)) { loadMcConfig = load }(loadMcConfig)
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		return &configV10{Aliases: map[string]aliasConfigV10{"play": {}}}, nil
+	}
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", "/home/alice")
+
+	sh := &mcShell{cwd: "play/mybucket", cmds: appCmds}
+	testCases := []struct {
+		cmd      string
+		args     []string
+		expected []string
+	}{
+		{"rm", []string{"--recursive", "--force", "logs/"}, []string{"--recursive", "--force", "play/mybucket/logs/"}},
+		{"find", []string{".", "--name", "*.jpg", "--older-than=7d"}, []string{"play/mybucket/", "--name", "*.jpg", "--older-than=7d"}},
+		{"cp", []string{"./notes.txt", "~/todo.txt", "/tmp/x", "docs/"}, []string{"./notes.txt", "/home/alice/todo.txt", "/tmp/x", "play/mybucket/docs/"}},
+		{"ls", []string{"~", "~bob/x"}, []string{"/home/alice", "play/mybucket/~bob/x"}},
+		{"tag", []string{"list", "photo.jpg"}, []string{"list", "play/mybucket/photo.jpg"}},
+		{"cat", []string{"--", "-dash"}, []string{"--", "play/mybucket/-dash"}},
+		{"stat", []string{"..", "/play/other/x"}, []string{"play/", "play/other/x"}},
+	}
+	for i, testCase := range testCases {
+		if got := sh.resolveArgs(testCase.cmd, testCase.args); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+//go:build !windows
+// +build !windows
+
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// trapPauseSignals pauses the transfers on SIGUSR1 and resumes them
+// on SIGUSR2.
+func trapPauseSignals() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1, syscall.SIGUSR2)
+	for s := range sigCh {
+		globalTransferPause.set(s == syscall.SIGUSR1)
+	}
+}
+
+// signalPause pauses or resumes the transfers of another mc process.
+func signalPause(p *os.Process, paused bool) error {
+	if paused {
+		return p.Signal(syscall.SIGUSR1)
+	}
+	return p.Signal(syscall.SIGUSR2)
+}
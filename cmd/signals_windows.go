@@ -0,0 +1,33 @@
+//go:build windows
+// +build windows
+
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+)
+
+// trapPauseSignals does nothing, Windows has no user signals.
+func trapPauseSignals() {}
+
+// signalPause is not supported, Windows has no user signals.
+func signalPause(p *os.Process, paused bool) error {
+	return errors.New("pausing a running command is not supported on Windows")
+}
@@ -0,0 +1,162 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var snapshotCreateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "out, o",
+		Usage: "file to save the snapshot to, compressed with zstd for '.zst' and gzip for '.gz'",
+	},
+	cli.BoolFlag{
+		Name:  "versions",
+		Usage: "save all the versions and delete markers, not only the latest versions",
+	},
+	cli.BoolFlag{
+		Name:  "no-tags",
+		Usage: "do not save the tags, which takes one request per object",
+	},
+}
+
+var snapshotCreateCmd = cli.Command{
+	Name:         "create",
+	Usage:        "save the listing of a bucket to a file",
+	Action:       mainSnapshotCreate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(snapshotCreateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] ALIAS/BUCKET[/PREFIX] --out FILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Save the key, size, ETag, version ID, modification time and tags of each
+   object of a bucket, as of now. 'mc snapshot diff' then reports what changed
+   since, without enabling versioning on the bucket.
+
+EXAMPLES:
+  1. Save the listing of a bucket, compressed with zstd.
+     {{.Prompt}} {{.HelpName}} --out mybucket-2021-03-01.json.zst myminio/mybucket
+
+  2. Save all the versions of the objects under a prefix, without their tags.
+     {{.Prompt}} {{.HelpName}} --versions --no-tags --out photos.json.gz myminio/mybucket/photos/
+`,
+}
+
+// snapshotCreateMessage is printed once a snapshot is saved.
+type snapshotCreateMessage struct {
+	Status  string `json:"status"`
+	URL     string `json:"url"`
+	File    string `json:"file"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+}
+
+func (s snapshotCreateMessage) String() string {
+	return console.Colorize("SnapshotCreate", fmt.Sprintf("Saved the listing of `%s` to `%s`, %d object(s), %s.",
+		s.URL, s.File, s.Objects, humanize.IBytes(uint64(s.Size))))
+}
+
+func (s snapshotCreateMessage) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// parseSnapshotURL splits ALIAS/BUCKET[/PREFIX] of an object storage.
+func parseSnapshotURL(targetURL string) (alias, bucket, prefix string, err *probe.Error) {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return "", "", "", err.Trace(targetURL)
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return "", "", "", errInvalidArgument().Trace(targetURL)
+	}
+	bucket, prefix = s3Clnt.url2BucketAndObject()
+	if bucket == "" {
+		return "", "", "", errInvalidArgument().Trace(targetURL)
+	}
+	alias, _ = url2Alias(targetURL)
+	return alias, bucket, prefix, nil
+}
+
+func mainSnapshotCreate(cliCtx *cli.Context) error {
+	ctx, cancelSnapshotCreate := context.WithCancel(globalContext)
+	defer cancelSnapshotCreate()
+
+	console.SetColor("SnapshotCreate", color.New(color.FgGreen))
+
+	if len(cliCtx.Args()) != 1 || cliCtx.String("out") == "" {
+		cli.ShowCommandHelpAndExit(cliCtx, "create", globalInvalidArgsExitStatus)
+	}
+	targetURL := cliCtx.Args().Get(0)
+	filename := cliCtx.String("out")
+
+	alias, bucket, prefix, err := parseSnapshotURL(targetURL)
+	fatalIf(err, "Snapshots are only taken of buckets on object storage.")
+
+	header := snapshotHeader{
+		Version:  snapshotVersion,
+		URL:      targetURL,
+		Prefix:   prefix,
+		Time:     time.Now().UTC(),
+		Versions: cliCtx.Bool("versions"),
+		Tags:     !cliCtx.Bool("no-tags"),
+	}
+	w, err := createSnapshotFile(filename, header)
+	fatalIf(err, "Unable to create the snapshot file.")
+
+	msg := snapshotCreateMessage{URL: targetURL, File: filename}
+	for entry := range listSnapshot(ctx, alias, bucket, prefix, header.Versions, header.Tags) {
+		if entry.err == nil {
+			entry.err = w.write(entry)
+		}
+		if entry.err != nil {
+			w.abort()
+			fatalIf(entry.err, "Unable to save the listing of `%s`.", targetURL)
+		}
+		msg.Objects++
+		msg.Size += entry.Size
+	}
+	if ctx.Err() != nil {
+		w.abort()
+		fatalIf(probe.NewError(ctx.Err()), "Unable to save the listing of `%s`.", targetURL)
+	}
+	fatalIf(w.close(), "Unable to save the snapshot file.")
+
+	printMsg(msg)
+	return nil
+}
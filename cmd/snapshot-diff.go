@@ -0,0 +1,317 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var snapshotDiffCmd = cli.Command{
+	Name:         "diff",
+	Usage:        "report the changes of a bucket since a snapshot",
+	Action:       mainSnapshotDiff,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FILE ALIAS/BUCKET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Compare a snapshot saved by 'mc snapshot create' with the objects of a bucket
+   now, under the prefix of the snapshot. Each object is reported as added,
+   removed, modified when its content, version or modification time changed, or
+   with changed tags when the snapshot holds them. With a snapshot of all the
+   versions, versions added and removed are reported.
+
+EXAMPLES:
+  1. Report what changed in a bucket since a snapshot.
+     {{.Prompt}} {{.HelpName}} mybucket-2021-03-01.json.zst myminio/mybucket
+
+  2. Compare a replica with the snapshot of the source bucket, in JSON format.
+     {{.Prompt}} {{.HelpName}} --json mybucket-2021-03-01.json.zst replica/mybucket
+`,
+}
+
+// Changes reported by snapshot diff.
+const (
+	snapshotAdded    = "added"
+	snapshotRemoved  = "removed"
+	snapshotModified = "modified"
+	snapshotTagged   = "tags"
+)
+
+// snapshotDiffMessage is an object which changed since a snapshot.
+type snapshotDiffMessage struct {
+	Status    string         `json:"status"`
+	Diff      string         `json:"diff"`
+	URL       string         `json:"url"`
+	VersionID string         `json:"versionId,omitempty"`
+	Before    *snapshotEntry `json:"before,omitempty"`
+	After     *snapshotEntry `json:"after,omitempty"`
+}
+
+func (d snapshotDiffMessage) String() string {
+	name := d.URL
+	if d.VersionID != "" {
+		name += " (" + d.VersionID + ")"
+	}
+	switch d.Diff {
+	case snapshotAdded:
+		return console.Colorize("SnapshotAdded", "+ "+name)
+	case snapshotRemoved:
+		return console.Colorize("SnapshotRemoved", "- "+name)
+	case snapshotTagged:
+		return console.Colorize("SnapshotModified", "~ "+name+" (tags)")
+	default:
+		return console.Colorize("SnapshotModified", "~ "+name)
+	}
+}
+
+func (d snapshotDiffMessage) JSON() string {
+	d.Status = "success"
+	msgBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// snapshotDiffSummary counts the changes since a snapshot.
+type snapshotDiffSummary struct {
+	Status   string `json:"status"`
+	Since    string `json:"since"`
+	Added    int64  `json:"added"`
+	Removed  int64  `json:"removed"`
+	Modified int64  `json:"modified"`
+	Tags     int64  `json:"tags"`
+}
+
+func (s *snapshotDiffSummary) add(d snapshotDiffMessage) {
+	switch d.Diff {
+	case snapshotAdded:
+		s.Added++
+	case snapshotRemoved:
+		s.Removed++
+	case snapshotModified:
+		s.Modified++
+	case snapshotTagged:
+		s.Tags++
+	}
+}
+
+func (s snapshotDiffSummary) String() string {
+	return console.Colorize("SnapshotSummary", fmt.Sprintf("Since %s: %d added, %d removed, %d modified, %d with changed tags.",
+		s.Since, s.Added, s.Removed, s.Modified, s.Tags))
+}
+
+func (s snapshotDiffSummary) JSON() string {
+	s.Status = "success"
+	msgBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// snapshotCursor iterates over the entries of a snapshot or of a
+// listing, grouped by key.
+type snapshotCursor struct {
+	next func() (snapshotEntry, bool)
+	head *snapshotEntry
+	err  *probe.Error
+}
+
+// group returns the entries of the next key, nil at the end or after
+// an error.
+func (c *snapshotCursor) group() (entries []snapshotEntry) {
+	for {
+		if c.head == nil {
+			entry, ok := c.next()
+			if !ok {
+				return entries
+			}
+			if entry.err != nil {
+				c.err = entry.err
+				return nil
+			}
+			c.head = &entry
+		}
+		if len(entries) > 0 && c.head.Key != entries[0].Key {
+			return entries
+		}
+		entries = append(entries, *c.head)
+		c.head = nil
+	}
+}
+
+// diffSnapshotEntry returns how an object or a version changed, empty
+// when it did not. The null version of an unversioned bucket changes
+// in place.
+func diffSnapshotEntry(b, a snapshotEntry, tags bool) string {
+	switch {
+	case b.ETag != a.ETag, b.Size != a.Size, b.VersionID != a.VersionID,
+		b.DeleteMarker != a.DeleteMarker, !b.LastModified.Equal(a.LastModified):
+		return snapshotModified
+	case tags && (len(b.Tags) > 0 || len(a.Tags) > 0) && !reflect.DeepEqual(b.Tags, a.Tags):
+		return snapshotTagged
+	}
+	return ""
+}
+
+// diffSnapshotGroups compares the entries of a key in a snapshot and
+// now, versions are compared by their IDs.
+func diffSnapshotGroups(before, after []snapshotEntry, versions, tags bool) (diffs []snapshotDiffMessage) {
+	if !versions {
+		b, a := before[0], after[0]
+		if diff := diffSnapshotEntry(b, a, tags); diff != "" {
+			diffs = append(diffs, snapshotDiffMessage{Diff: diff, Before: &b, After: &a})
+		}
+		return diffs
+	}
+
+	old := make(map[string]snapshotEntry, len(before))
+	for _, b := range before {
+		old[b.VersionID] = b
+	}
+	for i := range after {
+		a := after[i]
+		b, ok := old[a.VersionID]
+		if !ok {
+			diffs = append(diffs, snapshotDiffMessage{Diff: snapshotAdded, VersionID: a.VersionID, After: &a})
+			continue
+		}
+		delete(old, a.VersionID)
+		if diff := diffSnapshotEntry(b, a, tags); diff != "" {
+			diffs = append(diffs, snapshotDiffMessage{Diff: diff, VersionID: a.VersionID, Before: &b, After: &a})
+		}
+	}
+	for i := range before {
+		if b, ok := old[before[i].VersionID]; ok {
+			diffs = append(diffs, snapshotDiffMessage{Diff: snapshotRemoved, VersionID: b.VersionID, Before: &b})
+		}
+	}
+	return diffs
+}
+
+// diffSnapshots merges the entries of a snapshot and of a listing,
+// both sorted by key, and reports the changes of each key. It stops
+// at the first error of either.
+func diffSnapshots(before, after *snapshotCursor, versions, tags bool, report func(snapshotDiffMessage)) {
+	b, a := before.group(), after.group()
+	for (b != nil || a != nil) && before.err == nil && after.err == nil {
+		switch {
+		case a == nil || (b != nil && b[0].Key < a[0].Key):
+			for i := range b {
+				report(snapshotDiffMessage{Diff: snapshotRemoved, VersionID: versionIf(versions, b[i]), Before: &b[i]})
+			}
+			b = before.group()
+		case b == nil || a[0].Key < b[0].Key:
+			for i := range a {
+				report(snapshotDiffMessage{Diff: snapshotAdded, VersionID: versionIf(versions, a[i]), After: &a[i]})
+			}
+			a = after.group()
+		default:
+			for _, d := range diffSnapshotGroups(b, a, versions, tags) {
+				report(d)
+			}
+			b, a = before.group(), after.group()
+		}
+	}
+}
+
+// versionIf returns the version ID of an entry reported with versions.
+func versionIf(versions bool, entry snapshotEntry) string {
+	if versions {
+		return entry.VersionID
+	}
+	return ""
+}
+
+func mainSnapshotDiff(cliCtx *cli.Context) error {
+	ctx, cancelSnapshotDiff := context.WithCancel(globalContext)
+	defer cancelSnapshotDiff()
+
+	console.SetColor("SnapshotAdded", color.New(color.FgGreen))
+	console.SetColor("SnapshotRemoved", color.New(color.FgRed))
+	console.SetColor("SnapshotModified", color.New(color.FgYellow, color.Bold))
+	console.SetColor("SnapshotSummary", color.New(color.Bold))
+
+	if len(cliCtx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "diff", globalInvalidArgsExitStatus)
+	}
+	filename := cliCtx.Args().Get(0)
+	targetURL := cliCtx.Args().Get(1)
+
+	alias, bucket, prefix, err := parseSnapshotURL(targetURL)
+	fatalIf(err, "Snapshots are only compared with buckets on object storage.")
+	if prefix != "" {
+		fatalIf(errInvalidArgument().Trace(targetURL), "Expected ALIAS/BUCKET, the objects are compared under the prefix of the snapshot.")
+	}
+
+	r, err := openSnapshotFile(filename)
+	fatalIf(err, "Unable to read the snapshot.")
+	defer r.close()
+	header := r.header
+
+	before := &snapshotCursor{next: func() (snapshotEntry, bool) {
+		entry, e := r.next()
+		if e == io.EOF {
+			return entry, false
+		}
+		if e != nil {
+			entry.err = probe.NewError(e).Trace(filename)
+		}
+		return entry, true
+	}}
+	entryCh := listSnapshot(ctx, alias, bucket, header.Prefix, header.Versions, header.Tags)
+	after := &snapshotCursor{next: func() (snapshotEntry, bool) {
+		entry, ok := <-entryCh
+		if !ok && ctx.Err() != nil {
+			// The listing was interrupted, the objects left are not removed.
+			return snapshotEntry{err: probe.NewError(ctx.Err())}, true
+		}
+		return entry, ok
+	}}
+
+	summary := snapshotDiffSummary{Since: header.Time.Format(printDate)}
+	bucketURL := alias + "/" + bucket
+	diffSnapshots(before, after, header.Versions, header.Tags, func(d snapshotDiffMessage) {
+		if d.Before != nil {
+			d.URL = urlJoinPath(bucketURL, d.Before.Key)
+		} else {
+			d.URL = urlJoinPath(bucketURL, d.After.Key)
+		}
+		summary.add(d)
+		printMsg(d)
+	})
+	fatalIf(before.err, "Unable to read the snapshot.")
+	fatalIf(after.err, "Unable to list `%s`.", targetURL)
+
+	printMsg(summary)
+	return nil
+}
@@ -0,0 +1,315 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+var snapshotSubcommands = []cli.Command{
+	snapshotCreateCmd,
+	snapshotDiffCmd,
+}
+
+var snapshotCmd = cli.Command{
+	Name:            "snapshot",
+	Usage:           "save the listing of a bucket and report what changed since",
+	Action:          mainSnapshot,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands:     snapshotSubcommands,
+}
+
+func mainSnapshot(ctx *cli.Context) error {
+	commandNotFound(ctx, snapshotSubcommands)
+	return nil
+}
+
+// Version of the snapshot file format.
+const snapshotVersion = 1
+
+// Number of objects whose tags are fetched in parallel.
+const snapshotTagWorkers = 16
+
+// snapshotHeader is the first line of a snapshot file, the objects
+// follow one per line in the order of the listing, sorted by key.
+type snapshotHeader struct {
+	Version  int       `json:"version"`
+	URL      string    `json:"url"`
+	Prefix   string    `json:"prefix,omitempty"`
+	Time     time.Time `json:"time"`
+	Versions bool      `json:"versions"`
+	Tags     bool      `json:"tags"`
+}
+
+// snapshotEntry is an object, or a version of an object with
+// --versions, its key is relative to the bucket.
+type snapshotEntry struct {
+	Key          string            `json:"key"`
+	VersionID    string            `json:"versionId,omitempty"`
+	DeleteMarker bool              `json:"deleteMarker,omitempty"`
+	Size         int64             `json:"size"`
+	ETag         string            `json:"etag,omitempty"`
+	LastModified time.Time         `json:"lastModified"`
+	Tags         map[string]string `json:"tags,omitempty"`
+
+	err *probe.Error
+}
+
+// snapshotWriter writes a snapshot to a temporary file renamed once
+// complete, compressed with zstd or gzip after the file extension.
+type snapshotWriter struct {
+	filename string
+	file     *os.File
+	compress io.WriteCloser
+	buf      *bufio.Writer
+	enc      *json.Encoder
+}
+
+func createSnapshotFile(filename string, header snapshotHeader) (*snapshotWriter, *probe.Error) {
+	file, e := os.Create(filename + ".tmp")
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	w := &snapshotWriter{filename: filename, file: file}
+	var out io.Writer = file
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".zst":
+		w.compress, e = zstd.NewWriter(file)
+	case ".gz":
+		w.compress = gzip.NewWriter(file)
+	}
+	if e != nil {
+		w.abort()
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	if w.compress != nil {
+		out = w.compress
+	}
+	w.buf = bufio.NewWriter(out)
+	w.enc = json.NewEncoder(w.buf)
+	if e = w.enc.Encode(header); e != nil {
+		w.abort()
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	return w, nil
+}
+
+func (w *snapshotWriter) write(entry snapshotEntry) *probe.Error {
+	if e := w.enc.Encode(entry); e != nil {
+		return probe.NewError(e).Trace(w.filename)
+	}
+	return nil
+}
+
+// close flushes the snapshot and renames it to its final name.
+func (w *snapshotWriter) close() *probe.Error {
+	e := w.buf.Flush()
+	if e == nil && w.compress != nil {
+		e = w.compress.Close()
+	}
+	if e == nil {
+		e = w.file.Close()
+	}
+	if e == nil {
+		e = os.Rename(w.file.Name(), w.filename)
+	}
+	if e != nil {
+		w.abort()
+		return probe.NewError(e).Trace(w.filename)
+	}
+	return nil
+}
+
+// abort removes an incomplete snapshot.
+func (w *snapshotWriter) abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
+
+// snapshotReader reads a snapshot written by snapshotWriter, the
+// compression is detected from the content.
+type snapshotReader struct {
+	header snapshotHeader
+	file   *os.File
+	zstd   *zstd.Decoder
+	dec    *json.Decoder
+}
+
+var (
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+func openSnapshotFile(filename string) (*snapshotReader, *probe.Error) {
+	file, e := os.Open(filename)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	r := &snapshotReader{file: file}
+	buf := bufio.NewReader(file)
+	magic, _ := buf.Peek(len(zstdMagic))
+	var in io.Reader = buf
+	switch {
+	case bytes.HasPrefix(magic, zstdMagic):
+		r.zstd, e = zstd.NewReader(buf)
+		in = r.zstd
+	case bytes.HasPrefix(magic, gzipMagic):
+		in, e = gzip.NewReader(buf)
+	}
+	if e != nil {
+		r.close()
+		return nil, probe.NewError(e).Trace(filename)
+	}
+	r.dec = json.NewDecoder(in)
+	if e = r.dec.Decode(&r.header); e != nil {
+		r.close()
+		return nil, probe.NewError(fmt.Errorf("not a snapshot: %v", e)).Trace(filename)
+	}
+	if r.header.Version != snapshotVersion {
+		r.close()
+		return nil, probe.NewError(fmt.Errorf("unsupported snapshot version %d", r.header.Version)).Trace(filename)
+	}
+	return r, nil
+}
+
+// next returns the next entry of the snapshot, io.EOF at the end.
+func (r *snapshotReader) next() (entry snapshotEntry, e error) {
+	e = r.dec.Decode(&entry)
+	return entry, e
+}
+
+func (r *snapshotReader) close() {
+	if r.zstd != nil {
+		r.zstd.Close()
+	}
+	r.file.Close()
+}
+
+// listSnapshot lists the objects of bucket under prefix in key order,
+// or all their versions and delete markers with versions. The tags of
+// the objects are fetched by workers in parallel when tags is set.
+// Listing stops after an entry with an error, or quietly when ctx is
+// canceled.
+func listSnapshot(ctx context.Context, alias, bucket, prefix string, versions, tags bool) <-chan snapshotEntry {
+	bucketURL := alias + "/" + bucket
+	entryCh := make(chan snapshotEntry)
+	clnt, err := newClient(urlJoinPath(bucketURL, prefix))
+	if err != nil {
+		go func() {
+			defer close(entryCh)
+			entryCh <- snapshotEntry{err: err}
+		}()
+		return entryCh
+	}
+
+	// Latest versions are listed as of now to get their version IDs.
+	opts := ListOptions{Recursive: true, ShowDir: DirNone, TimeRef: time.Now().UTC()}
+	if versions {
+		opts = ListOptions{Recursive: true, ShowDir: DirNone, WithOlderVersions: true, WithDeleteMarkers: true}
+	}
+
+	// Entries are sent in order once their tags are known.
+	pending := make(chan chan snapshotEntry, snapshotTagWorkers)
+	go func() {
+		defer close(pending)
+		for content := range clnt.List(ctx, opts) {
+			ch := make(chan snapshotEntry, 1)
+			select {
+			case pending <- ch:
+			case <-ctx.Done():
+				return
+			}
+			if content.Err != nil {
+				ch <- snapshotEntry{err: content.Err.Trace(bucketURL)}
+				return
+			}
+			entry := snapshotEntry{
+				Key:          strings.TrimPrefix(content.URL.Path, "/"+bucket+"/"),
+				VersionID:    content.VersionID,
+				DeleteMarker: content.IsDeleteMarker,
+				Size:         content.Size,
+				ETag:         strings.Trim(content.ETag, "\""),
+				LastModified: content.Time.UTC(),
+			}
+			if !tags || entry.DeleteMarker {
+				ch <- entry
+				continue
+			}
+			go func() {
+				entry.Tags, entry.err = getSnapshotTags(ctx, urlJoinPath(bucketURL, entry.Key), entry.VersionID)
+				ch <- entry
+			}()
+		}
+	}()
+
+	go func() {
+		defer close(entryCh)
+		for ch := range pending {
+			entry := <-ch
+			select {
+			case entryCh <- entry:
+			case <-ctx.Done():
+				return
+			}
+			if entry.err != nil {
+				return
+			}
+		}
+	}()
+	return entryCh
+}
+
+// getSnapshotTags returns the tags of an object version, an object
+// removed since it was listed has no tags.
+func getSnapshotTags(ctx context.Context, objectURL, versionID string) (map[string]string, *probe.Error) {
+	clnt, err := newClient(objectURL)
+	if err != nil {
+		return nil, err.Trace(objectURL)
+	}
+	if versionID == "null" {
+		versionID = ""
+	}
+	tags, err := clnt.GetTags(ctx, versionID)
+	if err != nil {
+		switch minio.ToErrorResponse(err.ToGoError()).Code {
+		case "NoSuchKey", "NoSuchVersion":
+			return nil, nil
+		}
+		return nil, err.Trace(objectURL)
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return tags, nil
+}
@@ -0,0 +1,158 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnapshotFile(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-snapshot-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	header := snapshotHeader{Version: snapshotVersion, URL: "play/mybucket", Time: time.Now().UTC(), Tags: true}
+	entries := []snapshotEntry{
+		{Key: "a.txt", VersionID: "null", Size: 2, ETag: "60b725f10c9c85c70d97880dfe8191b3", LastModified: time.Unix(1614556800, 0).UTC()},
+		{Key: "dir/b.txt", Size: 4, Tags: map[string]string{"project": "x"}, LastModified: time.Unix(1614556800, 0).UTC()},
+	}
+	for _, name := range []string{"snap.json", "snap.json.gz", "snap.json.zst"} {
+		filename := filepath.Join(dir, name)
+		w, err := createSnapshotFile(filename, header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			if err = w.write(entry); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = w.close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := openSnapshotFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r.header, header) {
+			t.Fatalf("%s: expected header %+v, got %+v", name, header, r.header)
+		}
+		var got []snapshotEntry
+		for {
+			entry, e := r.next()
+			if e == io.EOF {
+				break
+			}
+			if e != nil {
+				t.Fatal(e)
+			}
+			got = append(got, entry)
+		}
+		r.close()
+		if !reflect.DeepEqual(got, entries) {
+			t.Fatalf("%s: expected %+v, got %+v", name, entries, got)
+		}
+	}
+}
+
+// sliceSnapshotCursor iterates over entries sorted by key.
+func sliceSnapshotCursor(entries []snapshotEntry) *snapshotCursor {
+	return &snapshotCursor{next: func() (snapshotEntry, bool) {
+		if len(entries) == 0 {
+			return snapshotEntry{}, false
+		}
+		entry := entries[0]
+		entries = entries[1:]
+		return entry, true
+	}}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	testCases := []struct {
+		before, after []snapshotEntry
+		versions      bool
+		expected      []string
+	}{
+		// Objects added, removed, modified and retagged.
+		{
+			before: []snapshotEntry{
+				{Key: "a", ETag: "1"},
+				{Key: "b", ETag: "1", Tags: map[string]string{"k": "v"}},
+				{Key: "c", ETag: "1"},
+				{Key: "d", ETag: "1"},
+			},
+			after: []snapshotEntry{
+				{Key: "a", ETag: "2"},
+				{Key: "b", ETag: "1", Tags: map[string]string{"k": "w"}},
+				{Key: "d", ETag: "1"},
+				{Key: "e", ETag: "1"},
+			},
+			expected: []string{"modified a", "tags b", "removed c", "added e"},
+		},
+		// Versions added and removed, in the order of the listing.
+		{
+			before: []snapshotEntry{
+				{Key: "a", VersionID: "v2"},
+				{Key: "a", VersionID: "v1"},
+				{Key: "b", VersionID: "v1"},
+			},
+			after: []snapshotEntry{
+				{Key: "a", VersionID: "v3"},
+				{Key: "a", VersionID: "v2"},
+				{Key: "b", VersionID: "v2", DeleteMarker: true},
+				{Key: "b", VersionID: "v1"},
+			},
+			versions: true,
+			expected: []string{"added a v3", "removed a v1", "added b v2"},
+		},
+		// Nothing changed.
+		{
+			before:   []snapshotEntry{{Key: "a", ETag: "1"}},
+			after:    []snapshotEntry{{Key: "a", ETag: "1"}},
+			expected: nil,
+		},
+	}
+
+	for i, testCase := range testCases {
+		var got []string
+		diffSnapshots(sliceSnapshotCursor(testCase.before), sliceSnapshotCursor(testCase.after), testCase.versions, true, func(d snapshotDiffMessage) {
+			key := ""
+			if d.Before != nil {
+				key = d.Before.Key
+			} else {
+				key = d.After.Key
+			}
+			s := d.Diff + " " + key
+			if d.VersionID != "" {
+				s += " " + d.VersionID
+			}
+			got = append(got, s)
+		})
+		if !reflect.DeepEqual(got, testCase.expected) {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/minio/cli"
@@ -56,6 +57,10 @@ var (
 			Name:  "json-input",
 			Usage: "json input serialization option",
 		},
+		cli.BoolFlag{
+			Name:  "parquet-input",
+			Usage: "parquet input serialization, the default for objects named '*.parquet'",
+		},
 		cli.StringFlag{
 			Name:  "compression",
 			Usage: "input compression type",
@@ -72,6 +77,27 @@ var (
 			Name:  "json-output",
 			Usage: "json output serialization option",
 		},
+		cli.StringFlag{
+			Name:  "output-format",
+			Usage: "write the results as 'csv', 'json' lines or 'tsv', whatever the input serialization",
+		},
+		cli.StringFlag{
+			Name:  "output-delimiter",
+			Usage: "field delimiter of --output-format csv or tsv",
+		},
+		cli.BoolFlag{
+			Name:  "no-output-header",
+			Usage: "do not write the column names as first line of --output-format csv or tsv",
+		},
+		cli.IntFlag{
+			Name:  "parallel",
+			Usage: "number of objects queried concurrently, their records are merged",
+			Value: 1,
+		},
+		cli.BoolFlag{
+			Name:  "source-column",
+			Usage: "add the name of the object queried as first column '_source' of each record",
+		},
 	}
 )
 
@@ -124,6 +150,22 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} --compression GZIP --csv-input "rd=\n,fh=USE,fd=;" \
            --csv-output "rd=\n" --csv-output-header "device_id,uptime,lat,lon" \
            --query "select * from S3Object" myminio/iot-devices/data.csv
+
+  7. Run a query on JSON objects, and write the results as CSV with a header line for a spreadsheet.
+     {{.Prompt}} {{.HelpName}} --json-input "type=lines" --output-format csv \
+           --query "select s.device_id, s.uptime from S3Object s" myminio/iot-devices/logs.json > uptime.csv
+
+  8. Run a query on a CSV object, and pipe the results as JSON lines to jq.
+     {{.Prompt}} {{.HelpName}} --csv-input "fh=USE" --output-format json \
+           --query "select * from S3Object" myminio/iot-devices/data.csv | jq .device_id
+
+  9. Run a query on a log archive, 16 objects at a time, and tell which object each record comes from.
+     {{.Prompt}} {{.HelpName}} --recursive --parallel 16 --source-column --output-format json \
+           --query "select * from S3Object s where s.status = 500" myminio/logs/2021/
+
+  10. Run a query on all the Parquet objects of a table, whatever their names.
+     {{.Prompt}} {{.HelpName}} --recursive --parquet-input --output-format csv \
+           --query "select s.id, s.total from S3Object s" myminio/lake/orders/
 `,
 }
 
@@ -250,8 +292,12 @@ func getInputSerializationOpts(ctx *cli.Context) map[string]map[string]string {
 
 	csvType := ctx.IsSet("csv-input")
 	jsonType := ctx.IsSet("json-input")
-	if csvType && jsonType {
-		fatalIf(errInvalidArgument(), "Only one of --csv-input or --json-input can be specified as input serialization option")
+	parquetType := ctx.Bool("parquet-input")
+	if csvType && jsonType || parquetType && (csvType || jsonType) {
+		fatalIf(errInvalidArgument(), "Only one of --csv-input, --json-input or --parquet-input can be specified as input serialization option")
+	}
+	if parquetType && ctx.IsSet("compression") && !strings.EqualFold(ctx.String("compression"), string(minio.SelectCompressionNONE)) {
+		fatalIf(errInvalidArgument(), "--compression cannot be specified with --parquet-input, Parquet objects are compressed internally")
 	}
 
 	if icsv != "" {
@@ -266,6 +312,10 @@ func getInputSerializationOpts(ctx *cli.Context) map[string]map[string]string {
 		fatalIf(err, "Invalid serialization option(s) specified for --json-input flag")
 		m["json"] = kv
 	}
+	if parquetType {
+		// Parquet input has no options.
+		m["parquet"] = map[string]string{}
+	}
 
 	return m
 }
@@ -351,7 +401,8 @@ func getCSVOutputHeaders(ctx *cli.Context, url string, encKeyDB map[string][]pre
 	}
 
 	hdrStr := ctx.String("csv-output-header")
-	if hdrStr == "" && isSelectAll(query) {
+	isParquet := ctx.Bool("parquet-input") || strings.HasSuffix(url, ".parquet")
+	if hdrStr == "" && isSelectAll(query) && !isParquet {
 		// attempt to get the first line of csv as header
 		if hdrs, err := getCSVHeader(url, encKeyDB); err == nil {
 			return hdrs
@@ -362,9 +413,14 @@ func getCSVOutputHeaders(ctx *cli.Context, url string, encKeyDB map[string][]pre
 }
 
 // get the Select options for sql select API
-func getSQLOpts(ctx *cli.Context, csvHdrs []string) (s SelectObjectOpts) {
+func getSQLOpts(ctx *cli.Context, csvHdrs []string, output *sqlOutput) (s SelectObjectOpts) {
 	is := getInputSerializationOpts(ctx)
-	os := getOutputSerializationOpts(ctx, csvHdrs)
+	var os map[string]map[string]string
+	if output.format != "" {
+		os = output.serializationOpts()
+	} else {
+		os = getOutputSerializationOpts(ctx, csvHdrs)
+	}
 
 	return SelectObjectOpts{
 		InputSerOpts:    is,
@@ -383,7 +439,7 @@ func isCSVOrJSON(inOpts map[string]map[string]string) bool {
 	return false
 }
 
-func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, csvHdrs []string, writeHdr bool) *probe.Error {
+func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair, selOpts SelectObjectOpts, output *sqlOutput) *probe.Error {
 	ctx, cancelSelect := context.WithCancel(globalContext)
 	defer cancelSelect()
 
@@ -404,12 +460,28 @@ func sqlSelect(targetURL, expression string, encKeyDB map[string][]prefixSSEPair
 	}
 	defer outputer.Close()
 
-	// write csv header to stdout
-	if len(csvHdrs) > 0 && writeHdr {
-		fmt.Println(strings.Join(csvHdrs, ","))
+	raw := selectObjectOutputOpts(selOpts, selectObjectInputOpts(selOpts, targetURL))
+	return output.copy(outputer, targetURL, raw)
+}
+
+// isSQLObject returns true if the object listed can be queried, either
+// from its content type or because it is a Parquet object. With
+// --parquet-input, all the objects are, whatever their names, except the
+// empty ones, like the '_SUCCESS' markers of Spark.
+func isSQLObject(content *ClientContent, parquetInput bool) bool {
+	if parquetInput {
+		return content.Size > 0
 	}
-	_, e := io.Copy(os.Stdout, outputer)
-	return probe.NewError(e)
+	if strings.HasSuffix(content.URL.Path, ".parquet") {
+		return true
+	}
+	contentType := mimedb.TypeByExtension(filepath.Ext(content.URL.Path))
+	for _, cTypeSuffix := range supportedContentTypes {
+		if strings.Contains(contentType, cTypeSuffix) {
+			return true
+		}
+	}
+	return false
 }
 
 func validateOpts(selOpts SelectObjectOpts, url string) {
@@ -420,10 +492,11 @@ func validateOpts(selOpts SelectObjectOpts, url string) {
 }
 
 // validate args and optionally fetch the csv header of query object
-func getAndValidateArgs(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair, url string) (query string, csvHdrs []string, selOpts SelectObjectOpts) {
+func getAndValidateArgs(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair, url string, output *sqlOutput) (query string, csvHdrs []string, selOpts SelectObjectOpts) {
 	query = ctx.String("query")
 	csvHdrs = getCSVOutputHeaders(ctx, url, encKeyDB, query)
-	selOpts = getSQLOpts(ctx, csvHdrs)
+	output.header = csvHdrs
+	selOpts = getSQLOpts(ctx, csvHdrs, output)
 	validateOpts(selOpts, url)
 	return
 }
@@ -431,7 +504,10 @@ func getAndValidateArgs(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair, u
 // check sql input arguments.
 func checkSQLSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 {
-		cli.ShowCommandHelpAndExit(ctx, "sql", 1) // last argument is exit code.
+		cli.ShowCommandHelpAndExit(ctx, "sql", globalInvalidArgsExitStatus) // last argument is exit code.
+	}
+	if ctx.Int("parallel") < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("parallel")), "--parallel must be at least 1.")
 	}
 }
 
@@ -451,19 +527,41 @@ func mainSQL(cliCtx *cli.Context) error {
 
 	// validate sql input arguments.
 	checkSQLSyntax(cliCtx)
+	output := newSQLOutput(cliCtx)
+
+	// The query options are validated against the first object.
+	prepared := false
+	prepare := func(url string) {
+		if prepared {
+			return
+		}
+		query, csvHdrs, selOpts = getAndValidateArgs(cliCtx, encKeyDB, url, output)
+		output.writeHeader(csvHdrs)
+		prepared = true
+	}
+
+	// Objects are queried concurrently by --parallel workers.
+	objectsCh := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < cliCtx.Int("parallel"); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range objectsCh {
+				errorIf(sqlSelect(url, query, encKeyDB, selOpts, output).Trace(url), "Unable to run sql")
+			}
+		}()
+	}
+
 	// extract URLs.
 	URLs := cliCtx.Args()
-	writeHdr := true
 	for _, url := range URLs {
 		if _, targetContent, err := url2Stat(ctx, url, "", false, encKeyDB, time.Time{}); err != nil {
 			errorIf(err.Trace(url), "Unable to run sql for "+url+".")
 			continue
 		} else if !targetContent.Type.IsDir() {
-			if writeHdr {
-				query, csvHdrs, selOpts = getAndValidateArgs(cliCtx, encKeyDB, url)
-			}
-			errorIf(sqlSelect(url, query, encKeyDB, selOpts, csvHdrs, writeHdr).Trace(url), "Unable to run sql")
-			writeHdr = false
+			prepare(url)
+			objectsCh <- url
 			continue
 		}
 		targetAlias, targetURL, _ := mustExpandAlias(url)
@@ -478,19 +576,14 @@ func mainSQL(cliCtx *cli.Context) error {
 				errorIf(content.Err.Trace(url), "Unable to list on target `"+url+"`.")
 				continue
 			}
-			if writeHdr {
-				query, csvHdrs, selOpts = getAndValidateArgs(cliCtx, encKeyDB, targetAlias+content.URL.Path)
-			}
-			contentType := mimedb.TypeByExtension(filepath.Ext(content.URL.Path))
-			for _, cTypeSuffix := range supportedContentTypes {
-				if strings.Contains(contentType, cTypeSuffix) {
-					errorIf(sqlSelect(targetAlias+content.URL.Path, query,
-						encKeyDB, selOpts, csvHdrs, writeHdr).Trace(content.URL.String()), "Unable to run sql")
-				}
-				writeHdr = false
+			prepare(targetAlias + content.URL.Path)
+			if isSQLObject(content, cliCtx.Bool("parquet-input")) {
+				objectsCh <- targetAlias + content.URL.Path
 			}
 		}
 	}
+	close(objectsCh)
+	wg.Wait()
 
 	// Done.
 	return nil
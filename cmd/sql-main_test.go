@@ -138,3 +138,25 @@ func TestParseSerializationOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestIsSQLObject(t *testing.T) {
+	testCases := []struct {
+		path         string
+		size         int64
+		parquetInput bool
+		expected     bool
+	}{
+		{"/logs/2021/app.csv", 10, false, true},
+		{"/lake/orders/part-0.parquet", 10, false, true},
+		{"/logs/2021/app.txt", 10, false, false},
+		{"/lake/orders/part-00000-c000", 10, false, false},
+		{"/lake/orders/part-00000-c000", 10, true, true},
+		{"/lake/orders/_SUCCESS", 0, true, false},
+	}
+	for i, testCase := range testCases {
+		content := &ClientContent{URL: *newClientURL(testCase.path), Size: testCase.size}
+		if got := isSQLObject(content, testCase.parquetInput); got != testCase.expected {
+			t.Errorf("Test %d: expected %v for %q, got %v", i+1, testCase.expected, testCase.path, got)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+// Name of the column added by --source-column.
+const sqlSourceColumn = "_source"
+
+// sqlOutput writes the records returned by S3 Select. With
+// --output-format, records are always selected as JSON, which names the
+// columns, and converted locally for csv and tsv. Records of objects
+// queried concurrently are merged, a whole record at a time.
+type sqlOutput struct {
+	format     string // csv, json or tsv, empty for the output of S3 Select.
+	comma      rune
+	header     []string // Column names given by --csv-output-header.
+	noHeader   bool
+	hdrWritten bool
+
+	// source adds the name of the object as first column of the records.
+	source bool
+	// parallel is set when several objects are queried concurrently.
+	parallel bool
+
+	mutex sync.Mutex
+	w     io.Writer
+	cw    *csv.Writer
+}
+
+// newSQLOutput parses the --output-format and --source-column flags.
+func newSQLOutput(ctx *cli.Context) *sqlOutput {
+	o := &sqlOutput{
+		source:   ctx.Bool("source-column"),
+		parallel: ctx.Int("parallel") > 1,
+		w:        os.Stdout,
+	}
+	if !ctx.IsSet("output-format") {
+		if ctx.IsSet("output-delimiter") || ctx.Bool("no-output-header") {
+			fatalIf(errInvalidArgument(), "--output-delimiter and --no-output-header require --output-format")
+		}
+		return o
+	}
+	if ctx.IsSet("csv-output") || ctx.IsSet("json-output") || globalJSON {
+		fatalIf(errInvalidArgument(), "--output-format cannot be specified with --csv-output, --json-output or --json")
+	}
+
+	o.format = strings.ToLower(ctx.String("output-format"))
+	o.noHeader = ctx.Bool("no-output-header")
+	switch o.format {
+	case "csv":
+		o.comma = ','
+	case "tsv":
+		o.comma = '\t'
+	case "json":
+		if ctx.IsSet("output-delimiter") || o.noHeader || ctx.IsSet("csv-output-header") {
+			fatalIf(errInvalidArgument(), "--output-delimiter, --no-output-header and --csv-output-header cannot be specified with --output-format json")
+		}
+		return o
+	default:
+		fatalIf(errInvalidArgument().Trace(o.format), "Invalid --output-format, expected csv, json or tsv")
+	}
+
+	if ctx.IsSet("output-delimiter") {
+		delimiter := strings.NewReplacer(`\t`, "\t").Replace(ctx.String("output-delimiter"))
+		comma, size := utf8.DecodeRuneInString(delimiter)
+		if size == 0 || size != len(delimiter) || comma == '"' || comma == '\r' || comma == '\n' || comma == utf8.RuneError {
+			fatalIf(errInvalidArgument().Trace(delimiter), "--output-delimiter must be a single character other than a quote or a newline")
+		}
+		o.comma = comma
+	}
+	o.cw = csv.NewWriter(o.w)
+	o.cw.Comma = o.comma
+	return o
+}
+
+// serializationOpts returns the output serialization of the records
+// selected with --output-format.
+func (o *sqlOutput) serializationOpts() map[string]map[string]string {
+	return map[string]map[string]string{
+		"json": {recordDelimiterType: "\n"},
+	}
+}
+
+// writeHeader writes the header given by --csv-output-header before the
+// raw CSV output of S3 Select.
+func (o *sqlOutput) writeHeader(csvHdrs []string) {
+	if o.format != "" || len(csvHdrs) == 0 {
+		return
+	}
+	if o.source {
+		csvHdrs = append([]string{sqlSourceColumn}, csvHdrs...)
+	}
+	fmt.Fprintln(o.w, strings.Join(csvHdrs, ","))
+}
+
+// copy writes the records of the object read from r, raw is the output
+// serialization of the object when --output-format is not set.
+func (o *sqlOutput) copy(r io.Reader, object string, raw minio.SelectObjectOutputSerialization) *probe.Error {
+	var e error
+	switch o.format {
+	case "csv", "tsv":
+		e = o.copyCSV(r, object)
+	case "json":
+		e = o.copyRecords(r, "\n", func(record string) string {
+			return sqlJSONWithSource(record, object)
+		})
+	default:
+		if raw.JSON != nil {
+			e = o.copyRecords(r, raw.JSON.RecordDelimiter, func(record string) string {
+				return sqlJSONWithSource(record, object)
+			})
+		} else {
+			fieldDelimiter, quote := defaultFieldDelimiter, `"`
+			recordDelimiter := defaultRecordDelimiter
+			if raw.CSV != nil {
+				if raw.CSV.FieldDelimiter != "" {
+					fieldDelimiter = raw.CSV.FieldDelimiter
+				}
+				if raw.CSV.QuoteCharacter != "" {
+					quote = raw.CSV.QuoteCharacter
+				}
+				if raw.CSV.RecordDelimiter != "" {
+					recordDelimiter = raw.CSV.RecordDelimiter
+				}
+			}
+			source := sqlCSVQuote(object, fieldDelimiter, quote) + fieldDelimiter
+			e = o.copyRecords(r, recordDelimiter, func(record string) string {
+				return source + record
+			})
+		}
+	}
+	return probe.NewError(e)
+}
+
+// copyRecords copies the records delimited by delimiter, adding the
+// source column with addSource if requested.
+func (o *sqlOutput) copyRecords(r io.Reader, delimiter string, addSource func(string) string) error {
+	if !o.source && !o.parallel {
+		_, e := io.Copy(o.w, r)
+		return e
+	}
+	if delimiter == "" {
+		delimiter = defaultRecordDelimiter
+	}
+	br := bufio.NewReader(r)
+	for {
+		record, e := readSQLRecord(br, delimiter)
+		if record != "" {
+			if o.source {
+				record = strings.TrimSuffix(record, delimiter)
+				record = addSource(record) + delimiter
+			}
+			o.mutex.Lock()
+			_, we := io.WriteString(o.w, record)
+			o.mutex.Unlock()
+			if we != nil {
+				return we
+			}
+		}
+		if e == io.EOF {
+			return nil
+		}
+		if e != nil {
+			return e
+		}
+	}
+}
+
+// copyCSV converts the JSON records read from r to --output-format csv
+// or tsv.
+func (o *sqlOutput) copyCSV(r io.Reader, object string) error {
+	dec := json.NewDecoder(r)
+	for {
+		columns, values, e := decodeSQLRecord(dec)
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return e
+		}
+		if o.source {
+			columns = append([]string{sqlSourceColumn}, columns...)
+			values = append([]string{object}, values...)
+		}
+
+		o.mutex.Lock()
+		if !o.noHeader && !o.hdrWritten {
+			header := o.header
+			if len(header) == 0 {
+				header = columns
+			} else if o.source {
+				header = append([]string{sqlSourceColumn}, header...)
+			}
+			o.cw.Write(header)
+			o.hdrWritten = true
+		}
+		e = o.cw.Write(values)
+		o.mutex.Unlock()
+		if e != nil {
+			return e
+		}
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.cw.Flush()
+	return o.cw.Error()
+}
+
+// readSQLRecord reads a record up to and including the delimiter, the
+// last record may not be delimited.
+func readSQLRecord(br *bufio.Reader, delimiter string) (string, error) {
+	var record strings.Builder
+	last := delimiter[len(delimiter)-1]
+	for {
+		s, e := br.ReadString(last)
+		record.WriteString(s)
+		if e != nil || strings.HasSuffix(record.String(), delimiter) {
+			return record.String(), e
+		}
+	}
+}
+
+// sqlJSONWithSource adds the source column to a JSON record.
+func sqlJSONWithSource(record, object string) string {
+	trimmed := strings.TrimSpace(record)
+	if !strings.HasPrefix(trimmed, "{") {
+		return record
+	}
+	source, _ := json.Marshal(object)
+	fields := strings.TrimSpace(trimmed[1:])
+	if !strings.HasPrefix(fields, "}") {
+		fields = "," + fields
+	}
+	return `{"` + sqlSourceColumn + `":` + string(source) + fields
+}
+
+// sqlCSVQuote quotes a CSV field if it contains the field delimiter, the
+// quote character or a newline.
+func sqlCSVQuote(field, fieldDelimiter, quote string) string {
+	if !strings.Contains(field, fieldDelimiter) && !strings.Contains(field, quote) && !strings.ContainsAny(field, "\r\n") {
+		return field
+	}
+	return quote + strings.Replace(field, quote, quote+quote, -1) + quote
+}
+
+// decodeSQLRecord decodes the next JSON record, and returns the names
+// and values of its columns in order. Nested values are returned as
+// JSON, null values as empty strings.
+func decodeSQLRecord(dec *json.Decoder) (columns, values []string, e error) {
+	t, e := dec.Token()
+	if e != nil {
+		return nil, nil, e
+	}
+	if delim, ok := t.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("unexpected %v, expected a JSON record", t)
+	}
+	for dec.More() {
+		t, e = dec.Token()
+		if e != nil {
+			return nil, nil, e
+		}
+		column, ok := t.(string)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected %v, expected a column name", t)
+		}
+		var raw json.RawMessage
+		if e = dec.Decode(&raw); e != nil {
+			return nil, nil, e
+		}
+		var value string
+		switch {
+		case bytes.Equal(raw, []byte("null")):
+		case len(raw) > 0 && raw[0] == '"':
+			if e = json.Unmarshal(raw, &value); e != nil {
+				return nil, nil, e
+			}
+		default:
+			value = string(raw)
+		}
+		columns = append(columns, column)
+		values = append(values, value)
+	}
+	// Closing brace of the record.
+	if _, e = dec.Token(); e != nil {
+		return nil, nil, e
+	}
+	return columns, values, nil
+}
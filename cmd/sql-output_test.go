@@ -0,0 +1,119 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// newTestSQLOutput returns an output of the format written to buf.
+func newTestSQLOutput(format string, comma rune, buf *bytes.Buffer) *sqlOutput {
+	o := &sqlOutput{format: format, comma: comma, w: buf}
+	if format == "csv" || format == "tsv" {
+		o.cw = csv.NewWriter(buf)
+		o.cw.Comma = comma
+	}
+	return o
+}
+
+func TestSQLOutputCopy(t *testing.T) {
+	records := `{"id":"1","name":"a, b","tags":["x","y"],"size":10}
+{"id":"2","name":"c","tags":null,"size":2.5}
+`
+	testCases := []struct {
+		format   string
+		comma    rune
+		noHeader bool
+		header   []string
+		source   bool
+		expected string
+	}{
+		{"json", 0, false, nil, false, records},
+		{"json", 0, false, nil, true, `{"_source":"myminio/logs/a.json","id":"1","name":"a, b","tags":["x","y"],"size":10}
+{"_source":"myminio/logs/a.json","id":"2","name":"c","tags":null,"size":2.5}
+`},
+		{"csv", ',', false, nil, false, "id,name,tags,size\n1,\"a, b\",\"[\"\"x\"\",\"\"y\"\"]\",10\n2,c,,2.5\n"},
+		{"tsv", '\t', false, nil, false, "id\tname\ttags\tsize\n1\ta, b\t\"[\"\"x\"\",\"\"y\"\"]\"\t10\n2\tc\t\t2.5\n"},
+		{"csv", ';', true, nil, false, "1;a, b;\"[\"\"x\"\",\"\"y\"\"]\";10\n2;c;;2.5\n"},
+		{"csv", ',', false, []string{"ID", "NAME", "TAGS", "SIZE"}, false, "ID,NAME,TAGS,SIZE\n1,\"a, b\",\"[\"\"x\"\",\"\"y\"\"]\",10\n2,c,,2.5\n"},
+		{"csv", ',', false, nil, true, "_source,id,name,tags,size\nmyminio/logs/a.json,1,\"a, b\",\"[\"\"x\"\",\"\"y\"\"]\",10\nmyminio/logs/a.json,2,c,,2.5\n"},
+	}
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		output := newTestSQLOutput(testCase.format, testCase.comma, &buf)
+		output.noHeader = testCase.noHeader
+		output.header = testCase.header
+		output.source = testCase.source
+		if err := output.copy(strings.NewReader(records), "myminio/logs/a.json", minio.SelectObjectOutputSerialization{}); err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if buf.String() != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, buf.String())
+		}
+	}
+
+	// The header is only written once, for the first object.
+	var buf bytes.Buffer
+	output := newTestSQLOutput("csv", ',', &buf)
+	for i := 0; i < 2; i++ {
+		if err := output.copy(strings.NewReader(`{"id":"1"}`), "myminio/logs/a.json", minio.SelectObjectOutputSerialization{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if buf.String() != "id\n1\n1\n" {
+		t.Errorf("expected a single header, got %q", buf.String())
+	}
+
+	if err := output.copy(strings.NewReader(`["1"]`), "myminio/logs/a.json", minio.SelectObjectOutputSerialization{}); err == nil {
+		t.Error("expected an error for a record which is not a JSON object")
+	}
+}
+
+func TestSQLOutputRawSource(t *testing.T) {
+	csvOutput := minio.CSVOutputOptions{}
+	csvOutput.SetRecordDelimiter("\r\n")
+	csvOutput.SetFieldDelimiter(";")
+	jsonOutput := minio.JSONOutputOptions{}
+	jsonOutput.SetRecordDelimiter("\n")
+
+	testCases := []struct {
+		raw      minio.SelectObjectOutputSerialization
+		object   string
+		records  string
+		expected string
+	}{
+		{minio.SelectObjectOutputSerialization{}, "myminio/logs/a.csv", "1,a\n2,b", "myminio/logs/a.csv,1,a\nmyminio/logs/a.csv,2,b\n"},
+		{minio.SelectObjectOutputSerialization{CSV: &csvOutput}, "myminio/logs/a;b.csv", "1;a\r\n2;b\r\n", "\"myminio/logs/a;b.csv\";1;a\r\n\"myminio/logs/a;b.csv\";2;b\r\n"},
+		{minio.SelectObjectOutputSerialization{JSON: &jsonOutput}, "myminio/logs/a.json", "{\"id\":1}\n{}\n", "{\"_source\":\"myminio/logs/a.json\",\"id\":1}\n{\"_source\":\"myminio/logs/a.json\"}\n"},
+	}
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		output := newTestSQLOutput("", 0, &buf)
+		output.source = true
+		if err := output.copy(strings.NewReader(testCase.records), testCase.object, testCase.raw); err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if buf.String() != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, buf.String())
+		}
+	}
+}
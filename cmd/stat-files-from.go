@@ -0,0 +1,97 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Number of objects stat'ed concurrently with --files-from.
+const statFilesFromWorkers = 16
+
+// statFilesFrom stats the objects whose keys, relative to targetURL,
+// are read one per line from filesFrom, or from the standard input
+// when filesFrom is "-".
+func statFilesFrom(ctx context.Context, targetURL, filesFrom string, timeRef time.Time, withAttributes bool, encKeyDB map[string][]prefixSSEPair) error {
+	var reader io.Reader = os.Stdin
+	if filesFrom != "-" {
+		f, e := os.Open(filesFrom)
+		fatalIf(probe.NewError(e).Trace(filesFrom), "Unable to open the list of keys.")
+		defer f.Close()
+		reader = f
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		statOK = true
+	)
+	keyCh := make(chan string)
+	for i := 0; i < statFilesFromWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keyCh {
+				url := urlJoinPath(targetURL, key)
+				clnt, content, err := url2Stat(ctx, url, "", true, encKeyDB, timeRef)
+				if err == nil && withAttributes && !content.Type.IsDir() {
+					err = statAttributes(ctx, clnt, content, url, encKeyDB)
+				}
+				if err != nil {
+					errorIf(err.Trace(url), "Unable to stat `%s`.", key)
+					mu.Lock()
+					statOK = false
+					mu.Unlock()
+					continue
+				}
+				stat := parseStat(content)
+				stat.Key = key
+				stat.singleObject = true
+				stat.compact = true
+				printMsg(stat)
+			}
+		}()
+	}
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		key := strings.TrimSuffix(scanner.Text(), "\r")
+		if key == "" {
+			continue
+		}
+		select {
+		case keyCh <- key:
+		case <-ctx.Done():
+		}
+	}
+	close(keyCh)
+	wg.Wait()
+	fatalIf(probe.NewError(scanner.Err()), "Unable to read the list of keys.")
+
+	if !statOK {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
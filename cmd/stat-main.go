@@ -45,6 +45,14 @@ var (
 			Name:  "recursive, r",
 			Usage: "stat all objects recursively",
 		},
+		cli.StringFlag{
+			Name:  "files-from",
+			Usage: "stat the objects whose keys, relative to TARGET, are read one per line from a file, '-' for STDIN",
+		},
+		cli.BoolFlag{
+			Name:  "attributes",
+			Usage: "show the storage class, checksums and parts of objects, from the GetObjectAttributes API",
+		},
 	}
 )
 
@@ -90,13 +98,19 @@ EXAMPLES:
 
   7. Stat all objects versions recursively created before 1st January 2020.
      {{.Prompt}} {{.HelpName}} --versions --rewind 2020.01.01T00:00 s3/personal-docs/
+
+  8. Stat all objects listed in "keys.txt" of mybucket, one JSON object per line.
+     {{.Prompt}} {{.HelpName}} --json --files-from keys.txt s3/mybucket
+
+  9. Show the size and checksum of each part of an object uploaded in parts.
+     {{.Prompt}} {{.HelpName}} --attributes s3/backup/2006-Mar-1/backup.tar.gz
 `,
 }
 
 // parseAndCheckStatSyntax - parse and validate all the passed arguments
 func parseAndCheckStatSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) ([]string, bool, string, time.Time, bool) {
 	if !cliCtx.Args().Present() {
-		cli.ShowCommandHelpAndExit(cliCtx, "stat", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(cliCtx, "stat", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 
 	args := cliCtx.Args()
@@ -123,6 +137,23 @@ func parseAndCheckStatSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB
 		fatalIf(errInvalidArgument().Trace(args...), "You cannot specify --version-id with either --rewind, --versions or --recursive.")
 	}
 
+	if cliCtx.String("files-from") != "" {
+		if len(args) != 1 {
+			fatalIf(errInvalidArgument().Trace(args...), "You need to specify exactly one TARGET with --files-from.")
+		}
+		if versionID != "" || recursive || withVersions {
+			fatalIf(errInvalidArgument().Trace(args...), "You cannot specify --files-from with either --version-id, --versions or --recursive.")
+		}
+	}
+
+	if cliCtx.Bool("attributes") {
+		for _, url := range URLs {
+			if _, _, hostCfg, _ := expandAlias(url); hostCfg == nil {
+				fatalIf(errInvalidArgument().Trace(url), "--attributes is not supported for the local path `%s`.", url)
+			}
+		}
+	}
+
 	for _, url := range URLs {
 		_, _, err := url2Stat(ctx, url, versionID, false, encKeyDB, rewind)
 		if err != nil && !isURLPrefixExists(url, isIncomplete) {
@@ -161,9 +192,13 @@ func mainStat(cliCtx *cli.Context) error {
 		args = []string{"."}
 	}
 
+	if filesFrom := cliCtx.String("files-from"); filesFrom != "" {
+		return statFilesFrom(ctx, args[0], filesFrom, rewind, cliCtx.Bool("attributes"), encKeyDB)
+	}
+
 	var cErr error
 	for _, targetURL := range args {
-		contents, bstats, err := statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, encKeyDB)
+		contents, bstats, err := statURL(ctx, targetURL, versionID, rewind, withVersions, false, isRecursive, cliCtx.Bool("attributes"), encKeyDB)
 		if err != nil {
 			fatalIf(err, "Unable to stat `"+targetURL+"`.")
 		}
@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -51,7 +52,11 @@ type statMessage struct {
 	Metadata          map[string]string `json:"metadata"`
 	VersionID         string            `json:"versionID,omitempty"`
 	DeleteMarker      bool              `json:"deleteMarker,omitempty"`
+	Attributes        *ObjectAttributes `json:"attributes,omitempty"`
 	singleObject      bool
+
+	// Print the JSON message on a single line.
+	compact bool
 }
 
 func (stat statMessage) String() (msg string) {
@@ -114,6 +119,25 @@ func (stat statMessage) String() (msg string) {
 	if stat.ReplicationStatus != "" {
 		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Replication Status", stat.ReplicationStatus))
 	}
+	if attrs := stat.Attributes; attrs != nil {
+		if stat.ReplicationStatus != "" {
+			msgBuilder.WriteString("\n")
+		}
+		msgBuilder.WriteString(fmt.Sprintf("%-10s:", "Attributes") + "\n")
+		if attrs.StorageClass != "" {
+			msgBuilder.WriteString(fmt.Sprintf("  %-8s: %s ", "Class", attrs.StorageClass) + "\n")
+		}
+		if checksum := attrs.Checksum.String(); checksum != "" {
+			msgBuilder.WriteString(fmt.Sprintf("  %-8s: %s ", "Checksum", checksum) + "\n")
+		}
+		if attrs.PartsCount > 0 {
+			msgBuilder.WriteString(fmt.Sprintf("  %-8s: %d ", "Parts", attrs.PartsCount) + "\n")
+			width := len(strconv.Itoa(attrs.PartsCount))
+			for _, part := range attrs.Parts {
+				msgBuilder.WriteString(fmt.Sprintf("    %*d: %-10s %s", width, part.Number, humanize.IBytes(uint64(part.Size)), part.ObjectChecksum.String()) + "\n")
+			}
+		}
+	}
 
 	return msgBuilder.String()
 }
@@ -121,6 +145,11 @@ func (stat statMessage) String() (msg string) {
 // JSON jsonified content message.
 func (stat statMessage) JSON() string {
 	stat.Status = "success"
+	if stat.compact {
+		jsonMessageBytes, e := json.Marshal(stat)
+		fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+		return string(jsonMessageBytes)
+	}
 	jsonMessageBytes, e := json.MarshalIndent(stat, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
@@ -148,6 +177,7 @@ func parseStat(c *ClientContent) statMessage {
 	content.Expiration = c.Expiration
 	content.ExpirationRuleID = c.ExpirationRuleID
 	content.ReplicationStatus = c.ReplicationStatus
+	content.Attributes = c.Attributes
 	return content
 }
 
@@ -159,7 +189,7 @@ func getStandardizedURL(targetURL string) string {
 // statURL - uses combination of GET listing and HEAD to fetch information of one or more objects
 // HEAD can fail with 400 with an SSE-C encrypted object but we still return information gathered
 // from GET listing.
-func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive bool, encKeyDB map[string][]prefixSSEPair) ([]*ClientContent, []*BucketInfo, *probe.Error) {
+func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time, includeOlderVersions, isIncomplete, isRecursive, withAttributes bool, encKeyDB map[string][]prefixSSEPair) ([]*ClientContent, []*BucketInfo, *probe.Error) {
 	var stats []*ClientContent
 	var bucketStats []*BucketInfo
 	var clnt Client
@@ -243,6 +273,13 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 			}
 		}
 
+		if withAttributes && !stat.Type.IsDir() {
+			if err = statAttributes(ctx, clnt, stat, url, encKeyDB); err != nil {
+				errorIf(err.Trace(url), "Unable to get the attributes of `%s`.", url)
+				cErr = exitStatus(globalErrorExitStatus)
+			}
+		}
+
 		// Convert any os specific delimiters to "/".
 		contentURL := filepath.ToSlash(stat.URL.Path)
 		prefixPath = filepath.ToSlash(prefixPath)
@@ -255,6 +292,38 @@ func statURL(ctx context.Context, targetURL, versionID string, timeRef time.Time
 	return stats, bucketStats, probe.NewError(cErr)
 }
 
+// statAttributes gets the attributes of an object with GetObjectAttributes,
+// the customer provided key of an encrypted object is required.
+func statAttributes(ctx context.Context, clnt Client, content *ClientContent, url string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	alias, _, _ := mustExpandAlias(url)
+	attrs, err := clnt.GetObjectAttributes(ctx, content.VersionID, getSSE(url, encKeyDB[alias]))
+	if err != nil {
+		return err
+	}
+	content.Attributes = attrs
+	return nil
+}
+
+// String returns the checksums of an object or of a part, with their
+// algorithm, e.g. 'CRC32C:yZRlqg==-3 (COMPOSITE)'.
+func (c ObjectChecksum) String() string {
+	var sums []string
+	for _, sum := range []struct{ algorithm, value string }{
+		{"CRC32", c.CRC32},
+		{"CRC32C", c.CRC32C},
+		{"SHA1", c.SHA1},
+		{"SHA256", c.SHA256},
+	} {
+		if sum.value != "" {
+			sums = append(sums, sum.algorithm+":"+sum.value)
+		}
+	}
+	if len(sums) > 0 && c.Type != "" {
+		return strings.Join(sums, " ") + " (" + c.Type + ")"
+	}
+	return strings.Join(sums, " ")
+}
+
 // BucketInfo holds info about a bucket
 type BucketInfo struct {
 	URL        ClientURL   `json:"-"`
@@ -102,7 +102,7 @@ func (t tagSetMessage) JSON() string {
 
 func parseSetTagSyntax(ctx *cli.Context) (targetURL, versionID string, timeRef time.Time, withVersions bool, tags string) {
 	if len(ctx.Args()) != 2 || ctx.Args().Get(1) == "" {
-		cli.ShowCommandHelpAndExit(ctx, "set", globalErrorExitStatus)
+		cli.ShowCommandHelpAndExit(ctx, "set", globalInvalidArgsExitStatus)
 	}
 
 	targetURL = ctx.Args().Get(0)
@@ -0,0 +1,221 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/minio/pkg/console"
+)
+
+// mcEnvTheme selects the color theme, same as --theme.
+const mcEnvTheme = "MC_THEME"
+
+// Color themes, the colors of every message are mapped to the
+// colors of the selected theme when printed.
+const (
+	themeDefault    = "default"
+	themeColorblind = "colorblind"
+	themeMonochrome = "monochrome"
+)
+
+// Selected color theme, empty until set by flag, environment or config.
+var globalTheme string
+
+// isValidTheme - validates a color theme name.
+func isValidTheme(theme string) bool {
+	switch theme {
+	case themeDefault, themeColorblind, themeMonochrome:
+		return true
+	}
+	return false
+}
+
+// isNoColorEnv - returns true when colors are disabled following
+// the NO_COLOR convention, https://no-color.org.
+func isNoColorEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// colorblindColors avoids telling apart red and green, success is
+// shown in blue and failures in yellow.
+var colorblindColors = map[int]int{
+	int(color.FgRed):     int(color.FgYellow),
+	int(color.FgHiRed):   int(color.FgHiYellow),
+	int(color.FgGreen):   int(color.FgBlue),
+	int(color.FgHiGreen): int(color.FgHiBlue),
+	int(color.FgBlue):    int(color.FgCyan),
+	int(color.FgHiBlue):  int(color.FgHiCyan),
+	int(color.BgRed):     int(color.BgYellow),
+	int(color.BgHiRed):   int(color.BgHiYellow),
+	int(color.BgGreen):   int(color.BgBlue),
+	int(color.BgHiGreen): int(color.BgHiBlue),
+	int(color.BgBlue):    int(color.BgCyan),
+	int(color.BgHiBlue):  int(color.BgHiCyan),
+}
+
+// isColorParam - returns true for the SGR parameters selecting a
+// foreground or background color.
+func isColorParam(p int) bool {
+	return (p >= 30 && p <= 39) || (p >= 40 && p <= 49) || (p >= 90 && p <= 97) || (p >= 100 && p <= 107)
+}
+
+// themeParams maps the parameters of an SGR escape sequence to the
+// given theme.
+func themeParams(theme string, params []int) []int {
+	mapped := make([]int, 0, len(params))
+	for i := 0; i < len(params); i++ {
+		p := params[i]
+		switch theme {
+		case themeColorblind:
+			if c, ok := colorblindColors[p]; ok {
+				p = c
+			}
+		case themeMonochrome:
+			if p == 38 || p == 48 {
+				// Extended colors, 38;5;N or 38;2;R;G;B
+				if i+1 < len(params) && params[i+1] == 5 {
+					i += 2
+				} else if i+1 < len(params) && params[i+1] == 2 {
+					i += 4
+				}
+				continue
+			}
+			if isColorParam(p) {
+				continue
+			}
+		}
+		mapped = append(mapped, p)
+	}
+	return mapped
+}
+
+// applyTheme rewrites the SGR escape sequences of a text to the
+// given theme, other escape sequences are left untouched.
+func applyTheme(theme string, text []byte) []byte {
+	if theme == "" || theme == themeDefault || !bytes.Contains(text, []byte("\x1b[")) {
+		return text
+	}
+	var out bytes.Buffer
+	for len(text) > 0 {
+		i := bytes.Index(text, []byte("\x1b["))
+		if i < 0 {
+			out.Write(text)
+			break
+		}
+		out.Write(text[:i])
+		text = text[i:]
+
+		end := bytes.IndexFunc(text[2:], func(r rune) bool {
+			return (r < '0' || r > '9') && r != ';'
+		})
+		if end < 0 || text[2+end] != 'm' {
+			out.Write(text[:2])
+			text = text[2:]
+			continue
+		}
+		seq := string(text[2 : 2+end])
+		text = text[2+end+1:]
+
+		if seq == "" || seq == "0" {
+			out.WriteString("\x1b[" + seq + "m")
+			continue
+		}
+		var params []int
+		for _, s := range strings.Split(seq, ";") {
+			p, e := strconv.Atoi(s)
+			if e != nil {
+				p = 0
+			}
+			params = append(params, p)
+		}
+		params = themeParams(theme, params)
+		if len(params) == 0 {
+			continue
+		}
+		strParams := make([]string, len(params))
+		for j, p := range params {
+			strParams[j] = strconv.Itoa(p)
+		}
+		out.WriteString("\x1b[" + strings.Join(strParams, ";") + "m")
+	}
+	return out.Bytes()
+}
+
+// themeWriter applies the selected theme to the colored text
+// written to the console.
+type themeWriter struct {
+	io.Writer
+}
+
+func (w themeWriter) Write(p []byte) (int, error) {
+	if _, e := w.Writer.Write(applyTheme(globalTheme, p)); e != nil {
+		return 0, e
+	}
+	return len(p), nil
+}
+
+// themeColor returns a color of the selected theme.
+func themeColor(attrs ...color.Attribute) *color.Color {
+	params := make([]int, len(attrs))
+	for i, attr := range attrs {
+		params[i] = int(attr)
+	}
+	params = themeParams(globalTheme, params)
+	attrs = make([]color.Attribute, len(params))
+	for i, p := range params {
+		attrs[i] = color.Attribute(p)
+	}
+	return color.New(attrs...)
+}
+
+// setTheme selects the color theme of the console.
+func setTheme(theme string) {
+	theme = strings.ToLower(strings.TrimSpace(theme))
+	if theme == "" || theme == globalTheme {
+		return
+	}
+	if !isValidTheme(theme) {
+		fatalIf(errInvalidArgument().Trace(theme), "Invalid color theme `%s`. Valid options are `[default, colorblind, monochrome]`.", theme)
+	}
+	if globalTheme == "" {
+		color.Output = themeWriter{color.Output}
+	}
+	globalTheme = theme
+
+	// Errors are printed to stderr, set their colors directly.
+	console.SetColor("Debug", themeColor(color.FgWhite, color.Faint, color.Italic))
+	console.SetColor("Fatal", themeColor(color.FgRed, color.Italic, color.Bold))
+	console.SetColor("Error", themeColor(color.FgYellow, color.Italic))
+}
+
+// setThemeFromConfig selects the color theme of the config file,
+// unless set by flag or environment.
+func setThemeFromConfig() {
+	if globalTheme != "" {
+		return
+	}
+	// Unreadable config files are reported by checkConfig.
+	if conf, err := loadMcConfig(); err == nil {
+		setTheme(conf.Theme)
+	}
+}
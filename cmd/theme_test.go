@@ -0,0 +1,44 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestApplyTheme(t *testing.T) {
+	testCases := []struct {
+		theme    string
+		text     string
+		expected string
+	}{
+		{themeDefault, "\x1b[32;1mOK\x1b[0m", "\x1b[32;1mOK\x1b[0m"},
+		{themeColorblind, "\x1b[32;1mOK\x1b[0m", "\x1b[34;1mOK\x1b[0m"},
+		{themeColorblind, "\x1b[31mfailed\x1b[0m \x1b[34mAPI\x1b[0m", "\x1b[33mfailed\x1b[0m \x1b[36mAPI\x1b[0m"},
+		{themeMonochrome, "\x1b[32;1mOK\x1b[0m", "\x1b[1mOK\x1b[0m"},
+		{themeMonochrome, "\x1b[36mname\x1b[0m", "name\x1b[0m"},
+		{themeMonochrome, "\x1b[38;5;208;4mname\x1b[m", "\x1b[4mname\x1b[m"},
+		// Escape sequences other than colors are untouched.
+		{themeMonochrome, "\x1b[2K\rprogress", "\x1b[2K\rprogress"},
+		{themeMonochrome, "plain text", "plain text"},
+	}
+	for i, testCase := range testCases {
+		if got := string(applyTheme(testCase.theme, []byte(testCase.text))); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
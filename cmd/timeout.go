@@ -0,0 +1,206 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	// Maximum time a request may go without sending or receiving
+	// data, set via --request-timeout.
+	globalRequestTimeout time.Duration
+
+	// Maximum time the whole command may take, set via --operation-deadline.
+	globalOperationDeadline time.Duration
+)
+
+// Grace period given to a command to report its own errors once the
+// operation deadline is exceeded, before mc exits on its behalf.
+const operationDeadlineGrace = 5 * time.Second
+
+// requestTimeoutError is returned when a request was idle for longer
+// than --request-timeout.
+type requestTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e requestTimeoutError) Error() string {
+	return fmt.Sprintf("request timed out, no data was transferred for %s (--request-timeout)", e.timeout)
+}
+
+func (e requestTimeoutError) Timeout() bool   { return true }
+func (e requestTimeoutError) Temporary() bool { return false }
+
+// operationDeadlineError is returned when the command did not complete
+// within --operation-deadline.
+type operationDeadlineError struct {
+	deadline time.Duration
+}
+
+func (e operationDeadlineError) Error() string {
+	return fmt.Sprintf("operation did not complete within %s (--operation-deadline)", e.deadline)
+}
+
+func (e operationDeadlineError) Timeout() bool   { return true }
+func (e operationDeadlineError) Temporary() bool { return false }
+
+// setOperationDeadline bounds the global context by the given deadline,
+// every request and command loop of mc is canceled once it is passed.
+func setOperationDeadline(deadline time.Duration) {
+	if deadline <= 0 || globalOperationDeadline > 0 {
+		return
+	}
+	globalOperationDeadline = deadline
+	globalContext, globalCancel = context.WithTimeout(globalContext, deadline)
+
+	// Commands which do not report the canceled requests themselves
+	// would otherwise exit silently, or not at all.
+	go func() {
+		<-globalContext.Done()
+		if globalContext.Err() != context.DeadlineExceeded {
+			return
+		}
+		time.Sleep(operationDeadlineGrace)
+		checkOperationDeadline()
+	}()
+}
+
+// checkOperationDeadline exits with a timeout error when the operation
+// deadline is exceeded.
+func checkOperationDeadline() {
+	if globalOperationDeadline > 0 && globalContext.Err() == context.DeadlineExceeded {
+		fatalIf(probe.NewError(operationDeadlineError{globalOperationDeadline}),
+			"Unable to complete the command in time.")
+	}
+}
+
+// explainTimeout replaces the generic context errors of requests
+// canceled by the operation deadline with an explicit one.
+func explainTimeout(err *probe.Error) *probe.Error {
+	if err == nil || globalOperationDeadline == 0 {
+		return err
+	}
+	if globalContext.Err() == context.DeadlineExceeded && errors.Is(err.ToGoError(), context.DeadlineExceeded) {
+		err.Cause = operationDeadlineError{globalOperationDeadline}
+	}
+	return err
+}
+
+// idleTimer cancels a request when it was not reset for its duration.
+type idleTimer struct {
+	timeout time.Duration
+	timer   *time.Timer
+	fired   int32
+}
+
+func newIdleTimer(timeout time.Duration, cancel context.CancelFunc) *idleTimer {
+	t := &idleTimer{timeout: timeout}
+	t.timer = time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&t.fired, 1)
+		cancel()
+	})
+	return t
+}
+
+func (t *idleTimer) reset() {
+	if atomic.LoadInt32(&t.fired) == 0 {
+		t.timer.Reset(t.timeout)
+	}
+}
+
+func (t *idleTimer) stop() {
+	t.timer.Stop()
+}
+
+// wrap returns a timeout error in place of the error of a request
+// canceled by the timer.
+func (t *idleTimer) wrap(err error) error {
+	if err != nil && err != io.EOF && atomic.LoadInt32(&t.fired) == 1 {
+		return requestTimeoutError{t.timeout}
+	}
+	return err
+}
+
+// idleBody resets the idle timer of a request for every read of its
+// request or response body.
+type idleBody struct {
+	io.ReadCloser
+	timer  *idleTimer
+	cancel context.CancelFunc
+}
+
+func (b *idleBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.timer.reset()
+	}
+	return n, b.timer.wrap(err)
+}
+
+func (b *idleBody) Close() error {
+	if b.cancel != nil {
+		b.timer.stop()
+		b.cancel()
+	}
+	return b.ReadCloser.Close()
+}
+
+// timeoutTransport cancels the requests which are idle for longer
+// than the request timeout, slow transfers which keep making progress
+// are not interrupted.
+type timeoutTransport struct {
+	transport http.RoundTripper
+	timeout   time.Duration
+}
+
+// newTimeoutTransport applies --request-timeout to the requests of the
+// given transport, if set.
+func newTimeoutTransport(transport http.RoundTripper) http.RoundTripper {
+	if globalRequestTimeout <= 0 {
+		return transport
+	}
+	return &timeoutTransport{transport: transport, timeout: globalRequestTimeout}
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	timer := newIdleTimer(t.timeout, cancel)
+
+	req = req.Clone(ctx)
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = &idleBody{ReadCloser: req.Body, timer: timer}
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		timer.stop()
+		cancel()
+		return nil, timer.wrap(err)
+	}
+	timer.reset()
+	resp.Body = &idleBody{ReadCloser: resp.Body, timer: timer, cancel: cancel}
+	return resp, nil
+}
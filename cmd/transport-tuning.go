@@ -0,0 +1,153 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+)
+
+// transportTuning holds the settings of the connections to the server
+// of an alias, to saturate links with a high latency.
+type transportTuning struct {
+	// Maximum number of connections per host, no limit when zero.
+	MaxConnsPerHost int `json:"maxConnsPerHost,omitempty"`
+	// Maximum number of idle connections kept per host.
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost,omitempty"`
+	// Size in bytes of the send and receive buffers of the sockets,
+	// the system default when zero.
+	TCPBufferSize uint64 `json:"tcpBufferSize,omitempty"`
+	// Set to 'on' to negotiate HTTP/2 with TLS servers.
+	HTTP2 string `json:"http2,omitempty"`
+}
+
+// Connection settings set via command line, override the ones of the
+// alias.
+var globalTransportTuning transportTuning
+
+// Default maximum number of idle connections kept per host.
+const defaultMaxIdleConnsPerHost = 256
+
+// isSet returns true when a setting is set.
+func (t transportTuning) isSet() bool {
+	return t != transportTuning{}
+}
+
+// override returns the settings with the ones set in o.
+func (t transportTuning) override(o transportTuning) transportTuning {
+	if o.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = o.MaxConnsPerHost
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.TCPBufferSize > 0 {
+		t.TCPBufferSize = o.TCPBufferSize
+	}
+	if o.HTTP2 != "" {
+		t.HTTP2 = o.HTTP2
+	}
+	return t
+}
+
+func (t transportTuning) String() string {
+	var settings []string
+	if t.MaxConnsPerHost > 0 {
+		settings = append(settings, fmt.Sprintf("max-conns-per-host=%d", t.MaxConnsPerHost))
+	}
+	if t.MaxIdleConnsPerHost > 0 {
+		settings = append(settings, fmt.Sprintf("max-idle-conns-per-host=%d", t.MaxIdleConnsPerHost))
+	}
+	if t.TCPBufferSize > 0 {
+		settings = append(settings, "tcp-buffer="+strings.Replace(humanize.IBytes(t.TCPBufferSize), " ", "", 1))
+	}
+	if t.HTTP2 != "" {
+		settings = append(settings, "http2="+t.HTTP2)
+	}
+	return strings.Join(settings, " ")
+}
+
+// setTransportTuningFromContext sets the connection settings given via
+// command line.
+func setTransportTuningFromContext(ctx *cli.Context) {
+	intFlag := func(name string) int {
+		value := ctx.Int(name)
+		if !ctx.IsSet(name) && ctx.GlobalIsSet(name) {
+			value = ctx.GlobalInt(name)
+		}
+		if value < 0 {
+			fatalIf(errInvalidArgument(), "Invalid value for --%s, must be positive.", name)
+		}
+		return value
+	}
+	stringFlag := func(name string) string {
+		value := ctx.String(name)
+		if value == "" {
+			value = ctx.GlobalString(name)
+		}
+		return value
+	}
+
+	tuning := transportTuning{
+		MaxConnsPerHost:     intFlag("max-conns-per-host"),
+		MaxIdleConnsPerHost: intFlag("max-idle-conns-per-host"),
+		HTTP2:               strings.ToLower(stringFlag("http2")),
+	}
+	if size := stringFlag("tcp-buffer"); size != "" {
+		bytes, e := humanize.ParseBytes(size)
+		if e != nil || bytes == 0 || bytes > 1<<30 {
+			fatalIf(errInvalidArgument().Trace(size), "Invalid value for --tcp-buffer, must be a size up to 1GiB, e.g. '16MiB'.")
+		}
+		tuning.TCPBufferSize = bytes
+	}
+	switch tuning.HTTP2 {
+	case "", "on", "off":
+	default:
+		fatalIf(errInvalidArgument().Trace(tuning.HTTP2), "Invalid value for --http2, valid options are '[on, off]'.")
+	}
+	globalTransportTuning = globalTransportTuning.override(tuning)
+}
+
+// newTuningDialer returns the dialer of the connections, which sets the
+// size of the socket buffers before connecting, so that the TCP window
+// scale can cover them.
+func newTuningDialer(tuning transportTuning) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 15 * time.Second,
+	}
+	if tuning.TCPBufferSize > 0 {
+		size := int(tuning.TCPBufferSize)
+		dialer.Control = func(network, address string, c syscall.RawConn) error {
+			var e error
+			if ce := c.Control(func(fd uintptr) {
+				e = setSocketBuffers(fd, size)
+			}); ce != nil {
+				return ce
+			}
+			return e
+		}
+	}
+	return dialer.DialContext
+}
@@ -0,0 +1,45 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestTransportTuningOverride(t *testing.T) {
+	alias := transportTuning{MaxConnsPerHost: 16, TCPBufferSize: 4 << 20, HTTP2: "on"}
+	testCases := []struct {
+		flags    transportTuning
+		expected transportTuning
+		str      string
+	}{
+		{transportTuning{}, alias, "max-conns-per-host=16 tcp-buffer=4.0MiB http2=on"},
+		{transportTuning{HTTP2: "off"}, transportTuning{MaxConnsPerHost: 16, TCPBufferSize: 4 << 20, HTTP2: "off"}, "max-conns-per-host=16 tcp-buffer=4.0MiB http2=off"},
+		{
+			transportTuning{MaxConnsPerHost: 64, MaxIdleConnsPerHost: 64, TCPBufferSize: 16 << 20},
+			transportTuning{MaxConnsPerHost: 64, MaxIdleConnsPerHost: 64, TCPBufferSize: 16 << 20, HTTP2: "on"},
+			"max-conns-per-host=64 max-idle-conns-per-host=64 tcp-buffer=16MiB http2=on",
+		},
+	}
+	for i, testCase := range testCases {
+		tuning := alias.override(testCase.flags)
+		if tuning != testCase.expected {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, tuning)
+		}
+		if tuning.String() != testCase.str {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.str, tuning.String())
+		}
+	}
+}
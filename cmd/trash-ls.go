@@ -0,0 +1,130 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var trashLsFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "newer-than",
+		Usage: "list objects removed less than L days, M hours and N minutes ago",
+	},
+}
+
+var trashLsCmd = cli.Command{
+	Name:         "ls",
+	Usage:        "list objects removed from a versioned bucket",
+	Action:       mainTrashLs,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(trashLsFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+  List the objects under TARGET whose latest version is a delete marker, as
+  left by 'mc rm --soft', with the time they were removed and the size of
+  the version 'mc trash restore' brings back.
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+EXAMPLES:
+  1. List the objects removed from a bucket.
+     {{.Prompt}} {{.HelpName}} play/mybucket
+
+  2. List the objects removed under a prefix during the last 2 days.
+     {{.Prompt}} {{.HelpName}} --newer-than 2d play/mybucket/photos/
+`,
+}
+
+// trashLsMessage container for a removed object.
+type trashLsMessage struct {
+	Status    string    `json:"status"`
+	Key       string    `json:"key"`
+	Removed   time.Time `json:"removed"`
+	Size      int64     `json:"size"`
+	VersionID string    `json:"versionId"`
+}
+
+// String colorized removed object.
+func (t trashLsMessage) String() string {
+	message := console.Colorize("Time", fmt.Sprintf("[%s]", t.Removed.Local().Format(printDate)))
+	message += console.Colorize("Size", fmt.Sprintf("%7s", strings.Join(strings.Fields(humanize.IBytes(uint64(t.Size))), "")))
+	return message + " " + console.Colorize("File", t.Key)
+}
+
+// JSON jsonified removed object.
+func (t trashLsMessage) JSON() string {
+	t.Status = "success"
+	msgBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkTrashLsSyntax - validate arguments passed by a user.
+func checkTrashLsSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "ls", globalInvalidArgsExitStatus)
+	}
+}
+
+// mainTrashLs is the entry point for trash ls command.
+func mainTrashLs(cliCtx *cli.Context) error {
+	ctx, cancelTrashLs := context.WithCancel(globalContext)
+	defer cancelTrashLs()
+
+	checkTrashLsSyntax(cliCtx)
+	setTrashColors()
+
+	aliasedURL := cliCtx.Args().Get(0)
+	newerThan := cliCtx.String("newer-than")
+	checkTrashURL(ctx, aliasedURL)
+
+	alias, _ := url2Alias(aliasedURL)
+	clnt, err := newClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize target `"+aliasedURL+"`.")
+
+	err = listTrash(ctx, clnt, true, func(entry trashEntry) *probe.Error {
+		removed := entry.markers[0].Time
+		if isNewer(removed, newerThan) {
+			return nil
+		}
+		printMsg(trashLsMessage{
+			Key:       alias + getKey(entry.version),
+			Removed:   removed,
+			Size:      entry.version.Size,
+			VersionID: entry.version.VersionID,
+		})
+		return nil
+	})
+	fatalIf(err, "Unable to list the objects removed from `"+aliasedURL+"`.")
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var trashSubcommands = []cli.Command{
+	trashLsCmd,
+	trashRestoreCmd,
+}
+
+var trashCmd = cli.Command{
+	Name:            "trash",
+	Usage:           "list and restore objects removed from versioned buckets",
+	Action:          mainTrash,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands:     trashSubcommands,
+}
+
+func mainTrash(ctx *cli.Context) error {
+	commandNotFound(ctx, trashSubcommands)
+	return nil
+}
+
+// trashEntry is an object whose latest version is a delete marker,
+// removing its delete markers restores its last version.
+type trashEntry struct {
+	// Delete markers on top of the last version, latest first.
+	markers []*ClientContent
+	// Last version before the object was removed.
+	version *ClientContent
+}
+
+// trashEntryOf returns the trash entry of the versions of one object,
+// false when the object is not removed or has no version to restore.
+func trashEntryOf(versions []*ClientContent) (trashEntry, bool) {
+	sortObjectVersions(versions)
+	var entry trashEntry
+	for _, version := range versions {
+		if !version.IsDeleteMarker {
+			entry.version = version
+			break
+		}
+		entry.markers = append(entry.markers, version)
+	}
+	return entry, len(entry.markers) > 0 && entry.version != nil
+}
+
+// listTrash calls fn with the removed objects under the URL of clnt,
+// or only the object of the URL when not recursive.
+func listTrash(ctx context.Context, clnt Client, recursive bool, fn func(trashEntry) *probe.Error) *probe.Error {
+	targetPath := filepath.ToSlash(clnt.GetURL().Path)
+	var versions []*ClientContent
+	flush := func() *probe.Error {
+		if len(versions) == 0 {
+			return nil
+		}
+		entry, ok := trashEntryOf(versions)
+		versions = nil
+		if !ok {
+			return nil
+		}
+		return fn(entry)
+	}
+	for content := range clnt.List(ctx, ListOptions{
+		Recursive:         true,
+		WithOlderVersions: true,
+		WithDeleteMarkers: true,
+		ShowDir:           DirNone,
+	}) {
+		if content.Err != nil {
+			return content.Err.Trace(clnt.GetURL().String())
+		}
+		if !recursive && filepath.ToSlash(content.URL.Path) != targetPath {
+			continue
+		}
+		if len(versions) > 0 && versions[0].URL.Path != content.URL.Path {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		versions = append(versions, content)
+	}
+	return flush()
+}
+
+// checkTrashURL exits unless aliasedURL is in a bucket with
+// versioning enabled, the only buckets keeping removed objects.
+func checkTrashURL(ctx context.Context, aliasedURL string) {
+	if !checkIfBucketIsVersioned(ctx, aliasedURL) {
+		fatalIf(errInvalidArgument().Trace(aliasedURL),
+			"`"+aliasedURL+"` is not in a bucket with versioning enabled, removed objects are kept only by versioned buckets.")
+	}
+}
+
+// Color scheme for the trash commands.
+func setTrashColors() {
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Size", color.New(color.FgYellow))
+	console.SetColor("File", color.New(color.Bold))
+	console.SetColor("Restore", color.New(color.FgGreen, color.Bold))
+}
@@ -0,0 +1,69 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrashEntryOf(t *testing.T) {
+	now := time.Now()
+	version := func(id string, age time.Duration, deleteMarker, latest bool) *ClientContent {
+		return &ClientContent{VersionID: id, Time: now.Add(-age), IsDeleteMarker: deleteMarker, IsLatest: latest}
+	}
+	testCases := []struct {
+		versions []*ClientContent
+		markers  int
+		version  string
+		ok       bool
+	}{
+		// Not removed.
+		{[]*ClientContent{version("v2", time.Minute, false, true), version("v1", time.Hour, false, false)}, 0, "", false},
+		// Removed once, the latest version is restored.
+		{[]*ClientContent{version("v1", time.Hour, false, false), version("d1", time.Minute, true, true)}, 1, "v1", true},
+		// Removed twice, both delete markers are removed.
+		{[]*ClientContent{
+			version("d2", time.Minute, true, true),
+			version("d1", 2*time.Minute, true, false),
+			version("v2", time.Hour, false, false),
+			version("v1", 2*time.Hour, false, false),
+		}, 2, "v2", true},
+		// Removed again after a restore, only the latest delete marker counts.
+		{[]*ClientContent{
+			version("d2", time.Minute, true, true),
+			version("v2", time.Hour, false, false),
+			version("d1", 2*time.Hour, true, false),
+			version("v1", 3*time.Hour, false, false),
+		}, 1, "v2", true},
+		// Only delete markers, nothing to restore.
+		{[]*ClientContent{version("d1", time.Minute, true, true)}, 0, "", false},
+	}
+	for i, testCase := range testCases {
+		entry, ok := trashEntryOf(testCase.versions)
+		if ok != testCase.ok {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if len(entry.markers) != testCase.markers || entry.version.VersionID != testCase.version {
+			t.Errorf("Test %d: expected %d delete markers on %s, got %d on %s",
+				i+1, testCase.markers, testCase.version, len(entry.markers), entry.version.VersionID)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var trashRestoreFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "recursive, r",
+		Usage: "restore the removed objects under a prefix",
+	},
+	cli.BoolFlag{
+		Name:  "force",
+		Usage: "allow a recursive restore operation",
+	},
+	cli.StringFlag{
+		Name:  "newer-than",
+		Usage: "restore objects removed less than L days, M hours and N minutes ago",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "only print the objects which would be restored",
+	},
+}
+
+var trashRestoreCmd = cli.Command{
+	Name:         "restore",
+	Usage:        "restore objects removed from a versioned bucket",
+	Action:       mainTrashRestore,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(trashRestoreFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+  Remove the delete markers on top of the last version of removed objects,
+  which makes that version the current one again.
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+EXAMPLES:
+  1. Restore a removed object.
+     {{.Prompt}} {{.HelpName}} play/mybucket/report.pdf
+
+  2. Restore the objects removed under a prefix during the last hour.
+     {{.Prompt}} {{.HelpName}} --recursive --force --newer-than 1h play/mybucket/photos/
+
+  3. Show the objects which would be restored in a bucket.
+     {{.Prompt}} {{.HelpName}} --recursive --force --dry-run play/mybucket
+`,
+}
+
+// trashRestoreMessage container for a restored object.
+type trashRestoreMessage struct {
+	Status    string `json:"status"`
+	Key       string `json:"key"`
+	VersionID string `json:"versionId"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+}
+
+// String colorized restored object.
+func (t trashRestoreMessage) String() string {
+	if t.DryRun {
+		return console.Colorize("Restore", fmt.Sprintf("Would restore `%s` (versionId=%s).", t.Key, t.VersionID))
+	}
+	return console.Colorize("Restore", fmt.Sprintf("Restored `%s` (versionId=%s).", t.Key, t.VersionID))
+}
+
+// JSON jsonified restored object.
+func (t trashRestoreMessage) JSON() string {
+	t.Status = "success"
+	msgBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// checkTrashRestoreSyntax - validate arguments passed by a user.
+func checkTrashRestoreSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "restore", globalInvalidArgsExitStatus)
+	}
+	if ctx.Bool("recursive") && !ctx.Bool("force") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Restoring objects recursively requires --force flag.")
+	}
+}
+
+// mainTrashRestore is the entry point for trash restore command.
+func mainTrashRestore(cliCtx *cli.Context) error {
+	ctx, cancelTrashRestore := context.WithCancel(globalContext)
+	defer cancelTrashRestore()
+
+	checkTrashRestoreSyntax(cliCtx)
+	setTrashColors()
+
+	aliasedURL := cliCtx.Args().Get(0)
+	newerThan := cliCtx.String("newer-than")
+	dryRun := cliCtx.Bool("dry-run")
+	checkTrashURL(ctx, aliasedURL)
+
+	alias, _ := url2Alias(aliasedURL)
+	clnt, err := newClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize target `"+aliasedURL+"`.")
+
+	contentCh := make(chan *ClientContent)
+	errorCh := clnt.Remove(ctx, false, false, false, contentCh)
+	failed := make(chan bool, 1)
+	go func() {
+		var removeFailed bool
+		for err := range errorCh {
+			errorIf(err.Trace(aliasedURL), "Unable to restore an object of `"+aliasedURL+"`.")
+			removeFailed = true
+		}
+		failed <- removeFailed
+	}()
+
+	var restored int
+	err = listTrash(ctx, clnt, cliCtx.Bool("recursive"), func(entry trashEntry) *probe.Error {
+		if isNewer(entry.markers[0].Time, newerThan) {
+			return nil
+		}
+		restored++
+		if !dryRun {
+			for _, marker := range entry.markers {
+				contentCh <- marker
+			}
+		}
+		printMsg(trashRestoreMessage{
+			Key:       alias + getKey(entry.version),
+			VersionID: entry.version.VersionID,
+			DryRun:    dryRun,
+		})
+		return nil
+	})
+	close(contentCh)
+	removeFailed := <-failed
+	fatalIf(err, "Unable to list the objects removed from `"+aliasedURL+"`.")
+
+	if restored == 0 {
+		errorIf(errDummy().Trace(aliasedURL), "Unable to find any removed object to restore in `"+aliasedURL+"`.")
+		return exitStatus(globalErrorExitStatus)
+	}
+	if removeFailed {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
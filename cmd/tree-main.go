@@ -24,6 +24,8 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
@@ -42,6 +44,12 @@ type treeMessage struct {
 	Entry        string
 	IsDir        bool
 	BranchString string
+
+	// Size of a file or of all objects below a folder, with --size and --du.
+	Size        int64
+	Objects     int64
+	ShowSize    bool
+	ShowObjects bool
 }
 
 // Colorized message for console printing.
@@ -50,7 +58,15 @@ func (t treeMessage) String() string {
 	if t.IsDir {
 		entryType = "Dir"
 	}
-	return fmt.Sprintf("%s%s", t.BranchString, console.Colorize(entryType, t.Entry))
+	var annotation string
+	switch {
+	case t.ShowObjects:
+		annotation = console.Colorize("Size", fmt.Sprintf("[%s, %s] ",
+			english.Plural(int(t.Objects), "object", ""), humanize.IBytes(uint64(t.Size))))
+	case t.ShowSize:
+		annotation = console.Colorize("Size", fmt.Sprintf("[%s] ", humanize.IBytes(uint64(t.Size))))
+	}
+	return fmt.Sprintf("%s%s%s", t.BranchString, annotation, console.Colorize(entryType, t.Entry))
 }
 
 // JSON'ified message for scripting.
@@ -74,6 +90,14 @@ var treeFlags = []cli.Flag{
 		Name:  "rewind",
 		Usage: "display tree no later than specified date",
 	},
+	cli.BoolFlag{
+		Name:  "size",
+		Usage: "display the size of files and the total size of folders",
+	},
+	cli.BoolFlag{
+		Name:  "du",
+		Usage: "display the number of objects and the total size of folders",
+	},
 }
 
 // trees files and folders.
@@ -108,6 +132,9 @@ EXAMPLES:
 
    5. List all directories upto depth level '2' in tree format.
       {{.Prompt}} {{.HelpName}} --depth 2 myminio/mybucket/
+
+   6. Browse the capacity used by the directories of "mybucket", with the number of objects.
+      {{.Prompt}} {{.HelpName}} --du --depth 1 myminio/mybucket/
 `,
 }
 
@@ -262,6 +289,7 @@ func mainTree(cliCtx *cli.Context) error {
 
 	console.SetColor("File", color.New(color.Bold))
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
+	console.SetColor("Size", color.New(color.FgYellow))
 
 	// parse 'tree' cliCtx arguments.
 	args, depth, includeFiles, timeRef := parseTreeSyntax(ctx, cliCtx)
@@ -273,7 +301,11 @@ func mainTree(cliCtx *cli.Context) error {
 
 	var cErr error
 	for _, targetURL := range args {
-		if !globalJSON {
+		if cliCtx.Bool("size") || cliCtx.Bool("du") {
+			if e := doTreeSize(ctx, targetURL, timeRef, depth, includeFiles, cliCtx.Bool("du")); e != nil {
+				cErr = e
+			}
+		} else if !globalJSON {
 			if e := doTree(ctx, targetURL, timeRef, 1, false, "", depth, includeFiles); e != nil {
 				cErr = e
 			}
@@ -284,7 +316,7 @@ func mainTree(cliCtx *cli.Context) error {
 			}
 			clnt, err := newClientFromAlias(targetAlias, targetURL)
 			fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
-			if e := doList(ctx, clnt, true, false, false, timeRef, false); e != nil {
+			if e := doList(ctx, clnt, doListOptions{isRecursive: true, timeRef: timeRef}); e != nil {
 				cErr = e
 			}
 		}
@@ -0,0 +1,199 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// treeNode is a folder or a file of a tree built from a recursive
+// listing, folders hold the count and size of all objects below them.
+type treeNode struct {
+	name     string
+	isDir    bool
+	objects  int64
+	size     int64
+	children map[string]*treeNode
+}
+
+// child returns the child named name, created if not found.
+func (n *treeNode) child(name string, isDir bool) *treeNode {
+	if n.children == nil {
+		n.children = make(map[string]*treeNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &treeNode{name: name, isDir: isDir}
+		n.children[name] = c
+	}
+	return c
+}
+
+// add adds an entry at path, relative to the node, and counts it in
+// the node and in all folders above it.
+func (n *treeNode) add(path string, size int64, isDir, includeFiles bool) {
+	elems := strings.Split(strings.Trim(path, "/"), "/")
+	if !isDir {
+		n.objects++
+		n.size += size
+	}
+	node := n
+	for i, elem := range elems {
+		if elem == "" {
+			return
+		}
+		if i == len(elems)-1 && !isDir {
+			if includeFiles {
+				node.child(elem, false).size = size
+			}
+			return
+		}
+		node = node.child(elem, true)
+		if !isDir {
+			node.objects++
+			node.size += size
+		}
+	}
+}
+
+// sortedChildren returns the children of a node sorted by name.
+func (n *treeNode) sortedChildren() []*treeNode {
+	children := make([]*treeNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// doTreeSize - list all entities inside a folder in a tree format,
+// annotated with sizes and object counts computed in one recursive
+// listing.
+func doTreeSize(ctx context.Context, url string, timeRef time.Time, depth int, includeFiles, showObjects bool) error {
+	targetAlias, targetURL, _ := mustExpandAlias(url)
+	if !strings.HasSuffix(targetURL, "/") {
+		targetURL += "/"
+	}
+
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+
+	prefixPath := strings.TrimPrefix(filepath.ToSlash(clnt.GetURL().Path), "./")
+
+	root := &treeNode{name: url, isDir: true}
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, TimeRef: timeRef, ShowDir: DirFirst}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(clnt.GetURL().String()), "Unable to tree.")
+			continue
+		}
+		contentPath := strings.TrimPrefix(filepath.ToSlash(content.URL.Path), "./")
+		if !strings.HasPrefix(contentPath, prefixPath) || contentPath == "." {
+			// Skip the listed folder itself.
+			continue
+		}
+		root.add(strings.TrimPrefix(contentPath, prefixPath), content.Size, content.Type.IsDir(), includeFiles)
+	}
+
+	printTreeNode(root, root.name, "", 0, depth, showObjects)
+	return nil
+}
+
+// treeSizeMessage is the JSON message of a node printed with --size
+// or --du, one per folder or file.
+type treeSizeMessage struct {
+	Status  string `json:"status"`
+	Key     string `json:"key"`
+	Type    string `json:"type"`
+	Size    int64  `json:"size"`
+	Objects *int64 `json:"objects,omitempty"`
+}
+
+func (t treeSizeMessage) String() string {
+	return fmt.Sprintf("%s %d", t.Key, t.Size)
+}
+
+func (t treeSizeMessage) JSON() string {
+	t.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// printTreeNode prints a node and the nodes below it, up to depth
+// levels below the root like doTree. key is the path of the node
+// printed with --json.
+func printTreeNode(node *treeNode, key, branchString string, level, depth int, showObjects bool) {
+	if globalJSON {
+		msg := treeSizeMessage{Key: key, Type: "file", Size: node.size}
+		if node.isDir {
+			msg.Type = "folder"
+			if showObjects {
+				objects := node.objects
+				msg.Objects = &objects
+			}
+		}
+		printMsg(msg)
+	} else {
+		msg := treeMessage{
+			Entry:        node.name,
+			IsDir:        node.isDir,
+			BranchString: branchString,
+			Size:         node.size,
+			ShowSize:     true,
+		}
+		if node.isDir {
+			msg.Objects = node.objects
+			msg.ShowObjects = showObjects
+		}
+		printMsg(msg)
+	}
+
+	if level > 0 && depth != -1 && level > depth {
+		return
+	}
+
+	indent := strings.TrimSuffix(strings.TrimSuffix(branchString, treeEntry), treeLastEntry)
+	if level > 0 {
+		if strings.HasSuffix(branchString, treeLastEntry) {
+			indent += " " + treeLevel
+		} else {
+			indent += treeNext + treeLevel
+		}
+	}
+	children := node.sortedChildren()
+	for i, c := range children {
+		childBranch := indent + treeEntry
+		if i == len(children)-1 {
+			childBranch = indent + treeLastEntry
+		}
+		childKey := strings.TrimSuffix(key, "/") + "/" + c.name
+		if c.isDir {
+			childKey += "/"
+		}
+		printTreeNode(c, childKey, childBranch, level+1, depth, showObjects)
+	}
+}
@@ -33,9 +33,12 @@ var errDummy = func() *probe.Error {
 
 type invalidArgumentErr error
 
+// errInvalidArgumentCause is shared by all invalid argument errors,
+// it allows to map them to their own exit status.
+var errInvalidArgumentCause = invalidArgumentErr(errors.New("Invalid arguments provided, please refer " + "`mc <command> -h` for relevant documentation."))
+
 var errInvalidArgument = func() *probe.Error {
-	msg := "Invalid arguments provided, please refer " + "`mc <command> -h` for relevant documentation."
-	return probe.NewError(invalidArgumentErr(errors.New(msg))).Untrace()
+	return probe.NewError(errInvalidArgumentCause).Untrace()
 }
 
 type unrecognizedDiffTypeErr error
@@ -30,7 +30,9 @@ type URLs struct {
 	TotalSize        int64
 	MD5              bool
 	DisableMultipart bool
+	Dedupe           bool
 	encKeyDB         map[string][]prefixSSEPair
+	clientEncryption *clientEncryption
 	Error            *probe.Error `json:"-"`
 	ErrorCond        differType   `json:"-"`
 }
@@ -40,6 +40,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 
 	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/mc/pkg/mcconfig"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/console"
 )
@@ -151,6 +152,26 @@ func NewS3Config(urlStr string, aliasCfg *aliasConfigV10) *Config {
 		s3Config.SecretKey = aliasCfg.SecretKey
 		s3Config.SessionToken = aliasCfg.SessionToken
 		s3Config.Signature = aliasCfg.API
+		s3Config.Proxy = aliasCfg.Proxy
+		s3Config.CACert = aliasCfg.CACert
+		s3Config.ClientCert = aliasCfg.ClientCert
+		s3Config.ClientKey = aliasCfg.ClientKey
+		s3Config.RequestPayer = aliasCfg.RequestPayer
+		if aliasCfg.Tuning != nil {
+			s3Config.Tuning = *aliasCfg.Tuning
+		}
+		s3Config.PartSize = aliasCfg.PartSize
+		s3Config.MultipartThreshold = aliasCfg.MultipartThreshold
+	}
+	if globalRequestPayer != "" {
+		s3Config.RequestPayer = globalRequestPayer
+	}
+	s3Config.Tuning = s3Config.Tuning.override(globalTransportTuning)
+	if globalPartSize > 0 {
+		s3Config.PartSize = globalPartSize
+	}
+	if globalMultipartThreshold > 0 {
+		s3Config.MultipartThreshold = globalMultipartThreshold
 	}
 	s3Config.Lookup = getLookupType(aliasCfg.Path)
 	return s3Config
@@ -196,14 +217,7 @@ func isNewer(ti time.Time, newerRef string) bool {
 // getLookupType returns the minio.BucketLookupType for lookup
 // option entered on the command line
 func getLookupType(l string) minio.BucketLookupType {
-	l = strings.ToLower(l)
-	switch l {
-	case "off":
-		return minio.BucketLookupDNS
-	case "on":
-		return minio.BucketLookupPath
-	}
-	return minio.BucketLookupAuto
+	return mcconfig.BucketLookup(l)
 }
 
 // struct representing object prefix and sse keys association.
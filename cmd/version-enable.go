@@ -52,7 +52,7 @@ EXAMPLES:
 // checkVersionEnableSyntax - validate all the passed arguments
 func checkVersionEnableSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "enable", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "enable", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
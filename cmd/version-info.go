@@ -53,7 +53,7 @@ EXAMPLES:
 // checkVersionInfoSyntax - validate all the passed arguments
 func checkVersionInfoSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "info", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
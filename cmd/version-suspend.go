@@ -52,7 +52,7 @@ EXAMPLES:
 // checkVersionSuspendSyntax - validate all the passed arguments
 func checkVersionSuspendSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "suspend", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "suspend", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -93,7 +93,7 @@ EXAMPLES:
 // checkWatchSyntax - validate all the passed arguments
 func checkWatchSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
-		cli.ShowCommandHelpAndExit(ctx, "watch", 1) // last argument is exit code
+		cli.ShowCommandHelpAndExit(ctx, "watch", globalInvalidArgsExitStatus) // last argument is exit code
 	}
 }
 
@@ -0,0 +1,175 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+)
+
+var websiteConfigFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "index",
+		Usage: "document served for the requests of a folder, e.g. 'index.html'",
+	},
+	cli.StringFlag{
+		Name:  "error",
+		Usage: "document served for the missing objects, e.g. '404.html'",
+	},
+	cli.BoolFlag{
+		Name:  "remove",
+		Usage: "remove the website configuration",
+	},
+}
+
+var websiteConfigCmd = cli.Command{
+	Name:         "config",
+	Usage:        "show or set the index and error documents of a bucket",
+	Action:       mainWebsiteConfig,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(websiteConfigFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] ALIAS/BUCKET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Show the website configuration of a bucket, or set it with --index and
+   --error. The index document is required, it defaults to 'index.html' when
+   only the error document is given and none is configured.
+
+EXAMPLES:
+  1. Show the website configuration of a bucket.
+     {{.Prompt}} {{.HelpName}} s3/www.example.com
+
+  2. Serve 'index.html' for folders and '404.html' for missing pages.
+     {{.Prompt}} {{.HelpName}} --index index.html --error 404.html s3/www.example.com
+
+  3. Remove the website configuration of a bucket.
+     {{.Prompt}} {{.HelpName}} --remove s3/www.example.com
+`,
+}
+
+const websiteDefaultIndex = "index.html"
+
+// websiteConfigMessage is the website configuration of a bucket.
+type websiteConfigMessage struct {
+	Status  string         `json:"status"`
+	URL     string         `json:"url"`
+	Op      string         `json:"op"`
+	Website *bucketWebsite `json:"website,omitempty"`
+}
+
+func (w websiteConfigMessage) String() string {
+	switch {
+	case w.Op == "remove":
+		return console.Colorize("WebsiteConfig", fmt.Sprintf("Website configuration of `%s` removed.", w.URL))
+	case w.Website == nil:
+		return console.Colorize("WebsiteConfig", fmt.Sprintf("No website configuration for `%s`.", w.URL))
+	}
+	msg := fmt.Sprintf("Index document: %s", w.Website.Index)
+	if w.Website.Error != "" {
+		msg += fmt.Sprintf("\nError document: %s", w.Website.Error)
+	}
+	if w.Op == "set" {
+		msg = fmt.Sprintf("Website configuration of `%s` set.\n", w.URL) + msg
+	}
+	return console.Colorize("WebsiteConfig", msg)
+}
+
+func (w websiteConfigMessage) JSON() string {
+	w.Status = "success"
+	msgBytes, e := json.MarshalIndent(w, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// newWebsiteClient returns the client of a bucket on object storage.
+func newWebsiteClient(bucketURL string) (*S3Client, *probe.Error) {
+	clnt, err := newClient(bucketURL)
+	if err != nil {
+		return nil, err.Trace(bucketURL)
+	}
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		return nil, errInvalidArgument().Trace(bucketURL)
+	}
+	if bucket, object := s3Clnt.url2BucketAndObject(); bucket == "" || object != "" {
+		return nil, errInvalidArgument().Trace(bucketURL)
+	}
+	return s3Clnt, nil
+}
+
+func mainWebsiteConfig(cliCtx *cli.Context) error {
+	ctx, cancelWebsiteConfig := context.WithCancel(globalContext)
+	defer cancelWebsiteConfig()
+
+	console.SetColor("WebsiteConfig", color.New(color.FgGreen))
+
+	if len(cliCtx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(cliCtx, "config", globalInvalidArgsExitStatus)
+	}
+	bucketURL := cliCtx.Args().Get(0)
+	index, errorDoc := cliCtx.String("index"), cliCtx.String("error")
+	remove := cliCtx.Bool("remove")
+	if remove && (index != "" || errorDoc != "") {
+		fatalIf(errInvalidArgument(), "--remove cannot be used with --index or --error.")
+	}
+
+	clnt, err := newWebsiteClient(bucketURL)
+	fatalIf(err, "Website configuration is only set on buckets, expected ALIAS/BUCKET.")
+
+	if remove {
+		fatalIf(clnt.RemoveBucketWebsite(ctx).Trace(bucketURL), "Unable to remove the website configuration.")
+		printMsg(websiteConfigMessage{URL: bucketURL, Op: "remove"})
+		return nil
+	}
+
+	website, err := clnt.GetBucketWebsite(ctx)
+	fatalIf(err.Trace(bucketURL), "Unable to get the website configuration.")
+	if index == "" && errorDoc == "" {
+		printMsg(websiteConfigMessage{URL: bucketURL, Op: "get", Website: website})
+		return nil
+	}
+
+	if website == nil {
+		website = &bucketWebsite{}
+	}
+	if index != "" {
+		website.Index = index
+	}
+	if website.Index == "" {
+		website.Index = websiteDefaultIndex
+	}
+	if errorDoc != "" {
+		website.Error = errorDoc
+	}
+	fatalIf(clnt.SetBucketWebsite(ctx, *website).Trace(bucketURL), "Unable to set the website configuration.")
+	printMsg(websiteConfigMessage{URL: bucketURL, Op: "set", Website: website})
+	return nil
+}
@@ -0,0 +1,644 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/console"
+	"github.com/minio/minio/pkg/mimedb"
+)
+
+var websiteDeployFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "attr-map",
+		Usage: "set attributes of the objects matching a pattern, e.g. '*.js:Cache-Control=max-age=31536000', '@FILE' for a file of rules",
+	},
+	cli.StringFlag{
+		Name:  "precompress",
+		Usage: "upload compressed variants of text files next to them, 'gzip', 'br' or 'gzip,br'",
+	},
+	cli.BoolFlag{
+		Name:  "remove",
+		Usage: "remove objects which are not in the local folder anymore",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "show the changes without deploying",
+	},
+}
+
+var websiteDeployCmd = cli.Command{
+	Name:         "deploy",
+	Usage:        "upload a local folder to a bucket serving a static website",
+	Action:       mainWebsiteDeploy,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(websiteDeployFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [COMMAND FLAGS] LOCALDIR ALIAS/BUCKET[/PREFIX]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Upload the files of LOCALDIR which are new or changed. The Content-Type of
+   the objects is detected from the file extensions and can be overridden,
+   like any other HTTP attribute, with --attr-map rules.
+
+   With --precompress, text files of at least 1KiB get a '.gz' and/or '.br'
+   variant with the Content-Encoding set, when it is smaller than the file.
+   Brotli variants need the 'brotli' program. The variants keep the
+   attributes of the original file.
+
+   With --remove, the objects under the target which are neither a local file
+   nor one of its variants are removed.
+
+EXAMPLES:
+  1. Deploy a site built in './public'.
+     {{.Prompt}} {{.HelpName}} ./public s3/www.example.com
+
+  2. Deploy a site, cache the assets for a year and remove the deleted pages.
+     {{.Prompt}} {{.HelpName}} --remove --attr-map '*.css:Cache-Control=max-age=31536000' \
+          --attr-map 'assets/*:Cache-Control=max-age=31536000' ./public s3/www.example.com
+
+  3. Deploy a site with gzip and brotli variants of the text files.
+     {{.Prompt}} {{.HelpName}} --precompress gzip,br ./public s3/www.example.com
+
+  4. Show what a deployment would change.
+     {{.Prompt}} {{.HelpName}} --dry-run --remove ./public s3/www.example.com
+`,
+}
+
+const (
+	websiteDeployWorkers      = 8
+	websitePrecompressMinSize = 1024
+)
+
+// websiteEncodings maps the --precompress values to the extension and the
+// Content-Encoding of the variants.
+var websiteEncodings = map[string]string{
+	"gzip": ".gz",
+	"br":   ".br",
+}
+
+// websiteCompressibleTypes are the content types, besides text/*, which
+// are worth compressing.
+var websiteCompressibleTypes = []string{
+	"application/javascript",
+	"application/json",
+	"application/manifest+json",
+	"application/wasm",
+	"application/xml",
+	"image/svg+xml",
+}
+
+// websiteDeployMessage is an object uploaded or removed by a deployment.
+type websiteDeployMessage struct {
+	Status string `json:"status"`
+	Op     string `json:"op"`
+	URL    string `json:"url"`
+	Size   int64  `json:"size,omitempty"`
+	DryRun bool   `json:"dryRun,omitempty"`
+}
+
+func (w websiteDeployMessage) String() string {
+	switch {
+	case w.Op == "remove" && w.DryRun:
+		return console.Colorize("WebsiteRemove", fmt.Sprintf("Would remove `%s`.", w.URL))
+	case w.Op == "remove":
+		return console.Colorize("WebsiteRemove", fmt.Sprintf("Removed `%s`.", w.URL))
+	case w.DryRun:
+		return console.Colorize("WebsiteUpload", fmt.Sprintf("Would upload `%s`, %s.", w.URL, humanize.IBytes(uint64(w.Size))))
+	}
+	return console.Colorize("WebsiteUpload", fmt.Sprintf("Uploaded `%s`, %s.", w.URL, humanize.IBytes(uint64(w.Size))))
+}
+
+func (w websiteDeployMessage) JSON() string {
+	w.Status = "success"
+	msgBytes, e := json.MarshalIndent(w, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// websiteDeploySummary is the outcome of a deployment.
+type websiteDeploySummary struct {
+	Status    string `json:"status"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Uploaded  int    `json:"uploaded"`
+	Unchanged int    `json:"unchanged"`
+	Removed   int    `json:"removed"`
+	Failed    int    `json:"failed"`
+	Size      int64  `json:"size"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+}
+
+func (w websiteDeploySummary) String() string {
+	msg := fmt.Sprintf("Deployed `%s` to `%s`: %d uploaded (%s), %d unchanged, %d removed",
+		w.Source, w.Target, w.Uploaded, humanize.IBytes(uint64(w.Size)), w.Unchanged, w.Removed)
+	if w.Failed > 0 {
+		msg += fmt.Sprintf(", %d failed", w.Failed)
+	}
+	msg += "."
+	if w.DryRun {
+		msg += " Dry run, nothing was changed."
+	}
+	return console.Colorize("WebsiteSummary", msg)
+}
+
+func (w websiteDeploySummary) JSON() string {
+	w.Status = "success"
+	if w.Failed > 0 {
+		w.Status = "failure"
+	}
+	msgBytes, e := json.MarshalIndent(w, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// parseWebsiteEncodings parses the --precompress value.
+func parseWebsiteEncodings(value string) ([]string, *probe.Error) {
+	var encodings []string
+	for _, encoding := range strings.Split(value, ",") {
+		encoding = strings.TrimSpace(encoding)
+		if encoding == "" {
+			continue
+		}
+		if _, ok := websiteEncodings[encoding]; !ok {
+			return nil, probe.NewError(fmt.Errorf("unknown encoding `%s`, expected 'gzip' or 'br'", encoding))
+		}
+		if encoding == "br" {
+			if _, e := exec.LookPath("brotli"); e != nil {
+				return nil, probe.NewError(fmt.Errorf("brotli variants need the 'brotli' program: %v", e))
+			}
+		}
+		encodings = append(encodings, encoding)
+	}
+	sort.Strings(encodings)
+	return encodings, nil
+}
+
+// isWebsiteCompressible returns true for the content types worth sending
+// compressed.
+func isWebsiteCompressible(contentType string) bool {
+	contentType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	if strings.HasPrefix(contentType, "text/") {
+		return true
+	}
+	for _, t := range websiteCompressibleTypes {
+		if contentType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// websiteMetadata returns the attributes of the object uploaded for key,
+// variants keep the attributes of the original file and set the encoding.
+func websiteMetadata(rules attrMap, key, encoding string) map[string]string {
+	metadata := map[string]string{"Content-Type": mimedb.TypeByExtension(path.Ext(key))}
+	rules.apply(key, metadata)
+	if encoding != "" {
+		metadata["Content-Encoding"] = encoding
+	}
+	normalized := map[string]string{}
+	for k, v := range metadata {
+		if v != "" {
+			normalized[normalizeMetadataKey(k)] = v
+		}
+	}
+	return normalized
+}
+
+// websiteCompress returns the data compressed with encoding, gzip output
+// carries no name or time so that it only changes with the data.
+func websiteCompress(ctx context.Context, encoding string, data []byte) ([]byte, *probe.Error) {
+	var buf bytes.Buffer
+	switch encoding {
+	case "gzip":
+		w, e := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		if _, e = w.Write(data); e != nil {
+			return nil, probe.NewError(e)
+		}
+		if e = w.Close(); e != nil {
+			return nil, probe.NewError(e)
+		}
+	case "br":
+		cmd := exec.CommandContext(ctx, "brotli", "-c", "-q", "11")
+		var stderr bytes.Buffer
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stdout, cmd.Stderr = &buf, &stderr
+		if e := cmd.Run(); e != nil {
+			return nil, probe.NewError(fmt.Errorf("brotli: %v %s", e, strings.TrimSpace(stderr.String())))
+		}
+	default:
+		return nil, errInvalidArgument().Trace(encoding)
+	}
+	return buf.Bytes(), nil
+}
+
+// websiteObject is an object to deploy, a local file or a compressed
+// variant of it.
+type websiteObject struct {
+	key      string
+	file     string
+	data     []byte
+	size     int64
+	md5      string
+	metadata map[string]string
+}
+
+// websiteResult is the outcome of deploying an object.
+type websiteResult struct {
+	key       string
+	uploaded  bool
+	unchanged bool
+	size      int64
+	err       *probe.Error
+}
+
+// websiteDeployer uploads the new and changed objects of a deployment.
+type websiteDeployer struct {
+	bucketURL string
+	rules     attrMap
+	encodings []string
+	dryRun    bool
+	local     map[string]bool
+	remote    map[string]*ClientContent
+}
+
+// objectURL returns the URL of the object stored under key.
+func (d *websiteDeployer) objectURL(key string) string {
+	return urlJoinPath(d.bucketURL, key)
+}
+
+// objects returns the objects to deploy for a local file, the original
+// and its compressed variants.
+func (d *websiteDeployer) objects(ctx context.Context, file, key string) ([]websiteObject, *probe.Error) {
+	f, e := os.Open(file)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+
+	metadata := websiteMetadata(d.rules, key, "")
+	compress := len(d.encodings) > 0 && isWebsiteCompressible(metadata["Content-Type"]) && metadata["Content-Encoding"] == ""
+
+	var data bytes.Buffer
+	h := md5.New()
+	w := io.Writer(h)
+	if compress {
+		w = io.MultiWriter(h, &data)
+	}
+	size, e := io.Copy(w, f)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	objects := []websiteObject{{key: key, file: file, size: size, md5: hex.EncodeToString(h.Sum(nil)), metadata: metadata}}
+	if !compress || size < websitePrecompressMinSize {
+		return objects, nil
+	}
+	for _, encoding := range d.encodings {
+		variant := key + websiteEncodings[encoding]
+		if d.local[variant] {
+			// A local file of the same name takes precedence.
+			continue
+		}
+		compressed, err := websiteCompress(ctx, encoding, data.Bytes())
+		if err != nil {
+			return nil, err.Trace(file)
+		}
+		if int64(len(compressed)) >= size {
+			continue
+		}
+		sum := md5.Sum(compressed)
+		objects = append(objects, websiteObject{
+			key:      variant,
+			data:     compressed,
+			size:     int64(len(compressed)),
+			md5:      hex.EncodeToString(sum[:]),
+			metadata: websiteMetadata(d.rules, key, encoding),
+		})
+	}
+	return objects, nil
+}
+
+// User metadata keeping the MD5 of the uploaded data, the ETag of
+// multipart uploads is not their MD5.
+const websiteMD5Metadata = "X-Amz-Meta-Mc-Content-Md5"
+
+// unchanged returns true when the object stored under the key of obj has
+// the same data and attributes.
+func (d *websiteDeployer) unchanged(ctx context.Context, obj websiteObject) (bool, *probe.Error) {
+	remote, ok := d.remote[obj.key]
+	if !ok || remote.Size != obj.size {
+		return false, nil
+	}
+	etag := strings.Trim(remote.ETag, "\"")
+	if etag != obj.md5 && !strings.Contains(etag, "-") {
+		// Not a multipart upload, its ETag is the MD5 of its data.
+		return false, nil
+	}
+	clnt, err := newClient(d.objectURL(obj.key))
+	if err != nil {
+		return false, err
+	}
+	st, err := clnt.Stat(ctx, StatOptions{})
+	if err != nil {
+		return false, err
+	}
+	current := editableMetadata(st.Metadata)
+	if etag != obj.md5 && current[websiteMD5Metadata] != obj.md5 {
+		return false, nil
+	}
+	for k, v := range obj.metadata {
+		if current[k] != v {
+			return false, nil
+		}
+	}
+	for k := range current {
+		if _, ok := obj.metadata[k]; !ok && !strings.HasPrefix(k, metadataUserPrefix) {
+			// A standard attribute which is not wanted anymore.
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// deploy uploads obj unless the stored object is the same.
+func (d *websiteDeployer) deploy(ctx context.Context, obj websiteObject) websiteResult {
+	res := websiteResult{key: obj.key, size: obj.size}
+	same, err := d.unchanged(ctx, obj)
+	if err != nil {
+		res.err = err.Trace(d.objectURL(obj.key))
+		return res
+	}
+	if same {
+		res.unchanged = true
+		return res
+	}
+	res.uploaded = true
+	if d.dryRun {
+		return res
+	}
+
+	var reader io.Reader = bytes.NewReader(obj.data)
+	if obj.file != "" {
+		f, e := os.Open(obj.file)
+		if e != nil {
+			res.err = probe.NewError(e).Trace(obj.file)
+			return res
+		}
+		defer f.Close()
+		reader = f
+	}
+	clnt, err := newClient(d.objectURL(obj.key))
+	if err != nil {
+		res.err = err.Trace(d.objectURL(obj.key))
+		return res
+	}
+	metadata := map[string]string{}
+	for k, v := range obj.metadata {
+		metadata[k] = v
+	}
+	metadata[websiteMD5Metadata] = obj.md5
+	if _, err = clnt.Put(ctx, reader, obj.size, nil, PutOptions{metadata: metadata}); err != nil {
+		res.err = err.Trace(d.objectURL(obj.key))
+	}
+	return res
+}
+
+// listWebsiteFiles returns the regular files of dir by their keys under
+// prefix.
+func listWebsiteFiles(dir, prefix string) (map[string]string, *probe.Error) {
+	files := map[string]string{}
+	e := filepath.Walk(dir, func(file string, info os.FileInfo, e error) error {
+		if e != nil {
+			return e
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info, e = os.Stat(file); e != nil {
+				return e
+			}
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, e := filepath.Rel(dir, file)
+		if e != nil {
+			return e
+		}
+		files[prefix+filepath.ToSlash(rel)] = file
+		return nil
+	})
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return files, nil
+}
+
+func mainWebsiteDeploy(cliCtx *cli.Context) error {
+	ctx, cancelWebsiteDeploy := context.WithCancel(globalContext)
+	defer cancelWebsiteDeploy()
+
+	console.SetColor("WebsiteUpload", color.New(color.FgGreen))
+	console.SetColor("WebsiteRemove", color.New(color.FgRed))
+	console.SetColor("WebsiteSummary", color.New(color.FgCyan, color.Bold))
+
+	if len(cliCtx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "deploy", globalInvalidArgsExitStatus)
+	}
+	dir, targetURL := cliCtx.Args().Get(0), cliCtx.Args().Get(1)
+	if st, e := os.Stat(dir); e != nil || !st.IsDir() {
+		if e == nil {
+			e = fmt.Errorf("`%s` is not a folder", dir)
+		}
+		fatalIf(probe.NewError(e), "Unable to read the local folder.")
+	}
+
+	rules, err := parseAttrMap(cliCtx.StringSlice("attr-map"))
+	fatalIf(err, "Unable to parse --attr-map.")
+	encodings, err := parseWebsiteEncodings(cliCtx.String("precompress"))
+	fatalIf(err, "Unable to parse --precompress.")
+
+	clnt, err := newClient(targetURL)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
+	s3Clnt, ok := clnt.(*S3Client)
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(targetURL), "The target must be a bucket on object storage.")
+	}
+	bucket, prefix := s3Clnt.url2BucketAndObject()
+	if bucket == "" {
+		fatalIf(errInvalidArgument().Trace(targetURL), "The target must be a bucket on object storage.")
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	aliasBucket := strings.SplitN(strings.TrimPrefix(filepath.ToSlash(targetURL), "/"), "/", 3)
+
+	d := &websiteDeployer{
+		bucketURL: aliasBucket[0] + "/" + bucket,
+		rules:     rules,
+		encodings: encodings,
+		dryRun:    cliCtx.Bool("dry-run"),
+		remote:    map[string]*ClientContent{},
+	}
+
+	files, err := listWebsiteFiles(dir, prefix)
+	fatalIf(err.Trace(dir), "Unable to read the local folder.")
+	d.local = map[string]bool{}
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		d.local[key] = true
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	bucketClnt, err := newClient(d.bucketURL)
+	fatalIf(err.Trace(d.bucketURL), "Unable to initialize target `"+d.bucketURL+"`.")
+	listURL := d.bucketURL
+	if prefix != "" {
+		listURL = urlJoinPath(d.bucketURL, prefix)
+	}
+	listClnt, err := newClient(listURL)
+	fatalIf(err.Trace(listURL), "Unable to initialize target `"+listURL+"`.")
+	for content := range listClnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			fatalIf(content.Err.Trace(listURL), "Unable to list the target.")
+		}
+		key := strings.TrimPrefix(content.URL.Path, string(content.URL.Separator)+bucket+string(content.URL.Separator))
+		d.remote[key] = content
+	}
+
+	summary := websiteDeploySummary{Source: dir, Target: targetURL, DryRun: d.dryRun}
+	wanted := map[string]bool{}
+	failed := false
+
+	jobs := make(chan string)
+	results := make(chan websiteResult)
+	var wg sync.WaitGroup
+	for i := 0; i < websiteDeployWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				objects, err := d.objects(ctx, files[key], key)
+				if err != nil {
+					results <- websiteResult{key: key, err: err.Trace(files[key])}
+					continue
+				}
+				for _, obj := range objects {
+					results <- d.deploy(ctx, obj)
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, key := range keys {
+			select {
+			case jobs <- key:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for res := range results {
+		wanted[res.key] = true
+		switch {
+		case res.err != nil:
+			errorIf(res.err, "Unable to deploy `%s`.", d.objectURL(res.key))
+			summary.Failed++
+			failed = true
+		case res.uploaded:
+			summary.Uploaded++
+			summary.Size += res.size
+			printMsg(websiteDeployMessage{Op: "upload", URL: d.objectURL(res.key), Size: res.size, DryRun: d.dryRun})
+		case res.unchanged:
+			summary.Unchanged++
+		}
+	}
+	if ctx.Err() != nil {
+		fatalIf(probe.NewError(ctx.Err()), "Deployment interrupted.")
+	}
+
+	// Nothing is removed after a failure, the variants of a file which
+	// failed are not known.
+	if cliCtx.Bool("remove") && !failed {
+		var stale []string
+		for key := range d.remote {
+			if !wanted[key] {
+				stale = append(stale, key)
+			}
+		}
+		sort.Strings(stale)
+		for _, key := range stale {
+			if !d.dryRun {
+				contentCh := make(chan *ClientContent, 1)
+				contentCh <- d.remote[key]
+				close(contentCh)
+				var err *probe.Error
+				for err = range bucketClnt.Remove(ctx, false, false, false, contentCh) {
+					errorIf(err.Trace(d.objectURL(key)), "Unable to remove `%s`.", d.objectURL(key))
+				}
+				if err != nil {
+					summary.Failed++
+					failed = true
+					continue
+				}
+			}
+			summary.Removed++
+			printMsg(websiteDeployMessage{Op: "remove", URL: d.objectURL(key), DryRun: d.dryRun})
+		}
+	}
+
+	printMsg(summary)
+	if failed {
+		return exitStatus(globalPartialExitStatus)
+	}
+	return nil
+}
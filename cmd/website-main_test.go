@@ -0,0 +1,160 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseWebsiteEncodings(t *testing.T) {
+	encodings, err := parseWebsiteEncodings(" gzip ,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(encodings, []string{"gzip"}) {
+		t.Errorf("unexpected encodings %v", encodings)
+	}
+	if encodings, err = parseWebsiteEncodings(""); err != nil || len(encodings) != 0 {
+		t.Errorf("unexpected encodings %v, %v", encodings, err)
+	}
+	if _, err = parseWebsiteEncodings("gzip,zip"); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
+
+func TestIsWebsiteCompressible(t *testing.T) {
+	testCases := []struct {
+		contentType string
+		expected    bool
+	}{
+		{"text/html", true},
+		{"text/css; charset=utf-8", true},
+		{"application/javascript", true},
+		{"image/svg+xml", true},
+		{"image/png", false},
+		{"application/octet-stream", false},
+	}
+	for _, testCase := range testCases {
+		if got := isWebsiteCompressible(testCase.contentType); got != testCase.expected {
+			t.Errorf("%s: expected %v, got %v", testCase.contentType, testCase.expected, got)
+		}
+	}
+}
+
+func TestWebsiteMetadata(t *testing.T) {
+	rules, err := parseAttrMap([]string{"css/*:Cache-Control=max-age=3600;owner=web", "*.txt:Content-Type="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		key      string
+		encoding string
+		expected map[string]string
+	}{
+		{"index.html", "", map[string]string{"Content-Type": "text/html"}},
+		{"css/app.css", "", map[string]string{"Content-Type": "text/css", "Cache-Control": "max-age=3600", "X-Amz-Meta-Owner": "web"}},
+		{"css/app.css", "gzip", map[string]string{"Content-Type": "text/css", "Cache-Control": "max-age=3600", "X-Amz-Meta-Owner": "web", "Content-Encoding": "gzip"}},
+		{"notes.txt", "", map[string]string{}},
+	}
+	for _, testCase := range testCases {
+		if got := websiteMetadata(rules, testCase.key, testCase.encoding); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("%s %s: expected %v, got %v", testCase.key, testCase.encoding, testCase.expected, got)
+		}
+	}
+}
+
+func TestWebsiteCompress(t *testing.T) {
+	data := bytes.Repeat([]byte("body{color:red}\n"), 100)
+	first, err := websiteCompress(context.Background(), "gzip", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := websiteCompress(context.Background(), "gzip", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("gzip variants of the same data differ")
+	}
+	if len(first) >= len(data) {
+		t.Errorf("gzip variant of %d bytes is %d bytes", len(data), len(first))
+	}
+}
+
+func TestWebsiteCompressBrotli(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the brotli stand-ins are shell scripts")
+	}
+	dir, e := ioutil.TempDir("", "website-brotli")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", dir)
+	brotli := filepath.Join(dir, "brotli")
+
+	// A brotli which upper cases its input, the data is read from
+	// stdin and the variant written to stdout.
+	if e = ioutil.WriteFile(brotli, []byte("#!/bin/sh\nexec /usr/bin/tr a-z A-Z\n"), 0755); e != nil {
+		t.Fatal(e)
+	}
+	data := bytes.Repeat([]byte("body{color:red}\n"), 100)
+	compressed, err := websiteCompress(context.Background(), "br", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(compressed, bytes.ToUpper(data)) {
+		t.Errorf("expected the output of brotli, got %d bytes", len(compressed))
+	}
+
+	// The errors of brotli are reported.
+	if e = ioutil.WriteFile(brotli, []byte("#!/bin/sh\necho 'broken pipe' >&2\nexit 1\n"), 0755); e != nil {
+		t.Fatal(e)
+	}
+	if _, err = websiteCompress(context.Background(), "br", data); err == nil || !strings.Contains(err.ToGoError().Error(), "broken pipe") {
+		t.Errorf("expected the error of brotli, got %v", err)
+	}
+}
+
+func TestBucketWebsiteXML(t *testing.T) {
+	website := bucketWebsite{Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/", Index: "index.html", Error: "404.html"}
+	data, e := xml.Marshal(website)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `<WebsiteConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+	var got bucketWebsite
+	if e = xml.Unmarshal(data, &got); e != nil {
+		t.Fatal(e)
+	}
+	if got.Index != website.Index || got.Error != website.Error {
+		t.Errorf("expected %+v, got %+v", website, got)
+	}
+}
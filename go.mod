@@ -3,6 +3,7 @@ module github.com/minio/mc
 go 1.14
 
 require (
+	filippo.io/age v1.0.0
 	github.com/cheggaaa/pb v1.0.29
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/dustin/go-humanize v1.0.0
@@ -27,10 +28,11 @@ require (
 	github.com/rs/xid v1.2.1
 	github.com/shirou/gopsutil/v3 v3.21.3
 	github.com/tidwall/gjson v1.7.5
-	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b
+	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5
 	golang.org/x/net v0.0.0-20210421230115-4e50805a0758
 	golang.org/x/text v0.3.6
 	gopkg.in/check.v1 v1.0.0-20200902074654-038fdea0a05b
 	gopkg.in/h2non/filetype.v1 v1.0.5
 	gopkg.in/yaml.v2 v2.3.0
+	rsc.io/qr v0.2.0
 )
@@ -0,0 +1,64 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/mcconfig"
+)
+
+// Admin returns a client of the admin API of the server of an alias,
+// for the operations of 'mc admin'.
+func (c *Client) Admin(alias string) (*madmin.AdminClient, error) {
+	a, ok := c.opts.Aliases[alias]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", alias, ErrAliasNotFound)
+	}
+	u, e := parseAliasURL(a.URL)
+	if e != nil {
+		return nil, e
+	}
+	admin, e := madmin.NewWithOptions(u.Host, &madmin.Options{
+		Creds:  mcconfig.StaticCredentials(a.AccessKey, a.SecretKey, a.SessionToken, a.API),
+		Secure: u.Scheme == "https",
+	})
+	if e != nil {
+		return nil, e
+	}
+	transport, e := c.transport(a, u)
+	if e != nil {
+		return nil, fmt.Errorf("alias %s: %w", alias, e)
+	}
+	admin.SetCustomTransport(transport)
+	if c.opts.AppName != "" {
+		admin.SetAppInfo(c.opts.AppName, c.opts.AppVersion)
+	}
+	return admin, nil
+}
+
+// ServerInfo returns the servers, drives and usage of the cluster of an
+// alias, as 'mc admin info'.
+func (c *Client) ServerInfo(ctx context.Context, alias string) (madmin.InfoMessage, error) {
+	admin, e := c.Admin(alias)
+	if e != nil {
+		return madmin.InfoMessage{}, e
+	}
+	return admin.ServerInfo(ctx)
+}
@@ -0,0 +1,283 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package client runs mc operations, ls, cp, mirror and admin info,
+// from Go programs. Operations are context aware, they return typed
+// results and report progress through callbacks instead of printing.
+//
+// Targets are named as on the mc command line, ALIAS/BUCKET/PREFIX for
+// an alias of the client, or a local path otherwise:
+//
+//	aliases, err := client.LoadAliases(configDir, client.LoadOptions{})
+//	c, err := client.New(client.Options{Aliases: aliases, CAsDir: filepath.Join(configDir, "certs", "CAs")})
+//	result, err := c.Copy(ctx, "/data/report.csv", "play/mybucket/", client.CopyOptions{})
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/mc/pkg/mcconfig"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio/pkg/certs"
+)
+
+// Errors returned by the operations, possibly wrapped.
+var (
+	ErrAliasNotFound   = errors.New("alias not found")
+	ErrEncryptedConfig = errors.New("configuration is encrypted, a passphrase is required")
+	ErrNotFound        = errors.New("object not found")
+	ErrBucketRequired  = errors.New("bucket name is required")
+)
+
+// Alias is a server and its credentials, as saved by 'mc alias set'.
+type Alias struct {
+	URL          string `json:"url"`
+	AccessKey    string `json:"accessKey"`
+	SecretKey    string `json:"secretKey"`
+	SessionToken string `json:"sessionToken,omitempty"`
+	// Signature version, "S3v4" by default or "S3v2".
+	API string `json:"api"`
+	// Bucket lookup, "auto" by default, "on" for path style or "off"
+	// for virtual host style.
+	Path string `json:"path"`
+	// Set to "keychain" when the secret key is kept in the OS keychain,
+	// LoadAliases reads it from there.
+	CredentialStore string `json:"credentialStore,omitempty"`
+	// Proxy URL, CA certificate and client certificate files.
+	Proxy      string `json:"proxy,omitempty"`
+	CACert     string `json:"caCert,omitempty"`
+	ClientCert string `json:"clientCert,omitempty"`
+	ClientKey  string `json:"clientKey,omitempty"`
+	// Set to "requester" to read requester pays buckets.
+	RequestPayer string `json:"requestPayer,omitempty"`
+}
+
+// Options configure a client.
+type Options struct {
+	// Aliases of the servers, e.g. loaded with LoadAliases.
+	Aliases map[string]Alias
+	// Folder of the CA certificates trusted in addition to the system
+	// ones, such as the certs/CAs folder of the mc configuration.
+	CAsDir string
+	// Transport of the requests, by default one with the proxy and the
+	// certificates of the alias. A transport given here is used as is.
+	Transport http.RoundTripper
+	// Application appended to the user agent of the requests.
+	AppName, AppVersion string
+}
+
+// Client runs operations on the servers of its aliases and on the
+// local filesystem. A client is safe for concurrent use.
+type Client struct {
+	opts Options
+
+	mu      sync.Mutex
+	servers map[string]*minio.Client
+}
+
+// New returns a client for the given aliases.
+func New(opts Options) (*Client, error) {
+	for name, alias := range opts.Aliases {
+		if _, e := parseAliasURL(alias.URL); e != nil {
+			return nil, fmt.Errorf("alias %s: %w", name, e)
+		}
+	}
+	return &Client{opts: opts, servers: make(map[string]*minio.Client)}, nil
+}
+
+// LoadOptions select the configuration read by LoadAliases.
+type LoadOptions struct {
+	// Profile of the configuration, as 'mc --profile', the default
+	// one if empty.
+	Profile string
+	// Passphrase of an encrypted configuration.
+	Passphrase string
+}
+
+// LoadAliases reads the aliases of a mc configuration folder, such as
+// ~/.mc, as mc does. Secret keys kept in the OS keychain are read from it.
+func LoadAliases(configDir string, opts LoadOptions) (map[string]Alias, error) {
+	if strings.ContainsAny(opts.Profile, `/\`) {
+		return nil, fmt.Errorf("invalid profile %q", opts.Profile)
+	}
+	data, e := ioutil.ReadFile(mcconfig.ProfilePath(configDir, opts.Profile))
+	if e != nil {
+		return nil, e
+	}
+	if mcconfig.IsEncrypted(data) {
+		if opts.Passphrase == "" {
+			return nil, ErrEncryptedConfig
+		}
+		if data, e = mcconfig.Decrypt(data, opts.Passphrase); e != nil {
+			return nil, e
+		}
+	}
+	var config struct {
+		Version string           `json:"version"`
+		Aliases map[string]Alias `json:"aliases"`
+	}
+	if e = json.Unmarshal(data, &config); e != nil {
+		return nil, e
+	}
+	if config.Version != "10" {
+		return nil, fmt.Errorf("unsupported configuration version %q, run mc once to migrate it", config.Version)
+	}
+	for name, alias := range config.Aliases {
+		if alias.CredentialStore != mcconfig.CredentialStoreKeychain || alias.SecretKey != "" {
+			continue
+		}
+		if alias.SecretKey, e = mcconfig.KeychainGet(mcconfig.KeychainAccount(opts.Profile, name)); e != nil {
+			return nil, fmt.Errorf("alias %s: %w", name, e)
+		}
+		config.Aliases[name] = alias
+	}
+	return config.Aliases, nil
+}
+
+func parseAliasURL(s string) (*url.URL, error) {
+	u, e := url.Parse(s)
+	if e != nil {
+		return nil, e
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q", s)
+	}
+	return u, nil
+}
+
+// location is a parsed target, on a server when alias is set or a
+// local path otherwise.
+type location struct {
+	alias  string
+	bucket string
+	key    string
+	path   string
+}
+
+func (l location) isLocal() bool {
+	return l.alias == ""
+}
+
+func (l location) String() string {
+	if l.isLocal() {
+		return l.path
+	}
+	s := l.alias
+	if l.bucket != "" {
+		s += "/" + l.bucket
+	}
+	if l.key != "" {
+		s += "/" + l.key
+	}
+	return s
+}
+
+// join returns the location of key relative to l.
+func (l location) join(key string) location {
+	if l.isLocal() {
+		l.path = filepath.Join(l.path, filepath.FromSlash(key))
+		return l
+	}
+	if l.key != "" && !strings.HasSuffix(l.key, "/") {
+		l.key += "/"
+	}
+	l.key += key
+	return l
+}
+
+// locate parses a target, its first element is an alias of the client
+// or the target is a local path.
+func (c *Client) locate(target string) location {
+	parts := strings.SplitN(filepath.ToSlash(target), "/", 3)
+	if _, ok := c.opts.Aliases[parts[0]]; !ok {
+		return location{path: target}
+	}
+	l := location{alias: parts[0]}
+	if len(parts) > 1 {
+		l.bucket = parts[1]
+	}
+	if len(parts) > 2 {
+		l.key = parts[2]
+	}
+	return l
+}
+
+// server returns the S3 client of an alias.
+func (c *Client) server(name string) (*minio.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.servers[name]; ok {
+		return s, nil
+	}
+	alias, ok := c.opts.Aliases[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrAliasNotFound)
+	}
+	u, e := parseAliasURL(alias.URL)
+	if e != nil {
+		return nil, e
+	}
+	transport, e := c.transport(alias, u)
+	if e != nil {
+		return nil, fmt.Errorf("alias %s: %w", name, e)
+	}
+	s, e := minio.New(u.Host, &minio.Options{
+		Creds:        mcconfig.StaticCredentials(alias.AccessKey, alias.SecretKey, alias.SessionToken, alias.API),
+		Secure:       u.Scheme == "https",
+		Transport:    mcconfig.RequestPayerTransport(transport, alias.RequestPayer, alias.AccessKey, alias.SecretKey, alias.SessionToken),
+		BucketLookup: mcconfig.BucketLookup(alias.Path),
+	})
+	if e != nil {
+		return nil, e
+	}
+	if c.opts.AppName != "" {
+		s.SetAppInfo(c.opts.AppName, c.opts.AppVersion)
+	}
+	c.servers[name] = s
+	return s, nil
+}
+
+// transport returns the transport of the requests to an alias, with
+// its proxy and certificates unless a transport is given in the options.
+func (c *Client) transport(alias Alias, u *url.URL) (http.RoundTripper, error) {
+	if c.opts.Transport != nil {
+		return c.opts.Transport, nil
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	proxy, e := mcconfig.ProxyFunc(alias.Proxy, http.ProxyFromEnvironment)
+	if e != nil {
+		return nil, e
+	}
+	tr.Proxy = proxy
+	if u.Scheme == "https" {
+		rootCAs, e := certs.GetRootCAs(c.opts.CAsDir)
+		if e != nil {
+			return nil, e
+		}
+		if tr.TLSClientConfig, e = mcconfig.TLSConfig(rootCAs, c.opts.CAsDir, false, alias.CACert, alias.ClientCert, alias.ClientKey); e != nil {
+			return nil, e
+		}
+	}
+	return tr, nil
+}
@@ -0,0 +1,184 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/mcconfig"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if e := os.MkdirAll(filepath.Dir(name), 0755); e != nil {
+			t.Fatal(e)
+		}
+		if e := ioutil.WriteFile(name, []byte(content), 0644); e != nil {
+			t.Fatal(e)
+		}
+	}
+}
+
+func listKeys(t *testing.T, c *Client, target string, opts ListOptions) []string {
+	var keys []string
+	e := c.List(context.Background(), target, opts, func(info ObjectInfo) error {
+		keys = append(keys, info.Key)
+		return nil
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	return keys
+}
+
+func TestLoadAliases(t *testing.T) {
+	dir, e := ioutil.TempDir("", "client")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{"config.json": `{"version": "10", "aliases": {"play": {"url": "https://play.min.io", "accessKey": "Q3AM3UQ867SPQQA43P2F", "api": "S3v4", "path": "auto"}}}`})
+	aliases, e := LoadAliases(dir, LoadOptions{})
+	if e != nil {
+		t.Fatal(e)
+	}
+	c, e := New(Options{Aliases: aliases})
+	if e != nil {
+		t.Fatal(e)
+	}
+	testCases := []struct {
+		target   string
+		expected location
+	}{
+		{"play", location{alias: "play"}},
+		{"play/mybucket/dir/a.txt", location{alias: "play", bucket: "mybucket", key: "dir/a.txt"}},
+		{"/tmp/play", location{path: "/tmp/play"}},
+		{"local/mybucket", location{path: "local/mybucket"}},
+	}
+	for i, testCase := range testCases {
+		if l := c.locate(testCase.target); l != testCase.expected {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, l)
+		}
+	}
+
+	plain := `{"version": "10", "aliases": {"work": {"url": "https://s3.example.com", "accessKey": "ACCESS", "secretKey": "SECRET", "api": "S3v4", "path": "off"}}}`
+	encrypted, e := mcconfig.Encrypt("10", []byte(plain), "passphrase")
+	if e != nil {
+		t.Fatal(e)
+	}
+	writeFiles(t, dir, map[string]string{"profiles/work.json": string(encrypted)})
+	if _, e = LoadAliases(dir, LoadOptions{Profile: "work"}); !errors.Is(e, ErrEncryptedConfig) {
+		t.Errorf("expected ErrEncryptedConfig, got %v", e)
+	}
+	if _, e = LoadAliases(dir, LoadOptions{Profile: "work", Passphrase: "wrong"}); e == nil {
+		t.Error("expected an error with a wrong passphrase")
+	}
+	aliases, e = LoadAliases(dir, LoadOptions{Profile: "work", Passphrase: "passphrase"})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if alias := aliases["work"]; alias.SecretKey != "SECRET" || alias.Path != "off" {
+		t.Errorf("unexpected alias %+v", alias)
+	}
+}
+
+func TestListLocal(t *testing.T) {
+	dir, e := ioutil.TempDir("", "client")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{"b.txt": "b", "a/2.txt": "2", "a/1.txt": "1", "a.txt": "a"})
+
+	c, _ := New(Options{})
+	if keys := listKeys(t, c, dir, ListOptions{Recursive: true}); !reflect.DeepEqual(keys, []string{"a.txt", "a/1.txt", "a/2.txt", "b.txt"}) {
+		t.Errorf("unexpected recursive listing %v", keys)
+	}
+	if keys := listKeys(t, c, dir, ListOptions{}); !reflect.DeepEqual(keys, []string{"a.txt", "a/", "b.txt"}) {
+		t.Errorf("unexpected listing %v", keys)
+	}
+	if keys := listKeys(t, c, filepath.Join(dir, "b.txt"), ListOptions{}); !reflect.DeepEqual(keys, []string{"b.txt"}) {
+		t.Errorf("unexpected file listing %v", keys)
+	}
+}
+
+func TestCopyAndMirrorLocal(t *testing.T) {
+	dir, e := ioutil.TempDir("", "client")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+	src, dst := filepath.Join(dir, "src"), filepath.Join(dir, "dst")
+	writeFiles(t, src, map[string]string{"a.txt": "hello", "dir/b.txt": "world", "c.txt": "new"})
+	writeFiles(t, dst, map[string]string{"c.txt": "old!!", "stale.txt": "x"})
+
+	c, _ := New(Options{})
+	ctx := context.Background()
+
+	var transferred int64
+	result, e := c.Copy(ctx, filepath.Join(src, "a.txt"), dir+string(filepath.Separator), CopyOptions{
+		Progress: func(p Progress) { transferred = p.Transferred },
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if result.Size != 5 || transferred != 5 || result.Target != filepath.Join(dir, "a.txt") {
+		t.Errorf("unexpected copy %+v, %d bytes transferred", result, transferred)
+	}
+	if _, e = c.Copy(ctx, filepath.Join(src, "missing.txt"), dir, CopyOptions{}); !os.IsNotExist(e) {
+		t.Errorf("expected a missing source, got %v", e)
+	}
+
+	// c.txt differs but is not newer in the source.
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(src, "c.txt"), old, old)
+	summary, e := c.Mirror(ctx, src, dst, MirrorOptions{Remove: true})
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := MirrorSummary{Copied: 2, Skipped: 1, Removed: 1, Bytes: 10}
+	if summary != expected {
+		t.Errorf("expected %+v, got %+v", expected, summary)
+	}
+	if keys := listKeys(t, c, dst, ListOptions{Recursive: true}); !reflect.DeepEqual(keys, []string{"a.txt", "c.txt", "dir/b.txt"}) {
+		t.Errorf("unexpected mirror %v", keys)
+	}
+
+	var results []string
+	summary, e = c.Mirror(ctx, src, dst, MirrorOptions{
+		Overwrite: true,
+		OnResult:  func(r MirrorResult) { results = append(results, string(r.Action)+" "+filepath.Base(r.Key)) },
+	})
+	if e != nil {
+		t.Fatal(e)
+	}
+	if summary.Overwritten != 1 || !reflect.DeepEqual(results, []string{"overwrite c.txt"}) {
+		t.Errorf("unexpected overwrite %+v %v", summary, results)
+	}
+	if data, _ := ioutil.ReadFile(filepath.Join(dst, "c.txt")); string(data) != "new" {
+		t.Errorf("expected c.txt to be overwritten, got %q", data)
+	}
+}
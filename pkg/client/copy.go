@@ -0,0 +1,251 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/minio-go/v7"
+)
+
+// Progress of a transfer.
+type Progress struct {
+	Source, Target     string
+	Transferred, Total int64
+}
+
+// ProgressFunc is called as a transfer progresses, from the goroutine
+// of the transfer.
+type ProgressFunc func(Progress)
+
+// CopyOptions configure a copy.
+type CopyOptions struct {
+	// Called as the object is transferred, if set.
+	Progress ProgressFunc
+	// Size of the parts of multipart uploads, chosen by minio-go if zero.
+	PartSize uint64
+	// Storage class, content type and metadata of an uploaded object,
+	// those of the source when copied between buckets of a server.
+	StorageClass string
+	ContentType  string
+	UserMetadata map[string]string
+}
+
+// CopyResult is an object copied.
+type CopyResult struct {
+	Source, Target string
+	Size           int64
+	// ETag and version of the object uploaded, empty for files.
+	ETag      string
+	VersionID string
+}
+
+// source is an object or a file to copy.
+type source struct {
+	location
+	size        int64
+	modTime     time.Time
+	contentType string
+}
+
+// Copy copies an object or a file to target. When target ends with a
+// slash, or is a local folder, the source keeps its base name in it.
+// Objects are copied server side between buckets of the same alias.
+func (c *Client) Copy(ctx context.Context, sourceTarget, target string, opts CopyOptions) (CopyResult, error) {
+	src, e := c.stat(ctx, c.locate(sourceTarget))
+	if e != nil {
+		return CopyResult{}, e
+	}
+	dst := c.locate(target)
+	if isFolder(dst, target) {
+		dst = dst.join(path.Base(filepath.ToSlash(src.String())))
+	}
+	return c.copy(ctx, src, dst, opts)
+}
+
+// isFolder returns true if the objects copied to l are put in it.
+func isFolder(l location, target string) bool {
+	if strings.HasSuffix(target, "/") || strings.HasSuffix(target, string(filepath.Separator)) {
+		return true
+	}
+	if l.isLocal() {
+		fi, e := os.Stat(l.path)
+		return e == nil && fi.IsDir()
+	}
+	return l.key == ""
+}
+
+// stat returns the size of a file or an object to copy.
+func (c *Client) stat(ctx context.Context, l location) (source, error) {
+	if l.isLocal() {
+		fi, e := os.Stat(l.path)
+		if e != nil {
+			return source{}, e
+		}
+		if !fi.Mode().IsRegular() {
+			return source{}, fmt.Errorf("%s is not a regular file", l.path)
+		}
+		return source{location: l, size: fi.Size(), modTime: fi.ModTime()}, nil
+	}
+	if l.bucket == "" || l.key == "" {
+		return source{}, fmt.Errorf("%s: %w", l, ErrNotFound)
+	}
+	s, e := c.server(l.alias)
+	if e != nil {
+		return source{}, e
+	}
+	info, e := s.StatObject(ctx, l.bucket, l.key, minio.StatObjectOptions{})
+	if e != nil {
+		if minio.ToErrorResponse(e).StatusCode == 404 {
+			return source{}, fmt.Errorf("%s: %w", l, ErrNotFound)
+		}
+		return source{}, e
+	}
+	return source{location: l, size: info.Size, modTime: info.LastModified, contentType: info.ContentType}, nil
+}
+
+// progressReader reports the bytes read through it, it is used as the
+// progress of the minio-go transfers.
+type progressReader struct {
+	fn       ProgressFunc
+	progress Progress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	r.progress.Transferred += int64(len(b))
+	r.fn(r.progress)
+	return len(b), nil
+}
+
+func (c *Client) copy(ctx context.Context, src source, dst location, opts CopyOptions) (CopyResult, error) {
+	result := CopyResult{Source: src.String(), Target: dst.String(), Size: src.size}
+	var progress *progressReader
+	if opts.Progress != nil {
+		progress = &progressReader{fn: opts.Progress, progress: Progress{Source: result.Source, Target: result.Target, Total: src.size}}
+	}
+
+	if !dst.isLocal() && dst.bucket == "" {
+		return result, ErrBucketRequired
+	}
+	if !src.isLocal() && !dst.isLocal() && src.alias == dst.alias {
+		s, e := c.server(dst.alias)
+		if e != nil {
+			return result, e
+		}
+		destOpts := minio.CopyDestOptions{
+			Bucket:          dst.bucket,
+			Object:          dst.key,
+			UserMetadata:    opts.UserMetadata,
+			ReplaceMetadata: len(opts.UserMetadata) > 0,
+			Size:            src.size,
+		}
+		if progress != nil {
+			destOpts.Progress = progress
+		}
+		info, e := s.ComposeObject(ctx, destOpts, minio.CopySrcOptions{Bucket: src.bucket, Object: src.key})
+		if e != nil {
+			return result, e
+		}
+		result.ETag, result.VersionID = info.ETag, info.VersionID
+		return result, nil
+	}
+
+	reader, e := c.open(ctx, src)
+	if e != nil {
+		return result, e
+	}
+	defer reader.Close()
+
+	if dst.isLocal() {
+		var r io.Reader = reader
+		if progress != nil {
+			r = hookreader.NewHook(reader, progress)
+		}
+		return result, writeFile(dst.path, r)
+	}
+
+	s, e := c.server(dst.alias)
+	if e != nil {
+		return result, e
+	}
+	putOpts := minio.PutObjectOptions{
+		PartSize:     opts.PartSize,
+		StorageClass: opts.StorageClass,
+		ContentType:  opts.ContentType,
+		UserMetadata: opts.UserMetadata,
+	}
+	if putOpts.ContentType == "" {
+		putOpts.ContentType = src.contentType
+	}
+	if progress != nil {
+		putOpts.Progress = progress
+	}
+	info, e := s.PutObject(ctx, dst.bucket, dst.key, reader, src.size, putOpts)
+	if e != nil {
+		return result, e
+	}
+	result.ETag, result.VersionID = info.ETag, info.VersionID
+	return result, nil
+}
+
+// open returns the content of a file or an object.
+func (c *Client) open(ctx context.Context, src source) (io.ReadCloser, error) {
+	if src.isLocal() {
+		return os.Open(src.path)
+	}
+	s, e := c.server(src.alias)
+	if e != nil {
+		return nil, e
+	}
+	return s.GetObject(ctx, src.bucket, src.key, minio.GetObjectOptions{})
+}
+
+// writeFile writes a file through a temporary file renamed once
+// complete, so that a failed copy does not leave a partial file.
+func writeFile(name string, r io.Reader) error {
+	dir := filepath.Dir(name)
+	if e := os.MkdirAll(dir, 0777); e != nil {
+		return e
+	}
+	f, e := ioutil.TempFile(dir, "."+filepath.Base(name)+".part.")
+	if e != nil {
+		return e
+	}
+	defer os.Remove(f.Name())
+	if _, e = io.Copy(f, r); e != nil {
+		f.Close()
+		return e
+	}
+	// Temporary files are only readable by their owner.
+	if e = f.Chmod(0644); e != nil {
+		f.Close()
+		return e
+	}
+	if e = f.Close(); e != nil {
+		return e
+	}
+	return os.Rename(f.Name(), name)
+}
@@ -0,0 +1,156 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/ioutils"
+	"github.com/minio/minio-go/v7"
+)
+
+// ObjectInfo is an object, a version of an object or a folder found by
+// List. Its key is relative to the target listed.
+type ObjectInfo struct {
+	Key            string
+	Size           int64
+	ETag           string
+	LastModified   time.Time
+	StorageClass   string
+	VersionID      string
+	IsLatest       bool
+	IsDeleteMarker bool
+	IsDir          bool
+}
+
+// ListOptions configure a listing.
+type ListOptions struct {
+	// List the objects of the folders as well, instead of the folders.
+	Recursive bool
+	// List all the versions of the objects, latest first.
+	Versions bool
+}
+
+// List calls fn for each object under target, in lexical order of
+// their keys, until fn returns an error. An alias without bucket lists
+// the buckets as folders. A target which is an object lists the object
+// alone, with its base name as key.
+func (c *Client) List(ctx context.Context, target string, opts ListOptions, fn func(ObjectInfo) error) error {
+	l := c.locate(target)
+	if l.isLocal() {
+		return listLocal(ctx, l.path, opts, fn)
+	}
+
+	s, e := c.server(l.alias)
+	if e != nil {
+		return e
+	}
+	if l.bucket == "" {
+		buckets, e := s.ListBuckets(ctx)
+		if e != nil {
+			return e
+		}
+		for _, bucket := range buckets {
+			if e = fn(ObjectInfo{Key: bucket.Name + "/", LastModified: bucket.CreationDate, IsDir: true}); e != nil {
+				return e
+			}
+		}
+		return nil
+	}
+
+	prefix := l.key
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		if info, e := s.StatObject(ctx, l.bucket, prefix, minio.StatObjectOptions{}); e == nil {
+			return fn(objectInfo(info, path.Base(prefix)))
+		}
+		prefix += "/"
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for info := range s.ListObjects(ctx, l.bucket, minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    opts.Recursive,
+		WithVersions: opts.Versions,
+	}) {
+		if info.Err != nil {
+			return info.Err
+		}
+		if e = fn(objectInfo(info, strings.TrimPrefix(info.Key, prefix))); e != nil {
+			return e
+		}
+	}
+	return ctx.Err()
+}
+
+func objectInfo(info minio.ObjectInfo, key string) ObjectInfo {
+	return ObjectInfo{
+		Key:            key,
+		Size:           info.Size,
+		ETag:           info.ETag,
+		LastModified:   info.LastModified,
+		StorageClass:   info.StorageClass,
+		VersionID:      info.VersionID,
+		IsLatest:       info.IsLatest,
+		IsDeleteMarker: info.IsDeleteMarker,
+		IsDir:          strings.HasSuffix(key, "/"),
+	}
+}
+
+// listLocal lists the regular files of a folder, or a file alone.
+func listLocal(ctx context.Context, root string, opts ListOptions, fn func(ObjectInfo) error) error {
+	fi, e := os.Stat(root)
+	if e != nil {
+		return e
+	}
+	if !fi.IsDir() {
+		return fn(ObjectInfo{Key: fi.Name(), Size: fi.Size(), LastModified: fi.ModTime()})
+	}
+	return ioutils.FTW(root, func(name string, info os.FileInfo, e error) error {
+		if e != nil {
+			return e
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if name == root {
+			return nil
+		}
+		rel, e := filepath.Rel(root, name)
+		if e != nil {
+			return e
+		}
+		key := filepath.ToSlash(rel)
+		switch {
+		case info.IsDir() && opts.Recursive:
+			return nil
+		case info.IsDir():
+			if e = fn(ObjectInfo{Key: key + "/", LastModified: info.ModTime(), IsDir: true}); e != nil {
+				return e
+			}
+			return ioutils.ErrSkipDir
+		case info.Mode().IsRegular():
+			return fn(ObjectInfo{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		}
+		return nil
+	})
+}
@@ -0,0 +1,210 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// Number of objects copied in parallel by default.
+const defaultMirrorWorkers = 4
+
+// MirrorAction is what was done to an object of a mirror.
+type MirrorAction string
+
+// Actions of a mirror.
+const (
+	MirrorCopied      MirrorAction = "copy"
+	MirrorOverwritten MirrorAction = "overwrite"
+	MirrorRemoved     MirrorAction = "remove"
+	// The object differs on the target and Overwrite is not set.
+	MirrorSkipped MirrorAction = "skip"
+)
+
+// MirrorOptions configure a mirror.
+type MirrorOptions struct {
+	// Overwrite the objects which differ in size, or are newer in the
+	// source, instead of skipping them.
+	Overwrite bool
+	// Remove the objects of the target which are not in the source.
+	Remove bool
+	// Report the actions without copying nor removing objects.
+	DryRun bool
+	// Number of objects copied in parallel, 4 by default.
+	Workers int
+	// Options of the copies, their progress is reported per object.
+	Copy CopyOptions
+	// Called for each object copied, overwritten, skipped or removed,
+	// from the goroutine of the transfer.
+	OnResult func(MirrorResult)
+}
+
+// MirrorResult is an object of a mirror, Err is set if its copy or
+// removal failed.
+type MirrorResult struct {
+	Key    string
+	Action MirrorAction
+	Size   int64
+	Err    error
+}
+
+// MirrorSummary counts the objects of a mirror.
+type MirrorSummary struct {
+	Copied, Overwritten, Removed, Skipped, Failed int
+	// Bytes copied.
+	Bytes int64
+}
+
+func (s *MirrorSummary) add(r MirrorResult) {
+	switch {
+	case r.Err != nil:
+		s.Failed++
+	case r.Action == MirrorCopied:
+		s.Copied++
+		s.Bytes += r.Size
+	case r.Action == MirrorOverwritten:
+		s.Overwritten++
+		s.Bytes += r.Size
+	case r.Action == MirrorRemoved:
+		s.Removed++
+	case r.Action == MirrorSkipped:
+		s.Skipped++
+	}
+}
+
+// Mirror synchronizes the objects of the target folder with those of
+// the source folder recursively, as 'mc mirror'. Objects missing in target are copied,
+// objects differing in size or newer in source are overwritten with
+// Overwrite. The error is that of the listings, objects which failed
+// are counted in the summary and reported to OnResult.
+func (c *Client) Mirror(ctx context.Context, sourceTarget, target string, opts MirrorOptions) (MirrorSummary, error) {
+	var summary MirrorSummary
+	src, dst := c.locate(sourceTarget), c.locate(target)
+
+	// Objects of the target not found in the source yet.
+	existing := make(map[string]ObjectInfo)
+	e := c.List(ctx, target, ListOptions{Recursive: true}, func(info ObjectInfo) error {
+		existing[info.Key] = info
+		return nil
+	})
+	if e != nil && !(dst.isLocal() && os.IsNotExist(e)) {
+		return summary, e
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultMirrorWorkers
+	}
+	var mu sync.Mutex
+	report := func(r MirrorResult) {
+		mu.Lock()
+		summary.add(r)
+		mu.Unlock()
+		if opts.OnResult != nil {
+			opts.OnResult(r)
+		}
+	}
+
+	type copyJob struct {
+		src    source
+		dst    location
+		action MirrorAction
+	}
+	jobCh := make(chan copyJob)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				r := MirrorResult{Key: job.dst.String(), Action: job.action, Size: job.src.size}
+				if !opts.DryRun {
+					_, r.Err = c.copy(ctx, job.src, job.dst, opts.Copy)
+				}
+				report(r)
+			}
+		}()
+	}
+
+	e = c.List(ctx, sourceTarget, ListOptions{Recursive: true}, func(info ObjectInfo) error {
+		if info.IsDir {
+			return nil
+		}
+		job := copyJob{
+			src:    source{location: src.join(info.Key), size: info.Size, modTime: info.LastModified},
+			dst:    dst.join(info.Key),
+			action: MirrorCopied,
+		}
+		if old, ok := existing[info.Key]; ok {
+			delete(existing, info.Key)
+			if old.Size == info.Size && !info.LastModified.After(old.LastModified) {
+				return nil
+			}
+			if !opts.Overwrite {
+				report(MirrorResult{Key: job.dst.String(), Action: MirrorSkipped, Size: info.Size})
+				return nil
+			}
+			job.action = MirrorOverwritten
+		}
+		select {
+		case jobCh <- job:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(jobCh)
+	wg.Wait()
+	if e != nil {
+		return summary, e
+	}
+
+	if opts.Remove {
+		keys := make([]string, 0, len(existing))
+		for key := range existing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			info := existing[key]
+			l := dst.join(key)
+			r := MirrorResult{Key: l.String(), Action: MirrorRemoved, Size: info.Size}
+			if !opts.DryRun {
+				r.Err = c.remove(ctx, l)
+			}
+			report(r)
+		}
+	}
+	return summary, ctx.Err()
+}
+
+// remove removes a file or an object.
+func (c *Client) remove(ctx context.Context, l location) error {
+	if l.isLocal() {
+		return os.Remove(l.path)
+	}
+	s, e := c.server(l.alias)
+	if e != nil {
+		return e
+	}
+	return s.RemoveObject(ctx, l.bucket, l.key, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,70 @@
+// +build darwin
+
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcconfig
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// The macOS Keychain is accessed with the 'security' tool
+// shipped with the OS.
+
+// KeychainSet stores the secret of an account in the keychain.
+func KeychainSet(account, secret string) error {
+	// The command is read from stdin by the interactive mode to keep the
+	// secret out of the process list, hex encoded to avoid any quoting.
+	// -U updates the entry if it already exists.
+	command := fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n",
+		keychainService, account, hex.EncodeToString([]byte(secret)))
+	_, e := runKeychainTool([]byte(command), "-i")
+	return e
+}
+
+// KeychainGet returns the secret of an account from the keychain.
+func KeychainGet(account string) (string, error) {
+	out, e := runKeychainTool(nil, "find-generic-password", "-s", keychainService, "-a", account, "-w")
+	return strings.TrimSuffix(out, "\n"), e
+}
+
+// KeychainRemove removes the secret of an account from the keychain.
+func KeychainRemove(account string) error {
+	_, e := runKeychainTool(nil, "delete-generic-password", "-s", keychainService, "-a", account)
+	return e
+}
+
+func runKeychainTool(stdin []byte, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("security", args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if e := cmd.Run(); e != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New("keychain: " + msg)
+		}
+		return "", e
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,72 @@
+// +build !darwin,!windows
+
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcconfig
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// The keychain is accessed through libsecret with its 'secret-tool'
+// client, which talks to GNOME Keyring, KWallet or any other
+// Secret Service provider.
+
+// KeychainSet stores the secret of an account in the keychain.
+func KeychainSet(account, secret string) error {
+	// The secret is read from stdin to keep it out of the process list.
+	_, e := runKeychainTool([]byte(secret), "store", "--label=mc alias "+account,
+		"service", keychainService, "alias", account)
+	return e
+}
+
+// KeychainGet returns the secret of an account from the keychain.
+func KeychainGet(account string) (string, error) {
+	out, e := runKeychainTool(nil, "lookup", "service", keychainService, "alias", account)
+	if e != nil {
+		return "", e
+	}
+	if out == "" {
+		return "", errors.New("keychain: no secret found for alias `" + account + "`")
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// KeychainRemove removes the secret of an account from the keychain.
+func KeychainRemove(account string) error {
+	_, e := runKeychainTool(nil, "clear", "service", keychainService, "alias", account)
+	return e
+}
+
+func runKeychainTool(stdin []byte, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("secret-tool", args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if e := cmd.Run(); e != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New("keychain: " + msg)
+		}
+		return "", e
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,119 @@
+// +build windows
+
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcconfig
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// Secrets are kept as generic credentials of the Windows
+// Credential Manager, named 'minio-mc:<alias>'.
+
+var (
+	modadvapi32    = syscall.NewLazyDLL("advapi32.dll")
+	procCredWrite  = modadvapi32.NewProc("CredWriteW")
+	procCredRead   = modadvapi32.NewProc("CredReadW")
+	procCredDelete = modadvapi32.NewProc("CredDeleteW")
+	procCredFree   = modadvapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+// winCredential mirrors the CREDENTIALW structure.
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+func keychainTarget(account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(keychainService + ":" + account)
+}
+
+// KeychainSet stores the secret of an account in the keychain.
+func KeychainSet(account, secret string) error {
+	target, e := keychainTarget(account)
+	if e != nil {
+		return e
+	}
+	userName, e := syscall.UTF16PtrFromString(account)
+	if e != nil {
+		return e
+	}
+	blob := []byte(secret)
+	cred := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	if r, _, e := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0); r == 0 {
+		return e
+	}
+	return nil
+}
+
+// KeychainGet returns the secret of an account from the keychain.
+func KeychainGet(account string) (string, error) {
+	target, e := keychainTarget(account)
+	if e != nil {
+		return "", e
+	}
+	var cred *winCredential
+	if r, _, e := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0,
+		uintptr(unsafe.Pointer(&cred))); r == 0 {
+		return "", e
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	blob := make([]byte, cred.CredentialBlobSize)
+	if cred.CredentialBlobSize > 0 {
+		copy(blob, (*[1 << 20]byte)(unsafe.Pointer(cred.CredentialBlob))[:cred.CredentialBlobSize:cred.CredentialBlobSize])
+	}
+	return string(blob), nil
+}
+
+// KeychainRemove removes the secret of an account from the keychain.
+func KeychainRemove(account string) error {
+	target, e := keychainTarget(account)
+	if e != nil {
+		return e
+	}
+	if r, _, e := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0); r == 0 {
+		return e
+	}
+	return nil
+}
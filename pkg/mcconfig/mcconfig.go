@@ -0,0 +1,188 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mcconfig resolves the aliases of the mc configuration, the
+// file of a profile, encrypted files, secret keys kept in the OS
+// keychain and the connection settings of an alias. It is shared by the
+// mc commands and the client package so that both reach the servers of
+// an alias the same way.
+package mcconfig
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/pkg/certs"
+)
+
+const (
+	// ConfigFile is the configuration file of the default profile.
+	ConfigFile = "config.json"
+	// ProfilesDir holds the configuration files of the other profiles.
+	ProfilesDir = "profiles"
+	// DefaultProfile names the profile of ConfigFile.
+	DefaultProfile = "default"
+
+	// CredentialStoreKeychain is set on aliases whose secret key is kept
+	// in the OS keychain instead of the config file.
+	CredentialStoreKeychain = "keychain"
+)
+
+// keychainService is the service name of mc entries in the OS keychain,
+// entries are keyed by alias.
+const keychainService = "minio-mc"
+
+// ProfilePath returns the configuration file of a profile in configDir,
+// the default profile when empty.
+func ProfilePath(configDir, profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return filepath.Join(configDir, ConfigFile)
+	}
+	return filepath.Join(configDir, ProfilesDir, profile+".json")
+}
+
+// KeychainAccount returns the keychain account of an alias, aliases
+// of configuration profiles other than the default are kept apart.
+func KeychainAccount(profile, alias string) string {
+	if profile == "" || profile == DefaultProfile {
+		return alias
+	}
+	return profile + "/" + alias
+}
+
+// encryptedConfig is the layout of an encrypted config file, the
+// version is kept in clear so that config migration leaves it alone.
+type encryptedConfig struct {
+	Version   string `json:"version"`
+	Encrypted []byte `json:"encrypted"`
+}
+
+// IsEncrypted returns true if data is an encrypted config file.
+func IsEncrypted(data []byte) bool {
+	var enc encryptedConfig
+	return json.Unmarshal(data, &enc) == nil && len(enc.Encrypted) > 0
+}
+
+// Decrypt returns the plain text config of an encrypted config file.
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	var enc encryptedConfig
+	if e := json.Unmarshal(data, &enc); e != nil {
+		return nil, e
+	}
+	plain, e := madmin.DecryptData(passphrase, bytes.NewReader(enc.Encrypted))
+	if e != nil {
+		return nil, fmt.Errorf("unable to decrypt, wrong passphrase? %v", e)
+	}
+	return plain, nil
+}
+
+// Encrypt returns the encrypted config file of a plain text config.
+func Encrypt(version string, plain []byte, passphrase string) ([]byte, error) {
+	encrypted, e := madmin.EncryptData(passphrase, plain)
+	if e != nil {
+		return nil, e
+	}
+	return json.MarshalIndent(encryptedConfig{Version: version, Encrypted: encrypted}, "", "\t")
+}
+
+// BucketLookup returns the bucket lookup of the path setting of an
+// alias, "on" for path style, "off" for virtual host style or "auto".
+func BucketLookup(path string) minio.BucketLookupType {
+	switch strings.ToLower(path) {
+	case "off":
+		return minio.BucketLookupDNS
+	case "on":
+		return minio.BucketLookupPath
+	}
+	return minio.BucketLookupAuto
+}
+
+// StaticCredentials returns the credentials of an alias signed with
+// its API, "S3v4" or "S3v2".
+func StaticCredentials(accessKey, secretKey, sessionToken, api string) *credentials.Credentials {
+	if strings.EqualFold(api, "S3v2") {
+		return credentials.NewStaticV2(accessKey, secretKey, "")
+	}
+	return credentials.NewStaticV4(accessKey, secretKey, sessionToken)
+}
+
+// TLSConfig returns the TLS settings of an alias. The CA certificate
+// and the client certificate of the alias, if any, are added to rootCAs
+// or, with a CA certificate, to the system roots and those of casDir.
+func TLSConfig(rootCAs *x509.CertPool, casDir string, insecure bool, caCert, clientCert, clientKey string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		RootCAs: rootCAs,
+		// Can't use SSLv3 because of POODLE and BEAST
+		// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
+		// Can't use TLSv1.1 because of RC4 cipher usage
+		MinVersion: tls.VersionTLS12,
+	}
+	if insecure {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if caCert != "" {
+		// Start from a fresh pool, rootCAs is shared by all aliases.
+		pool, e := certs.GetRootCAs(casDir)
+		if e != nil {
+			return nil, e
+		}
+		pemBytes, e := ioutil.ReadFile(caCert)
+		if e != nil {
+			return nil, e
+		}
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("no PEM certificate found in `%s`", caCert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert != "" || clientKey != "" {
+		cert, e := tls.LoadX509KeyPair(clientCert, clientKey)
+		if e != nil {
+			return nil, e
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// ProxyFunc returns the proxy of an alias, the proxy URL of the alias
+// takes precedence over defaultProxy.
+func ProxyFunc(proxy string, defaultProxy func(*http.Request) (*url.URL, error)) (func(*http.Request) (*url.URL, error), error) {
+	if proxy == "" {
+		return defaultProxy, nil
+	}
+	proxyURL, e := url.Parse(proxy)
+	if e != nil {
+		return nil, e
+	}
+	return http.ProxyURL(proxyURL), nil
+}
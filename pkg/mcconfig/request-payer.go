@@ -0,0 +1,69 @@
+/*
+ * MinIO Client (C) 2021 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mcconfig
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+// Region of the credential scope of a request signed with v4.
+var credentialRegionRegexp = regexp.MustCompile(`Credential=[^/]*/[^/]*/([^/]*)/`)
+
+// requestPayerTransport sets the 'x-amz-request-payer' header of the
+// requests reading buckets and objects. minio-go cannot set it on all
+// the requests, they are signed again with the header.
+type requestPayerTransport struct {
+	transport                          http.RoundTripper
+	payer                              string
+	accessKey, secretKey, sessionToken string
+}
+
+// RequestPayerTransport sets the request payer of an alias, if any, on
+// the requests of transport, signed again with the credentials of the
+// alias.
+func RequestPayerTransport(transport http.RoundTripper, payer, accessKey, secretKey, sessionToken string) http.RoundTripper {
+	if payer == "" {
+		return transport
+	}
+	return &requestPayerTransport{
+		transport:    transport,
+		payer:        payer,
+		accessKey:    accessKey,
+		secretKey:    secretKey,
+		sessionToken: sessionToken,
+	}
+}
+
+func (t *requestPayerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.transport.RoundTrip(req)
+	}
+	// Only requests signed with v4 in headers are signed again, the
+	// header is otherwise sent as is.
+	auth := req.Header.Get("Authorization")
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Amz-Request-Payer", t.payer)
+	if m := credentialRegionRegexp.FindStringSubmatch(auth); m != nil && strings.HasPrefix(auth, "AWS4-HMAC-SHA256") {
+		req.Header.Del("Authorization")
+		req = signer.SignV4(*req, t.accessKey, t.secretKey, t.sessionToken, m[1])
+	}
+	return t.transport.RoundTrip(req)
+}